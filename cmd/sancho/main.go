@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/startup"
 	"github.com/javiermolinar/sancho/internal/ui"
 )
 
@@ -21,6 +22,7 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+	startup.Mark("config")
 
 	app := ui.NewApp(nil, cfg)
 	defer func() { _ = app.Close() }()
@@ -38,7 +38,7 @@ func mustParseDate(t *testing.T, s string) time.Time {
 func createTask(t *testing.T, repo *db.SQLite, desc, category, date, start, end string) *task.Task {
 	t.Helper()
 	ctx := context.Background()
-	tsk, err := task.New(desc, category, date, start, end)
+	tsk, err := task.New(desc, category, date, start, end, time.Now())
 	if err != nil {
 		t.Fatalf("failed to create task: %v", err)
 	}
@@ -52,7 +52,7 @@ func TestCreateTask(t *testing.T) {
 	repo := openRepo(t)
 	ctx := context.Background()
 
-	tsk, err := task.New("Integration test task", "deep", "2025-01-20", "08:00", "09:00")
+	tsk, err := task.New("Integration test task", "deep", "2025-01-20", "08:00", "09:00", time.Now())
 	if err != nil {
 		t.Fatalf("failed to create task: %v", err)
 	}
@@ -94,7 +94,7 @@ func TestCreateTask_WithCategory(t *testing.T) {
 	repo := openRepo(t)
 	ctx := context.Background()
 
-	tsk, err := task.New("Shallow work task", "shallow", "2025-01-20", "10:00", "10:30")
+	tsk, err := task.New("Shallow work task", "shallow", "2025-01-20", "10:00", "10:30", time.Now())
 	if err != nil {
 		t.Fatalf("failed to create task: %v", err)
 	}
@@ -174,7 +174,7 @@ func TestNewTask_ValidationErrors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := task.New(tt.desc, tt.cat, tt.date, tt.start, tt.end)
+			_, err := task.New(tt.desc, tt.cat, tt.date, tt.start, tt.end, time.Now())
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("got error %v, want %v", err, tt.wantErr)
 			}
@@ -201,7 +201,7 @@ func TestCancelTask(t *testing.T) {
 
 	tsk := createTask(t, repo, "Task to cancel", "deep", "2025-01-21", "11:00", "12:00")
 
-	if err := repo.CancelTask(ctx, tsk.ID); err != nil {
+	if err := repo.CancelTask(ctx, tsk.ID, 0); err != nil {
 		t.Fatalf("failed to cancel task: %v", err)
 	}
 
@@ -221,7 +221,7 @@ func TestCancelTask_NotFound(t *testing.T) {
 	repo := openRepo(t)
 	ctx := context.Background()
 
-	err := repo.CancelTask(ctx, 99999)
+	err := repo.CancelTask(ctx, 99999, 0)
 	if err == nil {
 		t.Fatal("expected error for non-existent task")
 	}
@@ -237,7 +237,7 @@ func TestSetTaskOutcome(t *testing.T) {
 
 	tsk := createTask(t, repo, "Task with outcome", "deep", "2025-01-21", "13:00", "14:00")
 
-	if err := repo.SetTaskOutcome(ctx, tsk.ID, task.OutcomeOnTime); err != nil {
+	if err := repo.SetTaskOutcome(ctx, tsk.ID, task.OutcomeOnTime, 0); err != nil {
 		t.Fatalf("failed to set outcome: %v", err)
 	}
 
@@ -270,7 +270,7 @@ func TestSetTaskOutcome_AllValues(t *testing.T) {
 
 			tsk := createTask(t, repo, "Task for "+string(outcome), "deep", "2025-01-21", "09:00", "10:00")
 
-			if err := repo.SetTaskOutcome(ctx, tsk.ID, outcome); err != nil {
+			if err := repo.SetTaskOutcome(ctx, tsk.ID, outcome, 0); err != nil {
 				t.Fatalf("failed to set outcome: %v", err)
 			}
 
@@ -307,7 +307,7 @@ func TestSetTaskOutcome_NotFound(t *testing.T) {
 	repo := openRepo(t)
 	ctx := context.Background()
 
-	err := repo.SetTaskOutcome(ctx, 99999, task.OutcomeOnTime)
+	err := repo.SetTaskOutcome(ctx, 99999, task.OutcomeOnTime, 0)
 	if err == nil {
 		t.Fatal("expected error for non-existent task")
 	}
@@ -409,7 +409,7 @@ func TestPostponeTask(t *testing.T) {
 
 	// Postpone to new date/time
 	newDate := mustParseDate(t, "2025-04-02")
-	newTask, err := repo.PostponeTask(ctx, original.ID, newDate, "14:00", "15:00")
+	newTask, err := repo.PostponeTask(ctx, original.ID, newDate, "14:00", "15:00", 0)
 	if err != nil {
 		t.Fatalf("failed to postpone task: %v", err)
 	}
@@ -471,7 +471,7 @@ func TestPostponeTask_NotFound(t *testing.T) {
 	ctx := context.Background()
 
 	newDate := mustParseDate(t, "2025-04-10")
-	_, err := repo.PostponeTask(ctx, 99999, newDate, "14:00", "15:00")
+	_, err := repo.PostponeTask(ctx, 99999, newDate, "14:00", "15:00", 0)
 	if err == nil {
 		t.Fatal("expected error for non-existent task")
 	}
@@ -487,7 +487,7 @@ func TestCreateTasks_Batch(t *testing.T) {
 	tasks := []*task.Task{}
 	for i, desc := range []string{"Batch task 1", "Batch task 2", "Batch task 3"} {
 		start, end := getTimeSlot(i)
-		tsk, err := task.New(desc, "deep", "2025-05-10", start, end)
+		tsk, err := task.New(desc, "deep", "2025-05-10", start, end, time.Now())
 		if err != nil {
 			t.Fatalf("failed to create task: %v", err)
 		}
@@ -524,7 +524,7 @@ func TestTimeBlockOverlap(t *testing.T) {
 	createTask(t, repo, "First task", "deep", "2025-06-01", "09:00", "10:00")
 
 	// Try to create overlapping task
-	overlapping, err := task.New("Overlapping task", "deep", "2025-06-01", "09:30", "10:30")
+	overlapping, err := task.New("Overlapping task", "deep", "2025-06-01", "09:30", "10:30", time.Now())
 	if err != nil {
 		t.Fatalf("failed to create task: %v", err)
 	}
@@ -543,8 +543,8 @@ func TestTimeBlockOverlap_Batch(t *testing.T) {
 	ctx := context.Background()
 
 	// Try to create batch with overlapping tasks
-	task1, _ := task.New("Batch overlap 1", "deep", "2025-06-02", "09:00", "10:00")
-	task2, _ := task.New("Batch overlap 2", "deep", "2025-06-02", "09:30", "10:30")
+	task1, _ := task.New("Batch overlap 1", "deep", "2025-06-02", "09:00", "10:00", time.Now())
+	task2, _ := task.New("Batch overlap 2", "deep", "2025-06-02", "09:30", "10:30", time.Now())
 
 	err := repo.CreateTasks(ctx, []*task.Task{task1, task2})
 	if err == nil {
@@ -576,7 +576,7 @@ func TestFullWorkflow(t *testing.T) {
 	}
 
 	// 3. Set outcome on completed task
-	if err := repo.SetTaskOutcome(ctx, task1.ID, task.OutcomeOnTime); err != nil {
+	if err := repo.SetTaskOutcome(ctx, task1.ID, task.OutcomeOnTime, 0); err != nil {
 		t.Fatalf("failed to set outcome: %v", err)
 	}
 	got1, _ := repo.GetTask(ctx, task1.ID)
@@ -586,7 +586,7 @@ func TestFullWorkflow(t *testing.T) {
 
 	// 4. Postpone a task to next day
 	nextDay := mustParseDate(t, "2025-05-02")
-	_, err = repo.PostponeTask(ctx, task2.ID, nextDay, "10:00", "11:00")
+	_, err = repo.PostponeTask(ctx, task2.ID, nextDay, "10:00", "11:00", 0)
 	if err != nil {
 		t.Fatalf("failed to postpone task: %v", err)
 	}
@@ -612,7 +612,7 @@ func TestFullWorkflow(t *testing.T) {
 	}
 
 	// 5. Cancel a task
-	if err := repo.CancelTask(ctx, task3.ID); err != nil {
+	if err := repo.CancelTask(ctx, task3.ID, 0); err != nil {
 		t.Fatalf("failed to cancel task: %v", err)
 	}
 	got3, _ := repo.GetTask(ctx, task3.ID)
@@ -0,0 +1,76 @@
+// Package autosave keeps the unsaved changes of a TUI edit session in a
+// file, so that a crashed terminal or dropped connection does not lose a
+// long rearrangement.
+package autosave
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Change is one task an edit session moved or resized.
+type Change struct {
+	ID          int64     `json:"id"`
+	Description string    `json:"description"`
+	OldDate     time.Time `json:"old_date"`
+	OldStart    string    `json:"old_start"`
+	OldEnd      string    `json:"old_end"`
+	Date        time.Time `json:"date"`
+	Start       string    `json:"start"`
+	End         string    `json:"end"`
+}
+
+// Session is an edit session as it was last written.
+type Session struct {
+	WeekStart time.Time `json:"week_start"` // Week shown when it was written
+	SavedAt   time.Time `json:"saved_at"`
+	Changes   []Change  `json:"changes"`
+}
+
+// Write replaces the session stored at path. The file is written next to
+// it first and renamed, so a crash mid-write keeps the previous session.
+func Write(path string, s *Session) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing session: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing session: %w", err)
+	}
+	return nil
+}
+
+// Read returns the session stored at path, or nil when there is none.
+func Read(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("decoding session %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Remove deletes the session stored at path, if any.
+func Remove(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing session: %w", err)
+	}
+	return nil
+}
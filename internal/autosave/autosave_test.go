@@ -0,0 +1,50 @@
+package autosave
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteReadRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "edit-session.json")
+
+	if s, err := Read(path); err != nil || s != nil {
+		t.Fatalf("Read(missing) = %v, %v; want nil, nil", s, err)
+	}
+
+	day := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+	want := &Session{
+		WeekStart: day.AddDate(0, 0, -1),
+		SavedAt:   day.Add(10 * time.Hour),
+		Changes: []Change{{
+			ID: 4, Description: "Write report",
+			OldDate: day, OldStart: "09:00", OldEnd: "10:00",
+			Date: day.AddDate(0, 0, 1), Start: "14:00", End: "15:30",
+		}},
+	}
+	if err := Write(path, want); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !got.WeekStart.Equal(want.WeekStart) || len(got.Changes) != 1 {
+		t.Fatalf("Read = %+v, want %+v", got, want)
+	}
+	if c := got.Changes[0]; c.ID != 4 || !c.Date.Equal(want.Changes[0].Date) || c.Start != "14:00" || c.OldEnd != "10:00" {
+		t.Errorf("change = %+v, want %+v", c, want.Changes[0])
+	}
+
+	if err := Remove(path); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := Remove(path); err != nil {
+		t.Errorf("Remove(missing): %v", err)
+	}
+	if s, _ := Read(path); s != nil {
+		t.Errorf("Read after Remove = %+v, want nil", s)
+	}
+}
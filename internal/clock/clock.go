@@ -0,0 +1,78 @@
+// Package clock provides an injectable source of the current time so the
+// TUI, planner, and database agree on "now" and can be time-travelled in
+// tests or with the --fake-now debug flag.
+package clock
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Clock reports the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Func adapts a plain function to a Clock.
+type Func func() time.Time
+
+// Now calls f.
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// System is the real wall clock.
+var System Clock = Func(time.Now)
+
+// Fixed returns a clock that is frozen at t.
+func Fixed(t time.Time) Clock {
+	return Func(func() time.Time { return t })
+}
+
+// Offset returns a clock that starts at start and keeps ticking in real time.
+// Used for --fake-now so the UI still advances while testing another day.
+func Offset(start time.Time) Clock {
+	delta := time.Until(start)
+	return Func(func() time.Time { return time.Now().Add(delta) })
+}
+
+// OrSystem returns c, or System when c is nil.
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+	return c
+}
+
+var parseLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Parse parses a --fake-now value. It accepts RFC 3339 timestamps and local
+// "YYYY-MM-DD[ HH:MM]" values; a date without a time means 09:00.
+func Parse(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range parseLayouts {
+		var (
+			t   time.Time
+			err error
+		)
+		if layout == time.RFC3339 {
+			t, err = time.Parse(layout, s)
+		} else {
+			t, err = time.ParseInLocation(layout, s, time.Local)
+		}
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 9, 0, 0, 0, time.Local)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use YYYY-MM-DD, YYYY-MM-DD HH:MM or RFC 3339)", s)
+}
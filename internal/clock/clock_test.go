@@ -0,0 +1,68 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2025-03-10", time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local)},
+		{"2025-03-10 14:30", time.Date(2025, 3, 10, 14, 30, 0, 0, time.Local)},
+		{"2025-03-10T14:30", time.Date(2025, 3, 10, 14, 30, 0, 0, time.Local)},
+		{" 2025-03-10T14:30:00Z ", time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "tomorrow", "2025-13-01", "10:00"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) expected error", input)
+		}
+	}
+}
+
+func TestFixed(t *testing.T) {
+	at := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	c := Fixed(at)
+	if got := c.Now(); !got.Equal(at) {
+		t.Errorf("Now() = %v, want %v", got, at)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	start := time.Date(2020, 2, 29, 8, 0, 0, 0, time.UTC)
+	c := Offset(start)
+
+	first := c.Now()
+	if d := first.Sub(start); d < 0 || d > time.Minute {
+		t.Fatalf("Now() = %v, want close to %v", first, start)
+	}
+	if second := c.Now(); second.Before(first) {
+		t.Errorf("clock went backwards: %v then %v", first, second)
+	}
+}
+
+func TestOrSystem(t *testing.T) {
+	if got := OrSystem(nil).Now(); time.Since(got) > time.Minute {
+		t.Errorf("OrSystem(nil).Now() = %v, want the wall clock", got)
+	}
+	at := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	if got := OrSystem(Fixed(at)).Now(); !got.Equal(at) {
+		t.Errorf("OrSystem(Fixed).Now() = %v, want %v", got, at)
+	}
+}
@@ -6,9 +6,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
+	"github.com/rivo/uniseg"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
 )
 
 // Config holds the application configuration.
@@ -17,11 +23,254 @@ type Config struct {
 	LLM      LLMConfig      `toml:"llm"`
 	Storage  StorageConfig  `toml:"storage"`
 	UI       UIConfig       `toml:"ui"`
+	Goals    GoalsConfig    `toml:"goals"`
+	Energy   EnergyConfig   `toml:"energy"`
+	Sync     SyncConfig     `toml:"sync"`
+	Privacy  PrivacyConfig  `toml:"privacy"`
+	Export   ExportConfig   `toml:"export"`
+	Import   ImportConfig   `toml:"import"`
+
+	Categories []CategoryConfig `toml:"categories"` // Work categories beyond deep and shallow
+}
+
+// CategoryConfig defines a work category. Naming it deep or shallow changes
+// the code, color or limit of a built-in category instead of adding one.
+type CategoryConfig struct {
+	Name             string  `toml:"name"`               // e.g. "meeting"
+	Code             string  `toml:"code"`               // Letter or digit shown in the grid, e.g. "M"
+	Color            string  `toml:"color"`              // Hex color such as "#f5a97f"; empty uses the theme
+	Icon             string  `toml:"icon"`               // Emoji or Nerd Font glyph shown before task titles, e.g. "📞"
+	WeeklyLimitHours float64 `toml:"weekly_limit_hours"` // Warn when a week holds more; 0 = no limit
+}
+
+// WeeklyLimitMinutes returns the weekly limit in minutes, 0 for none.
+func (c CategoryConfig) WeeklyLimitMinutes() int {
+	return int(c.WeeklyLimitHours * 60)
+}
+
+// Accepted category codes and colors.
+var (
+	categoryCode = regexp.MustCompile(`^[0-9A-Za-z]$`)
+	hexColor     = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// ExportConfig holds the destinations of schedule exports.
+type ExportConfig struct {
+	// ObsidianDailyNote is the path of the Obsidian daily note, with {date}
+	// (YYYY-MM-DD), {year}, {month} and {day} replaced by the exported day,
+	// e.g. "~/vault/Daily/{date}.md".
+	ObsidianDailyNote string `toml:"obsidian_daily_note"`
+}
+
+// ImportConfig configures the trackers work is imported from into the
+// backlog.
+type ImportConfig struct {
+	GitHub GitHubImport `toml:"github"`
+	Jira   JiraImport   `toml:"jira"`
+}
+
+// GitHubImport configures "/import github owner/repo".
+type GitHubImport struct {
+	User     string `toml:"user"`      // Login whose assigned issues are imported; empty asks GitHub for the token's owner
+	TokenEnv string `toml:"token_env"` // Environment variable holding an access token (default GITHUB_TOKEN)
+}
+
+// defaultGitHubTokenEnv is read when token_env is unset.
+const defaultGitHubTokenEnv = "GITHUB_TOKEN"
+
+// Token returns the GitHub access token from the configured environment
+// variable, or "" when it is not set.
+func (g GitHubImport) Token() string {
+	env := g.TokenEnv
+	if env == "" {
+		env = defaultGitHubTokenEnv
+	}
+	return os.Getenv(env)
+}
+
+// JiraImport configures "/import jira".
+type JiraImport struct {
+	URL      string `toml:"url"`       // Site URL, e.g. "https://acme.atlassian.net"
+	JQL      string `toml:"jql"`       // Query selecting the issues to import
+	Email    string `toml:"email"`     // Jira Cloud account; empty sends the token as a bearer token
+	TokenEnv string `toml:"token_env"` // Environment variable holding the token (default JIRA_TOKEN)
+}
+
+// defaultJiraTokenEnv is read when token_env is unset.
+const defaultJiraTokenEnv = "JIRA_TOKEN"
+
+// Token returns the Jira token from the configured environment variable, or
+// "" when it is not set.
+func (j JiraImport) Token() string {
+	env := j.TokenEnv
+	if env == "" {
+		env = defaultJiraTokenEnv
+	}
+	return os.Getenv(env)
+}
+
+// PrivacyConfig holds settings that limit what leaves the machine.
+type PrivacyConfig struct {
+	// LocalOnly blocks every network request to another host: cloud LLM
+	// providers and remote sync feeds. Local providers on localhost still work.
+	LocalOnly bool `toml:"local_only"`
+}
+
+// SyncConfig configures the sources that are synced in the background while
+// the TUI is open.
+type SyncConfig struct {
+	IntervalMinutes int       `toml:"interval_minutes"` // Time between runs per source (0 = 15)
+	ICS             []ICSFeed `toml:"ics"`              // Calendar feeds imported as tasks
+	Replica         Replica   `toml:"replica"`          // Remote copy of the whole database
+}
+
+// Replica is a remote copy of the database, shared by every machine that
+// syncs to it. Changes are merged row by row using their timestamps.
+type Replica struct {
+	Kind        string `toml:"kind"`         // "webdav" or "git"; empty turns replication off
+	URL         string `toml:"url"`          // webdav: URL of the database file
+	Username    string `toml:"username"`     // webdav: basic auth user
+	PasswordEnv string `toml:"password_env"` // webdav: environment variable holding the password
+	Dir         string `toml:"dir"`          // git: working copy with an upstream to push to
+	File        string `toml:"file"`         // git: database path inside dir (default "sancho.db")
+}
+
+// Enabled returns true if a replica is configured.
+func (r Replica) Enabled() bool {
+	return r.Kind != ""
+}
+
+// ICSFeed is an iCalendar feed whose events are imported as tasks.
+type ICSFeed struct {
+	Name     string `toml:"name"`     // Shown in sync status, e.g. "work"
+	URL      string `toml:"url"`      // http(s) URL or local file path
+	Category string `toml:"category"` // "deep", "shallow" or a configured category (default shallow)
+}
+
+// defaultSyncIntervalMinutes is used when interval_minutes is unset.
+const defaultSyncIntervalMinutes = 15
+
+// Interval returns the time between sync runs of each source.
+func (s SyncConfig) Interval() time.Duration {
+	if s.IntervalMinutes <= 0 {
+		return defaultSyncIntervalMinutes * time.Minute
+	}
+	return time.Duration(s.IntervalMinutes) * time.Minute
+}
+
+// HasSources returns true if any sync source is configured.
+func (s SyncConfig) HasSources() bool {
+	return len(s.ICS) > 0
+}
+
+// EnergyConfig describes the daily energy profile as lists of "HH:MM-HH:MM"
+// windows per level. Times outside every window have no level.
+type EnergyConfig struct {
+	High   []string `toml:"high"`   // e.g., ["09:00-12:00"]
+	Medium []string `toml:"medium"` // e.g., ["12:00-13:00", "15:00-17:00"]
+	Low    []string `toml:"low"`    // e.g., ["13:00-15:00"]
+}
+
+// LevelAt returns "high", "medium" or "low" for a minute of the day, or ""
+// if no window covers it. Earlier levels win where windows overlap.
+func (e EnergyConfig) LevelAt(minute int) string {
+	levels := []struct {
+		name    string
+		windows []string
+	}{
+		{"high", e.High},
+		{"medium", e.Medium},
+		{"low", e.Low},
+	}
+	for _, level := range levels {
+		for _, w := range level.windows {
+			start, end, ok := parseWindow(w)
+			if ok && minute >= start && minute < end {
+				return level.name
+			}
+		}
+	}
+	return ""
+}
+
+// HasProfile returns true if any energy window is configured.
+func (e EnergyConfig) HasProfile() bool {
+	return len(e.High) > 0 || len(e.Medium) > 0 || len(e.Low) > 0
+}
+
+// parseWindow parses "HH:MM-HH:MM" into minutes of the day.
+func parseWindow(w string) (start, end int, ok bool) {
+	from, to, found := strings.Cut(strings.TrimSpace(w), "-")
+	if !found || validateTime(from, "") != nil || validateTime(to, "") != nil {
+		return 0, 0, false
+	}
+	start = minutesOf(from)
+	end = minutesOf(to)
+	return start, end, start < end
+}
+
+func minutesOf(hhmm string) int {
+	h, _ := strconv.Atoi(hhmm[0:2])
+	m, _ := strconv.Atoi(hhmm[3:5])
+	return h*60 + m
+}
+
+// GoalsConfig holds weekly targets. Zero disables a target.
+type GoalsConfig struct {
+	DeepHours  float64 `toml:"deep_hours"`  // e.g., 15
+	TotalHours float64 `toml:"total_hours"` // e.g., 30
+}
+
+// DeepMinutes returns the weekly deep work target in minutes.
+func (g GoalsConfig) DeepMinutes() int {
+	return int(g.DeepHours * 60)
+}
+
+// TotalMinutes returns the weekly total work target in minutes.
+func (g GoalsConfig) TotalMinutes() int {
+	return int(g.TotalHours * 60)
 }
 
 // UIConfig holds TUI settings.
 type UIConfig struct {
-	Theme string `toml:"theme"` // "mocha", "macchiato", "frappe", "latte"
+	Theme       string `toml:"theme"`        // "mocha", "macchiato", "frappe", "latte"
+	SlotMinutes int    `toml:"slot_minutes"` // Minutes per grid row: 15, 30 or 60 (0 = 15)
+	WindowWeeks int    `toml:"window_weeks"` // Weeks kept loaded around the visible one: odd, 3-13 (0 = 3)
+	WeekStart   string `toml:"week_start"`   // "monday" or "sunday" (empty = monday)
+	Locale      string `toml:"locale"`       // Day and month names in the grid: "en", "es", "fr", ... (empty = en)
+	Weekends    string `toml:"weekends"`     // Empty days off: "show", "narrow" or "hide" (empty = show)
+
+	CollapseOffHours bool   `toml:"collapse_off_hours"` // Fold tasks outside day_start-day_end into a thin band (z expands)
+	SplitOwner       string `toml:"split_owner"`        // Owner whose tasks share each day column on its right half (empty = one lane)
+
+	MoveStepMinutes       int `toml:"move_step_minutes"`        // j/k step into free time in move mode (0 = one grid row)
+	CoarseMoveStepMinutes int `toml:"coarse_move_step_minutes"` // J/K step in move mode (0 = 60)
+}
+
+// FirstWeekday returns the day weeks start on.
+func (u UIConfig) FirstWeekday() time.Weekday {
+	if strings.EqualFold(u.WeekStart, "sunday") {
+		return time.Sunday
+	}
+	return time.Monday
+}
+
+// WeekendsMode returns how days off without tasks are drawn in the grid:
+// "show", "narrow" or "hide".
+func (u UIConfig) WeekendsMode() string {
+	if u.Weekends == "" {
+		return "show"
+	}
+	return strings.ToLower(u.Weekends)
+}
+
+// WindowRadius returns how many weeks the TUI keeps loaded on each side of
+// the visible week.
+func (u UIConfig) WindowRadius() int {
+	if u.WindowWeeks <= 0 {
+		return 1
+	}
+	return u.WindowWeeks / 2
 }
 
 // ScheduleConfig holds workday scheduling settings.
@@ -31,6 +280,12 @@ type ScheduleConfig struct {
 	DayEnd         string   `toml:"day_end"`          // e.g., "17:00"
 	PeakHoursStart string   `toml:"peak_hours_start"` // e.g., "09:00" (optional)
 	PeakHoursEnd   string   `toml:"peak_hours_end"`   // e.g., "12:00" (optional)
+	BufferMinutes  int      `toml:"buffer_minutes"`   // Gap kept after new tasks, multiple of 15 (0 = off)
+	AllowOverlaps  bool     `toml:"allow_overlaps"`   // Store overlapping blocks instead of rejecting them
+
+	RescheduleMissed bool `toml:"reschedule_missed"` // On startup, offer to reschedule tasks just marked missed
+
+	TimezonePins []TimezonePin `toml:"timezone_pins,omitempty"` // Travel days shown in another zone
 }
 
 // LLMConfig holds LLM provider settings.
@@ -38,6 +293,52 @@ type LLMConfig struct {
 	Provider string `toml:"provider"` // "copilot", "ollama", etc.
 	Model    string `toml:"model"`    // e.g., "gpt-4o"
 	BaseURL  string `toml:"base_url"` // e.g., "http://localhost:11434"
+
+	Ollama OllamaConfig `toml:"ollama"`
+	Audit  AuditConfig  `toml:"audit"`
+}
+
+// AuditConfig controls the local log of LLM prompts and responses.
+type AuditConfig struct {
+	Enabled bool     `toml:"enabled"`
+	Path    string   `toml:"path"`   // Empty means llm-audit.jsonl next to the database
+	Redact  []string `toml:"redact"` // Regular expressions replaced with [REDACTED]
+}
+
+// AuditPath returns the audit log path, defaulting to a file next to the database.
+func (c *Config) AuditPath() string {
+	if c.LLM.Audit.Path != "" {
+		return c.LLM.Audit.Path
+	}
+	return filepath.Join(filepath.Dir(c.Storage.DBPath), "llm-audit.jsonl")
+}
+
+// EditAutosavePath returns the file the TUI keeps unsaved edit-mode changes
+// in, next to the database.
+func (c *Config) EditAutosavePath() string {
+	return filepath.Join(filepath.Dir(c.Storage.DBPath), "edit-session.json")
+}
+
+// OllamaConfig holds settings for a local Ollama server.
+// Empty values fall back to the top-level [llm] model and base_url.
+type OllamaConfig struct {
+	Host  string `toml:"host"`  // e.g., "http://localhost:11434"
+	Model string `toml:"model"` // e.g., "llama3.1"
+}
+
+// Endpoint returns the model and base URL to use for the configured provider,
+// applying provider-specific overrides.
+func (c LLMConfig) Endpoint() (model, baseURL string) {
+	model, baseURL = c.Model, c.BaseURL
+	if strings.EqualFold(strings.TrimSpace(c.Provider), "ollama") {
+		if c.Ollama.Host != "" {
+			baseURL = c.Ollama.Host
+		}
+		if c.Ollama.Model != "" {
+			model = c.Ollama.Model
+		}
+	}
+	return model, baseURL
 }
 
 // StorageConfig holds database settings.
@@ -66,6 +367,9 @@ func Default() *Config {
 		UI: UIConfig{
 			Theme: "frappe", // Default to Catppuccin Mocha
 		},
+		Sync: SyncConfig{
+			IntervalMinutes: defaultSyncIntervalMinutes,
+		},
 	}
 }
 
@@ -107,6 +411,9 @@ func LoadFrom(path string) (*Config, error) {
 
 	// Expand paths
 	cfg.Storage.DBPath = expandPath(cfg.Storage.DBPath)
+	cfg.LLM.Audit.Path = expandPath(cfg.LLM.Audit.Path)
+	cfg.Sync.Replica.Dir = expandPath(cfg.Sync.Replica.Dir)
+	cfg.Export.ObsidianDailyNote = expandPath(cfg.Export.ObsidianDailyNote)
 
 	// Validate
 	if err := cfg.Validate(); err != nil {
@@ -143,6 +450,11 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("DEEPWORK_DAY_END"); v != "" {
 		cfg.Schedule.DayEnd = v
 	}
+	if v := os.Getenv("DEEPWORK_BUFFER_MINUTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Schedule.BufferMinutes = n
+		}
+	}
 	if v := os.Getenv("DEEPWORK_WORKDAYS"); v != "" {
 		cfg.Schedule.Workdays = strings.Split(v, ",")
 	}
@@ -163,6 +475,19 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("DEEPWORK_LLM_BASE_URL"); v != "" {
 		cfg.LLM.BaseURL = v
 	}
+	if v := os.Getenv("DEEPWORK_OLLAMA_HOST"); v != "" {
+		cfg.LLM.Ollama.Host = v
+	}
+	if v := os.Getenv("DEEPWORK_OLLAMA_MODEL"); v != "" {
+		cfg.LLM.Ollama.Model = v
+	}
+
+	// Privacy overrides
+	if v := os.Getenv("DEEPWORK_LOCAL_ONLY"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Privacy.LocalOnly = b
+		}
+	}
 
 	// Storage overrides
 	if v := os.Getenv("DEEPWORK_DB_PATH"); v != "" {
@@ -173,6 +498,24 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("DEEPWORK_UI_THEME"); v != "" {
 		cfg.UI.Theme = v
 	}
+	if v := os.Getenv("DEEPWORK_UI_WEEK_START"); v != "" {
+		cfg.UI.WeekStart = v
+	}
+	if v := os.Getenv("DEEPWORK_UI_LOCALE"); v != "" {
+		cfg.UI.Locale = v
+	}
+
+	// Goal overrides (invalid numbers are ignored)
+	if v := os.Getenv("DEEPWORK_GOAL_DEEP_HOURS"); v != "" {
+		if h, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.Goals.DeepHours = h
+		}
+	}
+	if v := os.Getenv("DEEPWORK_GOAL_TOTAL_HOURS"); v != "" {
+		if h, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.Goals.TotalHours = h
+		}
+	}
 }
 
 // expandPath expands ~ to the user's home directory.
@@ -225,9 +568,184 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("invalid workday: %s", day)
 		}
 	}
+	if err := validateTimezonePins(c.Schedule.TimezonePins); err != nil {
+		return err
+	}
 	if c.Storage.DBPath == "" {
 		return errors.New("db_path must be set")
 	}
+	if b := c.Schedule.BufferMinutes; b < 0 || b > 240 || b%15 != 0 {
+		return fmt.Errorf("buffer_minutes must be a multiple of 15 between 0 and 240, got %d", b)
+	}
+	for _, windows := range [][]string{c.Energy.High, c.Energy.Medium, c.Energy.Low} {
+		for _, w := range windows {
+			if _, _, ok := parseWindow(w); !ok {
+				return fmt.Errorf("energy window must be HH:MM-HH:MM with start before end, got %q", w)
+			}
+		}
+	}
+	if err := validateGoalHours(c.Goals.DeepHours, "deep_hours"); err != nil {
+		return err
+	}
+	if err := validateGoalHours(c.Goals.TotalHours, "total_hours"); err != nil {
+		return err
+	}
+	switch c.UI.SlotMinutes {
+	case 0, 15, 30, 60:
+	default:
+		return fmt.Errorf("slot_minutes must be 15, 30 or 60, got %d", c.UI.SlotMinutes)
+	}
+	if err := validateMoveStep(c.UI.MoveStepMinutes, "move_step_minutes"); err != nil {
+		return err
+	}
+	if err := validateMoveStep(c.UI.CoarseMoveStepMinutes, "coarse_move_step_minutes"); err != nil {
+		return err
+	}
+	if w := c.UI.WindowWeeks; w != 0 && (w < 3 || w > 13 || w%2 == 0) {
+		return fmt.Errorf("window_weeks must be an odd number between 3 and 13, got %d", w)
+	}
+	switch strings.ToLower(c.UI.WeekStart) {
+	case "", "monday", "sunday":
+	default:
+		return fmt.Errorf("week_start must be monday or sunday, got %q", c.UI.WeekStart)
+	}
+	switch strings.ToLower(c.UI.Weekends) {
+	case "", "show", "narrow", "hide":
+	default:
+		return fmt.Errorf("weekends must be show, narrow or hide, got %q", c.UI.Weekends)
+	}
+	if _, ok := dateutil.LookupLocale(c.UI.Locale); !ok {
+		return fmt.Errorf("locale must be one of %s, got %q", strings.Join(dateutil.LocaleNames(), ", "), c.UI.Locale)
+	}
+	for _, pattern := range c.LLM.Audit.Redact {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("llm.audit redact pattern %q: %w", pattern, err)
+		}
+	}
+	if err := validateCategories(c.Categories); err != nil {
+		return err
+	}
+	if j := c.Import.Jira; j.JQL != "" || j.URL != "" {
+		if !strings.HasPrefix(j.URL, "http://") && !strings.HasPrefix(j.URL, "https://") {
+			return fmt.Errorf("import.jira url must be an http(s) URL, got %q", j.URL)
+		}
+		if strings.TrimSpace(j.JQL) == "" {
+			return errors.New("import.jira jql must be set")
+		}
+	}
+	return validateSync(c.Sync, c.Categories)
+}
+
+// validateCategories checks that category names and codes are unique,
+// colors are hex and icons are a single character at most two columns wide.
+func validateCategories(categories []CategoryConfig) error {
+	names := map[string]bool{}
+	codes := map[string]string{"D": "deep", "S": "shallow"}
+	for i, c := range categories {
+		name := strings.ToLower(strings.TrimSpace(c.Name))
+		if name == "" {
+			return fmt.Errorf("categories[%d]: name must be set", i)
+		}
+		if names[name] {
+			return fmt.Errorf("categories[%d]: duplicate name %q", i, name)
+		}
+		names[name] = true
+		if (c.Code != "" || !isBuiltinCategory(name)) && !categoryCode.MatchString(c.Code) {
+			return fmt.Errorf("categories[%d] (%s): code must be a single letter or digit, got %q", i, name, c.Code)
+		}
+		if c.Code != "" {
+			code := strings.ToUpper(c.Code)
+			if other, ok := codes[code]; ok && other != name {
+				return fmt.Errorf("categories[%d] (%s): code %q is already used by %s", i, name, c.Code, other)
+			}
+			codes[code] = name
+		}
+		if c.Color != "" && !hexColor.MatchString(c.Color) {
+			return fmt.Errorf("categories[%d] (%s): color must be a hex color like #f5a97f, got %q", i, name, c.Color)
+		}
+		if c.Icon != "" && (uniseg.GraphemeClusterCount(c.Icon) != 1 || uniseg.StringWidth(c.Icon) > 2) {
+			return fmt.Errorf("categories[%d] (%s): icon must be a single emoji or glyph, got %q", i, name, c.Icon)
+		}
+		if err := validateGoalHours(c.WeeklyLimitHours, fmt.Sprintf("categories[%d] (%s): weekly_limit_hours", i, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isBuiltinCategory(name string) bool {
+	return name == "deep" || name == "shallow"
+}
+
+// isCategoryIn returns true if name is deep, shallow or one of categories.
+func isCategoryIn(name string, categories []CategoryConfig) bool {
+	if isBuiltinCategory(name) {
+		return true
+	}
+	for _, c := range categories {
+		if strings.ToLower(strings.TrimSpace(c.Name)) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSync checks the sync interval, feeds and replica.
+func validateSync(s SyncConfig, categories []CategoryConfig) error {
+	if s.IntervalMinutes < 0 || s.IntervalMinutes > 24*60 {
+		return fmt.Errorf("sync interval_minutes must be between 0 and 1440, got %d", s.IntervalMinutes)
+	}
+	names := make(map[string]bool, len(s.ICS))
+	for i, feed := range s.ICS {
+		if strings.TrimSpace(feed.Name) == "" {
+			return fmt.Errorf("sync.ics[%d]: name must be set", i)
+		}
+		if names[feed.Name] {
+			return fmt.Errorf("sync.ics[%d]: duplicate name %q", i, feed.Name)
+		}
+		names[feed.Name] = true
+		if strings.TrimSpace(feed.URL) == "" {
+			return fmt.Errorf("sync.ics[%d] (%s): url must be set", i, feed.Name)
+		}
+		if feed.Category != "" && !isCategoryIn(feed.Category, categories) {
+			return fmt.Errorf("sync.ics[%d] (%s): category must be 'deep', 'shallow' or a configured category, got %q", i, feed.Name, feed.Category)
+		}
+	}
+	return validateReplica(s.Replica)
+}
+
+// validateReplica checks that the replica has what its kind needs.
+func validateReplica(r Replica) error {
+	switch r.Kind {
+	case "":
+	case "webdav":
+		if strings.TrimSpace(r.URL) == "" {
+			return fmt.Errorf("sync.replica: url must be set for webdav")
+		}
+	case "git":
+		if strings.TrimSpace(r.Dir) == "" {
+			return fmt.Errorf("sync.replica: dir must be set for git")
+		}
+	default:
+		return fmt.Errorf("sync.replica: kind must be 'webdav' or 'git', got %q", r.Kind)
+	}
+	return nil
+}
+
+// validateGoalHours checks that a weekly target fits in a week.
+func validateGoalHours(hours float64, field string) error {
+	if hours < 0 || hours > 7*24 {
+		return fmt.Errorf("%s must be between 0 and 168, got %v", field, hours)
+	}
+	return nil
+}
+
+// validateMoveStep checks a move step: a multiple of 15 minutes up to 4
+// hours, or 0 for the default.
+func validateMoveStep(minutes int, field string) error {
+	if minutes < 0 || minutes > 240 || minutes%15 != 0 {
+		return fmt.Errorf("%s must be a multiple of 15 up to 240, got %d", field, minutes)
+	}
 	return nil
 }
 
@@ -278,6 +796,11 @@ func (c *Config) IsWorkday(weekday string) bool {
 	return false
 }
 
+// HasGoals returns true if any weekly target is configured.
+func (c *Config) HasGoals() bool {
+	return c.Goals.DeepHours > 0 || c.Goals.TotalHours > 0
+}
+
 // HasPeakHours returns true if peak hours are configured.
 func (c *Config) HasPeakHours() bool {
 	return c.Schedule.PeakHoursStart != "" && c.Schedule.PeakHoursEnd != ""
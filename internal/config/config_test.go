@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestDefault(t *testing.T) {
@@ -354,3 +355,444 @@ db_path = "/tmp/test.db"
 		t.Error("expected HasPeakHours() = true")
 	}
 }
+
+func TestLoadFrom_OllamaSection(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[llm]
+provider = "ollama"
+model = "gpt-4o"
+
+[llm.ollama]
+host = "http://gpu-box:11434"
+model = "llama3.1"
+
+[storage]
+db_path = "/tmp/test.db"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	model, baseURL := cfg.LLM.Endpoint()
+	if model != "llama3.1" {
+		t.Errorf("expected model llama3.1, got %q", model)
+	}
+	if baseURL != "http://gpu-box:11434" {
+		t.Errorf("expected base_url http://gpu-box:11434, got %q", baseURL)
+	}
+}
+
+func TestLLMEndpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       LLMConfig
+		wantModel string
+		wantURL   string
+	}{
+		{
+			name:      "copilot ignores ollama section",
+			cfg:       LLMConfig{Provider: "copilot", Model: "gpt-4o", BaseURL: "u", Ollama: OllamaConfig{Host: "h", Model: "m"}},
+			wantModel: "gpt-4o",
+			wantURL:   "u",
+		},
+		{
+			name:      "ollama falls back to top-level values",
+			cfg:       LLMConfig{Provider: "ollama", Model: "llama3", BaseURL: "u"},
+			wantModel: "llama3",
+			wantURL:   "u",
+		},
+		{
+			name:      "ollama section overrides",
+			cfg:       LLMConfig{Provider: "Ollama", Model: "llama3", BaseURL: "u", Ollama: OllamaConfig{Host: "h", Model: "m"}},
+			wantModel: "m",
+			wantURL:   "h",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, url := tt.cfg.Endpoint()
+			if model != tt.wantModel || url != tt.wantURL {
+				t.Errorf("Endpoint() = (%q, %q), want (%q, %q)", model, url, tt.wantModel, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestLoadFrom_OllamaEnvOverrides(t *testing.T) {
+	t.Setenv("DEEPWORK_OLLAMA_HOST", "http://localhost:9999")
+	t.Setenv("DEEPWORK_OLLAMA_MODEL", "qwen2.5")
+
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.LLM.Ollama.Host != "http://localhost:9999" {
+		t.Errorf("expected ollama host from env, got %q", cfg.LLM.Ollama.Host)
+	}
+	if cfg.LLM.Ollama.Model != "qwen2.5" {
+		t.Errorf("expected ollama model from env, got %q", cfg.LLM.Ollama.Model)
+	}
+}
+
+func TestValidate_Goals(t *testing.T) {
+	tests := []struct {
+		name    string
+		goals   GoalsConfig
+		wantErr bool
+	}{
+		{"unset", GoalsConfig{}, false},
+		{"valid", GoalsConfig{DeepHours: 15, TotalHours: 30}, false},
+		{"negative", GoalsConfig{DeepHours: -1}, true},
+		{"more than a week", GoalsConfig{TotalHours: 200}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Goals = tt.goals
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadFrom_GoalsEnvOverrides(t *testing.T) {
+	t.Setenv("DEEPWORK_GOAL_DEEP_HOURS", "12.5")
+	t.Setenv("DEEPWORK_GOAL_TOTAL_HOURS", "not-a-number")
+
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("LoadFrom() error = %v", err)
+	}
+	if cfg.Goals.DeepHours != 12.5 {
+		t.Errorf("DeepHours = %v, want 12.5", cfg.Goals.DeepHours)
+	}
+	if cfg.Goals.TotalHours != 0 {
+		t.Errorf("TotalHours = %v, want 0 (invalid value ignored)", cfg.Goals.TotalHours)
+	}
+	if got := cfg.Goals.DeepMinutes(); got != 750 {
+		t.Errorf("DeepMinutes() = %d, want 750", got)
+	}
+	if !cfg.HasGoals() {
+		t.Error("HasGoals() = false, want true")
+	}
+}
+
+func TestEnergyLevelAt(t *testing.T) {
+	e := EnergyConfig{
+		High: []string{"09:00-12:00"},
+		Low:  []string{"13:00-15:00", "11:00-12:00"},
+	}
+	tests := []struct {
+		minute int
+		want   string
+	}{
+		{9 * 60, "high"},
+		{11*60 + 30, "high"}, // high wins over an overlapping low window
+		{12 * 60, ""},
+		{14*60 + 59, "low"},
+		{15 * 60, ""},
+	}
+	for _, tt := range tests {
+		if got := e.LevelAt(tt.minute); got != tt.want {
+			t.Errorf("LevelAt(%d) = %q, want %q", tt.minute, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_EnergyWindows(t *testing.T) {
+	cfg := Default()
+	cfg.Energy.Low = []string{"15:00-13:00"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected validation error for reversed energy window")
+	}
+
+	cfg.Energy.Low = []string{"13:00-15:00"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_BufferMinutes(t *testing.T) {
+	tests := []struct {
+		buffer  int
+		wantErr bool
+	}{
+		{0, false},
+		{15, false},
+		{240, false},
+		{10, true},
+		{-15, true},
+		{255, true},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.Schedule.BufferMinutes = tt.buffer
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("buffer %d: Validate() error = %v, wantErr %v", tt.buffer, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_WindowWeeks(t *testing.T) {
+	tests := []struct {
+		weeks      int
+		wantErr    bool
+		wantRadius int
+	}{
+		{0, false, 1},
+		{3, false, 1},
+		{9, false, 4},
+		{13, false, 6},
+		{1, true, 0},
+		{4, true, 0},
+		{15, true, 0},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.UI.WindowWeeks = tt.weeks
+		err := cfg.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("window_weeks %d: Validate() error = %v, wantErr %v", tt.weeks, err, tt.wantErr)
+		}
+		if err == nil && cfg.UI.WindowRadius() != tt.wantRadius {
+			t.Errorf("window_weeks %d: WindowRadius() = %d, want %d", tt.weeks, cfg.UI.WindowRadius(), tt.wantRadius)
+		}
+	}
+}
+
+func TestValidate_WeekStartAndLocale(t *testing.T) {
+	tests := []struct {
+		weekStart string
+		locale    string
+		wantErr   bool
+		wantFirst time.Weekday
+	}{
+		{"", "", false, time.Monday},
+		{"monday", "en", false, time.Monday},
+		{"Sunday", "es", false, time.Sunday},
+		{"saturday", "", true, 0},
+		{"", "xx", true, 0},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.UI.WeekStart = tt.weekStart
+		cfg.UI.Locale = tt.locale
+		err := cfg.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("week_start %q locale %q: Validate() error = %v, wantErr %v", tt.weekStart, tt.locale, err, tt.wantErr)
+		}
+		if err == nil && cfg.UI.FirstWeekday() != tt.wantFirst {
+			t.Errorf("week_start %q: FirstWeekday() = %v, want %v", tt.weekStart, cfg.UI.FirstWeekday(), tt.wantFirst)
+		}
+	}
+}
+
+func TestValidate_Weekends(t *testing.T) {
+	tests := []struct {
+		weekends string
+		wantErr  bool
+		wantMode string
+	}{
+		{"", false, "show"},
+		{"narrow", false, "narrow"},
+		{"Hide", false, "hide"},
+		{"fold", true, ""},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.UI.Weekends = tt.weekends
+		err := cfg.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("weekends %q: Validate() error = %v, wantErr %v", tt.weekends, err, tt.wantErr)
+		}
+		if err == nil && cfg.UI.WeekendsMode() != tt.wantMode {
+			t.Errorf("weekends %q: WeekendsMode() = %q, want %q", tt.weekends, cfg.UI.WeekendsMode(), tt.wantMode)
+		}
+	}
+}
+
+func TestValidate_MoveSteps(t *testing.T) {
+	tests := []struct {
+		fine, coarse int
+		wantErr      bool
+	}{
+		{0, 0, false},
+		{15, 60, false},
+		{30, 240, false},
+		{10, 0, true},
+		{0, 300, true},
+		{-15, 0, true},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.UI.MoveStepMinutes = tt.fine
+		cfg.UI.CoarseMoveStepMinutes = tt.coarse
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("move steps %d/%d: Validate() error = %v, wantErr %v", tt.fine, tt.coarse, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_Categories(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []CategoryConfig
+		feed       string
+		wantErr    bool
+	}{
+		{"none", nil, "", false},
+		{"custom", []CategoryConfig{{Name: "meeting", Code: "M", Color: "#f5a97f"}}, "meeting", false},
+		{"recolor built-in", []CategoryConfig{{Name: "deep", Color: "#8aadf4"}}, "", false},
+		{"missing name", []CategoryConfig{{Code: "M"}}, "", true},
+		{"missing code", []CategoryConfig{{Name: "meeting"}}, "", true},
+		{"long code", []CategoryConfig{{Name: "meeting", Code: "MT"}}, "", true},
+		{"code taken", []CategoryConfig{{Name: "sales", Code: "s"}}, "", true},
+		{"duplicate", []CategoryConfig{{Name: "meeting", Code: "M"}, {Name: "Meeting", Code: "N"}}, "", true},
+		{"bad color", []CategoryConfig{{Name: "meeting", Code: "M", Color: "orange"}}, "", true},
+		{"emoji icon", []CategoryConfig{{Name: "meeting", Code: "M", Icon: "📞"}}, "", false},
+		{"joined emoji icon", []CategoryConfig{{Name: "deep", Icon: "🧑‍💻"}}, "", false},
+		{"nerd font icon", []CategoryConfig{{Name: "meeting", Code: "M", Icon: "\uf0c0"}}, "", false},
+		{"word icon", []CategoryConfig{{Name: "meeting", Code: "M", Icon: "call"}}, "", true},
+		{"weekly limit", []CategoryConfig{{Name: "shallow", WeeklyLimitHours: 10}}, "", false},
+		{"negative weekly limit", []CategoryConfig{{Name: "shallow", WeeklyLimitHours: -1}}, "", true},
+		{"unknown feed category", nil, "meeting", true},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.Categories = tt.categories
+		if tt.feed != "" {
+			cfg.Sync.ICS = []ICSFeed{{Name: "work", URL: "work.ics", Category: tt.feed}}
+		}
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_JiraImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		jira    JiraImport
+		wantErr bool
+	}{
+		{"unset", JiraImport{}, false},
+		{"valid", JiraImport{URL: "https://acme.atlassian.net", JQL: "assignee = currentUser()"}, false},
+		{"missing url", JiraImport{JQL: "assignee = currentUser()"}, true},
+		{"missing jql", JiraImport{URL: "https://acme.atlassian.net"}, true},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.Import.Jira = tt.jira
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoadFrom_LLMAudit(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[storage]
+db_path = "/tmp/sancho/test.db"
+
+[llm.audit]
+enabled = true
+redact = ["sk-[A-Za-z0-9]+"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.LLM.Audit.Enabled || len(cfg.LLM.Audit.Redact) != 1 {
+		t.Errorf("Audit = %+v", cfg.LLM.Audit)
+	}
+	if got, want := cfg.AuditPath(), filepath.Join("/tmp/sancho", "llm-audit.jsonl"); got != want {
+		t.Errorf("AuditPath() = %q, want %q", got, want)
+	}
+	if got, want := cfg.EditAutosavePath(), filepath.Join("/tmp/sancho", "edit-session.json"); got != want {
+		t.Errorf("EditAutosavePath() = %q, want %q", got, want)
+	}
+
+	cfg.LLM.Audit.Redact = []string{"("}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for invalid redact pattern")
+	}
+}
+
+func TestLoadFrom_SyncSection(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+
+	content := `
+[storage]
+db_path = "/tmp/test.db"
+
+[sync]
+interval_minutes = 30
+
+[[sync.ics]]
+name = "work"
+url = "https://example.com/work.ics"
+
+[[sync.ics]]
+name = "gym"
+url = "~/calendars/gym.ics"
+category = "deep"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.Sync.Interval(); got != 30*time.Minute {
+		t.Errorf("Interval() = %v, want 30m", got)
+	}
+	if len(cfg.Sync.ICS) != 2 || cfg.Sync.ICS[1].Category != "deep" {
+		t.Fatalf("ICS feeds = %+v", cfg.Sync.ICS)
+	}
+	if !cfg.Sync.HasSources() {
+		t.Error("expected HasSources() = true")
+	}
+}
+
+func TestValidate_Sync(t *testing.T) {
+	tests := []struct {
+		name    string
+		sync    SyncConfig
+		wantErr bool
+	}{
+		{"defaults", SyncConfig{}, false},
+		{"negative interval", SyncConfig{IntervalMinutes: -1}, true},
+		{"missing url", SyncConfig{ICS: []ICSFeed{{Name: "work"}}}, true},
+		{"missing name", SyncConfig{ICS: []ICSFeed{{URL: "a.ics"}}}, true},
+		{"duplicate name", SyncConfig{ICS: []ICSFeed{{Name: "a", URL: "a.ics"}, {Name: "a", URL: "b.ics"}}}, true},
+		{"bad category", SyncConfig{ICS: []ICSFeed{{Name: "a", URL: "a.ics", Category: "meeting"}}}, true},
+		{"valid feed", SyncConfig{ICS: []ICSFeed{{Name: "a", URL: "a.ics", Category: "shallow"}}}, false},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.Sync = tt.sync
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimezonePin pins a date range to a timezone other than the local one.
+// Tasks on pinned days are displayed and validated in that zone, which is
+// useful for travel such as a conference abroad.
+type TimezonePin struct {
+	Start string `toml:"start"` // YYYY-MM-DD (inclusive)
+	End   string `toml:"end"`   // YYYY-MM-DD (inclusive)
+	Zone  string `toml:"zone"`  // IANA name, e.g. "Europe/Berlin"
+
+	loc *time.Location // Zone resolved by Validate or PinTimezone
+}
+
+// Contains reports whether the pin covers the calendar date of d.
+func (p TimezonePin) Contains(d time.Time) bool {
+	key := d.Format("2006-01-02")
+	return key >= p.Start && key <= p.End
+}
+
+// LocationFor returns the timezone for the calendar date of d.
+// Unpinned dates, and pins not yet resolved by Validate, use the local
+// timezone. It runs per grid cell, so it never loads zone data itself.
+func (c *Config) LocationFor(d time.Time) *time.Location {
+	for i := len(c.Schedule.TimezonePins) - 1; i >= 0; i-- {
+		pin := c.Schedule.TimezonePins[i]
+		if pin.loc != nil && pin.Contains(d) {
+			return pin.loc
+		}
+	}
+	return time.Local
+}
+
+// PinTimezone pins the inclusive date range to zone, replacing any pin with
+// the same range.
+func (c *Config) PinTimezone(start, end time.Time, zone string) error {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return fmt.Errorf("unknown timezone %q: %w", zone, err)
+	}
+	pin := TimezonePin{
+		Start: start.Format("2006-01-02"),
+		End:   end.Format("2006-01-02"),
+		Zone:  zone,
+		loc:   loc,
+	}
+	if pin.End < pin.Start {
+		return errors.New("timezone pin end must not be before start")
+	}
+
+	pins := c.Schedule.TimezonePins[:0:0]
+	for _, p := range c.Schedule.TimezonePins {
+		if p.Start == pin.Start && p.End == pin.End {
+			continue
+		}
+		pins = append(pins, p)
+	}
+	c.Schedule.TimezonePins = append(pins, pin)
+	return nil
+}
+
+// UnpinTimezone removes every pin covering the calendar date of d.
+// It returns the number of pins removed.
+func (c *Config) UnpinTimezone(d time.Time) int {
+	pins := c.Schedule.TimezonePins[:0:0]
+	for _, p := range c.Schedule.TimezonePins {
+		if !p.Contains(d) {
+			pins = append(pins, p)
+		}
+	}
+	removed := len(c.Schedule.TimezonePins) - len(pins)
+	c.Schedule.TimezonePins = pins
+	return removed
+}
+
+// validateTimezonePins checks pin dates and zones, and resolves each zone
+// so LocationFor does not have to.
+func validateTimezonePins(pins []TimezonePin) error {
+	for i := range pins {
+		p := &pins[i]
+		start, err := time.Parse("2006-01-02", p.Start)
+		if err != nil {
+			return fmt.Errorf("timezone pin start must be YYYY-MM-DD, got %q", p.Start)
+		}
+		end, err := time.Parse("2006-01-02", p.End)
+		if err != nil {
+			return fmt.Errorf("timezone pin end must be YYYY-MM-DD, got %q", p.End)
+		}
+		if end.Before(start) {
+			return fmt.Errorf("timezone pin %s..%s ends before it starts", p.Start, p.End)
+		}
+		loc, err := time.LoadLocation(p.Zone)
+		if err != nil {
+			return fmt.Errorf("timezone pin %s..%s has unknown zone %q", p.Start, p.End, p.Zone)
+		}
+		p.loc = loc
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocationFor(t *testing.T) {
+	cfg := Default()
+	cfg.Schedule.TimezonePins = []TimezonePin{
+		{Start: "2026-03-02", End: "2026-03-06", Zone: "UTC"},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want *time.Location
+	}{
+		{"before pin", time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local), time.Local},
+		{"first pinned day", time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local), time.UTC},
+		{"last pinned day", time.Date(2026, 3, 6, 23, 0, 0, 0, time.Local), time.UTC},
+		{"after pin", time.Date(2026, 3, 7, 0, 0, 0, 0, time.Local), time.Local},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cfg.LocationFor(tt.date)
+			if got.String() != tt.want.String() {
+				t.Errorf("LocationFor() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPinAndUnpinTimezone(t *testing.T) {
+	cfg := Default()
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+
+	if err := cfg.PinTimezone(start, end, "UTC"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Re-pinning the same range replaces the zone
+	if err := cfg.PinTimezone(start, end, "Etc/GMT-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Schedule.TimezonePins) != 1 || cfg.Schedule.TimezonePins[0].Zone != "Etc/GMT-1" {
+		t.Fatalf("pins = %+v, want single replaced pin", cfg.Schedule.TimezonePins)
+	}
+	if got := cfg.LocationFor(start); got.String() != "Etc/GMT-1" {
+		t.Errorf("LocationFor() = %s, want Etc/GMT-1", got)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+
+	if removed := cfg.UnpinTimezone(start.AddDate(0, 0, 3)); removed != 1 {
+		t.Errorf("UnpinTimezone() removed %d, want 1", removed)
+	}
+	if len(cfg.Schedule.TimezonePins) != 0 {
+		t.Errorf("expected no pins left, got %+v", cfg.Schedule.TimezonePins)
+	}
+}
+
+func TestPinTimezone_Errors(t *testing.T) {
+	cfg := Default()
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	if err := cfg.PinTimezone(day, day, "Mars/Olympus"); err == nil {
+		t.Error("expected error for unknown zone")
+	}
+	if err := cfg.PinTimezone(day, day.AddDate(0, 0, -1), "UTC"); err == nil {
+		t.Error("expected error for end before start")
+	}
+}
+
+func TestValidate_TimezonePins(t *testing.T) {
+	tests := []struct {
+		name string
+		pin  TimezonePin
+	}{
+		{"bad start", TimezonePin{Start: "03/02/2026", End: "2026-03-06", Zone: "UTC"}},
+		{"bad end", TimezonePin{Start: "2026-03-02", End: "", Zone: "UTC"}},
+		{"reversed", TimezonePin{Start: "2026-03-06", End: "2026-03-02", Zone: "UTC"}},
+		{"unknown zone", TimezonePin{Start: "2026-03-02", End: "2026-03-06", Zone: "Nowhere/City"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Schedule.TimezonePins = []TimezonePin{tt.pin}
+			if err := cfg.Validate(); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ package dateutil
 import (
 	"errors"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -71,16 +72,57 @@ func ParseDate(s string) (time.Time, error) {
 	return t, nil
 }
 
-// WeekRange returns the Monday and Sunday of the ISO week containing t.
-func WeekRange(t time.Time) (monday, sunday time.Time) {
+// firstWeekday is the day weeks start on; Monday unless SetFirstWeekday
+// changed it.
+var firstWeekday atomic.Int32
+
+func init() {
+	firstWeekday.Store(int32(time.Monday))
+}
+
+// SetFirstWeekday sets the day weeks start on for WeekRange, StartOfWeek and
+// WeekdayOffset. It is meant to be called once at startup from config.
+func SetFirstWeekday(d time.Weekday) {
+	firstWeekday.Store(int32(d))
+}
+
+// FirstWeekday returns the day weeks start on.
+func FirstWeekday() time.Weekday {
+	return time.Weekday(firstWeekday.Load())
+}
+
+// WeekdayOffset returns how many days t is after the first day of its week,
+// from 0 to 6.
+func WeekdayOffset(t time.Time) int {
+	return (int(t.Weekday()) - int(FirstWeekday()) + 7) % 7
+}
+
+// StartOfWeek returns midnight on the first day of the week containing t.
+func StartOfWeek(t time.Time) time.Time {
 	t = TruncateToDay(t)
-	weekday := int(t.Weekday())
-	if weekday == 0 {
-		weekday = 7 // Sunday becomes day 7 in ISO week
-	}
-	monday = t.AddDate(0, 0, -(weekday - 1))
-	sunday = monday.AddDate(0, 0, 6)
-	return monday, sunday
+	return t.AddDate(0, 0, -WeekdayOffset(t))
+}
+
+// WeekRange returns the first and last day of the week containing t. Weeks
+// run Monday to Sunday unless SetFirstWeekday chose another start.
+func WeekRange(t time.Time) (start, end time.Time) {
+	start = StartOfWeek(t)
+	return start, start.AddDate(0, 0, 6)
+}
+
+// ISOWeek returns the ISO year and week number of the week starting at
+// start. Weeks starting on Sunday take the number of the Monday after.
+func ISOWeek(start time.Time) (year, week int) {
+	monday := start.AddDate(0, 0, (int(time.Monday)-int(start.Weekday())+7)%7)
+	return monday.ISOWeek()
+}
+
+// ISOWeekStart returns the first day of the week holding the Monday of ISO
+// week number week of year.
+func ISOWeekStart(year, week int) time.Time {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7+7*(week-1))
+	return StartOfWeek(monday)
 }
 
 // TruncateToDay returns t with time set to midnight.
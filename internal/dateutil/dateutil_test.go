@@ -184,6 +184,63 @@ func TestWeekRange(t *testing.T) {
 	}
 }
 
+func TestWeekRange_SundayStart(t *testing.T) {
+	SetFirstWeekday(time.Sunday)
+	t.Cleanup(func() { SetFirstWeekday(time.Monday) })
+
+	wednesday := time.Date(2025, 1, 8, 14, 0, 0, 0, time.UTC)
+	start, end := WeekRange(wednesday)
+	if want := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start: got %v, want %v", start, want)
+	}
+	if want := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end: got %v, want %v", end, want)
+	}
+	if got := WeekdayOffset(wednesday); got != 3 {
+		t.Errorf("WeekdayOffset(Wednesday) = %d, want 3", got)
+	}
+
+	// Sunday opens its own week instead of closing the previous one.
+	sunday := time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC)
+	if got := StartOfWeek(sunday); !got.Equal(TruncateToDay(sunday)) {
+		t.Errorf("StartOfWeek(Sunday) = %v, want the same day", got)
+	}
+	if _, week := ISOWeek(StartOfWeek(sunday)); week != 3 {
+		t.Errorf("ISOWeek of week starting Sunday Jan 12 = %d, want 3", week)
+	}
+}
+
+func TestISOWeekStart(t *testing.T) {
+	// ISO week 1 of 2026 runs from Monday Dec 29, 2025.
+	want := time.Date(2025, 12, 29, 0, 0, 0, 0, time.Local)
+	if got := ISOWeekStart(2026, 1); !got.Equal(want) {
+		t.Errorf("ISOWeekStart(2026, 1) = %v, want %v", got, want)
+	}
+	if got := ISOWeekStart(2026, 2); !got.Equal(want.AddDate(0, 0, 7)) {
+		t.Errorf("ISOWeekStart(2026, 2) = %v, want %v", got, want.AddDate(0, 0, 7))
+	}
+
+	SetFirstWeekday(time.Sunday)
+	t.Cleanup(func() { SetFirstWeekday(time.Monday) })
+	if got := ISOWeekStart(2026, 1); !got.Equal(want.AddDate(0, 0, -1)) {
+		t.Errorf("ISOWeekStart(2026, 1) with Sunday start = %v, want %v", got, want.AddDate(0, 0, -1))
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	en, ok := LookupLocale("")
+	if !ok || en.ShortWeekday(time.Monday) != "Mon" || en.ShortMonth(time.January) != "Jan" {
+		t.Fatalf("default locale: got %v %q %q", ok, en.ShortWeekday(time.Monday), en.ShortMonth(time.January))
+	}
+	es, ok := LookupLocale("ES")
+	if !ok || es.ShortWeekday(time.Wednesday) != "Mié" || es.ShortMonth(time.August) != "Ago" {
+		t.Fatalf("es locale: got %v %q %q", ok, es.ShortWeekday(time.Wednesday), es.ShortMonth(time.August))
+	}
+	if _, ok := LookupLocale("klingon"); ok {
+		t.Fatal("expected unknown locale to be rejected")
+	}
+}
+
 func TestTruncateToDay(t *testing.T) {
 	input := time.Date(2025, 1, 15, 14, 30, 45, 123456789, time.UTC)
 	got := TruncateToDay(input)
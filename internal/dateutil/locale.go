@@ -0,0 +1,74 @@
+package dateutil
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is the locale used when none is configured.
+const DefaultLocale = "en"
+
+// Locale holds the day and month names used to format dates for display.
+type Locale struct {
+	weekdays [7]string  // Short weekday names, Sunday first like time.Weekday
+	months   [12]string // Short month names, January first
+}
+
+var locales = map[string]Locale{
+	"en": {
+		weekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		months:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	},
+	"es": {
+		weekdays: [7]string{"Dom", "Lun", "Mar", "Mié", "Jue", "Vie", "Sáb"},
+		months:   [12]string{"Ene", "Feb", "Mar", "Abr", "May", "Jun", "Jul", "Ago", "Sep", "Oct", "Nov", "Dic"},
+	},
+	"fr": {
+		weekdays: [7]string{"Dim", "Lun", "Mar", "Mer", "Jeu", "Ven", "Sam"},
+		months:   [12]string{"Jan", "Fév", "Mar", "Avr", "Mai", "Jun", "Jul", "Aoû", "Sep", "Oct", "Nov", "Déc"},
+	},
+	"de": {
+		weekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		months:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	},
+	"pt": {
+		weekdays: [7]string{"Dom", "Seg", "Ter", "Qua", "Qui", "Sex", "Sáb"},
+		months:   [12]string{"Jan", "Fev", "Mar", "Abr", "Mai", "Jun", "Jul", "Ago", "Set", "Out", "Nov", "Dez"},
+	},
+	"it": {
+		weekdays: [7]string{"Dom", "Lun", "Mar", "Mer", "Gio", "Ven", "Sab"},
+		months:   [12]string{"Gen", "Feb", "Mar", "Apr", "Mag", "Giu", "Lug", "Ago", "Set", "Ott", "Nov", "Dic"},
+	},
+}
+
+// LookupLocale returns the locale called name, case-insensitively. An empty
+// name is the default locale.
+func LookupLocale(name string) (Locale, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		key = DefaultLocale
+	}
+	l, ok := locales[key]
+	return l, ok
+}
+
+// LocaleNames returns the names of all supported locales, sorted.
+func LocaleNames() []string {
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ShortWeekday returns the abbreviated name of d, e.g. "Mon".
+func (l Locale) ShortWeekday(d time.Weekday) string {
+	return l.weekdays[d]
+}
+
+// ShortMonth returns the abbreviated name of m, e.g. "Jan".
+func (l Locale) ShortMonth(m time.Month) string {
+	return l.months[m-1]
+}
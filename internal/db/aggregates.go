@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// taskMinutesSQL is a task's duration in minutes; blocks past midnight wrap.
+const taskMinutesSQL = `((CAST(substr(scheduled_end, 1, 2) AS INTEGER) * 60 + CAST(substr(scheduled_end, 4, 2) AS INTEGER))
+	- (CAST(substr(scheduled_start, 1, 2) AS INTEGER) * 60 + CAST(substr(scheduled_start, 4, 2) AS INTEGER)) + 1440) % 1440`
+
+// dailyAggregateColumnsSQL computes the daily_stats columns over a set of
+// task rows, matching task.AggregateByDay.
+var dailyAggregateColumnsSQL = strings.NewReplacer("{minutes}", taskMinutesSQL).Replace(`
+	COALESCE(SUM(CASE WHEN status = 'scheduled' AND category = 'deep' THEN {minutes} END), 0),
+	COALESCE(SUM(CASE WHEN status = 'scheduled' AND category IS NOT 'deep' THEN {minutes} END), 0),
+	COUNT(*),
+	COALESCE(SUM(status = 'cancelled'), 0),
+	COALESCE(SUM(status = 'postponed'), 0),
+	COALESCE(SUM(status = 'missed'), 0),
+	COALESCE(SUM(status = 'scheduled' AND outcome = 'on_time'), 0),
+	COALESCE(SUM(status = 'scheduled' AND outcome = 'over'), 0),
+	COALESCE(SUM(status = 'scheduled' AND outcome = 'under'), 0)`)
+
+const dailyStatsInsertSQL = `INSERT OR REPLACE INTO daily_stats (
+	date, deep_minutes, shallow_minutes, total_blocks, cancelled_blocks,
+	postponed_blocks, missed_blocks, on_time, over, under)`
+
+// recomputeDaySQL refreshes the daily_stats row for the date expression
+// (e.g. NEW.scheduled_date inside a trigger), dropping it when the day is empty.
+func recomputeDaySQL(date string) string {
+	return fmt.Sprintf(`
+		DELETE FROM daily_stats WHERE date = %[1]s;
+		%[2]s
+		SELECT %[1]s, %[3]s FROM tasks WHERE scheduled_date = %[1]s HAVING COUNT(*) > 0;`,
+		date, dailyStatsInsertSQL, dailyAggregateColumnsSQL)
+}
+
+// migrateDailyStats creates the daily_stats table and the triggers that keep
+// it in step with every write to tasks, and backfills it when empty.
+func (s *SQLite) migrateDailyStats() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS daily_stats (
+			date             DATE PRIMARY KEY,
+			deep_minutes     INTEGER NOT NULL DEFAULT 0,
+			shallow_minutes  INTEGER NOT NULL DEFAULT 0,
+			total_blocks     INTEGER NOT NULL DEFAULT 0,
+			cancelled_blocks INTEGER NOT NULL DEFAULT 0,
+			postponed_blocks INTEGER NOT NULL DEFAULT 0,
+			missed_blocks    INTEGER NOT NULL DEFAULT 0,
+			on_time          INTEGER NOT NULL DEFAULT 0,
+			over             INTEGER NOT NULL DEFAULT 0,
+			under            INTEGER NOT NULL DEFAULT 0
+		);
+
+		DROP TRIGGER IF EXISTS daily_stats_insert;
+		DROP TRIGGER IF EXISTS daily_stats_update;
+		DROP TRIGGER IF EXISTS daily_stats_delete;
+
+		CREATE TRIGGER daily_stats_insert AFTER INSERT ON tasks BEGIN` + recomputeDaySQL("NEW.scheduled_date") + `
+		END;
+
+		CREATE TRIGGER daily_stats_update
+		AFTER UPDATE OF category, scheduled_date, scheduled_start, scheduled_end, status, outcome ON tasks BEGIN` +
+		recomputeDaySQL("OLD.scheduled_date") + recomputeDaySQL("NEW.scheduled_date") + `
+		END;
+
+		CREATE TRIGGER daily_stats_delete AFTER DELETE ON tasks BEGIN` + recomputeDaySQL("OLD.scheduled_date") + `
+		END;
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating daily_stats: %w", err)
+	}
+
+	// Tables created before missed tasks were counted need the column and
+	// a full rebuild.
+	hasMissed, err := s.hasColumn("daily_stats", "missed_blocks")
+	if err != nil {
+		return err
+	}
+	if !hasMissed {
+		if _, err := s.db.Exec(`ALTER TABLE daily_stats ADD COLUMN missed_blocks INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("adding daily_stats.missed_blocks: %w", err)
+		}
+		return s.rebuildDailyStats(context.Background())
+	}
+
+	var rows int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM daily_stats`).Scan(&rows); err != nil {
+		return fmt.Errorf("counting daily_stats: %w", err)
+	}
+	if rows == 0 {
+		return s.rebuildDailyStats(context.Background())
+	}
+	return nil
+}
+
+// rebuildDailyStats recomputes every daily_stats row from the tasks table.
+func (s *SQLite) rebuildDailyStats(ctx context.Context) error {
+	query := `DELETE FROM daily_stats; ` + dailyStatsInsertSQL + `
+		SELECT scheduled_date, ` + dailyAggregateColumnsSQL + `
+		FROM tasks GROUP BY scheduled_date`
+	if _, err := s.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("rebuilding daily_stats: %w", err)
+	}
+	return nil
+}
+
+// ListDailyAggregates returns the pre-computed daily aggregates in the
+// inclusive range, ordered by date. Days without tasks are omitted.
+func (s *SQLite) ListDailyAggregates(ctx context.Context, start, end time.Time) ([]task.DailyAggregate, error) {
+	query := `
+		SELECT date, deep_minutes, shallow_minutes, total_blocks, cancelled_blocks,
+		       postponed_blocks, missed_blocks, on_time, over, under
+		FROM daily_stats
+		WHERE date >= ? AND date <= ?
+		ORDER BY date
+	`
+	rows, err := s.db.QueryContext(ctx, query, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("querying daily stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []task.DailyAggregate
+	for rows.Next() {
+		var (
+			agg  task.DailyAggregate
+			date string
+		)
+		if err := rows.Scan(&date, &agg.DeepMinutes, &agg.ShallowMinutes, &agg.TotalBlocks,
+			&agg.CancelledBlocks, &agg.PostponedBlocks, &agg.MissedBlocks, &agg.OnTime, &agg.Over, &agg.Under); err != nil {
+			return nil, fmt.Errorf("scanning daily stats: %w", err)
+		}
+		if agg.Date, err = parseDate(date); err != nil {
+			return nil, fmt.Errorf("parsing daily stats date: %w", err)
+		}
+		result = append(result, agg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating daily stats: %w", err)
+	}
+	return result, nil
+}
+
+// ListWeeklyAggregates sums the pre-computed daily aggregates per
+// Monday-to-Sunday week in the inclusive range, ordered by week.
+// Weeks without tasks are omitted.
+func (s *SQLite) ListWeeklyAggregates(ctx context.Context, start, end time.Time) ([]task.WeeklyAggregate, error) {
+	query := `
+		SELECT date(date, '-' || ((CAST(strftime('%w', date) AS INTEGER) + 6) % 7) || ' days') AS week,
+		       SUM(deep_minutes), SUM(shallow_minutes), SUM(total_blocks), SUM(cancelled_blocks),
+		       SUM(postponed_blocks), SUM(missed_blocks), SUM(on_time), SUM(over), SUM(under)
+		FROM daily_stats
+		WHERE date >= ? AND date <= ?
+		GROUP BY week
+		ORDER BY week
+	`
+	rows, err := s.db.QueryContext(ctx, query, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("querying weekly stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []task.WeeklyAggregate
+	for rows.Next() {
+		var (
+			agg  task.WeeklyAggregate
+			week string
+		)
+		if err := rows.Scan(&week, &agg.DeepMinutes, &agg.ShallowMinutes, &agg.TotalBlocks,
+			&agg.CancelledBlocks, &agg.PostponedBlocks, &agg.MissedBlocks, &agg.OnTime, &agg.Over, &agg.Under); err != nil {
+			return nil, fmt.Errorf("scanning weekly stats: %w", err)
+		}
+		if agg.WeekStart, err = parseDate(week); err != nil {
+			return nil, fmt.Errorf("parsing weekly stats date: %w", err)
+		}
+		result = append(result, agg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating weekly stats: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// backlogTimeLayout is how backlog.created_at is stored.
+const backlogTimeLayout = time.RFC3339
+
+// migrateBacklog creates the backlog table. Like external_ref on tasks, an
+// item's reference is unique so importers can upsert.
+func (s *SQLite) migrateBacklog() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS backlog (
+			id           INTEGER PRIMARY KEY AUTOINCREMENT,
+			title        TEXT NOT NULL,
+			notes        TEXT NOT NULL DEFAULT '',
+			external_ref TEXT,
+			created_at   TEXT NOT NULL
+		);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_backlog_external_ref ON backlog(external_ref);
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating backlog: %w", err)
+	}
+	// Items parked on a day keep it here; NULL while in the backlog
+	return s.addColumnIfMissing("backlog", "day", "DATE")
+}
+
+// UpsertBacklogItem adds the item, or refreshes the title and notes of the
+// item with the same external reference. Reports whether a new item was
+// created.
+func (s *SQLite) UpsertBacklogItem(ctx context.Context, item *task.BacklogItem) (bool, error) {
+	if item.Title == "" {
+		return false, task.ErrEmptyDescription
+	}
+	var ref sql.NullString
+	if !item.ExternalRef.IsZero() {
+		ref = sql.NullString{String: item.ExternalRef.String(), Valid: true}
+		var id int64
+		err := s.db.QueryRowContext(ctx, `SELECT id FROM backlog WHERE external_ref = ?`, ref).Scan(&id)
+		if err == nil {
+			if _, err := s.db.ExecContext(ctx, `UPDATE backlog SET title = ?, notes = ? WHERE id = ?`,
+				item.Title, item.Notes, id); err != nil {
+				return false, fmt.Errorf("updating backlog item: %w", err)
+			}
+			item.ID = id
+			return false, nil
+		}
+		if err != sql.ErrNoRows {
+			return false, fmt.Errorf("querying backlog by external reference: %w", err)
+		}
+	}
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = s.clock.Now()
+	}
+	result, err := s.db.ExecContext(ctx, `INSERT INTO backlog (title, notes, external_ref, day, created_at) VALUES (?, ?, ?, ?, ?)`,
+		item.Title, item.Notes, ref, backlogDay(item.Day), item.CreatedAt.UTC().Format(backlogTimeLayout))
+	if err != nil {
+		return false, fmt.Errorf("inserting backlog item: %w", err)
+	}
+	if item.ID, err = result.LastInsertId(); err != nil {
+		return false, fmt.Errorf("getting backlog item id: %w", err)
+	}
+	return true, nil
+}
+
+// ListBacklog returns every backlog item, oldest first.
+func (s *SQLite) ListBacklog(ctx context.Context) ([]*task.BacklogItem, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, title, notes, external_ref, day, created_at FROM backlog ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("querying backlog: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*task.BacklogItem
+	for rows.Next() {
+		var (
+			item      task.BacklogItem
+			ref       sql.NullString
+			day       sql.NullString
+			createdAt string
+		)
+		if err := rows.Scan(&item.ID, &item.Title, &item.Notes, &ref, &day, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning backlog: %w", err)
+		}
+		if day.Valid {
+			if item.Day, err = parseDate(day.String); err != nil {
+				return nil, fmt.Errorf("parsing backlog day: %w", err)
+			}
+		}
+		if item.ExternalRef, err = task.ParseExternalRef(ref.String); err != nil {
+			return nil, fmt.Errorf("parsing backlog reference: %w", err)
+		}
+		if item.CreatedAt, err = time.Parse(backlogTimeLayout, createdAt); err != nil {
+			return nil, fmt.Errorf("parsing backlog time: %w", err)
+		}
+		result = append(result, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating backlog: %w", err)
+	}
+	return result, nil
+}
+
+// DeleteBacklogItem removes an item from the backlog.
+func (s *SQLite) DeleteBacklogItem(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM backlog WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("deleting backlog item: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("backlog item %d not found", id)
+	}
+	return nil
+}
+
+// ParkBacklogItem parks the item on day. A zero day returns it to the
+// backlog.
+func (s *SQLite) ParkBacklogItem(ctx context.Context, id int64, day time.Time) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE backlog SET day = ? WHERE id = ?`, backlogDay(day), id)
+	if err != nil {
+		return fmt.Errorf("parking backlog item: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("backlog item %d not found", id)
+	}
+	return nil
+}
+
+// backlogDay is how a parked day is stored: NULL for none.
+func backlogDay(day time.Time) sql.NullString {
+	if day.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: day.Format("2006-01-02"), Valid: true}
+}
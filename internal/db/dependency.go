@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// migrateDependencies creates the task_dependencies table. Like notes, the
+// links live apart from tasks so replication and exports of the tasks
+// table are unchanged.
+func (s *SQLite) migrateDependencies() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_dependencies (
+			task_id    INTEGER NOT NULL REFERENCES tasks(id),
+			blocked_by INTEGER NOT NULL REFERENCES tasks(id),
+			created_at TEXT NOT NULL,
+			PRIMARY KEY (task_id, blocked_by)
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocked_by ON task_dependencies(blocked_by);
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating task_dependencies: %w", err)
+	}
+	return nil
+}
+
+// AddDependency stores d. Both tasks must exist.
+func (s *SQLite) AddDependency(ctx context.Context, d task.Dependency) error {
+	for _, id := range []int64{d.TaskID, d.BlockedBy} {
+		t, err := getTask(ctx, s.db, id)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return fmt.Errorf("task %d not found", id)
+		}
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO task_dependencies (task_id, blocked_by, created_at) VALUES (?, ?, ?)
+		ON CONFLICT(task_id, blocked_by) DO NOTHING`,
+		d.TaskID, d.BlockedBy, s.clock.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("saving dependency: %w", err)
+	}
+	return nil
+}
+
+// RemoveDependency deletes d.
+func (s *SQLite) RemoveDependency(ctx context.Context, d task.Dependency) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM task_dependencies WHERE task_id = ? AND blocked_by = ?`, d.TaskID, d.BlockedBy)
+	if err != nil {
+		return fmt.Errorf("removing dependency: %w", err)
+	}
+	return nil
+}
+
+// Dependencies returns the links in which any of the given tasks is the
+// blocked task or the prerequisite, ordered by task.
+func (s *SQLite) Dependencies(ctx context.Context, ids []int64) ([]task.Dependency, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	args := make([]any, 0, 2*len(ids))
+	for range 2 {
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+	in := `(?` + strings.Repeat(", ?", len(ids)-1) + `)`
+	query := `SELECT task_id, blocked_by FROM task_dependencies
+		WHERE task_id IN ` + in + ` OR blocked_by IN ` + in + `
+		ORDER BY task_id, blocked_by`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var deps []task.Dependency
+	for rows.Next() {
+		var d task.Dependency
+		if err := rows.Scan(&d.TaskID, &d.BlockedBy); err != nil {
+			return nil, fmt.Errorf("scanning dependency: %w", err)
+		}
+		deps = append(deps, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating dependencies: %w", err)
+	}
+	return deps, nil
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// migrateTaskActuals creates the task_actuals table, which records how long
+// tasks took against their blocks. The category, description key and
+// estimate are copied in when recorded, so later edits to the task do not
+// rewrite what was learned from it.
+func (s *SQLite) migrateTaskActuals() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_actuals (
+			task_id           INTEGER PRIMARY KEY REFERENCES tasks(id),
+			category          TEXT NOT NULL,
+			description_key   TEXT NOT NULL,
+			estimated_minutes INTEGER NOT NULL,
+			actual_minutes    INTEGER NOT NULL,
+			recorded_at       TEXT NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_task_actuals_key ON task_actuals(category, description_key);
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating task_actuals: %w", err)
+	}
+	return nil
+}
+
+// RecordActual stores that t took actualMinutes, replacing any earlier record.
+func (s *SQLite) RecordActual(ctx context.Context, t *task.Task, actualMinutes int) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO task_actuals (task_id, category, description_key, estimated_minutes, actual_minutes, recorded_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		t.ID, string(t.Category), task.DescriptionKey(t.Description), t.Duration(), actualMinutes,
+		s.clock.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("recording actual duration: %w", err)
+	}
+	return nil
+}
+
+// ForgetActual drops the record of task id, if any.
+func (s *SQLite) ForgetActual(ctx context.Context, id int64) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM task_actuals WHERE task_id = ?`, id); err != nil {
+		return fmt.Errorf("forgetting actual duration: %w", err)
+	}
+	return nil
+}
+
+// EstimateAccuracy sums the records of the tasks of category whose
+// description has key as its DescriptionKey.
+func (s *SQLite) EstimateAccuracy(ctx context.Context, category task.Category, key string) (task.EstimateAccuracy, error) {
+	var acc task.EstimateAccuracy
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(estimated_minutes), 0), COALESCE(SUM(actual_minutes), 0)
+		FROM task_actuals WHERE category = ? AND description_key = ?`,
+		string(category), key).Scan(&acc.Samples, &acc.EstimatedMinutes, &acc.ActualMinutes)
+	if err != nil {
+		return acc, fmt.Errorf("querying estimate accuracy: %w", err)
+	}
+	return acc, nil
+}
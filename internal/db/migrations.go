@@ -1,22 +1,35 @@
 package db
 
-import "fmt"
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
 
-// migrate runs database migrations.
-func (s *SQLite) migrate() error {
-	query := `
-		CREATE TABLE IF NOT EXISTS tasks (
+// tasksColumnsSQL is the tasks table definition.
+const tasksColumnsSQL = `(
 			id              INTEGER PRIMARY KEY AUTOINCREMENT,
 			description     TEXT NOT NULL,
-			category        TEXT CHECK(category IN ('deep', 'shallow')),
+			category        TEXT,
 			scheduled_date  DATE NOT NULL,
 			scheduled_start TIME NOT NULL,
 			scheduled_end   TIME NOT NULL,
-			status          TEXT DEFAULT 'scheduled' CHECK(status IN ('scheduled', 'postponed', 'cancelled')),
+			status          TEXT DEFAULT 'scheduled' CHECK(status IN ('scheduled', 'postponed', 'cancelled', 'missed')),
 			outcome         TEXT CHECK(outcome IN ('on_time', 'over', 'under')),
+			energy          TEXT CHECK(energy IN ('high', 'medium', 'low')),
 			postponed_from  INTEGER REFERENCES tasks(id),
-			created_at      DATETIME DEFAULT CURRENT_TIMESTAMP
-		);
+			external_ref    TEXT,
+			owner           TEXT NOT NULL DEFAULT '',
+			created_at      DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at      TEXT,
+			version         INTEGER NOT NULL DEFAULT 1,
+			uid             TEXT NOT NULL DEFAULT (lower(hex(randomblob(16))))
+		)`
+
+// migrate runs database migrations.
+func (s *SQLite) migrate() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS tasks ` + tasksColumnsSQL + `;
 
 		CREATE INDEX IF NOT EXISTS idx_tasks_scheduled ON tasks(scheduled_date);
 		CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
@@ -26,5 +39,251 @@ func (s *SQLite) migrate() error {
 		return fmt.Errorf("creating tasks table: %w", err)
 	}
 
+	// Databases created before the energy column existed
+	if err := s.addColumnIfMissing("tasks", "energy", "TEXT CHECK(energy IN ('high', 'medium', 'low'))"); err != nil {
+		return err
+	}
+
+	// Databases created before the external_ref column existed
+	if err := s.addColumnIfMissing("tasks", "external_ref", "TEXT"); err != nil {
+		return err
+	}
+
+	// Databases created before the missed status, custom categories or uids
+	// existed
+	if err := s.relaxTaskChecks(); err != nil {
+		return err
+	}
+
+	// Databases created before rows were stamped for replication
+	if err := s.addColumnIfMissing("tasks", "updated_at", "TEXT"); err != nil {
+		return err
+	}
+
+	// Databases created before tasks had owners
+	if err := s.addColumnIfMissing("tasks", "owner", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+
+	// The stamping triggers watch the owner column, so they come after it
+	if err := s.migrateUpdatedAt(); err != nil {
+		return err
+	}
+
+	// Databases created before writes checked the version they read
+	if err := s.addColumnIfMissing("tasks", "version", "INTEGER NOT NULL DEFAULT 1"); err != nil {
+		return err
+	}
+	if err := s.migrateVersion(); err != nil {
+		return err
+	}
+
+	// Listing a date range by status, and scheduled tasks by time, stay
+	// index lookups on databases with years of tasks
+	if _, err := s.db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_tasks_date_status ON tasks(scheduled_date, status);
+		CREATE INDEX IF NOT EXISTS idx_tasks_status_start ON tasks(status, scheduled_start);
+	`); err != nil {
+		return fmt.Errorf("creating listing indexes: %w", err)
+	}
+
+	// One task per external reference, so importers and sync can upsert
+	if _, err := s.db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_external_ref ON tasks(external_ref)`); err != nil {
+		return fmt.Errorf("creating external_ref index: %w", err)
+	}
+
+	// Replica merges pair rows by uid, since ids are only unique per machine
+	if _, err := s.db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_uid ON tasks(uid)`); err != nil {
+		return fmt.Errorf("creating uid index: %w", err)
+	}
+
+	if err := s.migrateRevisions(); err != nil {
+		return err
+	}
+
+	if err := s.migrateBacklog(); err != nil {
+		return err
+	}
+
+	if err := s.migrateTaskNotes(); err != nil {
+		return err
+	}
+
+	if err := s.migrateDependencies(); err != nil {
+		return err
+	}
+
+	if err := s.migrateObjectives(); err != nil {
+		return err
+	}
+
+	if err := s.migrateTaskActuals(); err != nil {
+		return err
+	}
+
+	return s.migrateDailyStats()
+}
+
+// relaxTaskChecks rebuilds the tasks table when its status CHECK predates
+// the missed status, its category CHECK predates custom categories or it has
+// no uid column, since SQLite can neither alter a constraint in place nor
+// add a column with a random default. The dropped indexes and triggers are
+// recreated by the rest of migrate.
+//
+// Rows that predate uids get one derived from their id, which is how
+// replica merges paired rows before, so two machines that already synced
+// still agree on which rows are the same task.
+func (s *SQLite) relaxTaskChecks() error {
+	var schema string
+	if err := s.db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'tasks'`).Scan(&schema); err != nil {
+		return fmt.Errorf("reading tasks schema: %w", err)
+	}
+	hasUID, err := s.hasColumn("tasks", "uid")
+	if err != nil {
+		return err
+	}
+	if strings.Contains(schema, "'missed'") && !strings.Contains(schema, "CHECK(category") && hasUID {
+		return nil
+	}
+
+	columns := `id, description, category, scheduled_date, scheduled_start, scheduled_end,
+		status, outcome, energy, postponed_from, external_ref, created_at`
+	hasUpdatedAt, err := s.hasColumn("tasks", "updated_at")
+	if err != nil {
+		return err
+	}
+	if hasUpdatedAt {
+		columns += ", updated_at"
+	}
+	hasOwner, err := s.hasColumn("tasks", "owner")
+	if err != nil {
+		return err
+	}
+	if hasOwner {
+		columns += ", owner"
+	}
+	hasVersion, err := s.hasColumn("tasks", "version")
+	if err != nil {
+		return err
+	}
+	if hasVersion {
+		columns += ", version"
+	}
+	values := columns + ", uid"
+	if !hasUID {
+		values = columns + ", 'id:' || id"
+	}
+	query := `
+		CREATE TABLE tasks_new ` + tasksColumnsSQL + `;
+		INSERT INTO tasks_new (` + columns + `, uid) SELECT ` + values + ` FROM tasks;
+		DROP TABLE tasks;
+		ALTER TABLE tasks_new RENAME TO tasks;
+		CREATE INDEX IF NOT EXISTS idx_tasks_scheduled ON tasks(scheduled_date);
+		CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
+	`
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("rebuilding tasks table: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// updatedAtSQL is the current time as stored in tasks.updated_at. The fixed
+// width UTC format keeps the column ordered as text.
+const updatedAtSQL = `strftime('%Y-%m-%dT%H:%M:%fZ', 'now')`
+
+// migrateUpdatedAt creates the triggers that stamp updated_at on every
+// write, and stamps older rows with the epoch so they count as synced.
+// Writes that set updated_at themselves, like a replica merge, keep it. The
+// update trigger is recreated every time, since databases from before owners
+// have one that ignores owner changes.
+func (s *SQLite) migrateUpdatedAt() error {
+	query := `
+		UPDATE tasks SET updated_at = '1970-01-01T00:00:00.000Z' WHERE updated_at IS NULL;
+
+		CREATE TRIGGER IF NOT EXISTS tasks_touch_insert AFTER INSERT ON tasks
+		WHEN NEW.updated_at IS NULL BEGIN
+			UPDATE tasks SET updated_at = ` + updatedAtSQL + ` WHERE id = NEW.id;
+		END;
+
+		DROP TRIGGER IF EXISTS tasks_touch_update;
+		CREATE TRIGGER tasks_touch_update
+		AFTER UPDATE OF description, category, scheduled_date, scheduled_start, scheduled_end,
+		                status, outcome, energy, postponed_from, external_ref, owner ON tasks
+		WHEN NEW.updated_at IS OLD.updated_at BEGIN
+			UPDATE tasks SET updated_at = ` + updatedAtSQL + ` WHERE id = NEW.id;
+		END;
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating updated_at triggers: %w", err)
+	}
+	return nil
+}
+
+// migrateVersion creates the trigger that increments a task's version on
+// every write, so a writer can tell the task changed since it read it. The
+// column list leaves out updated_at and version, which the triggers set
+// themselves.
+func (s *SQLite) migrateVersion() error {
+	query := `
+		CREATE TRIGGER IF NOT EXISTS tasks_version_update
+		AFTER UPDATE OF description, category, scheduled_date, scheduled_start, scheduled_end,
+		                status, outcome, energy, postponed_from, external_ref, owner ON tasks
+		WHEN NEW.version IS OLD.version BEGIN
+			UPDATE tasks SET version = OLD.version + 1 WHERE id = NEW.id;
+		END;
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating version trigger: %w", err)
+	}
 	return nil
 }
+
+// addColumnIfMissing adds a column to an existing table unless it is already there.
+func (s *SQLite) addColumnIfMissing(table, column, definition string) error {
+	ok, err := s.hasColumn(table, column)
+	if err != nil || ok {
+		return err
+	}
+	if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("adding %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+// hasColumn reports whether table has the named column.
+func (s *SQLite) hasColumn(table, column string) (bool, error) {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, fmt.Errorf("reading %s columns: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return false, fmt.Errorf("scanning %s columns: %w", table, err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("iterating %s columns: %w", table, err)
+	}
+	return false, nil
+}
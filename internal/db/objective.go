@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// migrateObjectives creates the objectives table and task_objectives, which
+// attaches tasks to them. Like notes, the attachments live apart from tasks
+// so replication and exports of the tasks table are unchanged.
+func (s *SQLite) migrateObjectives() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS objectives (
+			id             INTEGER PRIMARY KEY AUTOINCREMENT,
+			title          TEXT NOT NULL,
+			quarter        TEXT NOT NULL,
+			target_minutes INTEGER NOT NULL DEFAULT 0,
+			created_at     TEXT NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_objectives_quarter ON objectives(quarter);
+
+		CREATE TABLE IF NOT EXISTS task_objectives (
+			task_id      INTEGER PRIMARY KEY REFERENCES tasks(id),
+			objective_id INTEGER NOT NULL REFERENCES objectives(id)
+		);
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating objectives: %w", err)
+	}
+	return nil
+}
+
+// CreateObjective stores o and sets its ID.
+func (s *SQLite) CreateObjective(ctx context.Context, o *task.Objective) error {
+	if o.Title == "" {
+		return task.ErrEmptyDescription
+	}
+	if _, _, err := task.QuarterRange(o.Quarter, time.UTC); err != nil {
+		return err
+	}
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = s.clock.Now()
+	}
+	result, err := s.db.ExecContext(ctx, `INSERT INTO objectives (title, quarter, target_minutes, created_at) VALUES (?, ?, ?, ?)`,
+		o.Title, o.Quarter, o.TargetMinutes, o.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("inserting objective: %w", err)
+	}
+	if o.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("getting objective id: %w", err)
+	}
+	return nil
+}
+
+// ListObjectives returns the objectives of quarter, or of every quarter
+// when it is empty, oldest first.
+func (s *SQLite) ListObjectives(ctx context.Context, quarter string) ([]*task.Objective, error) {
+	query := `SELECT id, title, quarter, target_minutes, created_at FROM objectives`
+	var args []any
+	if quarter != "" {
+		query += ` WHERE quarter = ?`
+		args = append(args, quarter)
+	}
+	rows, err := s.db.QueryContext(ctx, query+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying objectives: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*task.Objective
+	for rows.Next() {
+		var (
+			o         task.Objective
+			createdAt string
+		)
+		if err := rows.Scan(&o.ID, &o.Title, &o.Quarter, &o.TargetMinutes, &createdAt); err != nil {
+			return nil, fmt.Errorf("scanning objective: %w", err)
+		}
+		if o.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+			return nil, fmt.Errorf("parsing objective time: %w", err)
+		}
+		result = append(result, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating objectives: %w", err)
+	}
+	return result, nil
+}
+
+// AttachTask attaches a task to an objective, replacing the one it was
+// attached to. An objectiveID of 0 detaches the task.
+func (s *SQLite) AttachTask(ctx context.Context, taskID, objectiveID int64) error {
+	if objectiveID == 0 {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM task_objectives WHERE task_id = ?`, taskID); err != nil {
+			return fmt.Errorf("detaching task: %w", err)
+		}
+		return nil
+	}
+	t, err := getTask(ctx, s.db, taskID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return fmt.Errorf("task %d not found", taskID)
+	}
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM objectives WHERE id = ?)`, objectiveID).Scan(&exists); err != nil {
+		return fmt.Errorf("querying objective: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("objective %d not found", objectiveID)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO task_objectives (task_id, objective_id) VALUES (?, ?)
+		ON CONFLICT(task_id) DO UPDATE SET objective_id = excluded.objective_id`,
+		taskID, objectiveID)
+	if err != nil {
+		return fmt.Errorf("attaching task: %w", err)
+	}
+	return nil
+}
+
+// TaskObjectives returns the objective of each of the given tasks.
+func (s *SQLite) TaskObjectives(ctx context.Context, ids []int64) (map[int64]int64, error) {
+	attached := make(map[int64]int64)
+	if len(ids) == 0 {
+		return attached, nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	query := `SELECT task_id, objective_id FROM task_objectives WHERE task_id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying task objectives: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var taskID, objectiveID int64
+		if err := rows.Scan(&taskID, &objectiveID); err != nil {
+			return nil, fmt.Errorf("scanning task objective: %w", err)
+		}
+		attached[taskID] = objectiveID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating task objectives: %w", err)
+	}
+	return attached, nil
+}
@@ -0,0 +1,375 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// replicaColumns are the tasks columns copied by a replica merge.
+const replicaColumns = `uid, description, category, scheduled_date, scheduled_start, scheduled_end,
+	status, outcome, energy, postponed_from, external_ref, owner, created_at, updated_at`
+
+// replicaValuesSQL selects replicaColumns from a remote row r. Task ids
+// differ between machines, so postponed_from points at the local copy of
+// the original task.
+const replicaValuesSQL = `r.uid, r.description, r.category, r.scheduled_date, r.scheduled_start, r.scheduled_end,
+	r.status, r.outcome, r.energy, ` + localIDSQL + `, r.external_ref, r.owner, r.created_at, r.updated_at`
+
+// localIDSQL is the local id of the task r.postponed_from names remotely.
+const localIDSQL = `(SELECT o.id FROM main.tasks o JOIN remote.tasks ro ON ro.uid = o.uid WHERE ro.id = r.postponed_from)`
+
+// pairsSQL pairs every remote task (rid) with its local copy (lid), which is
+// the row with the same uid or, failing that, the same external reference.
+// lid is NULL for tasks only the remote has.
+const pairsSQL = `pairs AS (
+	SELECT r.id AS rid, COALESCE(
+		(SELECT l.id FROM main.tasks l WHERE l.uid = r.uid),
+		(SELECT l.id FROM main.tasks l WHERE l.external_ref = r.external_ref)) AS lid
+	FROM remote.tasks r)`
+
+// rowDiffersSQL compares the local (l) and remote (r) copies of a task.
+const rowDiffersSQL = `(l.description IS NOT r.description OR l.category IS NOT r.category
+	OR l.scheduled_date IS NOT r.scheduled_date OR l.scheduled_start IS NOT r.scheduled_start
+	OR l.scheduled_end IS NOT r.scheduled_end OR l.status IS NOT r.status
+	OR l.outcome IS NOT r.outcome OR l.energy IS NOT r.energy
+	OR l.postponed_from IS NOT ` + localIDSQL + ` OR l.external_ref IS NOT r.external_ref
+	OR l.owner IS NOT r.owner)`
+
+// Resolution decides which copy of a row changed on both sides is kept when
+// merging a replica.
+type Resolution int
+
+const (
+	ResolveNone   Resolution = iota // Report conflicts and merge nothing
+	ResolveLocal                    // Keep the local rows, merge the rest
+	ResolveRemote                   // Take the remote rows
+)
+
+// MergeResult reports what MergeFrom did.
+type MergeResult struct {
+	Applied   int          // Remote rows copied into the database
+	Conflicts []*task.Task // Local copies of rows changed on both sides
+}
+
+// Snapshot writes a consistent copy of the database to path, which must not
+// exist yet.
+func (s *SQLite) Snapshot(ctx context.Context, path string) error {
+	if _, err := s.db.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return nil
+}
+
+// SyncMark returns the current time as row stamps are written. Rows written
+// after it compare greater, which is what ChangedSince and MergeFrom use to
+// tell what changed since a sync.
+func (s *SQLite) SyncMark(ctx context.Context) (string, error) {
+	var mark string
+	if err := s.db.QueryRowContext(ctx, `SELECT `+updatedAtSQL).Scan(&mark); err != nil {
+		return "", fmt.Errorf("reading sync mark: %w", err)
+	}
+	return mark, nil
+}
+
+// ChangedSince returns the number of tasks written after mark. An empty
+// mark counts every task.
+func (s *SQLite) ChangedSince(ctx context.Context, mark string) (int, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM tasks WHERE updated_at > ?`, mark).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting changed tasks: %w", err)
+	}
+	return n, nil
+}
+
+// MergeFrom copies the rows of the database at path that changed there
+// since the sync at mark. Rows are paired by uid, since each machine numbers
+// its tasks itself, and rows only the remote has get a new local id. A
+// remote row is taken when the local one is missing or unchanged since mark,
+// or when the remote one changed too. Rows changed on both sides with
+// different contents are conflicts: with ResolveNone they are returned and
+// nothing is merged, otherwise resolve picks the side that wins. Remote stamps come from another machine's
+// clock, so a conflict can go unnoticed if the clocks disagree by more than
+// the time between the two edits.
+func (s *SQLite) MergeFrom(ctx context.Context, path, mark string, resolve Resolution) (MergeResult, error) {
+	conn, detach, err := s.attachReplica(ctx, path)
+	if err != nil {
+		return MergeResult{}, err
+	}
+	defer detach()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return MergeResult{}, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	conflictIDs, err := queryIDs(ctx, tx, `WITH `+pairsSQL+`
+		SELECT l.id FROM pairs p
+		JOIN main.tasks l ON l.id = p.lid JOIN remote.tasks r ON r.id = p.rid
+		WHERE l.updated_at > ? AND r.updated_at > ? AND `+rowDiffersSQL+`
+		ORDER BY l.id`, mark, mark)
+	if err != nil {
+		return MergeResult{}, err
+	}
+
+	var res MergeResult
+	conflicted := make(map[int64]bool, len(conflictIDs))
+	for _, id := range conflictIDs {
+		conflicted[id] = true
+		if resolve != ResolveNone {
+			continue
+		}
+		t, err := getTask(ctx, tx, id)
+		if err != nil {
+			return MergeResult{}, err
+		}
+		res.Conflicts = append(res.Conflicts, t)
+	}
+	if len(res.Conflicts) > 0 {
+		return res, nil
+	}
+
+	// Ordered by remote id, so an original task is inserted before the
+	// copies postponed from it
+	changed, err := queryPairs(ctx, tx, `WITH `+pairsSQL+`
+		SELECT p.rid, p.lid FROM pairs p
+		JOIN remote.tasks r ON r.id = p.rid LEFT JOIN main.tasks l ON l.id = p.lid
+		WHERE l.id IS NULL
+		   OR ((l.updated_at IS NOT r.updated_at OR `+rowDiffersSQL+`) AND (l.updated_at <= ? OR r.updated_at > ?))
+		ORDER BY p.rid`, mark, mark)
+	if err != nil {
+		return MergeResult{}, err
+	}
+	for _, p := range changed {
+		if p.local == 0 {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO main.tasks (`+replicaColumns+`)
+				SELECT `+replicaValuesSQL+` FROM remote.tasks r WHERE r.id = ?`, p.remote)
+		} else {
+			if conflicted[p.local] && resolve == ResolveLocal {
+				continue
+			}
+			_, err = tx.ExecContext(ctx, `
+				UPDATE main.tasks SET (`+replicaColumns+`) =
+					(SELECT `+replicaValuesSQL+` FROM remote.tasks r WHERE r.id = ?)
+				WHERE id = ?`, p.remote, p.local)
+		}
+		if err != nil {
+			return MergeResult{}, fmt.Errorf("merging remote task %d: %w", p.remote, err)
+		}
+		res.Applied++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return MergeResult{}, fmt.Errorf("committing transaction: %w", err)
+	}
+	return res, nil
+}
+
+// queryIDs runs a query returning a single id column.
+func queryIDs(ctx context.Context, q queryer, query string, args ...any) ([]int64, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying replica rows: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning replica rows: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating replica rows: %w", err)
+	}
+	return ids, nil
+}
+
+// pair is a remote task id and the id of its local copy, zero if none.
+type pair struct {
+	remote, local int64
+}
+
+// queryPairs runs a query returning a remote id and a nullable local id.
+func queryPairs(ctx context.Context, q queryer, query string, args ...any) ([]pair, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying replica rows: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var pairs []pair
+	for rows.Next() {
+		var (
+			p     pair
+			local sql.NullInt64
+		)
+		if err := rows.Scan(&p.remote, &local); err != nil {
+			return nil, fmt.Errorf("scanning replica rows: %w", err)
+		}
+		p.local = local.Int64
+		pairs = append(pairs, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating replica rows: %w", err)
+	}
+	return pairs, nil
+}
+
+// attachReplica brings the database at path to the current schema and
+// attaches it as "remote" to a connection of s. detach undoes both.
+func (s *SQLite) attachReplica(ctx context.Context, path string) (conn *sql.Conn, detach func(), err error) {
+	remote, err := New(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening replica: %w", err)
+	}
+	if err := remote.Close(); err != nil {
+		return nil, nil, fmt.Errorf("closing replica: %w", err)
+	}
+
+	if conn, err = s.db.Conn(ctx); err != nil {
+		return nil, nil, fmt.Errorf("getting connection: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, `ATTACH DATABASE ? AS remote`, path); err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("attaching replica: %w", err)
+	}
+	return conn, func() {
+		_, _ = conn.ExecContext(context.Background(), `DETACH DATABASE remote`)
+		_ = conn.Close()
+	}, nil
+}
+
+// replicaTablesSQL reads the tables replicated besides tasks, with task ids
+// replaced by uids so two machines holding the same rows read the same.
+// Revisions and daily stats are left out: the triggers on tasks write them
+// again when a merge writes the tasks.
+var replicaTablesSQL = []string{
+	`SELECT t.uid, n.note, n.updated_at FROM task_notes n JOIN tasks t ON t.id = n.task_id ORDER BY t.uid`,
+	`SELECT t.uid, b.uid, d.created_at FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id JOIN tasks b ON b.id = d.blocked_by ORDER BY t.uid, b.uid`,
+	`SELECT id, title, quarter, target_minutes, created_at FROM objectives ORDER BY id`,
+	`SELECT t.uid, o.objective_id FROM task_objectives o JOIN tasks t ON t.id = o.task_id ORDER BY t.uid`,
+	`SELECT t.uid, a.category, a.description_key, a.estimated_minutes, a.actual_minutes, a.recorded_at
+		FROM task_actuals a JOIN tasks t ON t.id = a.task_id ORDER BY t.uid`,
+	`SELECT id, title, notes, external_ref, created_at FROM backlog ORDER BY id`,
+}
+
+// TablesDigest fingerprints the notes, dependencies, objectives, estimates
+// and backlog, which a replica merge does not merge row by row. Databases
+// holding the same rows get the same digest; it is empty when the tables
+// are.
+func (s *SQLite) TablesDigest(ctx context.Context) (string, error) {
+	h := sha256.New()
+	empty := true
+	for i, query := range replicaTablesSQL {
+		rows, err := s.db.QueryContext(ctx, query)
+		if err != nil {
+			return "", fmt.Errorf("reading replicated tables: %w", err)
+		}
+		cols, err := rows.Columns()
+		if err != nil {
+			_ = rows.Close()
+			return "", fmt.Errorf("reading replicated tables: %w", err)
+		}
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for j := range values {
+			ptrs[j] = &values[j]
+		}
+		for rows.Next() {
+			if err := rows.Scan(ptrs...); err != nil {
+				_ = rows.Close()
+				return "", fmt.Errorf("scanning replicated tables: %w", err)
+			}
+			empty = false
+			fmt.Fprintf(h, "%d", i)
+			for _, v := range values {
+				fmt.Fprintf(h, "\x1f%T:%v", v, v)
+			}
+			h.Write([]byte{'\n'})
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return "", fmt.Errorf("iterating replicated tables: %w", err)
+		}
+	}
+	if empty {
+		return "", nil
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// TablesDigestOf returns the TablesDigest of the database at path.
+func TablesDigestOf(ctx context.Context, path string) (string, error) {
+	remote, err := New(path)
+	if err != nil {
+		return "", fmt.Errorf("opening replica: %w", err)
+	}
+	defer func() { _ = remote.Close() }()
+	return remote.TablesDigest(ctx)
+}
+
+// AdoptTables replaces the tables covered by TablesDigest with those of the
+// database at path, in one transaction. Rows are attached to the local copy
+// of their task, so the tasks must be merged first; rows of tasks the
+// database does not have are dropped.
+func (s *SQLite) AdoptTables(ctx context.Context, path string) error {
+	conn, detach, err := s.attachReplica(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer detach()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		DELETE FROM main.task_notes;
+		INSERT INTO main.task_notes (task_id, note, updated_at)
+		SELECT l.id, n.note, n.updated_at FROM remote.task_notes n
+		JOIN remote.tasks r ON r.id = n.task_id JOIN main.tasks l ON l.uid = r.uid;
+
+		DELETE FROM main.task_dependencies;
+		INSERT INTO main.task_dependencies (task_id, blocked_by, created_at)
+		SELECT l.id, lb.id, d.created_at FROM remote.task_dependencies d
+		JOIN remote.tasks r ON r.id = d.task_id JOIN main.tasks l ON l.uid = r.uid
+		JOIN remote.tasks rb ON rb.id = d.blocked_by JOIN main.tasks lb ON lb.uid = rb.uid;
+
+		DELETE FROM main.task_objectives;
+		DELETE FROM main.objectives;
+		INSERT INTO main.objectives (id, title, quarter, target_minutes, created_at)
+		SELECT id, title, quarter, target_minutes, created_at FROM remote.objectives;
+		INSERT INTO main.task_objectives (task_id, objective_id)
+		SELECT l.id, o.objective_id FROM remote.task_objectives o
+		JOIN remote.tasks r ON r.id = o.task_id JOIN main.tasks l ON l.uid = r.uid;
+
+		DELETE FROM main.task_actuals;
+		INSERT INTO main.task_actuals (task_id, category, description_key, estimated_minutes, actual_minutes, recorded_at)
+		SELECT l.id, a.category, a.description_key, a.estimated_minutes, a.actual_minutes, a.recorded_at
+		FROM remote.task_actuals a JOIN remote.tasks r ON r.id = a.task_id JOIN main.tasks l ON l.uid = r.uid;
+
+		DELETE FROM main.backlog;
+		INSERT INTO main.backlog (id, title, notes, external_ref, created_at)
+		SELECT id, title, notes, external_ref, created_at FROM remote.backlog;
+	`
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("copying replicated tables: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// migrateTaskNotes creates the task_notes table. Notes live apart from
+// tasks so replication and exports of the tasks table are unchanged.
+func (s *SQLite) migrateTaskNotes() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_notes (
+			task_id    INTEGER PRIMARY KEY REFERENCES tasks(id),
+			note       TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		);
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating task_notes: %w", err)
+	}
+	return nil
+}
+
+// SaveReview sets the outcome and note of every entry in one transaction.
+func (s *SQLite) SaveReview(ctx context.Context, entries []task.ReviewEntry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.clock.Now().UTC().Format(time.RFC3339)
+	for _, e := range entries {
+		result, err := tx.ExecContext(ctx, setOutcomeSQL, e.Outcome, e.ID)
+		if err != nil {
+			return fmt.Errorf("setting task outcome: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return fmt.Errorf("task %d not found", e.ID)
+		}
+
+		if e.Note == "" {
+			_, err = tx.ExecContext(ctx, `DELETE FROM task_notes WHERE task_id = ?`, e.ID)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO task_notes (task_id, note, updated_at) VALUES (?, ?, ?)
+				ON CONFLICT(task_id) DO UPDATE SET note = excluded.note, updated_at = excluded.updated_at`,
+				e.ID, e.Note, now)
+		}
+		if err != nil {
+			return fmt.Errorf("saving task note: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// TaskNotes returns the notes of the tasks with the given IDs.
+func (s *SQLite) TaskNotes(ctx context.Context, ids []int64) (map[int64]string, error) {
+	notes := make(map[int64]string)
+	if len(ids) == 0 {
+		return notes, nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	query := `SELECT task_id, note FROM task_notes WHERE task_id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying task notes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			id   int64
+			note string
+		)
+		if err := rows.Scan(&id, &note); err != nil {
+			return nil, fmt.Errorf("scanning task note: %w", err)
+		}
+		notes[id] = note
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating task notes: %w", err)
+	}
+	return notes, nil
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// revisionTimeLayout is how task_revisions.changed_at is stored, matching
+// updatedAtSQL.
+const revisionTimeLayout = "2006-01-02T15:04:05.000Z"
+
+// migrateRevisions creates the task_revisions table and the trigger that
+// records a task's old description and schedule whenever one of them
+// changes, whichever code path wrote it.
+func (s *SQLite) migrateRevisions() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_revisions (
+			id              INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id         INTEGER NOT NULL REFERENCES tasks(id),
+			changed_at      TEXT NOT NULL,
+			description     TEXT NOT NULL,
+			scheduled_date  DATE NOT NULL,
+			scheduled_start TIME NOT NULL,
+			scheduled_end   TIME NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_task_revisions_task ON task_revisions(task_id);
+
+		CREATE TRIGGER IF NOT EXISTS task_revisions_record
+		AFTER UPDATE OF description, scheduled_date, scheduled_start, scheduled_end ON tasks
+		WHEN OLD.description IS NOT NEW.description
+		  OR date(OLD.scheduled_date) IS NOT date(NEW.scheduled_date)
+		  OR OLD.scheduled_start IS NOT NEW.scheduled_start
+		  OR OLD.scheduled_end IS NOT NEW.scheduled_end BEGIN
+			INSERT INTO task_revisions (task_id, changed_at, description, scheduled_date, scheduled_start, scheduled_end)
+			VALUES (OLD.id, ` + updatedAtSQL + `, OLD.description, date(OLD.scheduled_date), OLD.scheduled_start, OLD.scheduled_end);
+		END;
+	`
+	if _, err := s.db.Exec(query); err != nil {
+		return fmt.Errorf("creating task_revisions: %w", err)
+	}
+	return nil
+}
+
+// ListTaskRevisions returns the task's earlier descriptions and schedules,
+// newest first.
+func (s *SQLite) ListTaskRevisions(ctx context.Context, taskID int64) ([]task.Revision, error) {
+	query := `
+		SELECT id, task_id, changed_at, description, scheduled_date, scheduled_start, scheduled_end
+		FROM task_revisions
+		WHERE task_id = ?
+		ORDER BY id DESC
+	`
+	rows, err := s.db.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("querying task revisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []task.Revision
+	for rows.Next() {
+		var (
+			rev       task.Revision
+			changedAt string
+			date      string
+		)
+		if err := rows.Scan(&rev.ID, &rev.TaskID, &changedAt, &rev.Description, &date,
+			&rev.ScheduledStart, &rev.ScheduledEnd); err != nil {
+			return nil, fmt.Errorf("scanning task revisions: %w", err)
+		}
+		if rev.ChangedAt, err = time.Parse(revisionTimeLayout, changedAt); err != nil {
+			return nil, fmt.Errorf("parsing revision time: %w", err)
+		}
+		if rev.ScheduledDate, err = parseDate(date); err != nil {
+			return nil, fmt.Errorf("parsing revision date: %w", err)
+		}
+		result = append(result, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating task revisions: %w", err)
+	}
+	return result, nil
+}
@@ -4,23 +4,59 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	_ "modernc.org/sqlite" // SQLite driver
 
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
 // SQLite implements task.Repository using SQLite.
 type SQLite struct {
-	db *sql.DB
+	db    *sql.DB
+	stmts *statements
+	clock clock.Clock // Stamps created_at for new tasks
+
+	allowOverlaps bool // Store overlapping blocks instead of rejecting them
+}
+
+// Option configures a SQLite repository.
+type Option func(*SQLite)
+
+// WithClock sets the clock used for task timestamps.
+func WithClock(c clock.Clock) Option {
+	return func(s *SQLite) {
+		s.clock = clock.OrSystem(c)
+	}
+}
+
+// WithAllowOverlaps makes the repository store overlapping time blocks
+// instead of returning ErrTimeBlockOverlap.
+func WithAllowOverlaps(allow bool) Option {
+	return func(s *SQLite) {
+		s.allowOverlaps = allow
+	}
+}
+
+// busyTimeoutMS is how long a connection waits for another writer, e.g. a
+// second sancho process, before failing with SQLITE_BUSY.
+const busyTimeoutMS = 5000
+
+// dsn returns the connection string for path. WAL lets readers carry on
+// while another process writes, the busy timeout queues concurrent writers
+// instead of failing them, and immediate transactions take the write lock
+// up front so two writers never deadlock upgrading a read lock.
+func dsn(path string) string {
+	return fmt.Sprintf("%s?_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)&_txlock=immediate", path, busyTimeoutMS)
 }
 
 // New creates a new SQLite repository and runs migrations.
-func New(path string) (*SQLite, error) {
-	db, err := sql.Open("sqlite", path)
+func New(path string, opts ...Option) (*SQLite, error) {
+	db, err := sql.Open("sqlite", dsn(path))
 	if err != nil {
 		return nil, fmt.Errorf("opening database: %w", err)
 	}
@@ -29,30 +65,61 @@ func New(path string) (*SQLite, error) {
 		return nil, fmt.Errorf("connecting to database: %w", err)
 	}
 
-	s := &SQLite{db: db}
+	s := &SQLite{db: db, clock: clock.System}
+	for _, opt := range opts {
+		opt(s)
+	}
 	if err := s.migrate(); err != nil {
 		return nil, fmt.Errorf("running migrations: %w", err)
 	}
+	if s.stmts, err = prepareStatements(db); err != nil {
+		return nil, err
+	}
 
 	return s, nil
 }
 
+// AllowsOverlaps reports whether overlapping time blocks are stored.
+func (s *SQLite) AllowsOverlaps() bool {
+	return s.allowOverlaps
+}
+
+// DataVersion returns a value that changes whenever a task is written, by
+// this process or another one sharing the database file. The sum of the
+// task versions grows on every write, even two in the same millisecond.
+func (s *SQLite) DataVersion(ctx context.Context) (string, error) {
+	var version string
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(updated_at), '') || '/' || COUNT(*) || '/' || COALESCE(SUM(version), 0) FROM tasks`).Scan(&version)
+	if err != nil {
+		return "", fmt.Errorf("reading data version: %w", err)
+	}
+	return version, nil
+}
+
+// createdAt returns the task's creation time, stamping it from the
+// repository clock when the caller left it unset.
+func (s *SQLite) createdAt(t *task.Task) time.Time {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.clock.Now()
+	}
+	return t.CreatedAt
+}
+
 // CreateTask adds a new task to the repository.
 // Returns ErrTimeBlockOverlap if the task overlaps with an existing scheduled task.
 func (s *SQLite) CreateTask(ctx context.Context, t *task.Task) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
 	// Check for overlapping tasks
-	if err := s.checkOverlap(ctx, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd); err != nil {
+	if err := s.checkOverlapTx(ctx, tx, t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd); err != nil {
 		return err
 	}
 
-	query := `
-		INSERT INTO tasks (
-			description, category, scheduled_date, scheduled_start, scheduled_end,
-			status, outcome, postponed_from, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
-
-	result, err := s.db.ExecContext(ctx, query,
+	result, err := tx.StmtContext(ctx, s.stmts.insertTask).ExecContext(ctx,
 		t.Description,
 		t.Category,
 		t.ScheduledDate.Format("2006-01-02"),
@@ -60,8 +127,11 @@ func (s *SQLite) CreateTask(ctx context.Context, t *task.Task) error {
 		t.ScheduledEnd,
 		t.Status,
 		t.Outcome,
+		nullEnergy(t.Energy),
 		t.PostponedFrom,
-		t.CreatedAt.Format(time.RFC3339),
+		nullExternalRef(t.ExternalRef),
+		t.Owner,
+		s.createdAt(t).Format(time.RFC3339),
 	)
 	if err != nil {
 		return fmt.Errorf("inserting task: %w", err)
@@ -71,6 +141,10 @@ func (s *SQLite) CreateTask(ctx context.Context, t *task.Task) error {
 	if err != nil {
 		return fmt.Errorf("getting last insert id: %w", err)
 	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
 	t.ID = id
 
 	return nil
@@ -78,9 +152,19 @@ func (s *SQLite) CreateTask(ctx context.Context, t *task.Task) error {
 
 // GetTask retrieves a task by ID.
 func (s *SQLite) GetTask(ctx context.Context, id int64) (*task.Task, error) {
+	return getTask(ctx, s.db, id)
+}
+
+// rowQueryer is implemented by both *sql.DB and *sql.Tx.
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// getTask retrieves a task by ID, returning nil if it does not exist.
+func getTask(ctx context.Context, q rowQueryer, id int64) (*task.Task, error) {
 	query := `
 		SELECT id, description, category, scheduled_date, scheduled_start, scheduled_end,
-		       status, outcome, postponed_from, created_at
+		       status, outcome, energy, postponed_from, external_ref, owner, created_at, version
 		FROM tasks
 		WHERE id = ?
 	`
@@ -90,10 +174,13 @@ func (s *SQLite) GetTask(ctx context.Context, id int64) (*task.Task, error) {
 		scheduledDate string
 		createdAt     string
 		outcome       sql.NullString
+		energy        sql.NullString
 		postponedFrom sql.NullInt64
+		externalRef   sql.NullString
+		version       int64
 	)
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	err := q.QueryRowContext(ctx, query, id).Scan(
 		&t.ID,
 		&t.Description,
 		&t.Category,
@@ -102,8 +189,12 @@ func (s *SQLite) GetTask(ctx context.Context, id int64) (*task.Task, error) {
 		&t.ScheduledEnd,
 		&t.Status,
 		&outcome,
+		&energy,
 		&postponedFrom,
+		&externalRef,
+		&t.Owner,
 		&createdAt,
+		&version,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -126,6 +217,9 @@ func (s *SQLite) GetTask(ctx context.Context, id int64) (*task.Task, error) {
 		o := task.Outcome(outcome.String)
 		t.Outcome = &o
 	}
+	t.Energy = task.Energy(energy.String)
+	t.ExternalRef = externalRefFromDB(externalRef)
+	t.Version = version
 
 	if postponedFrom.Valid {
 		t.PostponedFrom = &postponedFrom.Int64
@@ -134,116 +228,240 @@ func (s *SQLite) GetTask(ctx context.Context, id int64) (*task.Task, error) {
 	return &t, nil
 }
 
-// CancelTask marks a task as cancelled.
-func (s *SQLite) CancelTask(ctx context.Context, id int64) error {
-	query := `UPDATE tasks SET status = ? WHERE id = ?`
+// GetTaskByExternalRef retrieves the task linked to an external reference.
+// Returns nil without an error when no task has the reference.
+func (s *SQLite) GetTaskByExternalRef(ctx context.Context, ref task.ExternalRef) (*task.Task, error) {
+	if ref.IsZero() {
+		return nil, task.ErrMissingExternalRef
+	}
 
-	result, err := s.db.ExecContext(ctx, query, task.StatusCancelled, id)
+	var id int64
+	err := s.db.QueryRowContext(ctx, `SELECT id FROM tasks WHERE external_ref = ?`, ref.String()).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("cancelling task: %w", err)
+		return nil, fmt.Errorf("querying task by external reference: %w", err)
 	}
+	return s.GetTask(ctx, id)
+}
 
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		return fmt.Errorf("task %d not found", id)
+// UpsertTask creates the task, or updates the task with the same external
+// reference in place, keeping its status and outcome. Reports whether a new
+// task was created. Returns ErrTimeBlockOverlap if the task conflicts with
+// another scheduled task, and ErrTaskChanged if t carries a Version and the
+// stored task was written since.
+func (s *SQLite) UpsertTask(ctx context.Context, t *task.Task) (bool, error) {
+	if t.ExternalRef.IsZero() {
+		return false, task.ErrMissingExternalRef
 	}
 
-	return nil
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var (
+		id     int64
+		status task.Status
+	)
+	err = tx.QueryRowContext(ctx, `SELECT id, status FROM tasks WHERE external_ref = ?`, t.ExternalRef.String()).Scan(&id, &status)
+	created := err == sql.ErrNoRows
+	if err != nil && !created {
+		return false, fmt.Errorf("querying task by external reference: %w", err)
+	}
+	if !created {
+		if err := checkVersion(ctx, tx, id, t.Version); err != nil {
+			return false, err
+		}
+	}
+
+	if created || status == task.StatusScheduled {
+		if err := s.findOverlap(ctx, tx, t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd, id); err != nil {
+			return false, err
+		}
+	}
+
+	if created {
+		result, err := tx.StmtContext(ctx, s.stmts.insertTask).ExecContext(ctx,
+			t.Description,
+			t.Category,
+			t.ScheduledDate.Format("2006-01-02"),
+			t.ScheduledStart,
+			t.ScheduledEnd,
+			t.Status,
+			t.Outcome,
+			nullEnergy(t.Energy),
+			t.PostponedFrom,
+			nullExternalRef(t.ExternalRef),
+			t.Owner,
+			s.createdAt(t).Format(time.RFC3339),
+		)
+		if err != nil {
+			return false, fmt.Errorf("inserting task: %w", err)
+		}
+		if id, err = result.LastInsertId(); err != nil {
+			return false, fmt.Errorf("getting last insert id: %w", err)
+		}
+	} else {
+		query := `
+			UPDATE tasks
+			SET description = ?, scheduled_date = ?, scheduled_start = ?, scheduled_end = ?, energy = ?
+			WHERE id = ?
+		`
+		_, err := tx.ExecContext(ctx, query,
+			t.Description,
+			t.ScheduledDate.Format("2006-01-02"),
+			t.ScheduledStart,
+			t.ScheduledEnd,
+			nullEnergy(t.Energy),
+			id,
+		)
+		if err != nil {
+			return false, fmt.Errorf("updating task: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing transaction: %w", err)
+	}
+	t.ID = id
+	return created, nil
 }
 
-// SetTaskOutcome sets the outcome of a task during review.
-func (s *SQLite) SetTaskOutcome(ctx context.Context, id int64, outcome task.Outcome) error {
-	query := `UPDATE tasks SET outcome = ? WHERE id = ?`
+// CancelTask marks a task as cancelled. Returns ErrTaskChanged if version
+// is set and the task was written since.
+func (s *SQLite) CancelTask(ctx context.Context, id, version int64) error {
+	query := `UPDATE tasks SET status = ? WHERE id = ?` + versionSQL
 
-	result, err := s.db.ExecContext(ctx, query, outcome, id)
+	result, err := s.db.ExecContext(ctx, query, task.StatusCancelled, id, version, version)
 	if err != nil {
-		return fmt.Errorf("setting task outcome: %w", err)
+		return fmt.Errorf("cancelling task: %w", err)
 	}
+	return s.checkWritten(ctx, result, id)
+}
+
+// versionSQL limits a single-task UPDATE to the version the caller read. It
+// takes the version twice; zero matches any version.
+const versionSQL = ` AND (? = 0 OR version = ?)`
 
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		return fmt.Errorf("task %d not found", id)
+// checkWritten tells why a single-task UPDATE limited by versionSQL matched
+// no row: ErrTaskChanged if the task exists at another version, not found
+// otherwise.
+func (s *SQLite) checkWritten(ctx context.Context, result sql.Result, id int64) error {
+	if rows, _ := result.RowsAffected(); rows > 0 {
+		return nil
+	}
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM tasks WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("reading task: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: task %d", task.ErrTaskChanged, id)
 	}
+	return fmt.Errorf("task %d: %w", id, task.ErrTaskNotFound)
+}
 
+// MarkTasksMissed sets the given scheduled tasks to missed in one transaction.
+func (s *SQLite) MarkTasksMissed(ctx context.Context, ids []int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, `UPDATE tasks SET status = ? WHERE id = ? AND status = ?`,
+			task.StatusMissed, id, task.StatusScheduled); err != nil {
+			return fmt.Errorf("marking task missed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
 	return nil
 }
 
-// ListTasksByDateRange returns all tasks scheduled within the date range (inclusive).
-func (s *SQLite) ListTasksByDateRange(ctx context.Context, start, end time.Time) ([]*task.Task, error) {
-	query := `
-		SELECT id, description, category, scheduled_date, scheduled_start, scheduled_end,
-		       status, outcome, postponed_from, created_at
-		FROM tasks
-		WHERE scheduled_date >= ? AND scheduled_date <= ?
-		ORDER BY scheduled_date, scheduled_start
+// setOutcomeSQL sets a task's outcome, scheduling it again if it was
+// missed and not rescheduled.
+const setOutcomeSQL = `
+		UPDATE tasks
+		SET outcome = ?,
+		    status = CASE
+		        WHEN status = 'missed' AND NOT EXISTS (SELECT 1 FROM tasks c WHERE c.postponed_from = tasks.id)
+		        THEN 'scheduled' ELSE status END
+		WHERE id = ?
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, start.Format("2006-01-02"), end.Format("2006-01-02"))
+// SetTaskOutcome sets the outcome of a task during review. A missed task
+// that was not rescheduled becomes scheduled again, since it did happen.
+// Returns ErrTaskChanged if version is set and the task was written since.
+func (s *SQLite) SetTaskOutcome(ctx context.Context, id int64, outcome task.Outcome, version int64) error {
+	result, err := s.db.ExecContext(ctx, setOutcomeSQL+versionSQL, outcome, id, version, version)
 	if err != nil {
-		return nil, fmt.Errorf("querying tasks: %w", err)
+		return fmt.Errorf("setting task outcome: %w", err)
 	}
-	defer func() { _ = rows.Close() }()
-
-	var tasks []*task.Task
-	for rows.Next() {
-		var (
-			t             task.Task
-			scheduledDate string
-			createdAt     string
-			outcome       sql.NullString
-			postponedFrom sql.NullInt64
-		)
-
-		err := rows.Scan(
-			&t.ID,
-			&t.Description,
-			&t.Category,
-			&scheduledDate,
-			&t.ScheduledStart,
-			&t.ScheduledEnd,
-			&t.Status,
-			&outcome,
-			&postponedFrom,
-			&createdAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("scanning task: %w", err)
-		}
+	return s.checkWritten(ctx, result, id)
+}
 
-		t.ScheduledDate, err = parseDate(scheduledDate)
-		if err != nil {
-			return nil, fmt.Errorf("parsing scheduled date: %w", err)
-		}
+// SetTaskEnergy sets the energy level of a task. An empty level clears it.
+// Returns ErrTaskChanged if version is set and the task was written since.
+func (s *SQLite) SetTaskEnergy(ctx context.Context, id int64, energy task.Energy, version int64) error {
+	if _, err := task.ParseEnergy(string(energy)); err != nil {
+		return err
+	}
 
-		t.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
-		if err != nil {
-			return nil, fmt.Errorf("parsing created at: %w", err)
-		}
+	query := `UPDATE tasks SET energy = ? WHERE id = ?` + versionSQL
 
-		if outcome.Valid {
-			o := task.Outcome(outcome.String)
-			t.Outcome = &o
-		}
+	result, err := s.db.ExecContext(ctx, query, nullEnergy(energy), id, version, version)
+	if err != nil {
+		return fmt.Errorf("setting task energy: %w", err)
+	}
+	return s.checkWritten(ctx, result, id)
+}
 
-		if postponedFrom.Valid {
-			t.PostponedFrom = &postponedFrom.Int64
-		}
+// nullEnergy stores an unset energy level as NULL.
+func nullEnergy(e task.Energy) any {
+	if e == "" {
+		return nil
+	}
+	return string(e)
+}
 
-		tasks = append(tasks, &t)
+// nullExternalRef stores an unset external reference as NULL.
+func nullExternalRef(r task.ExternalRef) any {
+	if r.IsZero() {
+		return nil
 	}
+	return r.String()
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating tasks: %w", err)
+// externalRefFromDB parses a stored external reference. Malformed values are
+// treated as unset rather than failing the whole query.
+func externalRefFromDB(ns sql.NullString) task.ExternalRef {
+	ref, err := task.ParseExternalRef(ns.String)
+	if err != nil {
+		return task.ExternalRef{}
 	}
+	return ref
+}
 
-	return tasks, nil
+// ListTasksByDateRange returns all tasks scheduled within the date range (inclusive).
+func (s *SQLite) ListTasksByDateRange(ctx context.Context, start, end time.Time) ([]*task.Task, error) {
+	rows, err := s.stmts.listRange.QueryContext(ctx, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("querying tasks: %w", err)
+	}
+	return scanTasks(rows)
 }
 
 // ListAllTasks returns all tasks ordered by ID.
 func (s *SQLite) ListAllTasks(ctx context.Context) ([]*task.Task, error) {
 	query := `
 		SELECT id, description, category, scheduled_date, scheduled_start, scheduled_end,
-		       status, outcome, postponed_from, created_at
+		       status, outcome, energy, postponed_from, external_ref, owner, created_at, version
 		FROM tasks
 		ORDER BY id
 	`
@@ -252,6 +470,65 @@ func (s *SQLite) ListAllTasks(ctx context.Context) ([]*task.Task, error) {
 	if err != nil {
 		return nil, fmt.Errorf("querying tasks: %w", err)
 	}
+	return scanTasks(rows)
+}
+
+// ListTasks returns one page of the tasks matching filter, ordered by date,
+// start time and ID. A limit of zero or less returns every remaining task.
+func (s *SQLite) ListTasks(ctx context.Context, filter task.TaskFilter, limit, offset int) ([]*task.Task, error) {
+	var (
+		where []string
+		args  []any
+	)
+	if !filter.From.IsZero() {
+		where = append(where, "scheduled_date >= ?")
+		args = append(args, filter.From.Format("2006-01-02"))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "scheduled_date <= ?")
+		args = append(args, filter.To.Format("2006-01-02"))
+	}
+	if len(filter.Statuses) > 0 {
+		where = append(where, "status IN (?"+strings.Repeat(", ?", len(filter.Statuses)-1)+")")
+		for _, st := range filter.Statuses {
+			args = append(args, st)
+		}
+	}
+	if filter.Category != "" {
+		where = append(where, "category = ?")
+		args = append(args, filter.Category)
+	}
+	if filter.Query != "" {
+		// instr on lowered text, since LIKE would treat % and _ as wildcards
+		where = append(where, "instr(lower(description), lower(?)) > 0")
+		args = append(args, filter.Query)
+	}
+
+	query := `
+		SELECT id, description, category, scheduled_date, scheduled_start, scheduled_end,
+		       status, outcome, energy, postponed_from, external_ref, owner, created_at, version
+		FROM tasks`
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += `
+		ORDER BY scheduled_date, scheduled_start, id
+		LIMIT ? OFFSET ?
+	`
+	if limit <= 0 {
+		limit = -1 // No limit
+	}
+	args = append(args, limit, max(offset, 0))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying tasks: %w", err)
+	}
+	return scanTasks(rows)
+}
+
+// scanTasks reads the task rows of a listing query and closes them.
+func scanTasks(rows *sql.Rows) ([]*task.Task, error) {
 	defer func() { _ = rows.Close() }()
 
 	var tasks []*task.Task
@@ -261,7 +538,10 @@ func (s *SQLite) ListAllTasks(ctx context.Context) ([]*task.Task, error) {
 			scheduledDate string
 			createdAt     string
 			outcome       sql.NullString
+			energy        sql.NullString
 			postponedFrom sql.NullInt64
+			externalRef   sql.NullString
+			version       int64
 		)
 
 		err := rows.Scan(
@@ -273,8 +553,12 @@ func (s *SQLite) ListAllTasks(ctx context.Context) ([]*task.Task, error) {
 			&t.ScheduledEnd,
 			&t.Status,
 			&outcome,
+			&energy,
 			&postponedFrom,
+			&externalRef,
+			&t.Owner,
 			&createdAt,
+			&version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scanning task: %w", err)
@@ -294,6 +578,9 @@ func (s *SQLite) ListAllTasks(ctx context.Context) ([]*task.Task, error) {
 			o := task.Outcome(outcome.String)
 			t.Outcome = &o
 		}
+		t.Energy = task.Energy(energy.String)
+		t.ExternalRef = externalRefFromDB(externalRef)
+		t.Version = version
 
 		if postponedFrom.Valid {
 			t.PostponedFrom = &postponedFrom.Int64
@@ -317,7 +604,7 @@ func (s *SQLite) CreateTasks(ctx context.Context, tasks []*task.Task) error {
 	}
 
 	// First, check for overlaps between the new tasks themselves
-	if err := checkBatchOverlap(tasks); err != nil {
+	if err := s.checkBatchOverlap(tasks); err != nil {
 		return err
 	}
 
@@ -329,24 +616,12 @@ func (s *SQLite) CreateTasks(ctx context.Context, tasks []*task.Task) error {
 
 	// Check for overlaps with existing tasks in the database
 	for _, t := range tasks {
-		if err := checkOverlapTx(ctx, tx, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd); err != nil {
+		if err := s.checkOverlapTx(ctx, tx, t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd); err != nil {
 			return err
 		}
 	}
 
-	query := `
-		INSERT INTO tasks (
-			description, category, scheduled_date, scheduled_start, scheduled_end,
-			status, outcome, postponed_from, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
-
-	stmt, err := tx.PrepareContext(ctx, query)
-	if err != nil {
-		return fmt.Errorf("preparing statement: %w", err)
-	}
-	defer func() { _ = stmt.Close() }()
-
+	stmt := tx.StmtContext(ctx, s.stmts.insertTask)
 	for _, t := range tasks {
 		result, err := stmt.ExecContext(ctx,
 			t.Description,
@@ -356,8 +631,11 @@ func (s *SQLite) CreateTasks(ctx context.Context, tasks []*task.Task) error {
 			t.ScheduledEnd,
 			t.Status,
 			t.Outcome,
+			nullEnergy(t.Energy),
 			t.PostponedFrom,
-			t.CreatedAt.Format(time.RFC3339),
+			nullExternalRef(t.ExternalRef),
+			t.Owner,
+			s.createdAt(t).Format(time.RFC3339),
 		)
 		if err != nil {
 			return fmt.Errorf("inserting task %q: %w", t.Description, err)
@@ -379,31 +657,29 @@ func (s *SQLite) CreateTasks(ctx context.Context, tasks []*task.Task) error {
 
 // PostponeTask atomically marks the original task as postponed and creates a new task.
 // Returns the newly created task with PostponedFrom pointing to the original.
-// Returns ErrTimeBlockOverlap if the new time slot overlaps with an existing task.
-func (s *SQLite) PostponeTask(ctx context.Context, taskID int64, newDate time.Time, newStart, newEnd string) (*task.Task, error) {
+// Returns ErrTimeBlockOverlap if the new time slot overlaps with an existing task,
+// and ErrTaskChanged if version is set and the task was written since.
+func (s *SQLite) PostponeTask(ctx context.Context, taskID int64, newDate time.Time, newStart, newEnd string, version int64) (*task.Task, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("beginning transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	// Check for overlapping tasks at the new time slot
-	if err := checkOverlapTx(ctx, tx, newDate, newStart, newEnd); err != nil {
-		return nil, err
-	}
-
 	// Get the original task
 	var (
 		original      task.Task
 		scheduledDate string
 		createdAt     string
 		outcome       sql.NullString
+		energy        sql.NullString
 		postponedFrom sql.NullInt64
+		externalRef   sql.NullString
 	)
 
 	query := `
 		SELECT id, description, category, scheduled_date, scheduled_start, scheduled_end,
-		       status, outcome, postponed_from, created_at
+		       status, outcome, energy, postponed_from, external_ref, owner, created_at
 		FROM tasks
 		WHERE id = ?
 	`
@@ -416,30 +692,36 @@ func (s *SQLite) PostponeTask(ctx context.Context, taskID int64, newDate time.Ti
 		&original.ScheduledEnd,
 		&original.Status,
 		&outcome,
+		&energy,
 		&postponedFrom,
+		&externalRef,
+		&original.Owner,
 		&createdAt,
 	)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("task %d not found", taskID)
+		return nil, fmt.Errorf("task %d: %w", taskID, task.ErrTaskNotFound)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("querying original task: %w", err)
 	}
+	if err := checkVersion(ctx, tx, taskID, version); err != nil {
+		return nil, err
+	}
+
+	// Check for overlapping tasks of the same owner at the new time slot
+	if err := s.checkOverlapTx(ctx, tx, original.Owner, newDate, newStart, newEnd); err != nil {
+		return nil, err
+	}
 
-	// Mark original as postponed
-	_, err = tx.ExecContext(ctx, `UPDATE tasks SET status = ? WHERE id = ?`, task.StatusPostponed, taskID)
+	// Mark original as postponed; its external reference moves to the new task
+	_, err = tx.ExecContext(ctx, `UPDATE tasks SET status = ?, external_ref = NULL WHERE id = ?`, task.StatusPostponed, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("marking task as postponed: %w", err)
 	}
 
 	// Create new task with reference to original
-	insertQuery := `
-		INSERT INTO tasks (
-			description, category, scheduled_date, scheduled_start, scheduled_end,
-			status, outcome, postponed_from, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
-	result, err := tx.ExecContext(ctx, insertQuery,
+	postponedAt := s.clock.Now()
+	result, err := tx.StmtContext(ctx, s.stmts.insertTask).ExecContext(ctx,
 		original.Description,
 		original.Category,
 		newDate.Format("2006-01-02"),
@@ -447,8 +729,11 @@ func (s *SQLite) PostponeTask(ctx context.Context, taskID int64, newDate time.Ti
 		newEnd,
 		task.StatusScheduled,
 		nil, // new task has no outcome yet
+		nullEnergy(task.Energy(energy.String)),
 		taskID,
-		time.Now().Format(time.RFC3339),
+		externalRef,
+		original.Owner,
+		postponedAt.Format(time.RFC3339),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("inserting new task: %w", err)
@@ -472,8 +757,11 @@ func (s *SQLite) PostponeTask(ctx context.Context, taskID int64, newDate time.Ti
 		ScheduledStart: newStart,
 		ScheduledEnd:   newEnd,
 		Status:         task.StatusScheduled,
+		Energy:         task.Energy(energy.String),
 		PostponedFrom:  &taskID,
-		CreatedAt:      time.Now(),
+		ExternalRef:    externalRefFromDB(externalRef),
+		Owner:          original.Owner,
+		CreatedAt:      postponedAt,
 	}
 
 	return newTask, nil
@@ -481,55 +769,61 @@ func (s *SQLite) PostponeTask(ctx context.Context, taskID int64, newDate time.Ti
 
 // Close releases database resources.
 func (s *SQLite) Close() error {
-	return s.db.Close()
+	return errors.Join(s.stmts.close(), s.db.Close())
 }
 
 // UpdateTask updates a task's scheduled times in place.
-// Returns ErrTimeBlockOverlap if the new times conflict with another task.
-func (s *SQLite) UpdateTask(ctx context.Context, id int64, newStart, newEnd string) error {
+// Returns ErrTimeBlockOverlap if the new times conflict with another task,
+// and ErrTaskChanged if version is set and the task was written since.
+func (s *SQLite) UpdateTask(ctx context.Context, id int64, newStart, newEnd string, version int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
 	// Get existing task to find date
-	t, err := s.GetTask(ctx, id)
+	t, err := getTask(ctx, tx, id)
 	if err != nil {
 		return fmt.Errorf("getting task: %w", err)
 	}
 	if t == nil {
-		return fmt.Errorf("task %d not found", id)
+		return fmt.Errorf("task %d: %w", id, task.ErrTaskNotFound)
+	}
+	if err := checkVersion(ctx, tx, id, version); err != nil {
+		return err
 	}
 
 	// Check for overlaps (excluding self)
-	if err := s.checkOverlapExcluding(ctx, t.ScheduledDate, newStart, newEnd, id); err != nil {
+	if err := s.findOverlap(ctx, tx, t.Owner, t.ScheduledDate, newStart, newEnd, id); err != nil {
 		return err
 	}
 
 	query := `UPDATE tasks SET scheduled_start = ?, scheduled_end = ? WHERE id = ?`
-	_, err = s.db.ExecContext(ctx, query, newStart, newEnd, id)
-	if err != nil {
+	if _, err := tx.ExecContext(ctx, query, newStart, newEnd, id); err != nil {
 		return fmt.Errorf("updating task times: %w", err)
 	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
 	return nil
 }
 
 // UpdateTaskDescription updates a task description in place.
-func (s *SQLite) UpdateTaskDescription(ctx context.Context, id int64, description string) error {
+// Returns ErrTaskChanged if version is set and the task was written since.
+func (s *SQLite) UpdateTaskDescription(ctx context.Context, id int64, description string, version int64) error {
 	description = strings.TrimSpace(description)
 	if description == "" {
 		return task.ErrEmptyDescription
 	}
 
-	t, err := s.GetTask(ctx, id)
-	if err != nil {
-		return fmt.Errorf("getting task: %w", err)
-	}
-	if t == nil {
-		return fmt.Errorf("task %d not found", id)
-	}
-
-	query := `UPDATE tasks SET description = ? WHERE id = ?`
-	_, err = s.db.ExecContext(ctx, query, description, id)
+	query := `UPDATE tasks SET description = ? WHERE id = ?` + versionSQL
+	result, err := s.db.ExecContext(ctx, query, description, id, version, version)
 	if err != nil {
 		return fmt.Errorf("updating task description: %w", err)
 	}
-	return nil
+	return s.checkWritten(ctx, result, id)
 }
 
 // parseDate parses a date string in various formats SQLite might return.
@@ -564,96 +858,73 @@ func parseDate(s string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unrecognized date format: %s", s)
 }
 
-// checkOverlap checks if a time block overlaps with existing tasks on the same day.
-// It uses the database connection (either *sql.DB or *sql.Tx) to query.
-// Two time ranges overlap if: start1 < end2 AND start2 < end1
-func (s *SQLite) checkOverlap(ctx context.Context, date time.Time, start, end string) error {
-	query := `
-		SELECT id, scheduled_start, scheduled_end, description
-		FROM tasks
-		WHERE scheduled_date = ?
-		  AND status = ?
-		  AND scheduled_start < ?
-		  AND scheduled_end > ?
-		LIMIT 1
-	`
-
-	var (
-		id          int64
-		existStart  string
-		existEnd    string
-		description string
-	)
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
 
-	err := s.db.QueryRowContext(ctx, query,
-		date.Format("2006-01-02"),
-		task.StatusScheduled,
-		end,
-		start,
-	).Scan(&id, &existStart, &existEnd, &description)
+// checkOverlapTx checks inside tx if a time block overlaps with existing
+// tasks of the same owner. Tasks on the day before and after are included,
+// since overnight blocks run into the next morning.
+func (s *SQLite) checkOverlapTx(ctx context.Context, tx *sql.Tx, owner string, date time.Time, start, end string) error {
+	return s.findOverlap(ctx, tx, owner, date, start, end, 0)
+}
 
-	if err == sql.ErrNoRows {
-		return nil // No overlap
+// findOverlap returns ErrTimeBlockOverlap for the first scheduled task of
+// owner that conflicts with the block, ignoring the task with excludeID. It
+// reads inside tx unless tx is nil, and never fails when overlaps are allowed.
+func (s *SQLite) findOverlap(ctx context.Context, tx *sql.Tx, owner string, date time.Time, start, end string, excludeID int64) error {
+	if s.allowOverlaps {
+		return nil
 	}
+	rows, err := inTx(ctx, tx, s.stmts.overlapCandidates).QueryContext(ctx,
+		date.AddDate(0, 0, -1).Format("2006-01-02"),
+		date.AddDate(0, 0, 1).Format("2006-01-02"),
+		task.StatusScheduled,
+		excludeID,
+		owner,
+	)
 	if err != nil {
 		return fmt.Errorf("checking overlap: %w", err)
 	}
+	defer func() { _ = rows.Close() }()
 
-	return fmt.Errorf("%w: conflicts with #%d %q (%s-%s)",
-		task.ErrTimeBlockOverlap, id, description, existStart, existEnd)
-}
-
-// checkOverlapTx is like checkOverlap but uses a transaction.
-func checkOverlapTx(ctx context.Context, tx *sql.Tx, date time.Time, start, end string) error {
-	query := `
-		SELECT id, scheduled_start, scheduled_end, description
-		FROM tasks
-		WHERE scheduled_date = ?
-		  AND status = ?
-		  AND scheduled_start < ?
-		  AND scheduled_end > ?
-		LIMIT 1
-	`
-
-	var (
-		id          int64
-		existStart  string
-		existEnd    string
-		description string
-	)
-
-	err := tx.QueryRowContext(ctx, query,
-		date.Format("2006-01-02"),
-		task.StatusScheduled,
-		end,
-		start,
-	).Scan(&id, &existStart, &existEnd, &description)
-
-	if err == sql.ErrNoRows {
-		return nil // No overlap
+	for rows.Next() {
+		var (
+			id          int64
+			dateStr     string
+			existStart  string
+			existEnd    string
+			description string
+		)
+		if err := rows.Scan(&id, &dateStr, &existStart, &existEnd, &description); err != nil {
+			return fmt.Errorf("checking overlap: %w", err)
+		}
+		existDate, err := parseDate(dateStr)
+		if err != nil {
+			return fmt.Errorf("checking overlap: %w", err)
+		}
+		if task.BlocksOverlap(date, start, end, existDate, existStart, existEnd) {
+			return fmt.Errorf("%w: conflicts with #%d %q (%s-%s)",
+				task.ErrTimeBlockOverlap, id, description, existStart, existEnd)
+		}
 	}
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return fmt.Errorf("checking overlap: %w", err)
 	}
-
-	return fmt.Errorf("%w: conflicts with #%d %q (%s-%s)",
-		task.ErrTimeBlockOverlap, id, description, existStart, existEnd)
+	return nil
 }
 
 // checkBatchOverlap checks for overlaps between tasks in the same batch.
-// Two time ranges overlap if: start1 < end2 AND start2 < end1
-func checkBatchOverlap(tasks []*task.Task) error {
+func (s *SQLite) checkBatchOverlap(tasks []*task.Task) error {
+	if s.allowOverlaps {
+		return nil
+	}
 	for i := 0; i < len(tasks); i++ {
 		for j := i + 1; j < len(tasks); j++ {
 			t1, t2 := tasks[i], tasks[j]
 
-			// Only check tasks on the same day
-			if !t1.ScheduledDate.Equal(t2.ScheduledDate) {
-				continue
-			}
-
-			// Check if time ranges overlap
-			if task.TimesOverlap(t1.ScheduledStart, t1.ScheduledEnd, t2.ScheduledStart, t2.ScheduledEnd) {
+			if t1.OverlapsWith(t2) {
 				return fmt.Errorf("%w: %q (%s-%s) conflicts with %q (%s-%s)",
 					task.ErrTimeBlockOverlap,
 					t1.Description, t1.ScheduledStart, t1.ScheduledEnd,
@@ -665,86 +936,127 @@ func checkBatchOverlap(tasks []*task.Task) error {
 	return nil
 }
 
-// checkOverlapExcluding checks for overlaps with existing tasks, excluding a specific task ID.
-// Used for update operations where the task being updated should not conflict with itself.
-func (s *SQLite) checkOverlapExcluding(ctx context.Context, date time.Time, start, end string, excludeID int64) error {
-	query := `
-		SELECT id, scheduled_start, scheduled_end, description
-		FROM tasks
-		WHERE scheduled_date = ?
-		  AND status = ?
-		  AND id != ?
-		  AND scheduled_start < ?
-		  AND scheduled_end > ?
-		LIMIT 1
-	`
-
-	var (
-		id          int64
-		existStart  string
-		existEnd    string
-		description string
-	)
+// BatchUpdateTaskTimes moves tasks to new times, on date or each update's
+// own Date, in a single transaction. It validates that the final state has
+// no overlaps before applying changes, so tasks can swap places or shift
+// across days together. This is used for move operations where multiple
+// tasks shift positions simultaneously.
+func (s *SQLite) BatchUpdateTaskTimes(ctx context.Context, date time.Time, updates []task.TaskTimeUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
 
-	err := s.db.QueryRowContext(ctx, query,
-		date.Format("2006-01-02"),
-		task.StatusScheduled,
-		excludeID,
-		end,
-		start,
-	).Scan(&id, &existStart, &existEnd, &description)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
 
-	if err == sql.ErrNoRows {
-		return nil // No overlap
+	// 1. Check that no task changed since it was read, and the final state
+	// for overlaps unless they are allowed
+	for _, u := range updates {
+		if err := checkVersion(ctx, tx, u.ID, u.Version); err != nil {
+			return err
+		}
+	}
+	if !s.allowOverlaps {
+		if err := checkMovedOverlap(ctx, tx, date, updates); err != nil {
+			return err
+		}
 	}
+
+	// 2. Execute all updates
+	updateQuery := `UPDATE tasks SET scheduled_date = ?, scheduled_start = ?, scheduled_end = ? WHERE id = ?`
+	stmt, err := tx.PrepareContext(ctx, updateQuery)
 	if err != nil {
-		return fmt.Errorf("checking overlap: %w", err)
+		return fmt.Errorf("preparing statement: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, u := range updates {
+		if _, err := stmt.ExecContext(ctx, u.DateOr(date).Format("2006-01-02"), u.NewStart, u.NewEnd, u.ID); err != nil {
+			return fmt.Errorf("updating task %d: %w", u.ID, err)
+		}
+	}
+
+	// 3. Commit
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
 	}
 
-	return fmt.Errorf("%w: conflicts with #%d %q (%s-%s)",
-		task.ErrTimeBlockOverlap, id, description, existStart, existEnd)
+	return nil
 }
 
-// BatchUpdateTaskTimes updates multiple tasks' times atomically in a single transaction.
-// It validates that the final state has no overlaps before applying changes.
-// This is used for move operations where multiple tasks shift positions simultaneously.
-func (s *SQLite) BatchUpdateTaskTimes(ctx context.Context, date time.Time, updates []task.TaskTimeUpdate) error {
-	if len(updates) == 0 {
+// checkVersion returns ErrTaskChanged when the task was written, or
+// deleted, since it was read at version. A zero version skips the check.
+func checkVersion(ctx context.Context, q rowQueryer, id, version int64) error {
+	if version == 0 {
 		return nil
 	}
+	var current int64
+	err := q.QueryRowContext(ctx, `SELECT version FROM tasks WHERE id = ?`, id).Scan(&current)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("reading task version: %w", err)
+	}
+	if err == sql.ErrNoRows || current != version {
+		return fmt.Errorf("%w: task %d", task.ErrTaskChanged, id)
+	}
+	return nil
+}
 
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("beginning transaction: %w", err)
+// checkMovedOverlap checks the moved blocks against each other and against
+// the scheduled tasks of the same owner that stay put on their days and the
+// days either side.
+func checkMovedOverlap(ctx context.Context, tx *sql.Tx, date time.Time, updates []task.TaskTimeUpdate) error {
+	type block struct {
+		id          int64
+		description string
+		owner       string
+		date        time.Time
+		start, end  string
+	}
+	moved := make(map[int64]bool, len(updates))
+	var first, last time.Time
+	for i, u := range updates {
+		moved[u.ID] = true
+		d := u.DateOr(date)
+		if i == 0 || d.Before(first) {
+			first = d
+		}
+		if i == 0 || d.After(last) {
+			last = d
+		}
 	}
-	defer func() { _ = tx.Rollback() }()
 
-	// 1. Get all scheduled tasks on this date
-	query := `
-		SELECT id, description, scheduled_start, scheduled_end
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, description, owner, scheduled_date, scheduled_start, scheduled_end
 		FROM tasks
-		WHERE scheduled_date = ?
+		WHERE scheduled_date >= ? AND scheduled_date <= ?
 		  AND status = ?
-	`
-	rows, err := tx.QueryContext(ctx, query, date.Format("2006-01-02"), task.StatusScheduled)
+	`, first.AddDate(0, 0, -1).Format("2006-01-02"), last.AddDate(0, 0, 1).Format("2006-01-02"), task.StatusScheduled)
 	if err != nil {
 		return fmt.Errorf("querying tasks: %w", err)
 	}
-
-	type taskTime struct {
-		id          int64
-		description string
-		start       string
-		end         string
-	}
-	var currentTasks []taskTime
+	movedRows := make(map[int64]block, len(updates))
+	var staying []block
 	for rows.Next() {
-		var t taskTime
-		if err := rows.Scan(&t.id, &t.description, &t.start, &t.end); err != nil {
+		var (
+			b       block
+			dateStr string
+		)
+		if err := rows.Scan(&b.id, &b.description, &b.owner, &dateStr, &b.start, &b.end); err != nil {
 			_ = rows.Close()
 			return fmt.Errorf("scanning task: %w", err)
 		}
-		currentTasks = append(currentTasks, t)
+		if moved[b.id] {
+			movedRows[b.id] = b
+			continue
+		}
+		if b.date, err = parseDate(dateStr); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		staying = append(staying, b)
 	}
 	if err := rows.Close(); err != nil {
 		return fmt.Errorf("closing rows: %w", err)
@@ -753,60 +1065,134 @@ func (s *SQLite) BatchUpdateTaskTimes(ctx context.Context, date time.Time, updat
 		return fmt.Errorf("iterating tasks: %w", err)
 	}
 
-	// 2. Build final state by applying updates
-	updateMap := make(map[int64]task.TaskTimeUpdate)
+	var placed []block
 	for _, u := range updates {
-		updateMap[u.ID] = u
-	}
-
-	finalState := make([]taskTime, len(currentTasks))
-	for i, t := range currentTasks {
-		if u, ok := updateMap[t.id]; ok {
-			// Apply the update
-			finalState[i] = taskTime{
-				id:          t.id,
-				description: t.description,
-				start:       u.NewStart,
-				end:         u.NewEnd,
+		b, ok := movedRows[u.ID]
+		if !ok {
+			// Moved in from outside the range, or not scheduled
+			t, err := getTask(ctx, tx, u.ID)
+			if err != nil {
+				return fmt.Errorf("getting task: %w", err)
+			}
+			if t == nil || !t.IsScheduled() {
+				continue
 			}
-		} else {
-			// Keep original times
-			finalState[i] = t
+			b = block{id: u.ID, description: t.Description, owner: t.Owner}
 		}
-	}
-
-	// 3. Check for overlaps in the final state
-	for i := 0; i < len(finalState); i++ {
-		for j := i + 1; j < len(finalState); j++ {
-			t1, t2 := finalState[i], finalState[j]
-			if task.TimesOverlap(t1.start, t1.end, t2.start, t2.end) {
+		b.date, b.start, b.end = u.DateOr(date), u.NewStart, u.NewEnd
+		for _, other := range append(staying, placed...) {
+			if b.owner == other.owner && task.BlocksOverlap(b.date, b.start, b.end, other.date, other.start, other.end) {
 				return fmt.Errorf("%w: %q (%s-%s) conflicts with %q (%s-%s)",
 					task.ErrTimeBlockOverlap,
-					t1.description, t1.start, t1.end,
-					t2.description, t2.start, t2.end,
+					b.description, b.start, b.end,
+					other.description, other.start, other.end,
 				)
 			}
 		}
+		placed = append(placed, b)
+	}
+	return nil
+}
+
+// BatchUpdate cancels, postpones, moves or recategorizes several scheduled
+// tasks in a single transaction. Overlaps are checked once every update is
+// applied, so tasks can swap or shift past each other. Missed tasks can be
+// postponed too; they keep their missed status.
+func (s *SQLite) BatchUpdate(ctx context.Context, updates []task.TaskUpdate) error {
+	if len(updates) == 0 {
+		return nil
 	}
 
-	// 4. Execute all updates
-	updateQuery := `UPDATE tasks SET scheduled_start = ?, scheduled_end = ? WHERE id = ?`
-	stmt, err := tx.PrepareContext(ctx, updateQuery)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("preparing statement: %w", err)
+		return fmt.Errorf("beginning transaction: %w", err)
 	}
-	defer func() { _ = stmt.Close() }()
+	defer func() { _ = tx.Rollback() }()
 
+	// Blocks that end up scheduled, checked against the final state
+	var placed []task.Task
+	postponedAt := s.clock.Now()
 	for _, u := range updates {
-		if _, err := stmt.ExecContext(ctx, u.NewStart, u.NewEnd, u.ID); err != nil {
-			return fmt.Errorf("updating task %d: %w", u.ID, err)
+		t, err := getTask(ctx, tx, u.ID)
+		if err != nil {
+			return fmt.Errorf("getting task: %w", err)
+		}
+		if t == nil {
+			return fmt.Errorf("task %d: %w", u.ID, task.ErrTaskNotFound)
+		}
+		if u.Version != 0 && t.Version != u.Version {
+			return fmt.Errorf("%w: task %d", task.ErrTaskChanged, u.ID)
+		}
+		if !t.IsScheduled() && !(u.Postpone && t.IsMissed()) {
+			return fmt.Errorf("task %d is %s", u.ID, t.Status)
+		}
+
+		switch {
+		case u.Cancel:
+			if _, err := tx.ExecContext(ctx, `UPDATE tasks SET status = ? WHERE id = ?`, task.StatusCancelled, u.ID); err != nil {
+				return fmt.Errorf("cancelling task: %w", err)
+			}
+
+		case u.Postpone:
+			nt := u.Apply(*t)
+			status := task.StatusPostponed
+			if t.IsMissed() {
+				status = task.StatusMissed
+			}
+			if _, err := tx.ExecContext(ctx, `UPDATE tasks SET status = ?, external_ref = NULL WHERE id = ?`, status, u.ID); err != nil {
+				return fmt.Errorf("marking task as postponed: %w", err)
+			}
+			result, err := tx.StmtContext(ctx, s.stmts.insertTask).ExecContext(ctx,
+				nt.Description,
+				nt.Category,
+				nt.ScheduledDate.Format("2006-01-02"),
+				nt.ScheduledStart,
+				nt.ScheduledEnd,
+				task.StatusScheduled,
+				nil, // new task has no outcome yet
+				nullEnergy(nt.Energy),
+				u.ID,
+				nullExternalRef(nt.ExternalRef),
+				nt.Owner,
+				postponedAt.Format(time.RFC3339),
+			)
+			if err != nil {
+				return fmt.Errorf("inserting new task: %w", err)
+			}
+			if nt.ID, err = result.LastInsertId(); err != nil {
+				return fmt.Errorf("getting new task id: %w", err)
+			}
+			placed = append(placed, nt)
+
+		default:
+			nt := u.Apply(*t)
+			query := `
+				UPDATE tasks
+				SET category = ?, scheduled_date = ?, scheduled_start = ?, scheduled_end = ?
+				WHERE id = ?
+			`
+			_, err := tx.ExecContext(ctx, query,
+				nt.Category,
+				nt.ScheduledDate.Format("2006-01-02"),
+				nt.ScheduledStart,
+				nt.ScheduledEnd,
+				u.ID,
+			)
+			if err != nil {
+				return fmt.Errorf("updating task: %w", err)
+			}
+			placed = append(placed, nt)
+		}
+	}
+
+	for _, t := range placed {
+		if err := s.findOverlap(ctx, tx, t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd, t.ID); err != nil {
+			return err
 		}
 	}
 
-	// 5. Commit
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
 	}
-
 	return nil
 }
@@ -2,11 +2,15 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
@@ -260,7 +264,7 @@ func TestCancelTask(t *testing.T) {
 	}
 
 	// Cancel it
-	err = repo.CancelTask(ctx, tsk.ID)
+	err = repo.CancelTask(ctx, tsk.ID, 0)
 	if err != nil {
 		t.Fatalf("CancelTask failed: %v", err)
 	}
@@ -279,7 +283,7 @@ func TestCancelTask_NotFound(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	err := repo.CancelTask(ctx, 9999)
+	err := repo.CancelTask(ctx, 9999, 0)
 	if err == nil {
 		t.Error("expected error for non-existent task")
 	}
@@ -305,7 +309,7 @@ func TestSetTaskOutcome(t *testing.T) {
 	}
 
 	// Set outcome
-	err = repo.SetTaskOutcome(ctx, tsk.ID, task.OutcomeOnTime)
+	err = repo.SetTaskOutcome(ctx, tsk.ID, task.OutcomeOnTime, 0)
 	if err != nil {
 		t.Fatalf("SetTaskOutcome failed: %v", err)
 	}
@@ -327,12 +331,178 @@ func TestSetTaskOutcome_NotFound(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	err := repo.SetTaskOutcome(ctx, 9999, task.OutcomeOver)
+	err := repo.SetTaskOutcome(ctx, 9999, task.OutcomeOver, 0)
 	if err == nil {
 		t.Error("expected error for non-existent task")
 	}
 }
 
+func TestSaveReview(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, start := range []string{"09:00", "11:00"} {
+		tsk := &task.Task{
+			Description:    "Task at " + start,
+			Category:       task.CategoryDeep,
+			ScheduledDate:  time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			ScheduledStart: start,
+			ScheduledEnd:   start[:3] + "30",
+			Status:         task.StatusScheduled,
+		}
+		if err := repo.CreateTask(ctx, tsk); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		ids = append(ids, tsk.ID)
+	}
+
+	err := repo.SaveReview(ctx, []task.ReviewEntry{
+		{ID: ids[0], Outcome: task.OutcomeOver, Note: "Interrupted twice"},
+		{ID: ids[1], Outcome: task.OutcomeOnTime},
+	})
+	if err != nil {
+		t.Fatalf("SaveReview failed: %v", err)
+	}
+	got, err := repo.GetTask(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if got.Outcome == nil || *got.Outcome != task.OutcomeOver {
+		t.Errorf("outcome = %v, want %q", got.Outcome, task.OutcomeOver)
+	}
+	notes, err := repo.TaskNotes(ctx, ids)
+	if err != nil {
+		t.Fatalf("TaskNotes failed: %v", err)
+	}
+	if want := map[int64]string{ids[0]: "Interrupted twice"}; !reflect.DeepEqual(notes, want) {
+		t.Errorf("TaskNotes = %v, want %v", notes, want)
+	}
+
+	// An unknown task rolls back the whole review
+	err = repo.SaveReview(ctx, []task.ReviewEntry{
+		{ID: ids[0], Outcome: task.OutcomeUnder},
+		{ID: 9999, Outcome: task.OutcomeOnTime},
+	})
+	if err == nil {
+		t.Fatal("expected error for non-existent task")
+	}
+	got, _ = repo.GetTask(ctx, ids[0])
+	if *got.Outcome != task.OutcomeOver {
+		t.Errorf("outcome after failed review = %q, want %q", *got.Outcome, task.OutcomeOver)
+	}
+	if notes, _ := repo.TaskNotes(ctx, ids[:1]); notes[ids[0]] != "Interrupted twice" {
+		t.Errorf("note after failed review = %q, want it kept", notes[ids[0]])
+	}
+}
+
+func TestSetTaskEnergy(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	tsk := &task.Task{
+		Description:    "Task with energy",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+		Energy:         task.EnergyLow,
+	}
+	if err := repo.CreateTask(ctx, tsk); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	if err := repo.SetTaskEnergy(ctx, tsk.ID, task.EnergyHigh, 0); err != nil {
+		t.Fatalf("SetTaskEnergy failed: %v", err)
+	}
+	got, err := repo.GetTask(ctx, tsk.ID)
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if got.Energy != task.EnergyHigh {
+		t.Errorf("expected energy %q, got %q", task.EnergyHigh, got.Energy)
+	}
+
+	// Postponed copies keep the energy level
+	newTask, err := repo.PostponeTask(ctx, tsk.ID, time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC), "09:00", "10:00", 0)
+	if err != nil {
+		t.Fatalf("PostponeTask failed: %v", err)
+	}
+	if newTask.Energy != task.EnergyHigh {
+		t.Errorf("expected postponed energy %q, got %q", task.EnergyHigh, newTask.Energy)
+	}
+
+	if err := repo.SetTaskEnergy(ctx, tsk.ID, "", 0); err != nil {
+		t.Fatalf("SetTaskEnergy (clear) failed: %v", err)
+	}
+	got, err = repo.GetTask(ctx, tsk.ID)
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if got.Energy != "" {
+		t.Errorf("expected energy to be cleared, got %q", got.Energy)
+	}
+
+	if err := repo.SetTaskEnergy(ctx, tsk.ID, "extreme", 0); !errors.Is(err, task.ErrInvalidEnergy) {
+		t.Errorf("expected ErrInvalidEnergy, got %v", err)
+	}
+}
+
+func TestMigrate_AddsEnergyColumn(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "old.db")
+
+	// Create a database with the schema from before the energy column
+	old, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	_, err = old.Exec(`
+		CREATE TABLE tasks (
+			id              INTEGER PRIMARY KEY AUTOINCREMENT,
+			description     TEXT NOT NULL,
+			category        TEXT,
+			scheduled_date  DATE NOT NULL,
+			scheduled_start TIME NOT NULL,
+			scheduled_end   TIME NOT NULL,
+			status          TEXT DEFAULT 'scheduled',
+			outcome         TEXT,
+			postponed_from  INTEGER REFERENCES tasks(id),
+			created_at      DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO tasks (description, category, scheduled_date, scheduled_start, scheduled_end, status, created_at)
+		VALUES ('Old task', 'deep', '2025-01-10', '09:00', '10:00', 'scheduled', '2025-01-01T00:00:00Z');
+	`)
+	_ = old.Close()
+	if err != nil {
+		t.Fatalf("creating old schema: %v", err)
+	}
+
+	repo, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	got, err := repo.GetTask(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if got == nil || got.Energy != "" {
+		t.Fatalf("expected old task without energy, got %+v", got)
+	}
+	if err := repo.SetTaskEnergy(context.Background(), 1, task.EnergyMedium, 0); err != nil {
+		t.Fatalf("SetTaskEnergy failed: %v", err)
+	}
+
+	// Re-opening must not try to add the column again
+	again, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("reopening failed: %v", err)
+	}
+	_ = again.Close()
+}
+
 func TestListTasksByDateRange(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -820,7 +990,7 @@ func TestPostponeTask(t *testing.T) {
 
 	// Postpone to next day
 	newDate := time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)
-	newTask, err := repo.PostponeTask(ctx, original.ID, newDate, "14:00", "16:00")
+	newTask, err := repo.PostponeTask(ctx, original.ID, newDate, "14:00", "16:00", 0)
 	if err != nil {
 		t.Fatalf("PostponeTask failed: %v", err)
 	}
@@ -873,12 +1043,53 @@ func TestPostponeTask(t *testing.T) {
 	}
 }
 
+func TestWithClock_StampsTimestamps(t *testing.T) {
+	fixed := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	repo, err := New(filepath.Join(t.TempDir(), "test.db"), WithClock(clock.Fixed(fixed)))
+	if err != nil {
+		t.Fatalf("failed to create test repo: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	ctx := context.Background()
+
+	tsk := &task.Task{
+		Description:    "No timestamp",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(ctx, tsk); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if !tsk.CreatedAt.Equal(fixed) {
+		t.Errorf("CreatedAt: got %v, want %v", tsk.CreatedAt, fixed)
+	}
+
+	postponed, err := repo.PostponeTask(ctx, tsk.ID, time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), "09:00", "10:00", 0)
+	if err != nil {
+		t.Fatalf("PostponeTask failed: %v", err)
+	}
+	if !postponed.CreatedAt.Equal(fixed) {
+		t.Errorf("postponed CreatedAt: got %v, want %v", postponed.CreatedAt, fixed)
+	}
+
+	item := &task.BacklogItem{Title: "No timestamp"}
+	if _, err := repo.UpsertBacklogItem(ctx, item); err != nil {
+		t.Fatalf("UpsertBacklogItem failed: %v", err)
+	}
+	if !item.CreatedAt.Equal(fixed) {
+		t.Errorf("backlog CreatedAt: got %v, want %v", item.CreatedAt, fixed)
+	}
+}
+
 func TestPostponeTask_NotFound(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
 	newDate := time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)
-	_, err := repo.PostponeTask(ctx, 9999, newDate, "14:00", "16:00")
+	_, err := repo.PostponeTask(ctx, 9999, newDate, "14:00", "16:00", 0)
 	if err == nil {
 		t.Error("expected error for non-existent task")
 	}
@@ -904,7 +1115,7 @@ func TestPostponeTask_PreservesCategory(t *testing.T) {
 	}
 
 	newDate := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
-	newTask, err := repo.PostponeTask(ctx, original.ID, newDate, "11:00", "11:30")
+	newTask, err := repo.PostponeTask(ctx, original.ID, newDate, "11:00", "11:30", 0)
 	if err != nil {
 		t.Fatalf("PostponeTask failed: %v", err)
 	}
@@ -987,6 +1198,36 @@ func TestCreateTask_OverlapError(t *testing.T) {
 	}
 }
 
+func TestCreateTask_AllowOverlaps(t *testing.T) {
+	repo, err := New(filepath.Join(t.TempDir(), "test.db"), WithAllowOverlaps(true))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	ctx := context.Background()
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	focus := &task.Task{Description: "Focus", Category: task.CategoryDeep, ScheduledDate: date, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled, CreatedAt: time.Now()}
+	meeting := &task.Task{Description: "Meeting", Category: task.CategoryShallow, ScheduledDate: date, ScheduledStart: "10:00", ScheduledEnd: "10:30", Status: task.StatusScheduled, CreatedAt: time.Now()}
+	if err := repo.CreateTask(ctx, focus); err != nil {
+		t.Fatalf("CreateTask (focus): %v", err)
+	}
+	if err := repo.CreateTask(ctx, meeting); err != nil {
+		t.Fatalf("CreateTask (meeting) = %v, want overlap stored", err)
+	}
+	if !task.AllowsOverlaps(repo) {
+		t.Error("AllowsOverlaps = false")
+	}
+
+	tasks, err := repo.ListTasksByDateRange(ctx, date, date)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("got %d tasks, want both blocks", len(tasks))
+	}
+}
+
 func TestCreateTask_NoOverlapWithAdjacentTasks(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -1072,6 +1313,63 @@ func TestCreateTask_NoOverlapOnDifferentDays(t *testing.T) {
 	}
 }
 
+func TestCreateTask_OvernightOverlapsNextDay(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	jan15 := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	jan16 := time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	// Create overnight task on Jan 15, 23:00-01:00
+	night := &task.Task{
+		Description:    "Release",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  jan15,
+		ScheduledStart: "23:00",
+		ScheduledEnd:   "01:00",
+		Status:         task.StatusScheduled,
+		CreatedAt:      time.Now(),
+	}
+	if err := repo.CreateTask(ctx, night); err != nil {
+		t.Fatalf("CreateTask (overnight) failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		date        time.Time
+		start, end  string
+		wantOverlap bool
+	}{
+		{"next morning during tail", jan16, "00:30", "01:30", true},
+		{"next morning after tail", jan16, "01:00", "02:00", false},
+		{"same evening before", jan15, "22:00", "23:00", false},
+		{"same evening during", jan15, "22:30", "23:30", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := &task.Task{
+				Description:    "Other task",
+				Category:       task.CategoryShallow,
+				ScheduledDate:  tt.date,
+				ScheduledStart: tt.start,
+				ScheduledEnd:   tt.end,
+				Status:         task.StatusScheduled,
+				CreatedAt:      time.Now(),
+			}
+			err := repo.CreateTask(ctx, other)
+			if got := errors.Is(err, task.ErrTimeBlockOverlap); got != tt.wantOverlap {
+				t.Errorf("overlap = %v, want %v (err: %v)", got, tt.wantOverlap, err)
+			}
+			if err == nil {
+				if err := repo.CancelTask(ctx, other.ID, 0); err != nil {
+					t.Fatalf("CancelTask failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
 func TestCreateTask_NoOverlapWithCancelledTask(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
@@ -1091,7 +1389,7 @@ func TestCreateTask_NoOverlapWithCancelledTask(t *testing.T) {
 	if err := repo.CreateTask(ctx, cancelled); err != nil {
 		t.Fatalf("CreateTask failed: %v", err)
 	}
-	if err := repo.CancelTask(ctx, cancelled.ID); err != nil {
+	if err := repo.CancelTask(ctx, cancelled.ID, 0); err != nil {
 		t.Fatalf("CancelTask failed: %v", err)
 	}
 
@@ -1225,7 +1523,7 @@ func TestPostponeTask_OverlapError(t *testing.T) {
 	}
 
 	// Try to postpone to overlapping slot
-	_, err := repo.PostponeTask(ctx, toPostpone.ID, nextDate, "15:00", "17:00")
+	_, err := repo.PostponeTask(ctx, toPostpone.ID, nextDate, "15:00", "17:00", 0)
 	if err == nil {
 		t.Error("expected overlap error, got nil")
 	}
@@ -1261,7 +1559,7 @@ func TestUpdateTask(t *testing.T) {
 	}
 
 	// Update times (grow by 15 min)
-	err := repo.UpdateTask(ctx, tsk.ID, "09:00", "10:15")
+	err := repo.UpdateTask(ctx, tsk.ID, "09:00", "10:15", 0)
 	if err != nil {
 		t.Fatalf("UpdateTask failed: %v", err)
 	}
@@ -1282,7 +1580,7 @@ func TestUpdateTask(t *testing.T) {
 func TestUpdateTaskDescription(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
-	tsk, err := task.New("Original", "deep", "2025-01-15", "09:00", "10:00")
+	tsk, err := task.New("Original", "deep", "2025-01-15", "09:00", "10:00", time.Now())
 	if err != nil {
 		t.Fatalf("New task failed: %v", err)
 	}
@@ -1291,7 +1589,7 @@ func TestUpdateTaskDescription(t *testing.T) {
 		t.Fatalf("CreateTask failed: %v", err)
 	}
 
-	if err := repo.UpdateTaskDescription(ctx, tsk.ID, "Updated"); err != nil {
+	if err := repo.UpdateTaskDescription(ctx, tsk.ID, "Updated", 0); err != nil {
 		t.Fatalf("UpdateTaskDescription failed: %v", err)
 	}
 
@@ -1310,7 +1608,7 @@ func TestUpdateTaskDescription(t *testing.T) {
 func TestUpdateTaskDescription_Empty(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
-	tsk, err := task.New("Original", "deep", "2025-01-15", "09:00", "10:00")
+	tsk, err := task.New("Original", "deep", "2025-01-15", "09:00", "10:00", time.Now())
 	if err != nil {
 		t.Fatalf("New task failed: %v", err)
 	}
@@ -1319,7 +1617,7 @@ func TestUpdateTaskDescription_Empty(t *testing.T) {
 		t.Fatalf("CreateTask failed: %v", err)
 	}
 
-	err = repo.UpdateTaskDescription(ctx, tsk.ID, " ")
+	err = repo.UpdateTaskDescription(ctx, tsk.ID, " ", 0)
 	if !errors.Is(err, task.ErrEmptyDescription) {
 		t.Fatalf("error = %v, want %v", err, task.ErrEmptyDescription)
 	}
@@ -1328,7 +1626,7 @@ func TestUpdateTaskDescription_Empty(t *testing.T) {
 func TestUpdateTaskDescription_NotFound(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
-	err := repo.UpdateTaskDescription(ctx, 9999, "Updated")
+	err := repo.UpdateTaskDescription(ctx, 9999, "Updated", 0)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -1338,7 +1636,7 @@ func TestUpdateTask_NotFound(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	err := repo.UpdateTask(ctx, 9999, "09:00", "10:00")
+	err := repo.UpdateTask(ctx, 9999, "09:00", "10:00", 0)
 	if err == nil {
 		t.Error("expected error for non-existent task")
 	}
@@ -1378,7 +1676,7 @@ func TestUpdateTask_OverlapError(t *testing.T) {
 	}
 
 	// Try to grow first task into second task's time
-	err := repo.UpdateTask(ctx, first.ID, "09:00", "10:30")
+	err := repo.UpdateTask(ctx, first.ID, "09:00", "10:30", 0)
 	if err == nil {
 		t.Error("expected overlap error, got nil")
 	}
@@ -1414,101 +1712,364 @@ func TestUpdateTask_NoSelfOverlap(t *testing.T) {
 	}
 
 	// Should be able to update to same times (no self-overlap)
-	err := repo.UpdateTask(ctx, tsk.ID, "09:00", "11:00")
+	err := repo.UpdateTask(ctx, tsk.ID, "09:00", "11:00", 0)
 	if err != nil {
 		t.Errorf("updating to same times should succeed: %v", err)
 	}
 
 	// Should be able to shrink
-	err = repo.UpdateTask(ctx, tsk.ID, "09:00", "10:00")
+	err = repo.UpdateTask(ctx, tsk.ID, "09:00", "10:00", 0)
 	if err != nil {
 		t.Errorf("shrinking should succeed: %v", err)
 	}
 }
 
-func TestParseDate_LocalTimezone(t *testing.T) {
-	// This tests that parseDate returns dates in local timezone,
-	// which is critical for matching with time.Now()-based dates in the TUI.
-	tests := []struct {
-		name  string
-		input string
-	}{
-		{"date only", "2025-01-15"},
-		{"date only different month", "2025-06-20"},
-		{"date only end of year", "2025-12-31"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			parsed, err := parseDate(tt.input)
-			if err != nil {
-				t.Fatalf("parseDate(%q) failed: %v", tt.input, err)
-			}
-
-			// The parsed date should be in local timezone
-			if parsed.Location() != time.Local {
-				t.Errorf("parseDate(%q) location = %v, want %v", tt.input, parsed.Location(), time.Local)
-			}
-
-			// Create a date the same way the TUI does (using time.Now's location)
-			localMidnight := time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.Local)
+func TestBatchUpdate(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
 
-			// They should be equal
-			if !parsed.Equal(localMidnight) {
-				t.Errorf("parseDate(%q) = %v, want %v (should match local midnight)", tt.input, parsed, localMidnight)
-			}
-		})
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	nextDay := date.AddDate(0, 0, 1)
+	mk := func(desc, start, end string) *task.Task {
+		tsk := &task.Task{
+			Description:    desc,
+			Category:       task.CategoryDeep,
+			ScheduledDate:  date,
+			ScheduledStart: start,
+			ScheduledEnd:   end,
+			Status:         task.StatusScheduled,
+			CreatedAt:      time.Now(),
+		}
+		if err := repo.CreateTask(ctx, tsk); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		return tsk
+	}
+	first := mk("First", "09:00", "10:00")
+	second := mk("Second", "10:00", "11:00")
+	third := mk("Third", "13:00", "14:00")
+	fourth := mk("Fourth", "15:00", "16:00")
+
+	// Shifting adjacent tasks by an hour only works when checked together
+	err := repo.BatchUpdate(ctx, []task.TaskUpdate{
+		{ID: first.ID, Start: "10:00", End: "11:00"},
+		{ID: second.ID, Start: "11:00", End: "12:00", Category: task.CategoryShallow},
+		{ID: third.ID, Cancel: true},
+		{ID: fourth.ID, Postpone: true, Date: nextDay},
+	})
+	if err != nil {
+		t.Fatalf("BatchUpdate failed: %v", err)
 	}
-}
 
-func TestParseDate_AllFormats(t *testing.T) {
-	tests := []struct {
-		name    string
-		input   string
-		wantErr bool
-	}{
-		{"date only", "2025-01-15", false},
-		{"datetime with Z", "2025-01-15T09:00:00Z", false},
-		{"datetime without tz", "2025-01-15 09:00:00", false},
-		{"RFC3339", "2025-01-15T09:00:00+05:00", false},
-		{"invalid format", "15/01/2025", true},
-		{"empty", "", true},
+	got, _ := repo.GetTask(ctx, first.ID)
+	if got.ScheduledStart != "10:00" || got.ScheduledEnd != "11:00" {
+		t.Errorf("first: got %s-%s, want 10:00-11:00", got.ScheduledStart, got.ScheduledEnd)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			_, err := parseDate(tt.input)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
-			}
-		})
+	got, _ = repo.GetTask(ctx, second.ID)
+	if got.ScheduledStart != "11:00" || got.Category != task.CategoryShallow {
+		t.Errorf("second: got %s %s, want 11:00 shallow", got.ScheduledStart, got.Category)
+	}
+	got, _ = repo.GetTask(ctx, third.ID)
+	if got.Status != task.StatusCancelled {
+		t.Errorf("third: status %s, want cancelled", got.Status)
+	}
+	got, _ = repo.GetTask(ctx, fourth.ID)
+	if got.Status != task.StatusPostponed {
+		t.Errorf("fourth: status %s, want postponed", got.Status)
+	}
+	moved, err := repo.ListTasksByDateRange(ctx, nextDay, nextDay)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange failed: %v", err)
+	}
+	if len(moved) != 1 || moved[0].ScheduledStart != "15:00" || moved[0].PostponedFrom == nil || *moved[0].PostponedFrom != fourth.ID {
+		t.Errorf("postponed copy: got %+v", moved)
 	}
 }
 
-func TestListTasksByDateRange_ReturnsLocalTimezone(t *testing.T) {
+func TestBatchUpdate_OverlapWritesNothing(t *testing.T) {
 	repo := newTestRepo(t)
 	ctx := context.Background()
 
-	// Create task with local timezone date
-	localDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)
-	tsk := &task.Task{
-		Description:    "Test task",
-		Category:       task.CategoryDeep,
-		ScheduledDate:  localDate,
-		ScheduledStart: "09:00",
-		ScheduledEnd:   "10:00",
-		Status:         task.StatusScheduled,
-		CreatedAt:      time.Now(),
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	var ids []int64
+	for _, slot := range [][2]string{{"09:00", "10:00"}, {"11:00", "12:00"}} {
+		tsk := &task.Task{
+			Description:    "Task " + slot[0],
+			Category:       task.CategoryDeep,
+			ScheduledDate:  date,
+			ScheduledStart: slot[0],
+			ScheduledEnd:   slot[1],
+			Status:         task.StatusScheduled,
+			CreatedAt:      time.Now(),
+		}
+		if err := repo.CreateTask(ctx, tsk); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		ids = append(ids, tsk.ID)
 	}
 
-	if err := repo.CreateTask(ctx, tsk); err != nil {
-		t.Fatalf("CreateTask failed: %v", err)
+	err := repo.BatchUpdate(ctx, []task.TaskUpdate{
+		{ID: ids[0], Category: task.CategoryShallow},
+		{ID: ids[0], Start: "11:30", End: "12:30"},
+	})
+	if !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Fatalf("BatchUpdate error = %v, want ErrTimeBlockOverlap", err)
 	}
 
-	// Fetch tasks
-	got, err := repo.ListTasksByDateRange(ctx, localDate, localDate)
-	if err != nil {
-		t.Fatalf("ListTasksByDateRange failed: %v", err)
+	got, _ := repo.GetTask(ctx, ids[0])
+	if got.ScheduledStart != "09:00" || got.Category != task.CategoryDeep {
+		t.Errorf("task changed despite failure: %s %s", got.ScheduledStart, got.Category)
+	}
+}
+
+func TestBatchUpdateTaskTimes_ChangesDates(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	mk := func(desc string, date time.Time, start, end string) *task.Task {
+		tsk := &task.Task{
+			Description:    desc,
+			Category:       task.CategoryDeep,
+			ScheduledDate:  date,
+			ScheduledStart: start,
+			ScheduledEnd:   end,
+			Status:         task.StatusScheduled,
+			CreatedAt:      time.Now(),
+		}
+		if err := repo.CreateTask(ctx, tsk); err != nil {
+			t.Fatalf("CreateTask failed: %v", err)
+		}
+		return tsk
+	}
+	late := mk("Late shift", monday, "22:00", "02:00")
+	report := mk("Report", monday, "09:00", "10:00")
+
+	// The overnight block runs into Tuesday morning
+	err := repo.BatchUpdateTaskTimes(ctx, time.Time{}, []task.TaskTimeUpdate{
+		{ID: report.ID, Date: monday.AddDate(0, 0, 1), NewStart: "01:00", NewEnd: "02:00"},
+	})
+	if !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Fatalf("BatchUpdateTaskTimes error = %v, want ErrTimeBlockOverlap", err)
+	}
+
+	// Moving the overnight block out of the way in the same batch frees it
+	err = repo.BatchUpdateTaskTimes(ctx, monday, []task.TaskTimeUpdate{
+		{ID: report.ID, Date: monday.AddDate(0, 0, 1), NewStart: "01:00", NewEnd: "02:00"},
+		{ID: late.ID, NewStart: "18:00", NewEnd: "20:00"},
+	})
+	if err != nil {
+		t.Fatalf("BatchUpdateTaskTimes failed: %v", err)
+	}
+	got, _ := repo.GetTask(ctx, report.ID)
+	if !got.ScheduledDate.Equal(monday.AddDate(0, 0, 1)) || got.ScheduledStart != "01:00" {
+		t.Errorf("report: got %v %s, want Tuesday 01:00", got.ScheduledDate, got.ScheduledStart)
+	}
+	got, _ = repo.GetTask(ctx, late.ID)
+	if !got.ScheduledDate.Equal(monday) || got.ScheduledStart != "18:00" {
+		t.Errorf("late shift: got %v %s, want Monday 18:00", got.ScheduledDate, got.ScheduledStart)
+	}
+}
+
+func TestBatchUpdate_StaleVersion(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	tsk := &task.Task{
+		Description:    "Report",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  monday,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+		CreatedAt:      time.Now(),
+	}
+	if err := repo.CreateTask(ctx, tsk); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	read, err := repo.GetTask(ctx, tsk.ID)
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if read.Version == 0 {
+		t.Fatal("GetTask returned no version")
+	}
+
+	// Another process renames the task after it was read, within the same
+	// millisecond
+	if _, err := repo.db.ExecContext(ctx, `UPDATE tasks SET description = 'Renamed' WHERE id = ?`, tsk.ID); err != nil {
+		t.Fatalf("renaming task: %v", err)
+	}
+
+	err = repo.BatchUpdate(ctx, []task.TaskUpdate{{ID: tsk.ID, Cancel: true, Version: read.Version}})
+	if !errors.Is(err, task.ErrTaskChanged) {
+		t.Errorf("BatchUpdate error = %v, want ErrTaskChanged", err)
+	}
+	err = repo.BatchUpdateTaskTimes(ctx, monday, []task.TaskTimeUpdate{
+		{ID: tsk.ID, NewStart: "11:00", NewEnd: "12:00", Version: read.Version},
+	})
+	if !errors.Is(err, task.ErrTaskChanged) {
+		t.Errorf("BatchUpdateTaskTimes error = %v, want ErrTaskChanged", err)
+	}
+	got, _ := repo.GetTask(ctx, tsk.ID)
+	if got.Status != task.StatusScheduled || got.ScheduledStart != "09:00" {
+		t.Errorf("stale writes were applied: status %s, start %s", got.Status, got.ScheduledStart)
+	}
+
+	// Read again, the writes go through
+	err = repo.BatchUpdateTaskTimes(ctx, monday, []task.TaskTimeUpdate{
+		{ID: tsk.ID, NewStart: "11:00", NewEnd: "12:00", Version: got.Version},
+	})
+	if err != nil {
+		t.Errorf("BatchUpdateTaskTimes with current version failed: %v", err)
+	}
+}
+
+func TestSingleTaskWrites_StaleVersion(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	tsk := &task.Task{
+		Description:    "Report",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  monday,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+		ExternalRef:    task.ExternalRef{Source: "ical", ID: "report"},
+	}
+	if err := repo.CreateTask(ctx, tsk); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	read, _ := repo.GetTask(ctx, tsk.ID)
+	if err := repo.SetTaskEnergy(ctx, tsk.ID, task.EnergyHigh, read.Version); err != nil {
+		t.Fatalf("SetTaskEnergy with current version failed: %v", err)
+	}
+	if got, _ := repo.GetTask(ctx, tsk.ID); got.Version != read.Version+1 {
+		t.Errorf("version after a write = %d, want %d", got.Version, read.Version+1)
+	}
+
+	// Every write from the first read is now stale
+	stale := read.Version
+	upsert := *read
+	upsert.Description = "Upserted"
+	writes := map[string]func() error{
+		"CancelTask":     func() error { return repo.CancelTask(ctx, tsk.ID, stale) },
+		"SetTaskOutcome": func() error { return repo.SetTaskOutcome(ctx, tsk.ID, task.OutcomeOver, stale) },
+		"SetTaskEnergy":  func() error { return repo.SetTaskEnergy(ctx, tsk.ID, task.EnergyLow, stale) },
+		"PostponeTask": func() error {
+			_, err := repo.PostponeTask(ctx, tsk.ID, monday.AddDate(0, 0, 1), "09:00", "10:00", stale)
+			return err
+		},
+		"UpdateTask":            func() error { return repo.UpdateTask(ctx, tsk.ID, "11:00", "12:00", stale) },
+		"UpdateTaskDescription": func() error { return repo.UpdateTaskDescription(ctx, tsk.ID, "Renamed", stale) },
+		"UpsertTask": func() error {
+			_, err := repo.UpsertTask(ctx, &upsert)
+			return err
+		},
+	}
+	for name, write := range writes {
+		if err := write(); !errors.Is(err, task.ErrTaskChanged) {
+			t.Errorf("%s error = %v, want ErrTaskChanged", name, err)
+		}
+	}
+	got, _ := repo.GetTask(ctx, tsk.ID)
+	if got.Status != task.StatusScheduled || got.Outcome != nil || got.Energy != task.EnergyHigh ||
+		got.ScheduledStart != "09:00" || got.Description != "Report" {
+		t.Errorf("stale writes were applied: %+v", got)
+	}
+
+	// A missing task is still reported as not found
+	if err := repo.CancelTask(ctx, 999, 1); err == nil || errors.Is(err, task.ErrTaskChanged) {
+		t.Errorf("CancelTask of a missing task = %v, want not found", err)
+	}
+}
+
+func TestParseDate_LocalTimezone(t *testing.T) {
+	// This tests that parseDate returns dates in local timezone,
+	// which is critical for matching with time.Now()-based dates in the TUI.
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"date only", "2025-01-15"},
+		{"date only different month", "2025-06-20"},
+		{"date only end of year", "2025-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseDate(tt.input)
+			if err != nil {
+				t.Fatalf("parseDate(%q) failed: %v", tt.input, err)
+			}
+
+			// The parsed date should be in local timezone
+			if parsed.Location() != time.Local {
+				t.Errorf("parseDate(%q) location = %v, want %v", tt.input, parsed.Location(), time.Local)
+			}
+
+			// Create a date the same way the TUI does (using time.Now's location)
+			localMidnight := time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.Local)
+
+			// They should be equal
+			if !parsed.Equal(localMidnight) {
+				t.Errorf("parseDate(%q) = %v, want %v (should match local midnight)", tt.input, parsed, localMidnight)
+			}
+		})
+	}
+}
+
+func TestParseDate_AllFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"date only", "2025-01-15", false},
+		{"datetime with Z", "2025-01-15T09:00:00Z", false},
+		{"datetime without tz", "2025-01-15 09:00:00", false},
+		{"RFC3339", "2025-01-15T09:00:00+05:00", false},
+		{"invalid format", "15/01/2025", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListTasksByDateRange_ReturnsLocalTimezone(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	// Create task with local timezone date
+	localDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)
+	tsk := &task.Task{
+		Description:    "Test task",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  localDate,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := repo.CreateTask(ctx, tsk); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	// Fetch tasks
+	got, err := repo.ListTasksByDateRange(ctx, localDate, localDate)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange failed: %v", err)
 	}
 
 	if len(got) != 1 {
@@ -1532,3 +2093,670 @@ func TestListTasksByDateRange_ReturnsLocalTimezone(t *testing.T) {
 		t.Errorf("ScheduledDate.Equal() failed: got %v, want %v", got[0].ScheduledDate, localDate)
 	}
 }
+
+func TestDailyAggregates_MaintainedOnWrite(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	mon := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	tue := mon.AddDate(0, 0, 1)
+
+	mk := func(desc string, cat task.Category, start, end string) *task.Task {
+		tk := &task.Task{Description: desc, Category: cat, ScheduledDate: mon, ScheduledStart: start, ScheduledEnd: end, Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask(%s): %v", desc, err)
+		}
+		return tk
+	}
+	write := mk("Write", task.CategoryDeep, "09:00", "11:00")
+	mk("Email", task.CategoryShallow, "11:00", "11:30")
+	meeting := mk("Meeting", task.CategoryShallow, "14:00", "15:00")
+	late := mk("Deploy", task.CategoryDeep, "23:00", "01:00")
+	review := mk("Review", task.CategoryShallow, "16:00", "17:00")
+
+	if err := repo.MarkTasksMissed(ctx, []int64{review.ID}); err != nil {
+		t.Fatalf("MarkTasksMissed: %v", err)
+	}
+	if err := repo.SetTaskOutcome(ctx, write.ID, task.OutcomeOver, 0); err != nil {
+		t.Fatalf("SetTaskOutcome: %v", err)
+	}
+	if err := repo.CancelTask(ctx, meeting.ID, 0); err != nil {
+		t.Fatalf("CancelTask: %v", err)
+	}
+	if _, err := repo.PostponeTask(ctx, late.ID, tue, "10:00", "12:00", 0); err != nil {
+		t.Fatalf("PostponeTask: %v", err)
+	}
+
+	got, err := repo.ListDailyAggregates(ctx, mon, tue)
+	if err != nil {
+		t.Fatalf("ListDailyAggregates: %v", err)
+	}
+	tasks, err := repo.ListTasksByDateRange(ctx, mon, tue)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	want := task.AggregateByDay(tasks)
+	if len(got) != 2 || len(want) != 2 {
+		t.Fatalf("got %d days, computed %d; want 2", len(got), len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.DayStats != w.DayStats || g.OnTime != w.OnTime || g.Over != w.Over || g.Under != w.Under ||
+			task.CalendarDaysBetween(g.Date, w.Date) != 0 {
+			t.Errorf("day %d: table %+v, computed %+v", i, g, w)
+		}
+	}
+	if got[0].DeepMinutes != 120 || got[0].ShallowMinutes != 30 || got[0].Over != 1 ||
+		got[0].CancelledBlocks != 1 || got[0].PostponedBlocks != 1 || got[0].MissedBlocks != 1 || got[0].TotalBlocks != 5 {
+		t.Errorf("monday = %+v", got[0])
+	}
+	if got[1].DeepMinutes != 120 {
+		t.Errorf("tuesday deep minutes = %d, want 120", got[1].DeepMinutes)
+	}
+}
+
+func TestListWeeklyAggregates(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	mon := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for i, date := range []time.Time{mon, mon.AddDate(0, 0, 6), mon.AddDate(0, 0, 7), mon.AddDate(0, 0, 22)} {
+		tk := &task.Task{Description: fmt.Sprintf("Task %d", i), Category: task.CategoryDeep, ScheduledDate: date,
+			ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+		if i == 0 {
+			if err := repo.SetTaskOutcome(ctx, tk.ID, task.OutcomeOnTime, 0); err != nil {
+				t.Fatalf("SetTaskOutcome: %v", err)
+			}
+		}
+	}
+
+	end := mon.AddDate(0, 0, 27)
+	got, err := repo.ListWeeklyAggregates(ctx, mon, end)
+	if err != nil {
+		t.Fatalf("ListWeeklyAggregates: %v", err)
+	}
+	days, err := repo.ListDailyAggregates(ctx, mon, end)
+	if err != nil {
+		t.Fatalf("ListDailyAggregates: %v", err)
+	}
+	want := task.AggregateByWeek(days)
+	if len(got) != 3 || len(want) != 3 {
+		t.Fatalf("got %d weeks, computed %d; want 3", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("week %d: query %+v, computed %+v", i, got[i], want[i])
+		}
+	}
+	if got[0].TotalMinutes() != 120 || got[0].CompletionPercent() != 50 {
+		t.Errorf("first week = %+v, want 120 minutes and 50%% complete", got[0])
+	}
+	if !got[2].WeekStart.Equal(mon.AddDate(0, 0, 21)) {
+		t.Errorf("third week starts %v, want %v", got[2].WeekStart, mon.AddDate(0, 0, 21))
+	}
+}
+
+func TestDailyAggregates_BackfilledOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	tk := &task.Task{Description: "Write", Category: task.CategoryDeep, ScheduledDate: date, ScheduledStart: "09:00", ScheduledEnd: "10:30", Status: task.StatusScheduled}
+	if err := repo.CreateTask(ctx, tk); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	// Simulate a database from before the table existed
+	if _, err := repo.db.Exec(`DROP TABLE daily_stats`); err != nil {
+		t.Fatalf("dropping daily_stats: %v", err)
+	}
+	_ = repo.Close()
+
+	repo, err = New(path)
+	if err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+	got, err := task.DailyAggregates(ctx, repo, date, date)
+	if err != nil {
+		t.Fatalf("DailyAggregates: %v", err)
+	}
+	if len(got) != 1 || got[0].DeepMinutes != 90 {
+		t.Errorf("aggregates = %+v, want one day with 90 deep minutes", got)
+	}
+}
+
+func TestMarkTasksMissed_UpgradesOldSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	old, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	// A database from before the missed status existed
+	_, err = old.Exec(`
+		CREATE TABLE tasks (
+			id              INTEGER PRIMARY KEY AUTOINCREMENT,
+			description     TEXT NOT NULL,
+			category        TEXT CHECK(category IN ('deep', 'shallow')),
+			scheduled_date  DATE NOT NULL,
+			scheduled_start TIME NOT NULL,
+			scheduled_end   TIME NOT NULL,
+			status          TEXT DEFAULT 'scheduled' CHECK(status IN ('scheduled', 'postponed', 'cancelled')),
+			outcome         TEXT CHECK(outcome IN ('on_time', 'over', 'under')),
+			postponed_from  INTEGER REFERENCES tasks(id),
+			created_at      DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO tasks (description, category, scheduled_date, scheduled_start, scheduled_end)
+		VALUES ('Report', 'deep', '2025-01-06', '09:00', '10:00');
+	`)
+	if err != nil {
+		t.Fatalf("creating old schema: %v", err)
+	}
+	_ = old.Close()
+
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	ctx := context.Background()
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	missed, err := task.MarkMissed(ctx, repo, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("MarkMissed: %v", err)
+	}
+	if len(missed) != 1 {
+		t.Fatalf("missed = %d tasks, want 1", len(missed))
+	}
+	got, err := repo.GetTask(ctx, missed[0].ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if !got.IsMissed() {
+		t.Fatalf("status = %s, want missed", got.Status)
+	}
+
+	next := date.AddDate(0, 0, 1)
+	err = repo.BatchUpdate(ctx, []task.TaskUpdate{{ID: got.ID, Postpone: true, Date: next, Start: "09:00", End: "10:00"}})
+	if err != nil {
+		t.Fatalf("BatchUpdate: %v", err)
+	}
+	if got, _ = repo.GetTask(ctx, got.ID); !got.IsMissed() {
+		t.Errorf("rescheduled original status = %s, want it to stay missed", got.Status)
+	}
+	copies, err := repo.ListTasksByDateRange(ctx, next, next)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(copies) != 1 || !copies[0].IsScheduled() || copies[0].PostponedFrom == nil {
+		t.Errorf("next day = %+v, want a scheduled copy", copies)
+	}
+}
+
+func TestMigrate_AllowsCustomCategories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	if err := repo.CreateTask(ctx, &task.Task{Description: "Report", Category: task.CategoryDeep, ScheduledDate: date, ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled}); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	// Put back the category CHECK of databases created before custom
+	// categories, keeping the stamped updated_at values.
+	if _, err := repo.db.Exec(`
+		CREATE TABLE tasks_old (
+			id              INTEGER PRIMARY KEY AUTOINCREMENT,
+			description     TEXT NOT NULL,
+			category        TEXT CHECK(category IN ('deep', 'shallow')),
+			scheduled_date  DATE NOT NULL,
+			scheduled_start TIME NOT NULL,
+			scheduled_end   TIME NOT NULL,
+			status          TEXT DEFAULT 'scheduled' CHECK(status IN ('scheduled', 'postponed', 'cancelled', 'missed')),
+			outcome         TEXT CHECK(outcome IN ('on_time', 'over', 'under')),
+			energy          TEXT CHECK(energy IN ('high', 'medium', 'low')),
+			postponed_from  INTEGER REFERENCES tasks(id),
+			external_ref    TEXT,
+			owner           TEXT NOT NULL DEFAULT '',
+			created_at      DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at      TEXT
+		);
+		INSERT INTO tasks_old SELECT id, description, category, scheduled_date, scheduled_start, scheduled_end,
+			status, outcome, energy, postponed_from, external_ref, owner, created_at, updated_at FROM tasks;
+		DROP TABLE tasks;
+		ALTER TABLE tasks_old RENAME TO tasks;
+	`); err != nil {
+		t.Fatalf("restoring old schema: %v", err)
+	}
+	var stamped string
+	if err := repo.db.QueryRow(`SELECT updated_at FROM tasks`).Scan(&stamped); err != nil {
+		t.Fatalf("reading updated_at: %v", err)
+	}
+	_ = repo.Close()
+
+	repo, err = New(path)
+	if err != nil {
+		t.Fatalf("New after downgrade: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+	if err := repo.CreateTask(ctx, &task.Task{Description: "Standup", Category: "meeting", ScheduledDate: date, ScheduledStart: "10:00", ScheduledEnd: "10:30", Status: task.StatusScheduled}); err != nil {
+		t.Fatalf("CreateTask with custom category: %v", err)
+	}
+	tasks, err := repo.ListTasksByDateRange(ctx, date, date)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(tasks) != 2 || tasks[1].Category != "meeting" {
+		t.Fatalf("tasks = %+v, want the report and a meeting", tasks)
+	}
+	var kept string
+	if err := repo.db.QueryRow(`SELECT updated_at FROM tasks WHERE id = ?`, tasks[0].ID).Scan(&kept); err != nil || kept != stamped {
+		t.Errorf("updated_at = %q, %v; want %q kept through the rebuild", kept, err, stamped)
+	}
+	// Older rows are identified by id, new ones get a random uid
+	var oldUID, newUID string
+	if err := repo.db.QueryRow(`SELECT uid FROM tasks WHERE id = ?`, tasks[0].ID).Scan(&oldUID); err != nil || oldUID != fmt.Sprintf("id:%d", tasks[0].ID) {
+		t.Errorf("old uid = %q, %v; want one derived from the id", oldUID, err)
+	}
+	if err := repo.db.QueryRow(`SELECT uid FROM tasks WHERE id = ?`, tasks[1].ID).Scan(&newUID); err != nil || len(newUID) != 32 {
+		t.Errorf("new uid = %q, %v; want a random one", newUID, err)
+	}
+}
+
+func TestSetTaskOutcome_RestoresMissed(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	mk := func(desc, start, end string) *task.Task {
+		tk := &task.Task{Description: desc, Category: task.CategoryDeep, ScheduledDate: date, ScheduledStart: start, ScheduledEnd: end, Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask(%s): %v", desc, err)
+		}
+		return tk
+	}
+	done := mk("Done after all", "09:00", "10:00")
+	moved := mk("Rescheduled", "10:00", "11:00")
+	if err := repo.MarkTasksMissed(ctx, []int64{done.ID, moved.ID}); err != nil {
+		t.Fatalf("MarkTasksMissed: %v", err)
+	}
+	err := repo.BatchUpdate(ctx, []task.TaskUpdate{{ID: moved.ID, Postpone: true, Date: date.AddDate(0, 0, 1), Start: "10:00", End: "11:00"}})
+	if err != nil {
+		t.Fatalf("BatchUpdate: %v", err)
+	}
+
+	for _, tk := range []*task.Task{done, moved} {
+		if err := repo.SetTaskOutcome(ctx, tk.ID, task.OutcomeOnTime, 0); err != nil {
+			t.Fatalf("SetTaskOutcome: %v", err)
+		}
+	}
+	if got, _ := repo.GetTask(ctx, done.ID); !got.IsScheduled() {
+		t.Errorf("reviewed missed task status = %s, want scheduled", got.Status)
+	}
+	if got, _ := repo.GetTask(ctx, moved.ID); !got.IsMissed() {
+		t.Errorf("rescheduled missed task status = %s, want it to stay missed", got.Status)
+	}
+}
+
+func TestNew_SharedByTwoProcesses(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	tui, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer func() { _ = tui.Close() }()
+	cli, err := New(path)
+	if err != nil {
+		t.Fatalf("New second instance: %v", err)
+	}
+	defer func() { _ = cli.Close() }()
+
+	var mode string
+	if err := tui.db.QueryRow(`PRAGMA journal_mode`).Scan(&mode); err != nil || mode != "wal" {
+		t.Fatalf("journal_mode = %q, %v; want wal", mode, err)
+	}
+
+	before, err := tui.DataVersion(ctx)
+	if err != nil {
+		t.Fatalf("DataVersion: %v", err)
+	}
+
+	// Both instances write at once; the busy timeout queues them
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	errs := make(chan error, 20)
+	for i := range 10 {
+		for j, repo := range []*SQLite{tui, cli} {
+			go func() {
+				errs <- repo.CreateTask(ctx, &task.Task{
+					Description:    fmt.Sprintf("Task %d-%d", i, j),
+					Category:       task.CategoryShallow,
+					ScheduledDate:  date.AddDate(0, 0, i),
+					ScheduledStart: fmt.Sprintf("%02d:00", 8+j),
+					ScheduledEnd:   fmt.Sprintf("%02d:30", 8+j),
+					Status:         task.StatusScheduled,
+				})
+			}()
+		}
+	}
+	for range 20 {
+		if err := <-errs; err != nil {
+			t.Errorf("concurrent CreateTask: %v", err)
+		}
+	}
+
+	after, err := tui.DataVersion(ctx)
+	if err != nil {
+		t.Fatalf("DataVersion: %v", err)
+	}
+	if after == before {
+		t.Error("DataVersion did not change after the other instance wrote")
+	}
+	all, err := tui.ListAllTasks(ctx)
+	if err != nil || len(all) != 20 {
+		t.Errorf("ListAllTasks = %d tasks, %v; want 20", len(all), err)
+	}
+}
+
+func TestCreateTask_SharedOverlapRace(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	var repos []*SQLite
+	for range 2 {
+		repo, err := New(path)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		defer func() { _ = repo.Close() }()
+		repos = append(repos, repo)
+	}
+
+	// Both instances try the same block at once; only one may win
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	errs := make(chan error, 20)
+	for i := range 10 {
+		for _, repo := range repos {
+			go func() {
+				errs <- repo.CreateTask(ctx, &task.Task{
+					Description:    fmt.Sprintf("Task %d", i),
+					Category:       task.CategoryShallow,
+					ScheduledDate:  date,
+					ScheduledStart: "09:00",
+					ScheduledEnd:   "10:00",
+					Status:         task.StatusScheduled,
+				})
+			}()
+		}
+	}
+	created := 0
+	for range 20 {
+		err := <-errs
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, task.ErrTimeBlockOverlap):
+			t.Errorf("concurrent CreateTask: %v", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("created %d overlapping tasks, want 1", created)
+	}
+}
+
+func TestTaskRevisions_RecordAndRestore(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)
+	tsk := &task.Task{
+		Description:    "Draft",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  date,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(ctx, tsk); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	if err := repo.UpdateTask(ctx, tsk.ID, "11:00", "12:00", 0); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if err := repo.UpdateTaskDescription(ctx, tsk.ID, "Final draft", 0); err != nil {
+		t.Fatalf("UpdateTaskDescription failed: %v", err)
+	}
+	// Outcome and energy are not part of the history
+	if err := repo.SetTaskEnergy(ctx, tsk.ID, task.EnergyHigh, 0); err != nil {
+		t.Fatalf("SetTaskEnergy failed: %v", err)
+	}
+
+	revs, err := repo.ListTaskRevisions(ctx, tsk.ID)
+	if err != nil {
+		t.Fatalf("ListTaskRevisions failed: %v", err)
+	}
+	if len(revs) != 2 {
+		t.Fatalf("got %d revisions, want 2: %+v", len(revs), revs)
+	}
+	if revs[0].Description != "Draft" || revs[0].ScheduledStart != "11:00" {
+		t.Errorf("newest revision = %+v, want Draft at 11:00", revs[0])
+	}
+	oldest := revs[1]
+	if oldest.Description != "Draft" || oldest.ScheduledStart != "09:00" || oldest.ScheduledEnd != "10:00" ||
+		!oldest.ScheduledDate.Equal(date) || oldest.ChangedAt.IsZero() {
+		t.Errorf("oldest revision = %+v, want Draft at 09:00-10:00", oldest)
+	}
+
+	current, _ := repo.GetTask(ctx, tsk.ID)
+	if err := task.RestoreRevision(ctx, repo, current, oldest); err != nil {
+		t.Fatalf("RestoreRevision failed: %v", err)
+	}
+	got, _ := repo.GetTask(ctx, tsk.ID)
+	if got.Description != "Draft" || got.ScheduledStart != "09:00" || got.ScheduledEnd != "10:00" {
+		t.Errorf("restored task = %q %s-%s, want Draft 09:00-10:00", got.Description, got.ScheduledStart, got.ScheduledEnd)
+	}
+	// The restore is history too
+	if revs, _ = repo.ListTaskRevisions(ctx, tsk.ID); len(revs) != 4 || revs[0].Description != "Final draft" {
+		t.Errorf("after restore got %d revisions, newest %+v; want 4, newest Final draft", len(revs), revs[0])
+	}
+
+	// A restore into a taken slot changes nothing
+	other := &task.Task{
+		Description:    "Standup",
+		Category:       task.CategoryShallow,
+		ScheduledDate:  date,
+		ScheduledStart: "11:00",
+		ScheduledEnd:   "12:00",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(ctx, other); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if err := task.RestoreRevision(ctx, repo, got, revs[1]); !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Fatalf("restore into taken slot = %v, want ErrTimeBlockOverlap", err)
+	}
+	if after, _ := repo.GetTask(ctx, tsk.ID); after.Description != "Draft" || after.ScheduledStart != "09:00" {
+		t.Errorf("failed restore changed the task to %q at %s", after.Description, after.ScheduledStart)
+	}
+}
+
+func TestMigrate_CreatesListingIndexes(t *testing.T) {
+	repo := newTestRepo(t)
+
+	for index, want := range map[string]string{
+		"idx_tasks_date_status":  "scheduled_date,status",
+		"idx_tasks_status_start": "status,scheduled_start",
+	} {
+		var columns string
+		err := repo.db.QueryRow(`SELECT group_concat(name, ',') FROM pragma_index_info(?)`, index).Scan(&columns)
+		if err != nil {
+			t.Fatalf("reading %s: %v", index, err)
+		}
+		if columns != want {
+			t.Errorf("%s columns = %q, want %q", index, columns, want)
+		}
+	}
+}
+
+func TestMigrate_TouchTriggerWatchesOwner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	repo, err := New(path)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	if err := repo.CreateTask(ctx, &task.Task{Description: "Report", Category: task.CategoryDeep, ScheduledDate: date, ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled}); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	// Put back the trigger of databases created before owners
+	if _, err := repo.db.Exec(`
+		DROP TRIGGER tasks_touch_update;
+		CREATE TRIGGER tasks_touch_update
+		AFTER UPDATE OF description, category, scheduled_date, scheduled_start, scheduled_end,
+		                status, outcome, energy, postponed_from, external_ref ON tasks
+		WHEN NEW.updated_at IS OLD.updated_at BEGIN
+			UPDATE tasks SET updated_at = ` + updatedAtSQL + ` WHERE id = NEW.id;
+		END;
+		UPDATE tasks SET updated_at = '1970-01-01T00:00:00.000Z';
+	`); err != nil {
+		t.Fatalf("restoring old trigger: %v", err)
+	}
+	_ = repo.Close()
+
+	repo, err = New(path)
+	if err != nil {
+		t.Fatalf("New after downgrade: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	if _, err := repo.db.Exec(`UPDATE tasks SET owner = 'ben'`); err != nil {
+		t.Fatalf("changing owner: %v", err)
+	}
+	var stamped string
+	if err := repo.db.QueryRow(`SELECT updated_at FROM tasks`).Scan(&stamped); err != nil {
+		t.Fatalf("reading updated_at: %v", err)
+	}
+	if stamped == "1970-01-01T00:00:00.000Z" {
+		t.Error("changing the owner did not stamp updated_at")
+	}
+}
+
+// BenchmarkListTasksByDateRange measures one week load on a database with a
+// few years of tasks, the query the TUI runs while navigating.
+func BenchmarkListTasksByDateRange(b *testing.B) {
+	repo, err := New(filepath.Join(b.TempDir(), "bench.db"))
+	if err != nil {
+		b.Fatalf("New: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	ctx := context.Background()
+	first := time.Date(2023, 1, 2, 0, 0, 0, 0, time.Local)
+	var tasks []*task.Task
+	for day := range 3 * 365 {
+		for hour := 9; hour < 17; hour += 2 {
+			tasks = append(tasks, &task.Task{
+				Description:    "Block",
+				Category:       task.CategoryDeep,
+				ScheduledDate:  first.AddDate(0, 0, day),
+				ScheduledStart: fmt.Sprintf("%02d:00", hour),
+				ScheduledEnd:   fmt.Sprintf("%02d:00", hour+1),
+				Status:         task.StatusScheduled,
+			})
+		}
+	}
+	if err := repo.CreateTasks(ctx, tasks); err != nil {
+		b.Fatalf("CreateTasks: %v", err)
+	}
+
+	week := first.AddDate(0, 0, 500)
+	for b.Loop() {
+		if _, err := repo.ListTasksByDateRange(ctx, week, week.AddDate(0, 0, 6)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBacklog(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	ref := task.ExternalRef{Source: "github", ID: "acme/api#7"}
+
+	item := &task.BacklogItem{Title: "#7 Fix login", Notes: "https://github.com/acme/api/issues/7", ExternalRef: ref}
+	created, err := repo.UpsertBacklogItem(ctx, item)
+	if err != nil || !created {
+		t.Fatalf("UpsertBacklogItem = %v, %v, want created", created, err)
+	}
+	if _, err := repo.UpsertBacklogItem(ctx, &task.BacklogItem{Title: "Write docs"}); err != nil {
+		t.Fatalf("UpsertBacklogItem without reference: %v", err)
+	}
+	created, err = repo.UpsertBacklogItem(ctx, &task.BacklogItem{Title: "#7 Fix login flow", Notes: item.Notes, ExternalRef: ref})
+	if err != nil || created {
+		t.Fatalf("UpsertBacklogItem again = %v, %v, want updated", created, err)
+	}
+
+	items, err := repo.ListBacklog(ctx)
+	if err != nil {
+		t.Fatalf("ListBacklog: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if items[0].Title != "#7 Fix login flow" || items[0].ExternalRef != ref || items[0].Notes != item.Notes {
+		t.Errorf("first item = %+v", items[0])
+	}
+
+	if err := repo.DeleteBacklogItem(ctx, items[0].ID); err != nil {
+		t.Fatalf("DeleteBacklogItem: %v", err)
+	}
+	if items, _ = repo.ListBacklog(ctx); len(items) != 1 || items[0].Title != "Write docs" {
+		t.Errorf("backlog after delete = %+v", items)
+	}
+}
+
+func TestBacklog_Park(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	day := time.Date(2025, 1, 7, 0, 0, 0, 0, time.Local)
+
+	parked := &task.BacklogItem{Title: "Call the bank", Day: day}
+	if _, err := repo.UpsertBacklogItem(ctx, parked); err != nil {
+		t.Fatalf("UpsertBacklogItem: %v", err)
+	}
+	open := &task.BacklogItem{Title: "Write docs"}
+	if _, err := repo.UpsertBacklogItem(ctx, open); err != nil {
+		t.Fatalf("UpsertBacklogItem: %v", err)
+	}
+	if err := repo.ParkBacklogItem(ctx, open.ID, day.AddDate(0, 0, 1)); err != nil {
+		t.Fatalf("ParkBacklogItem: %v", err)
+	}
+
+	items, err := repo.ListBacklog(ctx)
+	if err != nil {
+		t.Fatalf("ListBacklog: %v", err)
+	}
+	byDay := task.ParkedItems(items)
+	if got := byDay["2025-01-07"]; len(got) != 1 || got[0].Title != "Call the bank" || !got[0].Day.Equal(day) {
+		t.Errorf("parked on Jan 7 = %+v", got)
+	}
+	if got := byDay["2025-01-08"]; len(got) != 1 || got[0].Title != "Write docs" {
+		t.Errorf("parked on Jan 8 = %+v", got)
+	}
+
+	if err := repo.ParkBacklogItem(ctx, parked.ID, time.Time{}); err != nil {
+		t.Fatalf("ParkBacklogItem unpark: %v", err)
+	}
+	if items, _ = repo.ListBacklog(ctx); items[0].IsParked() {
+		t.Errorf("item still parked after unparking: %+v", items[0])
+	}
+	if err := repo.ParkBacklogItem(ctx, 999, day); err == nil {
+		t.Error("ParkBacklogItem on a missing item should fail")
+	}
+}
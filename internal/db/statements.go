@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// insertTaskSQL inserts a task; every code path that creates tasks uses it.
+const insertTaskSQL = `
+	INSERT INTO tasks (
+		description, category, scheduled_date, scheduled_start, scheduled_end,
+		status, outcome, energy, postponed_from, external_ref, owner, created_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+// overlapCandidatesSQL lists one owner's scheduled blocks from the day before
+// to the day after a date, except one task, for the overlap check.
+const overlapCandidatesSQL = `
+	SELECT id, scheduled_date, scheduled_start, scheduled_end, description
+	FROM tasks
+	WHERE scheduled_date >= ? AND scheduled_date <= ?
+	  AND status = ?
+	  AND id != ?
+	  AND owner = ?
+	ORDER BY scheduled_date, scheduled_start
+`
+
+// listRangeSQL lists the tasks in a date range, which the TUI runs for every
+// week it loads.
+const listRangeSQL = `
+	SELECT id, description, category, scheduled_date, scheduled_start, scheduled_end,
+	       status, outcome, energy, postponed_from, external_ref, owner, created_at, version
+	FROM tasks
+	WHERE scheduled_date >= ? AND scheduled_date <= ?
+	ORDER BY scheduled_date, scheduled_start
+`
+
+// statements are the queries run on every week load and write, prepared
+// once when the repository opens instead of parsed on every call.
+type statements struct {
+	insertTask        *sql.Stmt
+	overlapCandidates *sql.Stmt
+	listRange         *sql.Stmt
+}
+
+// prepareStatements prepares the statements on db. Prepare after migrating,
+// since SQLite statements are tied to the schema they were prepared for.
+func prepareStatements(db *sql.DB) (*statements, error) {
+	st := &statements{}
+	for _, p := range []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		{&st.insertTask, insertTaskSQL},
+		{&st.overlapCandidates, overlapCandidatesSQL},
+		{&st.listRange, listRangeSQL},
+	} {
+		stmt, err := db.Prepare(p.query)
+		if err != nil {
+			_ = st.close()
+			return nil, fmt.Errorf("preparing statement: %w", err)
+		}
+		*p.stmt = stmt
+	}
+	return st, nil
+}
+
+// close releases the prepared statements.
+func (st *statements) close() error {
+	var errs []error
+	for _, stmt := range []*sql.Stmt{st.insertTask, st.overlapCandidates, st.listRange} {
+		if stmt != nil {
+			errs = append(errs, stmt.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// inTx returns stmt bound to tx, or stmt itself when tx is nil. The bound
+// statement is closed with the transaction.
+func inTx(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt) *sql.Stmt {
+	if tx == nil {
+		return stmt
+	}
+	return tx.StmtContext(ctx, stmt)
+}
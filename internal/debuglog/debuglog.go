@@ -0,0 +1,172 @@
+// Package debuglog is sancho's leveled debug log. Records are written with
+// slog as JSON lines to a file that is rotated by size. Nothing is written
+// until Open is called, which the --debug flag does.
+package debuglog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	maxSize  = 5 << 20                // Bytes a log file grows to before it is rotated
+	keep     = 3                      // Rotated files kept, path.1 being the newest
+	slowSpan = 100 * time.Millisecond // Spans this long are logged as warnings
+)
+
+var (
+	mu     sync.Mutex
+	logger = slog.New(slog.DiscardHandler)
+	file   *rotatingFile
+)
+
+// Path returns the default log file, in the system's temporary directory.
+func Path() string {
+	return filepath.Join(os.TempDir(), "sancho-debug.log")
+}
+
+// ParseLevel parses "debug", "info", "warn" or "error".
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown debug level %q (use debug, info, warn or error)", s)
+	}
+	return level, nil
+}
+
+// Open starts logging records at level or above to path, replacing any log
+// opened before.
+func Open(path string, level slog.Level) error {
+	f, err := openRotating(path, maxSize)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		_ = file.Close()
+	}
+	file = f
+	logger = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: level}))
+	return nil
+}
+
+// Close stops logging and closes the log file.
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	logger = slog.New(slog.DiscardHandler)
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	file = nil
+	return err
+}
+
+// Logger returns the debug logger. It discards everything until Open.
+func Logger() *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return logger
+}
+
+// Enabled reports whether records at level are written, so callers can
+// skip building costly attributes.
+func Enabled(level slog.Level) bool {
+	return Logger().Enabled(context.Background(), level)
+}
+
+// Span times an operation: call it when the operation starts and the
+// returned function when it ends. The duration is logged at debug level,
+// or as a warning when it took longer than 100ms.
+//
+//	defer debuglog.Span("db.list_tasks", "from", start)()
+func Span(name string, attrs ...any) func() {
+	if !Enabled(slog.LevelWarn) {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		d := time.Since(start)
+		level := slog.LevelDebug
+		if d > slowSpan {
+			level = slog.LevelWarn
+		}
+		Logger().Log(context.Background(), level, name, append(attrs, slog.Duration("duration", d))...)
+	}
+}
+
+// rotatingFile is a log file that is renamed to path.1 once it reaches
+// max bytes, shifting older files up to path.<keep>.
+type rotatingFile struct {
+	mu   sync.Mutex
+	path string
+	max  int64
+	f    *os.File
+	size int64
+}
+
+var _ io.WriteCloser = (*rotatingFile)(nil)
+
+func openRotating(path string, max int64) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, max: max}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening debug log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("opening debug log: %w", err)
+	}
+	r.f, r.size = f, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > r.max {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate closes the file, shifts the older ones and starts a new file.
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	for i := keep - 1; i >= 1; i-- {
+		_ = os.Rename(r.path+"."+strconv.Itoa(i), r.path+"."+strconv.Itoa(i+1))
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("rotating debug log: %w", err)
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
@@ -0,0 +1,88 @@
+package debuglog
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+	r, err := openRotating(path, 10)
+	if err != nil {
+		t.Fatalf("openRotating: %v", err)
+	}
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n", "fifth\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := map[string]string{
+		path:        "fifth\n",
+		path + ".1": "fourth\n",
+		path + ".2": "third\n",
+		path + ".3": "second\n",
+	}
+	for p, content := range want {
+		got, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatalf("reading %s: %v", p, err)
+		}
+		if string(got) != content {
+			t.Errorf("%s = %q, want %q", filepath.Base(p), got, content)
+		}
+	}
+	if _, err := os.Stat(path + ".4"); !os.IsNotExist(err) {
+		t.Errorf("expected only %d rotated files", keep)
+	}
+}
+
+func TestOpenLevelsAndSpans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+	if Enabled(slog.LevelError) {
+		t.Fatal("nothing should be logged before Open")
+	}
+
+	level, err := ParseLevel("info")
+	if err != nil {
+		t.Fatalf("ParseLevel: %v", err)
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+	if err := Open(path, level); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	Logger().Debug("hidden")
+	Logger().Info("shown", "key", "j")
+	Span("fast span")() // Debug level, below info
+	if err := Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening log: %v", err)
+	}
+	defer f.Close()
+	var msgs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		msgs = append(msgs, rec["msg"].(string))
+	}
+	if got := strings.Join(msgs, ","); got != "shown" {
+		t.Errorf("logged %q, want only the info record", got)
+	}
+}
@@ -0,0 +1,79 @@
+package dwplanner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/llm"
+	"github.com/javiermolinar/sancho/internal/scheduler"
+)
+
+// autoPlanDays is how far ahead the auto-planner looks for free time.
+const autoPlanDays = scheduler.DefaultAutoDays
+
+// AutoPlan schedules the input without an LLM using the rule-based scheduler.
+// It is a fallback for when no LLM is configured or the API is unavailable.
+// Fixed appointments and blocked windows in the input are kept free.
+// Items that cannot be placed within the next week are reported as warnings.
+func (p *Planner) AutoPlan(ctx context.Context, input string) (*PlanResult, error) {
+	return p.autoPlanAt(ctx, input, p.now())
+}
+
+func (p *Planner) autoPlanAt(ctx context.Context, input string, now time.Time) (*PlanResult, error) {
+	input, constraints := ParseConstraints(input, now)
+	p.constraints = constraints
+
+	items, err := scheduler.ParseAutoItems(input)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := p.fetchExistingTasks(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("fetching existing tasks: %w", err)
+	}
+	p.existingTasks = existing
+
+	busy := scheduler.BusyFromTasks(existing)
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := 0; i <= autoPlanDays; i++ { // The window may start tomorrow
+		date := today.AddDate(0, 0, i)
+		for _, c := range constraints {
+			if c.AppliesTo(date) {
+				busy = append(busy, scheduler.Busy{Date: date, Start: c.Start, End: c.End})
+			}
+		}
+	}
+
+	// A configured buffer replaces the scheduler's default gap
+	placed, unplaced := p.scheduler.AutoSchedule(items, busy, scheduler.AutoOptions{
+		Now:           now,
+		Days:          autoPlanDays,
+		BufferMinutes: p.config.Schedule.BufferMinutes,
+	})
+
+	resp := &llm.PlanResponse{}
+	for _, pl := range placed {
+		resp.Tasks = append(resp.Tasks, llm.PlannedTask{
+			Description:    pl.Item.Description,
+			Category:       pl.Item.Category,
+			ScheduledDate:  pl.Date.Format("2006-01-02"),
+			ScheduledStart: pl.Start,
+			ScheduledEnd:   pl.End,
+		})
+	}
+	for _, item := range unplaced {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("Could not fit %q (%d min) in the next week", item.Description, item.Minutes))
+	}
+
+	slot := p.scheduler.NextAvailableStart(now)
+	effectiveEnd := p.config.Schedule.DayEnd
+	availableMinutes := p.scheduler.AvailableMinutes(scheduler.AvailableSlot{
+		Start: slot.Start,
+		End:   effectiveEnd,
+	})
+
+	return p.buildResult(ctx, resp, slot.Date, slot.Start, effectiveEnd, availableMinutes, nil), nil
+}
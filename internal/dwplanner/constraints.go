@@ -0,0 +1,245 @@
+package dwplanner
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// ConstraintKind distinguishes fixed appointments from blocked windows.
+type ConstraintKind string
+
+const (
+	// ConstraintFixed is a pinned appointment such as a daily standup.
+	ConstraintFixed ConstraintKind = "fixed"
+	// ConstraintBlocked is a window that must stay free of planned work.
+	ConstraintBlocked ConstraintKind = "blocked"
+)
+
+// Constraint is a time window the planner must not schedule over.
+type Constraint struct {
+	Kind     ConstraintKind
+	Label    string
+	Start    string         // "HH:MM"
+	End      string         // "HH:MM"
+	Date     time.Time      // Specific day; zero for recurring constraints
+	Weekdays []time.Weekday // Recurring days; empty with a zero Date means every day
+}
+
+// AppliesTo reports whether the constraint is active on the given date.
+func (c Constraint) AppliesTo(date time.Time) bool {
+	if !c.Date.IsZero() {
+		return task.CalendarDaysBetween(c.Date, date) == 0
+	}
+	if len(c.Weekdays) == 0 {
+		return true
+	}
+	for _, wd := range c.Weekdays {
+		if date.Weekday() == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// When describes the days the constraint applies to.
+func (c Constraint) When() string {
+	switch {
+	case !c.Date.IsZero():
+		return c.Date.Format("2006-01-02")
+	case len(c.Weekdays) == 0:
+		return "daily"
+	case len(c.Weekdays) == 5 && isWorkweek(c.Weekdays):
+		return "weekdays"
+	}
+	names := make([]string, len(c.Weekdays))
+	for i, wd := range c.Weekdays {
+		names[i] = wd.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// String returns a one-line description, e.g. "standup 10:00-10:15 (fixed, daily)".
+func (c Constraint) String() string {
+	return fmt.Sprintf("%s %s-%s (%s, %s)", c.Label, c.Start, c.End, c.Kind, c.When())
+}
+
+var (
+	constraintRangeRe = regexp.MustCompile(`\b(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})\b`)
+	constraintDateRe  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+var blockedPrefixes = []string{"blocked", "block", "busy", "unavailable", "no "}
+
+// fixedPrefix marks a one-off fixed appointment, e.g. "fixed: dentist 15:00-16:00".
+const fixedPrefix = "fixed:"
+
+var weekdayWords = map[string]time.Weekday{
+	"monday": time.Monday, "mondays": time.Monday,
+	"tuesday": time.Tuesday, "tuesdays": time.Tuesday,
+	"wednesday": time.Wednesday, "wednesdays": time.Wednesday,
+	"thursday": time.Thursday, "thursdays": time.Thursday,
+	"friday": time.Friday, "fridays": time.Friday,
+	"saturday": time.Saturday, "saturdays": time.Saturday,
+	"sunday": time.Sunday, "sundays": time.Sunday,
+}
+
+// ParseConstraints extracts fixed appointments and blocked windows from plan
+// input and returns the remaining input for the LLM.
+//
+// A clause (separated by ",", ";" or a newline) is a constraint when it
+// contains an explicit "HH:MM-HH:MM" range and a marker. Clauses starting
+// with "block", "busy", "unavailable" or "no" are blocked windows. Clauses
+// starting with "fixed:" or repeating ("daily", "every", "weekdays" or
+// weekday names) are fixed appointments. Any other clause with a range is a
+// task with a requested time and is left for the LLM, so "write doc
+// 09:00-11:00" is still planned. Days are taken from the repeat words,
+// "today", "tomorrow" or a YYYY-MM-DD date. Without a day, fixed
+// appointments apply today and blocked windows apply every day.
+//
+// Example: "standup 10:00-10:15 daily, block 12:00-13:00, fixed: dentist 15:00-16:00 tomorrow"
+func ParseConstraints(input string, now time.Time) (string, []Constraint) {
+	clauses := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ';' || r == '\n' })
+
+	var (
+		constraints []Constraint
+		rest        []string
+	)
+	for _, clause := range clauses {
+		c, ok := parseConstraint(strings.TrimSpace(clause), now)
+		if !ok {
+			if trimmed := strings.TrimSpace(clause); trimmed != "" {
+				rest = append(rest, trimmed)
+			}
+			continue
+		}
+		constraints = append(constraints, c)
+	}
+
+	if len(constraints) == 0 {
+		return input, nil
+	}
+	return strings.Join(rest, ", "), constraints
+}
+
+func parseConstraint(clause string, now time.Time) (Constraint, bool) {
+	m := constraintRangeRe.FindStringSubmatchIndex(clause)
+	if m == nil {
+		return Constraint{}, false
+	}
+	start, ok1 := clockValue(clause[m[2]:m[3]], clause[m[4]:m[5]])
+	end, ok2 := clockValue(clause[m[6]:m[7]], clause[m[8]:m[9]])
+	if !ok1 || !ok2 || end <= start {
+		return Constraint{}, false
+	}
+
+	c := Constraint{
+		Kind:  ConstraintFixed,
+		Start: start,
+		End:   end,
+	}
+	lower := strings.ToLower(clause)
+	marked := false
+	for _, prefix := range blockedPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			c.Kind = ConstraintBlocked
+			marked = true
+			break
+		}
+	}
+	if strings.HasPrefix(lower, fixedPrefix) {
+		marked = true
+		clause = strings.TrimSpace(clause[len(fixedPrefix):])
+		m = constraintRangeRe.FindStringSubmatchIndex(clause)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var (
+		words    []string
+		hasDays  bool
+		weekdays []time.Weekday
+	)
+	for _, f := range strings.Fields(clause[:m[0]] + " " + clause[m[1]:]) {
+		word := strings.ToLower(f)
+		switch {
+		case word == "daily" || word == "every" || word == "day" || word == "each":
+			hasDays, marked = true, true
+		case word == "weekdays":
+			hasDays, marked = true, true
+			weekdays = append(weekdays, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+		case word == "today":
+			hasDays = true
+			c.Date = today
+		case word == "tomorrow":
+			hasDays = true
+			c.Date = today.AddDate(0, 0, 1)
+		case constraintDateRe.MatchString(word):
+			if d, err := time.ParseInLocation("2006-01-02", word, now.Location()); err == nil {
+				hasDays = true
+				c.Date = d
+			}
+		default:
+			if wd, ok := weekdayWords[word]; ok {
+				hasDays, marked = true, true
+				weekdays = append(weekdays, wd)
+				continue
+			}
+			words = append(words, f)
+		}
+	}
+	if !marked {
+		return Constraint{}, false
+	}
+	if c.Date.IsZero() {
+		c.Weekdays = weekdays
+	}
+	if !hasDays && c.Kind == ConstraintFixed {
+		c.Date = today
+	}
+
+	if c.Kind == ConstraintBlocked && len(words) > 0 {
+		if first := strings.ToLower(words[0]); first == "block" || first == "blocked" {
+			words = words[1:]
+		}
+	}
+	c.Label = strings.Join(words, " ")
+	if c.Label == "" {
+		c.Label = string(c.Kind)
+	}
+	return c, true
+}
+
+// clockValue normalizes an hour and minute pair to "HH:MM".
+func clockValue(hour, minute string) (string, bool) {
+	h, err := strconv.Atoi(hour)
+	if err != nil || h > 23 {
+		return "", false
+	}
+	m, err := strconv.Atoi(minute)
+	if err != nil || m > 59 {
+		return "", false
+	}
+	return fmt.Sprintf("%02d:%02d", h, m), true
+}
+
+func isWorkweek(days []time.Weekday) bool {
+	for _, wd := range days {
+		if wd == time.Saturday || wd == time.Sunday {
+			return false
+		}
+	}
+	return true
+}
+
+// formatConstraints renders constraints as prompt lines for the LLM.
+func formatConstraints(constraints []Constraint) []string {
+	lines := make([]string, len(constraints))
+	for i, c := range constraints {
+		lines[i] = c.String()
+	}
+	return lines
+}
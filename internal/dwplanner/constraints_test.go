@@ -0,0 +1,108 @@
+package dwplanner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseConstraints(t *testing.T) {
+	now := time.Date(2025, 1, 13, 8, 0, 0, 0, time.Local) // Monday
+
+	rest, constraints := ParseConstraints("write report, standup 10:00-10:15 daily; block 12:00-13:00 lunch\nreview PRs, fixed: dentist 15:00-16:00 tomorrow", now)
+
+	if rest != "write report, review PRs" {
+		t.Errorf("rest = %q, want %q", rest, "write report, review PRs")
+	}
+	if len(constraints) != 3 {
+		t.Fatalf("expected 3 constraints, got %d", len(constraints))
+	}
+
+	tests := []struct {
+		kind  ConstraintKind
+		label string
+		start string
+		end   string
+		when  string
+	}{
+		{ConstraintFixed, "standup", "10:00", "10:15", "daily"},
+		{ConstraintBlocked, "lunch", "12:00", "13:00", "daily"},
+		{ConstraintFixed, "dentist", "15:00", "16:00", "2025-01-14"},
+	}
+	for i, tt := range tests {
+		c := constraints[i]
+		if c.Kind != tt.kind || c.Label != tt.label || c.Start != tt.start || c.End != tt.end || c.When() != tt.when {
+			t.Errorf("constraint %d = %s, want %s %s %s-%s (%s)", i, c, tt.kind, tt.label, tt.start, tt.end, tt.when)
+		}
+	}
+}
+
+func TestParseConstraints_NoConstraints(t *testing.T) {
+	input := "Write thesis introduction, review PRs"
+	rest, constraints := ParseConstraints(input, time.Now())
+	if rest != input {
+		t.Errorf("rest = %q, want input unchanged", rest)
+	}
+	if constraints != nil {
+		t.Errorf("expected no constraints, got %v", constraints)
+	}
+}
+
+func TestParseConstraints_UnmarkedRangeIsTask(t *testing.T) {
+	now := time.Date(2025, 1, 13, 8, 0, 0, 0, time.Local) // Monday
+
+	input := "write doc 09:00-11:00, call Ana 14:00-14:30 tomorrow"
+	rest, constraints := ParseConstraints(input, now)
+	if rest != input {
+		t.Errorf("rest = %q, want input unchanged", rest)
+	}
+	if constraints != nil {
+		t.Errorf("expected no constraints, got %v", constraints)
+	}
+}
+
+func TestParseConstraints_Days(t *testing.T) {
+	now := time.Date(2025, 1, 13, 8, 0, 0, 0, time.Local) // Monday
+
+	tests := []struct {
+		name  string
+		input string
+		when  string
+	}{
+		{"fixed defaults to today", "fixed: 1:1 9:30-10:00", "2025-01-13"},
+		{"blocked defaults to daily", "busy 16:00-17:00", "daily"},
+		{"weekdays", "standup 9:00-9:15 weekdays", "weekdays"},
+		{"weekday names", "no meetings 13:00-15:00 tuesdays thursdays", "Tuesday, Thursday"},
+		{"explicit date", "fixed: demo 11:00-12:00 2025-01-17", "2025-01-17"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, constraints := ParseConstraints(tt.input, now)
+			if len(constraints) != 1 {
+				t.Fatalf("expected 1 constraint, got %d", len(constraints))
+			}
+			if got := constraints[0].When(); got != tt.when {
+				t.Errorf("When() = %q, want %q", got, tt.when)
+			}
+		})
+	}
+}
+
+func TestConstraint_AppliesTo(t *testing.T) {
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	saturday := time.Date(2025, 1, 18, 0, 0, 0, 0, time.Local)
+
+	weekdays := Constraint{Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}}
+	if !weekdays.AppliesTo(monday) || weekdays.AppliesTo(saturday) {
+		t.Error("weekday constraint should apply on Monday only")
+	}
+
+	single := Constraint{Date: monday}
+	if !single.AppliesTo(monday.Add(15*time.Hour)) || single.AppliesTo(monday.AddDate(0, 0, 1)) {
+		t.Error("dated constraint should apply on its date only")
+	}
+
+	daily := Constraint{}
+	if !daily.AppliesTo(saturday) {
+		t.Error("daily constraint should apply every day")
+	}
+}
@@ -0,0 +1,120 @@
+package dwplanner
+
+import (
+	"sort"
+	"strings"
+)
+
+// ChangeKind classifies how a planned task differs between two plan results.
+type ChangeKind string
+
+const (
+	ChangeUnchanged ChangeKind = "unchanged"
+	ChangeAdded     ChangeKind = "added"
+	ChangeRemoved   ChangeKind = "removed"
+	ChangeMoved     ChangeKind = "moved"   // Date or time changed
+	ChangeUpdated   ChangeKind = "updated" // Same slot, different category
+)
+
+// PlanChange describes one task in a plan diff. Before is nil for added tasks
+// and After is nil for removed tasks.
+type PlanChange struct {
+	Kind   ChangeKind
+	Before *PlannedTask
+	After  *PlannedTask
+}
+
+// Tasks returns all planned tasks sorted by date and start time.
+func (r *PlanResult) Tasks() []PlannedTask {
+	if r == nil {
+		return nil
+	}
+	var tasks []PlannedTask
+	for _, date := range r.SortedDates {
+		tasks = append(tasks, r.TasksByDate[date]...)
+	}
+	sortPlannedTasks(tasks)
+	return tasks
+}
+
+// DiffPlans compares two plan results. Tasks are matched by description
+// (case-insensitive), in schedule order when a description repeats.
+// Changes are ordered by their position in the new plan, with removed tasks
+// placed by their old position.
+func DiffPlans(prev, next *PlanResult) []PlanChange {
+	before := prev.Tasks()
+	after := next.Tasks()
+
+	unmatched := make(map[string][]int)
+	for i, t := range before {
+		key := diffKey(t)
+		unmatched[key] = append(unmatched[key], i)
+	}
+
+	matched := make([]bool, len(before))
+	changes := make([]PlanChange, 0, len(after)+len(before))
+	for i := range after {
+		a := &after[i]
+		key := diffKey(*a)
+		idxs := unmatched[key]
+		if len(idxs) == 0 {
+			changes = append(changes, PlanChange{Kind: ChangeAdded, After: a})
+			continue
+		}
+		bi := idxs[0]
+		unmatched[key] = idxs[1:]
+		matched[bi] = true
+		b := &before[bi]
+
+		kind := ChangeUnchanged
+		switch {
+		case a.ScheduledDate != b.ScheduledDate || a.ScheduledStart != b.ScheduledStart || a.ScheduledEnd != b.ScheduledEnd:
+			kind = ChangeMoved
+		case a.Category != b.Category:
+			kind = ChangeUpdated
+		}
+		changes = append(changes, PlanChange{Kind: kind, Before: b, After: a})
+	}
+
+	for i := range before {
+		if !matched[i] {
+			changes = append(changes, PlanChange{Kind: ChangeRemoved, Before: &before[i]})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changeSortKey(changes[i]) < changeSortKey(changes[j])
+	})
+	return changes
+}
+
+// HasPlanChanges reports whether a diff contains anything besides unchanged tasks.
+func HasPlanChanges(changes []PlanChange) bool {
+	for _, c := range changes {
+		if c.Kind != ChangeUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+func diffKey(t PlannedTask) string {
+	return strings.ToLower(strings.TrimSpace(t.Description))
+}
+
+func changeSortKey(c PlanChange) string {
+	t := c.After
+	if t == nil {
+		t = c.Before
+	}
+	return t.ScheduledDate + " " + t.ScheduledStart
+}
+
+func sortPlannedTasks(tasks []PlannedTask) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].ScheduledDate != tasks[j].ScheduledDate {
+			return tasks[i].ScheduledDate < tasks[j].ScheduledDate
+		}
+		return tasks[i].ScheduledStart < tasks[j].ScheduledStart
+	})
+}
@@ -0,0 +1,91 @@
+package dwplanner
+
+import "testing"
+
+func planResultFrom(tasks ...PlannedTask) *PlanResult {
+	r := &PlanResult{TasksByDate: make(map[string][]PlannedTask)}
+	for _, t := range tasks {
+		if _, ok := r.TasksByDate[t.ScheduledDate]; !ok {
+			r.SortedDates = append(r.SortedDates, t.ScheduledDate)
+		}
+		r.TasksByDate[t.ScheduledDate] = append(r.TasksByDate[t.ScheduledDate], t)
+	}
+	return r
+}
+
+func TestDiffPlans(t *testing.T) {
+	prev := planResultFrom(
+		PlannedTask{Description: "Write report", Category: "deep", ScheduledDate: "2025-01-13", ScheduledStart: "09:00", ScheduledEnd: "11:00"},
+		PlannedTask{Description: "Email", Category: "shallow", ScheduledDate: "2025-01-13", ScheduledStart: "14:00", ScheduledEnd: "14:30"},
+		PlannedTask{Description: "Review PRs", Category: "deep", ScheduledDate: "2025-01-13", ScheduledStart: "15:00", ScheduledEnd: "16:00"},
+		PlannedTask{Description: "Plan sprint", Category: "deep", ScheduledDate: "2025-01-14", ScheduledStart: "09:00", ScheduledEnd: "10:00"},
+	)
+	next := planResultFrom(
+		PlannedTask{Description: "write report", Category: "deep", ScheduledDate: "2025-01-13", ScheduledStart: "09:00", ScheduledEnd: "11:00"},
+		PlannedTask{Description: "Email", Category: "deep", ScheduledDate: "2025-01-13", ScheduledStart: "14:00", ScheduledEnd: "14:30"},
+		PlannedTask{Description: "Review PRs", Category: "deep", ScheduledDate: "2025-01-14", ScheduledStart: "10:30", ScheduledEnd: "11:30"},
+		PlannedTask{Description: "Call vendor", Category: "shallow", ScheduledDate: "2025-01-14", ScheduledStart: "13:00", ScheduledEnd: "13:30"},
+	)
+
+	changes := DiffPlans(prev, next)
+
+	want := []struct {
+		kind ChangeKind
+		desc string
+	}{
+		{ChangeUnchanged, "write report"},
+		{ChangeUpdated, "Email"},
+		{ChangeRemoved, "Plan sprint"},
+		{ChangeMoved, "Review PRs"},
+		{ChangeAdded, "Call vendor"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(changes), len(want))
+	}
+	for i, w := range want {
+		c := changes[i]
+		task := c.After
+		if task == nil {
+			task = c.Before
+		}
+		if c.Kind != w.kind || task.Description != w.desc {
+			t.Errorf("change %d = %s %q, want %s %q", i, c.Kind, task.Description, w.kind, w.desc)
+		}
+	}
+
+	moved := changes[3]
+	if moved.Before.ScheduledDate != "2025-01-13" || moved.After.ScheduledDate != "2025-01-14" {
+		t.Errorf("moved change should keep both positions, got %+v -> %+v", moved.Before, moved.After)
+	}
+	if !HasPlanChanges(changes) {
+		t.Error("expected HasPlanChanges to be true")
+	}
+}
+
+func TestDiffPlans_RepeatedDescriptions(t *testing.T) {
+	prev := planResultFrom(
+		PlannedTask{Description: "Focus", ScheduledDate: "2025-01-13", ScheduledStart: "09:00", ScheduledEnd: "10:00"},
+		PlannedTask{Description: "Focus", ScheduledDate: "2025-01-13", ScheduledStart: "11:00", ScheduledEnd: "12:00"},
+	)
+	next := planResultFrom(
+		PlannedTask{Description: "Focus", ScheduledDate: "2025-01-13", ScheduledStart: "09:00", ScheduledEnd: "10:00"},
+	)
+
+	changes := DiffPlans(prev, next)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2", len(changes))
+	}
+	if changes[0].Kind != ChangeUnchanged || changes[1].Kind != ChangeRemoved {
+		t.Errorf("kinds = %s, %s; want unchanged, removed", changes[0].Kind, changes[1].Kind)
+	}
+	if changes[1].Before.ScheduledStart != "11:00" {
+		t.Errorf("expected the 11:00 block to be removed, got %s", changes[1].Before.ScheduledStart)
+	}
+}
+
+func TestDiffPlans_NoChanges(t *testing.T) {
+	r := planResultFrom(PlannedTask{Description: "Focus", ScheduledDate: "2025-01-13", ScheduledStart: "09:00", ScheduledEnd: "10:00"})
+	if HasPlanChanges(DiffPlans(r, r)) {
+		t.Error("identical plans should have no changes")
+	}
+}
@@ -9,10 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/dateutil"
 	"github.com/javiermolinar/sancho/internal/llm"
 	"github.com/javiermolinar/sancho/internal/scheduler"
 	"github.com/javiermolinar/sancho/internal/task"
@@ -32,15 +35,18 @@ type Planner struct {
 	messages      []llm.Message
 	existingTasks []*task.Task
 	lastResponse  *llm.PlanResponse
+	constraints   []Constraint
+
+	// onChunk receives streamed LLM output, tagged with the attempt number.
+	onChunk func(attempt int, chunk string)
+
+	clock clock.Clock
 }
 
+// useCompactPrompt reports whether the provider runs a local model that
+// benefits from the shorter prompt variant.
 func useCompactPrompt(provider string) bool {
-	switch strings.ToLower(strings.TrimSpace(provider)) {
-	case llm.ProviderOllama, llm.ProviderLMStudio, "lm-studio", "llmstudio":
-		return true
-	default:
-		return false
-	}
+	return llm.IsLocalProvider(provider)
 }
 
 // New creates a new Planner with the given dependencies.
@@ -54,9 +60,50 @@ func New(client llm.Client, cfg *config.Config, repo task.Repository) *Planner {
 	}
 }
 
+// NewFromConfig creates a Planner backed by the LLM provider configured in cfg.
+func NewFromConfig(cfg *config.Config, repo task.Repository) (*Planner, error) {
+	client, err := llm.NewClientFromConfig(cfg.LLM)
+	if err != nil {
+		return nil, fmt.Errorf("creating LLM client: %w", err)
+	}
+	return New(client, cfg, repo), nil
+}
+
+// SetStreamHandler registers a callback that receives LLM output as it streams.
+// The attempt number increases on each validation retry so callers can discard
+// output from the previous attempt.
+func (p *Planner) SetStreamHandler(fn func(attempt int, chunk string)) {
+	p.onChunk = fn
+}
+
+func (p *Planner) chunkHandler(attempt int) func(string) {
+	if p.onChunk == nil {
+		return nil
+	}
+	return func(chunk string) {
+		p.onChunk(attempt, chunk)
+	}
+}
+
+// SetClock sets the clock used for "now" when planning and validating.
+func (p *Planner) SetClock(c clock.Clock) {
+	p.clock = c
+}
+
+// now returns the current time in today's pinned timezone, so planning and
+// validation follow the wall clock of a travel day.
+func (p *Planner) now() time.Time {
+	now := clock.OrSystem(p.clock).Now()
+	return now.In(p.config.LocationFor(now))
+}
+
 // PlanRequest contains the input for planning.
 type PlanRequest struct {
 	Input string // Natural language description of tasks
+
+	// Constraints are fixed appointments and blocked windows in addition to
+	// any parsed from Input.
+	Constraints []Constraint
 }
 
 // PlanResult contains the result of a planning operation.
@@ -74,6 +121,13 @@ type PlanResult struct {
 	// Validation info (populated if retries exhausted)
 	ValidationErrors []ValidationError
 
+	// Dropped lists entries of a malformed LLM response that were left out.
+	// FixDropped asks the model to resend them.
+	Dropped []llm.DroppedTask
+
+	// Constraints the plan was validated against
+	Constraints []Constraint
+
 	// Context for display
 	EffectiveStart   string
 	EffectiveEnd     string
@@ -85,10 +139,14 @@ type PlanResult struct {
 // PlannedTask represents a single planned task.
 type PlannedTask struct {
 	Description    string
-	Category       string // "deep" or "shallow"
+	Category       string // "deep", "shallow" or a configured category
 	ScheduledDate  string // YYYY-MM-DD
 	ScheduledStart string // "HH:MM"
 	ScheduledEnd   string // "HH:MM"
+	Energy         string // "high", "medium", "low" or empty
+
+	// Issues lists validation problems for this task, shown inline.
+	Issues []string
 }
 
 // TotalTasks returns the total number of planned tasks across all days.
@@ -109,7 +167,7 @@ func (r *PlanResult) HasValidationErrors() bool {
 // It fetches existing tasks, calls the LLM, validates the response, and retries on failure.
 // If maxRetries are exhausted, returns result with ValidationErrors populated.
 func (p *Planner) PlanWithRetry(ctx context.Context, req PlanRequest, maxRetries int) (*PlanResult, error) {
-	now := time.Now()
+	now := p.now()
 
 	// Fetch existing tasks for context
 	existing, err := p.fetchExistingTasks(ctx, now)
@@ -123,6 +181,24 @@ func (p *Planner) PlanWithRetry(ctx context.Context, req PlanRequest, maxRetries
 		return nil, fmt.Errorf("fetching recent tasks: %w", err)
 	}
 
+	limits, err := p.categoryLimits(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("fetching category limits: %w", err)
+	}
+
+	objectives, err := p.objectiveBalances(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("fetching objectives: %w", err)
+	}
+
+	parked, err := p.parkedTasks(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("fetching parked tasks: %w", err)
+	}
+
+	input, constraints := ParseConstraints(req.Input, now)
+	p.constraints = append(append([]Constraint(nil), req.Constraints...), constraints...)
+
 	// Calculate scheduling context
 	slot := p.scheduler.NextAvailableStart(now)
 	effectiveStart := slot.Start
@@ -139,13 +215,17 @@ func (p *Planner) PlanWithRetry(ctx context.Context, req PlanRequest, maxRetries
 
 	// Build initial LLM request
 	llmReq := llm.PlanRequest{
-		Input:            req.Input,
+		Input:            input,
 		Date:             now,
 		DayStart:         effectiveStart,
 		DayEnd:           effectiveEnd,
 		NextWorkday:      nextWorkdaySlot.Date.Format("Monday, January 2"),
 		ExistingTasks:    p.convertToExistingTasks(existing),
 		RecentTasks:      p.convertToExistingTasks(recent),
+		Constraints:      formatConstraints(p.constraints),
+		CategoryLimits:   limits,
+		Objectives:       objectives,
+		Parked:           parked,
 		UseCompactPrompt: useCompactPrompt(p.config.LLM.Provider),
 	}
 
@@ -154,13 +234,13 @@ func (p *Planner) PlanWithRetry(ctx context.Context, req PlanRequest, maxRetries
 	p.messages = llmPlanner.BuildInitialMessages(llmReq)
 
 	// Add user message
-	p.messages = append(p.messages, llm.Message{Role: "user", Content: req.Input})
+	p.messages = append(p.messages, llm.Message{Role: "user", Content: input})
 
 	// Validation loop
 	var lastValidation ValidationResult
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Call LLM
-		resp, err := llmPlanner.PlanWithMessages(ctx, p.messages)
+		resp, err := llmPlanner.PlanWithMessagesStream(ctx, p.messages, p.chunkHandler(attempt))
 		if err != nil {
 			return nil, fmt.Errorf("LLM planning (attempt %d): %w", attempt+1, err)
 		}
@@ -168,11 +248,12 @@ func (p *Planner) PlanWithRetry(ctx context.Context, req PlanRequest, maxRetries
 
 		// Validate response
 		validator := NewValidator(now, effectiveStart, effectiveEnd, existing)
+		validator.SetConstraints(p.constraints)
 		lastValidation = validator.Validate(resp.Tasks)
 
 		if lastValidation.Valid {
 			// Success - build result
-			return p.buildResult(resp, slot.Date, effectiveStart, effectiveEnd, availableMinutes, nil), nil
+			return p.buildResult(ctx, resp, slot.Date, effectiveStart, effectiveEnd, availableMinutes, nil), nil
 		}
 
 		// Validation failed - append error feedback for retry
@@ -193,10 +274,16 @@ func (p *Planner) PlanWithRetry(ctx context.Context, req PlanRequest, maxRetries
 	}
 
 	// All retries exhausted - return result with errors
-	result := p.buildResult(p.lastResponse, slot.Date, effectiveStart, effectiveEnd, availableMinutes, lastValidation.Errors)
+	result := p.buildResult(ctx, p.lastResponse, slot.Date, effectiveStart, effectiveEnd, availableMinutes, lastValidation.Errors)
 	return result, nil
 }
 
+// HasSession reports whether the planner holds an LLM conversation that
+// ContinuePlanning can extend.
+func (p *Planner) HasSession() bool {
+	return p.llmClient != nil && len(p.messages) > 0
+}
+
 // ContinuePlanning adds context to the conversation and replans.
 // Used when user wants to modify the proposal.
 func (p *Planner) ContinuePlanning(ctx context.Context, additionalContext string, maxRetries int) (*PlanResult, error) {
@@ -204,7 +291,40 @@ func (p *Planner) ContinuePlanning(ctx context.Context, additionalContext string
 		return nil, errors.New("no active planning session")
 	}
 
-	now := time.Now()
+	// Pull out any new constraints from the user's context
+	additionalContext, constraints := ParseConstraints(additionalContext, p.now())
+	if len(constraints) > 0 {
+		p.constraints = append(p.constraints, constraints...)
+		additionalContext = strings.TrimSpace(additionalContext + "\n" + p.formatConstraintContext(constraints))
+	}
+	return p.replan(ctx, additionalContext, maxRetries)
+}
+
+// FixDropped shows the model the entries dropped from its last response and
+// asks for the whole plan again as valid JSON.
+func (p *Planner) FixDropped(ctx context.Context, maxRetries int) (*PlanResult, error) {
+	if len(p.messages) == 0 {
+		return nil, errors.New("no active planning session")
+	}
+	if p.lastResponse == nil || len(p.lastResponse.Dropped) == 0 {
+		return nil, errors.New("no dropped entries to fix")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("These entries of your last response could not be used:\n")
+	for _, d := range p.lastResponse.Dropped {
+		sb.WriteString("- " + d.String() + "\n")
+		if d.Entry != "" {
+			sb.WriteString("  " + d.Entry + "\n")
+		}
+	}
+	sb.WriteString("Return the complete plan again, including the tasks that were fine, as valid JSON matching the schema.")
+	return p.replan(ctx, sb.String(), maxRetries)
+}
+
+// replan sends content as the next user message and validates the new plan.
+func (p *Planner) replan(ctx context.Context, content string, maxRetries int) (*PlanResult, error) {
+	now := p.now()
 
 	// Calculate scheduling context
 	slot := p.scheduler.NextAvailableStart(now)
@@ -224,10 +344,9 @@ func (p *Planner) ContinuePlanning(ctx context.Context, additionalContext string
 		})
 	}
 
-	// Add user's additional context
 	p.messages = append(p.messages, llm.Message{
 		Role:    "user",
-		Content: additionalContext,
+		Content: content,
 	})
 
 	// Re-plan with updated messages
@@ -235,7 +354,7 @@ func (p *Planner) ContinuePlanning(ctx context.Context, additionalContext string
 
 	var lastValidation ValidationResult
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		resp, err := llmPlanner.PlanWithMessages(ctx, p.messages)
+		resp, err := llmPlanner.PlanWithMessagesStream(ctx, p.messages, p.chunkHandler(attempt))
 		if err != nil {
 			return nil, fmt.Errorf("LLM planning (attempt %d): %w", attempt+1, err)
 		}
@@ -243,10 +362,11 @@ func (p *Planner) ContinuePlanning(ctx context.Context, additionalContext string
 
 		// Validate response
 		validator := NewValidator(now, effectiveStart, effectiveEnd, p.existingTasks)
+		validator.SetConstraints(p.constraints)
 		lastValidation = validator.Validate(resp.Tasks)
 
 		if lastValidation.Valid {
-			return p.buildResult(resp, slot.Date, effectiveStart, effectiveEnd, availableMinutes, nil), nil
+			return p.buildResult(ctx, resp, slot.Date, effectiveStart, effectiveEnd, availableMinutes, nil), nil
 		}
 
 		// Retry with error feedback
@@ -264,7 +384,7 @@ func (p *Planner) ContinuePlanning(ctx context.Context, additionalContext string
 	}
 
 	// Return with validation errors
-	result := p.buildResult(p.lastResponse, slot.Date, effectiveStart, effectiveEnd, availableMinutes, lastValidation.Errors)
+	result := p.buildResult(ctx, p.lastResponse, slot.Date, effectiveStart, effectiveEnd, availableMinutes, lastValidation.Errors)
 	return result, nil
 }
 
@@ -289,7 +409,76 @@ func (p *Planner) Save(ctx context.Context, result *PlanResult) error {
 		return nil
 	}
 
-	return p.repo.CreateTasks(ctx, tasks)
+	if err := p.repo.CreateTasks(ctx, tasks); err != nil {
+		return err
+	}
+	if err := p.unparkPlanned(ctx, tasks); err != nil {
+		return err
+	}
+	return p.reserveBuffers(ctx, tasks)
+}
+
+// unparkPlanned takes the parked items that the saved tasks scheduled, on
+// the same day and with the same title, off their days.
+func (p *Planner) unparkPlanned(ctx context.Context, tasks []*task.Task) error {
+	backlog, err := task.BacklogOf(p.repo)
+	if errors.Is(err, task.ErrNoBacklog) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	items, err := backlog.ListBacklog(ctx)
+	if err != nil {
+		return fmt.Errorf("listing backlog: %w", err)
+	}
+	parked := task.ParkedItems(items)
+	for _, t := range tasks {
+		for _, item := range parked[t.ScheduledDate.Format("2006-01-02")] {
+			if strings.EqualFold(strings.TrimSpace(item.Title), strings.TrimSpace(t.Description)) {
+				if err := backlog.DeleteBacklogItem(ctx, item.ID); err != nil {
+					return fmt.Errorf("unparking %q: %w", item.Title, err)
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// reserveBuffers keeps the configured buffer free after each saved task,
+// shifting later tasks on the same day. Tasks are handled in chronological
+// order; a day without room for a buffer is left as planned.
+func (p *Planner) reserveBuffers(ctx context.Context, tasks []*task.Task) error {
+	buffer := p.config.Schedule.BufferMinutes
+	if buffer <= 0 {
+		return nil
+	}
+
+	sorted := make([]*task.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].ScheduledDate.Equal(sorted[j].ScheduledDate) {
+			return sorted[i].ScheduledDate.Before(sorted[j].ScheduledDate)
+		}
+		return sorted[i].ScheduledStart < sorted[j].ScheduledStart
+	})
+	for _, t := range sorted {
+		if _, _, err := task.ReserveBuffer(ctx, p.repo, t.ID, t.ScheduledDate, buffer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatConstraintContext describes constraints added during an amend.
+func (p *Planner) formatConstraintContext(constraints []Constraint) string {
+	var sb strings.Builder
+	sb.WriteString("Also respect these fixed appointments and blocked windows:\n")
+	for _, line := range formatConstraints(constraints) {
+		sb.WriteString("- " + line + "\n")
+	}
+	return sb.String()
 }
 
 // fetchExistingTasks retrieves all scheduled tasks from the given date onwards.
@@ -307,6 +496,70 @@ func (p *Planner) fetchRecentTasks(ctx context.Context, from time.Time) ([]*task
 	return p.repo.ListTasksByDateRange(ctx, historyStart, historyEnd)
 }
 
+// categoryLimits returns the weekly limits of the registered categories that
+// have one, with the time already scheduled in the week holding now.
+func (p *Planner) categoryLimits(ctx context.Context, now time.Time) ([]llm.CategoryLimit, error) {
+	var limits []llm.CategoryLimit
+	for _, info := range task.Categories() {
+		if info.WeeklyLimit <= 0 {
+			continue
+		}
+		used, limit, err := task.WeeklyUsage(ctx, p.repo, info.Name, now)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, llm.CategoryLimit{Category: string(info.Name), LimitMinutes: limit, UsedMinutes: used})
+	}
+	return limits, nil
+}
+
+// parkedTasks returns the backlog items parked on today or a later day, by
+// day. Repositories without a backlog have none.
+func (p *Planner) parkedTasks(ctx context.Context, now time.Time) ([]llm.ParkedTask, error) {
+	backlog, err := task.BacklogOf(p.repo)
+	if errors.Is(err, task.ErrNoBacklog) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	items, err := backlog.ListBacklog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing backlog: %w", err)
+	}
+	today := dateutil.TruncateToDay(now)
+	var parked []llm.ParkedTask
+	for _, item := range items {
+		if item.IsParked() && !item.Day.Before(today) {
+			parked = append(parked, llm.ParkedTask{Date: item.Day.Format("2006-01-02"), Title: item.Title})
+		}
+	}
+	sort.SliceStable(parked, func(i, j int) bool { return parked[i].Date < parked[j].Date })
+	return parked, nil
+}
+
+// objectiveBalances returns the objectives of the quarter holding now with
+// the time attached to each, the furthest behind first. Repositories without
+// objectives have none.
+func (p *Planner) objectiveBalances(ctx context.Context, now time.Time) ([]llm.ObjectiveBalance, error) {
+	progress, err := task.QuarterProgress(ctx, p.repo, task.Quarter(now), now)
+	if errors.Is(err, task.ErrNoObjectives) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]llm.ObjectiveBalance, len(progress))
+	for i, op := range progress {
+		balances[i] = llm.ObjectiveBalance{
+			Title:            op.Objective.Title,
+			TargetMinutes:    op.Objective.TargetMinutes,
+			ScheduledMinutes: op.ScheduledMinutes,
+		}
+	}
+	return balances, nil
+}
+
 // convertToExistingTasks converts task.Task slice to llm.ExistingTask slice.
 func (p *Planner) convertToExistingTasks(tasks []*task.Task) []llm.ExistingTask {
 	result := make([]llm.ExistingTask, 0, len(tasks))
@@ -326,12 +579,14 @@ func (p *Planner) convertToExistingTasks(tasks []*task.Task) []llm.ExistingTask
 }
 
 // buildResult creates a PlanResult from an LLM response.
-func (p *Planner) buildResult(resp *llm.PlanResponse, todayDate time.Time, effectiveStart, effectiveEnd string, availableMinutes int, validationErrors []ValidationError) *PlanResult {
+func (p *Planner) buildResult(ctx context.Context, resp *llm.PlanResponse, todayDate time.Time, effectiveStart, effectiveEnd string, availableMinutes int, validationErrors []ValidationError) *PlanResult {
 	result := &PlanResult{
 		TasksByDate:      make(map[string][]PlannedTask),
 		Warnings:         resp.Warnings,
 		Suggestions:      resp.Suggestions,
 		ValidationErrors: validationErrors,
+		Dropped:          resp.Dropped,
+		Constraints:      p.constraints,
 		EffectiveStart:   effectiveStart,
 		EffectiveEnd:     effectiveEnd,
 		AvailableMinutes: availableMinutes,
@@ -339,18 +594,30 @@ func (p *Planner) buildResult(resp *llm.PlanResponse, todayDate time.Time, effec
 		TodayDate:        todayDate,
 	}
 
+	issues := make(map[int][]string)
+	for _, ve := range validationErrors {
+		issues[ve.TaskIndex] = append(issues[ve.TaskIndex], ve.Message)
+	}
+
 	// Group tasks by date
-	for _, t := range resp.Tasks {
+	for i, t := range resp.Tasks {
 		pt := PlannedTask{
 			Description:    t.Description,
 			Category:       t.Category,
 			ScheduledDate:  t.ScheduledDate,
 			ScheduledStart: t.ScheduledStart,
 			ScheduledEnd:   t.ScheduledEnd,
+			Energy:         t.Energy,
+			Issues:         issues[i],
 		}
 		result.TasksByDate[t.ScheduledDate] = append(result.TasksByDate[t.ScheduledDate], pt)
+		if w := p.energyWarning(pt); w != "" {
+			result.Warnings = append(result.Warnings, w)
+		}
 	}
 
+	result.Warnings = append(result.Warnings, p.budgetWarnings(ctx, resp.Tasks)...)
+
 	// Create sorted date list
 	for date := range result.TasksByDate {
 		result.SortedDates = append(result.SortedDates, date)
@@ -362,16 +629,19 @@ func (p *Planner) buildResult(resp *llm.PlanResponse, todayDate time.Time, effec
 
 // toTask converts a PlannedTask to a domain Task.
 func (p *Planner) toTask(pt PlannedTask) (*task.Task, error) {
-	category := task.CategoryDeep
-	if pt.Category == "shallow" {
-		category = task.CategoryShallow
-	}
+	category := task.CategoryOrDeep(pt.Category)
 
 	scheduledDate, err := time.Parse("2006-01-02", pt.ScheduledDate)
 	if err != nil {
 		return nil, fmt.Errorf("parsing date %q: %w", pt.ScheduledDate, err)
 	}
 
+	// Unknown energy levels from the LLM are dropped rather than rejected
+	energy, err := task.ParseEnergy(pt.Energy)
+	if err != nil {
+		energy = ""
+	}
+
 	return &task.Task{
 		Description:    pt.Description,
 		Category:       category,
@@ -379,14 +649,72 @@ func (p *Planner) toTask(pt PlannedTask) (*task.Task, error) {
 		ScheduledStart: pt.ScheduledStart,
 		ScheduledEnd:   pt.ScheduledEnd,
 		Status:         task.StatusScheduled,
+		Energy:         energy,
 	}, nil
 }
 
+// budgetWarnings warns for each week in which the planned tasks would take
+// a category past its weekly limit, on top of what the week already holds.
+// Weeks whose usage cannot be read are left out: the warning is a nudge.
+func (p *Planner) budgetWarnings(ctx context.Context, planned []llm.PlannedTask) []string {
+	type budgetKey struct {
+		category task.Category
+		week     string
+	}
+	minutes := make(map[budgetKey]int)
+	dates := make(map[budgetKey]time.Time)
+	var keys []budgetKey
+	for _, t := range planned {
+		category := task.CategoryOrDeep(t.Category)
+		if info, ok := task.LookupCategory(category); !ok || info.WeeklyLimit <= 0 {
+			continue
+		}
+		date, err := time.ParseInLocation("2006-01-02", t.ScheduledDate, time.Local)
+		if err != nil {
+			continue
+		}
+		key := budgetKey{category, dateutil.StartOfWeek(date).Format("2006-01-02")}
+		if _, ok := minutes[key]; !ok {
+			keys = append(keys, key)
+			dates[key] = date
+		}
+		start, end := task.Span(t.ScheduledStart, t.ScheduledEnd)
+		minutes[key] += end - start
+	}
+
+	var warnings []string
+	for _, key := range keys {
+		used, limit, err := task.WeeklyUsage(ctx, p.repo, key.category, dates[key])
+		if err != nil || limit <= 0 || used+minutes[key] <= limit {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("This plan puts %s at %s in the week of %s, over its %s weekly budget",
+			key.category, formatHours(used+minutes[key]), dateutil.StartOfWeek(dates[key]).Format("Jan 2"), formatHours(limit)))
+	}
+	return warnings
+}
+
+// formatHours formats minutes as hours with at most one decimal, e.g. "12.5h".
+func formatHours(minutes int) string {
+	return strings.TrimSuffix(strconv.FormatFloat(float64(minutes)/60, 'f', 1, 64), ".0") + "h"
+}
+
+// energyWarning warns when a high-energy planned task lands in a
+// low-energy window of the configured profile.
+func (p *Planner) energyWarning(pt PlannedTask) string {
+	if p.config == nil || !p.config.Energy.HasProfile() {
+		return ""
+	}
+	return task.EnergyWarning(pt.Description, task.Energy(pt.Energy), pt.ScheduledStart, pt.ScheduledEnd, func(minute int) task.Energy {
+		return task.Energy(p.config.Energy.LevelAt(minute))
+	})
+}
+
 // Plan is the legacy method for backwards compatibility.
 //
 // Deprecated: Use PlanWithRetry for new code.
 func (p *Planner) Plan(ctx context.Context, req OldPlanRequest) (*OldPlanResult, error) {
-	now := time.Now()
+	now := clock.OrSystem(p.clock).Now()
 
 	// Default to today if no date specified
 	targetDate := req.Date
@@ -534,10 +862,7 @@ func (p *Planner) LegacySave(ctx context.Context, result *OldPlanResult) error {
 }
 
 func (p *Planner) legacyToTask(pt OldPlannedTask, date time.Time) *task.Task {
-	category := task.CategoryDeep
-	if pt.Category == "shallow" {
-		category = task.CategoryShallow
-	}
+	category := task.CategoryOrDeep(pt.Category)
 
 	return &task.Task{
 		Description:    pt.Description,
@@ -1,11 +1,16 @@
 package dwplanner
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/llm"
 	"github.com/javiermolinar/sancho/internal/scheduler"
+	"github.com/javiermolinar/sancho/internal/task"
 )
 
 func TestNextWorkdayCalculation(t *testing.T) {
@@ -125,3 +130,89 @@ func TestNextAvailableStart_CloseToMidnight(t *testing.T) {
 		})
 	}
 }
+
+func TestSave_ReservesBuffer(t *testing.T) {
+	ctx := context.Background()
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	date := time.Date(2025, 1, 7, 0, 0, 0, 0, time.Local)
+	existing := &task.Task{Description: "Meeting", Category: task.CategoryShallow, ScheduledDate: date,
+		ScheduledStart: "11:00", ScheduledEnd: "11:30", Status: task.StatusScheduled}
+	if err := repo.CreateTask(ctx, existing); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Schedule.BufferMinutes = 15
+	p := New(nil, cfg, repo)
+
+	result := &PlanResult{TasksByDate: map[string][]PlannedTask{
+		"2025-01-07": {
+			{Description: "Write", Category: "deep", ScheduledDate: "2025-01-07", ScheduledStart: "09:00", ScheduledEnd: "10:00"},
+			{Description: "Review", Category: "deep", ScheduledDate: "2025-01-07", ScheduledStart: "10:00", ScheduledEnd: "11:00"},
+		},
+	}}
+	if err := p.Save(ctx, result); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	tasks, err := repo.ListTasksByDateRange(ctx, date, date)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	got := make(map[string]string)
+	for _, tk := range tasks {
+		got[tk.Description] = tk.ScheduledStart + "-" + tk.ScheduledEnd
+	}
+	want := map[string]string{
+		"Write":   "09:00-10:00",
+		"Review":  "10:15-11:15",
+		"Meeting": "11:30-12:00",
+	}
+	for desc, slot := range want {
+		if got[desc] != slot {
+			t.Errorf("%s = %s, want %s", desc, got[desc], slot)
+		}
+	}
+}
+
+func TestBudgetWarnings(t *testing.T) {
+	task.SetCategories([]task.CategoryInfo{{Name: task.CategoryShallow, WeeklyLimit: 120}})
+	defer task.SetCategories(nil)
+
+	ctx := context.Background()
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	date := time.Date(2025, 1, 7, 0, 0, 0, 0, time.Local)
+	if err := repo.CreateTask(ctx, &task.Task{Description: "Email", Category: task.CategoryShallow, ScheduledDate: date,
+		ScheduledStart: "11:00", ScheduledEnd: "12:00", Status: task.StatusScheduled}); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	p := New(nil, config.Default(), repo)
+
+	planned := []llm.PlannedTask{
+		{Description: "Write", Category: "deep", ScheduledDate: "2025-01-07", ScheduledStart: "09:00", ScheduledEnd: "11:00"},
+		{Description: "Admin", Category: "shallow", ScheduledDate: "2025-01-08", ScheduledStart: "09:00", ScheduledEnd: "10:00"},
+	}
+	if got := p.budgetWarnings(ctx, planned); len(got) != 0 {
+		t.Errorf("budgetWarnings at the limit = %v, want none", got)
+	}
+
+	planned = append(planned,
+		llm.PlannedTask{Description: "Calls", Category: "shallow", ScheduledDate: "2025-01-09", ScheduledStart: "14:00", ScheduledEnd: "14:30"},
+		llm.PlannedTask{Description: "Admin", Category: "shallow", ScheduledDate: "2025-01-14", ScheduledStart: "09:00", ScheduledEnd: "10:00"},
+	)
+	got := p.budgetWarnings(ctx, planned)
+	want := "This plan puts shallow at 2.5h in the week of Jan 6, over its 2h weekly budget"
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("budgetWarnings = %q, want [%q]", got, want)
+	}
+}
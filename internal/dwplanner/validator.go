@@ -13,7 +13,7 @@ import (
 // ValidationError represents a single validation error for a planned task.
 type ValidationError struct {
 	TaskIndex int    // Index of the task in the input slice
-	Field     string // Field name: "scheduled_date", "scheduled_start", "scheduled_end", "overlap"
+	Field     string // Field name: "scheduled_date", "scheduled_start", "scheduled_end", "overlap", "constraint"
 	Message   string // Human-readable error message
 }
 
@@ -48,6 +48,8 @@ type Validator struct {
 	dayStart string       // Workday start time (HH:MM)
 	dayEnd   string       // Workday end time (HH:MM)
 	existing []*task.Task // Existing scheduled tasks to check for overlaps
+
+	constraints []Constraint // Fixed appointments and blocked windows
 }
 
 // NewValidator creates a new Validator with the given constraints.
@@ -60,6 +62,12 @@ func NewValidator(now time.Time, dayStart, dayEnd string, existing []*task.Task)
 	}
 }
 
+// SetConstraints sets the fixed appointments and blocked windows that planned
+// tasks must not overlap.
+func (v *Validator) SetConstraints(constraints []Constraint) {
+	v.constraints = constraints
+}
+
 // Validate checks the LLM-planned tasks for validity.
 // It validates:
 // - Date format (YYYY-MM-DD)
@@ -68,6 +76,7 @@ func NewValidator(now time.Time, dayStart, dayEnd string, existing []*task.Task)
 // - Start time not in the past (for today's tasks)
 // - No overlaps between proposed tasks
 // - No overlaps with existing scheduled tasks
+// - No overlaps with fixed appointments or blocked windows
 func (v *Validator) Validate(tasks []llm.PlannedTask) ValidationResult {
 	result := ValidationResult{Valid: true}
 
@@ -152,6 +161,9 @@ func (v *Validator) Validate(tasks []llm.PlannedTask) ValidationResult {
 	// Third pass: check for overlaps with existing tasks
 	v.checkExistingOverlaps(&result, validTasks)
 
+	// Fourth pass: check plan constraints
+	v.checkConstraintOverlaps(&result, validTasks)
+
 	result.Valid = len(result.Errors) == 0
 	return result
 }
@@ -269,3 +281,33 @@ func (v *Validator) checkExistingOverlaps(result *ValidationResult, validTasks [
 		}
 	}
 }
+
+// checkConstraintOverlaps checks proposed tasks against fixed appointments and blocked windows.
+func (v *Validator) checkConstraintOverlaps(result *ValidationResult, validTasks []struct {
+	index int
+	task  llm.PlannedTask
+	date  time.Time
+}) {
+	for _, vt := range validTasks {
+		for _, c := range v.constraints {
+			if !c.AppliesTo(vt.date) {
+				continue
+			}
+			if task.TimesOverlap(vt.task.ScheduledStart, vt.task.ScheduledEnd, c.Start, c.End) {
+				result.Errors = append(result.Errors, ValidationError{
+					TaskIndex: vt.index,
+					Field:     "constraint",
+					Message: fmt.Sprintf("overlaps %s '%s' (%s-%s on %s)",
+						constraintNoun(c.Kind), c.Label, c.Start, c.End, vt.date.Format("2006-01-02")),
+				})
+			}
+		}
+	}
+}
+
+func constraintNoun(kind ConstraintKind) string {
+	if kind == ConstraintBlocked {
+		return "blocked window"
+	}
+	return "fixed appointment"
+}
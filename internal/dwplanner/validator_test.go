@@ -322,6 +322,65 @@ func TestValidator_ExistingOverlap(t *testing.T) {
 	}
 }
 
+func TestValidator_Constraints(t *testing.T) {
+	now := time.Date(2025, 1, 13, 8, 0, 0, 0, time.Local) // Monday
+
+	v := NewValidator(now, "09:00", "17:00", nil)
+	v.SetConstraints([]Constraint{
+		{Kind: ConstraintFixed, Label: "standup", Start: "10:00", End: "10:15"},
+		{Kind: ConstraintBlocked, Label: "lunch", Start: "12:00", End: "13:00", Weekdays: []time.Weekday{time.Monday}},
+	})
+
+	tests := []struct {
+		name      string
+		tasks     []llm.PlannedTask
+		wantValid bool
+	}{
+		{
+			name: "clear of constraints",
+			tasks: []llm.PlannedTask{
+				{Description: "Focus", Category: "deep", ScheduledDate: "2025-01-13", ScheduledStart: "10:15", ScheduledEnd: "12:00"},
+			},
+			wantValid: true,
+		},
+		{
+			name: "overlaps fixed appointment",
+			tasks: []llm.PlannedTask{
+				{Description: "Focus", Category: "deep", ScheduledDate: "2025-01-14", ScheduledStart: "09:30", ScheduledEnd: "10:30"},
+			},
+			wantValid: false,
+		},
+		{
+			name: "overlaps blocked window",
+			tasks: []llm.PlannedTask{
+				{Description: "Email", Category: "shallow", ScheduledDate: "2025-01-13", ScheduledStart: "12:30", ScheduledEnd: "13:00"},
+			},
+			wantValid: false,
+		},
+		{
+			name: "blocked window only on Mondays",
+			tasks: []llm.PlannedTask{
+				{Description: "Email", Category: "shallow", ScheduledDate: "2025-01-14", ScheduledStart: "12:30", ScheduledEnd: "13:00"},
+			},
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.Validate(tt.tasks)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Validate() valid = %v, want %v", result.Valid, tt.wantValid)
+			}
+			for _, e := range result.Errors {
+				if e.Field != "constraint" {
+					t.Errorf("unexpected error field %q: %s", e.Field, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidator_MultipleErrors(t *testing.T) {
 	now := time.Date(2025, 1, 13, 10, 0, 0, 0, time.Local)
 	v := NewValidator(now, "09:00", "17:00", nil)
@@ -0,0 +1,52 @@
+package export
+
+import "github.com/javiermolinar/sancho/internal/task"
+
+// Colors of the built-in categories in the week reports. Custom categories
+// use their configured color, or the shallow one.
+const (
+	deepColor    = "#7aa2f7"
+	shallowColor = "#e0af68"
+)
+
+// gridMinHours is the shortest span of the day a week grid shows.
+const gridMinHours = 8
+
+// gridHours returns the whole hours, in minutes since midnight, that a week
+// grid spans: at least gridMinHours from 09:00, widened to fit every block.
+// All-day tasks have no block to fit.
+func gridHours(tasks []*task.Task) (first, last int) {
+	first, last = 9*60, (9+gridMinHours)*60
+	for _, t := range tasks {
+		if t.IsAllDay() {
+			continue
+		}
+		start, end := blockMinutes(t)
+		first = min(first, start/60*60)
+		last = max(last, (end+59)/60*60)
+	}
+	return first, last
+}
+
+// blockMinutes returns a block's start and end in minutes since midnight.
+// Overnight blocks are cut at midnight.
+func blockMinutes(t *task.Task) (start, end int) {
+	start, end = task.TimeToMinutes(t.ScheduledStart), task.TimeToMinutes(t.ScheduledEnd)
+	if end <= start {
+		end = 24 * 60
+	}
+	return start, end
+}
+
+func categoryColor(c task.Category) string {
+	switch c {
+	case task.CategoryDeep:
+		return deepColor
+	case task.CategoryShallow:
+		return shallowColor
+	}
+	if info, ok := task.LookupCategory(c); ok && info.Color != "" {
+		return info.Color
+	}
+	return shallowColor
+}
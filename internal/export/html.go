@@ -0,0 +1,198 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/javiermolinar/sancho/internal/summary"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// htmlPixelsPerHour is the vertical scale of the report grid.
+const htmlPixelsPerHour = 48
+
+type htmlReport struct {
+	Title   string
+	Days    []htmlDay
+	Hours   []htmlHour
+	Stats   []string
+	Delta   string
+	Goals   []string
+	Tickets []task.TicketTime
+	Height  int // Grid height in pixels
+}
+
+type htmlDay struct {
+	Label  string
+	Blocks []htmlBlock
+}
+
+type htmlHour struct {
+	Label string
+	Top   int
+}
+
+type htmlBlock struct {
+	Top, Height int
+	Time        string
+	Description string
+	Category    string
+	Color       string
+	Class       string // "", "cancelled" or "missed"
+	Done        bool
+}
+
+// HTML writes a self-contained HTML page with the week's grid and summary,
+// suitable for emailing or archiving. It has no scripts and loads nothing.
+// Postponed blocks are left out; their new copy is shown instead. All-day
+// tasks have no block in the grid.
+func HTML(w io.Writer, s *summary.WeekSummary) error {
+	first, last := gridHours(s.Tasks)
+	report := htmlReport{
+		Title:  fmt.Sprintf("Week of %s – %s", s.Start.Format("Mon Jan 2"), s.End.Format("Mon Jan 2, 2006")),
+		Height: (last - first) / 60 * htmlPixelsPerHour,
+	}
+	for m := first; m < last; m += 60 {
+		report.Hours = append(report.Hours, htmlHour{
+			Label: fmt.Sprintf("%02d:00", m/60),
+			Top:   (m - first) * htmlPixelsPerHour / 60,
+		})
+	}
+
+	byDay := make(map[string][]*task.Task)
+	for _, t := range s.Tasks {
+		key := t.ScheduledDate.Format("2006-01-02")
+		byDay[key] = append(byDay[key], t)
+	}
+	for d := s.Start; !d.After(s.End); d = d.AddDate(0, 0, 1) {
+		day := htmlDay{Label: d.Format("Mon Jan 2")}
+		for _, t := range byDay[d.Format("2006-01-02")] {
+			if t.IsPostponed() || t.IsAllDay() {
+				continue
+			}
+			start, end := blockMinutes(t)
+			b := htmlBlock{
+				Top:         (start - first) * htmlPixelsPerHour / 60,
+				Height:      max((end-start)*htmlPixelsPerHour/60, 1),
+				Time:        t.ScheduledStart + "–" + t.ScheduledEnd,
+				Description: t.Description,
+				Category:    string(t.Category),
+				Color:       categoryColor(t.Category),
+				Done:        t.Outcome != nil,
+			}
+			switch {
+			case t.IsCancelled():
+				b.Class = "cancelled"
+			case t.IsMissed():
+				b.Class = "missed"
+			}
+			day.Blocks = append(day.Blocks, b)
+		}
+		report.Days = append(report.Days, day)
+	}
+
+	stats := s.Stats
+	report.Stats = []string{
+		fmt.Sprintf("Deep: %s (%d%%)", formatDuration(stats.DeepMinutes), stats.DeepPercent()),
+		"Shallow: " + formatDuration(stats.ShallowMinutes),
+		fmt.Sprintf("Ratio: %s", stats.Ratio()),
+		fmt.Sprintf("Blocks: %d", stats.TotalBlocks),
+	}
+	if stats.CancelledBlocks > 0 || stats.PostponedBlocks > 0 || stats.MissedBlocks > 0 {
+		report.Stats = append(report.Stats, fmt.Sprintf("Cancelled: %d | Postponed: %d | Missed: %d",
+			stats.CancelledBlocks, stats.PostponedBlocks, stats.MissedBlocks))
+	}
+	if delta, ok := s.Delta(); ok {
+		report.Delta = "vs last week: " + delta.String()
+	}
+	for _, g := range s.Goals {
+		report.Goals = append(report.Goals, fmt.Sprintf("%s %s/%s (%d%%)",
+			g.Label, formatDuration(g.Total()), formatDuration(g.Target), g.Percent()))
+	}
+	report.Tickets = s.Tickets
+
+	return htmlTemplate.Execute(w, report)
+}
+
+// formatDuration formats minutes as "Xh Ym".
+func formatDuration(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
+
+var htmlTemplate = template.Must(template.New("week").Funcs(template.FuncMap{"duration": formatDuration}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #24283b; margin: 2em; }
+h1 { font-size: 1.4em; }
+h2 { font-size: 1.1em; margin-top: 2em; }
+.grid { display: flex; border: 1px solid #d5d6db; }
+.hours, .day { position: relative; }
+.hours { width: 3.5em; flex: none; }
+.day { flex: 1; border-left: 1px solid #d5d6db; min-width: 0; }
+.day h3 { font-size: .85em; text-align: center; margin: 0; padding: .4em 0; border-bottom: 1px solid #d5d6db; background: #f4f5f7; }
+.hours .head { height: 1.9em; border-bottom: 1px solid #d5d6db; background: #f4f5f7; }
+.body { position: relative; }
+.hour { position: absolute; left: .3em; font-size: .7em; color: #8c8fa1; }
+.block { position: absolute; left: 2px; right: 2px; overflow: hidden; border-radius: 3px; padding: 1px 4px; font-size: .75em; box-sizing: border-box; color: #1a1b26; }
+.block .time { font-size: .85em; opacity: .8; }
+.block.cancelled { opacity: .4; text-decoration: line-through; }
+.block.missed { opacity: .6; border: 1px dashed #f7768e; }
+ul { padding-left: 1.2em; }
+.meta { color: #8c8fa1; }
+table.tickets td { padding: 0 1em 0 0; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="grid">
+<div class="hours"><div class="head"></div><div class="body" style="height: {{.Height}}px">
+{{- range .Hours}}<div class="hour" style="top: {{.Top}}px">{{.Label}}</div>{{end -}}
+</div></div>
+{{- $height := .Height}}
+{{- range .Days}}
+<div class="day"><h3>{{.Label}}</h3><div class="body" style="height: {{$height}}px">
+{{- range .Blocks}}
+<div class="block {{.Class}}" style="top: {{.Top}}px; height: {{.Height}}px; background: {{.Color}}" title="{{.Time}} {{.Description}} ({{.Category}})">
+<div class="time">{{.Time}}{{if .Done}} ✓{{end}}</div>{{.Description}}</div>
+{{- end}}
+</div></div>
+{{- end}}
+</div>
+<h2>Summary</h2>
+<ul>
+{{- range .Stats}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- if .Delta}}
+<p class="meta">{{.Delta}}</p>
+{{- end}}
+{{- if .Goals}}
+<h2>Goals</h2>
+<ul>
+{{- range .Goals}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Tickets}}
+<h2>Tickets</h2>
+<table class="tickets">
+{{- range .Tickets}}
+<tr><td>{{.Key}}</td><td>{{duration .Minutes}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
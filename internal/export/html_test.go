@@ -0,0 +1,43 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/summary"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestHTML(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{Description: "API-42 Fix <login>", Category: task.CategoryDeep, ScheduledDate: monday, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled},
+		{Description: "Email", Category: task.CategoryShallow, ScheduledDate: monday.AddDate(0, 0, 1), ScheduledStart: "07:30", ScheduledEnd: "08:00", Status: task.StatusCancelled},
+	}
+	s := summary.SummarizeWeek(monday, tasks, summary.WeekSummaryOptions{Now: monday})
+
+	var sb strings.Builder
+	if err := HTML(&sb, s); err != nil {
+		t.Fatalf("HTML: %v", err)
+	}
+	page := sb.String()
+	for _, want := range []string{
+		"<title>Week of Mon Mar 10 – Sun Mar 16, 2025</title>",
+		"API-42 Fix &lt;login&gt;",
+		"background: #7aa2f7",
+		`class="block cancelled"`,
+		`<div class="hour" style="top: 0px">07:00</div>`,
+		// 09:00 is two hours below the 07:00 start of the grid
+		"top: 96px; height: 96px",
+		"<li>Deep: 2h (100%)</li>",
+		"<tr><td>API-42</td><td>2h</td></tr>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page lacks %q:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "<script") {
+		t.Error("report should have no scripts")
+	}
+}
@@ -0,0 +1,160 @@
+// Package export renders schedules for other tools: notes apps, editors and
+// static reports.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// ErrNoDailyNote is returned when no Obsidian daily note path is configured.
+var ErrNoDailyNote = errors.New("export.obsidian_daily_note is not set")
+
+// obsidianHeading starts the section sancho owns in a daily note. Exporting
+// again replaces the section instead of adding another.
+const obsidianHeading = "## Schedule (sancho)"
+
+// DailyNotePath fills the {date}, {year}, {month} and {day} placeholders of
+// an Obsidian daily note path template.
+func DailyNotePath(template string, day time.Time) string {
+	return strings.NewReplacer(
+		"{date}", day.Format("2006-01-02"),
+		"{year}", day.Format("2006"),
+		"{month}", day.Format("01"),
+		"{day}", day.Format("02"),
+	).Replace(template)
+}
+
+// Obsidian writes day's schedule into its daily note and returns the note's
+// path.
+func Obsidian(ctx context.Context, repo task.Repository, template string, day time.Time) (string, error) {
+	if template == "" {
+		return "", ErrNoDailyNote
+	}
+	tasks, err := repo.ListTasksByDateRange(ctx, day, day)
+	if err != nil {
+		return "", fmt.Errorf("fetching tasks: %w", err)
+	}
+	path := DailyNotePath(template, day)
+	if err := WriteDailyNote(path, ObsidianSection(tasks)); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ObsidianSection renders a day's schedule as a markdown section: one
+// checklist item per block, checked once it has an outcome. Cancelled and
+// postponed blocks are left out.
+func ObsidianSection(tasks []*task.Task) string {
+	var sb strings.Builder
+	sb.WriteString(obsidianHeading + "\n\n")
+	sorted := append([]*task.Task(nil), tasks...)
+	task.SortByTime(sorted)
+	n := 0
+	for _, t := range sorted {
+		if t.IsCancelled() || t.IsPostponed() {
+			continue
+		}
+		box := "[ ]"
+		if t.Outcome != nil {
+			box = "[x]"
+		}
+		fmt.Fprintf(&sb, "- %s %s %s (%s)\n", box, t.TimeRange(), t.Description, blockNote(t))
+		n++
+	}
+	if n == 0 {
+		sb.WriteString("No blocks scheduled.\n")
+	}
+	return sb.String()
+}
+
+// blockNote describes a block's category and how it went, e.g.
+// "deep, on time" or "shallow, missed".
+func blockNote(t *task.Task) string {
+	note := string(t.Category)
+	switch {
+	case t.IsMissed():
+		note += ", missed"
+	case t.Outcome != nil:
+		note += ", " + outcomeLabel(*t.Outcome)
+	}
+	return note
+}
+
+func outcomeLabel(o task.Outcome) string {
+	switch o {
+	case task.OutcomeOver:
+		return "ran over"
+	case task.OutcomeUnder:
+		return "finished early"
+	default:
+		return "on time"
+	}
+}
+
+// WriteDailyNote adds section to the note at path, replacing an earlier
+// sancho section and keeping everything else. Missing notes and folders
+// are created.
+func WriteDailyNote(path, section string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading daily note: %w", err)
+	}
+	content := replaceSection(string(data), section)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating daily note folder: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("writing daily note: %w", err)
+	}
+	return nil
+}
+
+// replaceSection swaps the sancho section of note for section, or appends
+// section when the note has none. The old section runs to the next heading
+// of the same or a higher level.
+func replaceSection(note, section string) string {
+	lines := strings.Split(note, "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == obsidianHeading {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		switch {
+		case note == "":
+			return section
+		case strings.HasSuffix(note, "\n\n"):
+			return note + section
+		case strings.HasSuffix(note, "\n"):
+			return note + "\n" + section
+		default:
+			return note + "\n\n" + section
+		}
+	}
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "# ") || strings.HasPrefix(lines[i], "## ") {
+			end = i
+			break
+		}
+	}
+	rest := strings.Join(lines[end:], "\n")
+	if rest != "" {
+		rest = "\n" + rest
+	}
+	before := strings.Join(lines[:start], "\n")
+	if start > 0 {
+		before += "\n"
+	}
+	return before + section + rest
+}
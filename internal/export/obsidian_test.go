@@ -0,0 +1,68 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestDailyNotePath(t *testing.T) {
+	day := time.Date(2025, 3, 7, 0, 0, 0, 0, time.Local)
+	got := DailyNotePath("/vault/Daily/{year}/{month}/{date}.md", day)
+	if want := "/vault/Daily/2025/03/2025-03-07.md"; got != want {
+		t.Errorf("DailyNotePath = %q, want %q", got, want)
+	}
+}
+
+func TestObsidianSection(t *testing.T) {
+	over := task.OutcomeOver
+	day := time.Date(2025, 3, 7, 0, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{Description: "Email", Category: task.CategoryShallow, ScheduledDate: day, ScheduledStart: "11:00", ScheduledEnd: "11:30", Status: task.StatusMissed},
+		{Description: "Design doc", Category: task.CategoryDeep, ScheduledDate: day, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled, Outcome: &over},
+		{Description: "Dropped", Category: task.CategoryDeep, ScheduledDate: day, ScheduledStart: "12:00", ScheduledEnd: "13:00", Status: task.StatusCancelled},
+	}
+	got := ObsidianSection(tasks)
+	want := "## Schedule (sancho)\n\n" +
+		"- [x] 09:00-11:00 Design doc (deep, ran over)\n" +
+		"- [ ] 11:00-11:30 Email (shallow, missed)\n"
+	if got != want {
+		t.Errorf("ObsidianSection =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteDailyNote(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Daily", "2025-03-07.md")
+	if err := WriteDailyNote(path, obsidianHeading+"\n\nfirst\n"); err != nil {
+		t.Fatalf("WriteDailyNote: %v", err)
+	}
+	note := "# Friday\n\nJournal\n\n" + obsidianHeading + "\n\nfirst\n\n## Later\n\nkeep\n"
+	if err := os.WriteFile(path, []byte(note), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := WriteDailyNote(path, obsidianHeading+"\n\nsecond\n"); err != nil {
+		t.Fatalf("WriteDailyNote: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want := "# Friday\n\nJournal\n\n" + obsidianHeading + "\n\nsecond\n\n## Later\n\nkeep\n"
+	if string(data) != want {
+		t.Errorf("note =\n%s\nwant\n%s", data, want)
+	}
+	if strings.Count(string(data), obsidianHeading) != 1 {
+		t.Error("section was duplicated")
+	}
+}
+
+func TestReplaceSection_Appends(t *testing.T) {
+	got := replaceSection("# Friday\nJournal", "## Schedule (sancho)\n")
+	if want := "# Friday\nJournal\n\n## Schedule (sancho)\n"; got != want {
+		t.Errorf("replaceSection = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// OrgWeek renders the week containing day as an org-mode file.
+func OrgWeek(ctx context.Context, repo task.Repository, day time.Time) (string, error) {
+	start, end := dateutil.WeekRange(day)
+	tasks, err := repo.ListTasksByDateRange(ctx, start, end)
+	if err != nil {
+		return "", fmt.Errorf("fetching tasks: %w", err)
+	}
+	return Org(start, tasks), nil
+}
+
+// Org renders the week containing day as an org-mode file for Emacs: a
+// heading per day and a TODO entry per block with its scheduled timestamp
+// and category tag. Blocks with an outcome are DONE, cancelled blocks are
+// CANCELLED and blocks that ended without an outcome are MISSED. Postponed
+// blocks are left out; their new copy is listed instead.
+func Org(day time.Time, tasks []*task.Task) string {
+	start, end := dateutil.WeekRange(day)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#+TITLE: Week of %s\n", start.Format("2006-01-02"))
+	sb.WriteString("#+TODO: TODO MISSED | DONE CANCELLED\n")
+
+	sorted := append([]*task.Task(nil), tasks...)
+	task.SortByTime(sorted)
+	current := ""
+	for _, t := range sorted {
+		if t.IsPostponed() || t.ScheduledDate.Before(start) || t.ScheduledDate.After(end) {
+			continue
+		}
+		if key := t.ScheduledDate.Format("2006-01-02"); key != current {
+			current = key
+			fmt.Fprintf(&sb, "\n* %s\n", t.ScheduledDate.Format("Monday, January 2"))
+		}
+		fmt.Fprintf(&sb, "** %s %s :%s:\n", orgState(t), t.Description, orgTag(t.Category))
+		if t.IsAllDay() {
+			fmt.Fprintf(&sb, "   SCHEDULED: <%s>\n", t.ScheduledDate.Format("2006-01-02 Mon"))
+			continue
+		}
+		fmt.Fprintf(&sb, "   SCHEDULED: <%s %s-%s>\n",
+			t.ScheduledDate.Format("2006-01-02 Mon"), t.ScheduledStart, t.ScheduledEnd)
+	}
+	return sb.String()
+}
+
+func orgState(t *task.Task) string {
+	switch {
+	case t.IsCancelled():
+		return "CANCELLED"
+	case t.IsMissed():
+		return "MISSED"
+	case t.Outcome != nil:
+		return "DONE"
+	default:
+		return "TODO"
+	}
+}
+
+// orgTag turns a category into a valid org tag, which may only hold
+// letters, digits, "_" and "@".
+func orgTag(c task.Category) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '@':
+			return r
+		default:
+			return '_'
+		}
+	}, string(c))
+}
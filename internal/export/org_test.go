@@ -0,0 +1,40 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestOrg(t *testing.T) {
+	onTime := task.OutcomeOnTime
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+	tasks := []*task.Task{
+		{Description: "Email", Category: task.CategoryShallow, ScheduledDate: tuesday, ScheduledStart: "09:00", ScheduledEnd: "09:30", Status: task.StatusCancelled},
+		{Description: "API-42 Fix login", Category: task.CategoryDeep, ScheduledDate: monday, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled, Outcome: &onTime},
+		{Description: "Review", Category: "code-review", ScheduledDate: monday, ScheduledStart: "14:00", ScheduledEnd: "15:00", Status: task.StatusMissed},
+		{Description: "Moved on", Category: task.CategoryDeep, ScheduledDate: tuesday, ScheduledStart: "10:00", ScheduledEnd: "11:00", Status: task.StatusPostponed},
+		{Description: "Plan", Category: task.CategoryDeep, ScheduledDate: tuesday, ScheduledStart: "11:00", ScheduledEnd: "12:00", Status: task.StatusScheduled},
+		{Description: "Next week", Category: task.CategoryDeep, ScheduledDate: monday.AddDate(0, 0, 7), ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled},
+	}
+	want := `#+TITLE: Week of 2025-03-10
+#+TODO: TODO MISSED | DONE CANCELLED
+
+* Monday, March 10
+** DONE API-42 Fix login :deep:
+   SCHEDULED: <2025-03-10 Mon 09:00-11:00>
+** MISSED Review :code_review:
+   SCHEDULED: <2025-03-10 Mon 14:00-15:00>
+
+* Tuesday, March 11
+** CANCELLED Email :shallow:
+   SCHEDULED: <2025-03-11 Tue 09:00-09:30>
+** TODO Plan :deep:
+   SCHEDULED: <2025-03-11 Tue 11:00-12:00>
+`
+	if got := Org(tuesday, tasks); got != want {
+		t.Errorf("Org =\n%s\nwant\n%s", got, want)
+	}
+}
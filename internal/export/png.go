@@ -0,0 +1,211 @@
+package export
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Snapshot geometry, in pixels.
+const (
+	pngHourWidth      = 48
+	pngDayWidth       = 160
+	pngTitleHeight    = 28
+	pngHeaderHeight   = 20
+	pngPixelsPerHour  = 48
+	pngMargin         = 8
+	pngLineHeight     = 13 // Height of basicfont.Face7x13
+	pngCharWidth      = 7
+	pngBlockPadding   = 3
+	pngFadedAlpha     = 0x66 // Opacity of cancelled blocks
+	pngMissedBorderPx = 2
+)
+
+var (
+	pngBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	pngHeaderFill = color.RGBA{0xf4, 0xf5, 0xf7, 0xff}
+	pngRule       = color.RGBA{0xd5, 0xd6, 0xdb, 0xff}
+	pngText       = color.RGBA{0x1a, 0x1b, 0x26, 0xff}
+	pngMuted      = color.RGBA{0x8c, 0x8f, 0xa1, 0xff}
+	pngMissed     = color.RGBA{0xf7, 0x76, 0x8e, 0xff}
+)
+
+// PNG draws the week starting at start as a PNG image with the same grid as
+// the HTML report: an hour column, a column per day and a colored box per
+// block with its time and description. The whole week is drawn whatever the
+// terminal size, so the image can be shared without cropping. Postponed
+// and all-day blocks are left out.
+func PNG(w io.Writer, start time.Time, tasks []*task.Task) error {
+	start, end := dateutil.WeekRange(start)
+	var week []*task.Task
+	for _, t := range tasks {
+		if !t.IsPostponed() && !t.IsAllDay() && !t.ScheduledDate.Before(start) && !t.ScheduledDate.After(end) {
+			week = append(week, t)
+		}
+	}
+	first, last := gridHours(week)
+
+	gridTop := pngMargin + pngTitleHeight + pngHeaderHeight
+	gridLeft := pngMargin + pngHourWidth
+	height := (last - first) / 60 * pngPixelsPerHour
+	img := image.NewRGBA(image.Rect(0, 0, gridLeft+7*pngDayWidth+pngMargin, gridTop+height+pngMargin))
+	fill(img, img.Bounds(), pngBackground)
+
+	drawText(img, pngMargin, pngMargin, fmt.Sprintf("Week of %s - %s", start.Format("Mon Jan 2"), end.Format("Mon Jan 2, 2006")), 0, pngText)
+
+	// Day headers and columns
+	headerTop := pngMargin + pngTitleHeight
+	fill(img, image.Rect(pngMargin, headerTop, gridLeft+7*pngDayWidth, gridTop), pngHeaderFill)
+	for i := range 7 {
+		x := gridLeft + i*pngDayWidth
+		label := start.AddDate(0, 0, i).Format("Mon Jan 2")
+		drawText(img, x+(pngDayWidth-len(label)*pngCharWidth)/2, headerTop+(pngHeaderHeight-pngLineHeight)/2, label, 0, pngText)
+		fill(img, image.Rect(x, headerTop, x+1, gridTop+height), pngRule)
+	}
+
+	// Hour labels and rules
+	for m := first; m < last; m += 60 {
+		y := gridTop + (m-first)*pngPixelsPerHour/60
+		fill(img, image.Rect(gridLeft, y, gridLeft+7*pngDayWidth, y+1), pngRule)
+		drawText(img, pngMargin+4, y+2, fmt.Sprintf("%02d:00", m/60), 0, pngMuted)
+	}
+	outline(img, image.Rect(pngMargin, headerTop, gridLeft+7*pngDayWidth+1, gridTop+height+1), 1, pngRule)
+
+	for _, t := range week {
+		day := 0
+		for day < 6 && start.AddDate(0, 0, day).Format("2006-01-02") != t.ScheduledDate.Format("2006-01-02") {
+			day++
+		}
+		blockStart, blockEnd := blockMinutes(t)
+		r := image.Rect(
+			gridLeft+day*pngDayWidth+2,
+			gridTop+(blockStart-first)*pngPixelsPerHour/60+1,
+			gridLeft+(day+1)*pngDayWidth-2,
+			gridTop+(blockEnd-first)*pngPixelsPerHour/60-1,
+		)
+		if r.Dy() < 1 {
+			r.Max.Y = r.Min.Y + 1
+		}
+		drawBlock(img, r, t)
+	}
+
+	return png.Encode(w, img)
+}
+
+// drawBlock draws one block: its category color, faded when cancelled and
+// outlined when missed, with as many lines of text as fit.
+func drawBlock(img *image.RGBA, r image.Rectangle, t *task.Task) {
+	c := parseHexColor(categoryColor(t.Category))
+	if t.IsCancelled() {
+		c.A = pngFadedAlpha
+		draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Over)
+	} else {
+		fill(img, r, c)
+	}
+	if t.IsMissed() {
+		outline(img, r, pngMissedBorderPx, pngMissed)
+	}
+
+	timeLine := t.ScheduledStart + "-" + t.ScheduledEnd
+	if t.Outcome != nil {
+		timeLine += " done"
+	}
+	maxChars := (r.Dx() - 2*pngBlockPadding) / pngCharWidth
+	lines := append([]string{timeLine}, wrapText(t.Description, maxChars)...)
+	fit := (r.Dy() - pngBlockPadding) / pngLineHeight
+	if fit < len(lines) {
+		lines = lines[:fit]
+	}
+	for i, line := range lines {
+		drawText(img, r.Min.X+pngBlockPadding, r.Min.Y+pngBlockPadding/2+i*pngLineHeight, line, maxChars, pngText)
+	}
+}
+
+// drawText draws s with its top-left corner at x, y, cut to maxChars
+// characters when maxChars is positive.
+func drawText(img *image.RGBA, x, y int, s string, maxChars int, c color.Color) {
+	if maxChars > 0 {
+		s = truncateText(s, maxChars)
+	}
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y+basicfont.Face7x13.Ascent),
+	}
+	d.DrawString(s)
+}
+
+// wrapText splits s into lines of at most width characters, breaking at
+// spaces where it can.
+func wrapText(s string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+	var lines []string
+	runes := []rune(s)
+	for len(runes) > width {
+		cut := width
+		for i := width; i > 0; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, string(runes[:cut]))
+		runes = runes[cut:]
+		for len(runes) > 0 && runes[0] == ' ' {
+			runes = runes[1:]
+		}
+	}
+	if len(runes) > 0 {
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+// truncateText cuts s to n characters, ending in "~" when cut.
+func truncateText(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	if n <= 1 {
+		return string(runes[:n])
+	}
+	return string(runes[:n-1]) + "~"
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// outline draws a border of the given width just inside r.
+func outline(img *image.RGBA, r image.Rectangle, width int, c color.Color) {
+	fill(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+width), c)
+	fill(img, image.Rect(r.Min.X, r.Max.Y-width, r.Max.X, r.Max.Y), c)
+	fill(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+width, r.Max.Y), c)
+	fill(img, image.Rect(r.Max.X-width, r.Min.Y, r.Max.X, r.Max.Y), c)
+}
+
+// parseHexColor parses a "#rrggbb" color, falling back to the shallow color.
+func parseHexColor(s string) color.NRGBA {
+	if len(s) == 7 && s[0] == '#' {
+		if v, err := strconv.ParseUint(s[1:], 16, 32); err == nil {
+			return color.NRGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff}
+		}
+	}
+	return color.NRGBA{0xe0, 0xaf, 0x68, 0xff} // shallowColor
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"bytes"
+	"image/png"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestPNG(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{Description: "Write the design doc", Category: task.CategoryDeep, ScheduledDate: monday, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled},
+		{Description: "Email", Category: task.CategoryShallow, ScheduledDate: monday.AddDate(0, 0, 2), ScheduledStart: "18:00", ScheduledEnd: "19:30", Status: task.StatusCancelled},
+		{Description: "Next week", Category: task.CategoryDeep, ScheduledDate: monday.AddDate(0, 0, 7), ScheduledStart: "06:00", ScheduledEnd: "07:00", Status: task.StatusScheduled},
+	}
+
+	var buf bytes.Buffer
+	if err := PNG(&buf, monday.AddDate(0, 0, 3), tasks); err != nil {
+		t.Fatalf("PNG: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+
+	// 09:00-20:00 is 11 hours; the next week's 06:00 block doesn't widen it.
+	wantW := pngMargin + pngHourWidth + 7*pngDayWidth + pngMargin
+	wantH := pngMargin + pngTitleHeight + pngHeaderHeight + 11*pngPixelsPerHour + pngMargin
+	if b := img.Bounds(); b.Dx() != wantW || b.Dy() != wantH {
+		t.Fatalf("size = %dx%d, want %dx%d", b.Dx(), b.Dy(), wantW, wantH)
+	}
+
+	// The bottom-right corner of Monday's 09:00-11:00 block has its color.
+	x := pngMargin + pngHourWidth + pngDayWidth - 4
+	y := pngMargin + pngTitleHeight + pngHeaderHeight + 2*pngPixelsPerHour - 3
+	r, g, b, _ := img.At(x, y).RGBA()
+	want := parseHexColor(deepColor)
+	if got := [3]uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)}; got != [3]uint8{want.R, want.G, want.B} {
+		t.Errorf("block color = %v, want %v", got, want)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	got := wrapText("Write the design doc for sync", 10)
+	want := []string{"Write the", "design doc", "for sync"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapText = %q, want %q", got, want)
+	}
+	if got := wrapText("Supercalifragilistic", 8); !reflect.DeepEqual(got, []string{"Supercal", "ifragili", "stic"}) {
+		t.Errorf("wrapText long word = %q", got)
+	}
+}
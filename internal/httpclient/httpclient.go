@@ -0,0 +1,69 @@
+// Package httpclient is the single place sancho creates HTTP clients, so the
+// privacy.local_only switch can block every outbound request in one spot.
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrLocalOnly is returned for requests that would leave the machine while
+// local-only mode is on.
+var ErrLocalOnly = errors.New("network access is disabled by privacy.local_only")
+
+var localOnly atomic.Bool
+
+// SetLocalOnly turns local-only mode on or off for every client from New.
+func SetLocalOnly(on bool) {
+	localOnly.Store(on)
+}
+
+// LocalOnly reports whether local-only mode is on.
+func LocalOnly() bool {
+	return localOnly.Load()
+}
+
+// New returns an HTTP client with the given timeout (0 means none). While
+// local-only mode is on it refuses any request to a non-loopback host,
+// including redirects.
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: guardTransport{base: http.DefaultTransport},
+	}
+}
+
+// CheckHost returns ErrLocalOnly if local-only mode is on and host is not
+// this machine.
+func CheckHost(host string) error {
+	if !LocalOnly() || IsLoopback(host) {
+		return nil
+	}
+	return fmt.Errorf("%w (refusing %s)", ErrLocalOnly, host)
+}
+
+// IsLoopback reports whether host (without port) names this machine.
+func IsLoopback(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+type guardTransport struct {
+	base http.RoundTripper
+}
+
+func (t guardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := CheckHost(req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
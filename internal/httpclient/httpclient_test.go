@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsLoopback(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"localhost", true},
+		{"LOCALHOST.", true},
+		{"ollama.localhost", true},
+		{"127.0.0.1", true},
+		{"127.1.2.3", true},
+		{"::1", true},
+		{"[::1]", true},
+		{"192.168.1.10", false},
+		{"api.githubcopilot.com", false},
+		{"localhost.example.com", false},
+	}
+	for _, tt := range tests {
+		if got := IsLoopback(tt.host); got != tt.want {
+			t.Errorf("IsLoopback(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestNew_LocalOnly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	SetLocalOnly(true)
+	defer SetLocalOnly(false)
+	client := New(0)
+
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("loopback request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if _, err := client.Get("https://example.com/feed.ics"); !errors.Is(err, ErrLocalOnly) {
+		t.Errorf("remote request error = %v, want ErrLocalOnly", err)
+	}
+
+	SetLocalOnly(false)
+	if err := CheckHost("example.com"); err != nil {
+		t.Errorf("CheckHost with local-only off = %v, want nil", err)
+	}
+}
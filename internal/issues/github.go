@@ -0,0 +1,138 @@
+package issues
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// ErrInvalidRepo is returned for a GitHub repository not written as
+// owner/repo.
+var ErrInvalidRepo = errors.New("repository must be owner/repo")
+
+// githubAPI is the default GitHub REST API endpoint.
+const githubAPI = "https://api.github.com"
+
+// githubPageSize is how many issues are requested per page, the API maximum.
+const githubPageSize = 100
+
+var repoPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// GitHub reads the open issues assigned to a user in one repository.
+type GitHub struct {
+	Repo    string       // "owner/repo"
+	User    string       // Assignee login; empty means the token's owner
+	Token   string       // Optional access token; needed for private repositories
+	BaseURL string       // Defaults to https://api.github.com
+	Client  *http.Client // Defaults to an httpclient client
+}
+
+type githubIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	HTMLURL     string    `json:"html_url"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// Items returns the open issues assigned to the user as backlog items, with
+// each issue's link in its notes. Pull requests are skipped.
+func (g *GitHub) Items(ctx context.Context) ([]task.BacklogItem, error) {
+	if !repoPattern.MatchString(g.Repo) {
+		return nil, fmt.Errorf("%w, got %q", ErrInvalidRepo, g.Repo)
+	}
+	user := g.User
+	if user == "" {
+		if g.Token == "" {
+			return nil, errors.New("set import.github.user or a GitHub token to know whose issues to import")
+		}
+		var me struct {
+			Login string `json:"login"`
+		}
+		if _, err := g.get(ctx, "/user", &me); err != nil {
+			return nil, err
+		}
+		user = me.Login
+	}
+
+	var items []task.BacklogItem
+	path := fmt.Sprintf("/repos/%s/issues?state=open&assignee=%s&per_page=%d", g.Repo, url.QueryEscape(user), githubPageSize)
+	for path != "" {
+		var page []githubIssue
+		next, err := g.get(ctx, path, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range page {
+			if issue.PullRequest != nil {
+				continue
+			}
+			items = append(items, task.BacklogItem{
+				Title:       fmt.Sprintf("#%d %s", issue.Number, issue.Title),
+				Notes:       issue.HTMLURL,
+				ExternalRef: task.ExternalRef{Source: "github", ID: fmt.Sprintf("%s#%d", g.Repo, issue.Number)},
+			})
+		}
+		path = next
+	}
+	return items, nil
+}
+
+// get decodes the JSON at path into v and returns the path of the next page,
+// if any.
+func (g *GitHub) get(ctx context.Context, path string, v any) (string, error) {
+	base := g.BaseURL
+	if base == "" {
+		base = githubAPI
+	}
+	base = strings.TrimSuffix(base, "/")
+	target := base + path
+	if strings.HasPrefix(path, "http") {
+		if !strings.HasPrefix(path, base+"/") {
+			return "", fmt.Errorf("refusing to follow GitHub link to %s", path)
+		}
+		target = path // A next page link
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if g.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.Token)
+	}
+	client := g.Client
+	if client == nil {
+		client = httpclient.New(30 * time.Second)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching from GitHub: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching from GitHub: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return "", fmt.Errorf("decoding GitHub response: %w", err)
+	}
+	return nextLink(resp.Header.Get("Link")), nil
+}
+
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextLink returns the rel="next" URL of a Link header, or "".
+func nextLink(header string) string {
+	if m := nextLinkPattern.FindStringSubmatch(header); m != nil {
+		return m[1]
+	}
+	return ""
+}
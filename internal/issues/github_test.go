@@ -0,0 +1,82 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestGitHubItems(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch {
+		case r.URL.Path == "/user":
+			fmt.Fprint(w, `{"login":"ana"}`)
+		case r.URL.Query().Get("page") == "2":
+			fmt.Fprint(w, `[{"number":9,"title":"Flaky test","html_url":"https://github.com/acme/api/issues/9"}]`)
+		default:
+			if got := r.URL.Query().Get("assignee"); got != "ana" {
+				t.Errorf("assignee = %q, want ana", got)
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/api/issues?page=2>; rel="next", <%s/x>; rel="last"`, srv.URL, srv.URL))
+			fmt.Fprint(w, `[{"number":7,"title":"Fix login","html_url":"https://github.com/acme/api/issues/7"},
+				{"number":8,"title":"A PR","html_url":"https://github.com/acme/api/pull/8","pull_request":{}}]`)
+		}
+	}))
+	defer srv.Close()
+
+	gh := &GitHub{Repo: "acme/api", Token: "secret", BaseURL: srv.URL}
+	items, err := gh.Items(context.Background())
+	if err != nil {
+		t.Fatalf("Items: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2 (pull requests skipped): %+v", len(items), items)
+	}
+	first := items[0]
+	if first.Title != "#7 Fix login" || first.Notes != "https://github.com/acme/api/issues/7" ||
+		first.ExternalRef.String() != "github:acme/api#7" {
+		t.Errorf("first item = %+v", first)
+	}
+	if items[1].Title != "#9 Flaky test" {
+		t.Errorf("second page item = %+v", items[1])
+	}
+}
+
+func TestGitHubItems_InvalidRepo(t *testing.T) {
+	gh := &GitHub{Repo: "acme", User: "ana"}
+	if _, err := gh.Items(context.Background()); err == nil {
+		t.Fatal("expected an error for a repository without an owner")
+	}
+}
+
+func TestImport_UpdatesExisting(t *testing.T) {
+	repo := memrepo.New()
+	ctx := context.Background()
+	ref := task.ExternalRef{Source: "github", ID: "acme/api#7"}
+	if _, _, err := Import(ctx, repo, []task.BacklogItem{{Title: "#7 Fix login", ExternalRef: ref}}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	created, updated, err := Import(ctx, repo, []task.BacklogItem{
+		{Title: "#7 Fix login flow", ExternalRef: ref},
+		{Title: "#9 Flaky test", ExternalRef: task.ExternalRef{Source: "github", ID: "acme/api#9"}},
+	})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if created != 1 || updated != 1 {
+		t.Errorf("created, updated = %d, %d, want 1, 1", created, updated)
+	}
+	items, _ := repo.ListBacklog(ctx)
+	if len(items) != 2 || items[0].Title != "#7 Fix login flow" {
+		t.Errorf("backlog = %+v", items)
+	}
+}
@@ -0,0 +1,31 @@
+// Package issues imports work from issue trackers into the backlog, so it
+// can be timeblocked without retyping it.
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Source is an issue tracker query whose results can be imported.
+type Source interface {
+	Items(ctx context.Context) ([]task.BacklogItem, error)
+}
+
+// Import adds items to the backlog, updating the ones imported before.
+func Import(ctx context.Context, backlog task.Backlog, items []task.BacklogItem) (created, updated int, err error) {
+	for i := range items {
+		isNew, err := backlog.UpsertBacklogItem(ctx, &items[i])
+		if err != nil {
+			return created, updated, fmt.Errorf("adding %q: %w", items[i].Title, err)
+		}
+		if isNew {
+			created++
+		} else {
+			updated++
+		}
+	}
+	return created, updated, nil
+}
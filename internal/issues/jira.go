@@ -0,0 +1,100 @@
+package issues
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// jiraPageSize is how many issues are requested per search page.
+const jiraPageSize = 100
+
+// Jira reads the issues matched by a JQL query.
+type Jira struct {
+	URL    string // Site URL, e.g. "https://acme.atlassian.net"
+	JQL    string // e.g. "assignee = currentUser() AND statusCategory != Done"
+	Email  string // Jira Cloud account; with Token, sent as basic auth
+	Token  string // API token (Cloud) or personal access token (Server)
+	Client *http.Client
+}
+
+type jiraSearch struct {
+	Total  int `json:"total"`
+	Issues []struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	} `json:"issues"`
+}
+
+// Items returns the issues matched by the query as backlog items titled
+// "KEY summary", with a link to each issue in its notes.
+func (j *Jira) Items(ctx context.Context) ([]task.BacklogItem, error) {
+	if j.URL == "" || j.JQL == "" {
+		return nil, errors.New("set import.jira.url and import.jira.jql")
+	}
+	site := strings.TrimSuffix(j.URL, "/")
+
+	var items []task.BacklogItem
+	for start := 0; ; {
+		q := url.Values{}
+		q.Set("jql", j.JQL)
+		q.Set("fields", "summary")
+		q.Set("startAt", fmt.Sprint(start))
+		q.Set("maxResults", fmt.Sprint(jiraPageSize))
+		var page jiraSearch
+		if err := j.get(ctx, site+"/rest/api/2/search?"+q.Encode(), &page); err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			items = append(items, task.BacklogItem{
+				Title:       issue.Key + " " + issue.Fields.Summary,
+				Notes:       site + "/browse/" + issue.Key,
+				ExternalRef: task.ExternalRef{Source: "jira", ID: issue.Key},
+			})
+		}
+		start += len(page.Issues)
+		if len(page.Issues) == 0 || start >= page.Total {
+			return items, nil
+		}
+	}
+}
+
+func (j *Jira) get(ctx context.Context, target string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case j.Email != "" && j.Token != "":
+		req.SetBasicAuth(j.Email, j.Token)
+	case j.Token != "":
+		req.Header.Set("Authorization", "Bearer "+j.Token)
+	}
+	client := j.Client
+	if client == nil {
+		client = httpclient.New(30 * time.Second)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching from Jira: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching from Jira: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding Jira response: %w", err)
+	}
+	return nil
+}
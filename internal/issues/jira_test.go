@@ -0,0 +1,42 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJiraItems(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "ana@acme.com" || pass != "secret" {
+			t.Errorf("basic auth = %q, %q, %v", user, pass, ok)
+		}
+		if got := r.URL.Query().Get("jql"); got != "assignee = currentUser()" {
+			t.Errorf("jql = %q", got)
+		}
+		if r.URL.Query().Get("startAt") == "0" {
+			fmt.Fprint(w, `{"total":2,"issues":[{"key":"API-42","fields":{"summary":"Fix login"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"total":2,"issues":[{"key":"OPS-7","fields":{"summary":"Rotate keys"}}]}`)
+	}))
+	defer srv.Close()
+
+	j := &Jira{URL: srv.URL + "/", JQL: "assignee = currentUser()", Email: "ana@acme.com", Token: "secret"}
+	items, err := j.Items(context.Background())
+	if err != nil {
+		t.Fatalf("Items: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2 across both pages", len(items))
+	}
+	if items[0].Title != "API-42 Fix login" || items[0].Notes != srv.URL+"/browse/API-42" ||
+		items[0].ExternalRef.String() != "jira:API-42" {
+		t.Errorf("first item = %+v", items[0])
+	}
+	if items[1].Title != "OPS-7 Rotate keys" {
+		t.Errorf("second item = %+v", items[1])
+	}
+}
@@ -0,0 +1,211 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// redacted replaces every match of a configured redaction pattern.
+const redacted = "[REDACTED]"
+
+// AuditEntry is one LLM call as written to the audit log.
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Provider   string    `json:"provider"`
+	Model      string    `json:"model"`
+	Kind       string    `json:"kind"` // "chat", "json" or "stream"
+	Messages   []Message `json:"messages"`
+	Response   string    `json:"response,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// AuditLog appends LLM prompts and responses to a local JSON Lines file,
+// replacing anything that matches a redaction pattern first.
+type AuditLog struct {
+	path   string
+	redact []*regexp.Regexp
+
+	mu sync.Mutex
+}
+
+// NewAuditLog creates an audit log writing to path. Each pattern is a regular
+// expression whose matches are replaced with [REDACTED].
+func NewAuditLog(path string, patterns []string) (*AuditLog, error) {
+	a := &AuditLog{path: path}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("compiling redact pattern %q: %w", p, err)
+		}
+		a.redact = append(a.redact, re)
+	}
+	return a, nil
+}
+
+// Path returns the file the log writes to.
+func (a *AuditLog) Path() string {
+	return a.path
+}
+
+// Redact replaces every match of the configured patterns in s.
+func (a *AuditLog) Redact(s string) string {
+	for _, re := range a.redact {
+		s = re.ReplaceAllString(s, redacted)
+	}
+	return s
+}
+
+// Record redacts e and appends it to the log.
+func (a *AuditLog) Record(e AuditEntry) error {
+	msgs := make([]Message, len(e.Messages))
+	for i, m := range e.Messages {
+		msgs[i] = Message{Role: m.Role, Content: a.Redact(m.Content)}
+	}
+	e.Messages = msgs
+	e.Response = a.Redact(e.Response)
+	e.Error = a.Redact(e.Error)
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encoding audit entry: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o755); err != nil {
+		return fmt.Errorf("creating audit directory: %w", err)
+	}
+	f, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// ReadAuditLog returns the last limit entries in path, oldest first.
+// A missing file yields no entries. Lines that fail to parse are skipped.
+func ReadAuditLog(path string, limit int) ([]AuditEntry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+	return entries, nil
+}
+
+var (
+	auditMu  sync.RWMutex
+	auditLog *AuditLog
+)
+
+// SetAuditLog makes NewClient wrap every client it creates so calls are
+// recorded to log. Passing nil turns auditing off.
+func SetAuditLog(log *AuditLog) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	auditLog = log
+}
+
+func currentAuditLog() *AuditLog {
+	auditMu.RLock()
+	defer auditMu.RUnlock()
+	return auditLog
+}
+
+// WithAudit wraps client so every call is recorded to log. The wrapper keeps
+// streaming support when client has it.
+func WithAudit(client Client, log *AuditLog, provider, model string) Client {
+	ac := &auditClient{client: client, log: log, provider: provider, model: model}
+	if streamer, ok := client.(StreamingClient); ok {
+		return &auditStreamingClient{auditClient: ac, streamer: streamer}
+	}
+	return ac
+}
+
+type auditClient struct {
+	client   Client
+	log      *AuditLog
+	provider string
+	model    string
+}
+
+func (c *auditClient) record(kind string, messages []Message, start time.Time, response string, err error) {
+	e := AuditEntry{
+		Time:       start,
+		Provider:   c.provider,
+		Model:      c.model,
+		Kind:       kind,
+		Messages:   messages,
+		Response:   response,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	// Auditing must never break planning, so write failures are dropped.
+	_ = c.log.Record(e)
+}
+
+func (c *auditClient) Chat(ctx context.Context, messages []Message) (string, error) {
+	start := time.Now()
+	content, err := c.client.Chat(ctx, messages)
+	c.record("chat", messages, start, content, err)
+	return content, err
+}
+
+func (c *auditClient) ChatJSON(ctx context.Context, messages []Message, result any) error {
+	start := time.Now()
+	err := c.client.ChatJSON(ctx, messages, result)
+	var response string
+	if err == nil {
+		if data, mErr := json.Marshal(result); mErr == nil {
+			response = string(data)
+		}
+	}
+	c.record("json", messages, start, response, err)
+	return err
+}
+
+type auditStreamingClient struct {
+	*auditClient
+	streamer StreamingClient
+}
+
+func (c *auditStreamingClient) ChatStream(ctx context.Context, messages []Message, onChunk func(string)) (string, error) {
+	start := time.Now()
+	content, err := c.streamer.ChatStream(ctx, messages, onChunk)
+	c.record("stream", messages, start, content, err)
+	return content, err
+}
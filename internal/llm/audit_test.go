@@ -0,0 +1,84 @@
+package llm
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditClient_RecordsRedactedCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := NewAuditLog(path, []string{`sk-[A-Za-z0-9]+`})
+	if err != nil {
+		t.Fatalf("NewAuditLog: %v", err)
+	}
+
+	client := WithAudit(fakeStreamingClient{chunks: []string{"ok ", "sk-abc123"}}, log, "copilot", "gpt-4o")
+	if _, ok := client.(StreamingClient); !ok {
+		t.Fatal("audited client lost streaming support")
+	}
+	msgs := []Message{{Role: "user", Content: "token sk-secret42 please"}}
+	if _, err := client.(StreamingClient).ChatStream(context.Background(), msgs, func(string) {}); err != nil {
+		t.Fatalf("ChatStream: %v", err)
+	}
+	if _, err := client.Chat(context.Background(), msgs); err == nil {
+		t.Fatal("expected Chat error from fake")
+	}
+
+	entries, err := ReadAuditLog(path, 0)
+	if err != nil {
+		t.Fatalf("ReadAuditLog: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	stream := entries[0]
+	if stream.Kind != "stream" || stream.Provider != "copilot" || stream.Model != "gpt-4o" {
+		t.Errorf("entry = %+v, want copilot/gpt-4o stream", stream)
+	}
+	if got := stream.Messages[0].Content; got != "token [REDACTED] please" {
+		t.Errorf("prompt = %q, want secret redacted", got)
+	}
+	if stream.Response != "ok [REDACTED]" {
+		t.Errorf("response = %q, want secret redacted", stream.Response)
+	}
+	if entries[1].Kind != "chat" || !strings.Contains(entries[1].Error, "not implemented") {
+		t.Errorf("chat entry = %+v, want recorded error", entries[1])
+	}
+	if msgs[0].Content != "token sk-secret42 please" {
+		t.Error("redaction modified the caller's messages")
+	}
+
+	last, err := ReadAuditLog(path, 1)
+	if err != nil {
+		t.Fatalf("ReadAuditLog: %v", err)
+	}
+	if len(last) != 1 || last[0].Kind != "chat" {
+		t.Errorf("limit 1 = %+v, want only the chat entry", last)
+	}
+}
+
+func TestNewClient_WrapsWhenAuditSet(t *testing.T) {
+	log, err := NewAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"), nil)
+	if err != nil {
+		t.Fatalf("NewAuditLog: %v", err)
+	}
+	SetAuditLog(log)
+	defer SetAuditLog(nil)
+
+	client, err := NewClient("ollama", "llama3", "")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, ok := client.(*auditStreamingClient); !ok {
+		t.Fatalf("client = %T, want audited streaming client", client)
+	}
+}
+
+func TestReadAuditLog_Missing(t *testing.T) {
+	entries, err := ReadAuditLog(filepath.Join(t.TempDir(), "none.jsonl"), 10)
+	if err != nil || entries != nil {
+		t.Errorf("got %v, %v; want no entries and no error", entries, err)
+	}
+}
@@ -3,6 +3,8 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 )
 
 // Message represents a chat message.
@@ -19,3 +21,20 @@ type Client interface {
 	// ChatJSON sends messages and parses the response as JSON into the provided type.
 	ChatJSON(ctx context.Context, messages []Message, result any) error
 }
+
+// RawJSON receives a JSON response as plain text instead of decoding it, so
+// callers can parse output that is not quite valid JSON themselves.
+type RawJSON string
+
+// decodeJSON stores a JSON response in result. Unless result is a *RawJSON,
+// the JSON is first extracted from any surrounding text or code fence.
+func decodeJSON(content string, result any) error {
+	if raw, ok := result.(*RawJSON); ok {
+		*raw = RawJSON(content)
+		return nil
+	}
+	if err := json.Unmarshal([]byte(extractJSON(content)), result); err != nil {
+		return fmt.Errorf("parsing JSON response: %w (content: %s)", err, content)
+	}
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
 )
 
 const (
@@ -40,7 +42,7 @@ func NewCopilotClient(model string) (*CopilotClient, error) {
 		model = DefaultModel
 	}
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
+	httpClient := httpclient.New(30 * time.Second)
 
 	// Load GitHub token
 	githubToken, err := LoadGitHubToken()
@@ -58,6 +60,7 @@ func NewCopilotClient(model string) (*CopilotClient, error) {
 	client := openai.NewClient(
 		option.WithBaseURL(copilotBaseURL),
 		option.WithAPIKey(bearerToken),
+		option.WithHTTPClient(httpclient.New(0)),
 		option.WithHeader("Editor-Version", "Sancho/1.0"),
 		option.WithHeader("Editor-Plugin-Version", "Sancho/1.0"),
 		option.WithHeader("Copilot-Integration-Id", "vscode-chat"),
@@ -137,14 +140,8 @@ func (c *CopilotClient) ChatJSON(ctx context.Context, messages []Message, result
 		return err
 	}
 
-	// Try to extract JSON from the response (may be wrapped in markdown code blocks)
-	jsonContent := extractJSON(content)
-
-	if err := json.Unmarshal([]byte(jsonContent), result); err != nil {
-		return fmt.Errorf("parsing JSON response: %w (content: %s)", err, content)
-	}
-
-	return nil
+	// The JSON may be wrapped in markdown code blocks
+	return decodeJSON(content, result)
 }
 
 // extractJSON attempts to extract JSON from a string that may contain markdown formatting.
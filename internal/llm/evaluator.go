@@ -36,6 +36,7 @@ Rules:
 - Keep each line under 70 characters
 - Be specific with times and durations from the data
 - If no issue exists for a category, omit that line
+- If a change from the previous week is given, let it inform NEXT WEEK
 - Output plain text only, no markdown formatting`
 
 // EvalOpts configures the evaluation behavior.
@@ -61,8 +62,13 @@ func NewEvaluatorWithOpts(client Client, opts EvalOpts) *Evaluator {
 }
 
 // EvaluateWeek sends the week's tasks to the LLM for deep work analysis.
-func (e *Evaluator) EvaluateWeek(ctx context.Context, start, end time.Time, tasks []*task.Task) (string, error) {
+// comparison, when not empty, describes the change from the previous week
+// (e.g. "deep hours +2.5h, postpones −3").
+func (e *Evaluator) EvaluateWeek(ctx context.Context, start, end time.Time, tasks []*task.Task, comparison string) (string, error) {
 	weekData := e.formatWeekData(start, end, tasks)
+	if comparison != "" {
+		weekData += "\nChange from previous week: " + comparison + "\n"
+	}
 
 	// Format peak hours for prompt (default to common values if not set)
 	peakStart := e.opts.PeakStart
@@ -113,10 +119,7 @@ func (e *Evaluator) formatWeekData(start, end time.Time, tasks []*task.Task) str
 		}
 
 		// Category
-		cat := "[S]"
-		if t.Category == task.CategoryDeep {
-			cat = "[D]"
-		}
+		cat := categoryTag(t.Category)
 
 		// Duration
 		duration := formatDuration(taskDurationMinutes(t))
@@ -133,6 +136,19 @@ func (e *Evaluator) formatWeekData(start, end time.Time, tasks []*task.Task) str
 	return sb.String()
 }
 
+// categoryTag labels a task's category for the model: [D] or [S] for the
+// built-in categories, and the full name of custom ones, e.g. [meeting].
+func categoryTag(c task.Category) string {
+	switch c {
+	case task.CategoryDeep:
+		return "[D]"
+	case task.CategoryShallow, "":
+		return "[S]"
+	default:
+		return "[" + string(c) + "]"
+	}
+}
+
 // taskDurationMinutes calculates the duration of a task in minutes.
 func taskDurationMinutes(t *task.Task) int {
 	start, err1 := time.Parse("15:04", t.ScheduledStart)
@@ -2,7 +2,8 @@ package llm
 
 import (
 	"fmt"
-	"strings"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
 )
 
 const (
@@ -12,15 +13,22 @@ const (
 )
 
 // NewClient creates an LLM client based on provider configuration.
+// Cloud providers are refused in local-only mode. When an audit log is set,
+// the client records every call to it.
 func NewClient(provider, model, baseURL string) (Client, error) {
-	switch strings.ToLower(strings.TrimSpace(provider)) {
-	case "", ProviderCopilot:
-		return NewCopilotClient(model)
-	case ProviderOllama:
-		return NewOllamaClient(model, baseURL)
-	case ProviderLMStudio, "lm-studio", "llmstudio":
-		return NewLMStudioClient(model, baseURL)
-	default:
-		return nil, fmt.Errorf("unsupported LLM provider: %s", provider)
+	p, err := LookupProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	if httpclient.LocalOnly() && !p.Local() {
+		return nil, fmt.Errorf("%s: %w", p.Name(), httpclient.ErrLocalOnly)
+	}
+	client, err := p.NewClient(model, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if log := currentAuditLog(); log != nil {
+		client = WithAudit(client, log, p.Name(), model)
 	}
+	return client, nil
 }
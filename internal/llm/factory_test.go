@@ -1,6 +1,12 @@
 package llm
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/httpclient"
+)
 
 func TestNewClient_Ollama(t *testing.T) {
 	client, err := NewClient("ollama", "llama3", "")
@@ -36,3 +42,74 @@ func TestNewClient_UnsupportedProvider(t *testing.T) {
 		t.Fatal("expected error for unsupported provider")
 	}
 }
+
+func TestLookupProvider_Aliases(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", ProviderCopilot},
+		{"Copilot", ProviderCopilot},
+		{" ollama ", ProviderOllama},
+		{"lm-studio", ProviderLMStudio},
+		{"llmstudio", ProviderLMStudio},
+	}
+	for _, tt := range tests {
+		p, err := LookupProvider(tt.name)
+		if err != nil {
+			t.Fatalf("LookupProvider(%q) error: %v", tt.name, err)
+		}
+		if p.Name() != tt.want {
+			t.Errorf("LookupProvider(%q).Name() = %q, want %q", tt.name, p.Name(), tt.want)
+		}
+	}
+}
+
+func TestIsLocalProvider(t *testing.T) {
+	if !IsLocalProvider("ollama") {
+		t.Error("expected ollama to be local")
+	}
+	if !IsLocalProvider("lmstudio") {
+		t.Error("expected lmstudio to be local")
+	}
+	if IsLocalProvider("copilot") {
+		t.Error("expected copilot to be remote")
+	}
+	if IsLocalProvider("unknown") {
+		t.Error("expected unknown provider to be remote")
+	}
+}
+
+func TestNewClientFromConfig_OllamaOverrides(t *testing.T) {
+	client, err := NewClientFromConfig(config.LLMConfig{
+		Provider: "ollama",
+		Model:    "gpt-4o",
+		BaseURL:  "http://localhost:11434",
+		Ollama:   config.OllamaConfig{Host: "http://gpu-box:11434", Model: "llama3.1"},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	ollamaClient, ok := client.(*OllamaClient)
+	if !ok {
+		t.Fatalf("expected OllamaClient, got %T", client)
+	}
+	if ollamaClient.model != "llama3.1" {
+		t.Errorf("model = %q, want %q", ollamaClient.model, "llama3.1")
+	}
+	if ollamaClient.baseURL != "http://gpu-box:11434" {
+		t.Errorf("baseURL = %q, want %q", ollamaClient.baseURL, "http://gpu-box:11434")
+	}
+}
+
+func TestNewClient_LocalOnlyRefusesCloudProviders(t *testing.T) {
+	httpclient.SetLocalOnly(true)
+	defer httpclient.SetLocalOnly(false)
+
+	if _, err := NewClient("copilot", "gpt-4o", ""); !errors.Is(err, httpclient.ErrLocalOnly) {
+		t.Errorf("copilot error = %v, want ErrLocalOnly", err)
+	}
+	if _, err := NewClient("ollama", "llama3", ""); err != nil {
+		t.Errorf("ollama error = %v, want nil", err)
+	}
+}
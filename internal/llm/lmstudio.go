@@ -2,7 +2,6 @@ package llm
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -10,6 +9,8 @@ import (
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
 )
 
 const defaultLMStudioBaseURL = "http://localhost:1234/v1"
@@ -41,6 +42,7 @@ func NewLMStudioClient(model, baseURL string) (*LMStudioClient, error) {
 	client := openai.NewClient(
 		option.WithBaseURL(baseURL),
 		option.WithAPIKey(apiKey),
+		option.WithHTTPClient(httpclient.New(0)),
 	)
 
 	return &LMStudioClient{
@@ -88,10 +90,5 @@ func (c *LMStudioClient) ChatJSON(ctx context.Context, messages []Message, resul
 		return err
 	}
 
-	jsonContent := extractJSON(content)
-	if err := json.Unmarshal([]byte(jsonContent), result); err != nil {
-		return fmt.Errorf("parsing JSON response: %w (content: %s)", err, content)
-	}
-
-	return nil
+	return decodeJSON(content, result)
 }
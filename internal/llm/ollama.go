@@ -2,13 +2,14 @@ package llm
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/ollama"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
 )
 
 const defaultOllamaBaseURL = "http://localhost:11434"
@@ -32,6 +33,7 @@ func NewOllamaClient(model, baseURL string) (*OllamaClient, error) {
 	client, err := ollama.New(
 		ollama.WithModel(model),
 		ollama.WithServerURL(baseURL),
+		ollama.WithHTTPClient(httpclient.New(0)),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating ollama client: %w", err)
@@ -71,11 +73,7 @@ func (c *OllamaClient) ChatJSON(ctx context.Context, messages []Message, result
 		return fmt.Errorf("no response choices returned")
 	}
 
-	content := extractJSON(resp.Choices[0].Content)
-	if err := json.Unmarshal([]byte(content), result); err != nil {
-		return fmt.Errorf("parsing JSON response: %w (content: %s)", err, resp.Choices[0].Content)
-	}
-	return nil
+	return decodeJSON(resp.Choices[0].Content, result)
 }
 
 func toLangChainMessages(messages []Message) []llms.MessageContent {
@@ -49,20 +49,22 @@ Other rules:
 3. Never overlap with existing tasks listed above
 4. Use 24-hour time format (HH:MM) for scheduled_start and scheduled_end
 5. Round durations to 15-minute increments (minimum 15 minutes)
-6. Categorize as "deep" (focused, cognitively demanding) or "shallow" (admin, meetings, email)
+6. Categorize as %s
 7. Schedule deep work in longer blocks, prefer earlier in the day
 8. Batch shallow tasks together when possible
 9. Add a warning if scheduling on a weekend (but still schedule it!)
 10. Warn if tasks don't fit in available time
 11. If a task lacks a specific time, infer a likely placement using the recent schedule history above
 12. If a task matches a suggested time window, prefer that time unless the user specifies otherwise
+13. Set "energy" to "high" for demanding tasks, "low" for easy ones, or leave it out
 
 Respond ONLY with valid JSON (no markdown, no explanation):
 {
   "tasks": [
     {
       "description": "string",
-      "category": "deep" or "shallow",
+      "category": %s,
+      "energy": "high", "medium" or "low" (optional),
       "scheduled_date": "YYYY-MM-DD",
       "scheduled_start": "HH:MM",
       "scheduled_end": "HH:MM"
@@ -91,7 +93,7 @@ Rules:
 - Do not overlap with existing tasks above.
 - Do not schedule before current time if scheduling today.
 - Use 15-minute increments (minimum 15 minutes).
-- Category must be "deep" or "shallow".
+- Category must be %s.
 - "warnings" and "suggestions" must be arrays of strings (no objects).
 
 JSON schema:
@@ -99,7 +101,8 @@ JSON schema:
   "tasks": [
     {
       "description": "string",
-      "category": "deep" or "shallow",
+      "category": %s,
+      "energy": "high", "medium" or "low" (optional),
       "scheduled_date": "YYYY-MM-DD",
       "scheduled_start": "HH:MM",
       "scheduled_end": "HH:MM"
@@ -112,22 +115,47 @@ JSON schema:
 // ExistingTask represents a task already in the schedule for LLM context.
 type ExistingTask struct {
 	Date        string // YYYY-MM-DD
-	Start       string // HH:MM
-	End         string // HH:MM
+	Start       string // HH:MM, empty for all-day tasks
+	End         string // HH:MM, empty for all-day tasks
 	Description string
-	Category    string // "deep" or "shallow"
+	Category    string // "deep", "shallow" or a configured category
+}
+
+// CategoryLimit is a weekly cap on the time spent on a category.
+type CategoryLimit struct {
+	Category     string
+	LimitMinutes int
+	UsedMinutes  int // Already scheduled in the current week
+}
+
+// ObjectiveBalance is a quarterly objective and the time attached to it so
+// far this quarter.
+type ObjectiveBalance struct {
+	Title            string
+	TargetMinutes    int // 0 when the objective has no target
+	ScheduledMinutes int
+}
+
+// ParkedTask is work committed to a day without a time yet.
+type ParkedTask struct {
+	Date  string // "YYYY-MM-DD"
+	Title string
 }
 
 // PlanRequest contains the input for the planner.
 type PlanRequest struct {
 	Input            string
 	Date             time.Time
-	DayStart         string         // "HH:MM"
-	DayEnd           string         // "HH:MM"
-	NextWorkday      string         // e.g., "Monday, January 13"
-	ExistingTasks    []ExistingTask // Tasks already scheduled (for overlap avoidance)
-	RecentTasks      []ExistingTask // Recent history for schedule pattern inference
-	UseCompactPrompt bool           // Use a shorter prompt for local models
+	DayStart         string             // "HH:MM"
+	DayEnd           string             // "HH:MM"
+	NextWorkday      string             // e.g., "Monday, January 13"
+	ExistingTasks    []ExistingTask     // Tasks already scheduled (for overlap avoidance)
+	RecentTasks      []ExistingTask     // Recent history for schedule pattern inference
+	Constraints      []string           // Fixed appointments and blocked windows, one per line
+	CategoryLimits   []CategoryLimit    // Weekly caps on categories, for the current week
+	Objectives       []ObjectiveBalance // Quarterly objectives, the furthest behind first
+	Parked           []ParkedTask       // Work parked on a day, waiting for a time
+	UseCompactPrompt bool               // Use a shorter prompt for local models
 }
 
 // PlanResponse contains the parsed LLM response.
@@ -135,6 +163,10 @@ type PlanResponse struct {
 	Tasks       []PlannedTask `json:"tasks"`
 	Warnings    []string      `json:"warnings"`
 	Suggestions []string      `json:"suggestions"`
+
+	// Dropped lists task entries that could not be used. Only
+	// ParsePlanResponse fills it.
+	Dropped []DroppedTask `json:"-"`
 }
 
 // PlannedTask represents a task planned by the LLM.
@@ -144,6 +176,7 @@ type PlannedTask struct {
 	ScheduledDate  string `json:"scheduled_date"` // YYYY-MM-DD format
 	ScheduledStart string `json:"scheduled_start"`
 	ScheduledEnd   string `json:"scheduled_end"`
+	Energy         string `json:"energy,omitempty"` // "high", "medium", "low" or empty
 }
 
 // Planner uses an LLM to plan tasks from natural language input.
@@ -168,6 +201,20 @@ func (p *Planner) PlanWithMessages(ctx context.Context, messages []Message) (*Pl
 	return p.planWithMessages(ctx, messages)
 }
 
+// PlanWithMessagesStream is like PlanWithMessages but reports response content
+// to onChunk as it streams in. Clients without streaming support fall back to a
+// single blocking call.
+//
+// The response is parsed with ParsePlanResponse, so a malformed plan yields
+// the usable tasks plus the dropped entries rather than an error.
+func (p *Planner) PlanWithMessagesStream(ctx context.Context, messages []Message, onChunk func(string)) (*PlanResponse, error) {
+	var raw RawJSON
+	if err := ChatJSONStream(ctx, p.client, messages, &raw, onChunk); err != nil {
+		return nil, fmt.Errorf("getting plan from LLM: %w", err)
+	}
+	return ParsePlanResponse(string(raw)), nil
+}
+
 // BuildInitialMessages creates the initial message list for a planning request.
 // Exported so dwplanner can build and modify messages for retries.
 func (p *Planner) BuildInitialMessages(req PlanRequest) []Message {
@@ -199,8 +246,22 @@ func (p *Planner) buildInitialMessages(req PlanRequest) []Message {
 	nextWorkdayDate := req.Date.AddDate(0, 0, 1).Format("2006-01-02")
 
 	existingSection := p.formatExistingTasks(req.ExistingTasks)
+	if len(req.Constraints) > 0 {
+		existingSection += "\n" + p.formatConstraints(req.Constraints)
+	}
+	if len(req.CategoryLimits) > 0 {
+		existingSection += "\n" + p.formatCategoryLimits(req.CategoryLimits)
+	}
+	if len(req.Objectives) > 0 {
+		existingSection += "\n" + p.formatObjectives(req.Objectives)
+	}
+	if len(req.Parked) > 0 {
+		existingSection += "\n" + p.formatParked(req.Parked)
+	}
 	recentSection := p.formatRecentTasks(req.RecentTasks)
 	suggestedSection := p.formatSuggestedTimes(req.RecentTasks)
+	categories := categoryChoices(false)
+	categoryRule := categoryChoices(true)
 
 	var prompt string
 	if req.UseCompactPrompt {
@@ -216,6 +277,8 @@ func (p *Planner) buildInitialMessages(req PlanRequest) []Message {
 			nextWorkdayDate, // Next workday date
 			existingSection, // Existing tasks
 			req.Input,       // User request
+			categories,      // Category rule
+			categories,      // Category in schema
 		)
 	} else {
 		prompt = fmt.Sprintf(systemPromptWithContext,
@@ -243,6 +306,8 @@ func (p *Planner) buildInitialMessages(req PlanRequest) []Message {
 			currentDate,      // Date parsing: today
 			tomorrowDate,     // Date parsing: tomorrow
 			currentTime,      // Current time for "don't schedule before"
+			categoryRule,     // Categorization rule
+			categories,       // Category in schema
 		)
 	}
 
@@ -251,6 +316,29 @@ func (p *Planner) buildInitialMessages(req PlanRequest) []Message {
 	}
 }
 
+// categoryChoices lists the registered categories for the prompt, e.g.
+// `"deep" or "shallow"`. described adds what deep and shallow work mean.
+func categoryChoices(described bool) string {
+	infos := task.Categories()
+	choices := make([]string, len(infos))
+	for i, info := range infos {
+		choices[i] = fmt.Sprintf("%q", info.Name)
+		if !described {
+			continue
+		}
+		switch info.Name {
+		case task.CategoryDeep:
+			choices[i] += " (focused, cognitively demanding)"
+		case task.CategoryShallow:
+			choices[i] += " (admin, meetings, email)"
+		}
+	}
+	if len(choices) == 1 {
+		return choices[0]
+	}
+	return strings.Join(choices[:len(choices)-1], ", ") + " or " + choices[len(choices)-1]
+}
+
 func dayKind(t time.Time) string {
 	switch t.Weekday() {
 	case time.Saturday, time.Sunday:
@@ -268,12 +356,59 @@ func (p *Planner) formatExistingTasks(tasks []ExistingTask) string {
 	var sb strings.Builder
 	sb.WriteString("Existing scheduled tasks (avoid overlaps):\n")
 	for _, t := range tasks {
+		if t.Start == "" && t.End == "" {
+			sb.WriteString(fmt.Sprintf("- %s all day: %s [%s] (takes no time, plan around it)\n",
+				t.Date, t.Description, t.Category))
+			continue
+		}
 		sb.WriteString(fmt.Sprintf("- %s %s-%s: %s [%s]\n",
 			t.Date, t.Start, t.End, t.Description, t.Category))
 	}
 	return sb.String()
 }
 
+func (p *Planner) formatConstraints(constraints []string) string {
+	var sb strings.Builder
+	sb.WriteString("Fixed appointments and blocked windows (never schedule over these):\n")
+	for _, c := range constraints {
+		sb.WriteString("- " + c + "\n")
+	}
+	return sb.String()
+}
+
+func (p *Planner) formatCategoryLimits(limits []CategoryLimit) string {
+	var sb strings.Builder
+	sb.WriteString("Weekly category limits (do not plan past these; warn instead):\n")
+	for _, l := range limits {
+		sb.WriteString(fmt.Sprintf("- %s: at most %s per week, %s already scheduled this week\n",
+			l.Category, formatDuration(l.LimitMinutes), formatDuration(l.UsedMinutes)))
+	}
+	return sb.String()
+}
+
+func (p *Planner) formatParked(parked []ParkedTask) string {
+	var sb strings.Builder
+	sb.WriteString("Parked tasks (committed to a day without a time; schedule each on its day, using its title as the description):\n")
+	for _, t := range parked {
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", t.Date, t.Title))
+	}
+	return sb.String()
+}
+
+func (p *Planner) formatObjectives(objectives []ObjectiveBalance) string {
+	var sb strings.Builder
+	sb.WriteString("Quarterly objectives (balance new deep work across these, favouring the ones furthest behind):\n")
+	for _, o := range objectives {
+		if o.TargetMinutes > 0 {
+			sb.WriteString(fmt.Sprintf("- %s: %s of %s scheduled this quarter\n",
+				o.Title, formatDuration(o.ScheduledMinutes), formatDuration(o.TargetMinutes)))
+		} else {
+			sb.WriteString(fmt.Sprintf("- %s: %s scheduled this quarter\n", o.Title, formatDuration(o.ScheduledMinutes)))
+		}
+	}
+	return sb.String()
+}
+
 func (p *Planner) formatRecentTasks(tasks []ExistingTask) string {
 	if len(tasks) == 0 {
 		return "Recent schedule history (last 14 days): None"
@@ -424,11 +559,11 @@ func minutesToHHMM(minutes int) string {
 }
 
 func (p *Planner) planWithMessages(ctx context.Context, messages []Message) (*PlanResponse, error) {
-	var resp PlanResponse
-	if err := p.client.ChatJSON(ctx, messages, &resp); err != nil {
+	var raw RawJSON
+	if err := p.client.ChatJSON(ctx, messages, &raw); err != nil {
 		return nil, fmt.Errorf("getting plan from LLM: %w", err)
 	}
-	return &resp, nil
+	return ParsePlanResponse(string(raw)), nil
 }
 
 // ToTasks converts planned tasks to domain Task objects.
@@ -437,10 +572,7 @@ func (pr *PlanResponse) ToTasks() ([]*task.Task, error) {
 	tasks := make([]*task.Task, 0, len(pr.Tasks))
 
 	for _, pt := range pr.Tasks {
-		category := task.CategoryDeep
-		if pt.Category == "shallow" {
-			category = task.CategoryShallow
-		}
+		category := task.CategoryOrDeep(pt.Category)
 
 		// Parse the scheduled date
 		scheduledDate, err := time.Parse("2006-01-02", pt.ScheduledDate)
@@ -471,10 +603,7 @@ func (pr *PlanResponse) ToTasksWithFallback(todayDate, nextWorkdayDate time.Time
 	tasks := make([]*task.Task, 0, len(pr.Tasks))
 
 	for _, pt := range pr.Tasks {
-		category := task.CategoryDeep
-		if pt.Category == "shallow" {
-			category = task.CategoryShallow
-		}
+		category := task.CategoryOrDeep(pt.Category)
 
 		// Determine the scheduled date
 		var scheduledDate time.Time
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
 )
 
 func TestBuildInitialMessages_IncludesWeekdayContext(t *testing.T) {
@@ -71,6 +73,125 @@ func TestBuildInitialMessages_CompactPrompt(t *testing.T) {
 	}
 }
 
+func TestBuildInitialMessages_ListsCategories(t *testing.T) {
+	req := PlanRequest{Input: "Plan my day", Date: time.Date(2026, 1, 8, 9, 30, 0, 0, time.UTC)}
+	content := NewPlanner(nil).BuildInitialMessages(req)[0].Content
+	if !strings.Contains(content, `"category": "deep" or "shallow",`) {
+		t.Fatalf("default schema should list deep and shallow: %s", content)
+	}
+
+	task.SetCategories([]task.CategoryInfo{{Name: "meeting", Code: "M"}})
+	defer task.SetCategories(nil)
+	for _, compact := range []bool{false, true} {
+		req.UseCompactPrompt = compact
+		content := NewPlanner(nil).BuildInitialMessages(req)[0].Content
+		if !strings.Contains(content, `"category": "deep", "shallow" or "meeting",`) {
+			t.Errorf("compact=%v: schema should list the custom category: %s", compact, content)
+		}
+	}
+
+	resp := &PlanResponse{Tasks: []PlannedTask{
+		{Description: "Sync", Category: "meeting", ScheduledDate: "2026-01-08", ScheduledStart: "10:00", ScheduledEnd: "10:30"},
+		{Description: "Guess", Category: "errand", ScheduledDate: "2026-01-08", ScheduledStart: "11:00", ScheduledEnd: "11:30"},
+	}}
+	tasks, err := resp.ToTasks()
+	if err != nil {
+		t.Fatalf("ToTasks: %v", err)
+	}
+	if tasks[0].Category != "meeting" || tasks[1].Category != task.CategoryDeep {
+		t.Errorf("categories = %s, %s; want meeting and the deep fallback", tasks[0].Category, tasks[1].Category)
+	}
+}
+
+func TestBuildInitialMessages_IncludesConstraints(t *testing.T) {
+	planner := NewPlanner(nil)
+	req := PlanRequest{
+		Input:       "Write report",
+		Date:        time.Date(2026, 1, 8, 9, 30, 0, 0, time.UTC),
+		Constraints: []string{"standup 10:00-10:15 (fixed, daily)"},
+	}
+
+	for _, compact := range []bool{false, true} {
+		req.UseCompactPrompt = compact
+		content := planner.BuildInitialMessages(req)[0].Content
+		if !strings.Contains(content, "Fixed appointments and blocked windows") {
+			t.Fatalf("compact=%v: missing constraints header: %s", compact, content)
+		}
+		if !strings.Contains(content, "- standup 10:00-10:15 (fixed, daily)") {
+			t.Fatalf("compact=%v: missing constraint line: %s", compact, content)
+		}
+	}
+}
+
+func TestBuildInitialMessages_IncludesCategoryLimits(t *testing.T) {
+	planner := NewPlanner(nil)
+	req := PlanRequest{
+		Input:          "Plan my week",
+		Date:           time.Date(2026, 1, 8, 9, 30, 0, 0, time.UTC),
+		CategoryLimits: []CategoryLimit{{Category: "shallow", LimitMinutes: 600, UsedMinutes: 390}},
+	}
+
+	for _, compact := range []bool{false, true} {
+		req.UseCompactPrompt = compact
+		content := planner.BuildInitialMessages(req)[0].Content
+		if !strings.Contains(content, "- shallow: at most 10h per week, 6h30m already scheduled this week") {
+			t.Fatalf("compact=%v: missing category limit: %s", compact, content)
+		}
+	}
+}
+
+func TestBuildInitialMessages_IncludesObjectives(t *testing.T) {
+	planner := NewPlanner(nil)
+	req := PlanRequest{
+		Input: "Plan my week",
+		Date:  time.Date(2026, 1, 8, 9, 30, 0, 0, time.UTC),
+		Objectives: []ObjectiveBalance{
+			{Title: "Ship v2", TargetMinutes: 2400, ScheduledMinutes: 720},
+			{Title: "Hiring", ScheduledMinutes: 90},
+		},
+	}
+
+	for _, compact := range []bool{false, true} {
+		req.UseCompactPrompt = compact
+		content := planner.BuildInitialMessages(req)[0].Content
+		for _, want := range []string{"- Ship v2: 12h of 40h scheduled this quarter", "- Hiring: 1h30m scheduled this quarter"} {
+			if !strings.Contains(content, want) {
+				t.Fatalf("compact=%v: missing objective %q: %s", compact, want, content)
+			}
+		}
+	}
+}
+
+func TestBuildInitialMessages_IncludesParked(t *testing.T) {
+	planner := NewPlanner(nil)
+	req := PlanRequest{
+		Input:  "Plan my week",
+		Date:   time.Date(2026, 1, 8, 9, 30, 0, 0, time.UTC),
+		Parked: []ParkedTask{{Date: "2026-01-09", Title: "Call the bank"}},
+	}
+
+	for _, compact := range []bool{false, true} {
+		req.UseCompactPrompt = compact
+		content := planner.BuildInitialMessages(req)[0].Content
+		if want := "- 2026-01-09: Call the bank"; !strings.Contains(content, want) {
+			t.Fatalf("compact=%v: missing parked task %q: %s", compact, want, content)
+		}
+	}
+}
+
+func TestFormatExistingTasks_AllDay(t *testing.T) {
+	planner := NewPlanner(nil)
+	got := planner.formatExistingTasks([]ExistingTask{
+		{Date: "2026-01-09", Description: "Conference", Category: "shallow"},
+		{Date: "2026-01-09", Start: "09:00", End: "10:00", Description: "Standup", Category: "shallow"},
+	})
+	for _, want := range []string{"- 2026-01-09 all day: Conference [shallow]", "- 2026-01-09 09:00-10:00: Standup [shallow]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q: %s", want, got)
+		}
+	}
+}
+
 func TestSortedExistingTasks_ByDateTime(t *testing.T) {
 	tasks := []ExistingTask{
 		{Date: "2026-01-08", Start: "09:00", End: "10:00", Description: "B", Category: "deep"},
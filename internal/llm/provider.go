@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/javiermolinar/sancho/internal/config"
+)
+
+// Provider builds Clients for a single LLM backend.
+type Provider interface {
+	// Name returns the canonical provider name used in config.
+	Name() string
+
+	// Local reports whether the backend runs on the user's machine and
+	// therefore needs no cloud API key.
+	Local() bool
+
+	// NewClient creates a client for the given model and endpoint.
+	NewClient(model, baseURL string) (Client, error)
+}
+
+type providerFunc struct {
+	name  string
+	local bool
+	newFn func(model, baseURL string) (Client, error)
+}
+
+func (p providerFunc) Name() string { return p.name }
+func (p providerFunc) Local() bool  { return p.local }
+
+func (p providerFunc) NewClient(model, baseURL string) (Client, error) {
+	return p.newFn(model, baseURL)
+}
+
+var providers = map[string]Provider{
+	ProviderCopilot: providerFunc{
+		name: ProviderCopilot,
+		newFn: func(model, _ string) (Client, error) {
+			return NewCopilotClient(model)
+		},
+	},
+	ProviderOllama: providerFunc{
+		name:  ProviderOllama,
+		local: true,
+		newFn: func(model, baseURL string) (Client, error) {
+			return NewOllamaClient(model, baseURL)
+		},
+	},
+	ProviderLMStudio: providerFunc{
+		name:  ProviderLMStudio,
+		local: true,
+		newFn: func(model, baseURL string) (Client, error) {
+			return NewLMStudioClient(model, baseURL)
+		},
+	},
+}
+
+var providerAliases = map[string]string{
+	"":          ProviderCopilot,
+	"lm-studio": ProviderLMStudio,
+	"llmstudio": ProviderLMStudio,
+}
+
+// LookupProvider returns the provider registered under name or one of its aliases.
+func LookupProvider(name string) (Provider, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := providerAliases[key]; ok {
+		key = alias
+	}
+	p, ok := providers[key]
+	if !ok {
+		return nil, fmt.Errorf("unsupported LLM provider: %s (available: %s)", name, strings.Join(ProviderNames(), ", "))
+	}
+	return p, nil
+}
+
+// ProviderNames returns the canonical names of all registered providers.
+func ProviderNames() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsLocalProvider reports whether the named provider runs without a cloud API.
+// Unknown providers are treated as remote.
+func IsLocalProvider(name string) bool {
+	p, err := LookupProvider(name)
+	if err != nil {
+		return false
+	}
+	return p.Local()
+}
+
+// NewClientFromConfig creates a client using the effective provider settings
+// from cfg, including any provider-specific overrides such as [llm.ollama].
+func NewClientFromConfig(cfg config.LLMConfig) (Client, error) {
+	model, baseURL := cfg.Endpoint()
+	return NewClient(cfg.Provider, model, baseURL)
+}
@@ -0,0 +1,154 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+const reflectorSystemPrompt = `You are a deep work coach reviewing how a person's plans played out. Output ONLY the exact format shown - no markdown, no extra text. Be concise and specific.`
+
+const reflectPromptTemplate = `Review the last week of planned work blocks and output EXACTLY this format (no markdown, no code blocks):
+
+OBSERVATIONS:
+- Pattern you noticed, citing times, days or categories from the data
+- Another pattern
+
+ADJUSTMENTS:
+- Concrete change to how future weeks are planned
+- Another change
+
+Data Format:
+- [D] = Deep Work, [S] = Shallow Work
+- outcome: over = ran longer than planned, under = finished early, on time otherwise
+- postponed = block was moved to another slot, cancelled = block was dropped
+- (from postponed) = block is the rescheduled copy of an earlier one
+
+Work Log:
+%s
+
+Rules:
+- 2-4 observations and 2-3 adjustments
+- Look for patterns such as blocks after a certain hour running over,
+  tasks that keep being postponed, or days overloaded with shallow work
+- Keep each line under 80 characters
+- Only mention what the data supports
+- Output plain text only, no markdown formatting`
+
+// Reflection is the LLM's review of past work.
+type Reflection struct {
+	Observations []string
+	Adjustments  []string
+}
+
+// Reflector asks the LLM for observations and planning adjustments based on
+// how past tasks turned out.
+type Reflector struct {
+	client Client
+}
+
+// NewReflector creates a new Reflector with the given LLM client.
+func NewReflector(client Client) *Reflector {
+	return &Reflector{client: client}
+}
+
+// Reflect sends the tasks between start and end, including outcomes and
+// postpones, to the LLM and parses its observations and adjustments.
+func (r *Reflector) Reflect(ctx context.Context, start, end time.Time, tasks []*task.Task) (*Reflection, error) {
+	prompt := fmt.Sprintf(reflectPromptTemplate, formatReflectionLog(start, end, tasks))
+
+	resp, err := r.client.Chat(ctx, []Message{
+		{Role: "system", Content: reflectorSystemPrompt},
+		{Role: "user", Content: prompt},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParseReflection(resp), nil
+}
+
+// ParseReflection splits an LLM reply into observations and adjustments.
+// Lines outside a known section are treated as observations so a loosely
+// formatted reply is still shown.
+func ParseReflection(text string) *Reflection {
+	r := &Reflection{}
+	section := &r.Observations
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "```") {
+			continue
+		}
+
+		header := strings.ToUpper(strings.TrimRight(strings.Trim(line, "*# "), ":"))
+		switch header {
+		case "OBSERVATIONS", "OBSERVATION":
+			section = &r.Observations
+			continue
+		case "ADJUSTMENTS", "ADJUSTMENT", "SUGGESTED ADJUSTMENTS", "SUGGESTIONS":
+			section = &r.Adjustments
+			continue
+		}
+
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*•➜ "))
+		if line != "" {
+			*section = append(*section, line)
+		}
+	}
+	return r
+}
+
+// formatReflectionLog formats tasks with their status and outcome for the
+// reflection prompt.
+func formatReflectionLog(start, end time.Time, tasks []*task.Task) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Period: %s - %s\n\n",
+		start.Format("Mon Jan 2"),
+		end.Format("Mon Jan 2, 2006")))
+
+	var currentDate string
+	for _, t := range tasks {
+		date := t.ScheduledDate.Format("2006-01-02")
+		if date != currentDate {
+			if currentDate != "" {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(fmt.Sprintf("%s\n", t.ScheduledDate.Format("Mon Jan 2")))
+			currentDate = date
+		}
+
+		cat := categoryTag(t.Category)
+
+		var notes []string
+		switch t.Status {
+		case task.StatusPostponed:
+			notes = append(notes, "postponed")
+		case task.StatusCancelled:
+			notes = append(notes, "cancelled")
+		case task.StatusMissed:
+			notes = append(notes, "missed")
+		}
+		if t.Outcome != nil && *t.Outcome != task.OutcomeOnTime {
+			notes = append(notes, "outcome: "+string(*t.Outcome))
+		}
+		if t.PostponedFrom != nil {
+			notes = append(notes, "(from postponed)")
+		}
+
+		line := fmt.Sprintf("  %s-%s  %s  %s  %s",
+			t.ScheduledStart,
+			t.ScheduledEnd,
+			cat,
+			t.Description,
+			formatDuration(taskDurationMinutes(t)))
+		if len(notes) > 0 {
+			line += "  " + strings.Join(notes, ", ")
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	return sb.String()
+}
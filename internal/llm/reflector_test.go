@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+type fakeChatClient struct {
+	reply    string
+	messages []Message
+}
+
+func (f *fakeChatClient) Chat(ctx context.Context, messages []Message) (string, error) {
+	f.messages = messages
+	return f.reply, nil
+}
+
+func (f *fakeChatClient) ChatJSON(ctx context.Context, messages []Message, result any) error {
+	return errors.New("not implemented")
+}
+
+func TestParseReflection(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		observations []string
+		adjustments  []string
+	}{
+		{
+			name: "expected format",
+			input: `OBSERVATIONS:
+- Deep blocks after 15:00 ran over 3 times
+- "Write report" was postponed twice
+
+ADJUSTMENTS:
+- Move deep work before 13:00
+- Split "Write report" into smaller blocks`,
+			observations: []string{"Deep blocks after 15:00 ran over 3 times", `"Write report" was postponed twice`},
+			adjustments:  []string{"Move deep work before 13:00", `Split "Write report" into smaller blocks`},
+		},
+		{
+			name:         "markdown headers",
+			input:        "**Observations**\n* Mondays are overloaded\n## Adjustments:\n➜ Keep Monday light",
+			observations: []string{"Mondays are overloaded"},
+			adjustments:  []string{"Keep Monday light"},
+		},
+		{
+			name:         "free text",
+			input:        "Your week looked balanced.",
+			observations: []string{"Your week looked balanced."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseReflection(tt.input)
+			if strings.Join(got.Observations, "|") != strings.Join(tt.observations, "|") {
+				t.Errorf("observations = %q, want %q", got.Observations, tt.observations)
+			}
+			if strings.Join(got.Adjustments, "|") != strings.Join(tt.adjustments, "|") {
+				t.Errorf("adjustments = %q, want %q", got.Adjustments, tt.adjustments)
+			}
+		})
+	}
+}
+
+func TestReflectorPromptIncludesOutcomesAndPostpones(t *testing.T) {
+	client := &fakeChatClient{reply: "OBSERVATIONS:\n- ok\nADJUSTMENTS:\n- none"}
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	over := task.OutcomeOver
+	from := int64(1)
+	tasks := []*task.Task{
+		{Description: "Write report", Category: task.CategoryDeep, ScheduledDate: monday, ScheduledStart: "15:00", ScheduledEnd: "17:00", Status: task.StatusPostponed},
+		{Description: "Write report", Category: task.CategoryDeep, ScheduledDate: monday.AddDate(0, 0, 1), ScheduledStart: "15:00", ScheduledEnd: "17:00", Status: task.StatusScheduled, Outcome: &over, PostponedFrom: &from},
+		{Description: "Email", Category: task.CategoryShallow, ScheduledDate: monday.AddDate(0, 0, 1), ScheduledStart: "17:00", ScheduledEnd: "17:30", Status: task.StatusCancelled},
+	}
+
+	result, err := NewReflector(client).Reflect(context.Background(), monday, monday.AddDate(0, 0, 6), tasks)
+	if err != nil {
+		t.Fatalf("Reflect: %v", err)
+	}
+	if len(result.Observations) != 1 || len(result.Adjustments) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if len(client.messages) != 2 {
+		t.Fatalf("messages = %d, want 2", len(client.messages))
+	}
+	prompt := client.messages[1].Content
+	for _, want := range []string{
+		"15:00-17:00  [D]  Write report  2h  postponed",
+		"outcome: over, (from postponed)",
+		"17:00-17:30  [S]  Email  30m  cancelled",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DroppedTask is a task entry of a plan response that could not be used.
+type DroppedTask struct {
+	Index  int    // Position in the response's task list, or -1 for the whole response
+	Entry  string // The entry as the model wrote it
+	Reason string
+}
+
+// String describes the entry and why it was dropped, e.g.
+// "task 2: missing scheduled_end".
+func (d DroppedTask) String() string {
+	if d.Index < 0 {
+		return "response: " + d.Reason
+	}
+	return fmt.Sprintf("task %d: %s", d.Index+1, d.Reason)
+}
+
+// ParsePlanResponse parses a plan response leniently. Commentary around the
+// JSON and unknown fields are ignored, task entries that cannot be used are
+// listed in Dropped with the reason, and when the JSON is cut off or broken
+// the complete entries before the damage are kept. A response without any
+// task list yields no tasks and a single dropped entry.
+func ParsePlanResponse(content string) *PlanResponse {
+	resp := &PlanResponse{}
+
+	var envelope struct {
+		Tasks       json.RawMessage `json:"tasks"`
+		Warnings    json.RawMessage `json:"warnings"`
+		Suggestions json.RawMessage `json:"suggestions"`
+	}
+	// A cut-off response can still contain a complete object, such as its
+	// first task, so only trust a decoded envelope that has a task list.
+	if err := json.Unmarshal([]byte(extractJSON(content)), &envelope); err == nil && envelope.Tasks != nil {
+		var entries []json.RawMessage
+		if len(envelope.Tasks) > 0 && json.Unmarshal(envelope.Tasks, &entries) != nil {
+			resp.drop(-1, string(envelope.Tasks), "tasks is not a list")
+		}
+		for i, entry := range entries {
+			resp.addTask(i, entry)
+		}
+		resp.Warnings = planNotes(envelope.Warnings)
+		resp.Suggestions = planNotes(envelope.Suggestions)
+		return resp
+	}
+
+	entries, rest, found := planTaskEntries(content)
+	if !found {
+		resp.drop(-1, strings.TrimSpace(content), "no task list found")
+		return resp
+	}
+	for i, entry := range entries {
+		resp.addTask(i, json.RawMessage(entry))
+	}
+	if rest = strings.TrimSpace(rest); rest != "" {
+		resp.drop(len(entries), rest, "response was cut off")
+	}
+	return resp
+}
+
+// addTask decodes one task entry, dropping it if it is not a task object or
+// lacks a required field. Values in the wrong format are left to validation.
+func (r *PlanResponse) addTask(index int, entry json.RawMessage) {
+	var pt PlannedTask
+	if err := json.Unmarshal(entry, &pt); err != nil {
+		r.drop(index, string(entry), "invalid entry: "+strings.TrimPrefix(err.Error(), "json: "))
+		return
+	}
+	var missing []string
+	for _, f := range []struct{ name, value string }{
+		{"description", pt.Description},
+		{"scheduled_date", pt.ScheduledDate},
+		{"scheduled_start", pt.ScheduledStart},
+		{"scheduled_end", pt.ScheduledEnd},
+	} {
+		if strings.TrimSpace(f.value) == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	if len(missing) > 0 {
+		r.drop(index, string(entry), "missing "+strings.Join(missing, ", "))
+		return
+	}
+	r.Tasks = append(r.Tasks, pt)
+}
+
+func (r *PlanResponse) drop(index int, entry, reason string) {
+	r.Dropped = append(r.Dropped, DroppedTask{Index: index, Entry: entry, Reason: reason})
+}
+
+// planNotes reads warnings or suggestions. Models sometimes send a single
+// string or objects instead of a list of strings; objects are kept as JSON.
+func planNotes(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var note string
+	if json.Unmarshal(raw, &note) == nil {
+		if note == "" {
+			return nil
+		}
+		return []string{note}
+	}
+	var items []json.RawMessage
+	if json.Unmarshal(raw, &items) != nil {
+		return []string{string(raw)}
+	}
+	notes := make([]string, 0, len(items))
+	for _, item := range items {
+		if json.Unmarshal(item, &note) == nil {
+			notes = append(notes, note)
+		} else {
+			notes = append(notes, string(item))
+		}
+	}
+	return notes
+}
+
+// planTaskEntries scans the "tasks" array of a possibly incomplete response
+// and returns the complete objects in it. rest holds an unfinished trailing
+// entry when the array is not closed. found is false when there is no array.
+func planTaskEntries(content string) (entries []string, rest string, found bool) {
+	keyIdx := strings.Index(content, `"tasks"`)
+	if keyIdx == -1 {
+		return nil, "", false
+	}
+	tail := content[keyIdx+len(`"tasks"`):]
+	arrIdx := strings.IndexByte(tail, '[')
+	if arrIdx == -1 {
+		return nil, "", false
+	}
+	tail = tail[arrIdx+1:]
+
+	var (
+		depth    int
+		start    = -1
+		inString bool
+		escaped  bool
+	)
+	for i := 0; i < len(tail); i++ {
+		c := tail[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case '}':
+			depth--
+			if depth == 0 && start >= 0 {
+				entries = append(entries, tail[start:i+1])
+				start = -1
+			}
+		case ']':
+			if depth == 0 {
+				return entries, "", true
+			}
+		}
+	}
+	if start >= 0 {
+		rest = tail[start:]
+	}
+	return entries, rest, true
+}
@@ -0,0 +1,91 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParsePlanResponse(t *testing.T) {
+	const good = `{"description": "Write", "category": "deep", "scheduled_date": "2025-01-06", "scheduled_start": "09:00", "scheduled_end": "10:00"}`
+
+	tests := []struct {
+		name        string
+		content     string
+		wantTasks   []string
+		wantDropped []string
+		wantWarning []string
+	}{
+		{
+			name:      "valid with unknown fields",
+			content:   `{"tasks": [` + good[:len(good)-1] + `, "priority": 1}], "warnings": ["busy"], "notes": "x"}`,
+			wantTasks: []string{"Write"},
+			wantWarning: []string{
+				"busy",
+			},
+		},
+		{
+			name:      "trailing commentary",
+			content:   `{"tasks": [` + good + `]}` + "\nI scheduled the writing first because {it} matters.",
+			wantTasks: []string{"Write"},
+		},
+		{
+			name:        "entry missing fields and wrong types",
+			content:     `{"tasks": [` + good + `, {"description": "Email"}, {"description": 5}], "warnings": "one warning", "suggestions": [{"text": "batch"}]}`,
+			wantTasks:   []string{"Write"},
+			wantDropped: []string{"task 2: missing scheduled_date, scheduled_start, scheduled_end", "task 3: invalid entry"},
+			wantWarning: []string{"one warning"},
+		},
+		{
+			name:        "cut off mid entry",
+			content:     `{"tasks": [` + good + `, {"description": "Rev`,
+			wantTasks:   []string{"Write"},
+			wantDropped: []string{"task 2: response was cut off"},
+		},
+		{
+			name:        "no plan at all",
+			content:     "Sorry, I cannot help with that.",
+			wantDropped: []string{"response: no task list found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := ParsePlanResponse(tt.content)
+			if len(resp.Tasks) != len(tt.wantTasks) {
+				t.Fatalf("got %d tasks (%+v), want %d", len(resp.Tasks), resp.Tasks, len(tt.wantTasks))
+			}
+			for i, desc := range tt.wantTasks {
+				if resp.Tasks[i].Description != desc {
+					t.Errorf("task %d = %q, want %q", i, resp.Tasks[i].Description, desc)
+				}
+			}
+			if len(resp.Dropped) != len(tt.wantDropped) {
+				t.Fatalf("dropped = %v, want %v", resp.Dropped, tt.wantDropped)
+			}
+			for i, want := range tt.wantDropped {
+				if got := resp.Dropped[i].String(); !strings.HasPrefix(got, want) {
+					t.Errorf("dropped %d = %q, want prefix %q", i, got, want)
+				}
+			}
+			if tt.wantWarning != nil && strings.Join(resp.Warnings, "|") != strings.Join(tt.wantWarning, "|") {
+				t.Errorf("warnings = %v, want %v", resp.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestPlanWithMessagesStream_SalvagesMalformedPlan(t *testing.T) {
+	client := fakeStreamingClient{chunks: []string{
+		"```json\n{\"tasks\": [{\"description\": \"Write\", \"scheduled_date\": \"2025-01-06\", ",
+		"\"scheduled_start\": \"09:00\", \"scheduled_end\": \"10:00\"}, {\"descr",
+	}}
+
+	resp, err := NewPlanner(client).PlanWithMessagesStream(context.Background(), nil, func(string) {})
+	if err != nil {
+		t.Fatalf("PlanWithMessagesStream: %v", err)
+	}
+	if len(resp.Tasks) != 1 || len(resp.Dropped) != 1 {
+		t.Errorf("tasks = %+v, dropped = %+v; want one of each", resp.Tasks, resp.Dropped)
+	}
+}
@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openai/openai-go"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// StreamingClient is implemented by clients that can stream response tokens.
+type StreamingClient interface {
+	// ChatStream sends messages and calls onChunk for each piece of content as
+	// it arrives. It returns the full response once the stream completes.
+	ChatStream(ctx context.Context, messages []Message, onChunk func(string)) (string, error)
+}
+
+// ChatJSONStream streams a JSON response into result when the client supports
+// streaming, and falls back to a regular ChatJSON call otherwise.
+func ChatJSONStream(ctx context.Context, client Client, messages []Message, result any, onChunk func(string)) error {
+	streamer, ok := client.(StreamingClient)
+	if !ok || onChunk == nil {
+		return client.ChatJSON(ctx, messages, result)
+	}
+
+	content, err := streamer.ChatStream(ctx, messages, onChunk)
+	if err != nil {
+		return err
+	}
+
+	return decodeJSON(content, result)
+}
+
+// ChatStream streams the Copilot response.
+func (c *CopilotClient) ChatStream(ctx context.Context, messages []Message, onChunk func(string)) (string, error) {
+	content, err := streamOpenAI(ctx, c.client, c.model, messages, onChunk)
+	if err != nil {
+		return "", fmt.Errorf("chat completion stream: %w", err)
+	}
+	return content, nil
+}
+
+// ChatStream streams the LM Studio response.
+func (c *LMStudioClient) ChatStream(ctx context.Context, messages []Message, onChunk func(string)) (string, error) {
+	content, err := streamOpenAI(ctx, c.client, c.model, messages, onChunk)
+	if err != nil {
+		return "", fmt.Errorf("lm studio chat completion stream: %w", err)
+	}
+	return content, nil
+}
+
+// ChatStream streams the Ollama response in JSON mode.
+func (c *OllamaClient) ChatStream(ctx context.Context, messages []Message, onChunk func(string)) (string, error) {
+	var content strings.Builder
+	_, err := c.client.GenerateContent(
+		ctx,
+		toLangChainMessages(messages),
+		llms.WithModel(c.model),
+		llms.WithJSONMode(),
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			content.Write(chunk)
+			onChunk(string(chunk))
+			return nil
+		}),
+	)
+	if err != nil {
+		return "", fmt.Errorf("ollama chat stream: %w", err)
+	}
+	return content.String(), nil
+}
+
+func streamOpenAI(ctx context.Context, client openai.Client, model string, messages []Message, onChunk func(string)) (string, error) {
+	stream := client.Chat.Completions.NewStreaming(ctx, openai.ChatCompletionNewParams{
+		Model:    model,
+		Messages: toOpenAIMessages(messages),
+	})
+	defer func() { _ = stream.Close() }()
+
+	var content strings.Builder
+	for stream.Next() {
+		chunk := stream.Current()
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		delta := chunk.Choices[0].Delta.Content
+		if delta == "" {
+			continue
+		}
+		content.WriteString(delta)
+		onChunk(delta)
+	}
+	if err := stream.Err(); err != nil {
+		return "", err
+	}
+	return content.String(), nil
+}
+
+func toOpenAIMessages(messages []Message) []openai.ChatCompletionMessageParamUnion {
+	result := make([]openai.ChatCompletionMessageParamUnion, len(messages))
+	for i, msg := range messages {
+		switch msg.Role {
+		case "system":
+			result[i] = openai.SystemMessage(msg.Content)
+		case "assistant":
+			result[i] = openai.AssistantMessage(msg.Content)
+		default:
+			result[i] = openai.UserMessage(msg.Content)
+		}
+	}
+	return result
+}
+
+// PartialPlanTasks extracts the tasks that are fully present in a partially
+// streamed plan response. Incomplete trailing objects are ignored.
+func PartialPlanTasks(content string) []PlannedTask {
+	entries, _, _ := planTaskEntries(content)
+	var tasks []PlannedTask
+	for _, entry := range entries {
+		var pt PlannedTask
+		if err := json.Unmarshal([]byte(entry), &pt); err == nil {
+			tasks = append(tasks, pt)
+		}
+	}
+	return tasks
+}
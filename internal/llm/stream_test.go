@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeStreamingClient struct {
+	chunks []string
+}
+
+func (f fakeStreamingClient) Chat(ctx context.Context, messages []Message) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (f fakeStreamingClient) ChatJSON(ctx context.Context, messages []Message, result any) error {
+	return errors.New("not implemented")
+}
+
+func (f fakeStreamingClient) ChatStream(ctx context.Context, messages []Message, onChunk func(string)) (string, error) {
+	for _, c := range f.chunks {
+		onChunk(c)
+	}
+	return strings.Join(f.chunks, ""), nil
+}
+
+func TestChatJSONStream(t *testing.T) {
+	client := fakeStreamingClient{chunks: []string{
+		`{"tasks": [{"description": "Wri`,
+		`te", "category": "deep"}], "warnings": []}`,
+	}}
+
+	var received []string
+	var resp PlanResponse
+	err := ChatJSONStream(context.Background(), client, nil, &resp, func(chunk string) {
+		received = append(received, chunk)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received) != 2 {
+		t.Errorf("received %d chunks, want 2", len(received))
+	}
+	if len(resp.Tasks) != 1 || resp.Tasks[0].Description != "Write" {
+		t.Errorf("tasks = %+v, want one task named Write", resp.Tasks)
+	}
+}
+
+func TestPartialPlanTasks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "no tasks key yet",
+			content: `{"tas`,
+			want:    nil,
+		},
+		{
+			name:    "incomplete first task",
+			content: `{"tasks": [{"description": "Write`,
+			want:    nil,
+		},
+		{
+			name:    "one complete task and one partial",
+			content: `{"tasks": [{"description": "Write {draft}", "category": "deep"}, {"description": "Em`,
+			want:    []string{"Write {draft}"},
+		},
+		{
+			name:    "closed array ignores later objects",
+			content: "```json\n" + `{"tasks": [{"description": "A"}, {"description": "B \"quoted\""}], "warnings": [{"x": 1}]}`,
+			want:    []string{"A", `B "quoted"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PartialPlanTasks(tt.content)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d tasks, want %d", len(got), len(tt.want))
+			}
+			for i, desc := range tt.want {
+				if got[i].Description != desc {
+					t.Errorf("task %d description = %q, want %q", i, got[i].Description, desc)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// UpsertBacklogItem adds the item, or refreshes the title and notes of the
+// item with the same external reference.
+func (r *Repo) UpsertBacklogItem(ctx context.Context, item *task.BacklogItem) (bool, error) {
+	if item.Title == "" {
+		return false, task.ErrEmptyDescription
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !item.ExternalRef.IsZero() {
+		for i := range r.backlog {
+			if r.backlog[i].ExternalRef == item.ExternalRef {
+				r.backlog[i].Title = item.Title
+				r.backlog[i].Notes = item.Notes
+				item.ID = r.backlog[i].ID
+				return false, nil
+			}
+		}
+	}
+	item.ID = r.nextBacklogID
+	r.nextBacklogID++
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = r.clock.Now()
+	}
+	r.backlog = append(r.backlog, *item)
+	return true, nil
+}
+
+// ListBacklog returns copies of every backlog item, oldest first.
+func (r *Repo) ListBacklog(ctx context.Context) ([]*task.BacklogItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*task.BacklogItem, len(r.backlog))
+	for i := range r.backlog {
+		item := r.backlog[i]
+		result[i] = &item
+	}
+	return result, nil
+}
+
+// DeleteBacklogItem removes an item from the backlog.
+func (r *Repo) DeleteBacklogItem(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.backlog {
+		if r.backlog[i].ID == id {
+			r.backlog = append(r.backlog[:i], r.backlog[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("backlog item %d not found", id)
+}
+
+// ParkBacklogItem parks the item on day. A zero day returns it to the
+// backlog.
+func (r *Repo) ParkBacklogItem(ctx context.Context, id int64, day time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.backlog {
+		if r.backlog[i].ID == id {
+			r.backlog[i].Day = day
+			return nil
+		}
+	}
+	return fmt.Errorf("backlog item %d not found", id)
+}
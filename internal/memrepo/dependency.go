@@ -0,0 +1,57 @@
+package memrepo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// AddDependency stores d. Both tasks must exist.
+func (r *Repo) AddDependency(ctx context.Context, d task.Dependency) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.lookup(d.TaskID); err != nil {
+		return err
+	}
+	if _, err := r.lookup(d.BlockedBy); err != nil {
+		return err
+	}
+	r.deps[d] = true
+	return nil
+}
+
+// RemoveDependency deletes d.
+func (r *Repo) RemoveDependency(ctx context.Context, d task.Dependency) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.deps, d)
+	return nil
+}
+
+// Dependencies returns the links in which any of the given tasks is the
+// blocked task or the prerequisite, ordered by task.
+func (r *Repo) Dependencies(ctx context.Context, ids []int64) ([]task.Dependency, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	want := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	var deps []task.Dependency
+	for d := range r.deps {
+		if want[d.TaskID] || want[d.BlockedBy] {
+			deps = append(deps, d)
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].TaskID != deps[j].TaskID {
+			return deps[i].TaskID < deps[j].TaskID
+		}
+		return deps[i].BlockedBy < deps[j].BlockedBy
+	})
+	return deps, nil
+}
@@ -0,0 +1,59 @@
+package memrepo
+
+import (
+	"context"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// actual is the recorded duration of a task, keyed like the analytics query
+// of the SQLite repository.
+type actual struct {
+	category  task.Category
+	key       string
+	estimated int
+	minutes   int
+}
+
+// RecordActual stores that t took actualMinutes, replacing any earlier record.
+func (r *Repo) RecordActual(ctx context.Context, t *task.Task, actualMinutes int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.lookup(t.ID); err != nil {
+		return err
+	}
+	r.actuals[t.ID] = actual{
+		category:  t.Category,
+		key:       task.DescriptionKey(t.Description),
+		estimated: t.Duration(),
+		minutes:   actualMinutes,
+	}
+	return nil
+}
+
+// ForgetActual drops the record of task id, if any.
+func (r *Repo) ForgetActual(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.actuals, id)
+	return nil
+}
+
+// EstimateAccuracy sums the records of the tasks of category whose
+// description has key as its DescriptionKey.
+func (r *Repo) EstimateAccuracy(ctx context.Context, category task.Category, key string) (task.EstimateAccuracy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var acc task.EstimateAccuracy
+	for _, a := range r.actuals {
+		if a.category == category && a.key == key {
+			acc.Samples++
+			acc.EstimatedMinutes += a.estimated
+			acc.ActualMinutes += a.minutes
+		}
+	}
+	return acc, nil
+}
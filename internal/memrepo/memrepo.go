@@ -0,0 +1,612 @@
+// Package memrepo provides an in-memory task.Repository. It behaves like the
+// SQLite repository (overlap checks, postpone chains, missed tasks) without a
+// database, so the TUI, planner and commands can be tested in isolation and
+// other backends have a reference to compare against. The sandbox package
+// builds on it, loading days from a real repository as they are needed.
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Repo is an in-memory task.Repository. It is safe for concurrent use.
+type Repo struct {
+	mu     sync.Mutex
+	tasks  map[int64]*task.Task
+	nextID int64
+	idStep int64       // 1, or -1 for WithNegativeIDs
+	clock  clock.Clock // Stamps CreatedAt for new tasks
+
+	backlog       []task.BacklogItem
+	nextBacklogID int64
+
+	notes map[int64]string // Review notes by task ID
+
+	deps map[task.Dependency]bool // blocked_by links
+
+	objectives      []*task.Objective
+	taskObjectives  map[int64]int64 // Objective ID by task ID
+	nextObjectiveID int64
+
+	actuals map[int64]actual // Recorded durations by task ID
+
+	allowOverlaps bool // Store overlapping blocks instead of rejecting them
+}
+
+// Option configures a Repo.
+type Option func(*Repo)
+
+// WithClock sets the clock used for task timestamps.
+func WithClock(c clock.Clock) Option {
+	return func(r *Repo) {
+		r.clock = clock.OrSystem(c)
+	}
+}
+
+// WithAllowOverlaps makes the repository store overlapping time blocks
+// instead of returning ErrTimeBlockOverlap.
+func WithAllowOverlaps(allow bool) Option {
+	return func(r *Repo) {
+		r.allowOverlaps = allow
+	}
+}
+
+// WithNegativeIDs makes new tasks get IDs -1, -2, ... so they never collide
+// with tasks copied in by Load from another repository.
+func WithNegativeIDs() Option {
+	return func(r *Repo) {
+		r.nextID = -1
+		r.idStep = -1
+	}
+}
+
+// New creates an empty repository.
+func New(opts ...Option) *Repo {
+	r := &Repo{
+		tasks:  make(map[int64]*task.Task),
+		nextID: 1,
+		idStep: 1,
+		clock:  clock.System,
+
+		nextBacklogID: 1,
+		notes:         make(map[int64]string),
+		deps:          make(map[task.Dependency]bool),
+
+		taskObjectives:  make(map[int64]int64),
+		nextObjectiveID: 1,
+
+		actuals: make(map[int64]actual),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// AllowsOverlaps reports whether overlapping time blocks are stored.
+func (r *Repo) AllowsOverlaps() bool {
+	return r.allowOverlaps
+}
+
+// insert stores a copy of t under a new ID at version 1 and sets t.ID.
+// Callers must hold r.mu.
+func (r *Repo) insert(t *task.Task) {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = r.clock.Now()
+	}
+	t.ID = r.nextID
+	r.nextID += r.idStep
+	cp := *t
+	cp.Version = 1
+	r.tasks[cp.ID] = &cp
+}
+
+// Load stores copies of tasks under their own IDs and versions, replacing
+// any stored task with the same ID. Nothing is checked for overlaps: the
+// tasks come from a repository that already validated them.
+func (r *Repo) Load(tasks ...*task.Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range tasks {
+		cp := *t
+		r.tasks[cp.ID] = &cp
+		if r.idStep > 0 && cp.ID >= r.nextID {
+			r.nextID = cp.ID + 1
+		}
+	}
+}
+
+// lookup returns the stored task with id, or an error wrapping
+// ErrTaskNotFound. Callers must hold r.mu.
+func (r *Repo) lookup(id int64) (*task.Task, error) {
+	t, ok := r.tasks[id]
+	if !ok {
+		return nil, fmt.Errorf("task %d: %w", id, task.ErrTaskNotFound)
+	}
+	return t, nil
+}
+
+// lookupAt is like lookup but returns ErrTaskChanged when version is set and
+// the task was written since it was read at version. Callers must hold r.mu.
+func (r *Repo) lookupAt(id, version int64) (*task.Task, error) {
+	t, err := r.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && t.Version != version {
+		return nil, fmt.Errorf("%w: task %d", task.ErrTaskChanged, id)
+	}
+	return t, nil
+}
+
+// checkOverlap returns ErrTimeBlockOverlap if the range conflicts with a
+// scheduled task of owner, ignoring the task with excludeID. Overnight blocks
+// on the neighbouring days count too. Callers must hold r.mu.
+func (r *Repo) checkOverlap(owner string, date time.Time, start, end string, excludeID int64) error {
+	if r.allowOverlaps {
+		return nil
+	}
+	for _, t := range r.sorted() {
+		if t.ID == excludeID || !t.IsScheduled() || t.Owner != owner {
+			continue
+		}
+		if task.BlocksOverlap(date, start, end, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd) {
+			return fmt.Errorf("%w: conflicts with %q (%s-%s)",
+				task.ErrTimeBlockOverlap, t.Description, t.ScheduledStart, t.ScheduledEnd)
+		}
+	}
+	return nil
+}
+
+// sorted returns the stored tasks ordered by ID, so errors and listings do
+// not depend on map order. Callers must hold r.mu.
+func (r *Repo) sorted() []*task.Task {
+	tasks := make([]*task.Task, 0, len(r.tasks))
+	for _, t := range r.tasks {
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks
+}
+
+// snapshot copies the stored tasks so a failed batch can be undone.
+// Callers must hold r.mu.
+func (r *Repo) snapshot() func() {
+	saved := make(map[int64]task.Task, len(r.tasks))
+	for id, t := range r.tasks {
+		saved[id] = *t
+	}
+	nextID := r.nextID
+	return func() {
+		r.tasks = make(map[int64]*task.Task, len(saved))
+		for id, t := range saved {
+			r.tasks[id] = &t
+		}
+		r.nextID = nextID
+	}
+}
+
+// CreateTask adds a new task to the repository.
+// Returns ErrTimeBlockOverlap if the task overlaps with an existing scheduled task.
+func (r *Repo) CreateTask(ctx context.Context, t *task.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkOverlap(t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd, 0); err != nil {
+		return err
+	}
+	r.insert(t)
+	return nil
+}
+
+// GetTask retrieves a task by ID. Returns nil without an error when the task
+// does not exist.
+func (r *Repo) GetTask(ctx context.Context, id int64) (*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tasks[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *t
+	return &cp, nil
+}
+
+// GetTaskByExternalRef retrieves the task linked to an external reference.
+// Returns nil without an error when no task has the reference.
+func (r *Repo) GetTaskByExternalRef(ctx context.Context, ref task.ExternalRef) (*task.Task, error) {
+	if ref.IsZero() {
+		return nil, task.ErrMissingExternalRef
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.lookupRef(ref)
+	if t == nil {
+		return nil, nil
+	}
+	cp := *t
+	return &cp, nil
+}
+
+// lookupRef returns the task with an external reference, or nil.
+// Callers must hold r.mu.
+func (r *Repo) lookupRef(ref task.ExternalRef) *task.Task {
+	for _, t := range r.tasks {
+		if t.ExternalRef == ref {
+			return t
+		}
+	}
+	return nil
+}
+
+// UpsertTask creates the task, or updates the task with the same external
+// reference in place, keeping its status and outcome.
+func (r *Repo) UpsertTask(ctx context.Context, t *task.Task) (bool, error) {
+	if t.ExternalRef.IsZero() {
+		return false, task.ErrMissingExternalRef
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := r.lookupRef(t.ExternalRef)
+	if existing == nil {
+		if err := r.checkOverlap(t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd, 0); err != nil {
+			return false, err
+		}
+		r.insert(t)
+		return true, nil
+	}
+
+	if t.Version != 0 && existing.Version != t.Version {
+		return false, fmt.Errorf("%w: task %d", task.ErrTaskChanged, existing.ID)
+	}
+	if existing.IsScheduled() {
+		if err := r.checkOverlap(t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd, existing.ID); err != nil {
+			return false, err
+		}
+	}
+	existing.Description = t.Description
+	existing.ScheduledDate = t.ScheduledDate
+	existing.ScheduledStart = t.ScheduledStart
+	existing.ScheduledEnd = t.ScheduledEnd
+	existing.Energy = t.Energy
+	existing.Version++
+	t.ID = existing.ID
+	return false, nil
+}
+
+// CancelTask marks a task as cancelled.
+func (r *Repo) CancelTask(ctx context.Context, id, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.lookupAt(id, version)
+	if err != nil {
+		return err
+	}
+	t.Status = task.StatusCancelled
+	t.Version++
+	return nil
+}
+
+// MarkTasksMissed marks the given scheduled tasks as missed. Tasks that are
+// no longer scheduled are left alone.
+func (r *Repo) MarkTasksMissed(ctx context.Context, ids []int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		if t, ok := r.tasks[id]; ok && t.IsScheduled() {
+			t.Status = task.StatusMissed
+			t.Version++
+		}
+	}
+	return nil
+}
+
+// SetTaskOutcome sets the outcome of a task during review. A missed task
+// that was not rescheduled becomes scheduled again, since it did happen.
+func (r *Repo) SetTaskOutcome(ctx context.Context, id int64, outcome task.Outcome, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.lookupAt(id, version)
+	if err != nil {
+		return err
+	}
+	o := outcome
+	t.Outcome = &o
+	if t.IsMissed() && !r.hasCopy(id) {
+		t.Status = task.StatusScheduled
+	}
+	t.Version++
+	return nil
+}
+
+// hasCopy reports whether a task was postponed from id.
+// Callers must hold r.mu.
+func (r *Repo) hasCopy(id int64) bool {
+	for _, t := range r.tasks {
+		if t.PostponedFrom != nil && *t.PostponedFrom == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTaskEnergy sets the energy level of a task. An empty level clears it.
+func (r *Repo) SetTaskEnergy(ctx context.Context, id int64, energy task.Energy, version int64) error {
+	if _, err := task.ParseEnergy(string(energy)); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.lookupAt(id, version)
+	if err != nil {
+		return err
+	}
+	t.Energy = energy
+	t.Version++
+	return nil
+}
+
+// ListTasksByDateRange returns all tasks scheduled within the date range
+// (inclusive), ordered by date and start time.
+func (r *Repo) ListTasksByDateRange(ctx context.Context, start, end time.Time) ([]*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tasks []*task.Task
+	for _, t := range r.sorted() {
+		if task.CalendarDaysBetween(start, t.ScheduledDate) < 0 || task.CalendarDaysBetween(t.ScheduledDate, end) < 0 {
+			continue
+		}
+		cp := *t
+		tasks = append(tasks, &cp)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if d := task.CalendarDaysBetween(tasks[j].ScheduledDate, tasks[i].ScheduledDate); d != 0 {
+			return d < 0
+		}
+		return tasks[i].ScheduledStart < tasks[j].ScheduledStart
+	})
+	return tasks, nil
+}
+
+// ListAllTasks returns all tasks ordered by ID.
+func (r *Repo) ListAllTasks(ctx context.Context) ([]*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tasks := r.sorted()
+	for i, t := range tasks {
+		cp := *t
+		tasks[i] = &cp
+	}
+	return tasks, nil
+}
+
+// ListTasks returns one page of the tasks matching filter, ordered by date,
+// start time and ID.
+func (r *Repo) ListTasks(ctx context.Context, filter task.TaskFilter, limit, offset int) ([]*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tasks []*task.Task
+	for _, t := range r.sorted() {
+		if filter.Match(t) {
+			cp := *t
+			tasks = append(tasks, &cp)
+		}
+	}
+	task.SortByTime(tasks)
+	return task.Page(tasks, limit, offset), nil
+}
+
+// CreateTasks adds multiple tasks; nothing is added if any of them overlaps.
+func (r *Repo) CreateTasks(ctx context.Context, tasks []*task.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, t := range tasks {
+		if err := r.checkOverlap(t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd, 0); err != nil {
+			return fmt.Errorf("task %d (%s): %w", i+1, t.Description, err)
+		}
+		for _, other := range tasks[:i] {
+			if !r.allowOverlaps && t.OverlapsWith(other) {
+				return fmt.Errorf("task %d (%s): %w", i+1, t.Description, task.ErrTimeBlockOverlap)
+			}
+		}
+	}
+	for _, t := range tasks {
+		r.insert(t)
+	}
+	return nil
+}
+
+// PostponeTask marks the original task as postponed and creates a new task.
+// The original's external reference moves to the new task.
+func (r *Repo) PostponeTask(ctx context.Context, taskID int64, newDate time.Time, newStart, newEnd string, version int64) (*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	orig, err := r.lookupAt(taskID, version)
+	if err != nil {
+		return nil, err
+	}
+	// The original still holds its slot while the new one is checked, as in SQLite
+	if err := r.checkOverlap(orig.Owner, newDate, newStart, newEnd, 0); err != nil {
+		return nil, err
+	}
+
+	orig.Status = task.StatusPostponed
+	ref := orig.ExternalRef
+	orig.ExternalRef = task.ExternalRef{}
+	orig.Version++
+	from := taskID
+	newTask := &task.Task{
+		Description:    orig.Description,
+		Category:       orig.Category,
+		ScheduledDate:  newDate,
+		ScheduledStart: newStart,
+		ScheduledEnd:   newEnd,
+		Status:         task.StatusScheduled,
+		Energy:         orig.Energy,
+		PostponedFrom:  &from,
+		ExternalRef:    ref,
+		Owner:          orig.Owner,
+	}
+	r.insert(newTask)
+	return newTask, nil
+}
+
+// UpdateTask updates a task's scheduled times in place.
+// Returns ErrTimeBlockOverlap if the new times conflict with another task.
+func (r *Repo) UpdateTask(ctx context.Context, id int64, newStart, newEnd string, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.lookupAt(id, version)
+	if err != nil {
+		return err
+	}
+	if err := r.checkOverlap(t.Owner, t.ScheduledDate, newStart, newEnd, id); err != nil {
+		return err
+	}
+	t.ScheduledStart = newStart
+	t.ScheduledEnd = newEnd
+	t.Version++
+	return nil
+}
+
+// UpdateTaskDescription updates a task description in place.
+func (r *Repo) UpdateTaskDescription(ctx context.Context, id int64, description string, version int64) error {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return task.ErrEmptyDescription
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, err := r.lookupAt(id, version)
+	if err != nil {
+		return err
+	}
+	t.Description = description
+	t.Version++
+	return nil
+}
+
+// BatchUpdateTaskTimes moves the given tasks to new times on date, or on an
+// update's own Date. The final schedule is checked for overlaps and nothing
+// changes if one is found.
+func (r *Repo) BatchUpdateTaskTimes(ctx context.Context, date time.Time, updates []task.TaskTimeUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rollback := r.snapshot()
+	for _, u := range updates {
+		t, err := r.lookupAt(u.ID, u.Version)
+		if err != nil {
+			rollback()
+			return err
+		}
+		t.ScheduledDate = u.DateOr(date)
+		t.ScheduledStart = u.NewStart
+		t.ScheduledEnd = u.NewEnd
+		t.Version++
+	}
+	for _, u := range updates {
+		t := r.tasks[u.ID]
+		if !t.IsScheduled() {
+			continue
+		}
+		if err := r.checkOverlap(t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd, t.ID); err != nil {
+			rollback()
+			return err
+		}
+	}
+	return nil
+}
+
+// BatchUpdate cancels, postpones, moves or recategorizes several scheduled
+// tasks. Overlaps are checked once every update is applied; on failure the
+// repository is left as it was. Missed tasks can be postponed too; they keep
+// their missed status.
+func (r *Repo) BatchUpdate(ctx context.Context, updates []task.TaskUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rollback := r.snapshot()
+	var placed []*task.Task
+	for _, u := range updates {
+		t, err := r.lookupAt(u.ID, u.Version)
+		if err != nil {
+			rollback()
+			return err
+		}
+		if !t.IsScheduled() && !(u.Postpone && t.IsMissed()) {
+			rollback()
+			return fmt.Errorf("task %d is %s", u.ID, t.Status)
+		}
+
+		t.Version++
+		switch {
+		case u.Cancel:
+			t.Status = task.StatusCancelled
+		case u.Postpone:
+			nt := u.Apply(*t)
+			if !t.IsMissed() {
+				t.Status = task.StatusPostponed
+			}
+			t.ExternalRef = task.ExternalRef{}
+			from := u.ID
+			nt.Status = task.StatusScheduled
+			nt.Outcome = nil
+			nt.PostponedFrom = &from
+			nt.CreatedAt = time.Time{}
+			r.insert(&nt)
+			placed = append(placed, r.tasks[nt.ID])
+		default:
+			*t = u.Apply(*t)
+			placed = append(placed, t)
+		}
+	}
+
+	for _, t := range placed {
+		if err := r.checkOverlap(t.Owner, t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd, t.ID); err != nil {
+			rollback()
+			return err
+		}
+	}
+	return nil
+}
+
+// Close is a no-op; the tasks live as long as the Repo.
+func (r *Repo) Close() error {
+	return nil
+}
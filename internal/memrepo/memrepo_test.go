@@ -0,0 +1,599 @@
+package memrepo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+var (
+	_ task.Repository     = (*Repo)(nil)
+	_ task.OverlapAllower = (*Repo)(nil)
+	_ task.MissedMarker   = (*Repo)(nil)
+	_ task.TaskPager      = (*Repo)(nil)
+)
+
+// repos returns an in-memory and a SQLite repository so the same scenario
+// can check that both behave alike.
+func repos(t *testing.T) map[string]task.Repository {
+	t.Helper()
+	sqlite, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("creating sqlite repo: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlite.Close() })
+	return map[string]task.Repository{"memory": New(), "sqlite": sqlite}
+}
+
+func scheduled(desc string, date time.Time, start, end string) *task.Task {
+	return &task.Task{
+		Description:    desc,
+		Category:       task.CategoryDeep,
+		ScheduledDate:  date,
+		ScheduledStart: start,
+		ScheduledEnd:   end,
+		Status:         task.StatusScheduled,
+	}
+}
+
+func TestRepo_MatchesSQLite(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			report := scheduled("Report", monday, "09:00", "10:00")
+			email := scheduled("Email", monday, "10:00", "11:00")
+			if err := repo.CreateTasks(ctx, []*task.Task{report, email}); err != nil {
+				t.Fatalf("CreateTasks: %v", err)
+			}
+			if err := repo.CreateTask(ctx, scheduled("Clash", monday, "09:30", "10:30")); !errors.Is(err, task.ErrTimeBlockOverlap) {
+				t.Fatalf("CreateTask overlap err = %v, want ErrTimeBlockOverlap", err)
+			}
+
+			if err := repo.BatchUpdateTaskTimes(ctx, monday, []task.TaskTimeUpdate{
+				{ID: report.ID, NewStart: "10:00", NewEnd: "11:00"},
+				{ID: email.ID, NewStart: "09:00", NewEnd: "10:00"},
+			}); err != nil {
+				t.Fatalf("BatchUpdateTaskTimes swap: %v", err)
+			}
+			if err := repo.UpdateTask(ctx, email.ID, "09:00", "10:30", 0); !errors.Is(err, task.ErrTimeBlockOverlap) {
+				t.Fatalf("UpdateTask overlap err = %v, want ErrTimeBlockOverlap", err)
+			}
+
+			moved, err := repo.PostponeTask(ctx, report.ID, monday.AddDate(0, 0, 1), "09:00", "10:00", 0)
+			if err != nil {
+				t.Fatalf("PostponeTask: %v", err)
+			}
+			if moved.PostponedFrom == nil || *moved.PostponedFrom != report.ID {
+				t.Errorf("PostponedFrom = %v, want %d", moved.PostponedFrom, report.ID)
+			}
+
+			err = repo.BatchUpdate(ctx, []task.TaskUpdate{
+				{ID: email.ID, Cancel: true},
+				{ID: moved.ID, Start: "13:00", End: "14:00"},
+				{ID: report.ID, Cancel: true},
+			})
+			if err == nil {
+				t.Fatal("BatchUpdate on a postponed task succeeded")
+			}
+
+			got, err := repo.ListTasksByDateRange(ctx, monday, monday.AddDate(0, 0, 1))
+			if err != nil {
+				t.Fatalf("ListTasksByDateRange: %v", err)
+			}
+			var summary []string
+			for _, tk := range got {
+				summary = append(summary, tk.Description+" "+tk.ScheduledStart+" "+string(tk.Status))
+			}
+			want := []string{"Email 09:00 scheduled", "Report 10:00 postponed", "Report 09:00 scheduled"}
+			if len(summary) != len(want) {
+				t.Fatalf("tasks = %v, want %v", summary, want)
+			}
+			for i := range want {
+				if summary[i] != want[i] {
+					t.Errorf("task %d = %q, want %q", i, summary[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRepo_BatchUpdateTaskTimesAcrossDays(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			a := scheduled("A", monday, "09:00", "10:00")
+			b := scheduled("B", tuesday, "09:00", "10:00")
+			if err := repo.CreateTasks(ctx, []*task.Task{a, b}); err != nil {
+				t.Fatalf("CreateTasks: %v", err)
+			}
+
+			// Written day by day, either move would hit the task still there
+			if err := repo.BatchUpdateTaskTimes(ctx, time.Time{}, []task.TaskTimeUpdate{
+				{ID: a.ID, Date: tuesday, NewStart: "09:00", NewEnd: "10:00"},
+				{ID: b.ID, Date: monday, NewStart: "09:00", NewEnd: "10:00"},
+			}); err != nil {
+				t.Fatalf("BatchUpdateTaskTimes swap across days: %v", err)
+			}
+			if got, _ := repo.GetTask(ctx, a.ID); !got.ScheduledDate.Equal(tuesday) {
+				t.Errorf("A date = %v, want %v", got.ScheduledDate, tuesday)
+			}
+			if got, _ := repo.GetTask(ctx, b.ID); !got.ScheduledDate.Equal(monday) {
+				t.Errorf("B date = %v, want %v", got.ScheduledDate, monday)
+			}
+
+			err := repo.BatchUpdateTaskTimes(ctx, tuesday, []task.TaskTimeUpdate{
+				{ID: a.ID, NewStart: "08:00", NewEnd: "09:45"},
+				{ID: b.ID, NewStart: "09:30", NewEnd: "10:30"},
+			})
+			if !errors.Is(err, task.ErrTimeBlockOverlap) {
+				t.Fatalf("BatchUpdateTaskTimes overlap err = %v, want ErrTimeBlockOverlap", err)
+			}
+			if got, _ := repo.GetTask(ctx, b.ID); !got.ScheduledDate.Equal(monday) || got.ScheduledStart != "09:00" {
+				t.Errorf("B = %v %s, want it left on %v at 09:00", got.ScheduledDate, got.ScheduledStart, monday)
+			}
+		})
+	}
+}
+
+func TestRepo_Versions(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			a := scheduled("A", monday, "09:00", "10:00")
+			if err := repo.CreateTask(ctx, a); err != nil {
+				t.Fatalf("CreateTask: %v", err)
+			}
+			read, _ := repo.GetTask(ctx, a.ID)
+			if read.Version != 1 {
+				t.Errorf("new task version = %d, want 1", read.Version)
+			}
+
+			if err := repo.UpdateTask(ctx, a.ID, "09:00", "10:30", read.Version); err != nil {
+				t.Fatalf("UpdateTask at the read version: %v", err)
+			}
+			if got, _ := repo.GetTask(ctx, a.ID); got.Version != 2 {
+				t.Errorf("version after a write = %d, want 2", got.Version)
+			}
+
+			if err := repo.CancelTask(ctx, a.ID, read.Version); !errors.Is(err, task.ErrTaskChanged) {
+				t.Errorf("CancelTask at a stale version = %v, want ErrTaskChanged", err)
+			}
+			err := repo.BatchUpdate(ctx, []task.TaskUpdate{{ID: a.ID, Cancel: true, Version: read.Version}})
+			if !errors.Is(err, task.ErrTaskChanged) {
+				t.Errorf("BatchUpdate at a stale version = %v, want ErrTaskChanged", err)
+			}
+			if got, _ := repo.GetTask(ctx, a.ID); !got.IsScheduled() {
+				t.Errorf("status = %s, want the stale writes refused", got.Status)
+			}
+		})
+	}
+}
+
+func TestRepo_Postpone(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			a := scheduled("A", monday, "09:00", "10:00")
+			if err := repo.CreateTask(ctx, a); err != nil {
+				t.Fatalf("CreateTask: %v", err)
+			}
+
+			// The original still holds its slot
+			if _, err := repo.PostponeTask(ctx, a.ID, monday, "09:30", "10:30", 0); !errors.Is(err, task.ErrTimeBlockOverlap) {
+				t.Errorf("PostponeTask over the original = %v, want ErrTimeBlockOverlap", err)
+			}
+			if _, err := repo.PostponeTask(ctx, a.ID+100, monday, "14:00", "15:00", 0); !errors.Is(err, task.ErrTaskNotFound) {
+				t.Errorf("PostponeTask of a missing task = %v, want ErrTaskNotFound", err)
+			}
+
+			moved, err := repo.PostponeTask(ctx, a.ID, monday, "14:00", "15:00", 0)
+			if err != nil {
+				t.Fatalf("PostponeTask: %v", err)
+			}
+			if moved.PostponedFrom == nil || *moved.PostponedFrom != a.ID || moved.ScheduledStart != "14:00" {
+				t.Errorf("new task = %+v, want A at 14:00 postponed from %d", moved, a.ID)
+			}
+			if got, _ := repo.GetTask(ctx, a.ID); got.Status != task.StatusPostponed {
+				t.Errorf("original status = %s, want postponed", got.Status)
+			}
+		})
+	}
+}
+
+func TestRepo_MissedTasks(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			skipped := scheduled("Skipped", monday, "09:00", "10:00")
+			moved := scheduled("Moved", monday, "10:00", "11:00")
+			if err := repo.CreateTasks(ctx, []*task.Task{skipped, moved}); err != nil {
+				t.Fatalf("CreateTasks: %v", err)
+			}
+
+			missed, err := task.MarkMissed(ctx, repo, monday, monday.AddDate(0, 0, 1))
+			if err != nil {
+				t.Fatalf("MarkMissed: %v", err)
+			}
+			if len(missed) != 2 {
+				t.Fatalf("missed = %d tasks, want 2", len(missed))
+			}
+
+			err = repo.BatchUpdate(ctx, []task.TaskUpdate{
+				{ID: moved.ID, Postpone: true, Date: monday.AddDate(0, 0, 1)},
+			})
+			if err != nil {
+				t.Fatalf("BatchUpdate postpone missed: %v", err)
+			}
+			for _, id := range []int64{skipped.ID, moved.ID} {
+				if err := repo.SetTaskOutcome(ctx, id, task.OutcomeOnTime, 0); err != nil {
+					t.Fatalf("SetTaskOutcome: %v", err)
+				}
+			}
+
+			for id, want := range map[int64]task.Status{skipped.ID: task.StatusScheduled, moved.ID: task.StatusMissed} {
+				got, err := repo.GetTask(ctx, id)
+				if err != nil {
+					t.Fatalf("GetTask: %v", err)
+				}
+				if got.Status != want {
+					t.Errorf("%s status = %s, want %s", got.Description, got.Status, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRepo_AllDayTasks(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			late := scheduled("Release", monday.AddDate(0, 0, -1), "23:00", "01:00")
+			onCall := scheduled("On call", monday, "", "")
+			conference := scheduled("Conference", monday, "", "")
+			if err := repo.CreateTasks(ctx, []*task.Task{late, onCall, conference}); err != nil {
+				t.Fatalf("CreateTasks: %v", err)
+			}
+			if err := repo.CreateTask(ctx, scheduled("Standup", monday, "09:00", "09:30")); err != nil {
+				t.Fatalf("CreateTask during all-day tasks: %v", err)
+			}
+
+			got, err := repo.GetTask(ctx, onCall.ID)
+			if err != nil {
+				t.Fatalf("GetTask: %v", err)
+			}
+			if !got.IsAllDay() {
+				t.Errorf("stored times %q-%q, want an all-day task", got.ScheduledStart, got.ScheduledEnd)
+			}
+
+			missed, err := task.MarkMissed(ctx, repo, monday, monday.AddDate(0, 0, 2))
+			if err != nil {
+				t.Fatalf("MarkMissed: %v", err)
+			}
+			for _, m := range missed {
+				if m.IsAllDay() {
+					t.Errorf("all-day task %q marked missed", m.Description)
+				}
+			}
+		})
+	}
+}
+
+func TestRepo_OwnersOverlapApart(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			mine := scheduled("Writing", monday, "09:00", "11:00")
+			later := scheduled("Planning", monday.AddDate(0, 0, 1), "09:00", "11:00")
+			theirs := scheduled("Coding", monday, "09:00", "11:00")
+			theirs.Owner = "ben"
+			if err := repo.CreateTasks(ctx, []*task.Task{mine, later, theirs}); err != nil {
+				t.Fatalf("CreateTasks for two owners: %v", err)
+			}
+
+			clash := scheduled("Review", monday, "10:00", "12:00")
+			clash.Owner = "ben"
+			if err := repo.CreateTask(ctx, clash); !errors.Is(err, task.ErrTimeBlockOverlap) {
+				t.Errorf("CreateTask over the same owner = %v, want ErrTimeBlockOverlap", err)
+			}
+
+			moved, err := repo.PostponeTask(ctx, theirs.ID, monday.AddDate(0, 0, 1), "09:00", "11:00", 0)
+			if err != nil {
+				t.Fatalf("PostponeTask beside the other owner: %v", err)
+			}
+			if moved.Owner != "ben" {
+				t.Errorf("postponed owner = %q, want ben", moved.Owner)
+			}
+			got, err := repo.GetTask(ctx, moved.ID)
+			if err != nil {
+				t.Fatalf("GetTask: %v", err)
+			}
+			if got.Owner != "ben" {
+				t.Errorf("stored owner = %q, want ben", got.Owner)
+			}
+		})
+	}
+}
+
+func TestRepo_AllowOverlaps(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	repo := New(WithAllowOverlaps(true))
+
+	if !task.AllowsOverlaps(repo) {
+		t.Fatal("AllowsOverlaps = false, want true")
+	}
+	if err := repo.CreateTask(ctx, scheduled("One", monday, "09:00", "10:00")); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if err := repo.CreateTask(ctx, scheduled("Two", monday, "09:30", "10:30")); err != nil {
+		t.Fatalf("CreateTask overlapping: %v", err)
+	}
+}
+
+func TestRepo_LoadWithNegativeIDs(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	repo := New(WithNegativeIDs())
+
+	loaded := scheduled("Loaded", monday, "09:00", "10:00")
+	loaded.ID = 7
+	repo.Load(loaded)
+
+	created := scheduled("Created", monday, "10:00", "11:00")
+	if err := repo.CreateTask(ctx, created); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if created.ID != -1 {
+		t.Errorf("created ID = %d, want -1", created.ID)
+	}
+	if err := repo.CreateTask(ctx, scheduled("Clash", monday, "09:30", "10:00")); !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Errorf("CreateTask over a loaded task: got %v, want ErrTimeBlockOverlap", err)
+	}
+	if err := repo.CancelTask(ctx, 8, 0); !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("CancelTask on a missing task: got %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestRepo_ListTasks(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			for day := range 5 {
+				date := monday.AddDate(0, 0, day)
+				if err := repo.CreateTasks(ctx, []*task.Task{
+					scheduled("Standup 100%", date, "09:00", "09:30"),
+					scheduled("Deep work", date, "10:00", "12:00"),
+				}); err != nil {
+					t.Fatalf("CreateTasks: %v", err)
+				}
+			}
+			all, _ := repo.ListTasksByDateRange(ctx, monday, monday.AddDate(0, 0, 4))
+			if err := repo.CancelTask(ctx, all[len(all)-1].ID, 0); err != nil {
+				t.Fatalf("CancelTask: %v", err)
+			}
+
+			filter := task.TaskFilter{Query: "standup", Statuses: []task.Status{task.StatusScheduled}}
+			var pages [][]*task.Task
+			for offset := 0; ; offset += 2 {
+				page, err := task.ListTasks(ctx, repo, filter, 2, offset)
+				if err != nil {
+					t.Fatalf("ListTasks: %v", err)
+				}
+				if len(page) == 0 {
+					break
+				}
+				pages = append(pages, page)
+			}
+			if len(pages) != 3 || len(pages[2]) != 1 {
+				t.Fatalf("got %d pages, want 5 standups in pages of 2", len(pages))
+			}
+			if !pages[0][0].ScheduledDate.Equal(monday) || !pages[2][0].ScheduledDate.Equal(monday.AddDate(0, 0, 4)) {
+				t.Errorf("pages not ordered by date: first %v, last %v", pages[0][0].ScheduledDate, pages[2][0].ScheduledDate)
+			}
+
+			// % is plain text, not a wildcard
+			got, _ := task.ListTasks(ctx, repo, task.TaskFilter{Query: "0%"}, 0, 0)
+			if len(got) != 5 {
+				t.Errorf("query 0%% matched %d tasks, want 5", len(got))
+			}
+
+			got, _ = task.ListTasks(ctx, repo, task.TaskFilter{
+				From:     monday.AddDate(0, 0, 3),
+				To:       monday.AddDate(0, 0, 4),
+				Statuses: []task.Status{task.StatusCancelled},
+			}, 10, 0)
+			if len(got) != 1 || got[0].Description != "Deep work" {
+				t.Errorf("cancelled on Thursday and Friday = %+v, want Friday's deep work", got)
+			}
+		})
+	}
+}
+
+func TestRepo_Dependencies(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			draft := scheduled("Draft", monday, "09:00", "10:00")
+			review := scheduled("Review", monday, "10:00", "11:00")
+			send := scheduled("Send", monday, "09:30", "09:45")
+			send.ScheduledDate = monday.AddDate(0, 0, 1)
+			plan := scheduled("Plan", monday, "11:00", "12:00")
+			if err := repo.CreateTasks(ctx, []*task.Task{draft, review, send, plan}); err != nil {
+				t.Fatalf("CreateTasks: %v", err)
+			}
+
+			if err := task.AddDependency(ctx, repo, review.ID, draft.ID); err != nil {
+				t.Fatalf("AddDependency review <- draft: %v", err)
+			}
+			if err := task.AddDependency(ctx, repo, send.ID, review.ID); err != nil {
+				t.Fatalf("AddDependency send <- review: %v", err)
+			}
+			if err := task.AddDependency(ctx, repo, draft.ID, send.ID); !errors.Is(err, task.ErrDependencyCycle) {
+				t.Errorf("AddDependency draft <- send err = %v, want ErrDependencyCycle", err)
+			}
+			if err := task.AddDependency(ctx, repo, draft.ID, plan.ID); !errors.Is(err, task.ErrStartsBeforePrerequisite) {
+				t.Errorf("AddDependency draft <- plan err = %v, want ErrStartsBeforePrerequisite", err)
+			}
+
+			links, err := task.TaskLinks(ctx, repo, review.ID)
+			if err != nil {
+				t.Fatalf("TaskLinks: %v", err)
+			}
+			if len(links.BlockedBy) != 1 || links.BlockedBy[0].ID != draft.ID || len(links.Blocks) != 1 || links.Blocks[0].ID != send.ID {
+				t.Errorf("TaskLinks(review) = %+v, want blocked by draft and blocking send", links)
+			}
+
+			// Moving the draft past the review breaks the order
+			moved := *draft
+			moved.ScheduledStart, moved.ScheduledEnd = "10:30", "11:30"
+			violations, err := task.OrderViolations(ctx, repo, []*task.Task{&moved})
+			if err != nil {
+				t.Fatalf("OrderViolations: %v", err)
+			}
+			if len(violations) != 1 || violations[0].String() != "Review starts before Draft ends" {
+				t.Errorf("OrderViolations = %v, want review before draft", violations)
+			}
+
+			if err := task.RemoveDependency(ctx, repo, review.ID, draft.ID); err != nil {
+				t.Fatalf("RemoveDependency: %v", err)
+			}
+			if violations, _ := task.OrderViolations(ctx, repo, []*task.Task{&moved}); len(violations) != 0 {
+				t.Errorf("OrderViolations after removing the link = %v, want none", violations)
+			}
+		})
+	}
+}
+
+func TestRepo_Objectives(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	now := monday.Add(12 * time.Hour)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			store, err := task.ObjectivesOf(repo)
+			if err != nil {
+				t.Fatalf("ObjectivesOf: %v", err)
+			}
+			ship := &task.Objective{Title: "Ship v2", Quarter: "2025-Q1", TargetMinutes: 600}
+			hiring := &task.Objective{Title: "Hiring", Quarter: "2025-Q1"}
+			later := &task.Objective{Title: "Later", Quarter: "2025-Q2"}
+			for _, o := range []*task.Objective{ship, hiring, later} {
+				if err := store.CreateObjective(ctx, o); err != nil {
+					t.Fatalf("CreateObjective: %v", err)
+				}
+			}
+			if err := store.CreateObjective(ctx, &task.Objective{Title: "Bad", Quarter: "2025-Q9"}); !errors.Is(err, task.ErrInvalidQuarter) {
+				t.Errorf("CreateObjective bad quarter err = %v, want ErrInvalidQuarter", err)
+			}
+
+			done := scheduled("Spec", monday, "09:00", "11:00")
+			planned := scheduled("Build", monday, "14:00", "15:00")
+			interview := scheduled("Interview", monday.AddDate(0, 0, 1), "10:00", "10:30")
+			if err := repo.CreateTasks(ctx, []*task.Task{done, planned, interview}); err != nil {
+				t.Fatalf("CreateTasks: %v", err)
+			}
+			for id, objective := range map[int64]int64{done.ID: ship.ID, planned.ID: ship.ID, interview.ID: later.ID} {
+				if err := store.AttachTask(ctx, id, objective); err != nil {
+					t.Fatalf("AttachTask: %v", err)
+				}
+			}
+			// Moved to the right objective
+			if err := store.AttachTask(ctx, interview.ID, hiring.ID); err != nil {
+				t.Fatalf("AttachTask: %v", err)
+			}
+			if err := store.AttachTask(ctx, interview.ID, 999); err == nil {
+				t.Error("AttachTask to an unknown objective should fail")
+			}
+
+			progress, err := task.QuarterProgress(ctx, repo, "2025-Q1", now)
+			if err != nil {
+				t.Fatalf("QuarterProgress: %v", err)
+			}
+			if len(progress) != 2 {
+				t.Fatalf("QuarterProgress = %d objectives, want 2", len(progress))
+			}
+			if p := progress[0]; p.Objective.ID != ship.ID || p.ScheduledMinutes != 180 || p.CompletedMinutes != 120 || p.Percent() != 20 {
+				t.Errorf("ship progress = %+v (%d%%), want 180m scheduled, 120m done, 20%%", p, p.Percent())
+			}
+			if p := progress[1]; p.Objective.ID != hiring.ID || p.ScheduledMinutes != 30 || p.CompletedMinutes != 0 {
+				t.Errorf("hiring progress = %+v, want 30m scheduled", p)
+			}
+
+			if err := store.AttachTask(ctx, planned.ID, 0); err != nil {
+				t.Fatalf("AttachTask detach: %v", err)
+			}
+			progress, _ = task.QuarterProgress(ctx, repo, "2025-Q1", now)
+			if progress[0].ScheduledMinutes != 120 {
+				t.Errorf("ship scheduled after detaching = %d, want 120", progress[0].ScheduledMinutes)
+			}
+		})
+	}
+}
+
+func TestRepo_EstimateAccuracy(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	for name, repo := range repos(t) {
+		t.Run(name, func(t *testing.T) {
+			first := scheduled("Write report #1", monday, "09:00", "10:00")
+			second := scheduled("write report 2", monday, "11:00", "12:00")
+			other := scheduled("Write report", monday, "14:00", "15:00")
+			other.Category = task.CategoryShallow
+			if err := repo.CreateTasks(ctx, []*task.Task{first, second, other}); err != nil {
+				t.Fatalf("CreateTasks: %v", err)
+			}
+			for _, r := range []struct {
+				t       *task.Task
+				minutes int
+			}{{first, 90}, {second, 30}, {second, 120}, {other, 60}} {
+				if err := task.RecordActual(ctx, repo, r.t, r.minutes); err != nil {
+					t.Fatalf("RecordActual: %v", err)
+				}
+			}
+
+			store := repo.(task.EstimateStore)
+			acc, err := store.EstimateAccuracy(ctx, task.CategoryDeep, "write report")
+			if err != nil {
+				t.Fatalf("EstimateAccuracy: %v", err)
+			}
+			want := task.EstimateAccuracy{Samples: 2, EstimatedMinutes: 120, ActualMinutes: 210}
+			if acc != want {
+				t.Errorf("EstimateAccuracy = %+v, want %+v", acc, want)
+			}
+
+			if err := task.RecordActual(ctx, repo, first, 0); err != nil {
+				t.Fatalf("RecordActual forget: %v", err)
+			}
+			acc, _ = store.EstimateAccuracy(ctx, task.CategoryDeep, "write report")
+			if acc.Samples != 1 || acc.ActualMinutes != 120 {
+				t.Errorf("EstimateAccuracy after forgetting = %+v, want 1 sample of 120m", acc)
+			}
+		})
+	}
+}
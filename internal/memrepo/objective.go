@@ -0,0 +1,83 @@
+package memrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// CreateObjective stores a copy of o and sets its ID.
+func (r *Repo) CreateObjective(ctx context.Context, o *task.Objective) error {
+	if o.Title == "" {
+		return task.ErrEmptyDescription
+	}
+	if _, _, err := task.QuarterRange(o.Quarter, time.UTC); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = r.clock.Now()
+	}
+	o.ID = r.nextObjectiveID
+	r.nextObjectiveID++
+	cp := *o
+	r.objectives = append(r.objectives, &cp)
+	return nil
+}
+
+// ListObjectives returns copies of the objectives of quarter, or of every
+// quarter when it is empty, oldest first.
+func (r *Repo) ListObjectives(ctx context.Context, quarter string) ([]*task.Objective, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []*task.Objective
+	for _, o := range r.objectives {
+		if quarter == "" || o.Quarter == quarter {
+			cp := *o
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+// AttachTask attaches a task to an objective, replacing the one it was
+// attached to. An objectiveID of 0 detaches the task.
+func (r *Repo) AttachTask(ctx context.Context, taskID, objectiveID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if objectiveID == 0 {
+		delete(r.taskObjectives, taskID)
+		return nil
+	}
+	if _, err := r.lookup(taskID); err != nil {
+		return err
+	}
+	for _, o := range r.objectives {
+		if o.ID == objectiveID {
+			r.taskObjectives[taskID] = objectiveID
+			return nil
+		}
+	}
+	return fmt.Errorf("objective %d not found", objectiveID)
+}
+
+// TaskObjectives returns the objective of each of the given tasks.
+func (r *Repo) TaskObjectives(ctx context.Context, ids []int64) (map[int64]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attached := make(map[int64]int64)
+	for _, id := range ids {
+		if objectiveID, ok := r.taskObjectives[id]; ok {
+			attached[id] = objectiveID
+		}
+	}
+	return attached, nil
+}
@@ -0,0 +1,48 @@
+package memrepo
+
+import (
+	"context"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// SaveReview sets the outcome and note of every entry. Nothing is written
+// if any entry names an unknown task.
+func (r *Repo) SaveReview(ctx context.Context, entries []task.ReviewEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range entries {
+		if _, err := r.lookup(e.ID); err != nil {
+			return err
+		}
+	}
+	for _, e := range entries {
+		t := r.tasks[e.ID]
+		o := e.Outcome
+		t.Outcome = &o
+		if t.IsMissed() && !r.hasCopy(e.ID) {
+			t.Status = task.StatusScheduled
+		}
+		if e.Note == "" {
+			delete(r.notes, e.ID)
+		} else {
+			r.notes[e.ID] = e.Note
+		}
+	}
+	return nil
+}
+
+// TaskNotes returns the notes of the tasks with the given IDs.
+func (r *Repo) TaskNotes(ctx context.Context, ids []int64) (map[int64]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notes := make(map[int64]string)
+	for _, id := range ids {
+		if note, ok := r.notes[id]; ok {
+			notes[id] = note
+		}
+	}
+	return notes, nil
+}
@@ -0,0 +1,225 @@
+package nlp
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+)
+
+// Errors returned by ParseInvite.
+var (
+	ErrInviteNoDate = errors.New("no meeting date found")
+	ErrInviteNoTime = errors.New("no meeting time range found")
+)
+
+// Invite is a meeting parsed from the text of a calendar invitation.
+type Invite struct {
+	Title string
+	Date  time.Time
+	Start string // "HH:MM"
+	End   string // "HH:MM"
+}
+
+var (
+	timeRangePattern  = regexp.MustCompile(`(?i)\b(\d{1,2}(?::\d{2})?)\s*([ap]\.?m\.?)?\s*(?:-|–|—|\bto\b|\buntil\b)\s*(\d{1,2}(?::\d{2})?)\s*([ap]\.?m\.?)?`)
+	clockPattern      = regexp.MustCompile(`(?i)\b(\d{1,2}(?::\d{2})?)\s*([ap]\.?m\.?)?`)
+	hourMinutePattern = regexp.MustCompile(`\b\d{1,2}:\d{2}\b`)
+	isoDatePattern    = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	slashDatePattern  = regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})/(\d{4}|\d{2})\b`)
+	monthDayPattern   = regexp.MustCompile(`(?i)\b(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+(\d{1,2})(?:st|nd|rd|th)?\b(?:,?\s+(\d{4}))?`)
+	dayMonthPattern   = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s+(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?(?:,?\s+(\d{4}))?`)
+)
+
+var monthPrefixes = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// ParseInvite extracts the title, day and time range of a meeting from the
+// text of an invitation copied out of Outlook or Google Calendar, e.g.
+//
+//	Weekly sync
+//	Tuesday, March 11 · 10:00 – 10:30am
+//
+// or
+//
+//	Subject: Project review
+//	When: Tuesday, March 11, 2025 10:00 AM-11:00 AM. (UTC+01:00) Brussels
+//
+// Labelled lines (Subject, Title, When, Start, End) win over guesses. Dates
+// without a year are the next such day from now. Times are taken as local;
+// time zone notes are ignored.
+func ParseInvite(text string, now time.Time) (Invite, error) {
+	var (
+		lines                       []string
+		title, when, start, endLine string
+	)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		label, value, found := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		if found && value != "" {
+			switch strings.ToLower(strings.TrimSpace(label)) {
+			case "subject", "title", "what":
+				title = value
+				continue
+			case "when", "date":
+				when = value
+				continue
+			case "start", "starts", "start time":
+				start = value
+				continue
+			case "end", "ends", "end time":
+				endLine = value
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	var inv Invite
+	var ok bool
+	timeLine := ""
+	switch {
+	case start != "" && endLine != "":
+		inv.Start, ok = firstClock(start)
+		if ok {
+			inv.End, ok = firstClock(endLine)
+		}
+		timeLine = start
+	case when != "":
+		inv.Start, inv.End, ok = parseTimeRange(when)
+		timeLine = when
+	default:
+		for _, line := range lines {
+			if inv.Start, inv.End, ok = parseTimeRange(line); ok {
+				timeLine = line
+				break
+			}
+		}
+	}
+	if !ok {
+		return Invite{}, ErrInviteNoTime
+	}
+
+	inv.Date, ok = parseInviteDate(timeLine, now)
+	for i := 0; !ok && i < len(lines); i++ {
+		inv.Date, ok = parseInviteDate(lines[i], now)
+	}
+	if !ok {
+		return Invite{}, ErrInviteNoDate
+	}
+
+	inv.Title = title
+	for i := 0; inv.Title == "" && i < len(lines); i++ {
+		line := lines[i]
+		if _, _, isTime := parseTimeRange(line); isTime {
+			continue
+		}
+		if _, isDate := parseInviteDate(line, now); isDate {
+			continue
+		}
+		inv.Title = line
+	}
+	if inv.Title == "" {
+		inv.Title = "Meeting"
+	}
+	return inv, nil
+}
+
+// parseTimeRange finds the first time range in s, such as "10:00 – 10:30am",
+// "2 - 3pm" or "10:00 AM-11:00 AM", and returns it as "HH:MM" times. A start
+// without am/pm takes the end's, unless that would put it after the end.
+func parseTimeRange(s string) (start, end string, ok bool) {
+	for _, m := range timeRangePattern.FindAllStringSubmatch(s, -1) {
+		fromNum, fromSfx, toNum, toSfx := m[1], meridiem(m[2]), m[3], meridiem(m[4])
+		if !strings.Contains(toNum, ":") && toSfx == "" {
+			continue // Probably part of a date, e.g. 03-11
+		}
+		if end, ok = ParseClock(toNum + toSfx); !ok {
+			continue
+		}
+		if fromSfx == "" && toSfx != "" {
+			if start, ok = ParseClock(fromNum + toSfx); ok && start < end {
+				return start, end, true
+			}
+		}
+		if start, ok = ParseClock(fromNum + fromSfx); ok {
+			return start, end, true
+		}
+	}
+	return "", "", false
+}
+
+// firstClock returns the first time of day in s, e.g. "10:00 AM" in
+// "Tue 3/11/2025 10:00 AM".
+func firstClock(s string) (string, bool) {
+	for _, m := range clockPattern.FindAllStringSubmatch(s, -1) {
+		if clock, ok := ParseClock(m[1] + meridiem(m[2])); ok {
+			return clock, true
+		}
+	}
+	return "", false
+}
+
+// meridiem normalizes "AM", "p.m." and the like to "am" or "pm".
+func meridiem(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), ".", "")
+}
+
+// parseInviteDate finds the first date in s: YYYY-MM-DD, M/D/YYYY, "March 11",
+// "Mar 11, 2025" or "11 March 2025".
+func parseInviteDate(s string, now time.Time) (time.Time, bool) {
+	s = hourMinutePattern.ReplaceAllString(s, " ") // So "March 3 11:30" is not March 11
+	if m := isoDatePattern.FindStringSubmatch(s); m != nil {
+		return inviteDate(atoi(m[1]), atoi(m[2]), atoi(m[3]), now)
+	}
+	if m := slashDatePattern.FindStringSubmatch(s); m != nil {
+		month, day, year := atoi(m[1]), atoi(m[2]), atoi(m[3])
+		if month > 12 {
+			month, day = day, month // Day first, e.g. 25/03/2025
+		}
+		if year < 100 {
+			year += 2000
+		}
+		return inviteDate(year, month, day, now)
+	}
+	if m := monthDayPattern.FindStringSubmatch(s); m != nil {
+		return inviteDate(atoi(m[3]), int(monthPrefixes[strings.ToLower(m[1])]), atoi(m[2]), now)
+	}
+	if m := dayMonthPattern.FindStringSubmatch(s); m != nil {
+		return inviteDate(atoi(m[3]), int(monthPrefixes[strings.ToLower(m[2])]), atoi(m[1]), now)
+	}
+	return time.Time{}, false
+}
+
+// inviteDate builds a date, rejecting days the month does not have. A zero
+// year means the next such day on or after today.
+func inviteDate(year, month, day int, now time.Time) (time.Time, bool) {
+	today := dateutil.TruncateToDay(now)
+	guessYear := year == 0
+	if guessYear {
+		year = today.Year()
+	}
+	d := time.Date(year, time.Month(month), day, 0, 0, 0, 0, now.Location())
+	if d.Month() != time.Month(month) || d.Day() != day {
+		return time.Time{}, false
+	}
+	if guessYear && d.Before(today) {
+		d = d.AddDate(1, 0, 0)
+	}
+	return d, true
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
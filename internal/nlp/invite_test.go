@@ -0,0 +1,86 @@
+package nlp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseInvite(t *testing.T) {
+	now := time.Date(2025, 3, 5, 10, 0, 0, 0, time.Local) // Wednesday
+	tests := []struct {
+		name  string
+		text  string
+		title string
+		date  string
+		start string
+		end   string
+	}{
+		{
+			name: "google",
+			text: `Weekly sync
+Tuesday, March 11 · 10:00 – 10:30am
+Time zone: Europe/Madrid
+Google Meet joining info
+Video call link: https://meet.google.com/abc-defg-hij`,
+			title: "Weekly sync", date: "2025-03-11", start: "10:00", end: "10:30",
+		},
+		{
+			name: "google afternoon without start meridiem",
+			text: `Design review
+Friday, March 7 · 2 – 3:30pm`,
+			title: "Design review", date: "2025-03-07", start: "14:00", end: "15:30",
+		},
+		{
+			name: "outlook when",
+			text: `Subject: Project review
+When: Tuesday, March 11, 2025 10:00 AM-11:00 AM. (UTC+01:00) Brussels, Copenhagen, Madrid, Paris
+Where: Microsoft Teams Meeting`,
+			title: "Project review", date: "2025-03-11", start: "10:00", end: "11:00",
+		},
+		{
+			name: "outlook start and end",
+			text: `Title: Budget 2025-26
+Start: Thu 3/13/2025 11:30 AM
+End: Thu 3/13/2025 12:15 PM`,
+			title: "Budget 2025-26", date: "2025-03-13", start: "11:30", end: "12:15",
+		},
+		{
+			name: "day first, noon crossing and past date",
+			text: `Retro
+3 February 11:30 - 12:30pm`,
+			title: "Retro", date: "2026-02-03", start: "11:30", end: "12:30",
+		},
+		{
+			name:  "iso date and 24-hour times",
+			text:  "2025-03-20 14:00-15:00",
+			title: "Meeting", date: "2025-03-20", start: "14:00", end: "15:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv, err := ParseInvite(tt.text, now)
+			if err != nil {
+				t.Fatalf("ParseInvite: %v", err)
+			}
+			got := inv.Title + " " + inv.Date.Format("2006-01-02") + " " + inv.Start + "-" + inv.End
+			want := tt.title + " " + tt.date + " " + tt.start + "-" + tt.end
+			if got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestParseInvite_Errors(t *testing.T) {
+	now := time.Date(2025, 3, 5, 10, 0, 0, 0, time.Local)
+	if _, err := ParseInvite("Lunch with Ana\nTuesday, March 11", now); !errors.Is(err, ErrInviteNoTime) {
+		t.Errorf("error = %v, want ErrInviteNoTime", err)
+	}
+	if _, err := ParseInvite("Lunch with Ana\n12:00 - 13:00", now); !errors.Is(err, ErrInviteNoDate) {
+		t.Errorf("error = %v, want ErrInviteNoDate", err)
+	}
+	if _, err := ParseInvite("Sync\nFebruary 30 · 10:00 – 10:30am", now); !errors.Is(err, ErrInviteNoDate) {
+		t.Errorf("error = %v, want ErrInviteNoDate for a day the month lacks", err)
+	}
+}
@@ -0,0 +1,200 @@
+// Package nlp parses short natural-language task descriptions offline,
+// without an LLM.
+package nlp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// DefaultMinutes is the duration of a quick-add task without an explicit one.
+const DefaultMinutes = 60
+
+// ErrMissingDescription is returned when only date, time, duration or
+// category tokens were given.
+var ErrMissingDescription = errors.New("missing task description")
+
+// QuickAdd is a task parsed from a single line such as
+// "Review PRs tomorrow 14:00 45m shallow".
+type QuickAdd struct {
+	Description string
+	Category    task.Category // Defaults to deep
+	Date        time.Time     // Defaults to today
+	Start       string        // "HH:MM", empty when no time was given
+	Minutes     int           // Defaults to DefaultMinutes
+}
+
+// End returns the end time for a parsed start, or "" without one.
+func (q QuickAdd) End() string {
+	if q.Start == "" {
+		return ""
+	}
+	return task.MinutesToTime((task.TimeToMinutes(q.Start) + q.Minutes) % (24 * 60))
+}
+
+// ParseQuickAdd extracts a day, a start time, a duration and a category from
+// input; the remaining words form the description. Recognized tokens:
+//
+//	day:      today, tomorrow, friday, next-friday, next-week, YYYY-MM-DD
+//	time:     14:00, 9:30, 9am, 2:30pm (optionally after "at")
+//	duration: 45m, 45min, 2h, 1h30m (optionally after "for")
+//	category: deep, shallow, or # and any category, e.g. #meeting
+//
+// The first token of each kind wins; later ones are kept in the description.
+func ParseQuickAdd(input string, now time.Time) (QuickAdd, error) {
+	q := QuickAdd{Category: task.CategoryDeep, Date: dateutil.TruncateToDay(now), Minutes: DefaultMinutes}
+	var haveDate, haveDuration, haveCategory bool
+
+	fields := strings.Fields(input)
+	var words []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		lower := strings.ToLower(f)
+
+		// Fillers are only dropped when they introduce a recognized token.
+		if i+1 < len(fields) {
+			next := strings.ToLower(fields[i+1])
+			switch {
+			case lower == "at" && q.Start == "":
+				if _, ok := ParseClock(next); ok {
+					continue
+				}
+			case lower == "on" && !haveDate:
+				if _, err := ParseDay(next, now); err == nil {
+					continue
+				}
+			case lower == "for" && !haveDuration:
+				if _, ok := ParseDuration(next); ok {
+					continue
+				}
+			}
+		}
+
+		if !haveCategory {
+			if c, ok := CategoryToken(lower); ok {
+				q.Category = c
+				haveCategory = true
+				continue
+			}
+		}
+		if !haveDate {
+			d, err := ParseDay(lower, now)
+			if errors.Is(err, dateutil.ErrDateInPast) {
+				return QuickAdd{}, fmt.Errorf("%s: %w", f, err)
+			}
+			if err == nil {
+				q.Date = d
+				haveDate = true
+				continue
+			}
+		}
+		if q.Start == "" {
+			if t, ok := ParseClock(lower); ok {
+				q.Start = t
+				continue
+			}
+		}
+		if !haveDuration {
+			if m, ok := ParseDuration(lower); ok {
+				q.Minutes = m
+				haveDuration = true
+				continue
+			}
+		}
+		words = append(words, f)
+	}
+
+	if len(words) == 0 {
+		return QuickAdd{}, ErrMissingDescription
+	}
+	q.Description = strings.Join(words, " ")
+	return q, nil
+}
+
+// ParseDuration parses tokens such as "45m", "45min", "2h" or "1h30m" into minutes.
+func ParseDuration(s string) (int, bool) {
+	s = strings.TrimSuffix(strings.ToLower(s), "in") // "45min" -> "45m"
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, false
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 || d%time.Minute != 0 {
+		return 0, false
+	}
+	return int(d / time.Minute), true
+}
+
+// ParseClock parses "14:00", "9:30", "9am" or "2:30pm" into "HH:MM".
+func ParseClock(s string) (string, bool) {
+	s = strings.ToLower(s)
+	suffix := ""
+	for _, sfx := range []string{"am", "pm"} {
+		if strings.HasSuffix(s, sfx) {
+			suffix, s = sfx, strings.TrimSuffix(s, sfx)
+		}
+	}
+
+	hourStr, minStr, hasMinutes := strings.Cut(s, ":")
+	if !hasMinutes && suffix == "" {
+		return "", false // A bare number is not a time
+	}
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || len(hourStr) > 2 {
+		return "", false
+	}
+	minute := 0
+	if hasMinutes {
+		if len(minStr) != 2 {
+			return "", false
+		}
+		if minute, err = strconv.Atoi(minStr); err != nil || minute > 59 {
+			return "", false
+		}
+	}
+
+	switch suffix {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return "", false
+		}
+		hour %= 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), true
+}
+
+// ParseDay parses a day token with dateutil.ParseRelativeDate ("today",
+// "tomorrow", "friday", "next-friday", "next-week" or YYYY-MM-DD).
+func ParseDay(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, dateutil.ErrInvalidDateFormat
+	}
+	return dateutil.ParseRelativeDate(s, now)
+}
+
+// CategoryToken returns the category named by a lowercased token: deep or
+// shallow, with or without a leading "#", or any registered category after
+// "#", so a custom name can still be part of the description.
+func CategoryToken(lower string) (task.Category, bool) {
+	switch lower {
+	case "deep", "shallow":
+		return task.Category(lower), true
+	}
+	name, ok := strings.CutPrefix(lower, "#")
+	if c := task.Category(name); ok && c.Valid() {
+		return c, true
+	}
+	return "", false
+}
@@ -0,0 +1,96 @@
+package nlp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.Local) // Wednesday
+	tests := []struct {
+		input    string
+		desc     string
+		category task.Category
+		date     string
+		start    string
+		end      string
+		minutes  int
+	}{
+		{"Review PRs tomorrow 14:00 45m shallow", "Review PRs", task.CategoryShallow, "2025-01-09", "14:00", "14:45", 45},
+		{"write report", "write report", task.CategoryDeep, "2025-01-08", "", "", DefaultMinutes},
+		{"1:1 with Ana at 2:30pm for 30min on friday", "1:1 with Ana", task.CategoryDeep, "2025-01-10", "14:30", "15:00", 30},
+		{"#shallow inbox 9am 2025-01-13", "inbox", task.CategoryShallow, "2025-01-13", "09:00", "10:00", 60},
+		{"meet at the office 1h30m", "meet at the office", task.CategoryDeep, "2025-01-08", "", "", 90},
+		{"deploy 23:30 1h", "deploy", task.CategoryDeep, "2025-01-08", "23:30", "00:30", 60},
+		{"read chapter 12 deep shallow", "read chapter 12 shallow", task.CategoryDeep, "2025-01-08", "", "", 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := ParseQuickAdd(tt.input, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if q.Description != tt.desc || q.Category != tt.category || q.Minutes != tt.minutes {
+				t.Errorf("got %q %s %dm, want %q %s %dm", q.Description, q.Category, q.Minutes, tt.desc, tt.category, tt.minutes)
+			}
+			if got := q.Date.Format("2006-01-02"); got != tt.date {
+				t.Errorf("date = %s, want %s", got, tt.date)
+			}
+			if q.Start != tt.start || q.End() != tt.end {
+				t.Errorf("time = %q-%q, want %q-%q", q.Start, q.End(), tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestParseQuickAdd_CustomCategory(t *testing.T) {
+	task.SetCategories([]task.CategoryInfo{{Name: "meeting", Code: "M"}})
+	defer task.SetCategories(nil)
+
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.Local)
+	q, err := ParseQuickAdd("meeting prep #meeting 15:00", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Description != "meeting prep" || q.Category != "meeting" {
+		t.Errorf("got %q %s, want %q meeting", q.Description, q.Category, "meeting prep")
+	}
+}
+
+func TestParseQuickAdd_Errors(t *testing.T) {
+	now := time.Date(2025, 1, 8, 10, 0, 0, 0, time.Local)
+	if _, err := ParseQuickAdd("tomorrow 14:00 30m", now); !errors.Is(err, ErrMissingDescription) {
+		t.Errorf("error = %v, want ErrMissingDescription", err)
+	}
+	if _, err := ParseQuickAdd("retro 2024-12-01", now); err == nil {
+		t.Error("expected error for a date in the past")
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"14:00", "14:00", true},
+		{"9:30", "09:30", true},
+		{"9am", "09:00", true},
+		{"12am", "00:00", true},
+		{"12pm", "12:00", true},
+		{"2:30PM", "14:30", true},
+		{"14", "", false},
+		{"24:00", "", false},
+		{"13pm", "", false},
+		{"9:5", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseClock(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseClock(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package patch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/sandbox"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Operation errors.
+var (
+	ErrUnknownOp    = errors.New("unknown operation (use create, move, postpone or cancel)")
+	ErrMissingID    = errors.New("id is required")
+	ErrMissingDate  = errors.New("date is required")
+	ErrMissingStart = errors.New("start is required")
+	ErrNotScheduled = errors.New("task is not scheduled")
+	ErrCrossDayMove = errors.New("move cannot change the day; use postpone")
+)
+
+// Step is the outcome of one operation.
+type Step struct {
+	Op     Op
+	Result string // e.g. "Tue Jan 7 10:00-11:00"
+}
+
+// Result is the outcome of running a patch.
+type Result struct {
+	Steps   []Step
+	Changes sandbox.Changes
+	DryRun  bool
+
+	// Before and After hold the tasks on every day the patch touched,
+	// as they were and as they are once the patch is applied.
+	Before []*task.Task
+	After  []*task.Task
+}
+
+// Run validates every operation against a sandbox copy of repo, using the
+// same overlap rules as the database. If any operation fails nothing is
+// written. Otherwise the changes are applied to repo unless dryRun is set.
+// now stamps created tasks and picks the day the sandbox starts from.
+func Run(ctx context.Context, repo task.Repository, p *Patch, now time.Time, dryRun bool) (*Result, error) {
+	sb, err := sandbox.New(ctx, repo, now, now)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{DryRun: dryRun}
+	touched := make(map[string]time.Time)
+	for i, op := range p.Operations {
+		summary, days, err := runOp(ctx, sb, op, now)
+		if err != nil {
+			return res, fmt.Errorf("operation %d (%s): %w", i+1, op, err)
+		}
+		res.Steps = append(res.Steps, Step{Op: op, Result: summary})
+		for _, d := range days {
+			touched[d.Format("2006-01-02")] = d
+		}
+	}
+
+	keys := make([]string, 0, len(touched))
+	for key := range touched {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		day := touched[key]
+		before, err := repo.ListTasksByDateRange(ctx, day, day)
+		if err != nil {
+			return res, fmt.Errorf("listing tasks on %s: %w", key, err)
+		}
+		after, err := sb.ListTasksByDateRange(ctx, day, day)
+		if err != nil {
+			return res, fmt.Errorf("listing tasks on %s: %w", key, err)
+		}
+		res.Before = append(res.Before, before...)
+		res.After = append(res.After, after...)
+	}
+
+	if dryRun {
+		res.Changes = sb.Changes()
+		return res, nil
+	}
+	changes, err := sb.Apply(ctx, repo)
+	res.Changes = changes
+	if err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// runOp applies one operation to the sandbox and returns a short description
+// of the resulting slot plus the days it touched.
+func runOp(ctx context.Context, sb *sandbox.Repo, op Op, now time.Time) (string, []time.Time, error) {
+	switch op.Op {
+	case OpCreate:
+		if op.Date == "" {
+			return "", nil, ErrMissingDate
+		}
+		category := op.Category
+		if category == "" {
+			category = string(task.CategoryDeep)
+		}
+		t, err := task.New(op.Description, category, op.Date, op.Start, op.End, now)
+		if err != nil {
+			return "", nil, err
+		}
+		if err := sb.CreateTask(ctx, t); err != nil {
+			return "", nil, err
+		}
+		return describeSlot(t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd), []time.Time{t.ScheduledDate}, nil
+
+	case OpMove:
+		t, err := scheduledTask(ctx, sb, op)
+		if err != nil {
+			return "", nil, err
+		}
+		if op.Start == "" {
+			return "", nil, ErrMissingStart
+		}
+		if op.Date != "" && op.Date != t.ScheduledDate.Format("2006-01-02") {
+			return "", nil, ErrCrossDayMove
+		}
+		end := op.End
+		if end == "" {
+			end = task.MinutesToTime(task.TimeToMinutes(op.Start) + t.Duration())
+		}
+		if _, err := task.New(t.Description, string(t.Category), t.ScheduledDate.Format("2006-01-02"), op.Start, end, now); err != nil {
+			return "", nil, err
+		}
+		if err := sb.BatchUpdateTaskTimes(ctx, t.ScheduledDate, []task.TaskTimeUpdate{{ID: t.ID, NewStart: op.Start, NewEnd: end}}); err != nil {
+			return "", nil, err
+		}
+		return describeSlot(t.ScheduledDate, op.Start, end), []time.Time{t.ScheduledDate}, nil
+
+	case OpPostpone:
+		t, err := scheduledTask(ctx, sb, op)
+		if err != nil {
+			return "", nil, err
+		}
+		if op.Date == "" {
+			return "", nil, ErrMissingDate
+		}
+		start, end := op.Start, op.End
+		if start == "" {
+			start = t.ScheduledStart
+		}
+		if end == "" {
+			end = task.MinutesToTime(task.TimeToMinutes(start) + t.Duration())
+		}
+		nt, err := task.New(t.Description, string(t.Category), op.Date, start, end, now)
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err := sb.PostponeTask(ctx, t.ID, nt.ScheduledDate, start, end, t.Version); err != nil {
+			return "", nil, err
+		}
+		return describeSlot(nt.ScheduledDate, start, end), []time.Time{t.ScheduledDate, nt.ScheduledDate}, nil
+
+	case OpCancel:
+		t, err := scheduledTask(ctx, sb, op)
+		if err != nil {
+			return "", nil, err
+		}
+		if err := sb.CancelTask(ctx, t.ID, t.Version); err != nil {
+			return "", nil, err
+		}
+		return "cancelled", []time.Time{t.ScheduledDate}, nil
+	}
+	return "", nil, ErrUnknownOp
+}
+
+func scheduledTask(ctx context.Context, sb *sandbox.Repo, op Op) (*task.Task, error) {
+	if op.ID == 0 {
+		return nil, ErrMissingID
+	}
+	t, err := sb.GetTask(ctx, op.ID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, task.ErrTaskNotFound
+	}
+	if !t.IsScheduled() {
+		return nil, fmt.Errorf("%w (status %s)", ErrNotScheduled, t.Status)
+	}
+	return t, nil
+}
+
+func describeSlot(date time.Time, start, end string) string {
+	return fmt.Sprintf("%s %s-%s", date.Format("Mon Jan 2"), start, end)
+}
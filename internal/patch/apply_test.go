@@ -0,0 +1,131 @@
+package patch
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// runNow is the clock reading patches run at, the day before the test tasks.
+var runNow = time.Date(2025, 1, 6, 9, 0, 0, 0, time.Local)
+
+func newTestRepo(t *testing.T) (*db.SQLite, *task.Task, *task.Task) {
+	t.Helper()
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	date := time.Date(2025, 1, 7, 0, 0, 0, 0, time.Local)
+	a := &task.Task{Description: "Review", Category: task.CategoryDeep, ScheduledDate: date, ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled}
+	b := &task.Task{Description: "Email", Category: task.CategoryShallow, ScheduledDate: date, ScheduledStart: "10:00", ScheduledEnd: "10:30", Status: task.StatusScheduled}
+	if err := repo.CreateTasks(context.Background(), []*task.Task{a, b}); err != nil {
+		t.Fatalf("CreateTasks: %v", err)
+	}
+	return repo, a, b
+}
+
+func listDay(t *testing.T, repo task.Repository, date string) []*task.Task {
+	t.Helper()
+	d, _ := time.ParseInLocation("2006-01-02", date, time.Local)
+	tasks, err := repo.ListTasksByDateRange(context.Background(), d, d)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	return tasks
+}
+
+func TestRunDryRunWritesNothing(t *testing.T) {
+	repo, a, b := newTestRepo(t)
+	p := &Patch{Operations: []Op{
+		{Op: OpMove, ID: a.ID, Start: "13:00"},
+		{Op: OpCancel, ID: b.ID},
+		{Op: OpCreate, Description: "Write", Date: "2025-01-07", Start: "09:00", End: "10:00"},
+	}}
+
+	res, err := Run(context.Background(), repo, p, runNow, true)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got, want := res.Changes.Summary(), "1 moved, 1 created, 1 cancelled"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+	if got := res.Steps[0].Result; got != "Tue Jan 7 13:00-14:00" {
+		t.Errorf("move result = %q", got)
+	}
+	if tasks := listDay(t, repo, "2025-01-07"); len(tasks) != 2 || tasks[0].ScheduledStart != "09:00" || !tasks[1].IsScheduled() {
+		t.Fatalf("dry run modified the repo: %+v", tasks)
+	}
+	if len(res.Before) != 2 || len(res.After) != 3 {
+		t.Errorf("before/after = %d/%d tasks, want 2/3", len(res.Before), len(res.After))
+	}
+}
+
+func TestRunAppliesChanges(t *testing.T) {
+	repo, a, b := newTestRepo(t)
+	p := &Patch{Operations: []Op{
+		{Op: OpMove, ID: a.ID, Start: "13:00", End: "14:30"},
+		{Op: OpPostpone, ID: b.ID, Date: "2025-01-08"},
+		{Op: OpCreate, Description: "Write", Date: "2025-01-07", Start: "09:00", End: "10:00"},
+	}}
+
+	if _, err := Run(context.Background(), repo, p, runNow, false); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	tue := listDay(t, repo, "2025-01-07")
+	if len(tue) != 3 {
+		t.Fatalf("tuesday tasks = %d, want 3", len(tue))
+	}
+	if tue[0].Description != "Write" || tue[1].Status != task.StatusPostponed || tue[2].ScheduledEnd != "14:30" {
+		t.Errorf("unexpected tuesday: %+v %+v %+v", tue[0], tue[1], tue[2])
+	}
+	wed := listDay(t, repo, "2025-01-08")
+	if len(wed) != 1 || wed[0].ScheduledStart != "10:00" || wed[0].ScheduledEnd != "10:30" {
+		t.Errorf("unexpected wednesday: %+v", wed)
+	}
+}
+
+func TestRunRejectsInvalidPatchAtomically(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func(a, b *task.Task) Op
+		wantErr error
+	}{
+		{"overlap", func(a, b *task.Task) Op {
+			return Op{Op: OpCreate, Description: "X", Date: "2025-01-07", Start: "09:30", End: "10:15"}
+		}, task.ErrTimeBlockOverlap},
+		{"move overlap", func(a, b *task.Task) Op { return Op{Op: OpMove, ID: a.ID, Start: "10:45"} }, task.ErrTimeBlockOverlap},
+		{"cross day move", func(a, b *task.Task) Op { return Op{Op: OpMove, ID: a.ID, Date: "2025-01-08", Start: "09:00"} }, ErrCrossDayMove},
+		{"missing task", func(a, b *task.Task) Op { return Op{Op: OpCancel, ID: 999} }, task.ErrTaskNotFound},
+		{"missing id", func(a, b *task.Task) Op { return Op{Op: OpCancel} }, ErrMissingID},
+		{"missing date", func(a, b *task.Task) Op { return Op{Op: OpCreate, Description: "X", Start: "09:00", End: "10:00"} }, ErrMissingDate},
+		{"bad times", func(a, b *task.Task) Op {
+			return Op{Op: OpCreate, Description: "X", Date: "2025-01-07", Start: "12:00", End: "11:00"}
+		}, task.ErrEndBeforeStart},
+		{"unknown op", func(a, b *task.Task) Op { return Op{Op: "delete", ID: a.ID} }, ErrUnknownOp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, a, b := newTestRepo(t)
+			// The first operation is valid; it must not be written either
+			p := &Patch{Operations: []Op{
+				{Op: OpCreate, Description: "Valid", Date: "2025-01-07", Start: "11:00", End: "12:00"},
+				tt.op(a, b),
+			}}
+			_, err := Run(context.Background(), repo, p, runNow, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run error = %v, want %v", err, tt.wantErr)
+			}
+			if tasks := listDay(t, repo, "2025-01-07"); len(tasks) != 2 || !tasks[1].IsScheduled() {
+				t.Fatalf("failed patch modified the repo: %+v", tasks)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Package patch applies scripted batch edits (moves, creates, postpones and
+// cancellations) described in a JSON or YAML file.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Operation kinds.
+const (
+	OpCreate   = "create"
+	OpMove     = "move"
+	OpPostpone = "postpone"
+	OpCancel   = "cancel"
+)
+
+// Parse errors.
+var (
+	ErrNoOperations  = errors.New("patch has no operations")
+	ErrUnknownFormat = errors.New("unknown patch format (use .json, .yaml or .yml)")
+)
+
+// Op is a single patch operation.
+//
+//	create:   description, date, start, end, category (default deep)
+//	move:     id, start, end (default: keep duration), date (default: same day)
+//	postpone: id, date, start, end (default: same times)
+//	cancel:   id
+type Op struct {
+	Op          string `json:"op"`
+	ID          int64  `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Date        string `json:"date,omitempty"` // YYYY-MM-DD
+	Start       string `json:"start,omitempty"`
+	End         string `json:"end,omitempty"`
+}
+
+// String describes the operation for output, e.g. "move #12".
+func (o Op) String() string {
+	if o.Op == OpCreate {
+		return fmt.Sprintf("create %q", o.Description)
+	}
+	return fmt.Sprintf("%s #%d", o.Op, o.ID)
+}
+
+// Patch is an ordered list of operations.
+type Patch struct {
+	Operations []Op `json:"operations"`
+}
+
+// Load reads a patch file. The format is chosen by extension; files without a
+// known extension are tried as JSON, then YAML.
+func Load(path string) (*Patch, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading patch: %w", err)
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	switch format {
+	case "json", "yaml", "yml":
+	default:
+		format = "json"
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '{' && trimmed[0] != '[' {
+			format = "yaml"
+		}
+	}
+	return Parse(data, format)
+}
+
+// Parse decodes a patch in the given format ("json", "yaml" or "yml").
+// Both formats accept either {"operations": [...]} or a bare list.
+func Parse(data []byte, format string) (*Patch, error) {
+	var (
+		p   Patch
+		err error
+	)
+	switch format {
+	case "json":
+		err = parseJSON(data, &p)
+	case "yaml", "yml":
+		err = parseYAML(data, &p)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Operations) == 0 {
+		return nil, ErrNoOperations
+	}
+	for i := range p.Operations {
+		p.Operations[i].Op = strings.ToLower(strings.TrimSpace(p.Operations[i].Op))
+	}
+	return &p, nil
+}
+
+func parseJSON(data []byte, p *Patch) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &p.Operations); err != nil {
+			return fmt.Errorf("parsing JSON patch: %w", err)
+		}
+		return nil
+	}
+	if err := json.Unmarshal(trimmed, p); err != nil {
+		return fmt.Errorf("parsing JSON patch: %w", err)
+	}
+	return nil
+}
+
+// parseYAML parses the small YAML subset used by patch files: an optional
+// "operations:" key followed by a list of flat "key: value" maps. Comments
+// and quoted values are supported; nesting, anchors and multi-line strings
+// are not.
+func parseYAML(data []byte, p *Patch) error {
+	var current map[string]string
+	var items []map[string]string
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		lineNo := i + 1
+		line := stripYAMLComment(strings.TrimRight(raw, " \t\r"))
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if trimmed == "operations:" && line == trimmed {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			current = make(map[string]string)
+			items = append(items, current)
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			if trimmed == "" {
+				continue
+			}
+		} else if current == nil {
+			return fmt.Errorf("parsing YAML patch: line %d: expected a list item", lineNo)
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return fmt.Errorf("parsing YAML patch: line %d: expected key: value", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		value = unquoteYAML(strings.TrimSpace(value))
+		if _, dup := current[key]; dup {
+			return fmt.Errorf("parsing YAML patch: line %d: duplicate key %q", lineNo, key)
+		}
+		current[key] = value
+	}
+
+	for i, item := range items {
+		op, err := opFromMap(item)
+		if err != nil {
+			return fmt.Errorf("parsing YAML patch: operation %d: %w", i+1, err)
+		}
+		p.Operations = append(p.Operations, op)
+	}
+	return nil
+}
+
+func opFromMap(m map[string]string) (Op, error) {
+	var op Op
+	for key, value := range m {
+		switch key {
+		case "op":
+			op.Op = value
+		case "id":
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return Op{}, fmt.Errorf("invalid id %q", value)
+			}
+			op.ID = id
+		case "description":
+			op.Description = value
+		case "category":
+			op.Category = value
+		case "date":
+			op.Date = value
+		case "start":
+			op.Start = value
+		case "end":
+			op.End = value
+		default:
+			return Op{}, fmt.Errorf("unknown field %q", key)
+		}
+	}
+	return op, nil
+}
+
+// stripYAMLComment removes a trailing "# comment" that is outside quotes.
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return line
+}
+
+func unquoteYAML(s string) string {
+	if len(s) >= 2 {
+		if (s[0] == '"' && s[len(s)-1] == '"') || (s[0] == '\'' && s[len(s)-1] == '\'') {
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
@@ -0,0 +1,129 @@
+package patch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var wantOps = []Op{
+	{Op: OpCreate, Description: "Write report # draft", Category: "deep", Date: "2025-01-07", Start: "09:00", End: "11:00"},
+	{Op: OpMove, ID: 12, Start: "14:00"},
+	{Op: OpCancel, ID: 14},
+}
+
+func TestParseYAML(t *testing.T) {
+	input := `# weekly reshuffle
+operations:
+  - op: create
+    description: "Write report # draft"
+    category: deep
+    date: 2025-01-07
+    start: "09:00"
+    end: '11:00'
+  - op: move   # same day
+    id: 12
+    start: "14:00"
+  -
+    op: Cancel
+    id: 14
+`
+	p, err := Parse([]byte(input), "yaml")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !reflect.DeepEqual(p.Operations, wantOps) {
+		t.Fatalf("operations = %+v, want %+v", p.Operations, wantOps)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "object",
+			input: `{"operations": [
+				{"op": "create", "description": "Write report # draft", "category": "deep", "date": "2025-01-07", "start": "09:00", "end": "11:00"},
+				{"op": "move", "id": 12, "start": "14:00"},
+				{"op": "cancel", "id": 14}
+			]}`,
+		},
+		{
+			name: "bare list",
+			input: `[
+				{"op": "create", "description": "Write report # draft", "category": "deep", "date": "2025-01-07", "start": "09:00", "end": "11:00"},
+				{"op": "move", "id": 12, "start": "14:00"},
+				{"op": "CANCEL", "id": 14}
+			]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse([]byte(tt.input), "json")
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if !reflect.DeepEqual(p.Operations, wantOps) {
+				t.Fatalf("operations = %+v, want %+v", p.Operations, wantOps)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		format  string
+		wantErr string
+	}{
+		{"empty yaml", "operations:\n", "yaml", ErrNoOperations.Error()},
+		{"empty json", `{"operations": []}`, "json", ErrNoOperations.Error()},
+		{"unknown field", "- op: move\n  when: later\n", "yaml", `unknown field "when"`},
+		{"bad id", "- op: cancel\n  id: abc\n", "yaml", `invalid id "abc"`},
+		{"not a list", "op: cancel\n", "yaml", "expected a list item"},
+		{"duplicate key", "- op: cancel\n  op: move\n", "yaml", `duplicate key "op"`},
+		{"bad json", `{"operations": [`, "json", "parsing JSON patch"},
+		{"unknown format", `{}`, "xml", ErrUnknownFormat.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.input), tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"patch.yml":   "- op: cancel\n  id: 1\n",
+		"patch.json":  `[{"op": "cancel", "id": 1}]`,
+		"patch":       "- op: cancel\n  id: 1\n",
+		"patch.jsonl": `{"operations": [{"op": "cancel", "id": 1}]}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		p, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s): %v", name, err)
+		}
+		if len(p.Operations) != 1 || p.Operations[0].ID != 1 {
+			t.Errorf("Load(%s) = %+v", name, p.Operations)
+		}
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); err == nil || errors.Is(err, ErrNoOperations) {
+		t.Errorf("expected read error, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package replica
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
+)
+
+// Git keeps the database as a file in a git working copy. Fetch pulls from
+// the upstream and Store commits and pushes; the version is the last commit
+// that touched the file. Dir must already be a clone with an upstream.
+type Git struct {
+	Dir  string
+	File string // Path of the database inside Dir
+}
+
+// Name returns the working copy directory.
+func (g *Git) Name() string {
+	return "git:" + filepath.Base(g.Dir)
+}
+
+// Fetch pulls the upstream and reads the file. A missing file is an empty copy.
+func (g *Git) Fetch(ctx context.Context) (Copy, error) {
+	if err := g.checkUpstream(ctx); err != nil {
+		return Copy{}, err
+	}
+	if err := g.discard(ctx); err != nil {
+		return Copy{}, err
+	}
+	if _, err := g.git(ctx, "pull", "--ff-only", "--quiet"); err != nil {
+		return Copy{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(g.Dir, g.File))
+	if errors.Is(err, os.ErrNotExist) {
+		return Copy{}, nil
+	}
+	if err != nil {
+		return Copy{}, fmt.Errorf("reading replica: %w", err)
+	}
+	ver, err := g.version(ctx)
+	if err != nil {
+		return Copy{}, err
+	}
+	return Copy{Data: data, Version: ver}, nil
+}
+
+// Store commits data and pushes it if the file is still at version. A push
+// rejected because the upstream moved on is undone so the next Fetch can
+// fast-forward. Any other failure keeps the upload staged and returns the
+// git error; the next Fetch drops it.
+func (g *Git) Store(ctx context.Context, data []byte, ver string) (string, error) {
+	if err := g.checkUpstream(ctx); err != nil {
+		return "", err
+	}
+	current, err := g.version(ctx)
+	if err != nil {
+		return "", err
+	}
+	if current != ver {
+		return "", ErrRemoteChanged
+	}
+
+	if err := os.WriteFile(filepath.Join(g.Dir, g.File), data, 0o644); err != nil {
+		return "", fmt.Errorf("writing replica: %w", err)
+	}
+	if _, err := g.git(ctx, "add", "--", g.File); err != nil {
+		return "", err
+	}
+	if _, err := g.git(ctx, "commit", "--quiet", "-m", "Sync sancho database", "--", g.File); err != nil {
+		return "", err
+	}
+	if _, err := g.git(ctx, "push", "--quiet"); err != nil {
+		if isRejected(err) {
+			_, _ = g.git(ctx, "reset", "--hard", "--quiet", "HEAD~1")
+			return "", fmt.Errorf("%w: %v", ErrRemoteChanged, err)
+		}
+		_, _ = g.git(ctx, "reset", "--soft", "--quiet", "HEAD~1")
+		return "", err
+	}
+	return g.version(ctx)
+}
+
+// isRejected reports whether a push failed because the upstream has commits
+// the working copy does not.
+func isRejected(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "[rejected]") || strings.Contains(msg, "non-fast-forward") ||
+		strings.Contains(msg, "fetch first")
+}
+
+// discard drops an upload left staged by a failed push, which would stop
+// the pull from fast-forwarding. The file in the working copy is only a
+// staging area for the database, so nothing is lost.
+func (g *Git) discard(ctx context.Context) error {
+	out, err := g.git(ctx, "status", "--porcelain", "--", g.File)
+	if err != nil || strings.TrimSpace(out) == "" {
+		return err
+	}
+	if _, err := g.git(ctx, "cat-file", "-e", "HEAD:"+g.File); err == nil {
+		_, err = g.git(ctx, "checkout", "--quiet", "HEAD", "--", g.File)
+		return err
+	}
+	if _, err := g.git(ctx, "rm", "--cached", "--quiet", "--ignore-unmatch", "--", g.File); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(g.Dir, g.File)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing replica: %w", err)
+	}
+	return nil
+}
+
+// checkUpstream returns httpclient.ErrLocalOnly when local-only mode is on
+// and the upstream is on another machine, before git reaches out to it.
+func (g *Git) checkUpstream(ctx context.Context) error {
+	if !httpclient.LocalOnly() {
+		return nil
+	}
+	out, err := g.git(ctx, "ls-remote", "--get-url")
+	if err != nil {
+		return err
+	}
+	return httpclient.CheckHost(remoteHost(strings.TrimSpace(out)))
+}
+
+// remoteHost returns the host of a git remote URL, or "localhost" for a
+// path on this machine. Both URLs ("ssh://host/repo") and the scp-like
+// form ("user@host:repo") are understood.
+func remoteHost(remote string) string {
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return remote
+		}
+		if u.Hostname() == "" {
+			return "localhost"
+		}
+		return u.Hostname()
+	}
+	if i := strings.Index(remote, ":"); i > 0 && !strings.Contains(remote[:i], "/") {
+		host := remote[:i]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		return strings.Trim(host, "[]")
+	}
+	return "localhost"
+}
+
+// version returns the last commit that touched the file, or "" if none has.
+func (g *Git) version(ctx context.Context) (string, error) {
+	out, err := g.git(ctx, "log", "-1", "--format=%H", "--", g.File)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (g *Git) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", g.Dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,137 @@
+package replica
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
+)
+
+// newGitClones creates a bare upstream with one commit and n clones of it.
+func newGitClones(t *testing.T, n int) []*Git {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	upstream := filepath.Join(dir, "upstream.git")
+	run := func(args ...string) {
+		t.Helper()
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "--quiet", "--bare", "-b", "main", upstream)
+	seed := filepath.Join(dir, "seed")
+	run("clone", "--quiet", upstream, seed)
+	run("-C", seed, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "--quiet", "--allow-empty", "-m", "init")
+	run("-C", seed, "push", "--quiet", "origin", "HEAD:main")
+
+	clones := make([]*Git, n)
+	for i := range clones {
+		clone := filepath.Join(dir, "clone"+string(rune('a'+i)))
+		run("clone", "--quiet", upstream, clone)
+		run("-C", clone, "config", "user.name", "t")
+		run("-C", clone, "config", "user.email", "t@example.com")
+		clones[i] = &Git{Dir: clone, File: "sancho.db"}
+	}
+	return clones
+}
+
+func TestGit_StoreAndFetch(t *testing.T) {
+	ctx := context.Background()
+	clones := newGitClones(t, 2)
+	a, b := clones[0], clones[1]
+
+	if c, err := b.Fetch(ctx); err != nil || c.Data != nil {
+		t.Fatalf("Fetch empty = %+v, %v", c, err)
+	}
+	if _, err := a.Store(ctx, []byte("one"), ""); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	// b still thinks there is no copy, so its push is rejected
+	if _, err := b.Store(ctx, []byte("stale"), ""); !errors.Is(err, ErrRemoteChanged) {
+		t.Fatalf("Store behind upstream = %v, want ErrRemoteChanged", err)
+	}
+	c, err := b.Fetch(ctx)
+	if err != nil || string(c.Data) != "one" {
+		t.Fatalf("Fetch = %q, %v; want one", c.Data, err)
+	}
+	if _, err := b.Store(ctx, []byte("two"), c.Version); err != nil {
+		t.Fatalf("Store at current version: %v", err)
+	}
+}
+
+func TestGit_PushFailureIsNotAConflict(t *testing.T) {
+	ctx := context.Background()
+	g := newGitClones(t, 1)[0]
+	url, err := g.git(ctx, "remote", "get-url", "origin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.git(ctx, "remote", "set-url", "--push", "origin", filepath.Join(t.TempDir(), "missing.git")); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = g.Store(ctx, []byte("one"), "")
+	if err == nil || errors.Is(err, ErrRemoteChanged) {
+		t.Fatalf("Store to an unreachable upstream = %v, want the git error", err)
+	}
+	if ver, _ := g.version(ctx); ver != "" {
+		t.Errorf("version after failed push = %q, want the commit undone", ver)
+	}
+
+	// The staged upload does not get in the way once the upstream is back
+	if _, err := g.git(ctx, "remote", "set-url", "--push", "origin", strings.TrimSpace(url)); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := g.Fetch(ctx); err != nil || c.Data != nil {
+		t.Fatalf("Fetch after failed push = %+v, %v; want an empty copy", c, err)
+	}
+	if _, err := g.Store(ctx, []byte("one"), ""); err != nil {
+		t.Fatalf("Store after failed push: %v", err)
+	}
+}
+
+func TestGit_LocalOnly(t *testing.T) {
+	ctx := context.Background()
+	g := newGitClones(t, 1)[0]
+	httpclient.SetLocalOnly(true)
+	t.Cleanup(func() { httpclient.SetLocalOnly(false) })
+
+	// An upstream on this machine is still fine
+	if _, err := g.Fetch(ctx); err != nil {
+		t.Fatalf("Fetch from a local upstream: %v", err)
+	}
+	if _, err := g.git(ctx, "remote", "set-url", "origin", "git@example.com:me/sancho.git"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Fetch(ctx); !errors.Is(err, httpclient.ErrLocalOnly) {
+		t.Errorf("Fetch = %v, want ErrLocalOnly", err)
+	}
+	if _, err := g.Store(ctx, []byte("one"), ""); !errors.Is(err, httpclient.ErrLocalOnly) {
+		t.Errorf("Store = %v, want ErrLocalOnly", err)
+	}
+}
+
+func TestRemoteHost(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/me/sancho.git":  "github.com",
+		"ssh://git@example.com:2222/sancho": "example.com",
+		"git@example.com:me/sancho.git":     "example.com",
+		"example.com:sancho.git":            "example.com",
+		"file:///srv/git/sancho.git":        "localhost",
+		"/srv/git/sancho.git":               "localhost",
+		"../sancho.git":                     "localhost",
+		"ssh://git@[::1]/sancho":            "::1",
+	}
+	for remote, want := range tests {
+		if got := remoteHost(remote); got != want {
+			t.Errorf("remoteHost(%q) = %q, want %q", remote, got, want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Package replica copies the whole database to a remote (a WebDAV server or
+// a git repository) so several machines can share one schedule. Each sync
+// merges the rows written remotely since the last sync, reports rows changed
+// on both sides as conflicts, and uploads the result.
+package replica
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/javiermolinar/sancho/internal/config"
+)
+
+// ErrRemoteChanged is returned by Store when the remote copy changed since
+// it was fetched. The next sync merges the new copy first.
+var ErrRemoteChanged = errors.New("remote copy changed since it was fetched")
+
+// Copy is a database file as stored on a remote.
+type Copy struct {
+	Data    []byte // Nil when the remote has no copy yet
+	Version string // Opaque version, e.g. an ETag or commit hash
+}
+
+// Remote stores the database file somewhere other machines can reach.
+type Remote interface {
+	// Name identifies the remote in status output.
+	Name() string
+
+	// Fetch returns the current copy.
+	Fetch(ctx context.Context) (Copy, error)
+
+	// Store replaces the copy if it is still at version (empty when there
+	// was none) and returns the new version. It returns ErrRemoteChanged
+	// otherwise.
+	Store(ctx context.Context, data []byte, version string) (string, error)
+}
+
+// NewRemote builds the configured remote.
+func NewRemote(cfg config.Replica) (Remote, error) {
+	switch cfg.Kind {
+	case "webdav":
+		return &WebDAV{
+			URL:      cfg.URL,
+			Username: cfg.Username,
+			Password: os.Getenv(cfg.PasswordEnv),
+		}, nil
+	case "git":
+		file := cfg.File
+		if file == "" {
+			file = "sancho.db"
+		}
+		return &Git{Dir: cfg.Dir, File: file}, nil
+	default:
+		return nil, fmt.Errorf("unknown replica kind %q", cfg.Kind)
+	}
+}
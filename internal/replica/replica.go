@@ -0,0 +1,316 @@
+package replica
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
+)
+
+// StatePath returns where the replica state for a database is kept.
+func StatePath(dbPath string) string {
+	return dbPath + ".replica.json"
+}
+
+// State is what the last successful sync agreed on.
+type State struct {
+	Version string `json:"version"` // Remote version both sides matched
+	Mark    string `json:"mark"`    // Row stamp taken when that sync started
+	Tables  string `json:"tables"`  // db.TablesDigest both sides matched
+}
+
+// Result is the outcome of one sync.
+type Result struct {
+	Pulled    int          // Remote rows merged into the database
+	Pushed    bool         // The database was uploaded
+	Conflicts []*task.Task // Rows changed on both sides; nothing was merged
+	Diverged  bool         // Notes or other tables changed on both sides; nothing was merged
+}
+
+// Conflicted reports whether the sync stopped for the user to pick a side.
+func (r Result) Conflicted() bool {
+	return len(r.Conflicts) > 0 || r.Diverged
+}
+
+// Status is the replication state shown in the TUI.
+type Status struct {
+	Remote   string
+	LastSync time.Time // Last successful run; zero before the first one
+	LastErr  error     // Error of the last run, nil if it succeeded
+	Failures int       // Consecutive failed runs
+	NextRun  time.Time
+	Result   Result // Outcome of the last successful run
+}
+
+// Replicator syncs a database with a remote copy.
+type Replicator struct {
+	db        *db.SQLite
+	remote    Remote
+	statePath string
+	clock     clock.Clock
+
+	mu       sync.Mutex // Serializes runs
+	status   Status
+	memState State // Used when statePath is empty
+}
+
+// New creates a replicator for repo. statePath may be empty to keep the
+// state in memory only.
+func New(repo *db.SQLite, remote Remote, statePath string) *Replicator {
+	return &Replicator{
+		db:        repo,
+		remote:    remote,
+		statePath: statePath,
+		clock:     clock.System,
+		status:    Status{Remote: remote.Name()},
+	}
+}
+
+// Status returns the state of the last run.
+func (r *Replicator) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Sync merges the remote copy into the database and uploads the result
+// when there are local changes. Rows changed on both sides are returned as
+// conflicts and nothing is written unless resolve picks a side.
+func (r *Replicator) Sync(ctx context.Context, resolve db.Resolution) (Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res, err := r.sync(ctx, resolve)
+	r.status.LastErr = err
+	if err != nil {
+		r.status.Failures++
+		return res, err
+	}
+	r.status.Failures = 0
+	r.status.LastSync = r.clock.Now()
+	r.status.Result = res
+	return res, nil
+}
+
+func (r *Replicator) sync(ctx context.Context, resolve db.Resolution) (Result, error) {
+	state, err := r.load()
+	if err != nil {
+		return Result{}, err
+	}
+	// Taken first, so rows written while syncing count as changed next time
+	mark, err := r.db.SyncMark(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	remote, err := r.remote.Fetch(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	// Counted before merging, since merged rows carry newer stamps too
+	changed, err := r.db.ChangedSince(ctx, state.Mark)
+	if err != nil {
+		return Result{}, err
+	}
+
+	tables, err := r.db.TablesDigest(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var res Result
+	theirs := state.Tables
+	if remote.Data != nil && remote.Version != state.Version {
+		merged, err := r.merge(ctx, remote.Data, state, tables, resolve)
+		if err != nil {
+			return Result{}, err
+		}
+		if merged.diverged || len(merged.Conflicts) > 0 {
+			res.Conflicts = merged.Conflicts
+			res.Diverged = merged.diverged
+			return res, nil
+		}
+		res.Pulled = merged.Applied
+		theirs = merged.tables
+		if merged.adopted {
+			if tables, err = r.db.TablesDigest(ctx); err != nil {
+				return Result{}, err
+			}
+		}
+	}
+
+	state.Version = remote.Version
+	if changed > 0 || tables != theirs || remote.Data == nil || resolve != db.ResolveNone {
+		data, err := r.snapshot(ctx)
+		if err != nil {
+			return Result{}, err
+		}
+		if state.Version, err = r.remote.Store(ctx, data, remote.Version); err != nil {
+			return res, err
+		}
+		res.Pushed = true
+	}
+
+	state.Mark = mark
+	state.Tables = tables
+	return res, r.save(state)
+}
+
+// merged is what merging a remote copy did.
+type merged struct {
+	db.MergeResult
+	tables   string // TablesDigest of the remote copy
+	adopted  bool   // The remote tables replaced the local ones
+	diverged bool   // Both sides changed the tables; nothing was merged
+}
+
+// merge writes a remote copy to a temporary file and merges its tasks.
+// Tables other than tasks are not merged row by row: when only the remote
+// changed them since the last sync they are taken as they are, and when
+// both sides did the merge stops unless resolve picks a side. ours is the
+// local TablesDigest.
+func (r *Replicator) merge(ctx context.Context, data []byte, state State, ours string, resolve db.Resolution) (merged, error) {
+	path, cleanup, err := tempPath()
+	if err != nil {
+		return merged{}, err
+	}
+	defer cleanup()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return merged{}, fmt.Errorf("writing replica copy: %w", err)
+	}
+
+	var m merged
+	if m.tables, err = db.TablesDigestOf(ctx, path); err != nil {
+		return merged{}, err
+	}
+	take := false
+	if m.tables != ours && m.tables != state.Tables {
+		switch {
+		case ours == state.Tables || resolve == db.ResolveRemote:
+			take = true
+		case resolve == db.ResolveNone:
+			m.diverged = true
+			return m, nil
+		}
+	}
+
+	if m.MergeResult, err = r.db.MergeFrom(ctx, path, state.Mark, resolve); err != nil {
+		return merged{}, err
+	}
+	if len(m.Conflicts) > 0 || !take {
+		return m, nil
+	}
+	if err := r.db.AdoptTables(ctx, path); err != nil {
+		return merged{}, err
+	}
+	m.adopted = true
+	return m, nil
+}
+
+// snapshot returns a consistent copy of the database file.
+func (r *Replicator) snapshot(ctx context.Context) ([]byte, error) {
+	path, cleanup, err := tempPath()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	if err := r.db.Snapshot(ctx, path); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+	return data, nil
+}
+
+// tempPath returns an unused file path in a new temporary directory.
+func tempPath() (string, func(), error) {
+	dir, err := os.MkdirTemp("", "sancho-replica-")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	return filepath.Join(dir, "sancho.db"), func() { _ = os.RemoveAll(dir) }, nil
+}
+
+// Start syncs in the background until ctx is cancelled: once right away,
+// then on the interval, backing off after failures like the sync sources.
+// The status after each run is delivered on the returned channel, which is
+// closed when the loop stops.
+func (r *Replicator) Start(ctx context.Context, interval time.Duration) <-chan Status {
+	events := make(chan Status, 1)
+	go func() {
+		defer close(events)
+		delay := time.Duration(0)
+		for {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			_, _ = r.Sync(ctx, db.ResolveNone)
+			r.mu.Lock()
+			delay = tasksync.Jitter(tasksync.NextDelay(interval, r.status.Failures))
+			r.status.NextRun = r.clock.Now().Add(delay)
+			st := r.status
+			r.mu.Unlock()
+
+			select {
+			case events <- st:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// load reads the state from statePath. Before the first sync every row
+// counts as changed.
+func (r *Replicator) load() (State, error) {
+	if r.statePath == "" {
+		return r.memState, nil
+	}
+	data, err := os.ReadFile(r.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("reading replica state: %w", err)
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("parsing replica state: %w", err)
+	}
+	return state, nil
+}
+
+// save writes the state to statePath.
+func (r *Replicator) save(state State) error {
+	if r.statePath == "" {
+		r.memState = state
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding replica state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.statePath), 0o755); err != nil {
+		return fmt.Errorf("creating replica state directory: %w", err)
+	}
+	if err := os.WriteFile(r.statePath, data, 0o644); err != nil {
+		return fmt.Errorf("writing replica state: %w", err)
+	}
+	return nil
+}
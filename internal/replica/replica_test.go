@@ -0,0 +1,341 @@
+package replica
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// memRemote is a Remote kept in memory, versioned by a counter.
+type memRemote struct {
+	data    []byte
+	version int
+}
+
+func (m *memRemote) Name() string { return "memory" }
+
+func (m *memRemote) Fetch(ctx context.Context) (Copy, error) {
+	if m.data == nil {
+		return Copy{}, nil
+	}
+	return Copy{Data: m.data, Version: strconv.Itoa(m.version)}, nil
+}
+
+func (m *memRemote) Store(ctx context.Context, data []byte, version string) (string, error) {
+	current := ""
+	if m.data != nil {
+		current = strconv.Itoa(m.version)
+	}
+	if version != current {
+		return "", ErrRemoteChanged
+	}
+	m.data = data
+	m.version++
+	return strconv.Itoa(m.version), nil
+}
+
+func newMachine(t *testing.T, remote Remote) (*db.SQLite, *Replicator) {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := db.New(filepath.Join(dir, "sancho.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo, New(repo, remote, StatePath(filepath.Join(dir, "sancho.db")))
+}
+
+func createTask(t *testing.T, repo *db.SQLite, desc, start, end string) *task.Task {
+	t.Helper()
+	tk := &task.Task{
+		Description:    desc,
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local),
+		ScheduledStart: start,
+		ScheduledEnd:   end,
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(context.Background(), tk); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	return tk
+}
+
+// tick waits long enough for the next write to get a newer row stamp.
+func tick() {
+	time.Sleep(2 * time.Millisecond)
+}
+
+func TestReplicator_SyncsTwoMachines(t *testing.T) {
+	ctx := context.Background()
+	remote := &memRemote{}
+	laptop, laptopSync := newMachine(t, remote)
+	desktop, desktopSync := newMachine(t, remote)
+
+	report := createTask(t, laptop, "Report", "09:00", "10:00")
+	res, err := laptopSync.Sync(ctx, db.ResolveNone)
+	if err != nil || !res.Pushed {
+		t.Fatalf("laptop first sync = %+v, %v; want pushed", res, err)
+	}
+
+	res, err = desktopSync.Sync(ctx, db.ResolveNone)
+	if err != nil || res.Pulled != 1 || res.Pushed {
+		t.Fatalf("desktop first sync = %+v, %v; want 1 pulled, no push", res, err)
+	}
+	got, err := desktop.GetTask(ctx, report.ID)
+	if err != nil || got == nil || got.Description != "Report" {
+		t.Fatalf("desktop task = %+v, %v; want Report", got, err)
+	}
+
+	// Nothing changed anywhere: no upload, so machines do not ping-pong
+	if res, err = laptopSync.Sync(ctx, db.ResolveNone); err != nil || res.Pushed || res.Pulled != 0 {
+		t.Fatalf("idle sync = %+v, %v; want nothing", res, err)
+	}
+
+	tick()
+	if err := desktop.UpdateTaskDescription(ctx, report.ID, "Quarterly report", 0); err != nil {
+		t.Fatalf("UpdateTaskDescription: %v", err)
+	}
+	createTask(t, laptop, "Email", "11:00", "12:00")
+	if _, err := desktopSync.Sync(ctx, db.ResolveNone); err != nil {
+		t.Fatalf("desktop sync: %v", err)
+	}
+	// Different rows changed on each side merge cleanly
+	res, err = laptopSync.Sync(ctx, db.ResolveNone)
+	if err != nil || res.Pulled != 1 || !res.Pushed || len(res.Conflicts) != 0 {
+		t.Fatalf("laptop merge = %+v, %v; want 1 pulled and pushed", res, err)
+	}
+	if got, _ := laptop.GetTask(ctx, report.ID); got.Description != "Quarterly report" {
+		t.Errorf("laptop description = %q, want merged edit", got.Description)
+	}
+	if _, err := desktopSync.Sync(ctx, db.ResolveNone); err != nil {
+		t.Fatalf("desktop sync: %v", err)
+	}
+	all, _ := desktop.ListAllTasks(ctx)
+	if len(all) != 2 {
+		t.Errorf("desktop has %d tasks, want 2", len(all))
+	}
+}
+
+func TestReplicator_KeepsTasksCreatedOnBothMachines(t *testing.T) {
+	ctx := context.Background()
+	remote := &memRemote{}
+	laptop, laptopSync := newMachine(t, remote)
+	desktop, desktopSync := newMachine(t, remote)
+
+	createTask(t, laptop, "Report", "09:00", "10:00")
+	for _, r := range []*Replicator{laptopSync, desktopSync} {
+		if _, err := r.Sync(ctx, db.ResolveNone); err != nil {
+			t.Fatalf("initial sync: %v", err)
+		}
+	}
+
+	// Both machines number their next task 2
+	tick()
+	laptopNew := createTask(t, laptop, "LaptopNew", "11:00", "12:00")
+	desktopNew := createTask(t, desktop, "DesktopNew", "14:00", "15:00")
+	if laptopNew.ID != desktopNew.ID {
+		t.Fatalf("ids = %d and %d, want the same id on both machines", laptopNew.ID, desktopNew.ID)
+	}
+	if _, err := laptopSync.Sync(ctx, db.ResolveNone); err != nil {
+		t.Fatalf("laptop sync: %v", err)
+	}
+	res, err := desktopSync.Sync(ctx, db.ResolveNone)
+	if err != nil || len(res.Conflicts) != 0 || res.Pulled != 1 || !res.Pushed {
+		t.Fatalf("desktop sync = %+v, %v; want 1 pulled and pushed, no conflicts", res, err)
+	}
+	if res, err = laptopSync.Sync(ctx, db.ResolveNone); err != nil || res.Pulled != 1 {
+		t.Fatalf("laptop sync = %+v, %v; want 1 pulled", res, err)
+	}
+
+	for name, repo := range map[string]*db.SQLite{"laptop": laptop, "desktop": desktop} {
+		all, err := repo.ListAllTasks(ctx)
+		if err != nil {
+			t.Fatalf("%s ListAllTasks: %v", name, err)
+		}
+		got := make(map[string]bool)
+		for _, tk := range all {
+			got[tk.Description] = true
+		}
+		if len(all) != 3 || !got["Report"] || !got["LaptopNew"] || !got["DesktopNew"] {
+			t.Errorf("%s has %d tasks %v, want Report, LaptopNew and DesktopNew", name, len(all), got)
+		}
+	}
+}
+
+func TestReplicator_CarriesOtherTables(t *testing.T) {
+	ctx := context.Background()
+	remote := &memRemote{}
+	laptop, laptopSync := newMachine(t, remote)
+	desktop, desktopSync := newMachine(t, remote)
+
+	report := createTask(t, laptop, "Report", "09:00", "10:00")
+	for _, r := range []*Replicator{laptopSync, desktopSync} {
+		if _, err := r.Sync(ctx, db.ResolveNone); err != nil {
+			t.Fatalf("initial sync: %v", err)
+		}
+	}
+
+	// The desktop takes id 2 first, so the laptop's new task gets id 3 there
+	tick()
+	createTask(t, desktop, "DesktopNew", "14:00", "15:00")
+	email := createTask(t, laptop, "Email", "11:00", "12:00")
+	if err := laptop.SaveReview(ctx, []task.ReviewEntry{{ID: report.ID, Outcome: task.OutcomeOnTime, Note: "Sent"}}); err != nil {
+		t.Fatalf("SaveReview: %v", err)
+	}
+	if err := laptop.AddDependency(ctx, task.Dependency{TaskID: email.ID, BlockedBy: report.ID}); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	if _, err := laptopSync.Sync(ctx, db.ResolveNone); err != nil {
+		t.Fatalf("laptop sync: %v", err)
+	}
+	res, err := desktopSync.Sync(ctx, db.ResolveNone)
+	if err != nil || res.Conflicted() || !res.Pushed {
+		t.Fatalf("desktop sync = %+v, %v; want merged and pushed", res, err)
+	}
+
+	notes, _ := desktop.TaskNotes(ctx, []int64{report.ID})
+	if notes[report.ID] != "Sent" {
+		t.Errorf("desktop notes = %v, want the laptop's note", notes)
+	}
+	deps, _ := desktop.Dependencies(ctx, []int64{report.ID})
+	if len(deps) != 1 || deps[0].TaskID != 3 || deps[0].BlockedBy != report.ID {
+		t.Errorf("desktop dependencies = %+v, want Email (id 3) blocked by Report", deps)
+	}
+	if res, err = laptopSync.Sync(ctx, db.ResolveNone); err != nil || res.Conflicted() {
+		t.Fatalf("laptop sync = %+v, %v; want merged", res, err)
+	}
+
+	// Notes written on both machines stop the sync until a side is picked
+	tick()
+	if err := laptop.SaveReview(ctx, []task.ReviewEntry{{ID: report.ID, Outcome: task.OutcomeOnTime, Note: "Laptop"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := desktop.SaveReview(ctx, []task.ReviewEntry{{ID: report.ID, Outcome: task.OutcomeOnTime, Note: "Desktop"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := laptopSync.Sync(ctx, db.ResolveNone); err != nil {
+		t.Fatalf("laptop sync: %v", err)
+	}
+	if res, err = desktopSync.Sync(ctx, db.ResolveNone); err != nil || !res.Diverged || res.Pushed {
+		t.Fatalf("desktop sync = %+v, %v; want diverged and no push", res, err)
+	}
+	if res, err = desktopSync.Sync(ctx, db.ResolveRemote); err != nil || res.Conflicted() {
+		t.Fatalf("resolve remote = %+v, %v", res, err)
+	}
+	if notes, _ := desktop.TaskNotes(ctx, []int64{report.ID}); notes[report.ID] != "Laptop" {
+		t.Errorf("desktop notes = %v, want the laptop's note taken", notes)
+	}
+}
+
+func TestReplicator_ReportsConflicts(t *testing.T) {
+	ctx := context.Background()
+	remote := &memRemote{}
+	laptop, laptopSync := newMachine(t, remote)
+	desktop, desktopSync := newMachine(t, remote)
+
+	report := createTask(t, laptop, "Report", "09:00", "10:00")
+	for _, r := range []*Replicator{laptopSync, desktopSync} {
+		if _, err := r.Sync(ctx, db.ResolveNone); err != nil {
+			t.Fatalf("initial sync: %v", err)
+		}
+	}
+
+	tick()
+	if err := laptop.UpdateTaskDescription(ctx, report.ID, "Laptop edit", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := desktop.UpdateTaskDescription(ctx, report.ID, "Desktop edit", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := laptopSync.Sync(ctx, db.ResolveNone); err != nil {
+		t.Fatalf("laptop sync: %v", err)
+	}
+
+	res, err := desktopSync.Sync(ctx, db.ResolveNone)
+	if err != nil {
+		t.Fatalf("desktop sync: %v", err)
+	}
+	if len(res.Conflicts) != 1 || res.Conflicts[0].Description != "Desktop edit" || res.Pushed {
+		t.Fatalf("desktop sync = %+v, want one conflict and no push", res)
+	}
+
+	// Keeping the local copy uploads it
+	if res, err = desktopSync.Sync(ctx, db.ResolveLocal); err != nil || !res.Pushed {
+		t.Fatalf("resolve local = %+v, %v; want pushed", res, err)
+	}
+	if _, err := laptopSync.Sync(ctx, db.ResolveNone); err != nil {
+		t.Fatalf("laptop sync: %v", err)
+	}
+	if got, _ := laptop.GetTask(ctx, report.ID); got.Description != "Desktop edit" {
+		t.Errorf("laptop description = %q, want the kept desktop edit", got.Description)
+	}
+}
+
+func TestWebDAV_ConditionalPut(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		body []byte
+		etag int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		current := ""
+		if body != nil {
+			current = `"` + strconv.Itoa(etag) + `"`
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if body == nil {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("ETag", current)
+			_, _ = w.Write(body)
+		case http.MethodPut:
+			if match := r.Header.Get("If-Match"); match != "" && match != current ||
+				r.Header.Get("If-None-Match") == "*" && body != nil {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			body, _ = io.ReadAll(r.Body)
+			etag++
+			w.Header().Set("ETag", `"`+strconv.Itoa(etag)+`"`)
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	w := &WebDAV{URL: srv.URL + "/sancho.db", Client: srv.Client()}
+	if c, err := w.Fetch(ctx); err != nil || c.Data != nil {
+		t.Fatalf("Fetch empty = %+v, %v", c, err)
+	}
+	v1, err := w.Store(ctx, []byte("one"), "")
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if _, err := w.Store(ctx, []byte("stale"), ""); !errors.Is(err, ErrRemoteChanged) {
+		t.Fatalf("Store over existing = %v, want ErrRemoteChanged", err)
+	}
+	if _, err := w.Store(ctx, []byte("two"), v1); err != nil {
+		t.Fatalf("Store at current version: %v", err)
+	}
+	if _, err := w.Store(ctx, []byte("stale"), v1); !errors.Is(err, ErrRemoteChanged) {
+		t.Fatalf("Store at old version = %v, want ErrRemoteChanged", err)
+	}
+	if c, err := w.Fetch(ctx); err != nil || string(c.Data) != "two" {
+		t.Fatalf("Fetch = %q, %v; want two", c.Data, err)
+	}
+}
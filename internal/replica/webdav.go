@@ -0,0 +1,121 @@
+package replica
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/httpclient"
+)
+
+// WebDAV keeps the database as a single file on a WebDAV server (or any
+// server that supports GET and PUT with ETags). Conditional PUTs make sure
+// a copy uploaded by another machine is never overwritten unseen.
+type WebDAV struct {
+	URL      string
+	Username string
+	Password string
+	Client   *http.Client // Defaults to a client with a one minute timeout
+}
+
+// Name returns the server host.
+func (w *WebDAV) Name() string {
+	if u, err := url.Parse(w.URL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return "webdav"
+}
+
+// Fetch downloads the file. A missing file is an empty copy.
+func (w *WebDAV) Fetch(ctx context.Context) (Copy, error) {
+	resp, err := w.do(ctx, http.MethodGet, nil, nil)
+	if err != nil {
+		return Copy{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return Copy{}, nil
+	case http.StatusOK:
+	default:
+		return Copy{}, fmt.Errorf("fetching replica: %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Copy{}, fmt.Errorf("reading replica: %w", err)
+	}
+	return Copy{Data: data, Version: version(resp)}, nil
+}
+
+// Store uploads data if the file is still at version.
+func (w *WebDAV) Store(ctx context.Context, data []byte, ver string) (string, error) {
+	header := http.Header{}
+	switch {
+	case ver == "":
+		header.Set("If-None-Match", "*")
+	case strings.HasPrefix(ver, `"`) || strings.HasPrefix(ver, "W/"):
+		header.Set("If-Match", ver)
+	default:
+		header.Set("If-Unmodified-Since", ver)
+	}
+	resp, err := w.do(ctx, http.MethodPut, bytes.NewReader(data), header)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+	case http.StatusPreconditionFailed:
+		return "", ErrRemoteChanged
+	default:
+		return "", fmt.Errorf("storing replica: %s", resp.Status)
+	}
+	if v := version(resp); v != "" {
+		return v, nil
+	}
+
+	// Not every server returns the new ETag on PUT
+	head, err := w.do(ctx, http.MethodHead, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	_ = head.Body.Close()
+	return version(head), nil
+}
+
+func (w *WebDAV) do(ctx context.Context, method string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, w.URL, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if w.Username != "" {
+		req.SetBasicAuth(w.Username, w.Password)
+	}
+	client := w.Client
+	if client == nil {
+		client = httpclient.New(time.Minute)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("contacting %s: %w", w.Name(), err)
+	}
+	return resp, nil
+}
+
+// version returns the ETag of a response, falling back to Last-Modified.
+func version(resp *http.Response) string {
+	if v := resp.Header.Get("ETag"); v != "" {
+		return v
+	}
+	return resp.Header.Get("Last-Modified")
+}
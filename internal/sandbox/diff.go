@@ -0,0 +1,210 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Postpone is a base task that was postponed in the sandbox, resolved to the
+// final slot of its (possibly repeatedly postponed) copy.
+type Postpone struct {
+	ID    int64
+	Date  time.Time
+	Start string
+	End   string
+}
+
+// Changes is the diff between the sandbox and the base repository.
+type Changes struct {
+	Created      []*task.Task
+	Cancelled    []int64
+	Postponed    []Postpone
+	Moved        []task.TaskTimeUpdate // Each with its new Date
+	Descriptions map[int64]string
+	Outcomes     map[int64]task.Outcome
+	Energies     map[int64]task.Energy
+	Categories   map[int64]task.Category
+}
+
+// Empty reports whether there is nothing to apply.
+func (c Changes) Empty() bool {
+	return len(c.Created) == 0 && len(c.Cancelled) == 0 && len(c.Postponed) == 0 &&
+		len(c.Moved) == 0 && len(c.Descriptions) == 0 && len(c.Outcomes) == 0 && len(c.Energies) == 0 && len(c.Categories) == 0
+}
+
+// Summary returns a short description such as "2 moved, 1 created".
+func (c Changes) Summary() string {
+	var parts []string
+	add := func(n int, label string) {
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, label))
+		}
+	}
+	add(len(c.Moved), "moved")
+	add(len(c.Created), "created")
+	add(len(c.Postponed), "postponed")
+	add(len(c.Cancelled), "cancelled")
+	add(len(c.Descriptions), "renamed")
+	add(len(c.Outcomes), "outcomes")
+	add(len(c.Energies), "energy")
+	add(len(c.Categories), "recategorized")
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Changes computes the diff between the sandbox and the state it was cloned from.
+func (r *Repo) Changes() Changes {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := Changes{
+		Descriptions: make(map[int64]string),
+		Outcomes:     make(map[int64]task.Outcome),
+		Energies:     make(map[int64]task.Energy),
+		Categories:   make(map[int64]task.Category),
+	}
+
+	all, _ := r.store.ListAllTasks(context.Background()) // Never fails in memory
+	tasks := make(map[int64]*task.Task, len(all))
+	for _, t := range all {
+		tasks[t.ID] = t
+	}
+
+	// Follow sandbox-created postpone copies back to the base task they replace.
+	postponedTo := make(map[int64]*task.Task)
+	for _, t := range all {
+		if t.ID >= 0 || !t.IsScheduled() {
+			continue
+		}
+		if root, ok := postponeRoot(tasks, t); ok {
+			postponedTo[root] = t
+			continue
+		}
+		cp := *t
+		cp.ID = 0
+		cp.PostponedFrom = nil
+		cp.CreatedAt = time.Time{} // Stamped by the target's clock
+		cp.Version = 0
+		c.Created = append(c.Created, &cp)
+	}
+
+	for id, orig := range r.original {
+		t := tasks[id]
+		switch {
+		case orig.IsScheduled() && t.Status == task.StatusCancelled:
+			c.Cancelled = append(c.Cancelled, id)
+			continue
+		case orig.IsScheduled() && t.Status == task.StatusPostponed:
+			if to, ok := postponedTo[id]; ok {
+				c.Postponed = append(c.Postponed, Postpone{ID: id, Date: to.ScheduledDate, Start: to.ScheduledStart, End: to.ScheduledEnd})
+			} else {
+				// The postponed copy was cancelled again
+				c.Cancelled = append(c.Cancelled, id)
+			}
+			continue
+		}
+
+		if t.IsScheduled() && (task.CalendarDaysBetween(orig.ScheduledDate, t.ScheduledDate) != 0 ||
+			orig.ScheduledStart != t.ScheduledStart || orig.ScheduledEnd != t.ScheduledEnd) {
+			c.Moved = append(c.Moved, task.TaskTimeUpdate{ID: id, Date: t.ScheduledDate, NewStart: t.ScheduledStart, NewEnd: t.ScheduledEnd})
+		}
+		if orig.Description != t.Description {
+			c.Descriptions[id] = t.Description
+		}
+		if t.Outcome != nil && (orig.Outcome == nil || *orig.Outcome != *t.Outcome) {
+			c.Outcomes[id] = *t.Outcome
+		}
+		if orig.Energy != t.Energy {
+			c.Energies[id] = t.Energy
+		}
+		if orig.Category != t.Category {
+			c.Categories[id] = t.Category
+		}
+	}
+
+	sortTasks(c.Created)
+	sort.Slice(c.Cancelled, func(i, j int) bool { return c.Cancelled[i] < c.Cancelled[j] })
+	sort.Slice(c.Postponed, func(i, j int) bool { return c.Postponed[i].ID < c.Postponed[j].ID })
+	sort.Slice(c.Moved, func(i, j int) bool { return c.Moved[i].ID < c.Moved[j].ID })
+	return c
+}
+
+// postponeRoot returns the base task ID a sandbox task was postponed from,
+// following chains of sandbox postpones through tasks.
+func postponeRoot(tasks map[int64]*task.Task, t *task.Task) (int64, bool) {
+	for t.PostponedFrom != nil {
+		from := *t.PostponedFrom
+		if from >= 0 {
+			return from, true
+		}
+		prev, ok := tasks[from]
+		if !ok {
+			return 0, false
+		}
+		t = prev
+	}
+	return 0, false
+}
+
+// Apply writes the sandbox changes to target, normally the base repository.
+// Cancellations and postpones run first to free slots, then moves, edits and
+// new tasks. It stops at the first error; earlier steps are not rolled back.
+func (r *Repo) Apply(ctx context.Context, target task.Repository) (Changes, error) {
+	c := r.Changes()
+
+	for _, id := range c.Cancelled {
+		if err := target.CancelTask(ctx, id, 0); err != nil {
+			return c, fmt.Errorf("cancelling task %d: %w", id, err)
+		}
+	}
+	for _, p := range c.Postponed {
+		if _, err := target.PostponeTask(ctx, p.ID, p.Date, p.Start, p.End, 0); err != nil {
+			return c, fmt.Errorf("postponing task %d: %w", p.ID, err)
+		}
+	}
+
+	// One batch, so tasks can trade places across days
+	if err := target.BatchUpdateTaskTimes(ctx, time.Time{}, c.Moved); err != nil {
+		return c, fmt.Errorf("moving tasks: %w", err)
+	}
+
+	for id, desc := range c.Descriptions {
+		if err := target.UpdateTaskDescription(ctx, id, desc, 0); err != nil {
+			return c, fmt.Errorf("renaming task %d: %w", id, err)
+		}
+	}
+	for id, outcome := range c.Outcomes {
+		if err := target.SetTaskOutcome(ctx, id, outcome, 0); err != nil {
+			return c, fmt.Errorf("setting outcome of task %d: %w", id, err)
+		}
+	}
+	for id, energy := range c.Energies {
+		if err := target.SetTaskEnergy(ctx, id, energy, 0); err != nil {
+			return c, fmt.Errorf("setting energy of task %d: %w", id, err)
+		}
+	}
+
+	if len(c.Categories) > 0 {
+		updates := make([]task.TaskUpdate, 0, len(c.Categories))
+		for id, category := range c.Categories {
+			updates = append(updates, task.TaskUpdate{ID: id, Category: category})
+		}
+		if err := target.BatchUpdate(ctx, updates); err != nil {
+			return c, fmt.Errorf("changing categories: %w", err)
+		}
+	}
+
+	if len(c.Created) > 0 {
+		if err := target.CreateTasks(ctx, c.Created); err != nil {
+			return c, fmt.Errorf("creating tasks: %w", err)
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,346 @@
+// Package sandbox provides an in-memory copy of the schedule for trying out
+// changes (moves, plans, cancellations) without touching the real database.
+// When done, the changes can be discarded or applied as a diff.
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+const dateKeyFormat = "2006-01-02"
+
+// Repo is a task.Repository overlay on a base repository. Writes go to an
+// in-memory memrepo.Repo, which applies the same rules (overlaps, postpone
+// chains) as everywhere else. Days are copied from the base lazily the first
+// time they are listed or written to, so browsing to another week in the
+// sandbox still shows real data. Tasks created in the sandbox get negative
+// IDs so they never collide with tasks loaded later.
+type Repo struct {
+	mu       sync.Mutex
+	base     task.Repository
+	store    *memrepo.Repo
+	original map[int64]task.Task // Snapshot of base tasks as first loaded
+	loaded   map[string]bool     // Days copied from base
+}
+
+// New creates a sandbox over base with the days between start and end
+// (inclusive) copied up front.
+func New(ctx context.Context, base task.Repository, start, end time.Time) (*Repo, error) {
+	r := &Repo{
+		base:     base,
+		store:    memrepo.New(memrepo.WithNegativeIDs(), memrepo.WithAllowOverlaps(task.AllowsOverlaps(base))),
+		original: make(map[int64]task.Task),
+		loaded:   make(map[string]bool),
+	}
+	if err := r.ensureLoaded(ctx, start, end); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// AllowsOverlaps reports whether overlapping time blocks are stored, as in
+// the base repository.
+func (r *Repo) AllowsOverlaps() bool {
+	return r.store.AllowsOverlaps()
+}
+
+// Base returns the repository the sandbox was cloned from.
+func (r *Repo) Base() task.Repository {
+	return r.base
+}
+
+// ensureLoaded copies any days in the range that have not been loaded yet.
+// Callers must hold r.mu.
+func (r *Repo) ensureLoaded(ctx context.Context, start, end time.Time) error {
+	days := task.CalendarDaysBetween(start, end)
+	missing := false
+	for i := 0; i <= days; i++ {
+		if !r.loaded[start.AddDate(0, 0, i).Format(dateKeyFormat)] {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return nil
+	}
+
+	tasks, err := r.base.ListTasksByDateRange(ctx, start, end)
+	if err != nil {
+		return fmt.Errorf("loading sandbox tasks: %w", err)
+	}
+	for _, t := range tasks {
+		r.adopt(t)
+	}
+	for i := 0; i <= days; i++ {
+		r.loaded[start.AddDate(0, 0, i).Format(dateKeyFormat)] = true
+	}
+	return nil
+}
+
+// around loads each date and its neighbours, which overnight blocks can run
+// into, so overlap checks see every block. Callers must hold r.mu.
+func (r *Repo) around(ctx context.Context, dates ...time.Time) error {
+	for _, d := range dates {
+		if err := r.ensureLoaded(ctx, d.AddDate(0, 0, -1), d.AddDate(0, 0, 1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adopt copies a base task into the store unless it is already known.
+// Callers must hold r.mu.
+func (r *Repo) adopt(t *task.Task) {
+	if _, ok := r.original[t.ID]; ok {
+		return
+	}
+	r.original[t.ID] = *t
+	r.store.Load(t)
+}
+
+// fetch returns the task with id, copying it from base if needed together
+// with the days around it. It returns nil without an error when the task
+// does not exist. Callers must hold r.mu.
+func (r *Repo) fetch(ctx context.Context, id int64) (*task.Task, error) {
+	t, err := r.store.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil && id > 0 {
+		if t, err = r.base.GetTask(ctx, id); err != nil {
+			return nil, fmt.Errorf("getting task: %w", err)
+		}
+		if t != nil {
+			r.adopt(t)
+		}
+	}
+	if t == nil {
+		return nil, nil
+	}
+	return t, r.around(ctx, t.ScheduledDate)
+}
+
+// fetchRef copies the task with an external reference from base unless the
+// store already has it. Callers must hold r.mu.
+func (r *Repo) fetchRef(ctx context.Context, ref task.ExternalRef) error {
+	if ref.IsZero() {
+		return task.ErrMissingExternalRef
+	}
+	if t, err := r.store.GetTaskByExternalRef(ctx, ref); err != nil || t != nil {
+		return err
+	}
+	t, err := r.base.GetTaskByExternalRef(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("getting task by external reference: %w", err)
+	}
+	if t == nil {
+		return nil
+	}
+	if _, ok := r.original[t.ID]; ok {
+		// Moved to another task in the sandbox, e.g. by a postpone
+		return nil
+	}
+	r.adopt(t)
+	return r.around(ctx, t.ScheduledDate)
+}
+
+// CreateTask adds a new task to the sandbox.
+func (r *Repo) CreateTask(ctx context.Context, t *task.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.around(ctx, t.ScheduledDate); err != nil {
+		return err
+	}
+	return r.store.CreateTask(ctx, t)
+}
+
+// GetTask retrieves a task by ID. Like the SQLite repository it returns nil
+// without an error when the task does not exist.
+func (r *Repo) GetTask(ctx context.Context, id int64) (*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.fetch(ctx, id)
+}
+
+// GetTaskByExternalRef retrieves the task linked to an external reference,
+// asking the base repository when no sandbox task has it.
+func (r *Repo) GetTaskByExternalRef(ctx context.Context, ref task.ExternalRef) (*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.fetchRef(ctx, ref); err != nil {
+		return nil, err
+	}
+	return r.store.GetTaskByExternalRef(ctx, ref)
+}
+
+// UpsertTask creates the task, or updates the task with the same external
+// reference in place, keeping its status and outcome.
+func (r *Repo) UpsertTask(ctx context.Context, t *task.Task) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.fetchRef(ctx, t.ExternalRef); err != nil {
+		return false, err
+	}
+	if err := r.around(ctx, t.ScheduledDate); err != nil {
+		return false, err
+	}
+	return r.store.UpsertTask(ctx, t)
+}
+
+// CancelTask marks a task as cancelled.
+func (r *Repo) CancelTask(ctx context.Context, id, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.fetch(ctx, id); err != nil {
+		return err
+	}
+	return r.store.CancelTask(ctx, id, version)
+}
+
+// SetTaskOutcome sets the outcome of a task.
+func (r *Repo) SetTaskOutcome(ctx context.Context, id int64, outcome task.Outcome, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.fetch(ctx, id); err != nil {
+		return err
+	}
+	return r.store.SetTaskOutcome(ctx, id, outcome, version)
+}
+
+// SetTaskEnergy sets the energy level of a task.
+func (r *Repo) SetTaskEnergy(ctx context.Context, id int64, energy task.Energy, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.fetch(ctx, id); err != nil {
+		return err
+	}
+	return r.store.SetTaskEnergy(ctx, id, energy, version)
+}
+
+// ListTasksByDateRange returns all tasks scheduled within the date range (inclusive).
+func (r *Repo) ListTasksByDateRange(ctx context.Context, start, end time.Time) ([]*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.ensureLoaded(ctx, start, end); err != nil {
+		return nil, err
+	}
+	return r.store.ListTasksByDateRange(ctx, start, end)
+}
+
+// CreateTasks adds multiple tasks; nothing is added if any of them overlaps.
+func (r *Repo) CreateTasks(ctx context.Context, tasks []*task.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range tasks {
+		if err := r.around(ctx, t.ScheduledDate); err != nil {
+			return err
+		}
+	}
+	return r.store.CreateTasks(ctx, tasks)
+}
+
+// PostponeTask marks the original task as postponed and creates a new task.
+func (r *Repo) PostponeTask(ctx context.Context, taskID int64, newDate time.Time, newStart, newEnd string, version int64) (*task.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.fetch(ctx, taskID); err != nil {
+		return nil, err
+	}
+	if err := r.around(ctx, newDate); err != nil {
+		return nil, err
+	}
+	return r.store.PostponeTask(ctx, taskID, newDate, newStart, newEnd, version)
+}
+
+// UpdateTask updates a task's scheduled times in place.
+func (r *Repo) UpdateTask(ctx context.Context, id int64, newStart, newEnd string, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.fetch(ctx, id); err != nil {
+		return err
+	}
+	return r.store.UpdateTask(ctx, id, newStart, newEnd, version)
+}
+
+// UpdateTaskDescription updates a task description in place.
+func (r *Repo) UpdateTaskDescription(ctx context.Context, id int64, description string, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.fetch(ctx, id); err != nil {
+		return err
+	}
+	return r.store.UpdateTaskDescription(ctx, id, description, version)
+}
+
+// BatchUpdateTaskTimes moves the given tasks to new times on date, or on an
+// update's own Date, validating that the days have no overlaps afterwards.
+func (r *Repo) BatchUpdateTaskTimes(ctx context.Context, date time.Time, updates []task.TaskTimeUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, u := range updates {
+		if _, err := r.fetch(ctx, u.ID); err != nil {
+			return fmt.Errorf("task %d: %w", u.ID, err)
+		}
+		if err := r.around(ctx, u.DateOr(date)); err != nil {
+			return err
+		}
+	}
+	return r.store.BatchUpdateTaskTimes(ctx, date, updates)
+}
+
+// BatchUpdate cancels, postpones, moves or recategorizes several tasks.
+// Overlaps are checked once every update is applied; on failure the sandbox
+// is left as it was.
+func (r *Repo) BatchUpdate(ctx context.Context, updates []task.TaskUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, u := range updates {
+		t, err := r.fetch(ctx, u.ID)
+		if err != nil {
+			return fmt.Errorf("task %d: %w", u.ID, err)
+		}
+		if t == nil {
+			continue // Reported by the store
+		}
+		moved := u.Apply(*t)
+		if err := r.around(ctx, moved.ScheduledDate); err != nil {
+			return err
+		}
+	}
+	return r.store.BatchUpdate(ctx, updates)
+}
+
+// Close is a no-op; the base repository is owned by the caller.
+func (r *Repo) Close() error {
+	return nil
+}
+
+func sortTasks(tasks []*task.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if d := task.CalendarDaysBetween(tasks[j].ScheduledDate, tasks[i].ScheduledDate); d != 0 {
+			return d < 0
+		}
+		return tasks[i].ScheduledStart < tasks[j].ScheduledStart
+	})
+}
@@ -0,0 +1,277 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func newBaseRepo(t *testing.T, tasks ...*task.Task) *db.SQLite {
+	t.Helper()
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create base repo: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	if len(tasks) > 0 {
+		if err := repo.CreateTasks(context.Background(), tasks); err != nil {
+			t.Fatalf("seeding base repo: %v", err)
+		}
+	}
+	return repo
+}
+
+func scheduled(desc string, date time.Time, start, end string) *task.Task {
+	return &task.Task{
+		Description:    desc,
+		Category:       task.CategoryDeep,
+		ScheduledDate:  date,
+		ScheduledStart: start,
+		ScheduledEnd:   end,
+		Status:         task.StatusScheduled,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func TestSandboxDoesNotTouchBase(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	a := scheduled("Write report", monday, "09:00", "10:00")
+	base := newBaseRepo(t, a)
+
+	sb, err := New(ctx, base, monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := sb.CancelTask(ctx, a.ID, 0); err != nil {
+		t.Fatalf("CancelTask: %v", err)
+	}
+	if err := sb.CreateTask(ctx, scheduled("Sandbox only", monday, "11:00", "12:00")); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	tasks, err := sb.ListTasksByDateRange(ctx, monday, monday)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Status != task.StatusCancelled || tasks[1].ID >= 0 {
+		t.Fatalf("unexpected sandbox tasks: %+v", tasks)
+	}
+
+	baseTasks, err := base.ListTasksByDateRange(ctx, monday, monday)
+	if err != nil {
+		t.Fatalf("base ListTasksByDateRange: %v", err)
+	}
+	if len(baseTasks) != 1 || baseTasks[0].Status != task.StatusScheduled {
+		t.Fatalf("base was modified: %+v", baseTasks)
+	}
+}
+
+func TestSandboxLoadsOtherWeeksLazily(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	later := scheduled("Later", monday.AddDate(0, 0, 21), "09:00", "10:00")
+	base := newBaseRepo(t, later)
+
+	sb, err := New(ctx, base, monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	tasks, err := sb.ListTasksByDateRange(ctx, later.ScheduledDate, later.ScheduledDate)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != later.ID {
+		t.Fatalf("expected lazily loaded task, got %+v", tasks)
+	}
+	if !sb.Changes().Empty() {
+		t.Fatalf("loading should not create changes: %s", sb.Changes().Summary())
+	}
+}
+
+func TestSandboxOverlap(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	a := scheduled("A", monday, "09:00", "10:00")
+	b := scheduled("B", monday, "10:00", "11:00")
+	base := newBaseRepo(t, a, b)
+
+	sb, err := New(ctx, base, monday, monday)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := sb.CreateTask(ctx, scheduled("C", monday, "09:30", "10:30")); !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Errorf("CreateTask overlap: got %v", err)
+	}
+	err = sb.BatchUpdateTaskTimes(ctx, monday, []task.TaskTimeUpdate{{ID: a.ID, NewStart: "10:30", NewEnd: "11:30"}})
+	if !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Errorf("BatchUpdateTaskTimes overlap: got %v", err)
+	}
+	// Swapping both blocks is fine
+	err = sb.BatchUpdateTaskTimes(ctx, monday, []task.TaskTimeUpdate{
+		{ID: a.ID, NewStart: "10:00", NewEnd: "11:00"},
+		{ID: b.ID, NewStart: "09:00", NewEnd: "10:00"},
+	})
+	if err != nil {
+		t.Errorf("BatchUpdateTaskTimes swap: %v", err)
+	}
+}
+
+func TestSandboxOverlapLoadsUnseenDays(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	later := scheduled("Later", monday.AddDate(0, 0, 14), "09:00", "10:00")
+	base := newBaseRepo(t, later)
+
+	sb, err := New(ctx, base, monday, monday)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	err = sb.CreateTask(ctx, scheduled("Clash", later.ScheduledDate, "09:30", "10:30"))
+	if !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Errorf("CreateTask on a day not listed yet: got %v, want ErrTimeBlockOverlap", err)
+	}
+}
+
+func TestSandboxApply(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+	move := scheduled("Move me", monday, "09:00", "10:00")
+	cancel := scheduled("Cancel me", monday, "11:00", "12:00")
+	postpone := scheduled("Postpone me", monday, "14:00", "15:00")
+	rename := scheduled("Rename me", monday, "16:00", "17:00")
+	base := newBaseRepo(t, move, cancel, postpone, rename)
+
+	sb, err := New(ctx, base, monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := sb.BatchUpdateTaskTimes(ctx, monday, []task.TaskTimeUpdate{{ID: move.ID, NewStart: "09:30", NewEnd: "10:30"}}); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if err := sb.CancelTask(ctx, cancel.ID, 0); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	// Postpone twice; only the final slot should reach the base repo
+	first, err := sb.PostponeTask(ctx, postpone.ID, tuesday, "09:00", "10:00", 0)
+	if err != nil {
+		t.Fatalf("postpone: %v", err)
+	}
+	if _, err := sb.PostponeTask(ctx, first.ID, tuesday, "13:00", "14:00", 0); err != nil {
+		t.Fatalf("postpone again: %v", err)
+	}
+	if err := sb.UpdateTaskDescription(ctx, rename.ID, "Renamed", 0); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if err := sb.CreateTasks(ctx, []*task.Task{scheduled("New", tuesday, "15:00", "16:00")}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	discarded := scheduled("Created then cancelled", tuesday, "16:00", "17:00")
+	if err := sb.CreateTask(ctx, discarded); err != nil {
+		t.Fatalf("create discarded: %v", err)
+	}
+	if err := sb.CancelTask(ctx, discarded.ID, 0); err != nil {
+		t.Fatalf("cancel discarded: %v", err)
+	}
+
+	changes, err := sb.Apply(ctx, base)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got, want := changes.Summary(), "1 moved, 1 created, 1 postponed, 1 cancelled, 1 renamed"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+
+	byDesc := func(date time.Time) map[string]*task.Task {
+		tasks, err := base.ListTasksByDateRange(ctx, date, date)
+		if err != nil {
+			t.Fatalf("ListTasksByDateRange: %v", err)
+		}
+		m := make(map[string]*task.Task)
+		for _, tk := range tasks {
+			m[tk.Description+"/"+string(tk.Status)] = tk
+		}
+		return m
+	}
+
+	mon := byDesc(monday)
+	if got := mon["Move me/scheduled"]; got == nil || got.ScheduledStart != "09:30" {
+		t.Errorf("moved task: %+v", got)
+	}
+	if mon["Cancel me/cancelled"] == nil {
+		t.Error("expected cancelled task")
+	}
+	if mon["Postpone me/postponed"] == nil {
+		t.Error("expected original task to be postponed")
+	}
+	if mon["Renamed/scheduled"] == nil {
+		t.Error("expected renamed task")
+	}
+
+	tue := byDesc(tuesday)
+	if got := tue["Postpone me/scheduled"]; got == nil || got.ScheduledStart != "13:00" || got.PostponedFrom == nil || *got.PostponedFrom != postpone.ID {
+		t.Errorf("postponed copy: %+v", got)
+	}
+	if tue["New/scheduled"] == nil {
+		t.Error("expected created task")
+	}
+	if len(tue) != 2 {
+		t.Errorf("tuesday tasks = %d, want 2: %v", len(tue), tue)
+	}
+}
+
+func TestSandboxBatchUpdate(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	a := scheduled("Write report", monday, "09:00", "10:00")
+	b := scheduled("Review PRs", monday, "10:00", "11:00")
+	base := newBaseRepo(t, a, b)
+
+	sb, err := New(ctx, base, monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// A failed batch leaves the sandbox untouched
+	err = sb.BatchUpdate(ctx, []task.TaskUpdate{
+		{ID: a.ID, Category: task.CategoryShallow},
+		{ID: a.ID, Start: "10:30", End: "11:30"},
+	})
+	if !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Fatalf("BatchUpdate error = %v, want ErrTimeBlockOverlap", err)
+	}
+	if got := sb.Changes(); !got.Empty() {
+		t.Fatalf("changes after failed batch = %s, want none", got.Summary())
+	}
+
+	err = sb.BatchUpdate(ctx, []task.TaskUpdate{
+		{ID: a.ID, Start: "10:00", End: "11:00", Category: task.CategoryShallow},
+		{ID: b.ID, Start: "11:00", End: "12:00"},
+	})
+	if err != nil {
+		t.Fatalf("BatchUpdate: %v", err)
+	}
+	if got, want := sb.Changes().Summary(), "2 moved, 1 recategorized"; got != want {
+		t.Fatalf("Summary = %q, want %q", got, want)
+	}
+
+	if _, err := sb.Apply(ctx, base); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	got, err := base.GetTask(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if got.ScheduledStart != "10:00" || got.Category != task.CategoryShallow {
+		t.Errorf("applied task = %s %s, want 10:00 shallow", got.ScheduledStart, got.Category)
+	}
+}
@@ -0,0 +1,305 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// DefaultAutoDays is how far ahead AutoSchedule looks for free time.
+const DefaultAutoDays = 7
+
+const (
+	defaultAutoBuffer = 15
+	defaultMorningEnd = "12:00"
+	autoStepMinutes   = 15
+)
+
+// AutoItem is a task to be placed by AutoSchedule.
+type AutoItem struct {
+	Description string
+	Category    string // "deep", "shallow" or a configured category
+	Minutes     int
+}
+
+// isDeep reports whether the item is deep work. Custom categories count as
+// shallow, as they do in the deep/shallow totals.
+func (i AutoItem) isDeep() bool {
+	return i.Category == string(task.CategoryDeep)
+}
+
+// Busy is an existing time block that AutoSchedule must not overlap.
+type Busy struct {
+	Date  time.Time
+	Start string // "HH:MM"
+	End   string // "HH:MM"
+}
+
+// BusyFromTasks returns the time blocks held by scheduled tasks.
+// All-day and unscheduled tasks keep no time busy.
+func BusyFromTasks(tasks []*task.Task) []Busy {
+	var busy []Busy
+	for _, t := range tasks {
+		if !t.IsScheduled() || t.IsAllDay() {
+			continue
+		}
+		busy = append(busy, Busy{
+			Date:  t.ScheduledDate,
+			Start: t.ScheduledStart,
+			End:   t.ScheduledEnd,
+		})
+	}
+	return busy
+}
+
+// AutoOptions tunes AutoSchedule.
+type AutoOptions struct {
+	Now           time.Time // Reference time, required; nothing is placed before it
+	Days          int       // Days to look ahead (default 7)
+	BufferMinutes int       // Gap kept between blocks (default 15, negative for none)
+	MorningEnd    string    // Deep work is preferred before this time (default "12:00")
+}
+
+// Placement is a scheduled position for an AutoItem.
+type Placement struct {
+	Item  AutoItem
+	Date  time.Time
+	Start string // "HH:MM"
+	End   string // "HH:MM"
+}
+
+// AutoSchedule places items deterministically without an LLM.
+//
+// Rules:
+//   - Deep items are placed before shallow ones, longest first.
+//   - Deep work prefers mornings (before MorningEnd), shallow work afternoons.
+//     If no preferred slot exists in the look-ahead window, the earliest
+//     slot anywhere in working hours is used.
+//   - Each block keeps BufferMinutes free on both sides, except at the edges
+//     of the working day.
+//
+// Items that do not fit anywhere are returned as unplaced.
+func (s *Scheduler) AutoSchedule(items []AutoItem, busy []Busy, opts AutoOptions) (placed []Placement, unplaced []AutoItem) {
+	opts = opts.withDefaults()
+
+	days := s.autoDays(opts)
+	blocks := make(map[string][]span)
+	for _, b := range busy {
+		key := b.Date.Format("2006-01-02")
+		blocks[key] = append(blocks[key], span{start: parseTime(b.Start), end: parseTime(b.End)})
+	}
+
+	order := make([]int, len(items))
+	for i := range items {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ia, ib := items[order[a]], items[order[b]]
+		da, db := ia.isDeep(), ib.isDeep()
+		if da != db {
+			return da
+		}
+		return ia.Minutes > ib.Minutes
+	})
+
+	morningEnd := parseTime(opts.MorningEnd)
+	for _, idx := range order {
+		item := items[idx]
+		minutes := roundUpMinutes(item.Minutes)
+		if minutes <= 0 {
+			unplaced = append(unplaced, item)
+			continue
+		}
+
+		preferMorning := item.isDeep()
+		day, start, ok := s.findSlot(days, blocks, minutes, opts.BufferMinutes, func(start, end int) bool {
+			if preferMorning {
+				return end <= morningEnd
+			}
+			return start >= morningEnd
+		})
+		if !ok {
+			day, start, ok = s.findSlot(days, blocks, minutes, opts.BufferMinutes, nil)
+		}
+		if !ok {
+			unplaced = append(unplaced, item)
+			continue
+		}
+
+		key := day.date.Format("2006-01-02")
+		blocks[key] = append(blocks[key], span{start: start, end: start + minutes})
+		placed = append(placed, Placement{
+			Item:  item,
+			Date:  day.date,
+			Start: formatMinutes(start),
+			End:   formatMinutes(start + minutes),
+		})
+	}
+
+	sort.SliceStable(placed, func(a, b int) bool {
+		if !placed[a].Date.Equal(placed[b].Date) {
+			return placed[a].Date.Before(placed[b].Date)
+		}
+		return placed[a].Start < placed[b].Start
+	})
+
+	return placed, unplaced
+}
+
+type span struct {
+	start int
+	end   int
+}
+
+// FirstFit returns the earliest start on date where a block of the given
+// length fits within working hours without overlapping busy, keeping buffer
+// minutes free around other blocks. On today's date nothing starts before now.
+func (s *Scheduler) FirstFit(date time.Time, busy []Busy, minutes, buffer int, now time.Time) (string, bool) {
+	day := s.usableDay(date, now)
+	key := date.Format("2006-01-02")
+	blocks := map[string][]span{key: busySpans(date, busy)}
+	_, start, ok := s.findSlot([]autoDay{day}, blocks, minutes, buffer, nil)
+	if !ok {
+		return "", false
+	}
+	return formatMinutes(start), true
+}
+
+// FitsAt reports whether a block of the given length can start at start on
+// date under the same rules as FirstFit.
+func (s *Scheduler) FitsAt(date time.Time, busy []Busy, start string, minutes, buffer int, now time.Time) bool {
+	day := s.usableDay(date, now)
+	begin := parseTime(start)
+	if begin < day.start || begin+minutes > day.end {
+		return false
+	}
+	return fitsWithBuffer(begin, begin+minutes, busySpans(date, busy), buffer)
+}
+
+// FreeMinutes returns how many working minutes on date are not covered by
+// busy. On today's date only the time after now counts.
+func (s *Scheduler) FreeMinutes(date time.Time, busy []Busy, now time.Time) int {
+	day := s.usableDay(date, now)
+	spans := busySpans(date, busy)
+	free := 0
+	for minute := day.start; minute < day.end; minute++ {
+		if fitsWithBuffer(minute, minute+1, spans, 0) {
+			free++
+		}
+	}
+	return free
+}
+
+// usableDay returns date's working hours, starting no earlier than now.
+func (s *Scheduler) usableDay(date, now time.Time) autoDay {
+	day := autoDay{date: date, start: parseTime(s.dayStart), end: parseTime(s.dayEnd)}
+	if y, m, d := date.Date(); y == now.Year() && m == now.Month() && d == now.Day() {
+		day.start = max(day.start, now.Hour()*60+now.Minute())
+	}
+	return day
+}
+
+// busySpans returns the busy blocks that fall on date.
+func busySpans(date time.Time, busy []Busy) []span {
+	key := date.Format("2006-01-02")
+	var spans []span
+	for _, b := range busy {
+		if b.Date.Format("2006-01-02") == key {
+			spans = append(spans, span{start: parseTime(b.Start), end: parseTime(b.End)})
+		}
+	}
+	return spans
+}
+
+type autoDay struct {
+	date  time.Time
+	start int // First usable minute
+	end   int // Day end minute
+}
+
+func (o AutoOptions) withDefaults() AutoOptions {
+	if o.Days <= 0 {
+		o.Days = DefaultAutoDays
+	}
+	switch {
+	case o.BufferMinutes == 0:
+		o.BufferMinutes = defaultAutoBuffer
+	case o.BufferMinutes < 0:
+		o.BufferMinutes = 0
+	}
+	if o.MorningEnd == "" {
+		o.MorningEnd = defaultMorningEnd
+	}
+	return o
+}
+
+// autoDays lists the workdays in the look-ahead window with their usable range.
+func (s *Scheduler) autoDays(opts AutoOptions) []autoDay {
+	first := s.NextAvailableStart(opts.Now)
+	firstDate := time.Date(first.Date.Year(), first.Date.Month(), first.Date.Day(), 0, 0, 0, 0, first.Date.Location())
+	dayStart := parseTime(s.dayStart)
+	dayEnd := parseTime(s.dayEnd)
+
+	var days []autoDay
+	for i := 0; i < opts.Days; i++ {
+		date := firstDate.AddDate(0, 0, i)
+		if !s.IsWorkday(date) {
+			continue
+		}
+		start := dayStart
+		if i == 0 {
+			start = parseTime(first.Start)
+		}
+		if start < dayEnd {
+			days = append(days, autoDay{date: date, start: start, end: dayEnd})
+		}
+	}
+	return days
+}
+
+// findSlot returns the earliest slot of the given length that keeps the
+// buffer from other blocks and satisfies accept (if non-nil).
+func (s *Scheduler) findSlot(days []autoDay, blocks map[string][]span, minutes, buffer int, accept func(start, end int) bool) (autoDay, int, bool) {
+	for _, day := range days {
+		existing := blocks[day.date.Format("2006-01-02")]
+		for start := alignUp(day.start); start+minutes <= day.end; start += autoStepMinutes {
+			end := start + minutes
+			if accept != nil && !accept(start, end) {
+				continue
+			}
+			if fitsWithBuffer(start, end, existing, buffer) {
+				return day, start, true
+			}
+		}
+	}
+	return autoDay{}, 0, false
+}
+
+func fitsWithBuffer(start, end int, existing []span, buffer int) bool {
+	for _, b := range existing {
+		if start < b.end+buffer && b.start-buffer < end {
+			return false
+		}
+	}
+	return true
+}
+
+func alignUp(minutes int) int {
+	if rem := minutes % autoStepMinutes; rem != 0 {
+		return minutes + autoStepMinutes - rem
+	}
+	return minutes
+}
+
+func roundUpMinutes(minutes int) int {
+	if minutes <= 0 {
+		return 0
+	}
+	return alignUp(minutes)
+}
+
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoSchedule_DeepMorningShallowAfternoon(t *testing.T) {
+	s := New([]string{"monday", "tuesday", "wednesday", "thursday", "friday"}, "09:00", "17:00")
+	now := time.Date(2025, 1, 6, 7, 30, 0, 0, time.Local) // Monday
+
+	items := []AutoItem{
+		{Description: "email", Category: "shallow", Minutes: 30},
+		{Description: "report", Category: "deep", Minutes: 120},
+		{Description: "review", Category: "deep", Minutes: 45},
+	}
+	placed, unplaced := s.AutoSchedule(items, nil, AutoOptions{Now: now})
+
+	if len(unplaced) != 0 {
+		t.Fatalf("expected all items placed, got unplaced %v", unplaced)
+	}
+
+	want := []struct {
+		desc, start, end string
+	}{
+		{"report", "09:00", "11:00"},
+		{"review", "11:15", "12:00"},
+		{"email", "12:15", "12:45"},
+	}
+	if len(placed) != len(want) {
+		t.Fatalf("expected %d placements, got %d", len(want), len(placed))
+	}
+	for i, w := range want {
+		p := placed[i]
+		if p.Item.Description != w.desc || p.Start != w.start || p.End != w.end {
+			t.Errorf("placement %d: got %s %s-%s, want %s %s-%s", i, p.Item.Description, p.Start, p.End, w.desc, w.start, w.end)
+		}
+		if p.Date.Day() != 6 {
+			t.Errorf("placement %d: expected Monday 6, got %d", i, p.Date.Day())
+		}
+	}
+}
+
+func TestAutoSchedule_CustomCategoryIsShallow(t *testing.T) {
+	s := New([]string{"monday", "tuesday", "wednesday", "thursday", "friday"}, "09:00", "17:00")
+	now := time.Date(2025, 1, 6, 7, 30, 0, 0, time.Local) // Monday
+
+	items := []AutoItem{
+		{Description: "standup", Category: "meeting", Minutes: 120},
+		{Description: "report", Category: "deep", Minutes: 60},
+	}
+	placed, unplaced := s.AutoSchedule(items, nil, AutoOptions{Now: now})
+	if len(unplaced) != 0 || len(placed) != 2 {
+		t.Fatalf("placed %d, unplaced %v; want both placed", len(placed), unplaced)
+	}
+	if placed[0].Item.Description != "report" || placed[0].Start != "09:00" {
+		t.Errorf("first placement = %s at %s, want report at 09:00", placed[0].Item.Description, placed[0].Start)
+	}
+	if placed[1].Item.Description != "standup" || placed[1].Start != "12:00" {
+		t.Errorf("second placement = %s at %s, want standup in the afternoon at 12:00", placed[1].Item.Description, placed[1].Start)
+	}
+}
+
+func TestAutoSchedule_AvoidsBusyWithBuffer(t *testing.T) {
+	s := New([]string{"monday", "tuesday", "wednesday", "thursday", "friday"}, "09:00", "17:00")
+	now := time.Date(2025, 1, 6, 7, 30, 0, 0, time.Local) // Monday
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+
+	busy := []Busy{{Date: monday, Start: "09:30", End: "10:30"}}
+	placed, _ := s.AutoSchedule([]AutoItem{{Description: "focus", Category: "deep", Minutes: 60}}, busy, AutoOptions{Now: now})
+
+	if len(placed) != 1 {
+		t.Fatalf("expected 1 placement, got %d", len(placed))
+	}
+	if placed[0].Start != "10:45" || placed[0].End != "11:45" {
+		t.Errorf("expected 10:45-11:45, got %s-%s", placed[0].Start, placed[0].End)
+	}
+}
+
+func TestFirstFit(t *testing.T) {
+	s := New([]string{"monday", "tuesday", "wednesday", "thursday", "friday"}, "09:00", "17:00")
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	busy := []Busy{
+		{Date: monday, Start: "09:00", End: "10:00"},
+		{Date: monday.AddDate(0, 0, 1), Start: "10:00", End: "12:00"}, // Other day, ignored
+	}
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		minutes int
+		buffer  int
+		want    string
+		ok      bool
+	}{
+		{"before the day", monday.Add(-time.Hour), 60, 0, "10:00", true},
+		{"with buffer", monday.Add(-time.Hour), 60, 15, "10:15", true},
+		{"from now", monday.Add(13*time.Hour + 5*time.Minute), 30, 0, "13:15", true},
+		{"too long", monday.Add(16 * time.Hour), 90, 0, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := s.FirstFit(monday, busy, tt.minutes, tt.buffer, tt.now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: FirstFit = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFitsAtAndFreeMinutes(t *testing.T) {
+	s := New([]string{"monday", "tuesday", "wednesday", "thursday", "friday"}, "09:00", "17:00")
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	busy := []Busy{
+		{Date: monday, Start: "09:00", End: "10:00"},
+		{Date: monday, Start: "09:30", End: "10:30"}, // Overlapping blocks count once
+		{Date: monday.AddDate(0, 0, 1), Start: "10:00", End: "12:00"},
+	}
+	before := monday.Add(-time.Hour)
+
+	if s.FitsAt(monday, busy, "10:00", 30, 0, before) {
+		t.Error("10:00 overlaps the second block")
+	}
+	if !s.FitsAt(monday, busy, "10:30", 30, 0, before) {
+		t.Error("10:30 should be free")
+	}
+	if s.FitsAt(monday, busy, "16:30", 60, 0, before) {
+		t.Error("a block past day end should not fit")
+	}
+	if got := s.FreeMinutes(monday, busy, before); got != 390 {
+		t.Errorf("FreeMinutes = %d, want 390", got)
+	}
+	if got := s.FreeMinutes(monday, busy, monday.Add(16*time.Hour)); got != 60 {
+		t.Errorf("FreeMinutes from 16:00 = %d, want 60", got)
+	}
+}
+
+func TestAutoSchedule_SpillsToNextWorkday(t *testing.T) {
+	s := New([]string{"monday", "tuesday", "wednesday", "thursday", "friday"}, "09:00", "17:00")
+	now := time.Date(2025, 1, 10, 16, 0, 0, 0, time.Local) // Friday afternoon
+
+	placed, unplaced := s.AutoSchedule([]AutoItem{{Description: "plan", Category: "deep", Minutes: 90}}, nil, AutoOptions{Now: now})
+
+	if len(unplaced) != 0 || len(placed) != 1 {
+		t.Fatalf("expected 1 placement, got placed=%d unplaced=%d", len(placed), len(unplaced))
+	}
+	if placed[0].Date.Weekday() != time.Monday || placed[0].Start != "09:00" {
+		t.Errorf("expected Monday 09:00, got %s %s", placed[0].Date.Weekday(), placed[0].Start)
+	}
+}
+
+func TestAutoSchedule_Unplaced(t *testing.T) {
+	s := New([]string{"monday"}, "09:00", "10:00")
+	now := time.Date(2025, 1, 6, 7, 30, 0, 0, time.Local) // Monday
+
+	items := []AutoItem{{Description: "too long", Category: "deep", Minutes: 120}}
+	placed, unplaced := s.AutoSchedule(items, nil, AutoOptions{Now: now, Days: 3})
+
+	if len(placed) != 0 {
+		t.Errorf("expected no placements, got %d", len(placed))
+	}
+	if len(unplaced) != 1 || unplaced[0].Description != "too long" {
+		t.Errorf("expected 'too long' unplaced, got %v", unplaced)
+	}
+}
+
+func TestAutoSchedule_Deterministic(t *testing.T) {
+	s := New([]string{"monday", "tuesday", "wednesday", "thursday", "friday"}, "09:00", "17:00")
+	now := time.Date(2025, 1, 6, 10, 7, 0, 0, time.Local)
+	items := []AutoItem{
+		{Description: "a", Category: "deep", Minutes: 60},
+		{Description: "b", Category: "deep", Minutes: 60},
+		{Description: "c", Category: "shallow", Minutes: 20},
+	}
+
+	first, _ := s.AutoSchedule(items, nil, AutoOptions{Now: now})
+	second, _ := s.AutoSchedule(items, nil, AutoOptions{Now: now})
+	if len(first) != len(second) {
+		t.Fatalf("placement counts differ: %d vs %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i].Item != second[i].Item || first[i].Start != second[i].Start || !first[i].Date.Equal(second[i].Date) {
+			t.Errorf("placement %d differs: %+v vs %+v", i, first[i], second[i])
+		}
+	}
+	if first[0].Item.Description != "a" || first[0].Start != "10:15" {
+		t.Errorf("expected a at 10:15 first, got %s at %s", first[0].Item.Description, first[0].Start)
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/javiermolinar/sancho/internal/nlp"
+)
+
+// DefaultAutoMinutes is used for auto-plan items without an explicit duration.
+const DefaultAutoMinutes = 60
+
+// ErrNoAutoItems is returned when auto-plan input contains no tasks.
+var ErrNoAutoItems = errors.New("no tasks to schedule")
+
+// autoDurationRe matches a duration token such as "90m", "45min", "2h",
+// "1hr" or "1h30m".
+var autoDurationRe = regexp.MustCompile(`^(?:(\d+)(?:h|hr|hrs))?(?:(\d+)(?:m|min|mins))?$`)
+
+// autoUnitRe matches a unit written apart from its number, as in "30 min".
+var autoUnitRe = regexp.MustCompile(`^(?:h|hr|hrs|m|min|mins)$`)
+
+// ParseAutoItems parses auto-plan input into schedulable items.
+//
+// Items are separated by ",", ";" or newlines. Each item may contain a duration
+// token (e.g. "90m", "45 min", "1h", "1h30m") and a category token ("deep",
+// "shallow" or "#" and any category); the remaining words form the
+// description. Items default to deep work and one hour.
+//
+// Example: "write report 2h; email triage 30m shallow; review PR 45m"
+func ParseAutoItems(input string) ([]AutoItem, error) {
+	var items []AutoItem
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+
+		item := AutoItem{Category: "deep", Minutes: DefaultAutoMinutes}
+		var words []string
+		for i := 0; i < len(fields); i++ {
+			lower := strings.ToLower(fields[i])
+			if c, ok := nlp.CategoryToken(lower); ok {
+				item.Category = string(c)
+				continue
+			}
+			if i+1 < len(fields) && autoUnitRe.MatchString(strings.ToLower(fields[i+1])) {
+				if minutes, ok := parseAutoDuration(lower + strings.ToLower(fields[i+1])); ok {
+					item.Minutes = minutes
+					i++
+					continue
+				}
+			}
+			if minutes, ok := parseAutoDuration(strings.TrimPrefix(lower, "#")); ok {
+				item.Minutes = minutes
+				continue
+			}
+			words = append(words, fields[i])
+		}
+
+		if len(words) == 0 {
+			return nil, fmt.Errorf("missing description in %q", strings.TrimSpace(part))
+		}
+		item.Description = strings.Join(words, " ")
+		items = append(items, item)
+	}
+
+	if len(items) == 0 {
+		return nil, ErrNoAutoItems
+	}
+	return items, nil
+}
+
+// parseAutoDuration parses a lowercased duration token into minutes.
+func parseAutoDuration(s string) (int, bool) {
+	m := autoDurationRe.FindStringSubmatch(s)
+	if m == nil || (m[1] == "" && m[2] == "") {
+		return 0, false
+	}
+	minutes := 0
+	if m[1] != "" {
+		hours, _ := strconv.Atoi(m[1])
+		minutes += hours * 60
+	}
+	if m[2] != "" {
+		mins, _ := strconv.Atoi(m[2])
+		minutes += mins
+	}
+	if minutes <= 0 {
+		return 0, false
+	}
+	return minutes, true
+}
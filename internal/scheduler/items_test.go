@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAutoItems(t *testing.T) {
+	items, err := ParseAutoItems("write report 2h; email triage 30min shallow\nreview PR #deep 1h15m; call mom; fix login 45 min; sign in 1hr")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		desc     string
+		category string
+		minutes  int
+	}{
+		{"write report", "deep", 120},
+		{"email triage", "shallow", 30},
+		{"review PR", "deep", 75},
+		{"call mom", "deep", DefaultAutoMinutes},
+		{"fix login", "deep", 45},
+		{"sign in", "deep", 60},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("expected %d items, got %d", len(want), len(items))
+	}
+	for i, w := range want {
+		if items[i].Description != w.desc || items[i].Category != w.category || items[i].Minutes != w.minutes {
+			t.Errorf("item %d: got %+v, want %+v", i, items[i], w)
+		}
+	}
+}
+
+func TestParseAutoDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"90m", 90, true},
+		{"45min", 45, true},
+		{"2h", 120, true},
+		{"1hr", 60, true},
+		{"1h30m", 90, true},
+		{"10in", 0, false},
+		{"in", 0, false},
+		{"0m", 0, false},
+		{"h", 0, false},
+		{"2d", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseAutoDuration(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseAutoDuration(%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseAutoItems_Errors(t *testing.T) {
+	if _, err := ParseAutoItems(" ; "); !errors.Is(err, ErrNoAutoItems) {
+		t.Errorf("expected ErrNoAutoItems, got %v", err)
+	}
+	if _, err := ParseAutoItems("2h shallow"); err == nil {
+		t.Error("expected error for item without description")
+	}
+}
@@ -207,13 +207,20 @@ func (s *Scheduler) CanFitAnyDay(startTime string, durationMinutes int) bool {
 }
 
 // roundUpTo15Min rounds a time up to the next 15-minute boundary.
+// Rounding is done on the wall clock so that a DST fall-back never moves the
+// result back into the repeated hour.
 func roundUpTo15Min(t time.Time) time.Time {
 	minute := t.Minute()
 	remainder := minute % 15
 	if remainder == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
 		return t
 	}
-	return t.Add(time.Duration(15-remainder) * time.Minute).Truncate(time.Minute)
+	rounded := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), minute-remainder+15, 0, 0, t.Location())
+	if !rounded.After(t) {
+		// The boundary fell into a spring-forward gap; use elapsed time instead
+		rounded = t.Add(time.Duration(15-remainder) * time.Minute).Truncate(time.Minute)
+	}
+	return rounded
 }
 
 // parseTime parses "HH:MM" to minutes since midnight.
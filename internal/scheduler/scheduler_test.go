@@ -253,6 +253,26 @@ func TestRoundUpTo15Min(t *testing.T) {
 	}
 }
 
+func TestRoundUpTo15Min_DST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// 01:50 EDT on fall-back day: adding wall-clock minutes must land on 02:00,
+	// not on 01:00 of the repeated hour.
+	fallBack := time.Date(2026, 11, 1, 1, 50, 0, 0, loc)
+	if got := roundUpTo15Min(fallBack).Format("15:04"); got != "02:00" {
+		t.Errorf("roundUpTo15Min(fall back) = %s, want 02:00", got)
+	}
+
+	// 01:50 EST on spring-forward day: 02:00 does not exist, so expect 03:00.
+	springForward := time.Date(2026, 3, 8, 1, 50, 0, 0, loc)
+	if got := roundUpTo15Min(springForward).Format("15:04"); got != "03:00" {
+		t.Errorf("roundUpTo15Min(spring forward) = %s, want 03:00", got)
+	}
+}
+
 func TestParseTime(t *testing.T) {
 	tests := []struct {
 		input string
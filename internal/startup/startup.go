@@ -0,0 +1,78 @@
+// Package startup records how long each startup phase takes so slow starts
+// can be traced with --trace-startup.
+package startup
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Budget is the target time from process start to the first TUI frame.
+const Budget = 100 * time.Millisecond
+
+// FirstFrame is the phase marked when the week grid is first drawn.
+const FirstFrame = "first frame"
+
+// Phase is one timed step of startup.
+type Phase struct {
+	Name     string
+	Duration time.Duration // Time since the previous phase ended
+	Elapsed  time.Duration // Time since process start
+}
+
+var (
+	mu     sync.Mutex
+	begin  = time.Now()
+	last   = begin
+	phases []Phase
+)
+
+// Mark ends the phase called name. Marks with a name already recorded are
+// ignored, so code that runs on every frame can mark safely.
+func Mark(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, p := range phases {
+		if p.Name == name {
+			return
+		}
+	}
+	now := time.Now()
+	phases = append(phases, Phase{Name: name, Duration: now.Sub(last), Elapsed: now.Sub(begin)})
+	last = now
+}
+
+// Phases returns the phases recorded so far, in order.
+func Phases() []Phase {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Phase(nil), phases...)
+}
+
+// Report writes the recorded phases and the time to the first frame
+// against Budget.
+func Report(w io.Writer) {
+	recorded := Phases()
+	_, _ = fmt.Fprintln(w, "Startup trace:")
+	for _, p := range recorded {
+		_, _ = fmt.Fprintf(w, "  %-16s %8s  (at %s)\n", p.Name, round(p.Duration), round(p.Elapsed))
+	}
+	for _, p := range recorded {
+		if p.Name != FirstFrame {
+			continue
+		}
+		verdict := "within"
+		if p.Elapsed > Budget {
+			verdict = "over"
+		}
+		_, _ = fmt.Fprintf(w, "First frame after %s, %s the %s budget\n", round(p.Elapsed), verdict, Budget)
+		return
+	}
+	_, _ = fmt.Fprintln(w, "No frame was drawn")
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(100 * time.Microsecond)
+}
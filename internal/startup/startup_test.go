@@ -0,0 +1,33 @@
+package startup
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMarkAndReport(t *testing.T) {
+	Mark("config")
+	Mark(FirstFrame)
+	Mark(FirstFrame)
+	Mark("adjacent weeks")
+
+	got := Phases()
+	if len(got) != 3 {
+		t.Fatalf("got %d phases, want 3 (repeat marks ignored): %+v", len(got), got)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Elapsed < got[i-1].Elapsed {
+			t.Errorf("phase %q elapsed before %q", got[i].Name, got[i-1].Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	Report(&buf)
+	out := buf.String()
+	for _, want := range []string{"config", "adjacent weeks", "First frame after", "budget"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/llm"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// reflectDays is the length of the look-back window for reflection.
+const reflectDays = 7
+
+// ErrNothingToReflect is returned when there are no past tasks to review.
+var ErrNothingToReflect = errors.New("no tasks in the last week to reflect on")
+
+// Reflection holds the last week's tasks with counts and the LLM's review.
+type Reflection struct {
+	Start        time.Time
+	End          time.Time
+	Tasks        []*task.Task
+	Counts       ReflectionCounts
+	Observations []string
+	Adjustments  []string
+}
+
+// ReflectionCounts summarizes how the reviewed tasks turned out.
+type ReflectionCounts struct {
+	Scheduled int // Blocks that were kept (not postponed, cancelled or missed)
+	Over      int
+	Under     int
+	Postponed int
+	Cancelled int
+	Missed    int
+}
+
+// BuildReflectionOptions configures the repository-backed reflection builder.
+type BuildReflectionOptions struct {
+	Now      time.Time // End of the window (inclusive); required
+	Provider string
+	Model    string
+	BaseURL  string
+}
+
+// CountReflection tallies statuses and outcomes for a set of tasks.
+func CountReflection(tasks []*task.Task) ReflectionCounts {
+	var c ReflectionCounts
+	for _, t := range tasks {
+		switch t.Status {
+		case task.StatusPostponed:
+			c.Postponed++
+			continue
+		case task.StatusCancelled:
+			c.Cancelled++
+			continue
+		case task.StatusMissed:
+			c.Missed++
+			continue
+		}
+		c.Scheduled++
+		if t.Outcome == nil {
+			continue
+		}
+		switch *t.Outcome {
+		case task.OutcomeOver:
+			c.Over++
+		case task.OutcomeUnder:
+			c.Under++
+		}
+	}
+	return c
+}
+
+// BuildReflection loads the last week's tasks (today and the six days before)
+// and asks the LLM for observations and suggested adjustments.
+func BuildReflection(ctx context.Context, repo task.Repository, opts BuildReflectionOptions) (*Reflection, error) {
+	end := dateutil.TruncateToDay(opts.Now)
+	start := end.AddDate(0, 0, -(reflectDays - 1))
+
+	tasks, err := repo.ListTasksByDateRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("fetching tasks: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil, ErrNothingToReflect
+	}
+	if opts.Model == "" {
+		return nil, errors.New("model is required for reflection")
+	}
+
+	client, err := llm.NewClient(opts.Provider, opts.Model, opts.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("creating LLM client: %w", err)
+	}
+	result, err := llm.NewReflector(client).Reflect(ctx, start, end, tasks)
+	if err != nil {
+		return nil, fmt.Errorf("reflecting: %w", err)
+	}
+
+	return &Reflection{
+		Start:        start,
+		End:          end,
+		Tasks:        tasks,
+		Counts:       CountReflection(tasks),
+		Observations: result.Observations,
+		Adjustments:  result.Adjustments,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestCountReflection(t *testing.T) {
+	over := task.OutcomeOver
+	under := task.OutcomeUnder
+	onTime := task.OutcomeOnTime
+	tasks := []*task.Task{
+		{Status: task.StatusScheduled, Outcome: &over},
+		{Status: task.StatusScheduled, Outcome: &over},
+		{Status: task.StatusScheduled, Outcome: &under},
+		{Status: task.StatusScheduled, Outcome: &onTime},
+		{Status: task.StatusScheduled},
+		{Status: task.StatusPostponed, Outcome: &over},
+		{Status: task.StatusCancelled},
+	}
+
+	got := CountReflection(tasks)
+	want := ReflectionCounts{Scheduled: 5, Over: 2, Under: 1, Postponed: 1, Cancelled: 1}
+	if got != want {
+		t.Fatalf("CountReflection = %+v, want %+v", got, want)
+	}
+}
+
+func TestBuildReflectionWindowEndsAtNow(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	ctx := context.Background()
+	old := &task.Task{Description: "Old", Category: task.CategoryDeep, ScheduledDate: time.Date(2025, 1, 5, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled}
+	if err := repo.CreateTask(ctx, old); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	now := time.Date(2025, 1, 13, 8, 0, 0, 0, time.Local)
+	if _, err := BuildReflection(ctx, repo, BuildReflectionOptions{Now: now}); !errors.Is(err, ErrNothingToReflect) {
+		t.Fatalf("err = %v, want ErrNothingToReflect for a task before the window", err)
+	}
+
+	// Seen once Now moves to within a week of it; the missing model is the next check
+	now = time.Date(2025, 1, 8, 8, 0, 0, 0, time.Local)
+	if _, err := BuildReflection(ctx, repo, BuildReflectionOptions{Now: now}); err == nil || errors.Is(err, ErrNothingToReflect) {
+		t.Fatalf("err = %v, want the task found in the window", err)
+	}
+}
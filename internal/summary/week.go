@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/javiermolinar/sancho/internal/dateutil"
@@ -18,20 +20,128 @@ type WeekSummary struct {
 	End     time.Time
 	Tasks   []*task.Task
 	Stats   task.WeekStats
+	Goals   []task.GoalProgress
+	Budgets []task.GoalProgress // Weekly limits of the categories that have one
+	Tickets []task.TicketTime   // Time per issue key, e.g. PROJ-123
 	Insight string
+
+	Previous *task.WeekStats // The week before, nil when it had no tasks
+}
+
+// WeekDelta is how a week's stats changed from the week before.
+type WeekDelta struct {
+	DeepMinutes    int
+	ShallowMinutes int
+	Postponed      int
+	Cancelled      int
+	Missed         int
+}
+
+// Delta compares the week with the previous one. It reports false when the
+// previous week had no tasks.
+func (s *WeekSummary) Delta() (WeekDelta, bool) {
+	if s.Previous == nil {
+		return WeekDelta{}, false
+	}
+	return WeekDelta{
+		DeepMinutes:    s.Stats.DeepMinutes - s.Previous.DeepMinutes,
+		ShallowMinutes: s.Stats.ShallowMinutes - s.Previous.ShallowMinutes,
+		Postponed:      s.Stats.PostponedBlocks - s.Previous.PostponedBlocks,
+		Cancelled:      s.Stats.CancelledBlocks - s.Previous.CancelledBlocks,
+		Missed:         s.Stats.MissedBlocks - s.Previous.MissedBlocks,
+	}, true
+}
+
+// String formats the delta as "deep hours +2.5h, shallow hours −1h,
+// postpones −3". Hours are always shown; counts only when they changed.
+func (d WeekDelta) String() string {
+	parts := []string{
+		"deep hours " + formatHoursDelta(d.DeepMinutes),
+		"shallow hours " + formatHoursDelta(d.ShallowMinutes),
+	}
+	for _, c := range []struct {
+		label string
+		n     int
+	}{{"postpones", d.Postponed}, {"cancels", d.Cancelled}, {"missed", d.Missed}} {
+		if c.n != 0 {
+			parts = append(parts, c.label+" "+formatSigned(strconv.Itoa(abs(c.n)), c.n))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatHoursDelta formats minutes as signed hours with at most one decimal.
+func formatHoursDelta(minutes int) string {
+	hours := strconv.FormatFloat(float64(abs(minutes))/60, 'f', 1, 64)
+	return formatSigned(strings.TrimSuffix(hours, ".0")+"h", minutes)
+}
+
+// formatSigned prefixes s with + or − (U+2212) following the sign of n.
+func formatSigned(s string, n int) string {
+	switch {
+	case n > 0:
+		return "+" + s
+	case n < 0:
+		return "−" + s
+	default:
+		return s
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
 }
 
 // WeekSummaryOptions configures week summary statistics.
 type WeekSummaryOptions struct {
 	PeakStart string
 	PeakEnd   string
+	Goals     GoalTargets
+	Now       time.Time // Splits goal progress into done and planned; required
+}
+
+// GoalTargets holds weekly targets in minutes. Zero disables a target.
+type GoalTargets struct {
+	DeepMinutes  int
+	TotalMinutes int
+}
+
+// WeekGoals computes progress for each configured target. Tasks for which
+// isDone returns true count as done, the rest as planned.
+func WeekGoals(tasks []*task.Task, targets GoalTargets, isDone func(*task.Task) bool) []task.GoalProgress {
+	var goals []task.GoalProgress
+	if targets.DeepMinutes > 0 {
+		goals = append(goals, task.NewGoalProgress("Deep", task.CategoryDeep, targets.DeepMinutes, tasks, isDone))
+	}
+	if targets.TotalMinutes > 0 {
+		goals = append(goals, task.NewGoalProgress("Total", "", targets.TotalMinutes, tasks, isDone))
+	}
+	return goals
+}
+
+// WeekBudgets computes the week's use of each category with a weekly limit,
+// in registry order. Tasks for which isDone returns true count as done, the
+// rest as planned.
+func WeekBudgets(tasks []*task.Task, isDone func(*task.Task) bool) []task.GoalProgress {
+	var budgets []task.GoalProgress
+	for _, info := range task.Categories() {
+		if info.WeeklyLimit > 0 {
+			budgets = append(budgets, task.NewGoalProgress(string(info.Name), info.Name, info.WeeklyLimit, tasks, isDone))
+		}
+	}
+	return budgets
 }
 
 // BuildWeekSummaryOptions configures the repository-backed summary builder.
 type BuildWeekSummaryOptions struct {
-	WeekStart      time.Time
+	WeekStart      time.Time // Defaults to the week of Now
 	PeakStart      string
 	PeakEnd        string
+	Goals          GoalTargets
+	Now            time.Time // Required; callers pass their clock's reading
 	IncludeInsight bool
 	Provider       string
 	Model          string
@@ -47,11 +157,18 @@ func SummarizeWeek(weekStart time.Time, tasks []*task.Task, opts WeekSummaryOpti
 		stats = week.StatsWithPeakHours(opts.PeakStart, opts.PeakEnd)
 	}
 
+	tasks = week.AllTasks()
+
+	isDone := func(t *task.Task) bool { return t.IsPastAt(opts.Now) }
+
 	return &WeekSummary{
-		Start: start,
-		End:   end,
-		Tasks: week.AllTasks(),
-		Stats: stats,
+		Start:   start,
+		End:     end,
+		Tasks:   tasks,
+		Stats:   stats,
+		Goals:   WeekGoals(tasks, opts.Goals, isDone),
+		Budgets: WeekBudgets(tasks, isDone),
+		Tickets: task.TicketTimes(tasks),
 	}
 }
 
@@ -59,19 +176,26 @@ func SummarizeWeek(weekStart time.Time, tasks []*task.Task, opts WeekSummaryOpti
 func BuildWeekSummary(ctx context.Context, repo task.Repository, opts BuildWeekSummaryOptions) (*WeekSummary, error) {
 	weekStart := opts.WeekStart
 	if weekStart.IsZero() {
-		weekStart = time.Now()
+		weekStart = opts.Now
 	}
 
 	start, end := dateutil.WeekRange(weekStart)
-	tasks, err := repo.ListTasksByDateRange(ctx, start, end)
+	prevStart := start.AddDate(0, 0, -7)
+	tasks, err := repo.ListTasksByDateRange(ctx, prevStart, end)
 	if err != nil {
 		return nil, fmt.Errorf("fetching tasks: %w", err)
 	}
 
-	summary := SummarizeWeek(start, tasks, WeekSummaryOptions{
+	statsOpts := WeekSummaryOptions{
 		PeakStart: opts.PeakStart,
 		PeakEnd:   opts.PeakEnd,
-	})
+		Goals:     opts.Goals,
+		Now:       opts.Now,
+	}
+	summary := SummarizeWeek(start, tasks, statsOpts)
+	if prev := SummarizeWeek(prevStart, tasks, statsOpts); len(prev.Tasks) > 0 {
+		summary.Previous = &prev.Stats
+	}
 
 	if opts.IncludeInsight && len(summary.Tasks) > 0 {
 		if opts.Model == "" {
@@ -86,7 +210,11 @@ func BuildWeekSummary(ctx context.Context, repo task.Repository, opts BuildWeekS
 			PeakStart: opts.PeakStart,
 			PeakEnd:   opts.PeakEnd,
 		})
-		result, err := evaluator.EvaluateWeek(ctx, start, end, summary.Tasks)
+		var comparison string
+		if delta, ok := summary.Delta(); ok {
+			comparison = delta.String()
+		}
+		result, err := evaluator.EvaluateWeek(ctx, start, end, summary.Tasks, comparison)
 		if err != nil {
 			return nil, fmt.Errorf("evaluating week: %w", err)
 		}
@@ -1,9 +1,12 @@
 package summary
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/javiermolinar/sancho/internal/db"
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
@@ -68,3 +71,84 @@ func TestSummarizeWeek(t *testing.T) {
 		t.Fatalf("peak deep minutes = %d, want 60", stats.PeakDeepMinutes)
 	}
 }
+
+func TestBuildWeekSummaryComparesPreviousWeek(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	lastMonday := monday.AddDate(0, 0, -7)
+	create := func(date time.Time, start, end string) *task.Task {
+		tk := &task.Task{Description: "Work", Category: task.CategoryDeep, ScheduledDate: date,
+			ScheduledStart: start, ScheduledEnd: end, Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+		return tk
+	}
+	create(lastMonday, "09:00", "11:00")
+	moved := create(lastMonday, "14:00", "15:00")
+	if _, err := repo.PostponeTask(ctx, moved.ID, lastMonday.AddDate(0, 0, 1), "14:00", "15:00", 0); err != nil {
+		t.Fatalf("PostponeTask: %v", err)
+	}
+	create(monday, "09:00", "13:30")
+	create(monday.AddDate(0, 0, 1), "09:00", "10:00")
+
+	summary, err := BuildWeekSummary(ctx, repo, BuildWeekSummaryOptions{WeekStart: monday, Now: monday})
+	if err != nil {
+		t.Fatalf("BuildWeekSummary: %v", err)
+	}
+	delta, ok := summary.Delta()
+	if !ok {
+		t.Fatal("expected a comparison with the previous week")
+	}
+	if want := "deep hours +2.5h, shallow hours 0h, postpones −1"; delta.String() != want {
+		t.Errorf("delta = %q, want %q", delta.String(), want)
+	}
+
+	first, err := BuildWeekSummary(ctx, repo, BuildWeekSummaryOptions{WeekStart: lastMonday, Now: monday})
+	if err != nil {
+		t.Fatalf("BuildWeekSummary: %v", err)
+	}
+	if _, ok := first.Delta(); ok {
+		t.Error("a week after an empty one should have no comparison")
+	}
+}
+
+func TestBuildWeekSummaryUsesNow(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	ctx := context.Background()
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	for _, date := range []time.Time{monday, monday.AddDate(0, 0, 3)} {
+		tk := &task.Task{Description: "Work", Category: task.CategoryDeep, ScheduledDate: date,
+			ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+
+	// No WeekStart: the week comes from Now, which also splits done from planned
+	now := monday.AddDate(0, 0, 2).Add(12 * time.Hour) // Wednesday noon
+	summary, err := BuildWeekSummary(ctx, repo, BuildWeekSummaryOptions{
+		Goals: GoalTargets{DeepMinutes: 240},
+		Now:   now,
+	})
+	if err != nil {
+		t.Fatalf("BuildWeekSummary: %v", err)
+	}
+	if !summary.Start.Equal(monday) {
+		t.Errorf("start = %v, want %v", summary.Start, monday)
+	}
+	if len(summary.Goals) != 1 || summary.Goals[0].Done != 60 || summary.Goals[0].Planned != 60 {
+		t.Errorf("goals = %+v, want 60 done and 60 planned", summary.Goals)
+	}
+}
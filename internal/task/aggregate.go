@@ -0,0 +1,155 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DailyAggregate is the summary of one day's tasks: minutes per category,
+// block counts and outcomes. Repositories may keep these pre-computed so
+// long-range reports do not scan every task.
+type DailyAggregate struct {
+	Date time.Time
+	DayStats
+	OnTime int
+	Over   int
+	Under  int
+}
+
+// AggregateLister is implemented by repositories that maintain daily
+// aggregates as tasks are written.
+type AggregateLister interface {
+	// ListDailyAggregates returns one aggregate per day with tasks in the
+	// inclusive range, ordered by date.
+	ListDailyAggregates(ctx context.Context, start, end time.Time) ([]DailyAggregate, error)
+}
+
+// WeeklyAggregate is the sum of the daily aggregates of one week.
+type WeeklyAggregate struct {
+	WeekStart time.Time
+	DayStats
+	OnTime int
+	Over   int
+	Under  int
+}
+
+// Scheduled returns the number of blocks that were neither cancelled nor postponed.
+func (a WeeklyAggregate) Scheduled() int {
+	return a.TotalBlocks - a.CancelledBlocks - a.PostponedBlocks
+}
+
+// CompletionPercent returns the share of scheduled blocks with an outcome
+// recorded, or 0 when nothing is scheduled.
+func (a WeeklyAggregate) CompletionPercent() int {
+	if a.Scheduled() <= 0 {
+		return 0
+	}
+	return (a.OnTime + a.Over + a.Under) * 100 / a.Scheduled()
+}
+
+// WeeklyAggregateLister is implemented by repositories that can sum their
+// daily aggregates per week in one query.
+type WeeklyAggregateLister interface {
+	// ListWeeklyAggregates returns one aggregate per week with tasks in the
+	// inclusive range, ordered by week.
+	ListWeeklyAggregates(ctx context.Context, start, end time.Time) ([]WeeklyAggregate, error)
+}
+
+// WeeklyAggregates returns per-week aggregates for the inclusive range. Weeks
+// without tasks are omitted.
+func WeeklyAggregates(ctx context.Context, repo Repository, start, end time.Time) ([]WeeklyAggregate, error) {
+	if lister, ok := repo.(WeeklyAggregateLister); ok {
+		return lister.ListWeeklyAggregates(ctx, start, end)
+	}
+	days, err := DailyAggregates(ctx, repo, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return AggregateByWeek(days), nil
+}
+
+// AggregateByWeek sums daily aggregates, ordered by date, into weeks.
+func AggregateByWeek(days []DailyAggregate) []WeeklyAggregate {
+	var result []WeeklyAggregate
+	for _, d := range days {
+		weekStart := startOfWeek(d.Date)
+		if n := len(result); n == 0 || !result[n-1].WeekStart.Equal(weekStart) {
+			result = append(result, WeeklyAggregate{WeekStart: weekStart})
+		}
+		w := &result[len(result)-1]
+		w.DeepMinutes += d.DeepMinutes
+		w.ShallowMinutes += d.ShallowMinutes
+		w.TotalBlocks += d.TotalBlocks
+		w.CancelledBlocks += d.CancelledBlocks
+		w.PostponedBlocks += d.PostponedBlocks
+		w.MissedBlocks += d.MissedBlocks
+		w.OnTime += d.OnTime
+		w.Over += d.Over
+		w.Under += d.Under
+	}
+	return result
+}
+
+// DailyAggregates returns per-day aggregates for the inclusive range, read
+// from the repository's pre-computed table when it has one and computed from
+// the tasks otherwise.
+func DailyAggregates(ctx context.Context, repo Repository, start, end time.Time) ([]DailyAggregate, error) {
+	if lister, ok := repo.(AggregateLister); ok {
+		return lister.ListDailyAggregates(ctx, start, end)
+	}
+	tasks, err := repo.ListTasksByDateRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("listing tasks: %w", err)
+	}
+	return AggregateByDay(tasks), nil
+}
+
+// AggregateByDay computes daily aggregates from tasks, ordered by date.
+// Minutes and outcomes only count scheduled tasks, as in Day.Stats.
+func AggregateByDay(tasks []*Task) []DailyAggregate {
+	byDay := make(map[string]*DailyAggregate)
+	for _, t := range tasks {
+		key := t.ScheduledDate.Format("2006-01-02")
+		agg, ok := byDay[key]
+		if !ok {
+			agg = &DailyAggregate{Date: truncateToDay(t.ScheduledDate)}
+			byDay[key] = agg
+		}
+		agg.TotalBlocks++
+		switch t.Status {
+		case StatusCancelled:
+			agg.CancelledBlocks++
+			continue
+		case StatusPostponed:
+			agg.PostponedBlocks++
+			continue
+		case StatusMissed:
+			agg.MissedBlocks++
+			continue
+		}
+		if t.IsDeep() {
+			agg.DeepMinutes += t.Duration()
+		} else {
+			agg.ShallowMinutes += t.Duration()
+		}
+		if t.Outcome != nil {
+			switch *t.Outcome {
+			case OutcomeOnTime:
+				agg.OnTime++
+			case OutcomeOver:
+				agg.Over++
+			case OutcomeUnder:
+				agg.Under++
+			}
+		}
+	}
+
+	result := make([]DailyAggregate, 0, len(byDay))
+	for _, agg := range byDay {
+		result = append(result, *agg)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+	return result
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoBacklog is returned when the repository cannot hold a backlog.
+var ErrNoBacklog = errors.New("this database has no backlog")
+
+// BacklogItem is work waiting to be timeblocked, such as an issue imported
+// from a tracker.
+type BacklogItem struct {
+	ID          int64
+	Title       string
+	Notes       string      // Free text, e.g. a link back to the issue
+	ExternalRef ExternalRef // Set by importers so importing again updates the item
+	Day         time.Time   // Day the item is parked on, committed to it without a time; zero while in the backlog
+	CreatedAt   time.Time
+}
+
+// IsParked reports whether the item is parked on a day.
+func (b *BacklogItem) IsParked() bool {
+	return !b.Day.IsZero()
+}
+
+// Backlog is implemented by repositories that keep a backlog of unscheduled
+// work next to the schedule.
+type Backlog interface {
+	// UpsertBacklogItem adds the item, or refreshes the title and notes of the
+	// item with the same external reference. Items without a reference are
+	// always added. Reports whether a new item was created.
+	UpsertBacklogItem(ctx context.Context, item *BacklogItem) (bool, error)
+
+	// ListBacklog returns every backlog item, oldest first.
+	ListBacklog(ctx context.Context) ([]*BacklogItem, error)
+
+	// DeleteBacklogItem removes an item from the backlog.
+	DeleteBacklogItem(ctx context.Context, id int64) error
+
+	// ParkBacklogItem parks the item on day. A zero day returns it to the
+	// backlog.
+	ParkBacklogItem(ctx context.Context, id int64, day time.Time) error
+}
+
+// BacklogOf returns repo's backlog, or ErrNoBacklog if it has none.
+func BacklogOf(repo Repository) (Backlog, error) {
+	b, ok := repo.(Backlog)
+	if !ok {
+		return nil, ErrNoBacklog
+	}
+	return b, nil
+}
+
+// ParkedItems returns the items parked on each day, keyed by the day as
+// "2006-01-02", oldest first within a day.
+func ParkedItems(items []*BacklogItem) map[string][]*BacklogItem {
+	parked := make(map[string][]*BacklogItem)
+	for _, item := range items {
+		if item.IsParked() {
+			key := item.Day.Format("2006-01-02")
+			parked[key] = append(parked[key], item)
+		}
+	}
+	return parked
+}
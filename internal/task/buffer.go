@@ -0,0 +1,104 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// dayEndMinutes is the end of the day (24:00) in minutes.
+const dayEndMinutes = 24 * 60
+
+// BufferShift computes the moves needed to keep buffer minutes free after
+// the block ending at end. Like inserting space in the grid, every scheduled
+// task starting at or after end is pushed later by the same amount, so the
+// gaps between them are kept. It returns ok=false without updates when the
+// shift would push a task past midnight.
+func BufferShift(dayTasks []*Task, end string, buffer int) (updates []TaskTimeUpdate, ok bool) {
+	if buffer <= 0 {
+		return nil, true
+	}
+	endMin := TimeToMinutes(end)
+
+	next := -1
+	for _, t := range dayTasks {
+		if !t.IsScheduled() {
+			continue
+		}
+		if start := TimeToMinutes(t.ScheduledStart); start >= endMin && (next < 0 || start < next) {
+			next = start
+		}
+	}
+	if next < 0 || next-endMin >= buffer {
+		return nil, true
+	}
+
+	shift := buffer - (next - endMin)
+	for _, t := range dayTasks {
+		if !t.IsScheduled() || TimeToMinutes(t.ScheduledStart) < endMin {
+			continue
+		}
+		_, end := Span(t.ScheduledStart, t.ScheduledEnd)
+		newEnd := end + shift
+		if newEnd >= dayEndMinutes {
+			return nil, false
+		}
+		updates = append(updates, TaskTimeUpdate{
+			ID:       t.ID,
+			NewStart: MinutesToTime(TimeToMinutes(t.ScheduledStart) + shift),
+			NewEnd:   MinutesToTime(newEnd),
+		})
+	}
+	return updates, true
+}
+
+// ReserveBuffer keeps buffer minutes free after the stored task with the
+// given ID by shifting the tasks that follow it on the same day. It returns
+// the number of tasks moved; ok is false when there was no room to shift.
+// Only tasks of the same owner shift. Overnight and all-day tasks reserve
+// nothing.
+func ReserveBuffer(ctx context.Context, repo Repository, id int64, date time.Time, buffer int) (moved int, ok bool, err error) {
+	if buffer <= 0 {
+		return 0, true, nil
+	}
+
+	dayTasks, err := repo.ListTasksByDateRange(ctx, date, date)
+	if err != nil {
+		return 0, false, fmt.Errorf("listing tasks: %w", err)
+	}
+
+	var self *Task
+	others := make([]*Task, 0, len(dayTasks))
+	for _, t := range dayTasks {
+		if t.ID == id {
+			self = t
+			continue
+		}
+		others = append(others, t)
+	}
+	if self == nil {
+		return 0, false, ErrTaskNotFound
+	}
+	if self.IsOvernight() {
+		// The gap would fall on the next day, past everything that could shift
+		return 0, true, nil
+	}
+	if self.IsAllDay() {
+		return 0, true, nil
+	}
+
+	mine := others[:0]
+	for _, t := range others {
+		if t.Owner == self.Owner {
+			mine = append(mine, t)
+		}
+	}
+	updates, ok := BufferShift(mine, self.ScheduledEnd, buffer)
+	if !ok || len(updates) == 0 {
+		return 0, ok, nil
+	}
+	if err := repo.BatchUpdateTaskTimes(ctx, date, updates); err != nil {
+		return 0, false, fmt.Errorf("reserving buffer: %w", err)
+	}
+	return len(updates), true, nil
+}
@@ -0,0 +1,78 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBufferShift(t *testing.T) {
+	mk := func(id int64, start, end string, status Status) *Task {
+		return &Task{ID: id, ScheduledStart: start, ScheduledEnd: end, Status: status}
+	}
+
+	tests := []struct {
+		name   string
+		tasks  []*Task
+		end    string
+		buffer int
+		want   []TaskTimeUpdate
+		wantOK bool
+	}{
+		{
+			name:   "no buffer",
+			tasks:  []*Task{mk(1, "10:00", "11:00", StatusScheduled)},
+			end:    "10:00",
+			buffer: 0,
+			wantOK: true,
+		},
+		{
+			name:   "gap already large enough",
+			tasks:  []*Task{mk(1, "10:30", "11:00", StatusScheduled)},
+			end:    "10:00",
+			buffer: 30,
+			wantOK: true,
+		},
+		{
+			name: "adjacent tasks shift together",
+			tasks: []*Task{
+				mk(1, "08:00", "09:00", StatusScheduled), // before, untouched
+				mk(2, "10:00", "11:00", StatusScheduled),
+				mk(3, "12:00", "12:30", StatusScheduled),
+				mk(4, "10:00", "10:30", StatusCancelled),
+			},
+			end:    "10:00",
+			buffer: 15,
+			want: []TaskTimeUpdate{
+				{ID: 2, NewStart: "10:15", NewEnd: "11:15"},
+				{ID: 3, NewStart: "12:15", NewEnd: "12:45"},
+			},
+			wantOK: true,
+		},
+		{
+			name:   "partial gap",
+			tasks:  []*Task{mk(1, "10:15", "11:00", StatusScheduled)},
+			end:    "10:00",
+			buffer: 30,
+			want:   []TaskTimeUpdate{{ID: 1, NewStart: "10:30", NewEnd: "11:15"}},
+			wantOK: true,
+		},
+		{
+			name:   "would overflow midnight",
+			tasks:  []*Task{mk(1, "23:00", "23:45", StatusScheduled)},
+			end:    "23:00",
+			buffer: 15,
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := BufferShift(tt.tasks, tt.end, tt.buffer)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("updates = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
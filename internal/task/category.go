@@ -0,0 +1,153 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+)
+
+// CategoryInfo describes a category in the registry: its name, the short code
+// shown in grid cells and listings, and an optional display color and icon.
+type CategoryInfo struct {
+	Name  Category
+	Code  string // Single character shown in the grid, e.g. "D"
+	Color string // Hex color such as "#89b4fa"; empty uses the theme
+	Icon  string // Emoji or Nerd Font glyph shown before task titles; empty for none
+
+	WeeklyLimit int // Most minutes a week should hold; 0 means no limit
+}
+
+var defaultCategories = []CategoryInfo{
+	{Name: CategoryDeep, Code: "D"},
+	{Name: CategoryShallow, Code: "S"},
+}
+
+var (
+	categoriesMu sync.RWMutex
+	categories   = defaultCategories
+)
+
+// SetCategories installs the category registry. Deep and shallow are always
+// registered first; an entry with one of their names overrides its code,
+// color, icon and weekly limit, any other entry adds a user-defined category. Custom categories
+// count as shallow time in deep/shallow totals.
+func SetCategories(custom []CategoryInfo) {
+	list := append([]CategoryInfo(nil), defaultCategories...)
+	for _, c := range custom {
+		c.Name = Category(strings.ToLower(strings.TrimSpace(string(c.Name))))
+		if c.Name == "" {
+			continue
+		}
+		if i := indexCategory(list, c.Name); i >= 0 {
+			if c.Code != "" {
+				list[i].Code = c.Code
+			}
+			if c.Color != "" {
+				list[i].Color = c.Color
+			}
+			if c.Icon != "" {
+				list[i].Icon = c.Icon
+			}
+			if c.WeeklyLimit > 0 {
+				list[i].WeeklyLimit = c.WeeklyLimit
+			}
+			continue
+		}
+		if c.Code == "" {
+			c.Code = strings.ToUpper(string([]rune(string(c.Name))[:1]))
+		}
+		list = append(list, c)
+	}
+	categoriesMu.Lock()
+	categories = list
+	categoriesMu.Unlock()
+}
+
+// Categories returns the registered categories, deep and shallow first.
+func Categories() []CategoryInfo {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+	return append([]CategoryInfo(nil), categories...)
+}
+
+// LookupCategory returns the registry entry for c.
+func LookupCategory(c Category) (CategoryInfo, bool) {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+	if i := indexCategory(categories, c); i >= 0 {
+		return categories[i], true
+	}
+	return CategoryInfo{}, false
+}
+
+// Valid returns true if c is a registered category.
+func (c Category) Valid() bool {
+	_, ok := LookupCategory(c)
+	return ok
+}
+
+// Code returns the short code of c, or "?" when it is not registered.
+func (c Category) Code() string {
+	if info, ok := LookupCategory(c); ok {
+		return info.Code
+	}
+	return "?"
+}
+
+// Icon returns the icon of c, or "" when it has none.
+func (c Category) Icon() string {
+	info, _ := LookupCategory(c)
+	return info.Icon
+}
+
+// Next returns the category after c in the registry, wrapping around.
+func (c Category) Next() Category {
+	list := Categories()
+	i := indexCategory(list, c)
+	return list[(i+1)%len(list)].Name
+}
+
+// CategoryOrDeep returns the registered category called s, falling back to
+// deep for unknown or empty names. Planners use it so a model answering with
+// a category the user never defined still produces a task.
+func CategoryOrDeep(s string) Category {
+	c := Category(strings.ToLower(strings.TrimSpace(s)))
+	if c.Valid() {
+		return c
+	}
+	return CategoryDeep
+}
+
+// WeeklyUsage returns the minutes of category scheduled in the week holding
+// date and the category's weekly limit. It skips the lookup and returns zero
+// for categories without a limit.
+func WeeklyUsage(ctx context.Context, repo Repository, category Category, date time.Time) (used, limit int, err error) {
+	info, ok := LookupCategory(category)
+	if !ok || info.WeeklyLimit <= 0 {
+		return 0, 0, nil
+	}
+	start := dateutil.StartOfWeek(date)
+	tasks, err := repo.ListTasksByDateRange(ctx, start, start.AddDate(0, 0, 6))
+	if err != nil {
+		return 0, 0, fmt.Errorf("listing tasks: %w", err)
+	}
+	for _, t := range tasks {
+		if t.IsScheduled() && t.Category == category {
+			used += t.Duration()
+		}
+	}
+	return used, info.WeeklyLimit, nil
+}
+
+func indexCategory(list []CategoryInfo, c Category) int {
+	for i, info := range list {
+		if info.Name == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -58,7 +58,21 @@ func (d *Day) AddTask(t *Task) error {
 		}
 	}
 
-	// Insert in sorted order by start time
+	d.insert(t)
+	return nil
+}
+
+// AddOverlapping adds a task without checking it against the day's other
+// tasks. Use it for tasks that storage already accepted, which may overlap
+// when the repository allows overlaps.
+func (d *Day) AddOverlapping(t *Task) {
+	if t != nil {
+		d.insert(t)
+	}
+}
+
+// insert adds t, keeping the tasks sorted by start time.
+func (d *Day) insert(t *Task) {
 	d.tasks = append(d.tasks, t)
 	slices.SortFunc(d.tasks, func(a, b *Task) int {
 		if a.ScheduledStart < b.ScheduledStart {
@@ -69,8 +83,6 @@ func (d *Day) AddTask(t *Task) error {
 		}
 		return 0
 	})
-
-	return nil
 }
 
 // FindOverlappingTask returns the first scheduled task that overlaps with the given time slot.
@@ -92,6 +104,17 @@ func (d *Day) HasOverlap(start, end string) bool {
 	return d.FindOverlappingTask(start, end) != nil
 }
 
+// AllDayTasks returns the scheduled all-day tasks of the day.
+func (d *Day) AllDayTasks() []*Task {
+	var result []*Task
+	for _, t := range d.tasks {
+		if t.IsScheduled() && t.IsAllDay() {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
 // ScheduledTasks returns only tasks with scheduled status.
 func (d *Day) ScheduledTasks() []*Task {
 	var result []*Task
@@ -123,10 +146,11 @@ func (d *Day) Len() int {
 // DayStats holds statistics for a single day.
 type DayStats struct {
 	DeepMinutes     int
-	ShallowMinutes  int
+	ShallowMinutes  int // Includes custom categories
 	TotalBlocks     int
 	CancelledBlocks int
 	PostponedBlocks int
+	MissedBlocks    int
 }
 
 // TotalMinutes returns the sum of deep and shallow minutes.
@@ -152,6 +176,8 @@ func (d *Day) Stats() DayStats {
 			stats.CancelledBlocks++
 		case StatusPostponed:
 			stats.PostponedBlocks++
+		case StatusMissed:
+			stats.MissedBlocks++
 		default:
 			if t.IsDeep() {
 				stats.DeepMinutes += t.Duration()
@@ -239,11 +239,18 @@ func TestDay_Stats(t *testing.T) {
 		ScheduledEnd:   "17:00",
 		Status:         StatusPostponed,
 	})
+	_ = day.AddTask(&Task{
+		Description:    "Missed",
+		Category:       CategoryDeep,
+		ScheduledStart: "17:00",
+		ScheduledEnd:   "18:00",
+		Status:         StatusMissed,
+	})
 
 	stats := day.Stats()
 
-	if stats.TotalBlocks != 4 {
-		t.Errorf("expected 4 total blocks, got %d", stats.TotalBlocks)
+	if stats.TotalBlocks != 5 {
+		t.Errorf("expected 5 total blocks, got %d", stats.TotalBlocks)
 	}
 	if stats.DeepMinutes != 120 {
 		t.Errorf("expected 120 deep minutes, got %d", stats.DeepMinutes)
@@ -257,6 +264,9 @@ func TestDay_Stats(t *testing.T) {
 	if stats.PostponedBlocks != 1 {
 		t.Errorf("expected 1 postponed block, got %d", stats.PostponedBlocks)
 	}
+	if stats.MissedBlocks != 1 {
+		t.Errorf("expected 1 missed block, got %d", stats.MissedBlocks)
+	}
 	if stats.TotalMinutes() != 180 {
 		t.Errorf("expected 180 total minutes, got %d", stats.TotalMinutes())
 	}
@@ -311,3 +321,28 @@ func TestDayStats_DeepPercent(t *testing.T) {
 		})
 	}
 }
+
+func TestDay_AddTask_DSTRepeatedHourOverlap(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// 2026-11-01 repeats 01:00-02:00; wall-clock ranges must still collide
+	date := time.Date(2026, 11, 1, 0, 0, 0, 0, loc)
+	day := NewDay(date)
+
+	first := &Task{ID: 1, ScheduledDate: date, ScheduledStart: "01:00", ScheduledEnd: "02:00", Status: StatusScheduled}
+	if err := day.AddTask(first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second := &Task{ID: 2, ScheduledDate: date, ScheduledStart: "01:30", ScheduledEnd: "02:30", Status: StatusScheduled}
+	if err := day.AddTask(second); err == nil {
+		t.Fatal("expected overlap in repeated hour to be rejected")
+	}
+
+	if got := first.Duration(); got != 60 {
+		t.Errorf("Duration() = %d, want wall-clock 60 minutes", got)
+	}
+}
@@ -0,0 +1,208 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Dependency errors.
+var (
+	ErrDependencyCycle          = errors.New("dependency would form a cycle")
+	ErrStartsBeforePrerequisite = errors.New("task starts before its prerequisite ends")
+	ErrDependenciesUnsupported  = errors.New("dependencies are not stored in this database")
+)
+
+// Dependency records that task TaskID is blocked by task BlockedBy: it
+// should not start before BlockedBy ends.
+type Dependency struct {
+	TaskID    int64
+	BlockedBy int64
+}
+
+// DependencyStore is implemented by repositories that keep blocked_by links
+// between tasks.
+type DependencyStore interface {
+	// AddDependency stores d. Adding a link that exists is not an error.
+	AddDependency(ctx context.Context, d Dependency) error
+
+	// RemoveDependency deletes d. Removing a link that does not exist is
+	// not an error.
+	RemoveDependency(ctx context.Context, d Dependency) error
+
+	// Dependencies returns the links in which any of the given tasks is
+	// the blocked task or the prerequisite.
+	Dependencies(ctx context.Context, ids []int64) ([]Dependency, error)
+}
+
+// StartsBeforeEndOf reports whether t starts before other ends, taking
+// their days and overnight blocks into account.
+func (t *Task) StartsBeforeEndOf(other *Task) bool {
+	offset := CalendarDaysBetween(other.ScheduledDate, t.ScheduledDate) * 24 * 60
+	_, end := Span(other.ScheduledStart, other.ScheduledEnd)
+	return TimeToMinutes(t.ScheduledStart)+offset < end
+}
+
+// AddDependency marks task id as blocked by task blockedBy. It fails when
+// repo cannot store links, when the link would close a cycle, and when both
+// tasks are scheduled and id starts before blockedBy ends.
+func AddDependency(ctx context.Context, repo Repository, id, blockedBy int64) error {
+	store, ok := repo.(DependencyStore)
+	if !ok {
+		return ErrDependenciesUnsupported
+	}
+	if id == blockedBy {
+		return fmt.Errorf("task #%d cannot block itself: %w", id, ErrDependencyCycle)
+	}
+	t, err := getTask(ctx, repo, id)
+	if err != nil {
+		return err
+	}
+	prerequisite, err := getTask(ctx, repo, blockedBy)
+	if err != nil {
+		return err
+	}
+
+	// Walk the prerequisites of blockedBy; reaching id means id already
+	// comes before it.
+	seen := map[int64]bool{blockedBy: true}
+	frontier := []int64{blockedBy}
+	for len(frontier) > 0 {
+		deps, err := store.Dependencies(ctx, frontier)
+		if err != nil {
+			return fmt.Errorf("listing dependencies: %w", err)
+		}
+		var next []int64
+		for _, d := range deps {
+			if !seen[d.TaskID] || seen[d.BlockedBy] {
+				continue
+			}
+			if d.BlockedBy == id {
+				return fmt.Errorf("%q already comes before %q: %w", t.Description, prerequisite.Description, ErrDependencyCycle)
+			}
+			seen[d.BlockedBy] = true
+			next = append(next, d.BlockedBy)
+		}
+		frontier = next
+	}
+	if t.IsScheduled() && prerequisite.IsScheduled() && t.StartsBeforeEndOf(prerequisite) {
+		return fmt.Errorf("%q starts before %q ends: %w", t.Description, prerequisite.Description, ErrStartsBeforePrerequisite)
+	}
+	return store.AddDependency(ctx, Dependency{TaskID: id, BlockedBy: blockedBy})
+}
+
+// RemoveDependency drops the link making task id blocked by task blockedBy.
+func RemoveDependency(ctx context.Context, repo Repository, id, blockedBy int64) error {
+	store, ok := repo.(DependencyStore)
+	if !ok {
+		return ErrDependenciesUnsupported
+	}
+	return store.RemoveDependency(ctx, Dependency{TaskID: id, BlockedBy: blockedBy})
+}
+
+// Links are the prerequisites of a task and the tasks it blocks.
+type Links struct {
+	BlockedBy []*Task
+	Blocks    []*Task
+}
+
+// TaskLinks returns the prerequisites and dependents of task id. Repositories
+// without a DependencyStore have none.
+func TaskLinks(ctx context.Context, repo Repository, id int64) (Links, error) {
+	var links Links
+	store, ok := repo.(DependencyStore)
+	if !ok {
+		return links, nil
+	}
+	deps, err := store.Dependencies(ctx, []int64{id})
+	if err != nil {
+		return links, fmt.Errorf("listing dependencies: %w", err)
+	}
+	for _, d := range deps {
+		other, list := d.BlockedBy, &links.BlockedBy
+		if d.BlockedBy == id {
+			other, list = d.TaskID, &links.Blocks
+		}
+		t, err := repo.GetTask(ctx, other)
+		if err != nil {
+			return links, fmt.Errorf("getting task: %w", err)
+		}
+		if t != nil {
+			*list = append(*list, t)
+		}
+	}
+	return links, nil
+}
+
+// OrderViolation is a dependency whose blocked task starts before its
+// prerequisite ends.
+type OrderViolation struct {
+	Task      *Task
+	BlockedBy *Task
+}
+
+func (v OrderViolation) String() string {
+	return fmt.Sprintf("%s starts before %s ends", v.Task.Description, v.BlockedBy.Description)
+}
+
+// OrderViolations returns the dependencies of the given tasks that their
+// times break, with the tasks where they are in tasks and as stored
+// otherwise. Cancelled and postponed tasks block nothing.
+func OrderViolations(ctx context.Context, repo Repository, tasks []*Task) ([]OrderViolation, error) {
+	store, ok := repo.(DependencyStore)
+	if !ok || len(tasks) == 0 {
+		return nil, nil
+	}
+	byID := make(map[int64]*Task, len(tasks))
+	ids := make([]int64, len(tasks))
+	for i, t := range tasks {
+		byID[t.ID] = t
+		ids[i] = t.ID
+	}
+	deps, err := store.Dependencies(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("listing dependencies: %w", err)
+	}
+	lookup := func(id int64) (*Task, error) {
+		if t, ok := byID[id]; ok {
+			return t, nil
+		}
+		t, err := repo.GetTask(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("getting task: %w", err)
+		}
+		byID[id] = t
+		return t, nil
+	}
+
+	var violations []OrderViolation
+	for _, d := range deps {
+		t, err := lookup(d.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		prerequisite, err := lookup(d.BlockedBy)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil || prerequisite == nil || !t.IsScheduled() || !prerequisite.IsScheduled() {
+			continue
+		}
+		if t.StartsBeforeEndOf(prerequisite) {
+			violations = append(violations, OrderViolation{Task: t, BlockedBy: prerequisite})
+		}
+	}
+	return violations, nil
+}
+
+// getTask returns task id, or an error wrapping ErrTaskNotFound.
+func getTask(ctx context.Context, repo Repository, id int64) (*Task, error) {
+	t, err := repo.GetTask(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting task: %w", err)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("task #%d: %w", id, ErrTaskNotFound)
+	}
+	return t, nil
+}
@@ -0,0 +1,64 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidEnergy is returned for an unknown energy level.
+var ErrInvalidEnergy = errors.New("energy must be 'high', 'medium' or 'low'")
+
+// Energy is how demanding a task is. The zero value means unset.
+type Energy string
+
+const (
+	EnergyHigh   Energy = "high"
+	EnergyMedium Energy = "medium"
+	EnergyLow    Energy = "low"
+)
+
+// ParseEnergy parses an energy level. An empty string is valid and means unset.
+func ParseEnergy(s string) (Energy, error) {
+	switch Energy(s) {
+	case "", EnergyHigh, EnergyMedium, EnergyLow:
+		return Energy(s), nil
+	default:
+		return "", ErrInvalidEnergy
+	}
+}
+
+// Next cycles through the levels: unset -> high -> medium -> low -> unset.
+func (e Energy) Next() Energy {
+	switch e {
+	case "":
+		return EnergyHigh
+	case EnergyHigh:
+		return EnergyMedium
+	case EnergyMedium:
+		return EnergyLow
+	default:
+		return ""
+	}
+}
+
+// EnergyWarning returns a warning when a high-energy task overlaps a
+// low-energy window. levelAt reports the configured energy at a minute of
+// the day (0-1439) and returns "" where no level is configured.
+func EnergyWarning(description string, energy Energy, start, end string, levelAt func(minute int) Energy) string {
+	if energy != EnergyHigh || levelAt == nil {
+		return ""
+	}
+	startMin, endMin := TimeToMinutes(start), TimeToMinutes(end)
+	for m := startMin; m < endMin; m++ {
+		if levelAt(m) != EnergyLow {
+			continue
+		}
+		windowEnd := m
+		for windowEnd < endMin && levelAt(windowEnd) == EnergyLow {
+			windowEnd++
+		}
+		return fmt.Sprintf("High-energy task %q is scheduled in a low-energy window (%s-%s)",
+			description, MinutesToTime(m), MinutesToTime(windowEnd))
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseEnergy(t *testing.T) {
+	for _, s := range []string{"", "high", "medium", "low"} {
+		if got, err := ParseEnergy(s); err != nil || string(got) != s {
+			t.Errorf("ParseEnergy(%q) = %q, %v", s, got, err)
+		}
+	}
+	if _, err := ParseEnergy("extreme"); !errors.Is(err, ErrInvalidEnergy) {
+		t.Errorf("ParseEnergy(extreme) error = %v, want ErrInvalidEnergy", err)
+	}
+}
+
+func TestEnergyNext(t *testing.T) {
+	e := Energy("")
+	want := []Energy{EnergyHigh, EnergyMedium, EnergyLow, ""}
+	for _, w := range want {
+		e = e.Next()
+		if e != w {
+			t.Fatalf("Next() = %q, want %q", e, w)
+		}
+	}
+}
+
+func TestEnergyWarning(t *testing.T) {
+	// Low energy from 13:00 to 15:00
+	levelAt := func(minute int) Energy {
+		if minute >= 13*60 && minute < 15*60 {
+			return EnergyLow
+		}
+		return EnergyHigh
+	}
+
+	tests := []struct {
+		name   string
+		energy Energy
+		start  string
+		end    string
+		want   string
+	}{
+		{"high in low window", EnergyHigh, "14:00", "16:00", "low-energy window (14:00-15:00)"},
+		{"high overlapping start", EnergyHigh, "12:30", "13:30", "low-energy window (13:00-13:30)"},
+		{"high outside window", EnergyHigh, "09:00", "11:00", ""},
+		{"low in low window", EnergyLow, "13:00", "14:00", ""},
+		{"unset", "", "13:00", "14:00", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EnergyWarning("Write", tt.energy, tt.start, tt.end, levelAt)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("EnergyWarning() = %q, want none", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("EnergyWarning() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
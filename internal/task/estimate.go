@@ -0,0 +1,128 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MinEstimateSamples is how many similar tasks must have a recorded actual
+// duration before EstimateHint says anything.
+const MinEstimateSamples = 3
+
+// EstimateStore is implemented by repositories that record how long tasks
+// actually took, to learn how far off the scheduled blocks tend to be.
+type EstimateStore interface {
+	// RecordActual stores that t took actualMinutes, against the minutes it
+	// was scheduled for. Recording a task again replaces its record.
+	RecordActual(ctx context.Context, t *Task, actualMinutes int) error
+
+	// ForgetActual drops the record of task id, if any.
+	ForgetActual(ctx context.Context, id int64) error
+
+	// EstimateAccuracy sums the records of the tasks of category whose
+	// description has key as its DescriptionKey.
+	EstimateAccuracy(ctx context.Context, category Category, key string) (EstimateAccuracy, error)
+}
+
+// EstimateAccuracy is how long a group of similar tasks took against the
+// time scheduled for them.
+type EstimateAccuracy struct {
+	Samples          int
+	EstimatedMinutes int
+	ActualMinutes    int
+}
+
+// Ratio returns actual time over estimated time, 1 when nothing is known.
+func (a EstimateAccuracy) Ratio() float64 {
+	if a.EstimatedMinutes <= 0 || a.ActualMinutes <= 0 {
+		return 1
+	}
+	return float64(a.ActualMinutes) / float64(a.EstimatedMinutes)
+}
+
+// DescriptionKey reduces a description to its lowercase words, dropping
+// numbers and punctuation, so "Weekly report #12" and "weekly report 13"
+// count as similar tasks.
+func DescriptionKey(description string) string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// RecordActual records how long t took through repo's EstimateStore. An
+// actualMinutes of 0 forgets the record instead. Repositories without a
+// store record nothing.
+func RecordActual(ctx context.Context, repo Repository, t *Task, actualMinutes int) error {
+	store, ok := repo.(EstimateStore)
+	if !ok {
+		return nil
+	}
+	if actualMinutes <= 0 {
+		return store.ForgetActual(ctx, t.ID)
+	}
+	return store.RecordActual(ctx, t, actualMinutes)
+}
+
+// RecordReviewActuals records, for the reviewed tasks, the scheduled time of
+// those rated on time and forgets the rest: over and under say a task ran
+// long or short, not by how much.
+func RecordReviewActuals(ctx context.Context, repo Repository, tasks []*Task, entries []ReviewEntry) error {
+	byID := make(map[int64]*Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+	for _, e := range entries {
+		t, ok := byID[e.ID]
+		if !ok {
+			continue
+		}
+		actual := 0
+		if e.Outcome == OutcomeOnTime {
+			actual = t.Duration()
+		}
+		if err := RecordActual(ctx, repo, t, actual); err != nil {
+			return fmt.Errorf("task #%d: %w", e.ID, err)
+		}
+	}
+	return nil
+}
+
+// EstimateHint returns a note on how long tasks similar to t usually take
+// against their blocks, e.g. "similar tasks usually take 1.5× longer". It is
+// empty until MinEstimateSamples similar tasks have been recorded, and when
+// they land within 10% of their estimates.
+func EstimateHint(ctx context.Context, repo Repository, t *Task) (string, error) {
+	store, ok := repo.(EstimateStore)
+	if !ok {
+		return "", nil
+	}
+	key := DescriptionKey(t.Description)
+	if key == "" {
+		return "", nil
+	}
+	acc, err := store.EstimateAccuracy(ctx, t.Category, key)
+	if err != nil {
+		return "", fmt.Errorf("reading estimate accuracy: %w", err)
+	}
+	if acc.Samples < MinEstimateSamples {
+		return "", nil
+	}
+	ratio := acc.Ratio()
+	switch {
+	case ratio >= 1.1:
+		return fmt.Sprintf("similar tasks usually take %s× longer", formatRatio(ratio)), nil
+	case ratio <= 0.9:
+		return fmt.Sprintf("similar tasks usually take %s× the time", formatRatio(ratio)), nil
+	default:
+		return "", nil
+	}
+}
+
+// formatRatio formats r with one decimal, dropping a trailing ".0".
+func formatRatio(r float64) string {
+	return strings.TrimSuffix(strconv.FormatFloat(r, 'f', 1, 64), ".0")
+}
@@ -0,0 +1,49 @@
+package task
+
+import (
+	"errors"
+	"strings"
+)
+
+// External reference errors.
+var (
+	ErrInvalidExternalRef = errors.New("external reference must be <source>:<id>")
+	ErrMissingExternalRef = errors.New("task has no external reference")
+)
+
+// ExternalRef identifies the copy of a task in another system, such as a
+// Jira issue or a calendar event. Importers and sync integrations set it so
+// that running them again updates the task instead of adding a duplicate.
+// The zero value means the task has no external copy.
+type ExternalRef struct {
+	Source string // e.g. "jira", "todoist", "ics"
+	ID     string // The task's ID within the source
+}
+
+// ParseExternalRef parses a "source:id" reference. The source is lowercased;
+// the ID is kept as is and may itself contain colons. An empty string parses
+// to the zero value.
+func ParseExternalRef(s string) (ExternalRef, error) {
+	if s == "" {
+		return ExternalRef{}, nil
+	}
+	source, id, ok := strings.Cut(s, ":")
+	source = strings.ToLower(strings.TrimSpace(source))
+	if !ok || source == "" || id == "" {
+		return ExternalRef{}, ErrInvalidExternalRef
+	}
+	return ExternalRef{Source: source, ID: id}, nil
+}
+
+// IsZero reports whether the reference is unset.
+func (r ExternalRef) IsZero() bool {
+	return r.Source == "" && r.ID == ""
+}
+
+// String formats the reference as "source:id", or "" when unset.
+func (r ExternalRef) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	return r.Source + ":" + r.ID
+}
@@ -0,0 +1,44 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseExternalRef(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ExternalRef
+		wantErr bool
+	}{
+		{"", ExternalRef{}, false},
+		{"jira:PROJ-12", ExternalRef{Source: "jira", ID: "PROJ-12"}, false},
+		{"ICS:event@example.com", ExternalRef{Source: "ics", ID: "event@example.com"}, false},
+		{"sancho:/tmp/other.db#4", ExternalRef{Source: "sancho", ID: "/tmp/other.db#4"}, false},
+		{"todoist:a:b", ExternalRef{Source: "todoist", ID: "a:b"}, false},
+		{"jira", ExternalRef{}, true},
+		{":12", ExternalRef{}, true},
+		{"jira:", ExternalRef{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseExternalRef(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidExternalRef) {
+					t.Fatalf("ParseExternalRef(%q) error = %v, want ErrInvalidExternalRef", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseExternalRef(%q) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseExternalRef(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("String() = %q, want %q", got.String(), tt.want.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaskFilter selects tasks for ListTasks. Zero fields match every task.
+type TaskFilter struct {
+	From     time.Time // First scheduled date, inclusive
+	To       time.Time // Last scheduled date, inclusive
+	Statuses []Status
+	Category Category
+	Query    string // Case-insensitive text the description contains
+}
+
+// Match reports whether t passes the filter.
+func (f TaskFilter) Match(t *Task) bool {
+	if !f.From.IsZero() && CalendarDaysBetween(f.From, t.ScheduledDate) < 0 {
+		return false
+	}
+	if !f.To.IsZero() && CalendarDaysBetween(t.ScheduledDate, f.To) < 0 {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status) {
+		return false
+	}
+	if f.Category != "" && t.Category != f.Category {
+		return false
+	}
+	return f.Query == "" || strings.Contains(strings.ToLower(t.Description), strings.ToLower(f.Query))
+}
+
+// TaskPager is implemented by repositories that can filter and page through
+// tasks without loading them all.
+type TaskPager interface {
+	// ListTasks returns the tasks matching filter ordered by date, start
+	// time and ID, skipping the first offset. A limit of zero or less
+	// returns every remaining task.
+	ListTasks(ctx context.Context, filter TaskFilter, limit, offset int) ([]*Task, error)
+}
+
+// ErrUnboundedFilter is returned by ListTasks when the repository cannot
+// page through tasks and the filter has no date range to load instead.
+var ErrUnboundedFilter = errors.New("filter needs a date range")
+
+// ListTasks pages through the tasks of repo matching filter, ordered by
+// date, start time and ID. Repositories that are not a TaskPager are read a
+// date range at a time, so the filter needs both From and To.
+func ListTasks(ctx context.Context, repo Repository, filter TaskFilter, limit, offset int) ([]*Task, error) {
+	if p, ok := repo.(TaskPager); ok {
+		return p.ListTasks(ctx, filter, limit, offset)
+	}
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return nil, ErrUnboundedFilter
+	}
+	all, err := repo.ListTasksByDateRange(ctx, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []*Task
+	for _, t := range all {
+		if filter.Match(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	SortByTime(tasks)
+	return Page(tasks, limit, offset), nil
+}
+
+// SortByTime orders tasks by date, start time and ID.
+func SortByTime(tasks []*Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if d := CalendarDaysBetween(tasks[j].ScheduledDate, tasks[i].ScheduledDate); d != 0 {
+			return d < 0
+		}
+		if tasks[i].ScheduledStart != tasks[j].ScheduledStart {
+			return tasks[i].ScheduledStart < tasks[j].ScheduledStart
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// Page returns the tasks after the first offset, at most limit of them. A
+// limit of zero or less keeps every remaining task.
+func Page(tasks []*Task, limit, offset int) []*Task {
+	if offset > 0 {
+		if offset >= len(tasks) {
+			return nil
+		}
+		tasks = tasks[offset:]
+	}
+	if limit > 0 && limit < len(tasks) {
+		tasks = tasks[:limit]
+	}
+	return tasks
+}
@@ -0,0 +1,52 @@
+package task
+
+// GoalProgress tracks a weekly time target against scheduled work.
+// Done counts blocks that have already ended; Planned counts blocks still
+// ahead in the week.
+type GoalProgress struct {
+	Label   string
+	Target  int // Minutes
+	Done    int // Minutes
+	Planned int // Minutes
+}
+
+// NewGoalProgress sums the scheduled tasks matching category (all categories
+// when empty) and splits them into done and planned using isDone.
+func NewGoalProgress(label string, category Category, target int, tasks []*Task, isDone func(*Task) bool) GoalProgress {
+	g := GoalProgress{Label: label, Target: target}
+	for _, t := range tasks {
+		if !t.IsScheduled() || (category != "" && t.Category != category) {
+			continue
+		}
+		if isDone(t) {
+			g.Done += t.Duration()
+		} else {
+			g.Planned += t.Duration()
+		}
+	}
+	return g
+}
+
+// Total returns done plus planned minutes.
+func (g GoalProgress) Total() int {
+	return g.Done + g.Planned
+}
+
+// Percent returns scheduled time as a percentage of the target, capped at 100.
+func (g GoalProgress) Percent() int {
+	if g.Target <= 0 {
+		return 0
+	}
+	return min(100, g.Total()*100/g.Target)
+}
+
+// Over reports whether the scheduled time passes the target, for targets
+// that are budgets rather than goals.
+func (g GoalProgress) Over() bool {
+	return g.Target > 0 && g.Total() > g.Target
+}
+
+// Met reports whether the scheduled time reaches the target.
+func (g GoalProgress) Met() bool {
+	return g.Target > 0 && g.Total() >= g.Target
+}
@@ -0,0 +1,45 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewGoalProgress(t *testing.T) {
+	day := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	tasks := []*Task{
+		{Category: CategoryDeep, ScheduledDate: day, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: StatusScheduled},
+		{Category: CategoryDeep, ScheduledDate: day, ScheduledStart: "13:00", ScheduledEnd: "14:00", Status: StatusScheduled},
+		{Category: CategoryShallow, ScheduledDate: day, ScheduledStart: "11:00", ScheduledEnd: "11:30", Status: StatusScheduled},
+		{Category: CategoryDeep, ScheduledDate: day, ScheduledStart: "15:00", ScheduledEnd: "16:00", Status: StatusCancelled},
+	}
+	isDone := func(t *Task) bool { return t.ScheduledStart < "12:00" }
+
+	tests := []struct {
+		name        string
+		category    Category
+		target      int
+		wantDone    int
+		wantPlanned int
+		wantPercent int
+		wantMet     bool
+	}{
+		{"deep", CategoryDeep, 240, 120, 60, 75, false},
+		{"all categories", "", 180, 150, 60, 100, true},
+		{"no target", CategoryDeep, 0, 120, 60, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGoalProgress("x", tt.category, tt.target, tasks, isDone)
+			if g.Done != tt.wantDone || g.Planned != tt.wantPlanned {
+				t.Errorf("done/planned = %d/%d, want %d/%d", g.Done, g.Planned, tt.wantDone, tt.wantPlanned)
+			}
+			if g.Percent() != tt.wantPercent {
+				t.Errorf("Percent() = %d, want %d", g.Percent(), tt.wantPercent)
+			}
+			if g.Met() != tt.wantMet {
+				t.Errorf("Met() = %v, want %v", g.Met(), tt.wantMet)
+			}
+		})
+	}
+}
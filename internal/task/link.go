@@ -0,0 +1,38 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// URLScheme is the scheme of task deep links.
+const URLScheme = "sancho"
+
+// ErrInvalidTaskURL is returned for a link that does not point at a task.
+var ErrInvalidTaskURL = errors.New("invalid task link (expected sancho://task/<id>)")
+
+// TaskURL returns the deep link for a task, e.g. "sancho://task/123".
+func TaskURL(id int64) string {
+	return fmt.Sprintf("%s://task/%d", URLScheme, id)
+}
+
+// ParseTaskURL returns the task ID of a deep link. A bare ID is also
+// accepted, so callers can take either form from the command line.
+func ParseTaskURL(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, URLScheme+":"); ok {
+		rest = strings.TrimPrefix(rest, "//")
+		path, ok := strings.CutPrefix(rest, "task/")
+		if !ok {
+			return 0, ErrInvalidTaskURL
+		}
+		s = strings.TrimRight(path, "/")
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidTaskURL
+	}
+	return id, nil
+}
@@ -0,0 +1,47 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTaskURL_RoundTrip(t *testing.T) {
+	url := TaskURL(123)
+	if url != "sancho://task/123" {
+		t.Fatalf("TaskURL = %q", url)
+	}
+	id, err := ParseTaskURL(url)
+	if err != nil || id != 123 {
+		t.Fatalf("ParseTaskURL(%q) = %d, %v", url, id, err)
+	}
+}
+
+func TestParseTaskURL(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "sancho://task/7", want: 7},
+		{in: "sancho://task/7/", want: 7},
+		{in: "sancho:task/7", want: 7},
+		{in: " 42 ", want: 42},
+		{in: "sancho://week/7", wantErr: true},
+		{in: "sancho://task/abc", wantErr: true},
+		{in: "sancho://task/0", wantErr: true},
+		{in: "http://task/7", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTaskURL(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidTaskURL) {
+				t.Errorf("ParseTaskURL(%q) error = %v, want ErrInvalidTaskURL", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseTaskURL(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MissedMarker is implemented by repositories that can store the missed status.
+type MissedMarker interface {
+	// MarkTasksMissed sets the given scheduled tasks to missed. Tasks that
+	// are no longer scheduled are left alone.
+	MarkTasksMissed(ctx context.Context, ids []int64) error
+}
+
+// FindMissed returns the scheduled tasks that ended before now without an
+// outcome, ordered by date and start time.
+func FindMissed(tasks []*Task, now time.Time) []*Task {
+	var missed []*Task
+	for _, t := range tasks {
+		if t.IsScheduled() && t.Outcome == nil && t.IsPastAt(now) {
+			missed = append(missed, t)
+		}
+	}
+	sort.SliceStable(missed, func(i, j int) bool {
+		if !missed[i].ScheduledDate.Equal(missed[j].ScheduledDate) {
+			return missed[i].ScheduledDate.Before(missed[j].ScheduledDate)
+		}
+		return missed[i].ScheduledStart < missed[j].ScheduledStart
+	})
+	return missed
+}
+
+// MarkMissed marks the tasks scheduled from since up to now that ended
+// without an outcome as missed, and returns them with the new status.
+// Repositories that cannot store the status are left untouched.
+func MarkMissed(ctx context.Context, repo Repository, since, now time.Time) ([]*Task, error) {
+	marker, ok := repo.(MissedMarker)
+	if !ok {
+		return nil, nil
+	}
+	tasks, err := repo.ListTasksByDateRange(ctx, since, now)
+	if err != nil {
+		return nil, fmt.Errorf("listing tasks: %w", err)
+	}
+	missed := FindMissed(tasks, now)
+	if len(missed) == 0 {
+		return nil, nil
+	}
+	ids := make([]int64, len(missed))
+	for i, t := range missed {
+		ids[i] = t.ID
+	}
+	if err := marker.MarkTasksMissed(ctx, ids); err != nil {
+		return nil, err
+	}
+	for _, t := range missed {
+		t.Status = StatusMissed
+		t.Version = 0 // Changed by the write; unknown until read again
+	}
+	return missed, nil
+}
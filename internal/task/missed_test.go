@@ -0,0 +1,40 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindMissed(t *testing.T) {
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	now := day.Add(12 * time.Hour)
+	onTime := OutcomeOnTime
+	mk := func(id int64, date time.Time, start, end string, status Status) *Task {
+		return &Task{ID: id, ScheduledDate: date, ScheduledStart: start, ScheduledEnd: end, Status: status}
+	}
+	reviewed := mk(4, day, "08:00", "09:00", StatusScheduled)
+	reviewed.Outcome = &onTime
+	tasks := []*Task{
+		mk(1, day, "10:00", "11:00", StatusScheduled),
+		mk(2, day.AddDate(0, 0, -1), "22:00", "01:00", StatusScheduled),
+		mk(3, day, "11:30", "12:30", StatusScheduled), // still running
+		reviewed,
+		mk(5, day, "09:00", "10:00", StatusCancelled),
+		mk(6, day.AddDate(0, 0, -1), "09:00", "10:00", StatusScheduled),
+	}
+
+	missed := FindMissed(tasks, now)
+	var ids []int64
+	for _, m := range missed {
+		ids = append(ids, m.ID)
+	}
+	want := []int64{6, 2, 1}
+	if len(ids) != len(want) {
+		t.Fatalf("missed = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("missed = %v, want %v", ids, want)
+		}
+	}
+}
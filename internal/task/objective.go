@@ -0,0 +1,148 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrNoObjectives is returned when the repository cannot hold objectives.
+var ErrNoObjectives = errors.New("this database has no objectives")
+
+// ErrInvalidQuarter is returned for a quarter not written like "2025-Q1".
+var ErrInvalidQuarter = errors.New("invalid quarter (expected YYYY-QN, e.g. 2025-Q1)")
+
+// Objective is a quarterly goal that tasks can be attached to, so the time
+// spent on it adds up across weeks.
+type Objective struct {
+	ID            int64
+	Title         string
+	Quarter       string // e.g. "2025-Q1"
+	TargetMinutes int    // 0 when the objective has no target
+	CreatedAt     time.Time
+}
+
+// Objectives is implemented by repositories that keep quarterly objectives
+// above the tasks.
+type Objectives interface {
+	// CreateObjective stores o and sets its ID.
+	CreateObjective(ctx context.Context, o *Objective) error
+
+	// ListObjectives returns the objectives of quarter, or of every quarter
+	// when it is empty, oldest first.
+	ListObjectives(ctx context.Context, quarter string) ([]*Objective, error)
+
+	// AttachTask attaches a task to an objective, replacing the one it was
+	// attached to. An objectiveID of 0 detaches the task.
+	AttachTask(ctx context.Context, taskID, objectiveID int64) error
+
+	// TaskObjectives returns the objective of each of the given tasks. Tasks
+	// not attached to one are left out.
+	TaskObjectives(ctx context.Context, ids []int64) (map[int64]int64, error)
+}
+
+// ObjectivesOf returns repo's objectives, or ErrNoObjectives if it has none.
+func ObjectivesOf(repo Repository) (Objectives, error) {
+	o, ok := repo.(Objectives)
+	if !ok {
+		return nil, ErrNoObjectives
+	}
+	return o, nil
+}
+
+// Quarter returns the quarter holding t, e.g. "2025-Q1".
+func Quarter(t time.Time) string {
+	return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+}
+
+// QuarterRange returns the first and last day of quarter, in loc.
+func QuarterRange(quarter string, loc *time.Location) (time.Time, time.Time, error) {
+	var year, q int
+	if n, err := fmt.Sscanf(quarter, "%4d-Q%1d", &year, &q); err != nil || n != 2 || q < 1 || q > 4 || len(quarter) != 7 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%q: %w", quarter, ErrInvalidQuarter)
+	}
+	start := time.Date(year, time.Month(3*(q-1)+1), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 3, -1), nil
+}
+
+// ObjectiveProgress is the time attached to an objective within its
+// quarter. Completed counts blocks that have ended; Scheduled counts every
+// scheduled block, completed ones included.
+type ObjectiveProgress struct {
+	Objective        *Objective
+	ScheduledMinutes int
+	CompletedMinutes int
+}
+
+// Percent returns completed time as a percentage of the target, capped at
+// 100. Objectives without a target report 0.
+func (p ObjectiveProgress) Percent() int {
+	if p.Objective.TargetMinutes <= 0 {
+		return 0
+	}
+	return min(100, p.CompletedMinutes*100/p.Objective.TargetMinutes)
+}
+
+// QuarterProgress sums the scheduled tasks attached to each objective of
+// quarter. Tasks that ended before now count as completed. The objectives
+// are ordered by the share of their target still to schedule, the furthest
+// behind first; those without a target come last.
+func QuarterProgress(ctx context.Context, repo Repository, quarter string, now time.Time) ([]ObjectiveProgress, error) {
+	store, err := ObjectivesOf(repo)
+	if err != nil {
+		return nil, err
+	}
+	objectives, err := store.ListObjectives(ctx, quarter)
+	if err != nil {
+		return nil, fmt.Errorf("listing objectives: %w", err)
+	}
+	if len(objectives) == 0 {
+		return nil, nil
+	}
+	start, end, err := QuarterRange(quarter, now.Location())
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := repo.ListTasksByDateRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("listing tasks: %w", err)
+	}
+	ids := make([]int64, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	attached, err := store.TaskObjectives(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("listing task objectives: %w", err)
+	}
+
+	progress := make([]ObjectiveProgress, len(objectives))
+	index := make(map[int64]int, len(objectives))
+	for i, o := range objectives {
+		progress[i].Objective = o
+		index[o.ID] = i
+	}
+	for _, t := range tasks {
+		i, ok := index[attached[t.ID]]
+		if !ok || !t.IsScheduled() {
+			continue
+		}
+		progress[i].ScheduledMinutes += t.Duration()
+		if t.IsPastAt(now) {
+			progress[i].CompletedMinutes += t.Duration()
+		}
+	}
+
+	behind := func(p ObjectiveProgress) float64 {
+		if p.Objective.TargetMinutes <= 0 {
+			return -1
+		}
+		return 1 - float64(p.ScheduledMinutes)/float64(p.Objective.TargetMinutes)
+	}
+	sort.SliceStable(progress, func(i, j int) bool {
+		return behind(progress[i]) > behind(progress[j])
+	})
+	return progress, nil
+}
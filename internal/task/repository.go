@@ -8,11 +8,54 @@ import (
 // TaskTimeUpdate represents a task time change for batch updates.
 type TaskTimeUpdate struct {
 	ID       int64
+	Date     time.Time // Day to move the task to; zero for the batch's date
 	NewStart string
 	NewEnd   string
+	Version  int64 // The task's Version when read; if set, a task written since fails with ErrTaskChanged
 }
 
-// Repository defines the storage interface for tasks.
+// DateOr returns the day the update moves its task to, date unless the
+// update names its own.
+func (u TaskTimeUpdate) DateOr(date time.Time) time.Time {
+	if u.Date.IsZero() {
+		return date
+	}
+	return u.Date
+}
+
+// TaskUpdate is one change applied by BatchUpdate. Empty fields keep the
+// task's current value.
+type TaskUpdate struct {
+	ID       int64
+	Cancel   bool // Mark the task cancelled; the other fields are ignored
+	Postpone bool // Mark the task postponed and schedule a copy at the new slot; missed tasks stay missed
+	Date     time.Time
+	Start    string
+	End      string
+	Category Category
+	Version  int64 // The task's Version when read; if set, a task written since fails with ErrTaskChanged
+}
+
+// Apply returns t with the update's slot and category.
+func (u TaskUpdate) Apply(t Task) Task {
+	if !u.Date.IsZero() {
+		t.ScheduledDate = u.Date
+	}
+	if u.Start != "" {
+		t.ScheduledStart = u.Start
+	}
+	if u.End != "" {
+		t.ScheduledEnd = u.End
+	}
+	if u.Category != "" {
+		t.Category = u.Category
+	}
+	return t
+}
+
+// Repository defines the storage interface for tasks. Writes to one
+// existing task take the Version the caller read it at; when it is set and
+// the task was written since, they fail with ErrTaskChanged and write nothing.
 type Repository interface {
 	// CreateTask adds a new task to the repository.
 	CreateTask(ctx context.Context, task *Task) error
@@ -21,10 +64,25 @@ type Repository interface {
 	GetTask(ctx context.Context, id int64) (*Task, error)
 
 	// CancelTask marks a task as cancelled.
-	CancelTask(ctx context.Context, id int64) error
+	CancelTask(ctx context.Context, id, version int64) error
 
 	// SetTaskOutcome sets the outcome of a task during review.
-	SetTaskOutcome(ctx context.Context, id int64, outcome Outcome) error
+	SetTaskOutcome(ctx context.Context, id int64, outcome Outcome, version int64) error
+
+	// SetTaskEnergy sets the energy level of a task. An empty level clears it.
+	SetTaskEnergy(ctx context.Context, id int64, energy Energy, version int64) error
+
+	// GetTaskByExternalRef retrieves the task linked to an external reference.
+	// Returns nil without an error when no task has the reference.
+	GetTaskByExternalRef(ctx context.Context, ref ExternalRef) (*Task, error)
+
+	// UpsertTask creates the task, or updates the task with the same external
+	// reference in place: its description, date, times and energy are
+	// refreshed while status and outcome are kept. Reports whether a new task
+	// was created. Returns ErrMissingExternalRef if the task has no reference,
+	// and ErrTaskChanged if the task carries a Version and the stored one was
+	// written since.
+	UpsertTask(ctx context.Context, task *Task) (bool, error)
 
 	// ListTasksByDateRange returns all tasks scheduled within the date range (inclusive).
 	ListTasksByDateRange(ctx context.Context, start, end time.Time) ([]*Task, error)
@@ -34,22 +92,49 @@ type Repository interface {
 
 	// PostponeTask atomically marks the original task as postponed and creates a new task.
 	// Returns the newly created task.
-	PostponeTask(ctx context.Context, taskID int64, newDate time.Time, newStart, newEnd string) (*Task, error)
+	PostponeTask(ctx context.Context, taskID int64, newDate time.Time, newStart, newEnd string, version int64) (*Task, error)
 
 	// UpdateTask updates a task's scheduled times in place.
 	// Used for minor adjustments like grow/shrink operations.
 	// Returns ErrTimeBlockOverlap if the new times conflict with another task.
-	UpdateTask(ctx context.Context, id int64, newStart, newEnd string) error
+	UpdateTask(ctx context.Context, id int64, newStart, newEnd string, version int64) error
 
 	// UpdateTaskDescription updates a task description in place.
 	// Returns ErrEmptyDescription if the description is empty.
-	UpdateTaskDescription(ctx context.Context, id int64, description string) error
+	UpdateTaskDescription(ctx context.Context, id int64, description string, version int64) error
 
-	// BatchUpdateTaskTimes updates multiple tasks' times atomically.
-	// It validates that the final state has no overlaps before applying changes.
+	// BatchUpdateTaskTimes moves multiple tasks to new times atomically, on
+	// date or on each update's own Date. It validates that the final state
+	// has no overlaps, and that no task was written since the Version an
+	// update carries, before applying changes.
 	// Used for move operations where multiple tasks shift positions.
 	BatchUpdateTaskTimes(ctx context.Context, date time.Time, updates []TaskTimeUpdate) error
 
+	// BatchUpdate cancels, postpones, moves or recategorizes several
+	// scheduled tasks atomically. Missed tasks may be postponed as well. The final schedule is checked for overlaps,
+	// updates carrying a Version for tasks written since fail with
+	// ErrTaskChanged, and nothing is written if any update fails.
+	BatchUpdate(ctx context.Context, updates []TaskUpdate) error
+
 	// Close releases any resources held by the repository.
 	Close() error
 }
+
+// OverlapAllower is implemented by repositories that can be configured to
+// store overlapping time blocks instead of returning ErrTimeBlockOverlap.
+type OverlapAllower interface {
+	AllowsOverlaps() bool
+}
+
+// AllowsOverlaps reports whether repo stores overlapping time blocks.
+func AllowsOverlaps(repo Repository) bool {
+	a, ok := repo.(OverlapAllower)
+	return ok && a.AllowsOverlaps()
+}
+
+// DataVersioner is implemented by repositories that can tell when their
+// contents change, including writes made by another process.
+type DataVersioner interface {
+	// DataVersion returns a value that differs after any task is written.
+	DataVersion(ctx context.Context) (string, error)
+}
@@ -0,0 +1,41 @@
+package task
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReviewEntry is the outcome, and optionally a note, given to one past task
+// during review.
+type ReviewEntry struct {
+	ID      int64
+	Outcome Outcome
+	Note    string // Replaces the stored note; empty removes it
+}
+
+// Reviewer is implemented by repositories that keep review notes on tasks
+// and can save a whole review at once.
+type Reviewer interface {
+	// SaveReview sets the outcome and note of every entry, all or nothing.
+	// Like SetTaskOutcome, a missed task that was not rescheduled becomes
+	// scheduled again.
+	SaveReview(ctx context.Context, entries []ReviewEntry) error
+
+	// TaskNotes returns the notes of the tasks with the given IDs. Tasks
+	// without a note are left out.
+	TaskNotes(ctx context.Context, ids []int64) (map[int64]string, error)
+}
+
+// SaveReview saves entries through repo's Reviewer. Repositories without
+// one get the outcomes set one at a time and the notes dropped.
+func SaveReview(ctx context.Context, repo Repository, entries []ReviewEntry) error {
+	if r, ok := repo.(Reviewer); ok {
+		return r.SaveReview(ctx, entries)
+	}
+	for _, e := range entries {
+		if err := repo.SetTaskOutcome(ctx, e.ID, e.Outcome, 0); err != nil {
+			return fmt.Errorf("task #%d: %w", e.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Revision is a task's description and schedule as they were before one
+// change.
+type Revision struct {
+	ID             int64
+	TaskID         int64
+	ChangedAt      time.Time // When the change replaced these values
+	Description    string
+	ScheduledDate  time.Time
+	ScheduledStart string
+	ScheduledEnd   string
+}
+
+// SameTime reports whether the revision has t's date and times.
+func (r Revision) SameTime(t *Task) bool {
+	return r.ScheduledDate.Equal(t.ScheduledDate) &&
+		r.ScheduledStart == t.ScheduledStart && r.ScheduledEnd == t.ScheduledEnd
+}
+
+// RevisionLister is implemented by repositories that record a revision every
+// time a task's description or schedule changes.
+type RevisionLister interface {
+	// ListTaskRevisions returns the task's earlier versions, newest first.
+	ListTaskRevisions(ctx context.Context, taskID int64) ([]Revision, error)
+}
+
+// RestoreRevision sets t back to the revision's description and time with
+// ordinary repository updates, so the restore is recorded as a revision
+// too. The time is moved first: if the old slot is taken nothing changes.
+func RestoreRevision(ctx context.Context, repo Repository, t *Task, rev Revision) error {
+	if rev.TaskID != t.ID {
+		return fmt.Errorf("revision %d belongs to task %d, not %d", rev.ID, rev.TaskID, t.ID)
+	}
+	if !t.IsScheduled() {
+		return fmt.Errorf("task %d is %s", t.ID, t.Status)
+	}
+	version := t.Version
+	if !rev.SameTime(t) {
+		update := TaskUpdate{ID: t.ID, Date: rev.ScheduledDate, Start: rev.ScheduledStart, End: rev.ScheduledEnd, Version: version}
+		if err := repo.BatchUpdate(ctx, []TaskUpdate{update}); err != nil {
+			return err
+		}
+		version = 0 // Checked by the move, which changed it
+	}
+	if rev.Description != t.Description {
+		if err := repo.UpdateTaskDescription(ctx, t.ID, rev.Description, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -12,7 +12,7 @@ import (
 // Validation errors.
 var (
 	ErrEmptyDescription  = errors.New("description cannot be empty")
-	ErrInvalidCategory   = errors.New("category must be 'deep' or 'shallow'")
+	ErrInvalidCategory   = errors.New("category must be 'deep', 'shallow' or a configured category")
 	ErrInvalidTimeFormat = errors.New("time must be in HH:MM format")
 	ErrEndBeforeStart    = errors.New("end time must be after start time")
 )
@@ -22,8 +22,13 @@ var (
 	ErrTimeBlockOverlap = errors.New("time block overlaps with existing task")
 	ErrCannotCancelPast = errors.New("cannot cancel past tasks")
 	ErrTaskNotFound     = errors.New("task not found")
+	ErrTaskChanged      = errors.New("task changed elsewhere")
 )
 
+// MaxOvernightMinutes is the longest a task that runs past midnight may
+// last. Longer blocks are more likely a typo (14:00-12:00) than a real plan.
+const MaxOvernightMinutes = 12 * 60
+
 // Status represents the state of a task.
 type Status string
 
@@ -31,6 +36,7 @@ const (
 	StatusScheduled Status = "scheduled"
 	StatusPostponed Status = "postponed"
 	StatusCancelled Status = "cancelled"
+	StatusMissed    Status = "missed" // Ended without an outcome
 )
 
 // Category represents the type of work.
@@ -69,16 +75,22 @@ type Task struct {
 	ScheduledStart string // "HH:MM" format
 	ScheduledEnd   string // "HH:MM" format
 	Status         Status
-	Outcome        *Outcome // optional, nil means assumed on_time
-	PostponedFrom  *int64   // FK to original task if postponed
+	Outcome        *Outcome    // optional, nil means assumed on_time
+	Energy         Energy      // optional, empty means unset
+	PostponedFrom  *int64      // FK to original task if postponed
+	ExternalRef    ExternalRef // optional, set by importers and sync integrations
+	Owner          string      // who the block belongs to in a shared schedule; empty for the default owner
 	CreatedAt      time.Time
+	Version        int64 // incremented on every write; zero if the repository does not track it
 }
 
-// New creates a new Task with validation.
-// date can be empty (defaults to today) or in YYYY-MM-DD format.
-// category must be "deep" or "shallow".
-// start and end must be in HH:MM format, with end after start.
-func New(description, category, date, start, end string) (*Task, error) {
+// New creates a new Task with validation, created at now.
+// date can be empty (defaults to now's day) or in YYYY-MM-DD format.
+// category must be "deep", "shallow" or a configured category.
+// start and end must be in HH:MM format, with end after start. An end before
+// the start makes an overnight task that ends on the following day, as long
+// as it lasts at most MaxOvernightMinutes.
+func New(description, category, date, start, end string, now time.Time) (*Task, error) {
 	if description == "" {
 		return nil, ErrEmptyDescription
 	}
@@ -88,7 +100,7 @@ func New(description, category, date, start, end string) (*Task, error) {
 		return nil, err
 	}
 
-	scheduledDate, err := dateutil.ParseDate(date)
+	scheduledDate, err := parseDate(date, now)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +113,10 @@ func New(description, category, date, start, end string) (*Task, error) {
 		return nil, fmt.Errorf("end time: %w", err)
 	}
 
-	if end <= start {
+	if end == start {
+		return nil, ErrEndBeforeStart
+	}
+	if s, e := Span(start, end); IsOvernight(start, end) && e-s > MaxOvernightMinutes {
 		return nil, ErrEndBeforeStart
 	}
 
@@ -112,19 +127,51 @@ func New(description, category, date, start, end string) (*Task, error) {
 		ScheduledStart: start,
 		ScheduledEnd:   end,
 		Status:         StatusScheduled,
-		CreatedAt:      time.Now(),
+		CreatedAt:      now,
+	}, nil
+}
+
+// NewAllDay creates a task that takes its whole day without blocking any
+// time in it, such as "On call" or "Conference". It is stored without start
+// and end times and overlaps nothing. date defaults to now's day, as in New.
+func NewAllDay(description, category, date string, now time.Time) (*Task, error) {
+	if description == "" {
+		return nil, ErrEmptyDescription
+	}
+
+	cat, err := parseCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledDate, err := parseDate(date, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Task{
+		Description:   description,
+		Category:      cat,
+		ScheduledDate: scheduledDate,
+		Status:        StatusScheduled,
+		CreatedAt:     now,
 	}, nil
 }
 
+// parseDate parses a YYYY-MM-DD date, or returns now's day when s is empty.
+func parseDate(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return dateutil.TruncateToDay(now), nil
+	}
+	return dateutil.ParseDate(s)
+}
+
 func parseCategory(s string) (Category, error) {
-	switch s {
-	case "deep":
-		return CategoryDeep, nil
-	case "shallow":
-		return CategoryShallow, nil
-	default:
+	c := Category(s)
+	if !c.Valid() {
 		return "", ErrInvalidCategory
 	}
+	return c, nil
 }
 
 func validateTimeFormat(s string) error {
@@ -152,6 +199,11 @@ func (t *Task) IsPostponed() bool {
 	return t.Status == StatusPostponed
 }
 
+// IsMissed returns true if the task has missed status.
+func (t *Task) IsMissed() bool {
+	return t.Status == StatusMissed
+}
+
 // IsDeep returns true if the task is categorized as deep work.
 func (t *Task) IsDeep() bool {
 	return t.Category == CategoryDeep
@@ -162,42 +214,63 @@ func (t *Task) IsShallow() bool {
 	return t.Category == CategoryShallow
 }
 
+// IsAllDay returns true if the task has no start and end times and spans
+// its whole day. All-day tasks take no time in the grid, never overlap
+// other tasks and are never marked missed.
+func (t *Task) IsAllDay() bool {
+	return t.ScheduledStart == "" && t.ScheduledEnd == ""
+}
+
+// TimeRange returns the task's times as "09:00-11:00", or "all day" for an
+// all-day task.
+func (t *Task) TimeRange() string {
+	if t.IsAllDay() {
+		return "all day"
+	}
+	return t.ScheduledStart + "-" + t.ScheduledEnd
+}
+
+// IsOvernight returns true if the task ends after midnight.
+func (t *Task) IsOvernight() bool {
+	return IsOvernight(t.ScheduledStart, t.ScheduledEnd)
+}
+
 // Duration returns the task duration in minutes.
 func (t *Task) Duration() int {
-	start, err1 := time.Parse("15:04", t.ScheduledStart)
-	end, err2 := time.Parse("15:04", t.ScheduledEnd)
-	if err1 != nil || err2 != nil {
+	if validateTimeFormat(t.ScheduledStart) != nil || validateTimeFormat(t.ScheduledEnd) != nil {
 		return 0
 	}
-	return int(end.Sub(start).Minutes())
+	start, end := Span(t.ScheduledStart, t.ScheduledEnd)
+	return end - start
 }
 
 // OverlapsWith returns true if this task overlaps with another task.
-// Tasks must be on the same day and have overlapping time ranges.
+// Tasks on different days only overlap when an overnight task runs into
+// the next day's block. Tasks of different owners never overlap.
 func (t *Task) OverlapsWith(other *Task) bool {
-	if other == nil {
-		return false
-	}
-	// Must be same day
-	if !t.ScheduledDate.Equal(other.ScheduledDate) {
+	if other == nil || other.Owner != t.Owner {
 		return false
 	}
-	// Check time overlap
-	return TimesOverlap(t.ScheduledStart, t.ScheduledEnd, other.ScheduledStart, other.ScheduledEnd)
+	return BlocksOverlap(t.ScheduledDate, t.ScheduledStart, t.ScheduledEnd,
+		other.ScheduledDate, other.ScheduledStart, other.ScheduledEnd)
 }
 
-// IsPast returns true if the task's scheduled end time has passed.
-func (t *Task) IsPast() bool {
-	now := time.Now()
+// IsPastAt reports whether the task ended before now. The scheduled times
+// are interpreted in now's location.
+func (t *Task) IsPastAt(now time.Time) bool {
 	endTime, err := time.Parse("15:04", t.ScheduledEnd)
 	if err != nil {
 		return false
 	}
 
+	day := t.ScheduledDate.Day()
+	if t.IsOvernight() {
+		day++
+	}
 	taskEnd := time.Date(
 		t.ScheduledDate.Year(),
 		t.ScheduledDate.Month(),
-		t.ScheduledDate.Day(),
+		day,
 		endTime.Hour(),
 		endTime.Minute(),
 		0, 0,
@@ -9,8 +9,10 @@ import (
 )
 
 func TestNew(t *testing.T) {
+	now := time.Date(2025, 1, 13, 8, 30, 0, 0, time.Local)
+
 	t.Run("valid task", func(t *testing.T) {
-		task, err := New("Write tests", "deep", "2025-01-15", "09:00", "11:00")
+		task, err := New("Write tests", "deep", "2025-01-15", "09:00", "11:00", now)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -29,24 +31,24 @@ func TestNew(t *testing.T) {
 		if task.Status != StatusScheduled {
 			t.Errorf("got status %q, want %q", task.Status, StatusScheduled)
 		}
-		if task.CreatedAt.IsZero() {
-			t.Error("expected CreatedAt to be set")
+		if !task.CreatedAt.Equal(now) {
+			t.Errorf("got CreatedAt %v, want %v", task.CreatedAt, now)
 		}
 	})
 
 	t.Run("empty date defaults to today", func(t *testing.T) {
-		task, err := New("Write tests", "deep", "", "09:00", "11:00")
+		task, err := New("Write tests", "deep", "", "09:00", "11:00", now)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		today := dateutil.TruncateToDay(time.Now())
+		today := dateutil.TruncateToDay(now)
 		if !task.ScheduledDate.Equal(today) {
 			t.Errorf("got date %v, want %v", task.ScheduledDate, today)
 		}
 	})
 
 	t.Run("shallow category", func(t *testing.T) {
-		task, err := New("Review PRs", "shallow", "", "14:00", "15:00")
+		task, err := New("Review PRs", "shallow", "", "14:00", "15:00", now)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -54,6 +56,49 @@ func TestNew(t *testing.T) {
 			t.Errorf("got category %q, want %q", task.Category, CategoryShallow)
 		}
 	})
+
+	t.Run("overnight", func(t *testing.T) {
+		task, err := New("Release", "deep", "2025-01-15", "23:00", "01:00", now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !task.IsOvernight() {
+			t.Error("expected an overnight task")
+		}
+		if task.Duration() != 120 {
+			t.Errorf("got duration %d, want 120", task.Duration())
+		}
+	})
+}
+
+func TestNewAllDay(t *testing.T) {
+	now := time.Date(2025, 1, 13, 8, 30, 0, 0, time.Local)
+	task, err := NewAllDay("On call", "shallow", "2025-01-15", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !task.CreatedAt.Equal(now) {
+		t.Errorf("got CreatedAt %v, want %v", task.CreatedAt, now)
+	}
+	if !task.IsAllDay() {
+		t.Error("expected an all-day task")
+	}
+	if task.Duration() != 0 || task.IsOvernight() {
+		t.Errorf("all-day task takes time: duration %d, overnight %v", task.Duration(), task.IsOvernight())
+	}
+	if task.TimeRange() != "all day" {
+		t.Errorf("TimeRange() = %q, want %q", task.TimeRange(), "all day")
+	}
+	if task.IsPastAt(time.Date(2025, 2, 1, 0, 0, 0, 0, time.Local)) {
+		t.Error("all-day task ended, would be marked missed")
+	}
+	meeting := &Task{ScheduledDate: task.ScheduledDate, ScheduledStart: "09:00", ScheduledEnd: "10:00"}
+	if task.OverlapsWith(meeting) || meeting.OverlapsWith(task) {
+		t.Error("all-day task overlaps a timed one")
+	}
+	if _, err := NewAllDay("", "shallow", "", now); err != ErrEmptyDescription {
+		t.Errorf("got error %v, want %v", err, ErrEmptyDescription)
+	}
 }
 
 func TestNew_Errors(t *testing.T) {
@@ -133,7 +178,7 @@ func TestNew_Errors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := New(tt.description, tt.category, tt.date, tt.start, tt.end)
+			_, err := New(tt.description, tt.category, tt.date, tt.start, tt.end, time.Now())
 			if err == nil {
 				t.Fatal("expected error, got nil")
 			}
@@ -217,6 +262,50 @@ func TestTask_CategoryChecks(t *testing.T) {
 	}
 }
 
+func TestSetCategories(t *testing.T) {
+	SetCategories([]CategoryInfo{
+		{Name: "Meeting", Code: "M", Color: "#f5a97f"},
+		{Name: CategoryDeep, Color: "#8aadf4", Icon: "🧠"},
+		{Name: "errand"},
+	})
+	defer SetCategories(nil)
+
+	got := Categories()
+	want := []CategoryInfo{
+		{Name: CategoryDeep, Code: "D", Color: "#8aadf4", Icon: "🧠"},
+		{Name: CategoryShallow, Code: "S"},
+		{Name: "meeting", Code: "M", Color: "#f5a97f"},
+		{Name: "errand", Code: "E"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Categories() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Categories()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := New("Standup", "meeting", "2025-01-06", "09:00", "09:15", time.Now()); err != nil {
+		t.Errorf("New with custom category: %v", err)
+	}
+	if _, err := New("Standup", "sales", "2025-01-06", "09:00", "09:15", time.Now()); err != ErrInvalidCategory {
+		t.Errorf("New with unknown category: err = %v, want ErrInvalidCategory", err)
+	}
+	if c := Category("errand").Next(); c != CategoryDeep {
+		t.Errorf("errand.Next() = %s, want it to wrap to deep", c)
+	}
+	if c := CategoryOrDeep(" Meeting "); c != "meeting" {
+		t.Errorf("CategoryOrDeep(Meeting) = %s, want meeting", c)
+	}
+	if c := Category("sales").Code(); c != "?" {
+		t.Errorf("unknown Code() = %q, want ?", c)
+	}
+	if icon := CategoryDeep.Icon(); icon != "🧠" {
+		t.Errorf("deep Icon() = %q, want 🧠", icon)
+	}
+}
+
 func TestTask_Duration(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -228,6 +317,8 @@ func TestTask_Duration(t *testing.T) {
 		{name: "2 hours", start: "09:00", end: "11:00", want: 120},
 		{name: "30 minutes", start: "09:00", end: "09:30", want: 30},
 		{name: "2.5 hours", start: "09:00", end: "11:30", want: 150},
+		{name: "overnight", start: "23:00", end: "01:00", want: 120},
+		{name: "until midnight", start: "23:00", end: "00:00", want: 60},
 		{name: "invalid start", start: "invalid", end: "10:00", want: 0},
 		{name: "invalid end", start: "09:00", end: "bad", want: 0},
 	}
@@ -283,6 +374,30 @@ func TestTask_OverlapsWith(t *testing.T) {
 			task2: &Task{ScheduledDate: baseDate, ScheduledStart: "09:00", ScheduledEnd: "11:00"},
 			want:  true,
 		},
+		{
+			name:  "overnight runs into next morning",
+			task1: &Task{ScheduledDate: baseDate, ScheduledStart: "23:00", ScheduledEnd: "01:00"},
+			task2: &Task{ScheduledDate: otherDate, ScheduledStart: "00:30", ScheduledEnd: "02:00"},
+			want:  true,
+		},
+		{
+			name:  "next morning after overnight ends",
+			task1: &Task{ScheduledDate: otherDate, ScheduledStart: "01:00", ScheduledEnd: "02:00"},
+			task2: &Task{ScheduledDate: baseDate, ScheduledStart: "23:00", ScheduledEnd: "01:00"},
+			want:  false,
+		},
+		{
+			name:  "overnight same evening",
+			task1: &Task{ScheduledDate: baseDate, ScheduledStart: "22:00", ScheduledEnd: "23:30"},
+			task2: &Task{ScheduledDate: baseDate, ScheduledStart: "23:00", ScheduledEnd: "01:00"},
+			want:  true,
+		},
+		{
+			name:  "same time different owners",
+			task1: &Task{ScheduledDate: baseDate, ScheduledStart: "09:00", ScheduledEnd: "11:00"},
+			task2: &Task{ScheduledDate: baseDate, ScheduledStart: "09:00", ScheduledEnd: "11:00", Owner: "ben"},
+			want:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -295,8 +410,8 @@ func TestTask_OverlapsWith(t *testing.T) {
 	}
 }
 
-func TestTask_IsPast(t *testing.T) {
-	now := time.Now()
+func TestTask_IsPastAt(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.Local)
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	yesterday := today.AddDate(0, 0, -1)
 	tomorrow := today.AddDate(0, 0, 1)
@@ -335,10 +450,89 @@ func TestTask_IsPast(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.task.IsPast()
+			got := tt.task.IsPastAt(now)
 			if got != tt.want {
-				t.Errorf("IsPast() = %v, want %v", got, tt.want)
+				t.Errorf("IsPastAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartsBeforeEndOf(t *testing.T) {
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	mk := func(date time.Time, start, end string) *Task {
+		return &Task{ScheduledDate: date, ScheduledStart: start, ScheduledEnd: end}
+	}
+	tests := []struct {
+		name  string
+		t     *Task
+		other *Task
+		want  bool
+	}{
+		{"right after", mk(monday, "10:00", "11:00"), mk(monday, "09:00", "10:00"), false},
+		{"overlapping", mk(monday, "09:30", "11:00"), mk(monday, "09:00", "10:00"), true},
+		{"earlier day", mk(monday, "12:00", "13:00"), mk(monday.AddDate(0, 0, 1), "09:00", "10:00"), true},
+		{"next day", mk(monday.AddDate(0, 0, 1), "08:00", "09:00"), mk(monday, "09:00", "10:00"), false},
+		{"overnight prerequisite", mk(monday.AddDate(0, 0, 1), "00:30", "01:00"), mk(monday, "23:00", "01:00"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.StartsBeforeEndOf(tt.other); got != tt.want {
+				t.Errorf("StartsBeforeEndOf = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestQuarterRange(t *testing.T) {
+	start, end, err := QuarterRange("2025-Q2", time.UTC)
+	if err != nil {
+		t.Fatalf("QuarterRange: %v", err)
+	}
+	if want := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %v, want %v", end, want)
+	}
+	if got := Quarter(end); got != "2025-Q2" {
+		t.Errorf("Quarter(%v) = %q, want 2025-Q2", end, got)
+	}
+
+	for _, bad := range []string{"", "2025", "2025-Q5", "2025-Q0", "2025-Q12", "25-Q1"} {
+		if _, _, err := QuarterRange(bad, time.UTC); !errors.Is(err, ErrInvalidQuarter) {
+			t.Errorf("QuarterRange(%q) err = %v, want ErrInvalidQuarter", bad, err)
+		}
+	}
+}
+
+func TestDescriptionKey(t *testing.T) {
+	tests := map[string]string{
+		"Weekly report #12":    "weekly report",
+		"  weekly   REPORT 13": "weekly report",
+		"PROJ-123: fix login":  "proj fix login",
+		"Écrire thèse":         "écrire thèse",
+		"1:1":                  "",
+	}
+	for in, want := range tests {
+		if got := DescriptionKey(in); got != want {
+			t.Errorf("DescriptionKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEstimateAccuracyRatio(t *testing.T) {
+	tests := []struct {
+		acc  EstimateAccuracy
+		want float64
+	}{
+		{EstimateAccuracy{}, 1},
+		{EstimateAccuracy{Samples: 3, EstimatedMinutes: 180, ActualMinutes: 270}, 1.5},
+		{EstimateAccuracy{Samples: 2, EstimatedMinutes: 120, ActualMinutes: 60}, 0.5},
+	}
+	for _, tt := range tests {
+		if got := tt.acc.Ratio(); got != tt.want {
+			t.Errorf("%+v.Ratio() = %v, want %v", tt.acc, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package task
+
+import (
+	"regexp"
+	"sort"
+)
+
+// ticketKeyPattern matches an issue key such as "PROJ-123" leading a
+// description, the way imported backlog items are titled. Keys elsewhere in
+// the text are not trusted: "UTF-8" looks just like one.
+var ticketKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
+// TicketKey returns the issue key a description starts with, e.g.
+// "PROJ-123" in "PROJ-123 Fix login", or "" when there is none.
+func TicketKey(description string) string {
+	return ticketKeyPattern.FindString(description)
+}
+
+// TicketTime is the time scheduled against one issue key.
+type TicketTime struct {
+	Key     string
+	Minutes int
+}
+
+// TicketTimes totals the scheduled minutes of each issue key the tasks'
+// descriptions start with, most time first. Postponed, cancelled and missed
+// blocks are left out.
+func TicketTimes(tasks []*Task) []TicketTime {
+	minutes := make(map[string]int)
+	for _, t := range tasks {
+		if !t.IsScheduled() {
+			continue
+		}
+		if key := TicketKey(t.Description); key != "" {
+			minutes[key] += t.Duration()
+		}
+	}
+	result := make([]TicketTime, 0, len(minutes))
+	for key, m := range minutes {
+		result = append(result, TicketTime{Key: key, Minutes: m})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Minutes != result[j].Minutes {
+			return result[i].Minutes > result[j].Minutes
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,40 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTicketKey(t *testing.T) {
+	tests := map[string]string{
+		"PROJ-123 Fix login":      "PROJ-123",
+		"OPS_2-7 Rotate keys":     "OPS_2-7",
+		"Review PROJ-12 design":   "",
+		"Pair on UTF-8 parsing":   "",
+		"API-42a is not a ticket": "",
+	}
+	for in, want := range tests {
+		if got := TicketKey(in); got != want {
+			t.Errorf("TicketKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTicketTimes(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	block := func(desc, start, end string, status Status) *Task {
+		return &Task{Description: desc, ScheduledDate: day, ScheduledStart: start, ScheduledEnd: end, Status: status}
+	}
+	tasks := []*Task{
+		block("OPS-7 Rotate keys", "09:00", "10:00", StatusScheduled),
+		block("API-42 Fix login", "10:00", "12:00", StatusScheduled),
+		block("OPS-7 rollout review", "13:00", "13:30", StatusScheduled),
+		block("API-42 Fix login", "14:00", "16:00", StatusCancelled),
+		block("Email about UTF-8", "16:00", "17:00", StatusScheduled),
+	}
+	want := []TicketTime{{Key: "API-42", Minutes: 120}, {Key: "OPS-7", Minutes: 90}}
+	if got := TicketTimes(tasks); !reflect.DeepEqual(got, want) {
+		t.Errorf("TicketTimes = %+v, want %+v", got, want)
+	}
+}
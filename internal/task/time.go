@@ -1,6 +1,9 @@
 package task
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // TimeToMinutes converts "HH:MM" to minutes since midnight.
 // Returns 0 for invalid input.
@@ -24,14 +27,28 @@ func MinutesToTime(m int) string {
 	return fmt.Sprintf("%02d:%02d", m/60, m%60)
 }
 
+// IsOvernight reports whether a block runs past midnight, which is stored as
+// an end time before the start time (e.g. 23:00-01:00).
+func IsOvernight(start, end string) bool {
+	return end < start
+}
+
+// Span returns a block's start and end in minutes from midnight of its
+// scheduled day. The end of an overnight block is past 1440.
+func Span(start, end string) (int, int) {
+	s, e := TimeToMinutes(start), TimeToMinutes(end)
+	if IsOvernight(start, end) {
+		e += 24 * 60
+	}
+	return s, e
+}
+
 // OverlapMinutes calculates the overlapping minutes between two time ranges.
 // All times are in "HH:MM" format.
 // Returns 0 if there is no overlap.
 func OverlapMinutes(start1, end1, start2, end2 string) int {
-	s1 := TimeToMinutes(start1)
-	e1 := TimeToMinutes(end1)
-	s2 := TimeToMinutes(start2)
-	e2 := TimeToMinutes(end2)
+	s1, e1 := Span(start1, end1)
+	s2, e2 := Span(start2, end2)
 
 	overlapStart := max(s1, s2)
 	overlapEnd := min(e1, e2)
@@ -42,8 +59,54 @@ func OverlapMinutes(start1, end1, start2, end2 string) int {
 	return overlapEnd - overlapStart
 }
 
-// TimesOverlap returns true if two time ranges overlap.
+// TimesOverlap returns true if two time ranges on the same day overlap.
 // Two time ranges overlap if: start1 < end2 AND start2 < end1
 func TimesOverlap(start1, end1, start2, end2 string) bool {
-	return start1 < end2 && start2 < end1
+	s1, e1 := Span(start1, end1)
+	s2, e2 := Span(start2, end2)
+	return s1 < e2 && s2 < e1
+}
+
+// BlocksOverlap is like TimesOverlap for blocks that may be on different
+// days, so an overnight block also conflicts with the next morning. All-day
+// blocks, without times, overlap nothing.
+func BlocksOverlap(date1 time.Time, start1, end1 string, date2 time.Time, start2, end2 string) bool {
+	if (start1 == "" && end1 == "") || (start2 == "" && end2 == "") {
+		return false
+	}
+	offset := CalendarDaysBetween(date1, date2) * 24 * 60
+	s1, e1 := Span(start1, end1)
+	s2, e2 := Span(start2, end2)
+	return s1 < e2+offset && s2+offset < e1
+}
+
+// CalendarDaysBetween returns the number of calendar days from a to b.
+// Only the calendar dates are compared, so the result is not affected by
+// 23 or 25 hour days around daylight saving transitions.
+func CalendarDaysBetween(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da) / (24 * time.Hour))
+}
+
+// WallClockMinutes returns the minutes since midnight on t's wall clock.
+// During the repeated hour after a DST fall-back it returns the last minute
+// of the repeated range, so times that already passed once never become
+// "future" again.
+func WallClockMinutes(t time.Time) int {
+	mins := t.Hour()*60 + t.Minute()
+
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return mins
+	}
+	_, offset := t.Zone()
+	_, prevOffset := start.Add(-time.Second).Zone()
+	repeated := time.Duration(prevOffset-offset) * time.Second
+	if repeated <= 0 || t.Sub(start) >= repeated {
+		return mins
+	}
+
+	startMins := start.Hour()*60 + start.Minute()
+	return max(mins, startMins+int(repeated/time.Minute)-1)
 }
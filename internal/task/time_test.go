@@ -1,6 +1,9 @@
 package task
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestTimeToMinutes(t *testing.T) {
 	tests := []struct {
@@ -151,6 +154,18 @@ func TestTimesOverlap(t *testing.T) {
 			start2: "10:00", end2: "11:00",
 			want: true,
 		},
+		{
+			name:   "overlap - overnight with late evening",
+			start1: "23:00", end1: "01:00",
+			start2: "23:30", end2: "23:45",
+			want: true,
+		},
+		{
+			name:   "no overlap - evening before overnight",
+			start1: "22:00", end1: "23:00",
+			start2: "23:00", end2: "01:00",
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -163,3 +178,99 @@ func TestTimesOverlap(t *testing.T) {
 		})
 	}
 }
+
+func TestBlocksOverlap(t *testing.T) {
+	mon := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	tue := mon.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name         string
+		date1        time.Time
+		start1, end1 string
+		date2        time.Time
+		start2, end2 string
+		want         bool
+	}{
+		{name: "same day", date1: mon, start1: "09:00", end1: "10:00", date2: mon, start2: "09:30", end2: "11:00", want: true},
+		{name: "different days", date1: mon, start1: "09:00", end1: "10:00", date2: tue, start2: "09:00", end2: "10:00", want: false},
+		{name: "overnight tail", date1: mon, start1: "23:00", end1: "01:00", date2: tue, start2: "00:30", end2: "01:30", want: true},
+		{name: "after overnight tail", date1: tue, start1: "01:00", end1: "02:00", date2: mon, start2: "23:00", end2: "01:00", want: false},
+		{name: "all day after overnight", date1: tue, start1: "", end1: "", date2: mon, start2: "23:00", end2: "01:00", want: false},
+		{name: "timed inside all day", date1: mon, start1: "09:00", end1: "10:00", date2: mon, start2: "", end2: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BlocksOverlap(tt.date1, tt.start1, tt.end1, tt.date2, tt.start2, tt.end2)
+			if got != tt.want {
+				t.Errorf("BlocksOverlap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func loadDSTZone(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	return loc
+}
+
+func TestCalendarDaysBetween_DST(t *testing.T) {
+	loc := loadDSTZone(t)
+
+	tests := []struct {
+		name string
+		a, b time.Time
+		want int
+	}{
+		// 2026-03-08 is a 23-hour day in New York
+		{"across spring forward", time.Date(2026, 3, 2, 0, 0, 0, 0, loc), time.Date(2026, 3, 9, 0, 0, 0, 0, loc), 7},
+		// 2026-11-01 is a 25-hour day in New York
+		{"across fall back", time.Date(2026, 10, 26, 0, 0, 0, 0, loc), time.Date(2026, 11, 2, 0, 0, 0, 0, loc), 7},
+		{"late evening to next morning", time.Date(2026, 3, 7, 23, 30, 0, 0, loc), time.Date(2026, 3, 8, 4, 0, 0, 0, loc), 1},
+		{"backwards", time.Date(2026, 11, 2, 0, 0, 0, 0, loc), time.Date(2026, 10, 31, 0, 0, 0, 0, loc), -2},
+		{"same day", time.Date(2026, 11, 1, 0, 0, 0, 0, loc), time.Date(2026, 11, 1, 23, 59, 0, 0, loc), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalendarDaysBetween(tt.a, tt.b); got != tt.want {
+				t.Errorf("CalendarDaysBetween() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWallClockMinutes_DST(t *testing.T) {
+	loc := loadDSTZone(t)
+
+	// 01:30 EDT is the first pass through the repeated hour
+	firstPass := time.Date(2026, 11, 1, 1, 30, 0, 0, loc)
+	// One hour later the wall clock reads 01:30 again (EST)
+	secondPass := firstPass.Add(time.Hour)
+	afterRepeat := firstPass.Add(2 * time.Hour)
+	springForward := time.Date(2026, 3, 8, 3, 15, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want int
+	}{
+		{"first pass through repeated hour", firstPass, 90},
+		{"second pass does not go backwards", secondPass, 119},
+		{"after repeated hour", afterRepeat, 150},
+		{"after spring forward", springForward, 195},
+		{"utc has no transitions", time.Date(2026, 11, 1, 1, 30, 0, 0, time.UTC), 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WallClockMinutes(tt.t); got != tt.want {
+				t.Errorf("WallClockMinutes(%s) = %d, want %d", tt.t, got, tt.want)
+			}
+		})
+	}
+}
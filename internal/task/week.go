@@ -3,21 +3,25 @@ package task
 import (
 	"fmt"
 	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
 )
 
-// Week holds 7 days starting from Monday.
+// Week holds 7 days starting from the first day of the week, Monday unless
+// dateutil.SetFirstWeekday chose another day.
 type Week struct {
-	StartDate time.Time // Monday of the week
-	Days      [7]*Day   // Monday (0) through Sunday (6)
+	StartDate time.Time // First day of the week
+	Days      [7]*Day   // First day (0) through last day (6)
 }
 
-// NewWeek creates a Week starting from the Monday of the given date.
+// NewWeek creates a Week starting from the first day of the week of the
+// given date.
 func NewWeek(date time.Time) *Week {
-	monday := startOfWeek(date)
-	w := &Week{StartDate: monday}
+	start := startOfWeek(date)
+	w := &Week{StartDate: start}
 
 	for i := 0; i < 7; i++ {
-		dayDate := monday.AddDate(0, 0, i)
+		dayDate := start.AddDate(0, 0, i)
 		w.Days[i] = NewDay(dayDate)
 	}
 
@@ -31,15 +35,16 @@ func NewWeekFromTasks(date time.Time, tasks []*Task) *Week {
 
 	for _, t := range tasks {
 		if day := w.DayByDate(t.ScheduledDate); day != nil {
-			// Ignore errors - just add what we can
-			_ = day.AddTask(t)
+			// Storage already validated these, and keeps overlapping blocks
+			// when overlaps are allowed
+			day.AddOverlapping(t)
 		}
 	}
 
 	return w
 }
 
-// Day returns the Day for the given weekday (0=Monday, 6=Sunday).
+// Day returns the Day at the given column of the week (0 = first day).
 // Returns nil if weekday is out of range.
 func (w *Week) Day(weekday int) *Day {
 	if weekday < 0 || weekday > 6 {
@@ -49,14 +54,14 @@ func (w *Week) Day(weekday int) *Day {
 }
 
 // DayByDate returns the Day for the given date, nil if not in this week.
+// Only the calendar date is compared, so a date stored in another timezone
+// (e.g. UTC midnight on Dec 31) still maps to its wall-clock day.
 func (w *Week) DayByDate(date time.Time) *Day {
-	truncated := truncateToDay(date)
-	for _, day := range w.Days {
-		if day.Date.Equal(truncated) {
-			return day
-		}
+	offset := CalendarDaysBetween(w.StartDate, date)
+	if offset < 0 || offset >= len(w.Days) {
+		return nil
 	}
-	return nil
+	return w.Days[offset]
 }
 
 // AllTasks returns all tasks across all days, sorted by date and start time.
@@ -81,6 +86,7 @@ type WeekStats struct {
 	TotalBlocks     int
 	CancelledBlocks int
 	PostponedBlocks int
+	MissedBlocks    int
 	DayStats        [7]DayStats
 }
 
@@ -118,7 +124,7 @@ func (s WeekStats) Ratio() string {
 	}
 }
 
-// BestDay returns the weekday (0=Monday) with the most deep work minutes and the minutes.
+// BestDay returns the column of the week (0 = first day) with the most deep work minutes and the minutes.
 func (s WeekStats) BestDay() (weekday int, deepMinutes int) {
 	weekday = -1
 	for i, ds := range s.DayStats {
@@ -141,10 +147,24 @@ func (w *Week) Stats() WeekStats {
 		stats.TotalBlocks += ds.TotalBlocks
 		stats.CancelledBlocks += ds.CancelledBlocks
 		stats.PostponedBlocks += ds.PostponedBlocks
+		stats.MissedBlocks += ds.MissedBlocks
 	}
 	return stats
 }
 
+// CategoryMinutes returns the scheduled minutes of each category in the week.
+func (w *Week) CategoryMinutes() map[Category]int {
+	minutes := make(map[Category]int)
+	for _, day := range w.Days {
+		for _, t := range day.Tasks() {
+			if t.IsScheduled() {
+				minutes[t.Category] += t.Duration()
+			}
+		}
+	}
+	return minutes
+}
+
 // StatsWithPeakHours calculates statistics including peak hour alignment.
 func (w *Week) StatsWithPeakHours(peakStart, peakEnd string) WeekStats {
 	stats := w.Stats()
@@ -157,32 +177,29 @@ func (w *Week) StatsWithPeakHours(peakStart, peakEnd string) WeekStats {
 	return stats
 }
 
-// WeekdayName returns the name of the weekday (0=Monday).
+// WeekdayName returns the name of the weekday at column weekday of a week,
+// where 0 is the first day of the week.
 func WeekdayName(weekday int) string {
-	names := []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
 	if weekday < 0 || weekday > 6 {
 		return ""
 	}
-	return names[weekday]
+	return weekdayAt(weekday).String()
 }
 
-// WeekdayShortName returns the short name of the weekday (0=Monday).
+// WeekdayShortName returns the short name of the weekday at column weekday
+// of a week, where 0 is the first day of the week.
 func WeekdayShortName(weekday int) string {
-	names := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
 	if weekday < 0 || weekday > 6 {
 		return ""
 	}
-	return names[weekday]
+	return weekdayAt(weekday).String()[:3]
 }
 
-// startOfWeek returns the Monday of the week containing the given date.
+func weekdayAt(column int) time.Weekday {
+	return (dateutil.FirstWeekday() + time.Weekday(column)) % 7
+}
+
+// startOfWeek returns the first day of the week containing the given date.
 func startOfWeek(t time.Time) time.Time {
-	t = truncateToDay(t)
-	weekday := int(t.Weekday())
-	// Convert Sunday (0) to 7 for easier calculation
-	if weekday == 0 {
-		weekday = 7
-	}
-	// Go back to Monday (weekday 1)
-	return t.AddDate(0, 0, -(weekday - 1))
+	return dateutil.StartOfWeek(t)
 }
@@ -336,3 +336,59 @@ func TestStartOfWeek(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWeek_YearBoundary(t *testing.T) {
+	// Wednesday Jan 1, 2025 belongs to the week starting Monday Dec 30, 2024.
+	w := NewWeek(time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local))
+
+	wantStart := time.Date(2024, 12, 30, 0, 0, 0, 0, time.Local)
+	if !w.StartDate.Equal(wantStart) {
+		t.Fatalf("StartDate = %v, want %v", w.StartDate, wantStart)
+	}
+	wantEnd := time.Date(2025, 1, 5, 0, 0, 0, 0, time.Local)
+	if !w.EndDate().Equal(wantEnd) {
+		t.Errorf("EndDate = %v, want %v", w.EndDate(), wantEnd)
+	}
+	if d := w.DayByDate(time.Date(2024, 12, 31, 23, 59, 0, 0, time.Local)); d != w.Days[1] {
+		t.Errorf("Dec 31 should be Tuesday of the week")
+	}
+	if d := w.DayByDate(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); d != w.Days[2] {
+		t.Errorf("UTC Jan 1 should map to Wednesday of the week")
+	}
+}
+
+func TestNewWeek_LeapDay(t *testing.T) {
+	w := NewWeek(time.Date(2024, 2, 29, 9, 0, 0, 0, time.Local)) // Thursday
+
+	if w.Days[3].Date.Day() != 29 || w.Days[3].Date.Month() != time.February {
+		t.Errorf("Thursday = %v, want Feb 29", w.Days[3].Date)
+	}
+	if w.Days[4].Date.Day() != 1 || w.Days[4].Date.Month() != time.March {
+		t.Errorf("Friday = %v, want Mar 1", w.Days[4].Date)
+	}
+	if d := w.DayByDate(time.Date(2024, 3, 4, 0, 0, 0, 0, time.Local)); d != nil {
+		t.Errorf("next Monday should not be in the week, got %v", d.Date)
+	}
+}
+
+func TestNewWeek_ConsecutiveDaysProperty(t *testing.T) {
+	// Every date from 2023 to 2029 must land in a Monday-first week of seven
+	// consecutive calendar days that contains it.
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local)
+
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		w := NewWeek(date)
+		if w.StartDate.Weekday() != time.Monday {
+			t.Fatalf("%s: week starts on %s", date.Format("2006-01-02"), w.StartDate.Weekday())
+		}
+		for i := 1; i < 7; i++ {
+			if CalendarDaysBetween(w.Days[i-1].Date, w.Days[i].Date) != 1 {
+				t.Fatalf("%s: day %d is not consecutive", date.Format("2006-01-02"), i)
+			}
+		}
+		if w.DayByDate(date) == nil {
+			t.Fatalf("%s: not found in its own week", date.Format("2006-01-02"))
+		}
+	}
+}
@@ -1,75 +1,134 @@
 package task
 
 // WeekWindow provides a sliding window of weeks for efficient TUI navigation.
-// It maintains 3 consecutive weeks: previous, current, and next.
+// It keeps the current week plus the same number of consecutive weeks on each
+// side (one by default: previous, current, and next).
 // This allows smooth week-to-week navigation without loading on every change.
 type WeekWindow struct {
-	weeks [3]*Week // [0]=prev, [1]=current, [2]=next
+	weeks  []*Week // Oldest first; weeks[radius] is the current week
+	radius int
 }
 
 // NewWeekWindow creates a window with three consecutive weeks.
 // The weeks should be consecutive (prev, current, next) for proper navigation.
 func NewWeekWindow(prev, current, next *Week) *WeekWindow {
+	return NewWeekWindowOf([]*Week{prev, current, next})
+}
+
+// NewWeekWindowOf creates a window from consecutive weeks, oldest first.
+// The middle week is the current one, so weeks should have an odd length.
+// Weeks that are not loaded yet may be nil.
+func NewWeekWindowOf(weeks []*Week) *WeekWindow {
 	return &WeekWindow{
-		weeks: [3]*Week{prev, current, next},
+		weeks:  append([]*Week(nil), weeks...),
+		radius: len(weeks) / 2,
 	}
 }
 
+// Len returns the number of weeks the window holds.
+func (w *WeekWindow) Len() int {
+	return len(w.weeks)
+}
+
+// Radius returns the number of weeks kept on each side of the current week.
+func (w *WeekWindow) Radius() int {
+	return w.radius
+}
+
+// Weeks returns the weeks in the window, oldest first. Unloaded weeks are nil.
+func (w *WeekWindow) Weeks() []*Week {
+	return w.weeks
+}
+
+// Week returns the week offset weeks from the current one (negative is
+// earlier), or nil if it is outside the window or not loaded.
+func (w *WeekWindow) Week(offset int) *Week {
+	i := w.radius + offset
+	if i < 0 || i >= len(w.weeks) {
+		return nil
+	}
+	return w.weeks[i]
+}
+
+// SetWeek replaces the week offset weeks from the current one.
+// Offsets outside the window are ignored.
+func (w *WeekWindow) SetWeek(offset int, week *Week) {
+	i := w.radius + offset
+	if i < 0 || i >= len(w.weeks) {
+		return
+	}
+	w.weeks[i] = week
+}
+
 // Current returns the focused (center) week.
 func (w *WeekWindow) Current() *Week {
-	return w.weeks[1]
+	return w.Week(0)
 }
 
 // Previous returns the week before current.
 func (w *WeekWindow) Previous() *Week {
-	return w.weeks[0]
+	return w.Week(-1)
 }
 
 // Next returns the week after current.
 func (w *WeekWindow) Next() *Week {
-	return w.weeks[2]
+	return w.Week(1)
 }
 
 // ShiftForward moves the window forward by one week.
-// The current week becomes the previous week, the next week becomes current,
-// and the provided newNext becomes the new next week.
-func (w *WeekWindow) ShiftForward(newNext *Week) {
-	w.weeks[0] = w.weeks[1] // old current becomes prev
-	w.weeks[1] = w.weeks[2] // old next becomes current
-	w.weeks[2] = newNext    // new week becomes next
+// The oldest week is dropped, every week moves one place back (so the next
+// week becomes current), and the provided newEdge becomes the last week.
+func (w *WeekWindow) ShiftForward(newEdge *Week) {
+	copy(w.weeks, w.weeks[1:])
+	w.weeks[len(w.weeks)-1] = newEdge
 }
 
 // ShiftBackward moves the window backward by one week.
-// The current week becomes the next week, the previous week becomes current,
-// and the provided newPrev becomes the new previous week.
-func (w *WeekWindow) ShiftBackward(newPrev *Week) {
-	w.weeks[2] = w.weeks[1] // old current becomes next
-	w.weeks[1] = w.weeks[0] // old prev becomes current
-	w.weeks[0] = newPrev    // new week becomes prev
+// The newest week is dropped, every week moves one place forward (so the
+// previous week becomes current), and the provided newEdge becomes the first week.
+func (w *WeekWindow) ShiftBackward(newEdge *Week) {
+	copy(w.weeks[1:], w.weeks)
+	w.weeks[0] = newEdge
 }
 
 // SetCurrent replaces the current week.
 // This is useful for cache invalidation after task mutations.
 func (w *WeekWindow) SetCurrent(week *Week) {
-	w.weeks[1] = week
+	w.SetWeek(0, week)
+}
+
+// SetDay replaces the day with the same date in whichever loaded week holds
+// it, so a write can refresh one day instead of the whole week. It reports
+// false when that week is outside the window or not loaded.
+func (w *WeekWindow) SetDay(day *Day) bool {
+	for _, week := range w.weeks {
+		if week == nil {
+			continue
+		}
+		if offset := CalendarDaysBetween(week.StartDate, day.Date); offset >= 0 && offset < len(week.Days) {
+			week.Days[offset] = day
+			return true
+		}
+	}
+	return false
 }
 
 // SetNext replaces the next week after it's been loaded.
 func (w *WeekWindow) SetNext(week *Week) {
-	w.weeks[2] = week
+	w.SetWeek(1, week)
 }
 
 // SetPrevious replaces the previous week after it's been loaded.
 func (w *WeekWindow) SetPrevious(week *Week) {
-	w.weeks[0] = week
+	w.SetWeek(-1, week)
 }
 
 // HasNext returns true if the next week is loaded (not nil).
 func (w *WeekWindow) HasNext() bool {
-	return w.weeks[2] != nil
+	return w.Next() != nil
 }
 
 // HasPrevious returns true if the previous week is loaded (not nil).
 func (w *WeekWindow) HasPrevious() bool {
-	return w.weeks[0] != nil
+	return w.Previous() != nil
 }
@@ -185,3 +185,60 @@ func TestWeekWindow_ShiftForwardThenBackward(t *testing.T) {
 		t.Error("after forward+backward, Next() should be week3")
 	}
 }
+
+func TestWeekWindowOf_WiderWindow(t *testing.T) {
+	var weeks []*Week
+	for i := range 6 {
+		weeks = append(weeks, NewWeek(time.Date(2025, 1, 6+7*i, 0, 0, 0, 0, time.Local)))
+	}
+
+	w := NewWeekWindowOf(weeks[:5])
+	if w.Len() != 5 || w.Radius() != 2 {
+		t.Fatalf("Len() = %d, Radius() = %d, want 5 and 2", w.Len(), w.Radius())
+	}
+	if w.Current() != weeks[2] || w.Week(-2) != weeks[0] || w.Week(2) != weeks[4] {
+		t.Error("the middle week should be current with two weeks on each side")
+	}
+	if w.Week(3) != nil {
+		t.Error("Week outside the window should be nil")
+	}
+
+	w.ShiftForward(weeks[5])
+	if w.Current() != weeks[3] || w.Week(-2) != weeks[1] || w.Week(2) != weeks[5] {
+		t.Error("ShiftForward should drop the first week and append the new edge week")
+	}
+
+	w.ShiftBackward(weeks[0])
+	if w.Current() != weeks[2] || w.Week(-2) != weeks[0] || w.Week(2) != weeks[4] {
+		t.Error("ShiftBackward should drop the last week and prepend the new edge week")
+	}
+
+	w.SetWeek(-2, nil)
+	if w.Week(-2) != nil || !w.HasPrevious() {
+		t.Error("SetWeek should only replace the given week")
+	}
+}
+
+func TestWeekWindow_SetDay(t *testing.T) {
+	curr := NewWeek(time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local))
+	next := NewWeek(time.Date(2025, 1, 20, 0, 0, 0, 0, time.Local))
+	w := NewWeekWindow(nil, curr, next)
+
+	wednesday := NewDay(time.Date(2025, 1, 22, 0, 0, 0, 0, time.Local))
+	if !w.SetDay(wednesday) {
+		t.Fatal("SetDay should find next week's Wednesday")
+	}
+	if next.Day(2) != wednesday || curr.Day(2) == wednesday {
+		t.Error("SetDay replaced the wrong day")
+	}
+
+	// The previous week is not loaded, and February is outside the window
+	for _, date := range []time.Time{
+		time.Date(2025, 1, 8, 0, 0, 0, 0, time.Local),
+		time.Date(2025, 2, 3, 0, 0, 0, 0, time.Local),
+	} {
+		if w.SetDay(NewDay(date)) {
+			t.Errorf("SetDay(%s) = true, want false", date.Format("Jan 2"))
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Package tasksync holds the pieces shared by sync integrations: detecting
+// tasks that changed on both sides since the last sync and merging them.
+package tasksync
+
+import (
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Field is a part of a task that sync compares and merges as a unit.
+type Field int
+
+const (
+	FieldDescription Field = iota
+	FieldCategory
+	FieldSchedule // Date, start and end move together
+	FieldEnergy
+)
+
+// Fields lists every mergeable field in display order.
+var Fields = []Field{FieldDescription, FieldCategory, FieldSchedule, FieldEnergy}
+
+// String returns the field label shown in the resolution modal.
+func (f Field) String() string {
+	switch f {
+	case FieldDescription:
+		return "Description"
+	case FieldCategory:
+		return "Category"
+	case FieldSchedule:
+		return "When"
+	case FieldEnergy:
+		return "Energy"
+	default:
+		return "?"
+	}
+}
+
+// Side picks which copy a merged field is taken from.
+type Side int
+
+const (
+	SideLocal Side = iota
+	SideRemote
+)
+
+// Conflict is a task that changed both locally and in the remote source
+// since the last sync. Choice holds the side each field is merged from.
+type Conflict struct {
+	Source string    // Integration that reported the conflict, e.g. "todoist"
+	Base   task.Task // As of the last sync
+	Local  task.Task
+	Remote task.Task
+	Choice map[Field]Side
+}
+
+// Detect compares both copies of a task against the last synced version. It
+// returns a conflict when both sides changed and they no longer agree. Fields
+// changed on one side only default to that side; fields changed on both
+// default to the local copy.
+func Detect(source string, base, local, remote task.Task) (*Conflict, bool) {
+	localChanged := changedFields(base, local)
+	remoteChanged := changedFields(base, remote)
+	if len(localChanged) == 0 || len(remoteChanged) == 0 || len(changedFields(local, remote)) == 0 {
+		return nil, false
+	}
+
+	c := &Conflict{
+		Source: source,
+		Base:   base,
+		Local:  local,
+		Remote: remote,
+		Choice: make(map[Field]Side, len(Fields)),
+	}
+	for _, f := range Fields {
+		if remoteChanged[f] && !localChanged[f] {
+			c.Choice[f] = SideRemote
+		}
+	}
+	return c, true
+}
+
+// Conflicting reports whether a field changed on both sides to different values.
+func (c *Conflict) Conflicting(f Field) bool {
+	return !fieldEqual(c.Base, c.Local, f) && !fieldEqual(c.Base, c.Remote, f) && !fieldEqual(c.Local, c.Remote, f)
+}
+
+// Toggle switches the side a field is merged from.
+func (c *Conflict) Toggle(f Field) {
+	if c.Choice[f] == SideLocal {
+		c.Choice[f] = SideRemote
+	} else {
+		c.Choice[f] = SideLocal
+	}
+}
+
+// ChooseAll merges every field from one side.
+func (c *Conflict) ChooseAll(side Side) {
+	for _, f := range Fields {
+		c.Choice[f] = side
+	}
+}
+
+// Merged returns the local task with each field taken from its chosen side.
+// Identity, status and outcome always come from the local copy.
+func (c *Conflict) Merged() task.Task {
+	merged := c.Local
+	for _, f := range Fields {
+		if c.Choice[f] == SideRemote {
+			copyField(&merged, c.Remote, f)
+		}
+	}
+	return merged
+}
+
+func changedFields(a, b task.Task) map[Field]bool {
+	changed := make(map[Field]bool)
+	for _, f := range Fields {
+		if !fieldEqual(a, b, f) {
+			changed[f] = true
+		}
+	}
+	return changed
+}
+
+func fieldEqual(a, b task.Task, f Field) bool {
+	switch f {
+	case FieldDescription:
+		return a.Description == b.Description
+	case FieldCategory:
+		return a.Category == b.Category
+	case FieldSchedule:
+		return task.CalendarDaysBetween(a.ScheduledDate, b.ScheduledDate) == 0 &&
+			a.ScheduledStart == b.ScheduledStart && a.ScheduledEnd == b.ScheduledEnd
+	case FieldEnergy:
+		return a.Energy == b.Energy
+	default:
+		return true
+	}
+}
+
+func copyField(dst *task.Task, src task.Task, f Field) {
+	switch f {
+	case FieldDescription:
+		dst.Description = src.Description
+	case FieldCategory:
+		dst.Category = src.Category
+	case FieldSchedule:
+		dst.ScheduledDate = src.ScheduledDate
+		dst.ScheduledStart = src.ScheduledStart
+		dst.ScheduledEnd = src.ScheduledEnd
+	case FieldEnergy:
+		dst.Energy = src.Energy
+	}
+}
@@ -0,0 +1,78 @@
+package tasksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func syncTask(desc, start, end string) task.Task {
+	return task.Task{
+		ID:             7,
+		Description:    desc,
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		ScheduledStart: start,
+		ScheduledEnd:   end,
+		Status:         task.StatusScheduled,
+		ExternalRef:    task.ExternalRef{Source: "todoist", ID: "42"},
+	}
+}
+
+func TestDetect(t *testing.T) {
+	base := syncTask("Write report", "09:00", "10:00")
+
+	tests := []struct {
+		name         string
+		local        task.Task
+		remote       task.Task
+		wantConflict bool
+	}{
+		{"unchanged", base, base, false},
+		{"local only", syncTask("Write final report", "09:00", "10:00"), base, false},
+		{"remote only", base, syncTask("Write report", "11:00", "12:00"), false},
+		{"same change on both", syncTask("Draft", "09:00", "10:00"), syncTask("Draft", "09:00", "10:00"), false},
+		{"different fields", syncTask("Draft", "09:00", "10:00"), syncTask("Write report", "11:00", "12:00"), true},
+		{"same field", syncTask("Draft", "09:00", "10:00"), syncTask("Outline", "09:00", "10:00"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := Detect("todoist", base, tt.local, tt.remote)
+			if got != tt.wantConflict {
+				t.Errorf("Detect() conflict = %v, want %v", got, tt.wantConflict)
+			}
+		})
+	}
+}
+
+func TestConflict_Merged(t *testing.T) {
+	base := syncTask("Write report", "09:00", "10:00")
+	local := syncTask("Draft", "09:00", "10:00")
+	remote := syncTask("Outline", "11:00", "12:00")
+
+	c, ok := Detect("todoist", base, local, remote)
+	if !ok {
+		t.Fatal("expected a conflict")
+	}
+	if !c.Conflicting(FieldDescription) || c.Conflicting(FieldSchedule) {
+		t.Errorf("Conflicting: description %v, schedule %v", c.Conflicting(FieldDescription), c.Conflicting(FieldSchedule))
+	}
+
+	// Description changed on both sides: local wins. Schedule changed remotely only.
+	merged := c.Merged()
+	if merged.Description != "Draft" || merged.ScheduledStart != "11:00" || merged.ID != local.ID {
+		t.Errorf("default merge = %q %s (#%d), want Draft 11:00 (#7)", merged.Description, merged.ScheduledStart, merged.ID)
+	}
+
+	c.Toggle(FieldDescription)
+	if got := c.Merged().Description; got != "Outline" {
+		t.Errorf("after toggle description = %q, want Outline", got)
+	}
+
+	c.ChooseAll(SideLocal)
+	if got := c.Merged(); got.Description != "Draft" || got.ScheduledStart != "09:00" {
+		t.Errorf("ChooseAll(local) = %q %s, want Draft 09:00", got.Description, got.ScheduledStart)
+	}
+}
@@ -0,0 +1,317 @@
+package tasksync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/httpclient"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// icsWindowPast and icsWindowAhead bound the events imported from a feed, so
+// years of history or far-off recurring series do not flood the schedule.
+const (
+	icsWindowPast  = 7
+	icsWindowAhead = 28
+)
+
+// CalendarEvent is an event read from an iCalendar feed.
+type CalendarEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+	AllDay  bool
+}
+
+// ICSSource imports the events of an iCalendar feed as tasks. It is one-way:
+// local edits are never written back to the feed. Recurring events only
+// contribute their first occurrence.
+type ICSSource struct {
+	Label    string        // Feed name, used in status and external references
+	URL      string        // http(s) URL or local file path
+	Category task.Category // Category given to imported tasks
+	Client   *http.Client  // Defaults to httpclient.New with a one minute timeout
+	Now      func() time.Time
+}
+
+// Name returns the feed name.
+func (s *ICSSource) Name() string {
+	return s.Label
+}
+
+// Pull fetches the feed and returns its timed events from the last week to
+// four weeks ahead as tasks.
+func (s *ICSSource) Pull(ctx context.Context) ([]task.Task, error) {
+	data, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, err := ParseICS(data, time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	today := dateutil.TruncateToDay(now())
+	from := today.AddDate(0, 0, -icsWindowPast)
+	to := today.AddDate(0, 0, icsWindowAhead)
+
+	category := s.Category
+	if category == "" {
+		category = task.CategoryShallow
+	}
+
+	var tasks []task.Task
+	for _, ev := range events {
+		t, ok := eventTask(ev, category)
+		if !ok || t.ScheduledDate.Before(from) || !t.ScheduledDate.Before(to) {
+			continue
+		}
+		t.ExternalRef = task.ExternalRef{Source: "ics", ID: s.Label + "/" + ev.UID}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+func (s *ICSSource) fetch(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.URL, "http://") && !strings.HasPrefix(s.URL, "https://") {
+		data, err := os.ReadFile(s.URL)
+		if err != nil {
+			return nil, fmt.Errorf("reading feed: %w", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	client := s.Client
+	if client == nil {
+		client = httpclient.New(time.Minute)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching feed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching feed: %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading feed: %w", err)
+	}
+	return data, nil
+}
+
+// eventTask converts a timed event to a scheduled task. All-day events and
+// events running past midnight for longer than an overnight task may are
+// skipped.
+func eventTask(ev CalendarEvent, category task.Category) (task.Task, bool) {
+	if ev.AllDay || ev.UID == "" {
+		return task.Task{}, false
+	}
+	start, end := ev.Start.In(time.Local), ev.End.In(time.Local)
+	if !end.After(start) {
+		return task.Task{}, false
+	}
+	startDay := dateutil.TruncateToDay(start)
+	days := task.CalendarDaysBetween(startDay, end)
+	if days > 1 || (days == 1 && end.Sub(start) > task.MaxOvernightMinutes*time.Minute) {
+		return task.Task{}, false
+	}
+
+	description := strings.TrimSpace(ev.Summary)
+	if description == "" {
+		description = "(no title)"
+	}
+	return task.Task{
+		Description:    description,
+		Category:       category,
+		ScheduledDate:  startDay,
+		ScheduledStart: start.Format("15:04"),
+		ScheduledEnd:   end.Format("15:04"),
+		Status:         task.StatusScheduled,
+	}, true
+}
+
+// ParseICS reads the VEVENTs of an iCalendar document. Times with a TZID are
+// read in that zone, UTC times keep their zone, and floating times use loc.
+// Cancelled events are left out.
+func ParseICS(data []byte, loc *time.Location) ([]CalendarEvent, error) {
+	var (
+		events    []CalendarEvent
+		current   *CalendarEvent
+		cancelled bool
+		duration  time.Duration
+	)
+	for _, line := range unfoldICS(data) {
+		name, params, value := splitICSLine(line)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &CalendarEvent{}
+			cancelled = false
+			duration = 0
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				continue
+			}
+			if current.End.IsZero() {
+				current.End = current.Start.Add(duration)
+			}
+			if !cancelled && !current.Start.IsZero() {
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeICS(value)
+		case name == "STATUS":
+			cancelled = strings.EqualFold(value, "CANCELLED")
+		case name == "DTSTART":
+			t, allDay, err := parseICSTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("event %q: DTSTART: %w", current.UID, err)
+			}
+			current.Start, current.AllDay = t, allDay
+		case name == "DTEND":
+			t, _, err := parseICSTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("event %q: DTEND: %w", current.UID, err)
+			}
+			current.End = t
+		case name == "DURATION":
+			d, err := parseICSDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("event %q: DURATION: %w", current.UID, err)
+			}
+			duration = d
+		}
+	}
+	return events, nil
+}
+
+// unfoldICS splits the document into logical lines, joining continuation
+// lines that start with a space or tab.
+func unfoldICS(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// splitICSLine splits "NAME;PARAM=x:value" into its parts.
+func splitICSLine(line string) (name string, params map[string]string, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+	name = strings.ToUpper(parts[0])
+	for _, p := range parts[1:] {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok {
+			continue
+		}
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return name, params, value
+}
+
+func parseICSTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	if tzid := params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+var errICSDuration = errors.New("unsupported duration")
+
+// parseICSDuration parses the day and time parts of an iCalendar duration
+// such as "PT1H30M" or "P1D".
+func parseICSDuration(value string) (time.Duration, error) {
+	s, ok := strings.CutPrefix(value, "P")
+	if !ok {
+		return 0, errICSDuration
+	}
+	var d time.Duration
+	inTime := false
+	num := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+		case r == 'T':
+			inTime = true
+		default:
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, errICSDuration
+			}
+			num = ""
+			switch {
+			case r == 'W' && !inTime:
+				d += time.Duration(n) * 7 * 24 * time.Hour
+			case r == 'D' && !inTime:
+				d += time.Duration(n) * 24 * time.Hour
+			case r == 'H' && inTime:
+				d += time.Duration(n) * time.Hour
+			case r == 'M' && inTime:
+				d += time.Duration(n) * time.Minute
+			case r == 'S' && inTime:
+				d += time.Duration(n) * time.Second
+			default:
+				return 0, errICSDuration
+			}
+		}
+	}
+	if num != "" {
+		return 0, errICSDuration
+	}
+	return d, nil
+}
+
+var icsUnescaper = strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeICS(s string) string {
+	return icsUnescaper.Replace(s)
+}
@@ -0,0 +1,118 @@
+package tasksync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"SUMMARY:Team\r\n" +
+	"  standup\\, daily\r\n" +
+	"DTSTART;TZID=Europe/Madrid:20250106T093000\r\n" +
+	"DTEND;TZID=Europe/Madrid:20250106T094500\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:review@example.com\r\n" +
+	"SUMMARY:Design review\r\n" +
+	"DTSTART:20250107T130000Z\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday@example.com\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"DTSTART;VALUE=DATE:20250108\r\n" +
+	"DTEND;VALUE=DATE:20250109\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:cancelled@example.com\r\n" +
+	"SUMMARY:Cancelled sync\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"DTSTART:20250109T100000Z\r\n" +
+	"DTEND:20250109T110000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICS(t *testing.T) {
+	events, err := ParseICS([]byte(testICS), time.UTC)
+	if err != nil {
+		t.Fatalf("ParseICS failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3 (cancelled one dropped)", len(events))
+	}
+
+	standup := events[0]
+	if standup.Summary != "Team standup, daily" {
+		t.Errorf("Summary = %q, want unfolded and unescaped", standup.Summary)
+	}
+	if got := standup.Start.UTC().Format("15:04"); got != "08:30" {
+		t.Errorf("standup start = %s UTC, want 08:30 (09:30 Madrid)", got)
+	}
+
+	review := events[1]
+	if got := review.End.Sub(review.Start); got != 90*time.Minute {
+		t.Errorf("review duration = %v, want 1h30m", got)
+	}
+	if !events[2].AllDay {
+		t.Error("holiday should be all-day")
+	}
+}
+
+func TestICSSource_Pull(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work.ics")
+	if err := os.WriteFile(path, []byte(testICS), 0o644); err != nil {
+		t.Fatalf("writing feed: %v", err)
+	}
+
+	src := &ICSSource{
+		Label: "work",
+		URL:   path,
+		Now:   func() time.Time { return time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local) },
+	}
+	tasks, err := src.Pull(context.Background())
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2 (all-day skipped)", len(tasks))
+	}
+	got := tasks[0]
+	if got.Category != task.CategoryShallow {
+		t.Errorf("Category = %q, want shallow by default", got.Category)
+	}
+	if want := (task.ExternalRef{Source: "ics", ID: "work/standup@example.com"}); got.ExternalRef != want {
+		t.Errorf("ExternalRef = %v, want %v", got.ExternalRef, want)
+	}
+	if d := got.Duration(); d != 15 {
+		t.Errorf("Duration = %d, want 15", d)
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"PT45M", 45 * time.Minute, false},
+		{"PT1H30M", 90 * time.Minute, false},
+		{"P1D", 24 * time.Hour, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"1H", 0, true},
+		{"PT5", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseICSDuration(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseICSDuration(%q) = %v, %v; want %v, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package tasksync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// ActionKind is the kind of change sent back to a source.
+type ActionKind string
+
+// Outbound actions.
+const (
+	ActionComplete ActionKind = "complete" // The task was done (an outcome was set)
+	ActionCancel   ActionKind = "cancel"
+)
+
+// Action is a change made in sancho that has to reach an external source.
+type Action struct {
+	Kind      ActionKind       `json:"kind"`
+	Ref       task.ExternalRef `json:"ref"`
+	Outcome   task.Outcome     `json:"outcome,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	Attempts  int              `json:"attempts,omitempty"`
+	LastErr   string           `json:"last_error,omitempty"`
+}
+
+// Pusher is implemented by sources that accept updates from sancho.
+type Pusher interface {
+	// Owns reports whether the source manages the referenced task.
+	Owns(ref task.ExternalRef) bool
+
+	// Push sends one action to the source.
+	Push(ctx context.Context, a Action) error
+}
+
+// Outbox is a durable queue of actions waiting to be pushed. It is saved to
+// disk on every change, so actions queued while offline survive restarts
+// and are replayed, in order, once the source is reachable again.
+type Outbox struct {
+	path string
+
+	mu      sync.Mutex
+	actions []Action
+}
+
+// OutboxPath returns where the outbox for a sync state file is kept.
+func OutboxPath(statePath string) string {
+	if statePath == "" {
+		return ""
+	}
+	return strings.TrimSuffix(statePath, ".json") + ".outbox.json"
+}
+
+// NewOutbox creates an outbox saved at path. An empty path keeps the queue
+// in memory only.
+func NewOutbox(path string) *Outbox {
+	return &Outbox{path: path}
+}
+
+// Load reads queued actions from disk. A missing file is an empty queue.
+func (o *Outbox) Load() error {
+	if o.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(o.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading outbox: %w", err)
+	}
+	var actions []Action
+	if err := json.Unmarshal(data, &actions); err != nil {
+		return fmt.Errorf("parsing outbox: %w", err)
+	}
+	o.mu.Lock()
+	o.actions = actions
+	o.mu.Unlock()
+	return nil
+}
+
+// Add queues an action. A queued action of the same kind for the same task
+// is replaced in place rather than sent twice.
+func (o *Outbox) Add(a Action) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for i, q := range o.actions {
+		if q.Kind == a.Kind && q.Ref == a.Ref {
+			o.actions[i] = a
+			return o.save()
+		}
+	}
+	o.actions = append(o.actions, a)
+	return o.save()
+}
+
+// Len returns the number of queued actions.
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.actions)
+}
+
+// Pending returns a copy of the queued actions, oldest first.
+func (o *Outbox) Pending() []Action {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]Action(nil), o.actions...)
+}
+
+// Flush pushes queued actions in order. When a push fails, later actions for
+// the same source wait for the next flush so they are not applied out of
+// order. Actions no pusher owns are dropped. Returns how many were sent and
+// the first error.
+func (o *Outbox) Flush(ctx context.Context, pushers []Pusher) (int, error) {
+	queued := o.Pending()
+	if len(queued) == 0 {
+		return 0, nil
+	}
+
+	var (
+		sent     int
+		firstErr error
+		done     = make(map[int]bool)
+		failed   = make(map[int]error)
+		blocked  = make(map[int]bool) // Pushers that failed, by index
+	)
+	for i, a := range queued {
+		p := ownerOf(pushers, a.Ref)
+		if p < 0 {
+			done[i] = true
+			continue
+		}
+		if blocked[p] {
+			continue
+		}
+		if err := pushers[p].Push(ctx, a); err != nil {
+			blocked[p] = true
+			failed[i] = err
+			if firstErr == nil {
+				firstErr = fmt.Errorf("pushing %s %s: %w", a.Kind, a.Ref, err)
+			}
+			continue
+		}
+		done[i] = true
+		sent++
+	}
+
+	// Actions added or replaced while pushing are kept as they are
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	rest := make([]Action, 0, len(o.actions))
+	for i, a := range o.actions {
+		unchanged := i < len(queued) && a == queued[i]
+		if unchanged && done[i] {
+			continue
+		}
+		if err, ok := failed[i]; ok && unchanged {
+			a.Attempts++
+			a.LastErr = err.Error()
+		}
+		rest = append(rest, a)
+	}
+	o.actions = rest
+	if err := o.save(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return sent, firstErr
+}
+
+// ownerOf returns the index of the pusher that owns ref, or -1.
+func ownerOf(pushers []Pusher, ref task.ExternalRef) int {
+	for i, p := range pushers {
+		if p.Owns(ref) {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes the queue to disk. Callers must hold o.mu.
+func (o *Outbox) save() error {
+	if o.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(o.actions)
+	if err != nil {
+		return fmt.Errorf("encoding outbox: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(o.path), 0o755); err != nil {
+		return fmt.Errorf("creating outbox directory: %w", err)
+	}
+	if err := os.WriteFile(o.path, data, 0o644); err != nil {
+		return fmt.Errorf("writing outbox: %w", err)
+	}
+	return nil
+}
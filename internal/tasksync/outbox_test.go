@@ -0,0 +1,97 @@
+package tasksync
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+var errOffline = errors.New("network is unreachable")
+
+// fakePusher is a source that accepts updates for refs of its own source.
+type fakePusher struct {
+	fakeSource
+	source  string
+	offline bool
+	pushed  []Action
+}
+
+func (f *fakePusher) Owns(ref task.ExternalRef) bool { return ref.Source == f.source }
+
+func (f *fakePusher) Push(ctx context.Context, a Action) error {
+	if f.offline {
+		return errOffline
+	}
+	f.pushed = append(f.pushed, a)
+	return nil
+}
+
+func TestOutbox_QueuesWhileOfflineAndReplaysInOrder(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "sync.outbox.json")
+	pusher := &fakePusher{source: "todoist", offline: true}
+	first := task.ExternalRef{Source: "todoist", ID: "1"}
+	second := task.ExternalRef{Source: "todoist", ID: "2"}
+
+	o := NewOutbox(path)
+	for _, a := range []Action{
+		{Kind: ActionComplete, Ref: first, Outcome: task.OutcomeOver},
+		{Kind: ActionCancel, Ref: second},
+		{Kind: ActionComplete, Ref: first, Outcome: task.OutcomeOnTime}, // Replaces the first
+		{Kind: ActionCancel, Ref: task.ExternalRef{Source: "jira", ID: "X-1"}},
+	} {
+		if err := o.Add(a); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if got := o.Len(); got != 3 {
+		t.Fatalf("Len = %d, want 3", got)
+	}
+
+	sent, err := o.Flush(ctx, []Pusher{pusher})
+	if !errors.Is(err, errOffline) || sent != 0 {
+		t.Fatalf("Flush offline = %d, %v; want 0 and the network error", sent, err)
+	}
+
+	// The queue survives a restart; the action nobody owns was dropped
+	reopened := NewOutbox(path)
+	if err := reopened.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	pending := reopened.Pending()
+	if len(pending) != 2 || pending[0].Attempts != 1 || pending[1].Attempts != 0 {
+		t.Fatalf("pending after failed flush = %+v, want 2 with only the first attempted", pending)
+	}
+
+	pusher.offline = false
+	sent, err = reopened.Flush(ctx, []Pusher{pusher})
+	if err != nil || sent != 2 {
+		t.Fatalf("Flush online = %d, %v; want 2, nil", sent, err)
+	}
+	if pusher.pushed[0].Ref != first || pusher.pushed[0].Outcome != task.OutcomeOnTime || pusher.pushed[1].Ref != second {
+		t.Errorf("pushed = %+v, want the first task's latest action, then the second", pusher.pushed)
+	}
+	if got := reopened.Len(); got != 0 {
+		t.Errorf("Len after replay = %d, want 0", got)
+	}
+}
+
+func TestScheduler_EnqueueNeedsPusher(t *testing.T) {
+	ref := task.ExternalRef{Source: "todoist", ID: "1"}
+
+	s := NewScheduler(nil, []Source{&fakeSource{}}, 0, "")
+	if ok, err := s.Enqueue(Action{Kind: ActionComplete, Ref: ref}); ok || err != nil {
+		t.Fatalf("Enqueue without pusher = %v, %v; want false, nil", ok, err)
+	}
+
+	s = NewScheduler(nil, []Source{&fakePusher{source: "todoist"}}, 0, "")
+	if ok, err := s.Enqueue(Action{Kind: ActionComplete, Ref: ref}); !ok || err != nil {
+		t.Fatalf("Enqueue = %v, %v; want true, nil", ok, err)
+	}
+	if got := s.Queued(); got != 1 {
+		t.Fatalf("Queued = %d, want 1", got)
+	}
+}
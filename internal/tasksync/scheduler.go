@@ -0,0 +1,401 @@
+package tasksync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Source is an external system whose tasks are synced into sancho.
+type Source interface {
+	// Name identifies the source in status output.
+	Name() string
+
+	// Pull returns the source's current tasks, each with ExternalRef set.
+	Pull(ctx context.Context) ([]task.Task, error)
+}
+
+// Sources builds the configured sync sources.
+func Sources(cfg config.SyncConfig) []Source {
+	sources := make([]Source, 0, len(cfg.ICS))
+	for _, feed := range cfg.ICS {
+		sources = append(sources, &ICSSource{
+			Label:    feed.Name,
+			URL:      feed.URL,
+			Category: task.Category(feed.Category),
+		})
+	}
+	return sources
+}
+
+// StatePath returns where the sync state for a database is kept.
+func StatePath(dbPath string) string {
+	return dbPath + ".sync.json"
+}
+
+// maxBackoffFactor caps how far a failing source is slowed down, as a
+// multiple of the normal interval.
+const maxBackoffFactor = 8
+
+// Result is the outcome of one sync run against a source.
+type Result struct {
+	Created   int
+	Updated   int
+	Skipped   int         // Tasks that could not be saved, e.g. overlaps
+	Conflicts []*Conflict // Tasks changed on both sides, waiting for the user
+	SkipErr   error       // First reason a task was skipped
+}
+
+// Status is the sync state of one source.
+type Status struct {
+	Source   string
+	LastSync time.Time // Last successful run; zero before the first one
+	LastErr  error     // Error of the last run, nil if it succeeded
+	PushErr  error     // Error pushing queued actions on the last run
+	Failures int       // Consecutive failed runs
+	NextRun  time.Time
+	Result   Result // Outcome of the last successful run
+}
+
+// Event is sent after every run of a source.
+type Event struct {
+	Status Status
+}
+
+// Scheduler runs sources in the background on an interval. It remembers the
+// version of each task it last synced (persisted to statePath when set), so
+// a later run can tell local edits from remote ones and report tasks that
+// changed on both sides as conflicts.
+type Scheduler struct {
+	repo      task.Repository
+	sources   []Source
+	interval  time.Duration
+	statePath string
+	clock     clock.Clock
+	jitter    func(time.Duration) time.Duration
+	outbox    *Outbox // Actions waiting to be pushed to sources
+
+	mu      sync.Mutex
+	bases   map[string]task.Task // Keyed by external reference
+	pending map[string]bool      // Conflicts reported and not yet resolved
+	status  map[string]*Status
+}
+
+// NewScheduler creates a scheduler for sources. statePath may be empty to
+// keep the last synced versions in memory only.
+func NewScheduler(repo task.Repository, sources []Source, interval time.Duration, statePath string) *Scheduler {
+	s := &Scheduler{
+		repo:      repo,
+		sources:   sources,
+		interval:  interval,
+		statePath: statePath,
+		clock:     clock.System,
+		jitter:    Jitter,
+		outbox:    NewOutbox(OutboxPath(statePath)),
+		bases:     make(map[string]task.Task),
+		pending:   make(map[string]bool),
+		status:    make(map[string]*Status, len(sources)),
+	}
+	for _, src := range sources {
+		s.status[src.Name()] = &Status{Source: src.Name()}
+	}
+	return s
+}
+
+// Jitter spreads d by up to 10% either way so sources configured together do
+// not all hit the network at the same moment.
+func Jitter(d time.Duration) time.Duration {
+	spread := int64(d / 10)
+	if spread <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int64N(2*spread+1)-spread)
+}
+
+// NextDelay returns the wait before the next run: the interval after a
+// success, doubling with each consecutive failure up to maxBackoffFactor
+// times the interval.
+func NextDelay(interval time.Duration, failures int) time.Duration {
+	factor := 1
+	for i := 0; i < failures && factor < maxBackoffFactor; i++ {
+		factor *= 2
+	}
+	return interval * time.Duration(factor)
+}
+
+// Start loads the saved sync state and runs every source in its own
+// goroutine until ctx is cancelled: once right away, then on the interval.
+// Events are delivered on the returned channel, which is closed when all
+// sources have stopped.
+func (s *Scheduler) Start(ctx context.Context) <-chan Event {
+	events := make(chan Event, len(s.sources))
+	err := s.load()
+	if err == nil {
+		err = s.outbox.Load()
+	}
+	if err != nil {
+		for _, st := range s.status {
+			st.LastErr = err
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, src := range s.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, src, events)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+	return events
+}
+
+func (s *Scheduler) loop(ctx context.Context, src Source, events chan<- Event) {
+	delay := time.Duration(0)
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		st := s.run(ctx, src)
+		delay = s.jitter(NextDelay(s.interval, st.Failures))
+		s.mu.Lock()
+		s.status[src.Name()].NextRun = s.clock.Now().Add(delay)
+		st = *s.status[src.Name()]
+		s.mu.Unlock()
+
+		select {
+		case events <- Event{Status: st}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run syncs one source and records its status. Queued actions are pushed
+// first, so a source that accepts updates sees local changes before its
+// tasks are pulled again.
+func (s *Scheduler) run(ctx context.Context, src Source) Status {
+	var pushErr error
+	if _, ok := src.(Pusher); ok {
+		_, pushErr = s.Flush(ctx)
+	}
+	res, err := s.RunOnce(ctx, src)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.status[src.Name()]
+	st.LastErr = err
+	st.PushErr = pushErr
+	if err != nil {
+		st.Failures++
+	} else {
+		st.Failures = 0
+		st.LastSync = s.clock.Now()
+		st.Result = res
+		if saveErr := s.save(); saveErr != nil {
+			st.LastErr = saveErr
+		}
+	}
+	return *st
+}
+
+// RunOnce pulls a source and applies its changes. New remote tasks are
+// created; tasks changed only remotely are updated; tasks changed on both
+// sides are returned as conflicts and left alone until resolved. Tasks
+// removed from the source are kept.
+func (s *Scheduler) RunOnce(ctx context.Context, src Source) (Result, error) {
+	remote, err := src.Pull(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var res Result
+	skip := func(t task.Task, err error) {
+		res.Skipped++
+		if res.SkipErr == nil {
+			res.SkipErr = fmt.Errorf("%q: %w", t.Description, err)
+		}
+	}
+
+	for _, r := range remote {
+		if r.ExternalRef.IsZero() {
+			skip(r, task.ErrMissingExternalRef)
+			continue
+		}
+		key := r.ExternalRef.String()
+
+		local, err := s.repo.GetTaskByExternalRef(ctx, r.ExternalRef)
+		if err != nil {
+			return res, fmt.Errorf("looking up %s: %w", key, err)
+		}
+
+		s.mu.Lock()
+		base, known := s.bases[key]
+		pending := s.pending[key]
+		s.mu.Unlock()
+		if pending {
+			continue
+		}
+
+		if local == nil {
+			t := r
+			t.ID = 0
+			t.Status = task.StatusScheduled
+			if _, err := s.repo.UpsertTask(ctx, &t); err != nil {
+				skip(r, err)
+				continue
+			}
+			res.Created++
+			s.setBase(key, r)
+			continue
+		}
+
+		if !known {
+			// Nothing synced yet (or the state was lost): assume the local
+			// copy is unchanged so the remote one wins.
+			base = *local
+		}
+		if c, ok := Detect(src.Name(), base, *local, r); ok {
+			s.mu.Lock()
+			s.pending[key] = true
+			s.mu.Unlock()
+			res.Conflicts = append(res.Conflicts, c)
+			continue
+		}
+		if len(changedFields(base, r)) > 0 && len(changedFields(*local, r)) > 0 {
+			t := r
+			if _, err := s.repo.UpsertTask(ctx, &t); err != nil {
+				skip(r, err)
+				continue
+			}
+			res.Updated++
+		}
+		s.setBase(key, r)
+	}
+	return res, nil
+}
+
+// Resolve marks a conflict as handled, whichever copy the user kept. The
+// remote version becomes the new base, so the task is only reported again
+// when it changes remotely once more.
+func (s *Scheduler) Resolve(c *Conflict) {
+	key := c.Remote.ExternalRef.String()
+	s.mu.Lock()
+	delete(s.pending, key)
+	s.bases[key] = c.Remote
+	_ = s.save()
+	s.mu.Unlock()
+}
+
+// Enqueue queues an action for the source that owns its task and reports
+// whether one does. Actions for tasks no source accepts updates for are
+// ignored.
+func (s *Scheduler) Enqueue(a Action) (bool, error) {
+	if ownerOf(s.pushers(), a.Ref) < 0 {
+		return false, nil
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = s.clock.Now()
+	}
+	return true, s.outbox.Add(a)
+}
+
+// Flush pushes queued actions now. Actions that fail stay queued and are
+// retried on the next run.
+func (s *Scheduler) Flush(ctx context.Context) (int, error) {
+	return s.outbox.Flush(ctx, s.pushers())
+}
+
+// Queued returns the number of actions waiting to be pushed.
+func (s *Scheduler) Queued() int {
+	return s.outbox.Len()
+}
+
+// pushers returns the sources that accept updates.
+func (s *Scheduler) pushers() []Pusher {
+	var pushers []Pusher
+	for _, src := range s.sources {
+		if p, ok := src.(Pusher); ok {
+			pushers = append(pushers, p)
+		}
+	}
+	return pushers
+}
+
+// Statuses returns the state of every source, sorted by name.
+func (s *Scheduler) Statuses() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Status, 0, len(s.status))
+	for _, st := range s.status {
+		out = append(out, *st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Source < out[j].Source })
+	return out
+}
+
+func (s *Scheduler) setBase(key string, t task.Task) {
+	s.mu.Lock()
+	s.bases[key] = t
+	s.mu.Unlock()
+}
+
+// load reads the last synced versions from statePath.
+func (s *Scheduler) load() error {
+	if s.statePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading sync state: %w", err)
+	}
+	bases := make(map[string]task.Task)
+	if err := json.Unmarshal(data, &bases); err != nil {
+		return fmt.Errorf("parsing sync state: %w", err)
+	}
+	s.mu.Lock()
+	s.bases = bases
+	s.mu.Unlock()
+	return nil
+}
+
+// save writes the last synced versions to statePath. Callers must hold s.mu.
+func (s *Scheduler) save() error {
+	if s.statePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(s.bases)
+	if err != nil {
+		return fmt.Errorf("encoding sync state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0o755); err != nil {
+		return fmt.Errorf("creating sync state directory: %w", err)
+	}
+	if err := os.WriteFile(s.statePath, data, 0o644); err != nil {
+		return fmt.Errorf("writing sync state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package tasksync
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+type fakeSource struct {
+	tasks []task.Task
+}
+
+func (f *fakeSource) Name() string { return "fake" }
+
+func (f *fakeSource) Pull(ctx context.Context) ([]task.Task, error) {
+	return f.tasks, nil
+}
+
+func TestNextDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 15 * time.Minute},
+		{1, 30 * time.Minute},
+		{2, time.Hour},
+		{3, 2 * time.Hour},
+		{10, 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := NextDelay(15*time.Minute, tt.failures); got != tt.want {
+			t.Errorf("NextDelay(15m, %d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestJitterStaysWithinTenPercent(t *testing.T) {
+	for range 100 {
+		if got := Jitter(time.Hour); got < 54*time.Minute || got > 66*time.Minute {
+			t.Fatalf("Jitter(1h) = %v, want within 10%%", got)
+		}
+	}
+}
+
+func TestScheduler_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo, err := db.New(filepath.Join(dir, "sancho.db"))
+	if err != nil {
+		t.Fatalf("creating repo: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+
+	ref := task.ExternalRef{Source: "ics", ID: "work/1"}
+	remote := task.Task{
+		Description:    "Standup",
+		Category:       task.CategoryShallow,
+		ScheduledDate:  time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "09:15",
+		Status:         task.StatusScheduled,
+		ExternalRef:    ref,
+	}
+	src := &fakeSource{tasks: []task.Task{remote}}
+	s := NewScheduler(repo, []Source{src}, 15*time.Minute, filepath.Join(dir, "sync.json"))
+
+	// First run creates the task; a second one is a no-op
+	for i, want := range []Result{{Created: 1}, {}} {
+		res, err := s.RunOnce(ctx, src)
+		if err != nil {
+			t.Fatalf("run %d: %v", i+1, err)
+		}
+		if res.Created != want.Created || res.Updated != 0 || len(res.Conflicts) != 0 {
+			t.Fatalf("run %d: result = %+v, want %+v", i+1, res, want)
+		}
+	}
+
+	// Remote-only change updates the local copy
+	src.tasks[0].ScheduledStart, src.tasks[0].ScheduledEnd = "10:00", "10:15"
+	res, err := s.RunOnce(ctx, src)
+	if err != nil || res.Updated != 1 {
+		t.Fatalf("remote change: result = %+v, err = %v; want 1 updated", res, err)
+	}
+	local, _ := repo.GetTaskByExternalRef(ctx, ref)
+	if local.ScheduledStart != "10:00" {
+		t.Fatalf("local start = %s, want 10:00", local.ScheduledStart)
+	}
+
+	// Changes on both sides are reported once, then wait for resolution
+	if err := repo.UpdateTaskDescription(ctx, local.ID, "Standup (notes)", 0); err != nil {
+		t.Fatalf("UpdateTaskDescription failed: %v", err)
+	}
+	src.tasks[0].Description = "Daily standup"
+	res, err = s.RunOnce(ctx, src)
+	if err != nil || len(res.Conflicts) != 1 {
+		t.Fatalf("both changed: result = %+v, err = %v; want 1 conflict", res, err)
+	}
+	if res, _ := s.RunOnce(ctx, src); len(res.Conflicts) != 0 {
+		t.Fatalf("pending conflict reported again: %+v", res)
+	}
+
+	s.Resolve(res.Conflicts[0])
+	res, err = s.RunOnce(ctx, src)
+	if err != nil || len(res.Conflicts) != 0 || res.Updated != 0 {
+		t.Fatalf("after resolve: result = %+v, err = %v; want no changes", res, err)
+	}
+
+	// The synced versions survive a restart
+	restarted := NewScheduler(repo, []Source{src}, 15*time.Minute, filepath.Join(dir, "sync.json"))
+	if err := restarted.load(); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if res, _ := restarted.RunOnce(ctx, src); res.Updated != 0 || len(res.Conflicts) != 0 {
+		t.Fatalf("after restart: result = %+v, want no changes", res)
+	}
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/autosave"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// autosaveInterval is how often the changes of an edit session are written
+// to the autosave file.
+const autosaveInterval = 10 * time.Second
+
+// handleAutosaveTick writes the pending changes of an edit session, or
+// removes the file once there are none, and waits for the next tick.
+func (m Model) handleAutosaveTick() (tea.Model, tea.Cmd) {
+	next := commands.AutosaveTick(autosaveInterval)
+	if !m.slotState.IsEditing() || !m.slotState.HasChanges() {
+		return m, tea.Batch(m.clearAutosave(), next)
+	}
+	changes := m.slotState.PendingChanges()
+	if len(changes) == 0 {
+		return m, tea.Batch(m.clearAutosave(), next)
+	}
+
+	s := &autosave.Session{WeekStart: m.weekStart, SavedAt: m.now()}
+	for _, c := range changes {
+		s.Changes = append(s.Changes, autosave.Change{
+			ID:          c.After.ID,
+			Description: c.After.Description,
+			OldDate:     c.Before.ScheduledDate,
+			OldStart:    c.Before.ScheduledStart,
+			OldEnd:      c.Before.ScheduledEnd,
+			Date:        c.After.ScheduledDate,
+			Start:       c.After.ScheduledStart,
+			End:         c.After.ScheduledEnd,
+		})
+	}
+	m.autosaved = true
+	return m, tea.Batch(commands.WriteAutosave(m.autosavePath, s), next)
+}
+
+// clearAutosave removes the autosave file once the session it was written
+// for has been saved or discarded.
+func (m *Model) clearAutosave() tea.Cmd {
+	if !m.autosaved {
+		return nil
+	}
+	m.autosaved = false
+	return commands.RemoveAutosave(m.autosavePath)
+}
+
+// handleAutosaveFound offers to restore the changes of an edit session that
+// ended without saving or discarding them.
+func (m Model) handleAutosaveFound(msg commands.AutosaveFoundMsg) (tea.Model, tea.Cmd) {
+	if m.mode != ModeNormal {
+		m.statusMsg = "Unsaved edits from an earlier session were kept; restart to restore them"
+		return m, nil
+	}
+	m.autosaveFound = msg.Session
+	m.mode = ModeModal
+	m.modalType = ModalRestoreEdits
+	return m, nil
+}
+
+// handleRestoreEditsKeys restores the autosaved session with Enter, once its
+// week is loaded, or deletes it.
+func (m Model) handleRestoreEditsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		m.restoreOnLoad = m.autosaveFound
+		m.closeRestoreEdits()
+		m.weekStart = m.restoreOnLoad.WeekStart
+		m.loading = true
+		return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+	case "esc", "q", "n":
+		m.closeRestoreEdits()
+		m.statusMsg = "Autosaved edits discarded"
+		return m, commands.RemoveAutosave(m.autosavePath)
+	}
+	return m, nil
+}
+
+func (m *Model) closeRestoreEdits() {
+	m.autosaveFound = nil
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+}
+
+// restoreAutosave reapplies the session waiting in restoreOnLoad to the
+// freshly loaded week in a new edit session. The file stays until the
+// edits are saved or discarded.
+func (m *Model) restoreAutosave() {
+	s := m.restoreOnLoad
+	m.restoreOnLoad = nil
+
+	changes := make([]PendingChange, len(s.Changes))
+	for i, c := range s.Changes {
+		changes[i] = PendingChange{
+			Before: &task.Task{ID: c.ID, Description: c.Description, ScheduledDate: c.OldDate, ScheduledStart: c.OldStart, ScheduledEnd: c.OldEnd},
+			After:  &task.Task{ID: c.ID, Description: c.Description, ScheduledDate: c.Date, ScheduledStart: c.Start, ScheduledEnd: c.End},
+		}
+	}
+	m.slotState.EnterEditMode()
+	skipped, err := m.slotState.ApplyChanges(changes)
+	if err != nil {
+		m.slotState.DiscardChanges()
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return
+	}
+	m.mode = ModeEdit
+	m.autosaved = true
+	m.markCacheDirty()
+	m.statusMsg = fmt.Sprintf("Restored %d edits; Enter to save, Esc to discard", len(changes)-skipped)
+	if skipped > 0 {
+		m.statusMsg = fmt.Sprintf("Restored %d edits, %d no longer fit; Enter to save, Esc to discard", len(changes)-skipped, skipped)
+	}
+}
+
+func (m Model) renderRestoreEditsModal() string {
+	s := m.autosaveFound
+	rows := make([]view.EditPreviewRow, len(s.Changes))
+	for i, c := range s.Changes {
+		rows[i] = view.EditPreviewRow{
+			Description: c.Description,
+			OldDate:     c.OldDate,
+			OldStart:    c.OldStart,
+			OldEnd:      c.OldEnd,
+			NewDate:     c.Date,
+			NewStart:    c.Start,
+			NewEnd:      c.End,
+		}
+	}
+	styles := view.MissedStyles{
+		BodyStyle: m.styles.ModalBodyStyle,
+		MetaStyle: m.styles.ModalMetaStyle,
+	}
+	intro := styles.MetaStyle.Render(fmt.Sprintf("An edit session from %s was not saved.", s.SavedAt.Format("Mon Jan 02 15:04")))
+	body := intro + "\n\n" + view.RenderEditPreviewBody(rows, styles)
+	footer := view.RestoreEditsFooter(m.modalStyles())
+	return view.RenderModalFrame("Restore unsaved edits", body, footer, m.modalStyles())
+}
@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/issues"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// importUsage is shown for an /import line that names no known tracker.
+const importUsage = "Usage: /import github owner/repo, or /import jira"
+
+// handleImportCommand handles "/import github owner/repo", which adds the
+// open issues assigned to the user to the backlog, and "/import jira", which
+// adds the issues matched by the configured JQL query.
+func (m Model) handleImportCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 || m.config == nil {
+		m.statusMsg = importUsage
+		return m, nil
+	}
+	var (
+		label string
+		src   issues.Source
+	)
+	switch {
+	case args[0] == "github" && len(args) == 2:
+		gh := m.config.Import.GitHub
+		label = args[1]
+		src = &issues.GitHub{Repo: args[1], User: gh.User, Token: gh.Token()}
+	case args[0] == "jira" && len(args) == 1:
+		jira := m.config.Import.Jira
+		if jira.JQL == "" {
+			m.statusMsg = "Set url and jql under [import.jira] to import from Jira"
+			return m, nil
+		}
+		label = "Jira"
+		src = &issues.Jira{URL: jira.URL, JQL: jira.JQL, Email: jira.Email, Token: jira.Token()}
+	default:
+		m.statusMsg = importUsage
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Importing issues from %s...", label)
+	return m, commands.ImportIssues(m.persistentRepo(), label, src)
+}
+
+func (m Model) handleBacklogImportedMsg(msg commands.BacklogImportedMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = fmt.Sprintf("Imported %d issues from %s into the backlog (%d updated); /backlog to schedule them",
+		msg.Created, msg.Source, msg.Updated)
+	return m, nil
+}
+
+// openBacklog loads the backlog for the /backlog modal.
+func (m Model) openBacklog() (tea.Model, tea.Cmd) {
+	return m, commands.LoadBacklog(m.persistentRepo())
+}
+
+func (m Model) handleBacklogMsg(msg commands.BacklogMsg) (tea.Model, tea.Cmd) {
+	m.backlogItems = msg.Items
+	m.backlogCursor = 0
+	m.mode = ModeModal
+	m.modalType = ModalBacklog
+	m.statusMsg = ""
+	return m, nil
+}
+
+// handleBacklogKeys moves through the backlog. Enter opens the prompt with
+// /add and the item's title so only the day and time are left to type; p
+// parks the item on the cursor's day, or unparks it; x removes the item.
+func (m Model) handleBacklogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.backlogCursor = min(m.backlogCursor+1, max(len(m.backlogItems)-1, 0))
+	case "k", "up":
+		m.backlogCursor = max(m.backlogCursor-1, 0)
+	case "enter":
+		if len(m.backlogItems) == 0 {
+			m.closeBacklog()
+			return m, nil
+		}
+		item := m.backlogItems[m.backlogCursor]
+		m.closeBacklog()
+		m.mode = ModePrompt
+		m.prompt.SetValue("/add " + item.Title + " ")
+		m.prompt.CursorEnd()
+		m.prompt.Focus()
+		m.calculateLayout()
+		m.layoutCache = m.buildLayoutCache(m.width, m.height)
+		m.statusMsg = "Add a day, time and length, e.g. tomorrow 14:00 2h"
+		return m, textinput.Blink
+	case "x":
+		if len(m.backlogItems) == 0 {
+			return m, nil
+		}
+		item := m.backlogItems[m.backlogCursor]
+		backlog, err := task.BacklogOf(m.persistentRepo())
+		if err == nil {
+			err = backlog.DeleteBacklogItem(context.Background(), item.ID)
+		}
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		m.backlogItems = append(m.backlogItems[:m.backlogCursor:m.backlogCursor], m.backlogItems[m.backlogCursor+1:]...)
+		m.backlogCursor = min(m.backlogCursor, max(len(m.backlogItems)-1, 0))
+		m.statusMsg = "Removed from backlog: " + item.Title
+		return m, commands.LoadParked(m.persistentRepo())
+	case "p":
+		return m.toggleParkBacklogItem()
+	case "esc", "q":
+		m.closeBacklog()
+	}
+	return m, nil
+}
+
+func (m *Model) closeBacklog() {
+	m.backlogItems = nil
+	m.backlogCursor = 0
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+}
+
+func (m Model) renderBacklogModal() string {
+	rows := make([]view.BacklogRow, len(m.backlogItems))
+	for i, item := range m.backlogItems {
+		rows[i] = view.BacklogRow{Title: item.Title, Notes: item.Notes}
+		if item.IsParked() {
+			rows[i].Parked = item.Day.Format("Mon Jan 2")
+		}
+	}
+	styles := view.BacklogStyles{
+		BodyStyle:   m.styles.ModalBodyStyle,
+		MetaStyle:   m.styles.ModalMetaStyle,
+		CursorStyle: m.styles.ModalInputCursorStyle,
+	}
+	body := view.RenderBacklogBody(rows, m.backlogCursor, styles)
+	footer := view.BacklogFooter(m.modalStyles())
+	return view.RenderModalFrame("Backlog", body, footer, m.modalStyles())
+}
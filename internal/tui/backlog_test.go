@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestBacklogModal(t *testing.T) {
+	repo := memrepo.New()
+	ctx := context.Background()
+	for _, title := range []string{"#7 Fix login", "#9 Flaky test"} {
+		if _, err := repo.UpsertBacklogItem(ctx, &task.BacklogItem{Title: title}); err != nil {
+			t.Fatalf("UpsertBacklogItem: %v", err)
+		}
+	}
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local)
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(now)))
+
+	msg := commands.LoadBacklog(repo)()
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if m.modalType != ModalBacklog || len(m.backlogItems) != 2 {
+		t.Fatalf("modal = %v with %d items, want the backlog with 2", m.modalType, len(m.backlogItems))
+	}
+
+	updated, _ = m.handleBacklogKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+	if items, _ := repo.ListBacklog(ctx); len(items) != 1 || items[0].Title != "#9 Flaky test" {
+		t.Fatalf("backlog after x = %+v", items)
+	}
+
+	updated, _ = m.handleBacklogKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.mode != ModePrompt || m.prompt.Value() != "/add #9 Flaky test " {
+		t.Errorf("mode %v, prompt %q, want the /add prompt with the title", m.mode, m.prompt.Value())
+	}
+}
+
+func TestParkAndPlace(t *testing.T) {
+	repo := memrepo.New()
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local) // Monday
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(now)))
+	m.width, m.height = 120, 40
+	updated, _ := m.Update(commands.LoadVisibleWeek(repo, m.weekStart, m.weekRadius)())
+	m = updated.(Model)
+	m.cursor.Day = 1
+
+	updated, cmd := m.handleParkCommand([]string{"Call", "the", "bank"})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if got := m.parkedOn(1); len(got) != 1 || got[0].Title != "Call the bank" {
+		t.Fatalf("parked on Tuesday = %+v", got)
+	}
+	if m.parkingLines() != 1 {
+		t.Fatalf("parkingLines = %d, want the strip shown", m.parkingLines())
+	}
+	if row, _ := m.parkingRow(); !strings.HasPrefix(row[2], " 1 Call the") {
+		t.Errorf("parking row = %q, want Tuesday to show the parked task", row)
+	}
+
+	m.cursor.Slot = 0
+	updated, cmd = m.placeParked()
+	m = updated.(Model)
+	if !strings.HasPrefix(m.statusMsg, "Placed: Call the bank") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	tuesday := m.weekStart.AddDate(0, 0, 1)
+	tasks, _ := repo.ListTasksByDateRange(context.Background(), tuesday, tuesday)
+	if len(tasks) != 1 || tasks[0].Description != "Call the bank" || tasks[0].Duration() != parkedMinutes {
+		t.Fatalf("tasks on Tuesday = %+v", tasks)
+	}
+	m = runCmds(m, cmd)
+	if m.parkingLines() != 0 {
+		t.Errorf("parkingLines = %d after placing the only parked task, want 0", m.parkingLines())
+	}
+}
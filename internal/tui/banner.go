@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// bannerLabel marks the all-day banner row in the time column.
+const bannerLabel = "all"
+
+// bannerLines is how many grid lines the all-day banner takes. The banner is
+// shown while any day of the visible week has an all-day task.
+func (m Model) bannerLines() int {
+	for _, tasks := range m.cachedBanners {
+		if len(tasks) > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// refreshBanners collects the all-day tasks of the visible week, and resizes
+// the grid when the banner appears or goes.
+func (m *Model) refreshBanners() {
+	before := m.bannerLines()
+	m.cachedBanners = [7][]*task.Task{}
+	if ww := m.slotState.WeekWindow(); ww != nil && ww.Current() != nil {
+		for day := range m.cachedBanners {
+			if d := ww.Current().Day(day); d != nil {
+				m.cachedBanners[day] = d.AllDayTasks()
+			}
+		}
+	}
+	if m.bannerLines() != before {
+		m.calculateLayout()
+		m.ensureCursorVisible()
+		m.layoutCache = m.buildLayoutCache(m.width, m.height)
+	}
+}
+
+// bannerRow renders the row over the grid with the all-day tasks of each
+// day, colored by the category of the first one.
+func (m Model) bannerRow() ([]string, []lipgloss.Style) {
+	days := m.visibleDays()
+	row := make([]string, 0, len(days)+1)
+	styles := make([]lipgloss.Style, 0, len(days)+1)
+	row = append(row, padRight(bannerLabel, 6))
+	styles = append(styles, m.styles.TimeColumnStyle.Width(6).Height(1))
+
+	for _, day := range days {
+		width := m.dayWidth(day)
+		tasks := m.cachedBanners[day]
+		if len(tasks) == 0 {
+			row = append(row, "")
+			styles = append(styles, m.styles.EmptyCellStyle.Height(1).Width(width))
+			continue
+		}
+		label := fmt.Sprintf("%d", len(tasks))
+		if !m.isNarrowDay(day) {
+			titles := make([]string, len(tasks))
+			for i, t := range tasks {
+				titles[i] = taskTitle(t)
+			}
+			label = strings.Join(titles, " · ")
+		}
+		row = append(row, " "+truncateWithEllipsis(label, max(width-2, 0)))
+		styles = append(styles, m.styleCache.cell(m.bannerStyleKey(tasks[0])).Height(1).Width(width))
+	}
+	return row, styles
+}
+
+// bannerStyleKey returns the cell style of an all-day task in the banner.
+func (m Model) bannerStyleKey(t *task.Task) cellStyleKey {
+	if key, colored := m.styleCache.categoryKey(t.Category); colored {
+		return key
+	}
+	if t.IsDeep() {
+		return cellDeep
+	}
+	return cellShallow
+}
+
+// handleAllDayCommand handles "/allday <title>", which adds an all-day task
+// such as "On call" to the cursor's day.
+func (m Model) handleAllDayCommand(args []string) (tea.Model, tea.Cmd) {
+	title := strings.TrimSpace(strings.Join(args, " "))
+	if title == "" {
+		m.statusMsg = "Usage: /allday <title>, adds an all-day task to the cursor's day"
+		return m, nil
+	}
+	if m.sandbox != nil {
+		m.statusMsg = "All-day tasks are added outside the sandbox"
+		return m, nil
+	}
+	date := m.weekStart.AddDate(0, 0, m.cursor.Day)
+	newTask := &task.Task{
+		Description:   title,
+		Category:      task.CategoryShallow,
+		ScheduledDate: date,
+		Status:        task.StatusScheduled,
+	}
+	if err := m.repo.CreateTask(context.Background(), newTask); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("All day on %s: %s", date.Format("Mon Jan 2"), title)
+	return m, m.reloadDays(date)
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestAllDayBanner(t *testing.T) {
+	repo := memrepo.New()
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local) // Monday
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(now)))
+	m.width, m.height = 120, 40
+	updated, _ := m.Update(commands.LoadVisibleWeek(repo, m.weekStart, m.weekRadius)())
+	m = updated.(Model)
+	m.cursor.Day = 1
+	if m.bannerLines() != 0 {
+		t.Fatalf("bannerLines = %d without all-day tasks, want 0", m.bannerLines())
+	}
+
+	updated, cmd := m.handleAllDayCommand([]string{"On", "call"})
+	m = runCmds(updated.(Model), cmd)
+	if m.bannerLines() != 1 {
+		t.Fatalf("bannerLines = %d, want the banner shown", m.bannerLines())
+	}
+	if row, _ := m.bannerRow(); !strings.HasPrefix(row[2], " On call") || row[1] != "" {
+		t.Errorf("banner row = %q, want Tuesday to show the all-day task", row)
+	}
+
+	// The all-day task takes no slot, so a block still fits anywhere that day
+	if got := m.taskAt(1, "09:00"); got != nil {
+		t.Errorf("taskAt(Tuesday 09:00) = %q, want a free slot", got.Description)
+	}
+	tuesday := m.weekStart.AddDate(0, 0, 1)
+	if err := repo.CreateTask(context.Background(), makeScheduledTask(0, tuesday, "09:00", "09:30")); err != nil {
+		t.Fatalf("CreateTask over an all-day task: %v", err)
+	}
+}
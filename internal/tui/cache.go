@@ -13,11 +13,15 @@ func (m *Model) markCacheDirty() {
 }
 
 func (m *Model) refreshViewCaches() {
+	m.syncColumns()
+	m.refreshBanners()
 	m.refreshGridCache()
 	m.cachedShadeMap = m.taskShadeMap()
 	m.cachedTaskLines = m.buildTaskLines()
+	m.cachedClock = m.clockStateNow()
 	m.refreshRenderCache()
 	m.cacheNeedsUpdate = false
+	m.profile.rebuild(profileGrid)
 }
 
 func (m *Model) refreshCachesIfNeeded() {
@@ -56,8 +60,6 @@ func (m *Model) buildTaskLines() map[int64][]string {
 		}
 	}
 
-	firstWidth := max(1, m.colWidth-5)
-	otherWidth := max(1, m.colWidth-1)
 	for _, t := range m.slotState.AllTasks() {
 		if t == nil {
 			continue
@@ -73,7 +75,8 @@ func (m *Model) buildTaskLines() map[int64][]string {
 		if maxLines < 1 {
 			continue
 		}
-		lines[t.ID] = wrapTextWithWidths(t.Description, firstWidth, otherWidth, maxLines)
+		width := m.taskWidth(t)
+		lines[t.ID] = wrapTextWithWidths(taskTitle(t), max(1, width-5), max(1, width-1), maxLines)
 	}
 	return lines
 }
@@ -111,6 +114,60 @@ func (m *Model) refreshGridCache() {
 		}
 		m.gridCache[day] = dayCache
 	}
+	m.refreshOverlapCache()
+}
+
+// refreshOverlapCache records, per display slot, the task overlapping the one
+// in gridCache, or the split owner's task when columns are split by owner.
+// It stays empty unless the grid holds overlapping tasks.
+func (m *Model) refreshOverlapCache() {
+	for day := range m.overlapCache {
+		m.overlapCache[day] = nil
+	}
+	grid := m.slotState.Grid()
+	if grid == nil || !grid.HasOverlaps() || m.mode == ModeMove {
+		return
+	}
+	if m.slotState.Config().SplitOwner != "" {
+		m.refreshSplitCache()
+		return
+	}
+
+	dayStart := m.dayStartMinutes()
+	for day := 0; day < 7; day++ {
+		var dayCache []*task.Task
+		for slot, t := range m.gridCache[day] {
+			if t == nil {
+				continue
+			}
+			o := m.overlapAt(day, minutesToTime(dayStart+(slot*m.rowHeight)), t)
+			if o == nil {
+				continue
+			}
+			if dayCache == nil {
+				dayCache = make([]*task.Task, len(m.gridCache[day]))
+			}
+			dayCache[slot] = o
+		}
+		m.overlapCache[day] = dayCache
+	}
+}
+
+// refreshSplitCache records, per display slot, the split owner's task shown
+// on the right half of the column.
+func (m *Model) refreshSplitCache() {
+	dayStart := m.dayStartMinutes()
+	for day := 0; day < 7; day++ {
+		_, right := m.splitSpans(m.daySpans(day))
+		if len(right) == 0 {
+			continue
+		}
+		dayCache := make([]*task.Task, len(m.gridCache[day]))
+		for slot := range dayCache {
+			dayCache[slot] = m.taskInSpans(right, day, minutesToTime(dayStart+(slot*m.rowHeight)))
+		}
+		m.overlapCache[day] = dayCache
+	}
 }
 
 func (m *Model) refreshRenderCache() {
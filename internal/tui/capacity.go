@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// capacityWarning returns a note for the status line when the week of one of
+// the given tasks now holds more of its category than the category's weekly
+// limit, e.g. " (over shallow limit: 10h 30m/10h this week)". Saving is
+// never blocked; the limit is a nudge.
+func (m *Model) capacityWarning(ctx context.Context, tasks ...*task.Task) string {
+	checked := make(map[string]bool)
+	for _, t := range tasks {
+		key := string(t.Category) + dateutil.StartOfWeek(t.ScheduledDate).Format("2006-01-02")
+		if checked[key] {
+			continue
+		}
+		checked[key] = true
+		used, limit, err := task.WeeklyUsage(ctx, m.repo, t.Category, t.ScheduledDate)
+		if err == nil && limit > 0 && used > limit {
+			return fmt.Sprintf(" (over %s limit: %s/%s this week)",
+				t.Category, view.FormatDuration(used), view.FormatDuration(limit))
+		}
+	}
+	return ""
+}
+
+// estimateHint returns a note for the status line on how long tasks like t
+// usually take against their blocks, e.g. " (similar tasks usually take
+// 1.5× longer)".
+func (m *Model) estimateHint(ctx context.Context, t *task.Task) string {
+	hint, err := task.EstimateHint(ctx, m.repo, t)
+	if err != nil || hint == "" {
+		return ""
+	}
+	return " (" + hint + ")"
+}
@@ -0,0 +1,69 @@
+// Package tui provides the terminal user interface for sancho.
+package tui
+
+// cellPos is a grid cell: a day column and a slot counted from the start of
+// the day, so scrolling keeps cells at the same position.
+type cellPos struct {
+	day  int
+	slot int
+}
+
+// renderedCell is a styled grid cell and the style and text it was styled
+// from.
+type renderedCell struct {
+	key     cellStyleKey
+	content string
+	out     string
+}
+
+// CellCache keeps the styled day cells between frames. Every frame still
+// works out each visible cell's text and style, which is cheap, but only
+// cells whose text or style changed since the previous frame go through
+// lipgloss again: a cursor move restyles the cell it left and the one it
+// entered instead of the whole grid.
+type CellCache struct {
+	widths   [7]int
+	rowLines int
+	cells    map[cellPos]renderedCell
+	restyled int // Cells styled by the current frame
+	reused   int // Cells the current frame took from the cache
+}
+
+// NewCellCache returns an empty cell cache.
+func NewCellCache() *CellCache {
+	return &CellCache{cells: make(map[cellPos]renderedCell)}
+}
+
+// beginFrame starts a frame with day columns of the given widths and cells
+// rowLines tall, dropping every cell when a size changed.
+func (c *CellCache) beginFrame(widths [7]int, rowLines int) {
+	if c == nil {
+		return
+	}
+	if c.widths != widths || c.rowLines != rowLines {
+		clear(c.cells)
+		c.widths = widths
+		c.rowLines = rowLines
+	}
+	c.restyled = 0
+	c.reused = 0
+}
+
+// styledCell returns the cell at day and slot rendered with the style named
+// by key, restyling it only when the style or content differ from the
+// previous frame's.
+func (m Model) styledCell(day, slot int, key cellStyleKey, content string) string {
+	c := m.cellCache
+	if c == nil {
+		return m.cellStyle(key, m.dayWidth(day)).Render(content)
+	}
+	pos := cellPos{day: day, slot: slot}
+	if cell, ok := c.cells[pos]; ok && cell.key == key && cell.content == content {
+		c.reused++
+		return cell.out
+	}
+	out := m.cellStyle(key, m.dayWidth(day)).Render(content)
+	c.cells[pos] = renderedCell{key: key, content: content, out: out}
+	c.restyled++
+	return out
+}
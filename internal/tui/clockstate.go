@@ -0,0 +1,49 @@
+package tui
+
+import "maps"
+
+// clockState is the part of the grid that changes as time passes: where the
+// current-time line is and which visible tasks are past or happening now.
+type clockState struct {
+	nowDay, nowSlot, nowLine int
+	showNow                  bool
+	past                     map[int64]bool
+	current                  map[int64]bool
+}
+
+// clockStateNow computes the clock state for the displayed week.
+func (m *Model) clockStateNow() clockState {
+	cs := clockState{past: make(map[int64]bool), current: make(map[int64]bool)}
+	cs.nowDay, cs.nowSlot, cs.nowLine, cs.showNow = m.nowLine()
+	for day := 0; day < 7; day++ {
+		for _, t := range m.gridCache[day] {
+			if t == nil {
+				continue
+			}
+			if _, seen := cs.past[t.ID]; seen {
+				continue
+			}
+			cs.past[t.ID] = m.isTaskPast(t)
+			if m.isCurrentTask(t) {
+				cs.current[t.ID] = true
+			}
+		}
+	}
+	return cs
+}
+
+func (cs clockState) equal(other clockState) bool {
+	return cs.nowDay == other.nowDay && cs.nowSlot == other.nowSlot && cs.nowLine == other.nowLine &&
+		cs.showNow == other.showNow && maps.Equal(cs.past, other.past) && maps.Equal(cs.current, other.current)
+}
+
+// refreshClock updates the cached clock state and reports whether anything
+// visible changed.
+func (m *Model) refreshClock() bool {
+	cs := m.clockStateNow()
+	if cs.equal(m.cachedClock) {
+		return false
+	}
+	m.cachedClock = cs
+	return true
+}
@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// minColWidth is the narrowest a day column gets, for readability.
+const minColWidth = 10
+
+// syncColumns brings the column widths up to date with the tasks shown:
+// days off collapsed by the weekends setting and per-day widths sized by
+// how many tasks each day holds. Changed widths mark the caches dirty.
+func (m *Model) syncColumns() {
+	if days := m.collapsibleDays(); days != m.collapsedDays {
+		m.collapsedDays = days
+		m.colWidth = m.calculateColWidth()
+		m.rebuildStyleCache()
+		m.markCacheDirty()
+	}
+	if widths := m.calculateDayWidths(); widths != m.dayWidths {
+		m.dayWidths = widths
+		m.markCacheDirty()
+	}
+}
+
+// calculateDayWidths splits the width the day columns share by content:
+// every day gets at least minColWidth, and the rest goes to the days with
+// tasks in proportion to how many they hold, up to twice the even share.
+// A week with no tasks, or the same number every day, comes out even.
+func (m *Model) calculateDayWidths() [7]int {
+	var widths [7]int
+	days := m.visibleDays()
+	full := make([]int, 0, len(days))
+	for _, day := range days {
+		if m.isNarrowDay(day) {
+			widths[day] = narrowColWidth
+			continue
+		}
+		full = append(full, day)
+	}
+	if m.width == 0 {
+		for _, day := range full {
+			widths[day] = m.colWidth
+		}
+		return widths
+	}
+
+	total := m.width - layoutChrome + (7 - len(days)) - (len(days)-len(full))*narrowColWidth
+	weights := make([]int, len(full))
+	for i, day := range full {
+		weights[i] = len(m.daySpans(day))
+	}
+	for i, width := range distributeWidths(total, weights, minColWidth, 2*m.colWidth) {
+		widths[full[i]] = width
+	}
+	return widths
+}
+
+// distributeWidths hands out total characters to columns of at least minW
+// and at most maxW. What is left over the minimums goes to the columns in
+// proportion to their weights, and evenly once only weightless columns have
+// room. Characters that fit nowhere are left unused.
+func distributeWidths(total int, weights []int, minW, maxW int) []int {
+	widths := make([]int, len(weights))
+	for i := range widths {
+		widths[i] = minW
+	}
+	remaining := total - len(widths)*minW
+	for remaining > 0 {
+		var open []int
+		sum := 0
+		for i, w := range widths {
+			if w < maxW {
+				open = append(open, i)
+				sum += weights[i]
+			}
+		}
+		if len(open) == 0 {
+			break
+		}
+
+		given := 0
+		for _, i := range open {
+			share := remaining / len(open)
+			if sum > 0 {
+				share = remaining * weights[i] / sum
+			}
+			share = min(share, maxW-widths[i])
+			widths[i] += share
+			given += share
+		}
+		if given == 0 {
+			// Shares rounded down to nothing: give the rest out one
+			// character at a time, heaviest columns first.
+			sort.SliceStable(open, func(a, b int) bool { return weights[open[a]] > weights[open[b]] })
+			for _, i := range open[:min(remaining, len(open))] {
+				widths[i]++
+				given++
+			}
+		}
+		remaining -= given
+	}
+	return widths
+}
+
+// dayWidth returns the width of a day column of the visible week.
+func (m Model) dayWidth(day int) int {
+	if day >= 0 && day < len(m.dayWidths) && m.dayWidths[day] > 0 {
+		return m.dayWidths[day]
+	}
+	return m.colWidth
+}
+
+// taskWidth returns the width of the column a task starts in, or the even
+// share when it is outside the visible week.
+func (m Model) taskWidth(t *task.Task) int {
+	if t == nil || !sameDay(startOfWeek(t.ScheduledDate), m.weekStart) {
+		return m.colWidth
+	}
+	return m.dayWidth(weekdayIndex(t.ScheduledDate))
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestDistributeWidths(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int
+		weights []int
+		want    []int
+	}{
+		{name: "no tasks is even", total: 42, weights: []int{0, 0, 0}, want: []int{14, 14, 14}},
+		{name: "same tasks is even", total: 42, weights: []int{2, 2, 2}, want: []int{14, 14, 14}},
+		{name: "busy day capped", total: 42, weights: []int{3, 0, 0}, want: []int{20, 11, 11}},
+		{name: "proportional", total: 42, weights: []int{2, 1, 0}, want: []int{18, 14, 10}},
+		{name: "remainder to heaviest", total: 31, weights: []int{1, 2, 0}, want: []int{10, 11, 10}},
+		{name: "too narrow", total: 20, weights: []int{1, 0, 0}, want: []int{10, 10, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := distributeWidths(tt.total, tt.weights, 10, 20); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("distributeWidths(%d, %v) = %v, want %v", tt.total, tt.weights, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayWidthsFollowTasks(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	m := *New(nil, config.Default(), WithClock(clock.Fixed(monday.Add(8*time.Hour))))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+	for day := 0; day < 7; day++ {
+		if m.dayWidth(day) != m.colWidth {
+			t.Fatalf("empty week: day %d width %d, want the even %d", day, m.dayWidth(day), m.colWidth)
+		}
+	}
+
+	tasks := []*task.Task{
+		{ID: 1, Description: "Write", Category: task.CategoryDeep, ScheduledDate: monday.AddDate(0, 0, 2), ScheduledStart: "10:00", ScheduledEnd: "11:00", Status: task.StatusScheduled},
+	}
+	updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, task.NewWeekFromTasks(monday, tasks), nil)})
+	m = updated.(Model)
+	if m.dayWidth(2) <= m.colWidth || m.dayWidth(0) >= m.colWidth {
+		t.Errorf("widths %v, want wednesday wider and the empty days narrower than %d", m.dayWidths, m.colWidth)
+	}
+	total := 0
+	for _, w := range m.dayWidths {
+		total += w
+	}
+	if want := m.width - layoutChrome; total != want {
+		t.Errorf("columns take %d characters, want %d", total, want)
+	}
+}
@@ -3,16 +3,28 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/javiermolinar/sancho/internal/autosave"
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/debuglog"
 	"github.com/javiermolinar/sancho/internal/dwplanner"
+	"github.com/javiermolinar/sancho/internal/issues"
 	"github.com/javiermolinar/sancho/internal/llm"
+	"github.com/javiermolinar/sancho/internal/replica"
+	"github.com/javiermolinar/sancho/internal/sandbox"
 	"github.com/javiermolinar/sancho/internal/summary"
 	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
 )
 
 // WeekLoadedMsg is sent when week data is loaded.
@@ -20,20 +32,36 @@ type WeekLoadedMsg struct {
 	Week *task.Week
 }
 
-// InitialLoadMsg is sent when all 3 weeks are loaded initially.
+// DaysLoadedMsg is sent when the days changed by a write are reloaded.
+type DaysLoadedMsg struct {
+	Days []*task.Day
+}
+
+// InitialLoadMsg is sent when the week window is loaded initially.
 type InitialLoadMsg struct {
 	Window *task.WeekWindow
+
+	// VisibleOnly is set at startup, when only the current week was loaded.
+	VisibleOnly bool
+}
+
+// AdjacentWeeksLoadedMsg is sent when the weeks around a week loaded with
+// LoadVisibleWeek arrive. The current week of Window is nil.
+type AdjacentWeeksLoadedMsg struct {
+	WeekStart time.Time
+	Window    *task.WeekWindow
 }
 
-// WeekShiftedMsg is sent when a new edge week is loaded after navigation.
-type WeekShiftedMsg struct {
-	Week    *task.Week
-	Forward bool // true if shifted forward, false if backward
+// WeekPrefetchedMsg is sent when a week at or beyond the edge of the window
+// has been loaded in the background.
+type WeekPrefetchedMsg struct {
+	Week *task.Week
 }
 
 // ErrMsg is sent when an error occurs.
 type ErrMsg struct {
-	Err error
+	Stream *PlanStream // Set when the error ends a plan stream
+	Err    error
 }
 
 // StatusMsgCmd is sent for temporary status messages.
@@ -44,15 +72,32 @@ type StatusMsgCmd struct {
 // ClearStatusMsg is sent to clear the status message.
 type ClearStatusMsg struct{}
 
+// NowTickMsg is sent at the start of every minute so the current-time line
+// can move.
+type NowTickMsg struct{}
+
 // PlanStartedMsg is sent when planning starts.
 type PlanStartedMsg struct{}
 
 // PlanResultMsg is sent when planning completes.
 type PlanResultMsg struct {
+	Stream  *PlanStream
 	Result  *dwplanner.PlanResult
 	Planner *dwplanner.Planner
 }
 
+// PlanChunkMsg is sent for each piece of streamed LLM output while planning.
+type PlanChunkMsg struct {
+	Stream  *PlanStream
+	Attempt int
+	Chunk   string
+}
+
+// PlanCancelledMsg is sent when an in-flight plan is cancelled.
+type PlanCancelledMsg struct {
+	Stream *PlanStream
+}
+
 // PlanSavedMsg is sent when plan is saved successfully.
 type PlanSavedMsg struct {
 	Count int
@@ -63,36 +108,66 @@ type WeekSummaryMsg struct {
 	Summary *summary.WeekSummary
 }
 
-// LoadInitialWeeks loads 3 weeks (prev, current, next).
-func LoadInitialWeeks(repo task.Repository, weekStart time.Time) tea.Cmd {
+// ReflectionMsg is sent when the reflection on the last week is ready.
+type ReflectionMsg struct {
+	Reflection *summary.Reflection
+}
+
+// LoadInitialWeeks loads the current week and radius weeks on each side.
+func LoadInitialWeeks(repo task.Repository, weekStart time.Time, radius int) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 
-		prevStart := weekStart.AddDate(0, 0, -7)
-		currStart := weekStart
-		nextStart := weekStart.AddDate(0, 0, 7)
-
-		prevTasks, err := repo.ListTasksByDateRange(ctx, prevStart, prevStart.AddDate(0, 0, 6))
-		if err != nil {
-			return ErrMsg{Err: err}
+		weeks := make([]*task.Week, 0, 2*radius+1)
+		for offset := -radius; offset <= radius; offset++ {
+			week, err := loadWeek(ctx, repo, weekStart.AddDate(0, 0, 7*offset))
+			if err != nil {
+				return ErrMsg{Err: err}
+			}
+			weeks = append(weeks, week)
 		}
 
-		currTasks, err := repo.ListTasksByDateRange(ctx, currStart, currStart.AddDate(0, 0, 6))
+		return InitialLoadMsg{Window: task.NewWeekWindowOf(weeks)}
+	}
+}
+
+// LoadVisibleWeek loads only the current week so the first frame can be
+// drawn quickly. Follow it with LoadAdjacentWeeks.
+func LoadVisibleWeek(repo task.Repository, weekStart time.Time, radius int) tea.Cmd {
+	return func() tea.Msg {
+		week, err := loadWeek(context.Background(), repo, weekStart)
 		if err != nil {
 			return ErrMsg{Err: err}
 		}
+		weeks := make([]*task.Week, 2*radius+1)
+		weeks[radius] = week
+		return InitialLoadMsg{
+			Window:      task.NewWeekWindowOf(weeks),
+			VisibleOnly: true,
+		}
+	}
+}
 
-		nextTasks, err := repo.ListTasksByDateRange(ctx, nextStart, nextStart.AddDate(0, 0, 6))
-		if err != nil {
-			return ErrMsg{Err: err}
+// LoadAdjacentWeeks loads the radius weeks on each side of weekStart.
+func LoadAdjacentWeeks(repo task.Repository, weekStart time.Time, radius int) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		weeks := make([]*task.Week, 2*radius+1)
+		for offset := -radius; offset <= radius; offset++ {
+			if offset == 0 {
+				continue
+			}
+			week, err := loadWeek(ctx, repo, weekStart.AddDate(0, 0, 7*offset))
+			if err != nil {
+				return ErrMsg{Err: err}
+			}
+			weeks[radius+offset] = week
 		}
 
-		return InitialLoadMsg{
-			Window: task.NewWeekWindow(
-				task.NewWeekFromTasks(prevStart, prevTasks),
-				task.NewWeekFromTasks(currStart, currTasks),
-				task.NewWeekFromTasks(nextStart, nextTasks),
-			),
+		return AdjacentWeeksLoadedMsg{
+			WeekStart: weekStart,
+			Window:    task.NewWeekWindowOf(weeks),
 		}
 	}
 }
@@ -100,47 +175,92 @@ func LoadInitialWeeks(repo task.Repository, weekStart time.Time) tea.Cmd {
 // LoadWeek loads tasks for the current week only (used after mutations).
 func LoadWeek(repo task.Repository, weekStart time.Time) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		start := weekStart
-		end := start.AddDate(0, 0, 6)
-
-		tasks, err := repo.ListTasksByDateRange(ctx, start, end)
+		week, err := loadWeek(context.Background(), repo, weekStart)
 		if err != nil {
 			return ErrMsg{Err: err}
 		}
 
-		return WeekLoadedMsg{Week: task.NewWeekFromTasks(start, tasks)}
+		return WeekLoadedMsg{Week: week}
 	}
 }
 
-// LoadNextWeek loads the next week after shifting forward.
-func LoadNextWeek(repo task.Repository, weekStart time.Time) tea.Cmd {
+// LoadDays reloads only the given days, for writes that touched a few days
+// rather than the whole week. Consecutive dates share one query.
+func LoadDays(repo task.Repository, dates []time.Time) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		nextStart := weekStart.AddDate(0, 0, 7)
-
-		tasks, err := repo.ListTasksByDateRange(ctx, nextStart, nextStart.AddDate(0, 0, 6))
+		days, err := loadDays(context.Background(), repo, dates)
 		if err != nil {
 			return ErrMsg{Err: err}
 		}
+		return DaysLoadedMsg{Days: days}
+	}
+}
+
+// loadDays queries each run of consecutive dates once and returns one day
+// per distinct date, oldest first.
+func loadDays(ctx context.Context, repo task.Repository, dates []time.Time) ([]*task.Day, error) {
+	days := make([]*task.Day, 0, len(dates))
+	for _, date := range dates {
+		day := task.NewDay(date)
+		if !slices.ContainsFunc(days, func(d *task.Day) bool { return d.Date.Equal(day.Date) }) {
+			days = append(days, day)
+		}
+	}
+	slices.SortFunc(days, func(a, b *task.Day) int { return a.Date.Compare(b.Date) })
 
-		return WeekShiftedMsg{Week: task.NewWeekFromTasks(nextStart, tasks), Forward: true}
+	for i := 0; i < len(days); {
+		j := i
+		for j+1 < len(days) && task.CalendarDaysBetween(days[j].Date, days[j+1].Date) == 1 {
+			j++
+		}
+		tasks, err := listTasks(ctx, repo, days[i].Date, days[j].Date)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			if k := task.CalendarDaysBetween(days[i].Date, t.ScheduledDate); k >= 0 && k <= j-i {
+				days[i+k].AddOverlapping(t)
+			}
+		}
+		i = j + 1
 	}
+	return days, nil
 }
 
-// LoadPrevWeek loads the previous week after shifting backward.
-func LoadPrevWeek(repo task.Repository, weekStart time.Time) tea.Cmd {
+// PrefetchWeek loads the week starting at weekStart in the background, so
+// navigation can bring it in without waiting.
+func PrefetchWeek(repo task.Repository, weekStart time.Time) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		prevStart := weekStart.AddDate(0, 0, -7)
-
-		tasks, err := repo.ListTasksByDateRange(ctx, prevStart, prevStart.AddDate(0, 0, 6))
+		week, err := loadWeek(context.Background(), repo, weekStart)
 		if err != nil {
 			return ErrMsg{Err: err}
 		}
+		return WeekPrefetchedMsg{Week: week}
+	}
+}
+
+// queries counts the task range queries run by commands.
+var queries atomic.Int64
 
-		return WeekShiftedMsg{Week: task.NewWeekFromTasks(prevStart, tasks), Forward: false}
+// Queries returns how many task range queries commands have run.
+func Queries() int64 {
+	return queries.Load()
+}
+
+// listTasks lists the tasks from start to end, counting and timing the query.
+func listTasks(ctx context.Context, repo task.Repository, start, end time.Time) ([]*task.Task, error) {
+	queries.Add(1)
+	defer debuglog.Span("db.list_tasks", "from", start.Format(time.DateOnly), "to", end.Format(time.DateOnly))()
+	return repo.ListTasksByDateRange(ctx, start, end)
+}
+
+// loadWeek loads the seven days starting at weekStart.
+func loadWeek(ctx context.Context, repo task.Repository, weekStart time.Time) (*task.Week, error) {
+	tasks, err := listTasks(ctx, repo, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return nil, err
 	}
+	return task.NewWeekFromTasks(weekStart, tasks), nil
 }
 
 // SavePlan creates a command to save the current plan.
@@ -158,36 +278,139 @@ func SavePlan(planner *dwplanner.Planner, result *dwplanner.PlanResult) tea.Cmd
 	}
 }
 
-// Plan creates a command that runs the LLM planning.
-func Plan(input string, cfg *config.Config, repo task.Repository) tea.Cmd {
+// PlanStream is an in-flight streaming plan request.
+// Messages are delivered one at a time through Next until the stream ends
+// with a PlanResultMsg, PlanCancelledMsg, or ErrMsg.
+type PlanStream struct {
+	msgs   chan tea.Msg
+	cancel context.CancelFunc
+}
+
+// Next returns a command that waits for the next message from the stream.
+func (s *PlanStream) Next() tea.Cmd {
 	return func() tea.Msg {
-		client, err := llm.NewClient(cfg.LLM.Provider, cfg.LLM.Model, cfg.LLM.BaseURL)
-		if err != nil {
-			return ErrMsg{Err: fmt.Errorf("creating LLM client: %w", err)}
+		msg, ok := <-s.msgs
+		if !ok {
+			return nil
 		}
+		return msg
+	}
+}
 
-		planner := dwplanner.New(client, cfg, repo)
+// Cancel aborts the in-flight LLM request.
+func (s *PlanStream) Cancel() {
+	s.cancel()
+}
+
+// Plan starts LLM planning in the background and streams its output.
+// The returned command yields the first message; call Next on the stream
+// after handling each PlanChunkMsg to keep receiving.
+func Plan(input string, cfg *config.Config, repo task.Repository, clk clock.Clock) (*PlanStream, tea.Cmd) {
+	return startPlanStream(
+		func() (*dwplanner.Planner, error) {
+			planner, err := dwplanner.NewFromConfig(cfg, repo)
+			if err != nil {
+				return nil, err
+			}
+			planner.SetClock(clk)
+			return planner, nil
+		},
+		func(ctx context.Context, planner *dwplanner.Planner) (*dwplanner.PlanResult, error) {
+			return planner.PlanWithRetry(ctx, dwplanner.PlanRequest{Input: input}, 3)
+		},
+	)
+}
+
+// AmendPlan continues an existing planning session with feedback and streams
+// the revised plan, like Plan.
+func AmendPlan(planner *dwplanner.Planner, feedback string) (*PlanStream, tea.Cmd) {
+	return startPlanStream(
+		func() (*dwplanner.Planner, error) { return planner, nil },
+		func(ctx context.Context, planner *dwplanner.Planner) (*dwplanner.PlanResult, error) {
+			return planner.ContinuePlanning(ctx, feedback, 3)
+		},
+	)
+}
+
+// FixPlan asks the model to resend the entries dropped from its last plan
+// and streams the corrected plan, like Plan.
+func FixPlan(planner *dwplanner.Planner) (*PlanStream, tea.Cmd) {
+	return startPlanStream(
+		func() (*dwplanner.Planner, error) { return planner, nil },
+		func(ctx context.Context, planner *dwplanner.Planner) (*dwplanner.PlanResult, error) {
+			return planner.FixDropped(ctx, 3)
+		},
+	)
+}
+
+func startPlanStream(
+	newPlanner func() (*dwplanner.Planner, error),
+	run func(context.Context, *dwplanner.Planner) (*dwplanner.PlanResult, error),
+) (*PlanStream, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &PlanStream{
+		msgs:   make(chan tea.Msg, 64),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer close(stream.msgs)
+		defer cancel()
 
-		result, err := planner.PlanWithRetry(context.Background(), dwplanner.PlanRequest{Input: input}, 3)
+		planner, err := newPlanner()
 		if err != nil {
-			return ErrMsg{Err: fmt.Errorf("planning: %w", err)}
+			stream.msgs <- ErrMsg{Stream: stream, Err: err}
+			return
 		}
+		planner.SetStreamHandler(func(attempt int, chunk string) {
+			select {
+			case stream.msgs <- PlanChunkMsg{Stream: stream, Attempt: attempt, Chunk: chunk}:
+			case <-ctx.Done():
+			}
+		})
+
+		result, err := run(ctx, planner)
+		switch {
+		case ctx.Err() != nil:
+			stream.msgs <- PlanCancelledMsg{Stream: stream}
+		case err != nil:
+			stream.msgs <- ErrMsg{Stream: stream, Err: fmt.Errorf("planning: %w (use /auto to schedule without the LLM)", err)}
+		default:
+			stream.msgs <- PlanResultMsg{Stream: stream, Result: result, Planner: planner}
+		}
+	}()
+
+	return stream, stream.Next()
+}
 
+// AutoPlan schedules input with the rule-based scheduler instead of the LLM.
+// It works offline and is meant as a fallback when no provider is available.
+func AutoPlan(input string, cfg *config.Config, repo task.Repository, clk clock.Clock) tea.Cmd {
+	return func() tea.Msg {
+		planner := dwplanner.New(nil, cfg, repo)
+		planner.SetClock(clk)
+		result, err := planner.AutoPlan(context.Background(), input)
+		if err != nil {
+			return ErrMsg{Err: fmt.Errorf("auto planning: %w", err)}
+		}
 		return PlanResultMsg{Result: result, Planner: planner}
 	}
 }
 
 // WeekSummary builds a week summary for the current week.
-func WeekSummary(cfg *config.Config, repo task.Repository, weekStart time.Time) tea.Cmd {
+func WeekSummary(cfg *config.Config, repo task.Repository, weekStart, now time.Time) tea.Cmd {
 	return func() tea.Msg {
+		model, baseURL := cfg.LLM.Endpoint()
 		weekSummary, err := summary.BuildWeekSummary(context.Background(), repo, summary.BuildWeekSummaryOptions{
 			WeekStart:      weekStart,
 			PeakStart:      cfg.Schedule.PeakHoursStart,
 			PeakEnd:        cfg.Schedule.PeakHoursEnd,
+			Goals:          summary.GoalTargets{DeepMinutes: cfg.Goals.DeepMinutes(), TotalMinutes: cfg.Goals.TotalMinutes()},
+			Now:            now,
 			IncludeInsight: true,
 			Provider:       cfg.LLM.Provider,
-			Model:          cfg.LLM.Model,
-			BaseURL:        cfg.LLM.BaseURL,
+			Model:          model,
+			BaseURL:        baseURL,
 		})
 		if err != nil {
 			return ErrMsg{Err: err}
@@ -195,3 +418,513 @@ func WeekSummary(cfg *config.Config, repo task.Repository, weekStart time.Time)
 		return WeekSummaryMsg{Summary: weekSummary}
 	}
 }
+
+// Reflect asks the LLM to review the last week's tasks, outcomes, and postpones.
+func Reflect(cfg *config.Config, repo task.Repository, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		model, baseURL := cfg.LLM.Endpoint()
+		reflection, err := summary.BuildReflection(context.Background(), repo, summary.BuildReflectionOptions{
+			Now:      now,
+			Provider: cfg.LLM.Provider,
+			Model:    model,
+			BaseURL:  baseURL,
+		})
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return ReflectionMsg{Reflection: reflection}
+	}
+}
+
+// LLMLogMsg is sent when the recent LLM audit entries have been read.
+type LLMLogMsg struct {
+	Path    string
+	Entries []llm.AuditEntry
+}
+
+// LoadLLMLog reads the last limit entries of the LLM audit log at path.
+func LoadLLMLog(path string, limit int) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := llm.ReadAuditLog(path, limit)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return LLMLogMsg{Path: path, Entries: entries}
+	}
+}
+
+// BacklogImportedMsg is sent when issues have been imported into the
+// backlog.
+type BacklogImportedMsg struct {
+	Source  string // e.g. "acme/api" or "Jira"
+	Created int
+	Updated int
+}
+
+// ImportIssues adds the issues of src to the backlog, updating the ones
+// imported before. label names the source in the status line.
+func ImportIssues(repo task.Repository, label string, src issues.Source) tea.Cmd {
+	return func() tea.Msg {
+		backlog, err := task.BacklogOf(repo)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		ctx := context.Background()
+		items, err := src.Items(ctx)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		created, updated, err := issues.Import(ctx, backlog, items)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return BacklogImportedMsg{Source: label, Created: created, Updated: updated}
+	}
+}
+
+// BacklogMsg is sent when the backlog has been read.
+type BacklogMsg struct {
+	Items []*task.BacklogItem
+}
+
+// LoadBacklog reads the backlog.
+func LoadBacklog(repo task.Repository) tea.Cmd {
+	return func() tea.Msg {
+		backlog, err := task.BacklogOf(repo)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		items, err := backlog.ListBacklog(context.Background())
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return BacklogMsg{Items: items}
+	}
+}
+
+// ParkedMsg is sent when the backlog items parked on days have been read.
+type ParkedMsg struct {
+	Items map[string][]*task.BacklogItem // By day as "2006-01-02"
+}
+
+// LoadParked reads the backlog items parked on days. Repositories without a
+// backlog have none.
+func LoadParked(repo task.Repository) tea.Cmd {
+	return func() tea.Msg {
+		backlog, err := task.BacklogOf(repo)
+		if err != nil {
+			return ParkedMsg{}
+		}
+		items, err := backlog.ListBacklog(context.Background())
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return ParkedMsg{Items: task.ParkedItems(items)}
+	}
+}
+
+// ReviewMsg is sent when the tasks of a review period have been read.
+type ReviewMsg struct {
+	Label string // e.g. "yesterday", shown in the modal title
+	Tasks []*task.Task
+	Notes map[int64]string // Review notes by task ID; nil when not supported
+}
+
+// LoadReview reads the tasks from start to end, ordered by time, with their
+// review notes.
+func LoadReview(repo task.Repository, label string, start, end time.Time) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		tasks, err := listTasks(ctx, repo, start, end)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		task.SortByTime(tasks)
+		msg := ReviewMsg{Label: label, Tasks: tasks}
+		if r, ok := repo.(task.Reviewer); ok {
+			ids := make([]int64, len(tasks))
+			for i, t := range tasks {
+				ids[i] = t.ID
+			}
+			if msg.Notes, err = r.TaskNotes(ctx, ids); err != nil {
+				return ErrMsg{Err: err}
+			}
+		}
+		return msg
+	}
+}
+
+// ReviewSavedMsg is sent after a review has been saved.
+type ReviewSavedMsg struct {
+	Tasks   []*task.Task // The reviewed tasks, as loaded
+	Entries []task.ReviewEntry
+	Skipped int // Tasks left without an outcome
+}
+
+// SaveReview writes the outcomes and notes of a review in one batch.
+func SaveReview(repo task.Repository, tasks []*task.Task, entries []task.ReviewEntry, skipped int) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := task.SaveReview(ctx, repo, entries); err != nil {
+			return ErrMsg{Err: err}
+		}
+		if err := task.RecordReviewActuals(ctx, repo, tasks, entries); err != nil {
+			return ErrMsg{Err: err}
+		}
+		return ReviewSavedMsg{Tasks: tasks, Entries: entries, Skipped: skipped}
+	}
+}
+
+// YearOverviewMsg is sent when the weekly totals of an ISO year are ready.
+type YearOverviewMsg struct {
+	Year      int
+	FirstWeek time.Time // First day of ISO week 1
+	NumWeeks  int
+	Weeks     []task.WeeklyAggregate // Weeks without tasks are omitted
+}
+
+// LoadYearOverview sums the tasks of every ISO week of year.
+func LoadYearOverview(repo task.Repository, year int) tea.Cmd {
+	return func() tea.Msg {
+		_, numWeeks := time.Date(year, time.December, 28, 0, 0, 0, 0, time.Local).ISOWeek()
+		first := dateutil.ISOWeekStart(year, 1)
+		_, lastEnd := dateutil.WeekRange(dateutil.ISOWeekStart(year, numWeeks))
+
+		weeks, err := task.WeeklyAggregates(context.Background(), repo, first, lastEnd)
+		if err != nil {
+			return ErrMsg{Err: fmt.Errorf("loading year overview: %w", err)}
+		}
+		return YearOverviewMsg{
+			Year:      year,
+			FirstWeek: first,
+			NumWeeks:  numWeeks,
+			Weeks:     weeks,
+		}
+	}
+}
+
+// ObjectivesMsg is sent when the objectives of a quarter and their hours
+// are ready.
+type ObjectivesMsg struct {
+	Quarter  string
+	Progress []task.ObjectiveProgress
+}
+
+// LoadObjectives sums the hours attached to each objective of quarter.
+func LoadObjectives(repo task.Repository, quarter string, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		progress, err := task.QuarterProgress(context.Background(), repo, quarter, now)
+		if err != nil {
+			return ErrMsg{Err: fmt.Errorf("loading objectives: %w", err)}
+		}
+		return ObjectivesMsg{Quarter: quarter, Progress: progress}
+	}
+}
+
+// MissedTasksMsg is sent after past tasks without an outcome were marked missed.
+type MissedTasksMsg struct {
+	Tasks []*task.Task // Ordered by date and start time
+}
+
+// MarkMissed marks the tasks scheduled from since up to now that ended
+// without an outcome as missed.
+func MarkMissed(repo task.Repository, since, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := task.MarkMissed(context.Background(), repo, since, now)
+		if err != nil {
+			return ErrMsg{Err: fmt.Errorf("marking missed tasks: %w", err)}
+		}
+		return MissedTasksMsg{Tasks: tasks}
+	}
+}
+
+// SandboxStartedMsg is sent when a sandbox copy of the schedule is ready.
+type SandboxStartedMsg struct {
+	Sandbox *sandbox.Repo
+}
+
+// SandboxAppliedMsg is sent when sandbox changes were written to the real repository.
+type SandboxAppliedMsg struct {
+	Summary string
+}
+
+// EnterSandbox clones the loaded three-week window into an in-memory sandbox.
+func EnterSandbox(repo task.Repository, weekStart time.Time) tea.Cmd {
+	return func() tea.Msg {
+		start := weekStart.AddDate(0, 0, -7)
+		end := weekStart.AddDate(0, 0, 13)
+		sb, err := sandbox.New(context.Background(), repo, start, end)
+		if err != nil {
+			return ErrMsg{Err: fmt.Errorf("starting sandbox: %w", err)}
+		}
+		return SandboxStartedMsg{Sandbox: sb}
+	}
+}
+
+// ApplySandbox writes the sandbox diff to target.
+func ApplySandbox(sb *sandbox.Repo, target task.Repository) tea.Cmd {
+	return func() tea.Msg {
+		changes, err := sb.Apply(context.Background(), target)
+		if err != nil {
+			return ErrMsg{Err: fmt.Errorf("applying sandbox: %w", err)}
+		}
+		return SandboxAppliedMsg{Summary: changes.Summary()}
+	}
+}
+
+// SyncConflictsMsg is sent when a sync integration finds tasks that changed
+// both locally and remotely since the last sync.
+type SyncConflictsMsg struct {
+	Conflicts []*tasksync.Conflict
+}
+
+// ConflictResolvedMsg is sent when a merged task from the sync conflict
+// modal has been saved.
+type ConflictResolvedMsg struct {
+	Task *task.Task
+}
+
+// ResolveConflict saves the merged copy of a conflicting task.
+func ResolveConflict(repo task.Repository, merged task.Task) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := repo.UpsertTask(context.Background(), &merged); err != nil {
+			return ErrMsg{Err: fmt.Errorf("saving merged task: %w", err)}
+		}
+		return ConflictResolvedMsg{Task: &merged}
+	}
+}
+
+// BatchUpdatedMsg is sent after a batch action from visual mode is saved.
+type BatchUpdatedMsg struct {
+	Updates []task.TaskUpdate
+	Summary string
+}
+
+// TaskLinksMsg carries the prerequisites of a task and the tasks it blocks.
+type TaskLinksMsg struct {
+	TaskID int64
+	Links  task.Links
+}
+
+// LoadTaskLinks loads the dependencies of task id for the detail modal.
+func LoadTaskLinks(repo task.Repository, id int64) tea.Cmd {
+	return func() tea.Msg {
+		links, err := task.TaskLinks(context.Background(), repo, id)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return TaskLinksMsg{TaskID: id, Links: links}
+	}
+}
+
+// TaskChangedMsg is sent when a write was refused because another process
+// changed one of its tasks since they were read.
+type TaskChangedMsg struct{}
+
+// BatchUpdate applies a batch of task updates atomically.
+func BatchUpdate(repo task.Repository, updates []task.TaskUpdate, summary string) tea.Cmd {
+	return func() tea.Msg {
+		err := repo.BatchUpdate(context.Background(), updates)
+		if errors.Is(err, task.ErrTaskChanged) {
+			return TaskChangedMsg{}
+		}
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return BatchUpdatedMsg{Updates: updates, Summary: summary}
+	}
+}
+
+// SyncStartedMsg is sent when the background sync scheduler is running.
+type SyncStartedMsg struct {
+	Scheduler *tasksync.Scheduler
+	Events    <-chan tasksync.Event
+}
+
+// SyncEventMsg is sent after each background sync run.
+type SyncEventMsg struct {
+	Event  tasksync.Event
+	Events <-chan tasksync.Event
+}
+
+// StartSync starts syncing the configured sources in the background. It
+// returns nil when no source is configured.
+func StartSync(cfg *config.Config, repo task.Repository) tea.Cmd {
+	if cfg == nil || !cfg.Sync.HasSources() {
+		return nil
+	}
+	return func() tea.Msg {
+		s := tasksync.NewScheduler(repo, tasksync.Sources(cfg.Sync), cfg.Sync.Interval(), tasksync.StatePath(cfg.Storage.DBPath))
+		return SyncStartedMsg{Scheduler: s, Events: s.Start(context.Background())}
+	}
+}
+
+// WaitSyncEvent waits for the next background sync run.
+func WaitSyncEvent(events <-chan tasksync.Event) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return nil
+		}
+		return SyncEventMsg{Event: ev, Events: events}
+	}
+}
+
+// DataVersionMsg carries the repository's data version after a poll.
+type DataVersionMsg struct {
+	Version string
+	Err     error
+}
+
+// PollDataVersion reads the repository's data version after wait, so the
+// TUI notices writes by another process. It returns nil when the
+// repository cannot report one.
+func PollDataVersion(repo task.Repository, wait time.Duration) tea.Cmd {
+	v, ok := repo.(task.DataVersioner)
+	if !ok {
+		return nil
+	}
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		version, err := v.DataVersion(context.Background())
+		return DataVersionMsg{Version: version, Err: err}
+	})
+}
+
+// ReplicaStartedMsg is sent when background replication is running.
+type ReplicaStartedMsg struct {
+	Replicator *replica.Replicator
+	Events     <-chan replica.Status
+}
+
+// ReplicaEventMsg is sent after each background replication run.
+type ReplicaEventMsg struct {
+	Status replica.Status
+	Events <-chan replica.Status
+}
+
+// ReplicaSyncedMsg is sent after a replication run started by the user.
+type ReplicaSyncedMsg struct {
+	Result replica.Result
+	Err    error
+}
+
+// StartReplica starts replicating the database to the configured remote in
+// the background. It returns nil when no replica is configured or repo is
+// not a SQLite database.
+func StartReplica(cfg *config.Config, repo task.Repository) tea.Cmd {
+	sqlite, ok := repo.(*db.SQLite)
+	if cfg == nil || !cfg.Sync.Replica.Enabled() || !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		remote, err := replica.NewRemote(cfg.Sync.Replica)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		r := replica.New(sqlite, remote, replica.StatePath(cfg.Storage.DBPath))
+		return ReplicaStartedMsg{Replicator: r, Events: r.Start(context.Background(), cfg.Sync.Interval())}
+	}
+}
+
+// WaitReplicaEvent waits for the next background replication run.
+func WaitReplicaEvent(events <-chan replica.Status) tea.Cmd {
+	return func() tea.Msg {
+		st, ok := <-events
+		if !ok {
+			return nil
+		}
+		return ReplicaEventMsg{Status: st, Events: events}
+	}
+}
+
+// SyncReplica runs replication now, resolving conflicts as asked.
+func SyncReplica(r *replica.Replicator, resolve db.Resolution) tea.Cmd {
+	return func() tea.Msg {
+		res, err := r.Sync(context.Background(), resolve)
+		return ReplicaSyncedMsg{Result: res, Err: err}
+	}
+}
+
+// ActionsPushedMsg is sent after queued sync actions were pushed.
+type ActionsPushedMsg struct {
+	Sent int
+	Err  error
+}
+
+// PushActions pushes the actions queued for synced sources. Actions that
+// cannot be sent stay queued for the next sync run.
+func PushActions(s *tasksync.Scheduler) tea.Cmd {
+	return func() tea.Msg {
+		sent, err := s.Flush(context.Background())
+		return ActionsPushedMsg{Sent: sent, Err: err}
+	}
+}
+
+// AutosaveTickMsg is sent every autosave interval.
+type AutosaveTickMsg struct{}
+
+// AutosaveTick waits interval and sends AutosaveTickMsg.
+func AutosaveTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return AutosaveTickMsg{}
+	})
+}
+
+// KioskTickMsg is sent when kiosk mode moves on to its next view.
+type KioskTickMsg struct{}
+
+// KioskTick waits interval and sends KioskTickMsg.
+func KioskTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return KioskTickMsg{}
+	})
+}
+
+// WriteAutosave stores the unsaved changes of an edit session at path.
+func WriteAutosave(path string, s *autosave.Session) tea.Cmd {
+	return func() tea.Msg {
+		if err := autosave.Write(path, s); err != nil {
+			return ErrMsg{Err: fmt.Errorf("autosaving edits: %w", err)}
+		}
+		return nil
+	}
+}
+
+// RemoveAutosave deletes the edit session stored at path.
+func RemoveAutosave(path string) tea.Cmd {
+	return func() tea.Msg {
+		if err := autosave.Remove(path); err != nil {
+			return ErrMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// AutosaveFoundMsg is sent at startup when an earlier edit session left
+// unsaved changes.
+type AutosaveFoundMsg struct {
+	Session *autosave.Session
+}
+
+// LoadAutosave reads the edit session stored at path. Nothing is sent when
+// there is none.
+func LoadAutosave(path string) tea.Cmd {
+	return func() tea.Msg {
+		s, err := autosave.Read(path)
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		if s == nil || len(s.Changes) == 0 {
+			return nil
+		}
+		return AutosaveFoundMsg{Session: s}
+	}
+}
+
+// NowTick waits until the minute after now and sends NowTickMsg.
+func NowTick(now time.Time) tea.Cmd {
+	wait := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return NowTickMsg{}
+	})
+}
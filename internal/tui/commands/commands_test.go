@@ -2,82 +2,28 @@ package commands
 
 import (
 	"context"
-	"errors"
 	"testing"
 	"time"
 
+	"github.com/javiermolinar/sancho/internal/memrepo"
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
-type fakeRepo struct {
-	tasksByRange func(start, end time.Time) ([]*task.Task, error)
-}
-
-func (f fakeRepo) CreateTask(ctx context.Context, task *task.Task) error {
-	return errors.New("not implemented")
-}
-
-func (f fakeRepo) GetTask(ctx context.Context, id int64) (*task.Task, error) {
-	return nil, errors.New("not implemented")
-}
-
-func (f fakeRepo) CancelTask(ctx context.Context, id int64) error {
-	return errors.New("not implemented")
-}
-
-func (f fakeRepo) SetTaskOutcome(ctx context.Context, id int64, outcome task.Outcome) error {
-	return errors.New("not implemented")
-}
-
-func (f fakeRepo) ListTasksByDateRange(ctx context.Context, start, end time.Time) ([]*task.Task, error) {
-	if f.tasksByRange == nil {
-		return nil, errors.New("not implemented")
-	}
-	return f.tasksByRange(start, end)
-}
-
-func (f fakeRepo) CreateTasks(ctx context.Context, tasks []*task.Task) error {
-	return errors.New("not implemented")
-}
-
-func (f fakeRepo) PostponeTask(ctx context.Context, taskID int64, newDate time.Time, newStart, newEnd string) (*task.Task, error) {
-	return nil, errors.New("not implemented")
-}
-
-func (f fakeRepo) UpdateTask(ctx context.Context, id int64, newStart, newEnd string) error {
-	return errors.New("not implemented")
-}
-
-func (f fakeRepo) UpdateTaskDescription(ctx context.Context, id int64, description string) error {
-	return errors.New("not implemented")
-}
-
-func (f fakeRepo) BatchUpdateTaskTimes(ctx context.Context, date time.Time, updates []task.TaskTimeUpdate) error {
-	return errors.New("not implemented")
-}
-
-func (f fakeRepo) Close() error {
-	return nil
-}
-
 func TestLoadWeekReturnsWeekLoadedMsg(t *testing.T) {
 	weekStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
 	taskDate := weekStart
 
-	repo := fakeRepo{
-		tasksByRange: func(start, end time.Time) ([]*task.Task, error) {
-			return []*task.Task{
-				{
-					ID:             1,
-					Description:    "Test",
-					Category:       task.CategoryDeep,
-					ScheduledDate:  taskDate,
-					ScheduledStart: "09:00",
-					ScheduledEnd:   "10:00",
-					Status:         task.StatusScheduled,
-				},
-			}, nil
-		},
+	repo := memrepo.New()
+	err := repo.CreateTask(context.Background(), &task.Task{
+		Description:    "Test",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  taskDate,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
 	}
 
 	cmd := LoadWeek(repo, weekStart)
@@ -105,3 +51,52 @@ func TestLoadWeekReturnsWeekLoadedMsg(t *testing.T) {
 		t.Fatalf("task description = %q, want %q", tasks[0].Description, "Test")
 	}
 }
+
+// countingRepo counts the range queries made against the repository.
+type countingRepo struct {
+	*memrepo.Repo
+	queries int
+}
+
+func (r *countingRepo) ListTasksByDateRange(ctx context.Context, start, end time.Time) ([]*task.Task, error) {
+	r.queries++
+	return r.Repo.ListTasksByDateRange(ctx, start, end)
+}
+
+func TestLoadDaysQueriesRunsOfDates(t *testing.T) {
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	repo := &countingRepo{Repo: memrepo.New()}
+	for _, day := range []int{0, 1, 4} {
+		err := repo.CreateTask(context.Background(), &task.Task{
+			Description:    "Block",
+			Category:       task.CategoryDeep,
+			ScheduledDate:  monday.AddDate(0, 0, day),
+			ScheduledStart: "09:00",
+			ScheduledEnd:   "10:00",
+			Status:         task.StatusScheduled,
+		})
+		if err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+
+	// Tuesday twice, Monday-Tuesday as one run, Friday on its own
+	dates := []time.Time{monday.AddDate(0, 0, 4), monday.AddDate(0, 0, 1), monday, monday.AddDate(0, 0, 1).Add(3 * time.Hour)}
+	msg := LoadDays(repo, dates)()
+	loaded, ok := msg.(DaysLoadedMsg)
+	if !ok {
+		t.Fatalf("msg type = %T, want DaysLoadedMsg", msg)
+	}
+	if repo.queries != 2 {
+		t.Errorf("queries = %d, want 2", repo.queries)
+	}
+	if len(loaded.Days) != 3 {
+		t.Fatalf("days = %d, want 3", len(loaded.Days))
+	}
+	for i, want := range []int{0, 1, 4} {
+		day := loaded.Days[i]
+		if !day.Date.Equal(monday.AddDate(0, 0, want)) || day.Len() != 1 {
+			t.Errorf("day %d = %s with %d tasks, want %s with 1", i, day.Date.Format("Mon"), day.Len(), monday.AddDate(0, 0, want).Format("Mon"))
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxCount caps a count prefix at a day of 15-minute slots.
+const maxCount = SlotsPerDay
+
+// readCount handles a vim-style count typed before an edit or move key:
+// digits accumulate in pendingCount, except a leading 0. It returns the
+// count for any other key, 1 when none was typed, or consumed when msg was
+// a digit of the count.
+func (m *Model) readCount(msg tea.KeyMsg) (count int, consumed bool) {
+	s := msg.String()
+	if len(s) == 1 && s[0] >= '0' && s[0] <= '9' && (s != "0" || m.pendingCount > 0) {
+		m.pendingCount = min(m.pendingCount*10+int(s[0]-'0'), maxCount)
+		m.statusMsg = strconv.Itoa(m.pendingCount)
+		return 0, true
+	}
+	count = max(m.pendingCount, 1)
+	m.pendingCount = 0
+	return count, false
+}
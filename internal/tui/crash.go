@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// crashKeys is how many of the last keystrokes a crash report lists.
+const crashKeys = 20
+
+// CrashError is returned by Run when the TUI panicked. The program was
+// quit normally, so the terminal is restored.
+type CrashError struct {
+	Value      any    // What the panic was called with
+	ReportPath string // Crash report; empty when it could not be written
+}
+
+func (e *CrashError) Error() string {
+	msg := fmt.Sprintf("sancho hit a bug and closed: %v", e.Value)
+	if e.ReportPath != "" {
+		msg += fmt.Sprintf("\nThe screen state and last keys pressed were saved to %s; please attach it when reporting the bug.", e.ReportPath)
+	}
+	return msg
+}
+
+// crashGuard wraps the model so that a panic in Update or View writes a
+// crash report and quits, instead of leaving only a stack trace behind.
+type crashGuard struct {
+	model tea.Model
+	state *crashState // Shared by every copy of the guard
+}
+
+type crashState struct {
+	program *tea.Program // Set once running; quits after a panic in View
+	keys    []string     // Last keystrokes, oldest first
+	err     *CrashError  // Set by the first panic
+}
+
+func newCrashGuard(model tea.Model) crashGuard {
+	return crashGuard{model: model, state: &crashState{}}
+}
+
+func (g crashGuard) Init() tea.Cmd {
+	return g.model.Init()
+}
+
+func (g crashGuard) Update(msg tea.Msg) (next tea.Model, cmd tea.Cmd) {
+	if g.state.err != nil {
+		return g, nil
+	}
+	if key, ok := msg.(tea.KeyMsg); ok {
+		g.state.keys = append(g.state.keys, key.String())
+		if len(g.state.keys) > crashKeys {
+			g.state.keys = g.state.keys[1:]
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.state.recover(r, g.model)
+			next, cmd = g, tea.Quit
+		}
+	}()
+	g.model, cmd = g.model.Update(msg)
+	return g, cmd
+}
+
+func (g crashGuard) View() (view string) {
+	if g.state.err != nil {
+		return ""
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.state.recover(r, g.model)
+			if p := g.state.program; p != nil {
+				go p.Quit() // View runs on the event loop, which Quit waits for
+			}
+			view = ""
+		}
+	}()
+	return g.model.View()
+}
+
+// recover records the panic r and writes the crash report next to the
+// system's temporary files.
+func (s *crashState) recover(r any, model tea.Model) {
+	s.err = &CrashError{Value: r}
+	now := time.Now()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "sancho crashed at %s\n\npanic: %v\n\n%s\n", now.Format(time.RFC3339), r, debug.Stack())
+	fmt.Fprintf(&b, "last keys: %s\n", strings.Join(s.keys, " "))
+	if m, ok := model.(Model); ok {
+		func() {
+			defer func() { _ = recover() }() // The state may be what broke
+			b.WriteString(m.crashDump())
+		}()
+	}
+
+	path := filepath.Join(os.TempDir(), "sancho-crash-"+now.Format("20060102-150405")+".log")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err == nil {
+		s.err.ReportPath = path
+	}
+	logEvent(slog.LevelError, "PANIC", map[string]any{"value": fmt.Sprint(r), "report": s.err.ReportPath})
+}
+
+// crashDump describes the model for a crash report. Task descriptions are
+// left out, so the report can be shared.
+func (m Model) crashDump() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "mode: %s, modal: %d\n", modeString(m.mode), m.modalType)
+	fmt.Fprintf(&b, "week: %s, cursor: day %d slot %d, scroll: %d\n",
+		m.weekStart.Format("2006-01-02"), m.cursor.Day, m.cursor.Slot, m.scrollOffset)
+	fmt.Fprintf(&b, "size: %dx%d, column width: %d, row minutes: %d\n", m.width, m.height, m.colWidth, m.rowHeight)
+	if sm := m.slotState; sm != nil {
+		fmt.Fprintf(&b, "editing: %v, moving: %v, undo: %d\n", sm.IsEditing(), sm.IsMoving(), sm.UndoCount())
+		for _, c := range sm.PendingChanges() {
+			fmt.Fprintf(&b, "  task %d: %s %s-%s -> %s %s-%s\n", c.After.ID,
+				c.Before.ScheduledDate.Format("2006-01-02"), c.Before.ScheduledStart, c.Before.ScheduledEnd,
+				c.After.ScheduledDate.Format("2006-01-02"), c.After.ScheduledStart, c.After.ScheduledEnd)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/config"
+)
+
+// panicModel panics on "!" in Update and once broken is set in View.
+type panicModel struct{ broken bool }
+
+func (p panicModel) Init() tea.Cmd { return nil }
+
+func (p panicModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "!" {
+		panic("boom")
+	}
+	return p, nil
+}
+
+func (p panicModel) View() string {
+	if p.broken {
+		panic("bad view")
+	}
+	return "ok"
+}
+
+func TestCrashGuard_UpdatePanic(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	g := newCrashGuard(panicModel{})
+
+	for _, key := range []string{"j", "k", "!"} {
+		next, cmd := g.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		g = next.(crashGuard)
+		if key == "!" {
+			if cmd == nil {
+				t.Fatal("expected a quit command after the panic")
+			}
+			if _, ok := cmd().(tea.QuitMsg); !ok {
+				t.Error("command after the panic should quit")
+			}
+		}
+	}
+
+	var crash *CrashError
+	if !errors.As(g.state.err, &crash) || crash.Value != "boom" {
+		t.Fatalf("crash = %+v, want the panic value", g.state.err)
+	}
+	data, err := os.ReadFile(crash.ReportPath)
+	if err != nil {
+		t.Fatalf("reading report: %v", err)
+	}
+	report := string(data)
+	for _, want := range []string{"panic: boom", "last keys: j k !", "crash_test.go"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if !strings.Contains(crash.Error(), crash.ReportPath) {
+		t.Errorf("Error() = %q, want it to name the report", crash.Error())
+	}
+
+	// Messages after the crash are ignored.
+	if _, cmd := g.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")}); cmd != nil {
+		t.Error("expected no command once crashed")
+	}
+}
+
+func TestCrashGuard_ViewPanic(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	g := newCrashGuard(panicModel{broken: true})
+
+	if got := g.View(); got != "" {
+		t.Errorf("View() = %q, want empty after a panic", got)
+	}
+	if g.state.err == nil || g.state.err.Value != "bad view" {
+		t.Errorf("crash = %+v, want the panic value", g.state.err)
+	}
+}
+
+func TestModel_CrashDump(t *testing.T) {
+	m := *New(nil, config.Default())
+	m.slotState.SetGrid(gridFromString("AAAA", m.slotState.Config()))
+	m.slotState.EnterEditMode()
+	dump := m.crashDump()
+	if !strings.Contains(dump, "mode: Normal") || !strings.Contains(dump, "editing: true") {
+		t.Errorf("dump = %q", dump)
+	}
+}
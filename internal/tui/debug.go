@@ -1,97 +1,69 @@
 package tui
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
-	"sync"
-	"time"
+	"log/slog"
+	"sort"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/javiermolinar/sancho/internal/debuglog"
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
-// DebugLogger logs TUI state, keystrokes, and events to a file.
-type DebugLogger struct {
-	mu      sync.Mutex
-	file    *os.File
-	enabled bool
-	seq     int
-}
-
-// Global debug logger instance
-var debugLog *DebugLogger
-
-// DebugLogPath is the fixed path for debug logs
-const DebugLogPath = "sancho-debug.log"
-
-// InitDebugLogger initializes the debug logger if debug mode is enabled.
-func InitDebugLogger(enabled bool) error {
-	if !enabled {
-		debugLog = &DebugLogger{enabled: false}
+// InitDebugLogger starts the debug log at level ("debug", "info", "warn"
+// or "error"). An empty level leaves it off.
+func InitDebugLogger(level string) error {
+	if level == "" {
 		return nil
 	}
-
-	// Create log file in current directory with fixed name (easy to find)
-	logPath := DebugLogPath
-	f, err := os.Create(logPath)
+	lvl, err := debuglog.ParseLevel(level)
 	if err != nil {
-		return fmt.Errorf("creating debug log: %w", err)
+		return err
 	}
-
-	debugLog = &DebugLogger{
-		file:    f,
-		enabled: true,
+	path := debuglog.Path()
+	if err := debuglog.Open(path, lvl); err != nil {
+		return err
 	}
-
-	debugLog.log("DEBUG_START", map[string]any{
-		"log_file": logPath,
-		"time":     time.Now().Format(time.RFC3339),
+	logEvent(slog.LevelInfo, "DEBUG_START", map[string]any{
+		"log_file": path,
+		"level":    lvl.String(),
 	})
-
 	return nil
 }
 
 // CloseDebugLogger closes the debug log file.
 func CloseDebugLogger() {
-	if debugLog != nil && debugLog.file != nil {
-		debugLog.log("DEBUG_END", map[string]any{
-			"time": time.Now().Format(time.RFC3339),
-		})
-		_ = debugLog.file.Close()
-	}
+	logEvent(slog.LevelInfo, "DEBUG_END", nil)
+	_ = debuglog.Close()
 }
 
-// log writes a structured log entry.
-func (d *DebugLogger) log(event string, data map[string]any) {
-	if d == nil || !d.enabled || d.file == nil {
+// debugEnabled reports whether records at level are logged.
+func debugEnabled(level slog.Level) bool {
+	return debuglog.Enabled(level)
+}
+
+// logEvent writes a structured log entry, with data's keys in order.
+func logEvent(level slog.Level, event string, data map[string]any) {
+	if !debugEnabled(level) {
 		return
 	}
-
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	d.seq++
-	entry := map[string]any{
-		"seq":   d.seq,
-		"ts":    time.Now().Format("15:04:05.000"),
-		"event": event,
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
 	}
-	for k, v := range data {
-		entry[k] = v
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, data[k]))
 	}
-
-	b, _ := json.Marshal(entry)
-	_, _ = fmt.Fprintf(d.file, "%s\n", b)
+	debuglog.Logger().LogAttrs(context.Background(), level, event, attrs...)
 }
 
 // LogKeyPress logs a key press event.
 func LogKeyPress(msg tea.KeyMsg) {
-	if debugLog == nil || !debugLog.enabled {
-		return
-	}
-	debugLog.log("KEY_PRESS", map[string]any{
+	logEvent(slog.LevelDebug, "KEY_PRESS", map[string]any{
 		"key":  msg.String(),
 		"type": fmt.Sprintf("%T", msg.Type),
 	})
@@ -99,10 +71,7 @@ func LogKeyPress(msg tea.KeyMsg) {
 
 // LogModeChange logs a mode change.
 func LogModeChange(from, to Mode, reason string) {
-	if debugLog == nil || !debugLog.enabled {
-		return
-	}
-	debugLog.log("MODE_CHANGE", map[string]any{
+	logEvent(slog.LevelInfo, "MODE_CHANGE", map[string]any{
 		"from":   modeString(from),
 		"to":     modeString(to),
 		"reason": reason,
@@ -111,10 +80,7 @@ func LogModeChange(from, to Mode, reason string) {
 
 // LogCursorMove logs cursor movement.
 func LogCursorMove(day, slot int, reason string) {
-	if debugLog == nil || !debugLog.enabled {
-		return
-	}
-	debugLog.log("CURSOR_MOVE", map[string]any{
+	logEvent(slog.LevelDebug, "CURSOR_MOVE", map[string]any{
 		"day":    day,
 		"slot":   slot,
 		"reason": reason,
@@ -123,7 +89,7 @@ func LogCursorMove(day, slot int, reason string) {
 
 // LogSlotState logs the current slot state.
 func LogSlotState(sm *SlotStateManager, action string) {
-	if debugLog == nil || !debugLog.enabled {
+	if !debugEnabled(slog.LevelDebug) {
 		return
 	}
 
@@ -178,16 +144,16 @@ func LogSlotState(sm *SlotStateManager, action string) {
 		data["grid_tasks"] = taskPositions
 	}
 
-	debugLog.log("SLOT_STATE", data)
+	logEvent(slog.LevelDebug, "SLOT_STATE", data)
 }
 
 // LogWeekWindow logs the current WeekWindow state.
 func LogWeekWindow(ww *task.WeekWindow, action string) {
-	if debugLog == nil || !debugLog.enabled {
+	if !debugEnabled(slog.LevelDebug) {
 		return
 	}
 	if ww == nil {
-		debugLog.log("WEEK_WINDOW", map[string]any{
+		logEvent(slog.LevelDebug, "WEEK_WINDOW", map[string]any{
 			"action": action,
 			"status": "nil",
 		})
@@ -218,19 +184,16 @@ func LogWeekWindow(ww *task.WeekWindow, action string) {
 		data["current_week_tasks"] = tasks
 	}
 
-	debugLog.log("WEEK_WINDOW", data)
+	logEvent(slog.LevelDebug, "WEEK_WINDOW", data)
 }
 
 // LogRenderCell logs cell rendering info (only for cursor or moving task cells).
 func LogRenderCell(day, slot int, timeLabel string, taskDesc string, isCursor bool, isMovingTask bool) {
-	if debugLog == nil || !debugLog.enabled {
-		return
-	}
 	// Only log cells that are relevant (cursor or moving task)
 	if !isCursor && !isMovingTask {
 		return
 	}
-	debugLog.log("RENDER_CELL", map[string]any{
+	logEvent(slog.LevelDebug, "RENDER_CELL", map[string]any{
 		"day":            day,
 		"slot":           slot,
 		"time_label":     timeLabel,
@@ -242,10 +205,7 @@ func LogRenderCell(day, slot int, timeLabel string, taskDesc string, isCursor bo
 
 // LogTaskLookup logs task lookup results.
 func LogTaskLookup(day int, timeLabel string, found bool, taskID int64, taskDesc string) {
-	if debugLog == nil || !debugLog.enabled {
-		return
-	}
-	debugLog.log("TASK_LOOKUP", map[string]any{
+	logEvent(slog.LevelDebug, "TASK_LOOKUP", map[string]any{
 		"day":        day,
 		"time_label": timeLabel,
 		"found":      found,
@@ -256,10 +216,7 @@ func LogTaskLookup(day int, timeLabel string, found bool, taskID int64, taskDesc
 
 // LogError logs an error.
 func LogError(context string, err error) {
-	if debugLog == nil || !debugLog.enabled {
-		return
-	}
-	debugLog.log("ERROR", map[string]any{
+	logEvent(slog.LevelError, "ERROR", map[string]any{
 		"context": context,
 		"error":   err.Error(),
 	})
@@ -278,6 +235,8 @@ func modeString(m Mode) string {
 		return "Prompt"
 	case ModeModal:
 		return "Modal"
+	case ModeVisual:
+		return "Visual"
 	default:
 		return fmt.Sprintf("Unknown(%d)", m)
 	}
@@ -293,12 +252,12 @@ func truncateStr(s string, max int) string {
 
 // LogChromeBreakdown logs detailed breakdown of chrome line calculation.
 func LogChromeBreakdown(breakdown map[string]int) {
-	if debugLog == nil || !debugLog.enabled {
+	if !debugEnabled(slog.LevelDebug) {
 		return
 	}
 	data := make(map[string]any, len(breakdown))
 	for k, v := range breakdown {
 		data[k] = v
 	}
-	debugLog.log("CHROME_BREAKDOWN", data)
+	logEvent(slog.LevelDebug, "CHROME_BREAKDOWN", data)
 }
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// dependencyWarning returns a note for the status line when one of the
+// given tasks, at its new time, now starts before a prerequisite ends or
+// ends after a task it blocks starts, e.g.
+// " (out of order: Review starts before Draft ends)". Moves are never
+// blocked; the order is a nudge.
+func (m *Model) dependencyWarning(ctx context.Context, tasks ...*task.Task) string {
+	violations, err := task.OrderViolations(ctx, m.repo, tasks)
+	if err != nil || len(violations) == 0 {
+		return ""
+	}
+	note := " (out of order: " + violations[0].String()
+	if len(violations) > 1 {
+		note += fmt.Sprintf(" and %d more", len(violations)-1)
+	}
+	return note + ")"
+}
+
+// openTaskDetail shows the detail modal of t and loads its dependencies.
+func (m *Model) openTaskDetail(t *task.Task) tea.Cmd {
+	m.mode = ModeModal
+	m.modalType = ModalTaskDetail
+	m.modalTask = t
+	if m.repo == nil {
+		return nil
+	}
+	return commands.LoadTaskLinks(m.repo, t.ID)
+}
+
+// handleTaskLinks keeps the dependencies loaded for the detail modal. They
+// are dropped if another task was opened meanwhile.
+func (m Model) handleTaskLinks(msg commands.TaskLinksMsg) (tea.Model, tea.Cmd) {
+	if m.modalTask != nil && m.modalTask.ID == msg.TaskID {
+		m.modalLinks = msg
+	}
+	return m, nil
+}
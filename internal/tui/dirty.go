@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// reloadDays re-queries only the days a write changed instead of the whole
+// week. Days whose week is not in the window are skipped, and dropped from
+// the prefetched weeks: they are read when navigation brings them in.
+func (m *Model) reloadDays(dates ...time.Time) tea.Cmd {
+	ww := m.slotState.WeekWindow()
+	if ww == nil {
+		return commands.LoadWeek(m.repo, m.weekStart)
+	}
+	var dirty []time.Time
+	for _, date := range dates {
+		if windowHasDay(ww, date) {
+			dirty = append(dirty, date)
+		} else {
+			m.forgetPrefetched(date)
+		}
+	}
+	if len(dirty) == 0 {
+		return nil
+	}
+	return commands.LoadDays(m.repo, dirty)
+}
+
+// windowHasDay reports whether the week holding date is loaded in ww.
+func windowHasDay(ww *task.WeekWindow, date time.Time) bool {
+	for _, week := range ww.Weeks() {
+		if week != nil && week.DayByDate(date) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// handleDaysLoaded swaps the reloaded days into the window and rebuilds the
+// grid.
+func (m Model) handleDaysLoaded(msg commands.DaysLoadedMsg) (tea.Model, tea.Cmd) {
+	ww := m.slotState.WeekWindow()
+	if ww == nil {
+		return m, nil
+	}
+	for _, day := range msg.Days {
+		ww.SetDay(day)
+	}
+	m.slotState.SetGrid(WeekWindowToSlotGrid(ww, m.slotState.Config()))
+	m.loading = false
+	m.refreshViewCaches()
+	return m, nil
+}
+
+// handleTaskChanged reloads the window after a write was refused because
+// another process changed its tasks, so the next one starts from what is
+// stored.
+func (m Model) handleTaskChanged() (tea.Model, tea.Cmd) {
+	m.statusMsg = "Task changed elsewhere, reloaded"
+	return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+}
+
+// handleModalTaskChanged closes the modal whose task another process wrote
+// and reloads like handleTaskChanged.
+func (m Model) handleModalTaskChanged() (tea.Model, tea.Cmd) {
+	m.modalTask = nil
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+	return m.handleTaskChanged()
+}
+
+// adoptVersion sets t to its stored version after a write to it, so the next
+// write from the same modal is not refused as a conflict with itself.
+func (m Model) adoptVersion(ctx context.Context, t *task.Task) {
+	if stored, err := m.repo.GetTask(ctx, t.ID); err == nil && stored != nil {
+		t.Version = stored.Version
+	}
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// openEditPreview lists the tasks whose day or times saving would change,
+// without writing anything.
+func (m Model) openEditPreview() (tea.Model, tea.Cmd) {
+	changes := m.slotState.PendingChanges()
+	if len(changes) == 0 {
+		m.statusMsg = "No changes to save"
+		return m, nil
+	}
+	m.previewChanges = changes
+	m.mode = ModeModal
+	m.modalType = ModalEditPreview
+	return m, nil
+}
+
+// handleEditPreviewKeys saves with Enter, or goes back to editing.
+func (m Model) handleEditPreviewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.closeEditPreview()
+		return m.saveEdits()
+	case "esc", "q", "P":
+		m.closeEditPreview()
+	}
+	return m, nil
+}
+
+func (m *Model) closeEditPreview() {
+	m.previewChanges = nil
+	m.mode = ModeEdit
+	m.modalType = ModalNone
+}
+
+func (m Model) renderEditPreviewModal() string {
+	rows := make([]view.EditPreviewRow, len(m.previewChanges))
+	for i, c := range m.previewChanges {
+		rows[i] = view.EditPreviewRow{
+			Description: c.After.Description,
+			OldDate:     c.Before.ScheduledDate,
+			OldStart:    c.Before.ScheduledStart,
+			OldEnd:      c.Before.ScheduledEnd,
+			NewDate:     c.After.ScheduledDate,
+			NewStart:    c.After.ScheduledStart,
+			NewEnd:      c.After.ScheduledEnd,
+		}
+	}
+	styles := view.MissedStyles{
+		BodyStyle: m.styles.ModalBodyStyle,
+		MetaStyle: m.styles.ModalMetaStyle,
+	}
+	body := view.RenderEditPreviewBody(rows, styles)
+	footer := view.EditPreviewFooter(m.modalStyles())
+	return view.RenderModalFrame("Pending changes", body, footer, m.modalStyles())
+}
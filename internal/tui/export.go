@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/export"
+)
+
+// handleExportCommand handles "/export obsidian", which writes today's
+// schedule to the Obsidian daily note.
+func (m Model) handleExportCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) != 1 || args[0] != "obsidian" {
+		m.statusMsg = "Usage: /export obsidian"
+		return m, nil
+	}
+	template := ""
+	if m.config != nil {
+		template = m.config.Export.ObsidianDailyNote
+	}
+	path, err := export.Obsidian(context.Background(), m.repo, template, dateutil.TruncateToDay(m.now()))
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Export failed: %v", err)
+		return m, nil
+	}
+	m.statusMsg = "Exported today to " + path
+	return m, nil
+}
+
+// handleSnapshotCommand handles "/snapshot", which draws the displayed week
+// to a PNG in the working directory for sharing.
+func (m Model) handleSnapshotCommand() (tea.Model, tea.Cmd) {
+	start, end := dateutil.WeekRange(m.weekStart)
+	tasks, err := m.repo.ListTasksByDateRange(context.Background(), start, end)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Snapshot failed: %v", err)
+		return m, nil
+	}
+	var buf bytes.Buffer
+	if err := export.PNG(&buf, start, tasks); err != nil {
+		m.statusMsg = fmt.Sprintf("Snapshot failed: %v", err)
+		return m, nil
+	}
+	path, err := filepath.Abs("sancho-week-" + start.Format("2006-01-02") + ".png")
+	if err == nil {
+		err = os.WriteFile(path, buf.Bytes(), 0o644)
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Snapshot failed: %v", err)
+		return m, nil
+	}
+	m.statusMsg = "Saved the week to " + path
+	return m, nil
+}
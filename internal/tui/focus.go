@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// toggleFocus switches focus mode, which shows today's column alone across
+// the full width, with the now-line, to cut the noise while working through
+// the day. Turning it on jumps to today; moving the cursor to another day
+// turns it off again.
+func (m Model) toggleFocus() (tea.Model, tea.Cmd) {
+	if !m.focusDate.IsZero() {
+		m.focusDate = time.Time{}
+		m.statusMsg = "Focus mode off"
+		m.resizeColumns()
+		return m, nil
+	}
+
+	now := m.now()
+	var cmd tea.Cmd
+	if weekStart := startOfWeek(now); !weekStart.Equal(m.weekStart) {
+		m.weekStart = weekStart
+		cmd = commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+	}
+	m.cursor.Day = weekdayIndex(now)
+	m.focusDate = m.cursorDate()
+	m.statusMsg = "Focus mode: today only (f for the whole week)"
+	m.resizeColumns()
+	return m, cmd
+}
+
+// leaveFocusIfMoved turns focus mode off once the cursor is on another day
+// than the focused one, so navigation never lands on a hidden column.
+func (m *Model) leaveFocusIfMoved() {
+	if m.focusDate.IsZero() || m.cursorDate().Equal(m.focusDate) {
+		return
+	}
+	m.focusDate = time.Time{}
+	m.resizeColumns()
+}
+
+// visibleDays returns the week columns shown in the grid: all of them, the
+// focused day alone, or the week without the empty days off when the
+// weekends setting hides them.
+func (m Model) visibleDays() []int {
+	if !m.focusDate.IsZero() {
+		return []int{weekdayIndex(m.focusDate)}
+	}
+	hide := m.config != nil && m.config.UI.WeekendsMode() == "hide"
+	days := make([]int, 0, 7)
+	for day := 0; day < 7; day++ {
+		if !hide || !m.collapsedDays[day] {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// cursorDate returns the date of the column under the cursor.
+func (m Model) cursorDate() time.Time {
+	return m.weekStart.AddDate(0, 0, m.cursor.Day)
+}
+
+// resizeColumns recomputes the column width and everything sized by it
+// after the number of visible days changed.
+func (m *Model) resizeColumns() {
+	m.colWidth = m.calculateColWidth()
+	m.rebuildStyleCache()
+	m.layoutCache = m.buildLayoutCache(m.width, m.height)
+	m.refreshViewCaches()
+}
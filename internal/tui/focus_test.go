@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+)
+
+func TestFocusMode(t *testing.T) {
+	cfg := config.Default()
+	cfg.Schedule.DayStart = "09:00"
+	cfg.Schedule.DayEnd = "17:00"
+	wednesday := time.Date(2025, 3, 12, 10, 40, 0, 0, time.Local)
+	m := *New(nil, cfg, WithClock(clock.Fixed(wednesday)))
+	m.cursor.Day = 0
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+	weekWidth := m.colWidth
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m = updated.(Model)
+	if m.cursor.Day != 2 {
+		t.Fatalf("cursor day = %d, want today (2)", m.cursor.Day)
+	}
+	if m.colWidth <= 6*weekWidth {
+		t.Errorf("focus column width = %d, want the full width (week columns are %d)", m.colWidth, weekWidth)
+	}
+	view := m.View()
+	if !strings.Contains(view, "*Wed*") || strings.Contains(view, "Mon") || strings.Contains(view, "Thu") {
+		t.Error("focus mode should show only today's column")
+	}
+	if !strings.Contains(view, nowMarker) {
+		t.Error("focus mode has no current-time marker")
+	}
+
+	// Moving to another day brings the week back
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = updated.(Model)
+	if !m.focusDate.IsZero() || m.colWidth != weekWidth {
+		t.Fatalf("focus date %v, column width %d after moving; want the week view", m.focusDate, m.colWidth)
+	}
+	if view := m.View(); !strings.Contains(view, "Mon") || !strings.Contains(view, "Thu") {
+		t.Error("week view should show every day again")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
 
+	"github.com/javiermolinar/sancho/internal/summary"
+	"github.com/javiermolinar/sancho/internal/task"
 	"github.com/javiermolinar/sancho/internal/tui/view"
 )
 
@@ -18,6 +20,20 @@ func (m Model) statusMsgOrDefault() string {
 	return m.statusMsg
 }
 
+// footerGoalBarWidth is the width of goal progress bars in the stats bar.
+const footerGoalBarWidth = 10
+
+// goalTargets returns the configured weekly targets in minutes.
+func (m Model) goalTargets() summary.GoalTargets {
+	if m.config == nil {
+		return summary.GoalTargets{}
+	}
+	return summary.GoalTargets{
+		DeepMinutes:  m.config.Goals.DeepMinutes(),
+		TotalMinutes: m.config.Goals.TotalMinutes(),
+	}
+}
+
 // renderStatsBar renders the statistics bar.
 func (m Model) renderStatsBar(width int) string {
 	ww := m.slotState.WeekWindow()
@@ -38,7 +54,7 @@ func (m Model) renderStatsBar(width int) string {
 	for _, d := range week.Days {
 		for _, t := range d.Tasks() {
 			if t.IsScheduled() {
-				if t.IsPast() {
+				if m.isTaskPast(t) {
 					done++
 				} else {
 					pending++
@@ -81,14 +97,46 @@ func (m Model) renderStatsBar(width int) string {
 	bar.WriteString(barStyle.Render(" shallow | Week: "))
 	bar.WriteString(barStyle.Render(weekTotal))
 	bar.WriteString(barStyle.Render(" total, "))
-	bar.WriteString(barStyle.Render(fmt.Sprintf("%d%% deep | ", weekDeep)))
+	bar.WriteString(barStyle.Render(fmt.Sprintf("%d%% deep", weekDeep)))
+	categoryMinutes := week.CategoryMinutes()
+	for _, info := range task.Categories() {
+		if info.Name == task.CategoryDeep || info.Name == task.CategoryShallow || categoryMinutes[info.Name] == 0 {
+			continue
+		}
+		categoryStyle := barStyle.Foreground(m.categoryColor(info)).Bold(true)
+		bar.WriteString(barStyle.Render(", "))
+		bar.WriteString(categoryStyle.Render(view.FormatDuration(categoryMinutes[info.Name])))
+		bar.WriteString(barStyle.Render(" " + string(info.Name)))
+	}
+	bar.WriteString(barStyle.Render(" | "))
 	bar.WriteString(barStyle.Render(fmt.Sprintf("%d pending, %d done", pending, done)))
+	for _, g := range summary.WeekGoals(week.AllTasks(), m.goalTargets(), m.isTaskPast) {
+		goalStyle := deepStyle
+		if g.Label != "Deep" {
+			goalStyle = barStyle
+		}
+		bar.WriteString(barStyle.Render(" | "))
+		bar.WriteString(goalStyle.Render(view.GoalBar(g, footerGoalBarWidth)))
+		bar.WriteString(barStyle.Render(" " + view.FormatGoal(g)))
+	}
+	for _, g := range summary.WeekBudgets(week.AllTasks(), m.isTaskPast) {
+		info, _ := task.LookupCategory(task.Category(g.Label))
+		bar.WriteString(barStyle.Render(" | "))
+		bar.WriteString(barStyle.Foreground(m.categoryColor(info)).Bold(true).Render(view.GoalBar(g, footerGoalBarWidth)))
+		bar.WriteString(barStyle.Render(" " + view.FormatBudget(g)))
+	}
 	if loadingIndicator != "" {
 		bar.WriteString(barStyle.Render(loadingIndicator))
 	}
 	if editIndicator != "" {
 		bar.WriteString(barStyle.Render(editIndicator))
 	}
+	if syncIndicator := m.syncIndicator(); syncIndicator != "" {
+		bar.WriteString(barStyle.Render(syncIndicator))
+	}
+	if replicaIndicator := m.replicaIndicator(); replicaIndicator != "" {
+		bar.WriteString(barStyle.Render(replicaIndicator))
+	}
 
 	statsStyle := m.layoutCache.StatsBarStyle
 	frameW, _ := statsStyle.GetFrameSize()
@@ -106,21 +154,41 @@ func (m Model) renderLegend() string {
 	baseStyle := lipgloss.NewStyle().
 		Foreground(m.styles.colorFg).
 		Background(m.styles.colorBg)
-	deepLabelStyle := baseStyle.
-		Foreground(m.styles.colorDeep).
-		Bold(true)
-	shallowLabelStyle := baseStyle.
-		Foreground(m.styles.colorShallow).
-		Bold(true)
 
 	var legend strings.Builder
 	legend.WriteString(baseStyle.Render("Legend: "))
-	legend.WriteString(deepLabelStyle.Render("[D] Deep"))
-	legend.WriteString(baseStyle.Render("  "))
-	legend.WriteString(shallowLabelStyle.Render("[S] Shallow"))
+	for i, info := range task.Categories() {
+		if i > 0 {
+			legend.WriteString(baseStyle.Render("  "))
+		}
+		labelStyle := baseStyle.
+			Foreground(m.categoryColor(info)).
+			Bold(true)
+		legend.WriteString(labelStyle.Render("[" + info.Code + "] " + view.FormatCategory(info.Name)))
+	}
+	if m.sandbox != nil {
+		sandboxStyle := baseStyle.
+			Foreground(m.styles.colorAccent).
+			Bold(true)
+		legend.WriteString(baseStyle.Render("  "))
+		legend.WriteString(sandboxStyle.Render("SANDBOX"))
+		legend.WriteString(baseStyle.Render(" (/sandbox apply | discard)"))
+	}
 	return legend.String()
 }
 
+// categoryColor returns the color a category is drawn with outside the grid.
+func (m Model) categoryColor(info task.CategoryInfo) lipgloss.Color {
+	switch {
+	case info.Color != "":
+		return lipgloss.Color(info.Color)
+	case info.Name == task.CategoryDeep:
+		return m.styles.colorDeep
+	default:
+		return m.styles.colorShallow
+	}
+}
+
 // promptCursor returns the cursor character if in prompt mode.
 func (m Model) promptCursor() string {
 	if m.mode == ModePrompt {
@@ -138,9 +206,11 @@ func (m Model) renderHelp() string {
 		if m.slotState.CanUndo() {
 			undoInfo = fmt.Sprintf(" (%d)", m.slotState.UndoCount())
 		}
-		help = fmt.Sprintf("EDIT: g/s/Space/x: modify | y: move | u: undo%s | Enter: save | Esc: discard", undoInfo)
+		help = fmt.Sprintf("EDIT: g/s/G/S/Space/x: modify | y: move | u: undo%s | ctrl+r: redo | m/': savepoint | P: preview | Enter: save | Esc: discard", undoInfo)
 	case ModeMove:
-		help = "h/j/k/l: navigate | Enter: confirm | Esc: cancel"
+		help = "h/j/k/l: navigate | J/K: bigger step | Enter: confirm | Esc: cancel"
+	case ModeVisual:
+		help = fmt.Sprintf("VISUAL (%d): Space: pick | x: cancel | d: defer | >/<: shift 1h | c: category | Esc: exit", len(m.visualSelection()))
 	case ModePrompt:
 		help = "Enter: submit | Esc: cancel"
 	case ModeModal:
@@ -148,20 +218,36 @@ func (m Model) renderHelp() string {
 		case ModalTaskForm:
 			help = "Tab: next field | Enter: save | Esc: cancel"
 		case ModalTaskDetail:
-			if m.modalTask != nil && m.modalTask.IsPast() {
-				help = "o: outcome | Enter/Esc: close"
+			if m.modalTask != nil && m.isTaskPast(m.modalTask) {
+				help = "o: outcome | n: energy | Y: copy | Tab: history | Enter/Esc: close"
 			} else {
-				help = "o: outcome | e: edit task | x: cancel task | Enter/Esc: close"
+				help = "o: outcome | n: energy | e: edit task | x: cancel task | Y: copy | Tab: history | Enter/Esc: close"
 			}
+		case ModalTaskHistory:
+			help = "j/k: pick version | Enter: restore | Tab: details | Esc: close"
 		case ModalConfirmDelete:
 			help = "y/Enter: confirm | n/Esc: cancel"
+		case ModalSaveConflict:
+			help = "i/Enter: edit again | Esc: close"
+		case ModalEditPreview:
+			help = "Enter: save | Esc: back to editing"
+		case ModalRestoreEdits:
+			help = "Enter: restore | Esc: discard"
+		case ModalObjectives:
+			help = "h/l: quarter | Esc: close"
 		case ModalPlanResult:
 			help = "a/Enter: apply | m: amend | c/Esc: cancel"
+			if m.planResult != nil && len(m.planResult.Dropped) > 0 {
+				help = "f: fix output | " + help
+			}
+			if m.planStream != nil {
+				help = "Esc: cancel generation"
+			}
 		default:
 			help = "Esc: close"
 		}
 	default:
-		help = "h/j/k/l: navigate | i: edit mode | d: defer | /: commands | q: quit"
+		help = "h/j/k/l: navigate | i: edit mode | v: select | yy/P: copy/paste | d: defer | f: focus | z: hours | +/-: zoom | /: commands | q: quit"
 	}
 	return m.styles.HelpStyle.Render(help)
 }
@@ -5,7 +5,14 @@ import (
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rivo/uniseg"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/dateutil"
 	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/view"
 )
 
 // Layout constants for boxed rendering.
@@ -45,10 +52,13 @@ func (m *Model) getFooterHeight() int {
 }
 
 // calculateLayout determines row height (minutes) and row lines based on terminal height.
-// The grid always uses 15-minute slots; only row line height adapts to available space.
+// Row height follows the zoom level; row line height adapts to available space.
 func (m *Model) calculateLayout() {
+	m.rowHeight = m.slotMinutes
+	if m.rowHeight <= 0 {
+		m.rowHeight = zoomLevels[0]
+	}
 	if m.height == 0 {
-		m.rowHeight = 15
 		m.rowLines = 1
 		return
 	}
@@ -57,7 +67,12 @@ func (m *Model) calculateLayout() {
 	_ = appH
 	innerH := m.height - appV
 	footer := m.getFooterHeight()
-	availableLines := innerH - footer
+	availableLines := innerH - footer - m.parkingLines() - m.bannerLines()
+	if m.offHoursCollapsed {
+		// Size rows so the whole working day fits below the table borders,
+		// header and bands, rather than rounding up and scrolling.
+		availableLines -= 3 + view.HeaderLines + m.offHoursBandLines()
+	}
 
 	if availableLines < 4 {
 		availableLines = 4
@@ -67,15 +82,13 @@ func (m *Model) calculateLayout() {
 	dayEnd := m.dayEndMinutes()
 	totalMinutes := dayEnd - dayStart
 
-	m.rowHeight = 15
-
 	slots := totalMinutes / m.rowHeight
 	if slots <= 0 {
 		slots = 1
 	}
 
 	linesPerRow := availableLines / slots
-	if availableLines%slots != 0 {
+	if availableLines%slots != 0 && !m.offHoursCollapsed {
 		linesPerRow++
 	}
 	if linesPerRow < 1 {
@@ -98,7 +111,8 @@ func (m *Model) calculateLayout() {
 
 const layoutChrome = 22
 
-// calculateColWidth determines the column width based on terminal width.
+// calculateColWidth determines the even share of the terminal width each
+// full day column would get. Day widths are sized around it by content.
 func (m *Model) calculateColWidth() int {
 	if m.width == 0 {
 		return defaultColWidth
@@ -110,14 +124,18 @@ func (m *Model) calculateColWidth() int {
 	// - Time column: 6 chars + 1 space + separator (1) = 8
 	// - Column separators: 6 separators between 7 days = 6
 	// Total chrome: 4 + 4 + 8 + 6 = 22
-	available := m.width - layoutChrome
+	// Focus mode and hidden weekends drop columns with their separators.
+	days := len(m.visibleDays())
+	available := m.width - layoutChrome + (7 - days)
 
-	// Divide by 7 days
-	colWidth := available / 7
+	// Narrow weekend columns take a fixed width; the other days share the rest.
+	narrow := m.narrowDays()
+	available -= narrow * narrowColWidth
+	colWidth := available / (days - narrow)
 
 	// Clamp to a minimum for readability.
-	if colWidth < 10 {
-		return 10
+	if colWidth < minColWidth {
+		return minColWidth
 	}
 
 	return colWidth
@@ -144,9 +162,7 @@ func (m *Model) taskSlotSpan(t *task.Task) int {
 	if m.rowHeight <= 0 {
 		return 1
 	}
-	start := task.TimeToMinutes(t.ScheduledStart)
-	end := task.TimeToMinutes(t.ScheduledEnd)
-	duration := end - start
+	duration := t.Duration()
 	if duration <= 0 {
 		return 1
 	}
@@ -218,24 +234,13 @@ func (m *Model) displayRangeMinutes() (int, int) {
 	start := task.TimeToMinutes(m.config.Schedule.DayStart)
 	end := task.TimeToMinutes(m.config.Schedule.DayEnd)
 
-	ww := m.slotState.WeekWindow()
-	if ww == nil || ww.Current() == nil {
-		return start, end
-	}
-
-	for day := 0; day < 7; day++ {
-		d := ww.Current().Day(day)
-		if d == nil {
-			continue
-		}
-		for _, t := range d.ScheduledTasks() {
-			taskStart := task.TimeToMinutes(t.ScheduledStart)
-			taskEnd := task.TimeToMinutes(t.ScheduledEnd)
-			if taskStart < start {
-				start = taskStart
+	for day := 0; day < 7 && !m.offHoursCollapsed; day++ {
+		for _, span := range m.daySpans(day) {
+			if span.start < start {
+				start = span.start
 			}
-			if taskEnd > end {
-				end = taskEnd
+			if span.end > end {
+				end = span.end
 			}
 		}
 	}
@@ -256,6 +261,46 @@ func (m *Model) displayRangeMinutes() (int, int) {
 	return start, end
 }
 
+// taskSpan is the part of a task shown in a day column, in minutes from that
+// day's midnight.
+type taskSpan struct {
+	task       *task.Task
+	start, end int
+}
+
+// daySpans returns the scheduled blocks shown on a day of the current week.
+// Overnight tasks are cut at midnight; the rest of them shows up at the top
+// of the next day.
+func (m *Model) daySpans(day int) []taskSpan {
+	ww := m.slotState.WeekWindow()
+	if ww == nil || ww.Current() == nil {
+		return nil
+	}
+
+	var spans []taskSpan
+	prev := ww.Current().Day(day - 1)
+	if day == 0 && ww.Previous() != nil {
+		prev = ww.Previous().Day(6)
+	}
+	if prev != nil {
+		for _, t := range prev.ScheduledTasks() {
+			if t.IsOvernight() {
+				spans = append(spans, taskSpan{task: t, start: 0, end: task.TimeToMinutes(t.ScheduledEnd)})
+			}
+		}
+	}
+	if d := ww.Current().Day(day); d != nil {
+		for _, t := range d.ScheduledTasks() {
+			if t.IsAllDay() {
+				continue
+			}
+			start, end := task.Span(t.ScheduledStart, t.ScheduledEnd)
+			spans = append(spans, taskSpan{task: t, start: start, end: min(end, MinutesPerDay)})
+		}
+	}
+	return spans
+}
+
 // slotToTime converts a slot index to a time string.
 func (m *Model) slotToTime(slot int) string {
 	mins := m.dayStartMinutes() + (slot * m.rowHeight)
@@ -268,19 +313,38 @@ func (m *Model) taskAtCursor() *task.Task {
 	return m.taskAt(m.cursor.Day, timeLabel)
 }
 
-// taskAt returns the task at a specific day and time.
+// splitSpans divides the spans of a day between the halves of a column
+// split by owner: the split owner's blocks go right, everyone else's left.
+// Without a split owner every block stays left.
+func (m *Model) splitSpans(spans []taskSpan) (left, right []taskSpan) {
+	cfg := m.slotState.Config()
+	if cfg.SplitOwner == "" {
+		return spans, nil
+	}
+	for _, span := range spans {
+		if cfg.isSplitOwner(span.task) {
+			right = append(right, span)
+		} else {
+			left = append(left, span)
+		}
+	}
+	return left, right
+}
+
+// taskAt returns the task at a specific day and time, leaving out the
+// split owner's tasks, which only show beside the rest.
+func (m *Model) taskAt(day int, timeLabel string) *task.Task {
+	spans, _ := m.splitSpans(m.daySpans(day))
+	return m.taskInSpans(spans, day, timeLabel)
+}
+
+// taskInSpans returns the task of spans at a specific day and time.
 // The time represents the start of a display slot. We treat a task as occupying a slot
 // if the slot index falls within the task's display coverage. If multiple tasks overlap
 // the slot, we prefer the task that starts within the slot range. Otherwise, we show the
 // task that started most recently before the slot and continues into it.
-func (m *Model) taskAt(day int, timeLabel string) *task.Task {
-	ww := m.slotState.WeekWindow()
-	if ww == nil || ww.Current() == nil {
-		return nil
-	}
-
-	d := ww.Current().Day(day)
-	if d == nil {
+func (m *Model) taskInSpans(spans []taskSpan, day int, timeLabel string) *task.Task {
+	if len(spans) == 0 {
 		return nil
 	}
 
@@ -298,9 +362,8 @@ func (m *Model) taskAt(day int, timeLabel string) *task.Task {
 	bestOverlap := -1
 	minOverlap := m.rowHeight / 2
 
-	for _, t := range d.ScheduledTasks() {
-		taskStart := task.TimeToMinutes(t.ScheduledStart)
-		taskEnd := task.TimeToMinutes(t.ScheduledEnd)
+	for _, span := range spans {
+		t, taskStart, taskEnd := span.task, span.start, span.end
 		taskDuration := taskEnd - taskStart
 		// Check if task overlaps the display slot's time range
 		// Overlap: task starts before slot ends AND task ends after slot starts
@@ -357,6 +420,49 @@ func (m *Model) taskAt(day int, timeLabel string) *task.Task {
 	return nil
 }
 
+// overlapAt returns the task sharing a display slot with primary, or nil.
+// Only tasks whose times overlap primary's count, so back-to-back tasks that
+// meet inside a slot are not shown side by side. When several qualify, the
+// one covering most of the slot wins.
+func (m *Model) overlapAt(day int, timeLabel string, primary *task.Task) *task.Task {
+	spans := m.daySpans(day)
+	primaryStart, primaryEnd := -1, -1
+	for _, span := range spans {
+		if span.task.ID == primary.ID {
+			primaryStart, primaryEnd = span.start, span.end
+			break
+		}
+	}
+	if primaryStart < 0 {
+		return nil
+	}
+
+	slotStart := task.TimeToMinutes(timeLabel)
+	slotEnd := slotStart + m.rowHeight
+	var best *task.Task
+	bestOverlap := 0
+	for _, span := range spans {
+		if span.task.ID == primary.ID || span.start >= primaryEnd || span.end <= primaryStart {
+			continue
+		}
+		overlap := min(span.end, slotEnd) - max(span.start, slotStart)
+		if overlap > bestOverlap {
+			best = span.task
+			bestOverlap = overlap
+		}
+	}
+	return best
+}
+
+// navWeekWindow returns the week window used for week navigation, or nil
+// while only the visible week is loaded so navigation does a full reload.
+func (m *Model) navWeekWindow() *task.WeekWindow {
+	if m.visibleOnly {
+		return nil
+	}
+	return m.slotState.WeekWindow()
+}
+
 func (m *Model) now() time.Time {
 	return m.nowFunc()()
 }
@@ -368,7 +474,7 @@ func (m *Model) nowFunc() func() time.Time {
 			return cfg.Now
 		}
 	}
-	return time.Now
+	return clock.OrSystem(m.clock).Now
 }
 
 func (m *Model) currentWeekDayIndex(now time.Time) int {
@@ -377,7 +483,7 @@ func (m *Model) currentWeekDayIndex(now time.Time) int {
 		gridDay := cfg.DateToDayIndex(now)
 		if gridDay >= 0 {
 			weekIndex, dayOfWeek := DayIndexToWeekAndDay(gridDay)
-			if weekIndex == 1 {
+			if weekIndex == cfg.CurrentWeek() {
 				return dayOfWeek
 			}
 		}
@@ -442,12 +548,66 @@ func (m *Model) focusCursorOnCurrentTaskOrTime() {
 	m.ensureCursorVisible()
 }
 
+// openFocusTask moves the cursor to the deep-linked task and opens its
+// detail modal. If the task is no longer scheduled the cursor falls back
+// to the current time.
+func (m *Model) openFocusTask() tea.Cmd {
+	id := m.focusTask.ID
+	m.focusTask = nil
+
+	if ww := m.slotState.WeekWindow(); ww != nil && ww.Current() != nil {
+		for dayIndex := 0; dayIndex < 7; dayIndex++ {
+			day := ww.Current().Day(dayIndex)
+			if day == nil {
+				continue
+			}
+			for _, t := range day.ScheduledTasks() {
+				if t.ID != id {
+					continue
+				}
+				m.cursor.Day = dayIndex
+				m.cursor.Slot = m.slotToDisplaySlot(task.TimeToMinutes(t.ScheduledStart) / 15)
+				m.ensureCursorVisible()
+				return m.openTaskDetail(t)
+			}
+		}
+	}
+
+	m.focusCursorOnCurrentTaskOrTime()
+	m.statusMsg = fmt.Sprintf("Task #%d is not scheduled", id)
+	return nil
+}
+
 // isWorkday returns true if the given date is a workday.
 func (m *Model) isWorkday(date time.Time) bool {
 	weekdayName := date.Weekday().String()
 	return m.config.IsWorkday(weekdayName)
 }
 
+// locationFor returns the timezone pinned for date, or the local zone.
+func (m *Model) locationFor(date time.Time) *time.Location {
+	if m.config == nil {
+		return time.Local
+	}
+	return m.config.LocationFor(date)
+}
+
+// pinnedZoneAbbr returns the zone abbreviation for a pinned day, or "" if the
+// day uses the local zone.
+func (m *Model) pinnedZoneAbbr(date time.Time) string {
+	loc := m.locationFor(date)
+	if loc == time.Local {
+		return ""
+	}
+	abbr, _ := date.In(loc).Zone()
+	return abbr
+}
+
+// isTaskPast reports whether t has ended, using its day's pinned timezone.
+func (m *Model) isTaskPast(t *task.Task) bool {
+	return t.IsPastAt(m.now().In(m.locationFor(t.ScheduledDate)))
+}
+
 // isCurrentTask returns true if the given task is happening right now.
 // A task is "current" if today matches its scheduled date and the current time
 // falls within its scheduled start and end times.
@@ -456,17 +616,21 @@ func (m *Model) isCurrentTask(t *task.Task) bool {
 		return false
 	}
 
-	now := m.now()
-
-	// Check if task is scheduled for today
-	if !sameDay(t.ScheduledDate, now) {
-		return false
-	}
+	now := m.now().In(m.locationFor(t.ScheduledDate))
 
 	// Get current time in minutes
 	currentMins := now.Hour()*60 + now.Minute()
-	startMins := task.TimeToMinutes(t.ScheduledStart)
-	endMins := task.TimeToMinutes(t.ScheduledEnd)
+	startMins, endMins := task.Span(t.ScheduledStart, t.ScheduledEnd)
+
+	// Check if task is scheduled for today, or is an overnight task from
+	// yesterday that has not ended yet
+	switch {
+	case sameDay(t.ScheduledDate, now):
+	case t.IsOvernight() && sameDay(t.ScheduledDate.AddDate(0, 0, 1), now):
+		currentMins += MinutesPerDay
+	default:
+		return false
+	}
 
 	// Check if current time is within task's time range
 	return currentMins >= startMins && currentMins < endMins
@@ -474,23 +638,14 @@ func (m *Model) isCurrentTask(t *task.Task) bool {
 
 // Utility functions
 
-// startOfWeek returns the Monday of the week containing the given date.
+// startOfWeek returns the first day of the week containing the given date.
 func startOfWeek(t time.Time) time.Time {
-	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
-	weekday := int(t.Weekday())
-	if weekday == 0 {
-		weekday = 7
-	}
-	return t.AddDate(0, 0, -(weekday - 1))
+	return dateutil.StartOfWeek(t)
 }
 
-// weekdayIndex returns the weekday index (0=Monday, 6=Sunday).
+// weekdayIndex returns the column of t in its week (0 = first day of the week).
 func weekdayIndex(t time.Time) int {
-	weekday := int(t.Weekday())
-	if weekday == 0 {
-		return 6 // Sunday
-	}
-	return weekday - 1
+	return dateutil.WeekdayOffset(t)
 }
 
 // sameDay returns true if two dates are the same day.
@@ -505,13 +660,18 @@ func minutesToTime(mins int) string {
 	return fmt.Sprintf("%02d:%02d", h, m)
 }
 
-// addMinutesToTime adds minutes to a time string.
+// addMinutesToTime adds minutes to a time string, wrapping past midnight.
 func addMinutesToTime(timeStr string, minutes int) string {
 	mins := task.TimeToMinutes(timeStr) + minutes
-	return minutesToTime(mins)
+	return minutesToTime(mins % MinutesPerDay)
 }
 
-// truncate truncates a string to the given length.
+// wrapTextWithWidths wraps s on spaces into at most maxLines lines, the first
+// firstWidth terminal columns wide and the rest otherWidth. A word wider than
+// a line, such as a run of CJK text, is broken across lines. Text that does
+// not fit ends the last line in "…". Lines are cut between grapheme
+// clusters, so accented letters and emoji stay whole, and wide characters
+// take two columns.
 func wrapTextWithWidths(s string, firstWidth, otherWidth, maxLines int) []string {
 	if firstWidth <= 0 || otherWidth <= 0 || maxLines <= 0 {
 		return nil
@@ -522,29 +682,37 @@ func wrapTextWithWidths(s string, firstWidth, otherWidth, maxLines int) []string
 		return []string{""}
 	}
 
+	lineWidth := func(i int) int {
+		if i == 0 {
+			return firstWidth
+		}
+		return otherWidth
+	}
+
 	var lines []string
 	var line string
-
+	used := 0
 	for _, word := range words {
-		width := otherWidth
-		if len(lines) == 0 {
-			width = firstWidth
-		}
-		if line == "" {
-			if len(word) > width {
-				line = word[:width]
-			} else {
-				line = word
-			}
+		w := uniseg.StringWidth(word)
+		if line != "" && used+1+w <= lineWidth(len(lines)) {
+			line += " " + word
+			used += 1 + w
 			continue
 		}
-
-		if len(line)+1+len(word) <= width {
-			line += " " + word
-		} else {
+		if line != "" {
 			lines = append(lines, line)
-			line = word
 		}
+		// Break words wider than a line
+		for w > lineWidth(len(lines)) {
+			head, rest := cutToWidth(word, lineWidth(len(lines)))
+			if head == "" {
+				// A character wider than the line gets a line of its own
+				head, rest, _, _ = uniseg.FirstGraphemeClusterInString(word, -1)
+			}
+			lines = append(lines, head)
+			word, w = rest, uniseg.StringWidth(rest)
+		}
+		line, used = word, w
 	}
 	if line != "" {
 		lines = append(lines, line)
@@ -555,38 +723,53 @@ func wrapTextWithWidths(s string, firstWidth, otherWidth, maxLines int) []string
 	}
 
 	lines = lines[:maxLines]
-	last := lines[maxLines-1]
-	width := otherWidth
-	if maxLines == 1 {
-		width = firstWidth
-	}
+	width := lineWidth(maxLines - 1)
 	if width == 1 {
 		lines[maxLines-1] = "…"
 		return lines
 	}
-	if len(last) >= width {
-		lines[maxLines-1] = last[:width-1] + "…"
-		return lines
+	last := lines[maxLines-1]
+	if uniseg.StringWidth(last) >= width {
+		last, _ = cutToWidth(last, width-1)
 	}
 	lines[maxLines-1] = last + "…"
 	return lines
 }
 
+// truncateWithEllipsis cuts s to width terminal columns, ending in "…" when
+// it is cut. Cuts fall between grapheme clusters.
 func truncateWithEllipsis(s string, width int) string {
 	if width <= 0 {
 		return ""
 	}
-	if len(s) <= width {
+	if uniseg.StringWidth(s) <= width {
 		return s
 	}
 	if width == 1 {
 		return "…"
 	}
-	return s[:width-1] + "…"
+	head, _ := cutToWidth(s, width-1)
+	return head + "…"
+}
+
+// cutToWidth splits s after the grapheme clusters that fit in width terminal
+// columns.
+func cutToWidth(s string, width int) (head, rest string) {
+	rest = s
+	used, state := 0, -1
+	for rest != "" {
+		_, next, w, newState := uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > width {
+			break
+		}
+		used += w
+		rest, state = next, newState
+	}
+	return s[:len(s)-len(rest)], rest
 }
 
 func (m Model) singleLineTaskContent(indicator string, t *task.Task) string {
-	contentWidth := max(0, m.colWidth-1)
+	contentWidth := max(0, m.taskWidth(t)-1)
 	if contentWidth == 0 {
 		return ""
 	}
@@ -595,23 +778,36 @@ func (m Model) singleLineTaskContent(indicator string, t *task.Task) string {
 	if contentWidth <= len(prefix) {
 		return prefix[:contentWidth]
 	}
+	title := taskTitle(t)
 
 	available := contentWidth - len(prefix)
 	timeRange := t.ScheduledStart + "-" + t.ScheduledEnd
-	if available > len(timeRange)+1 {
+	// A ticket key is worth more than the time range, which the grid shows
+	// anyway
+	keyLen := len(task.TicketKey(t.Description))
+	if available > len(timeRange)+1+keyLen {
 		descWidth := available - len(timeRange) - 1
-		desc := truncateWithEllipsis(t.Description, descWidth)
-		gap := descWidth - len(desc)
+		desc := truncateWithEllipsis(title, descWidth)
+		gap := descWidth - ansi.StringWidth(desc)
 		if gap < 1 {
 			gap = 1
 		}
 		return prefix + desc + strings.Repeat(" ", gap) + timeRange
 	}
 
-	desc := truncateWithEllipsis(t.Description, available)
+	desc := truncateWithEllipsis(title, available)
 	return prefix + desc
 }
 
+// taskTitle returns the title shown for t in the grid: its description,
+// after its category's icon when it has one.
+func taskTitle(t *task.Task) string {
+	if icon := t.Category.Icon(); icon != "" {
+		return icon + " " + t.Description
+	}
+	return t.Description
+}
+
 // nextSlotDown returns the next slot when moving down.
 // If on a task, jumps to the first slot after that task ends.
 // If on empty space, moves one slot down.
@@ -739,8 +935,7 @@ func (m *Model) updateCursorToMovingTask() {
 	targetDay := moveState.TargetDay
 	targetSlot := moveState.TargetSlot
 
-	// Convert slot grid day index (0-20 for 3 weeks) to current week day (0-6)
-	// The current week is days 7-13 in the grid
+	// Convert slot grid day index to current week day (0-6)
 	_, dayOfWeek := DayIndexToWeekAndDay(targetDay)
 
 	// Update cursor position
@@ -751,6 +946,27 @@ func (m *Model) updateCursorToMovingTask() {
 	m.ensureCursorVisible()
 }
 
+func (m *Model) focusCursorOnTaskStart(t *task.Task) {
+	if t == nil || m.slotState == nil {
+		return
+	}
+
+	grid := m.slotState.Grid()
+	if grid == nil {
+		return
+	}
+
+	day, startSlot, _, found := grid.FindTask(t)
+	if !found {
+		return
+	}
+
+	_, dayOfWeek := DayIndexToWeekAndDay(day)
+	m.cursor.Day = dayOfWeek
+	m.cursor.Slot = m.slotToDisplaySlot(startSlot)
+	m.ensureCursorVisible()
+}
+
 func (m *Model) focusCursorOnTaskEnd(t *task.Task) {
 	if t == nil || m.slotState == nil {
 		return
@@ -802,3 +1018,14 @@ func (m *Model) displaySlotToSlot(slot int) int {
 	mins := m.dayStartMinutes() + (slot * m.rowHeight)
 	return mins / 15
 }
+
+// energyWarning returns a warning when a high-energy task sits in a
+// low-energy window of the configured profile.
+func (m *Model) energyWarning(t *task.Task) string {
+	if t == nil || m.config == nil || !m.config.Energy.HasProfile() {
+		return ""
+	}
+	return task.EnergyWarning(t.Description, t.Energy, t.ScheduledStart, t.ScheduledEnd, func(minute int) task.Energy {
+		return task.Energy(m.config.Energy.LevelAt(minute))
+	})
+}
@@ -2,10 +2,12 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/javiermolinar/sancho/internal/config"
 	"github.com/javiermolinar/sancho/internal/task"
@@ -79,8 +81,8 @@ func TestEnsureCursorVisibleUsesGridHeight(t *testing.T) {
 	m.cursor = Position{Day: 0, Slot: 12}
 	m.ensureCursorVisible()
 
-	if m.scrollOffset != 2 {
-		t.Fatalf("scrollOffset = %d, want %d", m.scrollOffset, 2)
+	if m.scrollOffset != 3 {
+		t.Fatalf("scrollOffset = %d, want %d", m.scrollOffset, 3)
 	}
 }
 
@@ -97,7 +99,8 @@ func TestFocusCursorOnTaskEndAfterShrink(t *testing.T) {
 		Category:    task.CategoryDeep,
 		Status:      task.StatusScheduled,
 	}
-	grid, err := grid.Place(taskA, 7, 0, 2)
+	// Next week's Monday, so the task is still in the future relative to nowFunc
+	grid, err := grid.Place(taskA, 14, 0, 2)
 	if err != nil {
 		t.Fatalf("place task failed: %v", err)
 	}
@@ -125,3 +128,88 @@ func TestFocusCursorOnTaskEndAfterShrink(t *testing.T) {
 		t.Fatalf("cursor slot = %d, want 0", m.cursor.Slot)
 	}
 }
+
+func TestSingleLineTaskContent_KeepsTicketKey(t *testing.T) {
+	m := Model{colWidth: 22}
+	plain := &task.Task{Description: "Fix login", ScheduledStart: "09:00", ScheduledEnd: "10:00"}
+	if got := m.singleLineTaskContent("D", plain); got != "[D] Fix … 09:00-10:00" {
+		t.Errorf("plain = %q", got)
+	}
+	ticket := &task.Task{Description: "API-42 Fix login", ScheduledStart: "09:00", ScheduledEnd: "10:00"}
+	if got := m.singleLineTaskContent("D", ticket); got != "[D] API-42 Fix login" {
+		t.Errorf("ticket = %q, want the key kept over the time range", got)
+	}
+}
+
+func TestSingleLineTaskContent_IconKeepsWidth(t *testing.T) {
+	task.SetCategories([]task.CategoryInfo{{Name: "call", Code: "C", Icon: "📞"}})
+	defer task.SetCategories(nil)
+
+	m := Model{colWidth: 30}
+	plain := &task.Task{Description: "Sync", Category: task.CategoryShallow, ScheduledStart: "09:00", ScheduledEnd: "10:00"}
+	call := &task.Task{Description: "Sync", Category: "call", ScheduledStart: "09:00", ScheduledEnd: "10:00"}
+	want := ansi.StringWidth(m.singleLineTaskContent("S", plain))
+	got := m.singleLineTaskContent("C", call)
+	if !strings.HasPrefix(got, "[C] 📞 Sync ") {
+		t.Errorf("call = %q, want the icon before the title", got)
+	}
+	if w := ansi.StringWidth(got); w != want {
+		t.Errorf("call width = %d, want %d like a line without icon", w, want)
+	}
+	if got := padRight("日本", 6); ansi.StringWidth(got) != 6 {
+		t.Errorf("padRight(日本, 6) = %q, want 6 columns", got)
+	}
+}
+
+func TestTruncateWithEllipsis_Unicode(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"Écrire thèse", 20, "Écrire thèse"},
+		{"Écrire thèse", 12, "Écrire thèse"},
+		{"Écrire thèse", 8, "Écrire …"},
+		{"会議の準備", 5, "会議…"},
+		{"会議の準備", 4, "会…"},
+		{"👩‍💻 Deploy", 4, "👩‍💻 …"},
+		{"Fix login", 1, "…"},
+		{"Fix login", 0, ""},
+	}
+	for _, tt := range tests {
+		got := truncateWithEllipsis(tt.s, tt.width)
+		if got != tt.want {
+			t.Errorf("truncateWithEllipsis(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		if w := ansi.StringWidth(got); w > tt.width {
+			t.Errorf("truncateWithEllipsis(%q, %d) is %d columns wide", tt.s, tt.width, w)
+		}
+	}
+}
+
+func TestWrapTextWithWidths_Unicode(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		width    int
+		maxLines int
+		want     []string
+	}{
+		{"accents", "Écrire thèse finale", 8, 3, []string{"Écrire", "thèse", "finale"}},
+		{"cjk breaks without spaces", "会議の準備をする", 6, 3, []string{"会議の", "準備を", "する"}},
+		{"cjk cut", "会議の準備をする", 6, 2, []string{"会議の", "準備…"}},
+		{"emoji", "🚀 Launch 🎉", 9, 2, []string{"🚀 Launch", "🎉"}},
+		{"joined emoji stays whole", "👩‍💻👩‍💻👩‍💻", 4, 2, []string{"👩‍💻👩‍💻", "👩‍💻"}},
+	}
+	for _, tt := range tests {
+		got := wrapTextWithWidths(tt.s, tt.width, tt.width, tt.maxLines)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: wrapTextWithWidths(%q) = %q, want %q", tt.name, tt.s, got, tt.want)
+		}
+		for _, line := range got {
+			if w := ansi.StringWidth(line); w > tt.width {
+				t.Errorf("%s: line %q is %d columns wide, want at most %d", tt.name, line, w, tt.width)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// openTaskHistory switches the detail modal to the history tab, listing the
+// earlier versions of the task.
+func (m Model) openTaskHistory() (tea.Model, tea.Cmd) {
+	lister, ok := m.repo.(task.RevisionLister)
+	if !ok {
+		m.statusMsg = "Task history is not available here"
+		return m, nil
+	}
+	revs, err := lister.ListTaskRevisions(context.Background(), m.modalTask.ID)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.taskHistory = revs
+	m.historyCursor = 0
+	m.modalType = ModalTaskHistory
+	return m, nil
+}
+
+// canRestoreTask reports whether the history tab can restore a version of
+// the task: past and no longer scheduled tasks are read-only.
+func (m Model) canRestoreTask() bool {
+	return m.modalTask != nil && m.modalTask.IsScheduled() && !m.isTaskPast(m.modalTask) && len(m.taskHistory) > 0
+}
+
+// handleTaskHistoryKeys handles keys in the history tab of the detail modal.
+func (m Model) handleTaskHistoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeTaskHistory()
+		m.mode = ModeNormal
+		m.modalType = ModalNone
+		m.modalTask = nil
+	case "tab":
+		m.closeTaskHistory()
+		m.modalType = ModalTaskDetail
+	case "j", "down":
+		if m.historyCursor < len(m.taskHistory)-1 {
+			m.historyCursor++
+		}
+	case "k", "up":
+		if m.historyCursor > 0 {
+			m.historyCursor--
+		}
+	case "enter", "r":
+		return m.restoreTaskRevision()
+	}
+	return m, nil
+}
+
+// restoreTaskRevision sets the task back to the version under the cursor.
+func (m Model) restoreTaskRevision() (tea.Model, tea.Cmd) {
+	if !m.canRestoreTask() {
+		if m.modalTask != nil && m.isTaskPast(m.modalTask) {
+			m.statusMsg = "Cannot edit past tasks"
+		}
+		return m, nil
+	}
+	rev := m.taskHistory[m.historyCursor]
+	if err := task.RestoreRevision(context.Background(), m.repo, m.modalTask, rev); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Restored: %s %s-%s", rev.Description, rev.ScheduledStart, rev.ScheduledEnd)
+	reload := m.reloadDays(m.modalTask.ScheduledDate, rev.ScheduledDate)
+	m.closeTaskHistory()
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+	m.modalTask = nil
+	return m, reload
+}
+
+func (m *Model) closeTaskHistory() {
+	m.taskHistory = nil
+	m.historyCursor = 0
+}
+
+// renderTaskHistoryModal renders the history tab of the task detail modal.
+func (m Model) renderTaskHistoryModal() string {
+	if m.modalTask == nil {
+		return ""
+	}
+	styles := view.TaskHistoryStyles{
+		BodyStyle:   m.styles.ModalBodyStyle,
+		MetaStyle:   m.styles.ModalMetaStyle,
+		CursorStyle: m.styles.ModalInputCursorStyle,
+	}
+	body := view.RenderTaskHistoryBody(m.modalTask, m.taskHistory, m.historyCursor, styles)
+	footer := view.TaskHistoryFooter(m.canRestoreTask(), m.modalStyles())
+	return view.RenderModalFrame("Task History", body, footer, m.modalStyles())
+}
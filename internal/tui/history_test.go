@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestTaskHistoryRestoresOldTime(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	friday := time.Date(2025, 3, 14, 0, 0, 0, 0, time.Local)
+	report := &task.Task{Description: "Report", Category: task.CategoryDeep, ScheduledDate: friday,
+		ScheduledStart: "10:00", ScheduledEnd: "11:00", Status: task.StatusScheduled}
+	if err := repo.CreateTask(ctx, report); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if err := repo.UpdateTask(ctx, report.ID, "14:00", "15:00", 0); err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	if err := repo.UpdateTaskDescription(ctx, report.ID, "Weekly report", 0); err != nil {
+		t.Fatalf("UpdateTaskDescription: %v", err)
+	}
+
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(friday.Add(8*time.Hour))))
+	m.rowHeight = 15
+	updated, _ := m.Update(commands.LoadInitialWeeks(repo, m.weekStart, m.weekRadius)())
+	m = updated.(Model)
+	m.cursor = Position{Day: 4, Slot: m.timeToDisplaySlot(friday.Add(14 * time.Hour))}
+
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		updated, _ := m.handleKeyMsg(msg)
+		m = updated.(Model)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	press(tea.KeyMsg{Type: tea.KeyTab})
+	if m.modalType != ModalTaskHistory || len(m.taskHistory) != 2 {
+		t.Fatalf("modal %v with %d versions, want the history tab with 2", m.modalType, len(m.taskHistory))
+	}
+	if m.renderTaskHistoryModal() == "" {
+		t.Fatal("history tab should render")
+	}
+
+	// The oldest version has the original time
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeNormal {
+		t.Fatalf("mode = %v, restoring should close the modal", m.mode)
+	}
+	got, err := repo.GetTask(ctx, report.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if got.Description != "Report" || got.ScheduledStart != "10:00" || got.ScheduledEnd != "11:00" {
+		t.Errorf("task = %q %s-%s, want Report 10:00-11:00", got.Description, got.ScheduledStart, got.ScheduledEnd)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/config"
 	"github.com/javiermolinar/sancho/internal/db"
 	"github.com/javiermolinar/sancho/internal/task"
@@ -56,7 +57,7 @@ func pathMissing(path string) (bool, error) {
 	return false, err
 }
 
-func openRepo(dbPath string) (task.Repository, error) {
+func openRepo(dbPath string, clk clock.Clock, allowOverlaps bool) (task.Repository, error) {
 	if dbPath == "" {
 		return nil, fmt.Errorf("db path is empty")
 	}
@@ -64,7 +65,7 @@ func openRepo(dbPath string) (task.Repository, error) {
 	if err := os.MkdirAll(dbDir, 0o755); err != nil {
 		return nil, fmt.Errorf("creating data directory: %w", err)
 	}
-	repo, err := db.New(dbPath)
+	repo, err := db.New(dbPath, db.WithClock(clk), db.WithAllowOverlaps(allowOverlaps))
 	if err != nil {
 		return nil, fmt.Errorf("initializing database: %w", err)
 	}
@@ -79,7 +80,7 @@ func (m Model) initializeStorage() (Model, error) {
 	}
 
 	if m.repo == nil {
-		repo, err := openRepo(m.initState.DBPath)
+		repo, err := openRepo(m.initState.DBPath, m.clock, m.config.Schedule.AllowOverlaps)
 		if err != nil {
 			return m, err
 		}
@@ -10,9 +10,13 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/javiermolinar/sancho/internal/debuglog"
+	"github.com/javiermolinar/sancho/internal/export"
 	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
 	"github.com/javiermolinar/sancho/internal/tui/commands"
 	"github.com/javiermolinar/sancho/internal/tui/input"
+	"github.com/javiermolinar/sancho/internal/tui/view"
 )
 
 // handleKeyMsg handles keyboard input.
@@ -24,6 +28,15 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if msg.String() == "ctrl+c" {
 		return m, tea.Quit
 	}
+	if msg.String() == profileKey {
+		return m.toggleProfile()
+	}
+	if m.kioskInterval > 0 {
+		// The first key stops kiosk mode rather than acting on a view that
+		// is about to change under it
+		m.stopKiosk()
+		return m, nil
+	}
 	// Mode-specific handling
 	switch m.mode {
 	case ModePrompt:
@@ -34,6 +47,8 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleModalKeys(msg)
 	case ModeEdit:
 		return m.handleEditKeys(msg)
+	case ModeVisual:
+		return m.handleVisualKeys(msg)
 	default:
 		return m.handleNormalKeys(msg)
 	}
@@ -41,7 +56,14 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleNormalKeys handles keys in normal mode.
 func (m Model) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	ww := m.slotState.WeekWindow()
+	ww := m.navWeekWindow()
+
+	// Two-key sequences such as "yy"
+	pending := m.pendingKey
+	m.pendingKey = ""
+	if pending == "y" && msg.String() == "y" {
+		return m.handleYankTask()
+	}
 
 	switch msg.String() {
 	case "q":
@@ -52,33 +74,29 @@ func (m Model) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.cursor.Day > 0 {
 			m.cursor.Day--
 		} else {
-			// Move to previous week, Sunday - use cached week if available
+			// Move to the last day of previous week - use cached week if available
 			if ww != nil && ww.HasPrevious() {
-				m.weekStart = m.weekStart.AddDate(0, 0, -7)
 				m.cursor.Day = 6
-				m.loading = true
-				return m, commands.LoadPrevWeek(m.repo, m.weekStart)
+				return m, m.shiftWeek(false)
 			}
 			// Fallback: full reload
 			m.weekStart = m.weekStart.AddDate(0, 0, -7)
 			m.cursor.Day = 6
-			return m, commands.LoadInitialWeeks(m.repo, m.weekStart)
+			return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
 		}
 	case "l", "right":
 		if m.cursor.Day < 6 {
 			m.cursor.Day++
 		} else {
-			// Move to next week, Monday - use cached week if available
+			// Move to the first day of next week - use cached week if available
 			if ww != nil && ww.HasNext() {
-				m.weekStart = m.weekStart.AddDate(0, 0, 7)
 				m.cursor.Day = 0
-				m.loading = true
-				return m, commands.LoadNextWeek(m.repo, m.weekStart)
+				return m, m.shiftWeek(true)
 			}
 			// Fallback: full reload
 			m.weekStart = m.weekStart.AddDate(0, 0, 7)
 			m.cursor.Day = 0
-			return m, commands.LoadInitialWeeks(m.repo, m.weekStart)
+			return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
 		}
 	case "j", "down":
 		m.cursor.Slot = m.nextSlotDown()
@@ -101,22 +119,29 @@ func (m Model) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Week navigation (jump to prev/next week)
 	case "H", "shift+left":
 		if ww != nil && ww.HasPrevious() {
-			m.weekStart = m.weekStart.AddDate(0, 0, -7)
-			m.loading = true
-			return m, commands.LoadPrevWeek(m.repo, m.weekStart)
+			return m, m.shiftWeek(false)
 		}
 		// Fallback: full reload if no cached prev
 		m.weekStart = m.weekStart.AddDate(0, 0, -7)
-		return m, commands.LoadInitialWeeks(m.repo, m.weekStart)
+		return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
 	case "L", "shift+right":
 		if ww != nil && ww.HasNext() {
-			m.weekStart = m.weekStart.AddDate(0, 0, 7)
-			m.loading = true
-			return m, commands.LoadNextWeek(m.repo, m.weekStart)
+			return m, m.shiftWeek(true)
 		}
 		// Fallback: full reload if no cached next
 		m.weekStart = m.weekStart.AddDate(0, 0, 7)
-		return m, commands.LoadInitialWeeks(m.repo, m.weekStart)
+		return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+
+	case "f":
+		return m.toggleFocus()
+	case "z":
+		return m.toggleOffHours()
+
+	// Zoom
+	case "+", "=":
+		return m.handleZoom(-1)
+	case "-":
+		return m.handleZoom(1)
 
 	// Actions
 	case "/":
@@ -138,25 +163,38 @@ func (m Model) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleEnter()
 
 	case "d":
-		return m.handleQuickPostpone()
+		return m.openPostponePicker()
+	case "D":
+		return m.openMovePrompt()
+	case "O":
+		return m.openYearOverview()
+
+	case "v":
+		return m.enterVisualMode()
 
 	// Edit mode entry
 	case "i":
 		m.slotState.EnterEditMode()
 		m.mode = ModeEdit
-		m.statusMsg = "Edit mode: g/s/Space/x to modify, y to move, u to undo, Enter to save, Esc to cancel"
+		m.statusMsg = "Edit mode: g/s/Space/x to modify, y to move, u/ctrl+r to undo/redo, m/' for savepoints, Enter to save, Esc to cancel"
 		return m, nil
 
-	// These operations require edit mode
+	// Copy and paste
 	case "y":
-		m.statusMsg = "Press i to enter edit mode first"
+		m.pendingKey = "y"
 		return m, nil
+	case "P":
+		return m.handlePasteTask()
+	case "b":
+		return m.placeParked()
 
-	case "g":
+	// These operations require edit mode
+
+	case "g", "G":
 		m.statusMsg = "Press i to enter edit mode first"
 		return m, nil
 
-	case "s":
+	case "s", "S":
 		m.statusMsg = "Press i to enter edit mode first"
 		return m, nil
 
@@ -176,7 +214,19 @@ func (m Model) handleNormalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // In edit mode, changes are made in-memory and can be undone.
 // Press Enter to save all changes to DB, Esc to discard.
 func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	ww := m.slotState.WeekWindow()
+	ww := m.navWeekWindow()
+
+	// Two-key sequences: m{a-z} sets a savepoint, '{a-z} restores one
+	pending := m.pendingKey
+	m.pendingKey = ""
+	if pending == "m" || pending == "'" {
+		return m.handleSavepointKey(pending, msg)
+	}
+
+	count, consumed := m.readCount(msg)
+	if consumed {
+		return m, nil
+	}
 
 	switch msg.String() {
 	case "q":
@@ -185,25 +235,22 @@ func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.statusMsg = "Unsaved changes! Press Enter to save or Esc to discard"
 			return m, nil
 		}
-		return m, tea.Quit
+		return m, tea.Sequence(m.clearAutosave(), tea.Quit)
 
 	// Save changes
 	case "enter":
-		ctx := context.Background()
-		if err := m.slotState.SaveChanges(ctx, m.repo); err != nil {
-			m.statusMsg = fmt.Sprintf("Error saving: %v", err)
-			return m, nil
-		}
-		m.mode = ModeNormal
-		m.statusMsg = "Changes saved"
-		return m, commands.LoadWeek(m.repo, m.weekStart) // Reload to sync with DB
+		return m.saveEdits()
+
+	// Preview what saving would change
+	case "P":
+		return m.openEditPreview()
 
 	// Discard changes
 	case "esc":
 		m.slotState.DiscardChanges()
 		m.mode = ModeNormal
 		m.statusMsg = "Changes discarded"
-		return m, nil
+		return m, m.clearAutosave()
 
 	// Undo last operation
 	case "u":
@@ -215,6 +262,7 @@ func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.statusMsg = fmt.Sprintf("Error: %v", err)
 			return m, nil
 		}
+		m.markCacheDirty()
 		remaining := m.slotState.UndoCount()
 		if remaining > 0 {
 			m.statusMsg = fmt.Sprintf("Undone (%d more available)", remaining)
@@ -223,24 +271,39 @@ func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	// Redo last undone operation
+	case "ctrl+r":
+		if !m.slotState.CanRedo() {
+			m.statusMsg = "Nothing to redo"
+			return m, nil
+		}
+		if err := m.slotState.Redo(); err != nil {
+			m.statusMsg = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		m.markCacheDirty()
+		remaining := m.slotState.RedoCount()
+		if remaining > 0 {
+			m.statusMsg = fmt.Sprintf("Redone (%d more available)", remaining)
+		} else {
+			m.statusMsg = "Redone (no more changes)"
+		}
+		return m, nil
+
 	// Navigation
 	case "h", "left":
 		if m.cursor.Day > 0 {
 			m.cursor.Day--
 		} else if ww != nil && ww.HasPrevious() {
-			m.weekStart = m.weekStart.AddDate(0, 0, -7)
 			m.cursor.Day = 6
-			m.loading = true
-			return m, commands.LoadPrevWeek(m.repo, m.weekStart)
+			return m, m.shiftWeek(false)
 		}
 	case "l", "right":
 		if m.cursor.Day < 6 {
 			m.cursor.Day++
 		} else if ww != nil && ww.HasNext() {
-			m.weekStart = m.weekStart.AddDate(0, 0, 7)
 			m.cursor.Day = 0
-			m.loading = true
-			return m, commands.LoadNextWeek(m.repo, m.weekStart)
+			return m, m.shiftWeek(true)
 		}
 	case "j", "down":
 		m.cursor.Slot = m.nextSlotDown()
@@ -262,10 +325,16 @@ func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Edit operations
 	case "g":
-		return m.handleGrow()
+		return m.handleGrow(count)
 
 	case "s":
-		return m.handleShrink()
+		return m.handleShrink(count)
+
+	case "G":
+		return m.handleGrowStart(count)
+
+	case "S":
+		return m.handleShrinkStart(count)
 
 	case " ":
 		return m.handleSpace()
@@ -275,11 +344,42 @@ func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "x":
 		return m.handleRemoveSpace()
+
+	// Savepoints
+	case "m", "'":
+		m.pendingKey = msg.String()
+		return m, nil
 	}
 
 	return m, nil
 }
 
+// saveEdits writes the edit session to the repository and leaves edit mode.
+func (m Model) saveEdits() (tea.Model, tea.Cmd) {
+	ctx := context.Background()
+	pending := m.slotState.PendingChanges()
+	done := debuglog.Span("db.save_changes")
+	err := m.slotState.SaveChanges(ctx, m.repo)
+	done()
+	if err != nil {
+		var conflict *SaveConflictError
+		if errors.As(err, &conflict) {
+			cleanup := m.clearAutosave()
+			updated, cmd := m.openSaveConflict(conflict)
+			return updated, tea.Batch(cleanup, cmd)
+		}
+		m.statusMsg = fmt.Sprintf("Error saving: %v", err)
+		return m, nil
+	}
+	moved := make([]*task.Task, len(pending))
+	for i, c := range pending {
+		moved[i] = c.After
+	}
+	m.mode = ModeNormal
+	m.statusMsg = "Changes saved" + m.dependencyWarning(ctx, moved...)
+	return m, tea.Batch(m.clearAutosave(), commands.LoadWeek(m.repo, m.weekStart)) // Reload to sync with DB
+}
+
 // handlePromptKeys handles keys in prompt mode.
 func (m Model) handlePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -289,6 +389,17 @@ func (m Model) handlePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.prompt.SetValue("")
 		m.calculateLayout()
 		m.layoutCache = m.buildLayoutCache(m.width, m.height)
+		if m.planAmending {
+			// Return to the draft being amended
+			m.planAmending = false
+			m.planResult = m.planPrevious
+			m.planPrevious = nil
+			if m.planResult != nil {
+				m.mode = ModeModal
+				m.modalType = ModalPlanResult
+				m.statusMsg = ""
+			}
+		}
 		return m, nil
 
 	case "enter":
@@ -320,6 +431,10 @@ func (m Model) handlePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleMoveKeys handles keys in move mode.
 // Uses the new direction-based SlotStateManager for moves.
 func (m Model) handleMoveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	count, consumed := m.readCount(msg)
+	if consumed {
+		return m, nil
+	}
 	switch msg.String() {
 	case "esc":
 		LogModeChange(m.mode, ModeEdit, "move_cancelled")
@@ -333,8 +448,8 @@ func (m Model) handleMoveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.confirmMove()
 
 	// Direction-based moves using SlotStateManager
-	case "k", "up":
-		if err := m.slotState.MoveUp(); err != nil {
+	case "k", "up", "K":
+		if err := m.slotState.MoveUpBy(m.moveStep(msg.String() == "K"), count); err != nil {
 			LogError("MoveUp", err)
 			return m, nil
 		}
@@ -344,8 +459,8 @@ func (m Model) handleMoveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.markCacheDirty()
 		return m, nil
 
-	case "j", "down":
-		if err := m.slotState.MoveDown(); err != nil {
+	case "j", "down", "J":
+		if err := m.slotState.MoveDownBy(m.moveStep(msg.String() == "J"), count); err != nil {
 			LogError("MoveDown", err)
 			return m, nil
 		}
@@ -381,6 +496,20 @@ func (m Model) handleMoveKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// moveStep returns how many 15-minute slots j/k, or J/K when coarse, move
+// a task into free time in move mode. Fine steps default to one grid row
+// and coarse steps to an hour.
+func (m Model) moveStep(coarse bool) int {
+	minutes := m.config.UI.MoveStepMinutes
+	if coarse {
+		minutes = m.config.UI.CoarseMoveStepMinutes
+		if minutes == 0 {
+			minutes = 60
+		}
+	}
+	return minutes / DefaultSlotDuration
+}
+
 // handleModalKeys handles keys in modal mode.
 func (m Model) handleModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.modalType {
@@ -388,12 +517,38 @@ func (m Model) handleModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleTaskFormKeys(msg)
 	case ModalTaskDetail:
 		return m.handleTaskDetailKeys(msg)
+	case ModalTaskHistory:
+		return m.handleTaskHistoryKeys(msg)
 	case ModalConfirmDelete:
 		return m.handleConfirmDeleteKeys(msg)
 	case ModalPlanResult:
 		return m.handlePlanResultKeys(msg)
 	case ModalWeekSummary:
 		return m.handleWeekSummaryKeys(msg)
+	case ModalReflection:
+		return m.handleReflectionKeys(msg)
+	case ModalLLMLog:
+		return m.handleLLMLogKeys(msg)
+	case ModalBacklog:
+		return m.handleBacklogKeys(msg)
+	case ModalYear:
+		return m.handleYearKeys(msg)
+	case ModalPostpone:
+		return m.handlePostponeKeys(msg)
+	case ModalMissed:
+		return m.handleMissedKeys(msg)
+	case ModalReview:
+		return m.handleReviewKeys(msg)
+	case ModalSaveConflict:
+		return m.handleSaveConflictKeys(msg)
+	case ModalEditPreview:
+		return m.handleEditPreviewKeys(msg)
+	case ModalRestoreEdits:
+		return m.handleRestoreEditsKeys(msg)
+	case ModalObjectives:
+		return m.handleObjectivesKeys(msg)
+	case ModalSyncConflict:
+		return m.handleSyncConflictKeys(msg)
 	case ModalInit:
 		return m.handleInitKeys(msg)
 	default:
@@ -422,7 +577,7 @@ func (m Model) handleInitKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		updated.modalType = ModalNone
 		updated.statusMsg = "Initialized config and database"
 		updated.loading = true
-		return updated, commands.LoadInitialWeeks(updated.repo, updated.weekStart)
+		return updated, updated.startupCmd()
 
 	case "esc", "n":
 		return m, tea.Quit
@@ -520,9 +675,30 @@ func (m Model) handleTaskDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m.cycleOutcome()
 		}
 
+	case "n":
+		// Cycle energy
+		if m.modalTask != nil {
+			return m.cycleEnergy()
+		}
+
+	case "tab":
+		if m.modalTask != nil {
+			return m.openTaskHistory()
+		}
+
+	case "Y":
+		if m.modalTask != nil {
+			if err := clipboard.WriteAll(view.BuildTaskCopyText(m.modalTask)); err != nil {
+				m.statusMsg = fmt.Sprintf("Copy failed: %v", err)
+				return m, nil
+			}
+			m.statusMsg = fmt.Sprintf("Copied: %s", m.modalTask.Description)
+			return m, nil
+		}
+
 	case "e":
 		if m.modalTask != nil {
-			if m.modalTask.IsPast() {
+			if m.isTaskPast(m.modalTask) {
 				m.statusMsg = "Cannot edit past tasks"
 				return m, nil
 			}
@@ -535,12 +711,12 @@ func (m Model) handleTaskDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "x":
 		// Open delete confirmation
-		if m.modalTask != nil && !m.modalTask.IsPast() {
+		if m.modalTask != nil && !m.isTaskPast(m.modalTask) {
 			m.modalType = ModalConfirmDelete
 			m.confirmMessage = fmt.Sprintf("Cancel task: %s?", m.modalTask.Description)
 			return m, nil
 		}
-		if m.modalTask != nil && m.modalTask.IsPast() {
+		if m.modalTask != nil && m.isTaskPast(m.modalTask) {
 			m.statusMsg = "Cannot cancel past tasks"
 		}
 	}
@@ -564,15 +740,22 @@ func (m Model) handleConfirmDeleteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.modalTask != nil {
 			// Delete the task
 			ctx := context.Background()
-			if err := m.repo.CancelTask(ctx, m.modalTask.ID); err != nil {
+			var push tea.Cmd
+			err := m.repo.CancelTask(ctx, m.modalTask.ID, m.modalTask.Version)
+			if errors.Is(err, task.ErrTaskChanged) {
+				return m.handleModalTaskChanged()
+			}
+			if err != nil {
 				m.statusMsg = fmt.Sprintf("Error: %v", err)
 			} else {
 				m.statusMsg = fmt.Sprintf("Cancelled: %s", m.modalTask.Description)
+				push = m.pushAction(m.modalTask, tasksync.ActionCancel, "")
 			}
+			reload := m.reloadDays(m.modalTask.ScheduledDate)
 			m.modalTask = nil
 			m.mode = ModeNormal
 			m.modalType = ModalNone
-			return m, commands.LoadWeek(m.repo, m.weekStart)
+			return m, tea.Batch(reload, push)
 		}
 	}
 	return m, nil
@@ -580,12 +763,22 @@ func (m Model) handleConfirmDeleteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handlePlanResultKeys handles keys in plan result modal.
 func (m Model) handlePlanResultKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.planStream != nil {
+		if msg.String() == "esc" || msg.String() == "c" {
+			m.planStream.Cancel()
+			m.stopPlanStream()
+			m.statusMsg = "Planning cancelled"
+		}
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "esc", "c":
 		// Cancel planning
 		m.mode = ModeNormal
 		m.modalType = ModalNone
 		m.planResult = nil
+		m.planPrevious = nil
 		m.planner = nil
 		m.statusMsg = "Planning cancelled"
 		return m, nil
@@ -601,8 +794,24 @@ func (m Model) handlePlanResultKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, commands.SavePlan(m.planner, m.planResult)
 
+	case "f":
+		// Ask the model to resend the entries dropped from a malformed reply
+		if m.planResult == nil || len(m.planResult.Dropped) == 0 || m.planner == nil || !m.planner.HasSession() {
+			return m, nil
+		}
+		stream, cmd := commands.FixPlan(m.planner)
+		m.planPrevious = m.planResult
+		m.planStream = stream
+		m.planStreamText = ""
+		m.planAttempt = 0
+		m.planResult = nil
+		m.statusMsg = ""
+		return m, tea.Batch(cmd, m.planSpinner.Tick)
+
 	case "m":
-		// Amend - ask for feedback
+		// Amend - ask for feedback; the current draft is kept for the diff view
+		m.planAmending = true
+		m.planPrevious = m.planResult
 		m.mode = ModePrompt
 		m.modalType = ModalNone
 		m.prompt.SetValue("")
@@ -622,18 +831,23 @@ func (m Model) saveTaskFromForm() (tea.Model, tea.Cmd) {
 	}
 
 	if m.modalTask != nil {
-		if m.modalTask.IsPast() {
+		if m.isTaskPast(m.modalTask) {
 			m.statusMsg = "Cannot edit past tasks"
 			return m, nil
 		}
 
 		ctx := context.Background()
-		if err := m.repo.UpdateTaskDescription(ctx, m.modalTask.ID, desc); err != nil {
+		err := m.repo.UpdateTaskDescription(ctx, m.modalTask.ID, desc, m.modalTask.Version)
+		if errors.Is(err, task.ErrTaskChanged) {
+			return m.handleModalTaskChanged()
+		}
+		if err != nil {
 			m.statusMsg = fmt.Sprintf("Error: %v", err)
 			return m, nil
 		}
 
 		m.modalTask.Description = desc
+		reload := m.reloadDays(m.modalTask.ScheduledDate)
 		m.formDesc.SetValue("")
 		m.formDesc.Blur()
 		m.formFocus = 0
@@ -641,7 +855,7 @@ func (m Model) saveTaskFromForm() (tea.Model, tea.Cmd) {
 		m.mode = ModeNormal
 		m.modalType = ModalNone
 		m.statusMsg = fmt.Sprintf("Updated: %s", desc)
-		return m, commands.LoadWeek(m.repo, m.weekStart)
+		return m, reload
 	}
 
 	// Calculate task times
@@ -652,8 +866,8 @@ func (m Model) saveTaskFromForm() (tea.Model, tea.Cmd) {
 
 	// Determine category
 	category := task.CategoryDeep
-	if m.formCategory == 1 {
-		category = task.CategoryShallow
+	if categories := task.Categories(); m.formCategory < len(categories) {
+		category = categories[m.formCategory].Name
 	}
 
 	// Create the task
@@ -671,6 +885,7 @@ func (m Model) saveTaskFromForm() (tea.Model, tea.Cmd) {
 		m.statusMsg = fmt.Sprintf("Error: %v", err)
 		return m, nil
 	}
+	status := fmt.Sprintf("Created: %s%s%s%s", desc, m.reserveBuffer(ctx, newTask), m.capacityWarning(ctx, newTask), m.estimateHint(ctx, newTask))
 
 	// Clear form and close modal
 	m.formDesc.SetValue("")
@@ -680,9 +895,28 @@ func (m Model) saveTaskFromForm() (tea.Model, tea.Cmd) {
 	m.formFocus = 0
 	m.mode = ModeNormal
 	m.modalType = ModalNone
-	m.statusMsg = fmt.Sprintf("Created: %s", desc)
+	m.statusMsg = status
+
+	return m, m.reloadDays(newTask.ScheduledDate)
+}
 
-	return m, commands.LoadWeek(m.repo, m.weekStart)
+// reserveBuffer keeps the configured buffer free after a new task and
+// returns a note for the status line, e.g. " (+10m buffer, shifted 2)".
+func (m *Model) reserveBuffer(ctx context.Context, t *task.Task) string {
+	buffer := m.config.Schedule.BufferMinutes
+	if buffer <= 0 {
+		return ""
+	}
+	moved, ok, err := task.ReserveBuffer(ctx, m.repo, t.ID, t.ScheduledDate, buffer)
+	switch {
+	case err != nil:
+		return fmt.Sprintf(" (buffer: %v)", err)
+	case !ok:
+		return fmt.Sprintf(" (no room for %dm buffer)", buffer)
+	case moved > 0:
+		return fmt.Sprintf(" (+%dm buffer, shifted %d)", buffer, moved)
+	}
+	return ""
 }
 
 // cycleOutcome cycles through task outcomes.
@@ -708,14 +942,56 @@ func (m Model) cycleOutcome() (tea.Model, tea.Cmd) {
 	}
 
 	ctx := context.Background()
-	if err := m.repo.SetTaskOutcome(ctx, m.modalTask.ID, newOutcome); err != nil {
+	err := m.repo.SetTaskOutcome(ctx, m.modalTask.ID, newOutcome, m.modalTask.Version)
+	if errors.Is(err, task.ErrTaskChanged) {
+		return m.handleModalTaskChanged()
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.adoptVersion(ctx, m.modalTask)
+	// An on-time task took its block; over and under say nothing of how long
+	actual := 0
+	if newOutcome == task.OutcomeOnTime {
+		actual = m.modalTask.Duration()
+	}
+	if err := task.RecordActual(ctx, m.repo, m.modalTask, actual); err != nil {
 		m.statusMsg = fmt.Sprintf("Error: %v", err)
 		return m, nil
 	}
 
 	m.modalTask.Outcome = &newOutcome
 	m.statusMsg = fmt.Sprintf("Outcome: %s", newOutcome)
-	return m, commands.LoadWeek(m.repo, m.weekStart)
+	return m, tea.Batch(m.reloadDays(m.modalTask.ScheduledDate), m.pushAction(m.modalTask, tasksync.ActionComplete, newOutcome))
+}
+
+// cycleEnergy cycles the energy level of the task in the detail modal.
+func (m Model) cycleEnergy() (tea.Model, tea.Cmd) {
+	if m.modalTask == nil {
+		return m, nil
+	}
+
+	// Cycle: unset -> high -> medium -> low -> unset
+	newEnergy := m.modalTask.Energy.Next()
+	ctx := context.Background()
+	err := m.repo.SetTaskEnergy(ctx, m.modalTask.ID, newEnergy, m.modalTask.Version)
+	if errors.Is(err, task.ErrTaskChanged) {
+		return m.handleModalTaskChanged()
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.adoptVersion(ctx, m.modalTask)
+
+	m.modalTask.Energy = newEnergy
+	if newEnergy == "" {
+		m.statusMsg = "Energy: not set"
+	} else {
+		m.statusMsg = fmt.Sprintf("Energy: %s", newEnergy)
+	}
+	return m, m.reloadDays(m.modalTask.ScheduledDate)
 }
 
 // handleEnter handles Enter key press.
@@ -735,10 +1011,7 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	}
 
 	// Task exists - open detail popup
-	m.mode = ModeModal
-	m.modalType = ModalTaskDetail
-	m.modalTask = t
-	return m, nil
+	return m, m.openTaskDetail(t)
 }
 
 // handleYank enters move mode.
@@ -749,7 +1022,7 @@ func (m Model) handleYank() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	if t.IsPast() {
+	if m.isTaskPast(t) {
 		m.statusMsg = "Cannot move past tasks"
 		return m, nil
 	}
@@ -768,7 +1041,7 @@ func (m Model) handleYank() (tea.Model, tea.Cmd) {
 	m.mode = ModeMove
 	m.moveOriginalDay = m.cursor.Day
 	m.moveOriginalSlot = m.cursor.Slot
-	m.statusMsg = fmt.Sprintf("Moving: %s (jk to move up/down, l to next day, Enter to confirm, Esc to cancel)", t.Description)
+	m.statusMsg = fmt.Sprintf("Moving: %s (jk to move up/down, JK by more, l to next day, Enter to confirm, Esc to cancel)", t.Description)
 	m.markCacheDirty()
 	return m, nil
 }
@@ -800,74 +1073,97 @@ func (m Model) confirmMove() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleQuickPostpone postpones to next working day.
-func (m Model) handleQuickPostpone() (tea.Model, tea.Cmd) {
+// handleGrow grows task by count times 15 minutes (edit mode only).
+func (m Model) handleGrow(count int) (tea.Model, tea.Cmd) {
 	t := m.taskAtCursor()
 	if t == nil {
-		m.statusMsg = "No task to postpone"
+		m.statusMsg = "No task to grow"
 		return m, nil
 	}
 
-	if t.IsPast() {
-		m.statusMsg = "Cannot postpone past tasks"
+	if m.isTaskPast(t) {
+		m.statusMsg = "Cannot modify past tasks"
 		return m, nil
 	}
 
-	// Find next working day
-	nextDay := t.ScheduledDate.AddDate(0, 0, 1)
-	for !m.isWorkday(nextDay) {
-		nextDay = nextDay.AddDate(0, 0, 1)
+	// Use SlotStateManager for grow
+	if err := m.slotState.GrowBy(t, count); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
 	}
 
-	ctx := context.Background()
-	_, err := m.repo.PostponeTask(ctx, t.ID, nextDay, t.ScheduledStart, t.ScheduledEnd)
-	if err != nil {
-		return m, func() tea.Msg { return commands.ErrMsg{Err: err} }
+	m.statusMsg = fmt.Sprintf("Grew: %s", t.Description)
+	m.markCacheDirty()
+	return m, nil
+}
+
+// handleShrink shrinks task by count times 15 minutes (edit mode only).
+func (m Model) handleShrink(count int) (tea.Model, tea.Cmd) {
+	t := m.taskAtCursor()
+	if t == nil {
+		m.statusMsg = "No task to shrink"
+		return m, nil
 	}
 
-	m.statusMsg = fmt.Sprintf("Postponed to %s", nextDay.Format("Mon Jan 2"))
-	return m, commands.LoadWeek(m.repo, m.weekStart)
+	if m.isTaskPast(t) {
+		m.statusMsg = "Cannot modify past tasks"
+		return m, nil
+	}
+
+	// Use SlotStateManager for shrink
+	if err := m.slotState.ShrinkBy(t, count); err != nil {
+		if errors.Is(err, ErrMinimumSlotsDuration) {
+			m.statusMsg = "Cannot shrink below 15 minutes"
+		} else {
+			m.statusMsg = fmt.Sprintf("Error: %v", err)
+		}
+		return m, nil
+	}
+
+	m.statusMsg = fmt.Sprintf("Shrunk: %s", t.Description)
+	m.focusCursorOnTaskEnd(t)
+	m.markCacheDirty()
+	return m, nil
 }
 
-// handleGrow grows task by 15 minutes (edit mode only).
-func (m Model) handleGrow() (tea.Model, tea.Cmd) {
+// handleGrowStart starts task count times 15 minutes earlier (edit mode only).
+func (m Model) handleGrowStart(count int) (tea.Model, tea.Cmd) {
 	t := m.taskAtCursor()
 	if t == nil {
 		m.statusMsg = "No task to grow"
 		return m, nil
 	}
 
-	if t.IsPast() {
+	if m.isTaskPast(t) {
 		m.statusMsg = "Cannot modify past tasks"
 		return m, nil
 	}
 
-	// Use SlotStateManager for grow
-	if err := m.slotState.Grow(t); err != nil {
+	if err := m.slotState.GrowStartBy(t, count); err != nil {
 		m.statusMsg = fmt.Sprintf("Error: %v", err)
 		return m, nil
 	}
 
-	m.statusMsg = fmt.Sprintf("Grew: %s", t.Description)
+	m.statusMsg = fmt.Sprintf("Starts earlier: %s", t.Description)
+	m.focusCursorOnTaskStart(t)
 	m.markCacheDirty()
 	return m, nil
 }
 
-// handleShrink shrinks task by 15 minutes (edit mode only).
-func (m Model) handleShrink() (tea.Model, tea.Cmd) {
+// handleShrinkStart starts task count times 15 minutes later (edit mode only).
+func (m Model) handleShrinkStart(count int) (tea.Model, tea.Cmd) {
 	t := m.taskAtCursor()
 	if t == nil {
 		m.statusMsg = "No task to shrink"
 		return m, nil
 	}
 
-	if t.IsPast() {
+	if m.isTaskPast(t) {
 		m.statusMsg = "Cannot modify past tasks"
 		return m, nil
 	}
 
-	// Use SlotStateManager for shrink
-	if err := m.slotState.Shrink(t); err != nil {
+	if err := m.slotState.ShrinkStartBy(t, count); err != nil {
 		if errors.Is(err, ErrMinimumSlotsDuration) {
 			m.statusMsg = "Cannot shrink below 15 minutes"
 		} else {
@@ -876,8 +1172,8 @@ func (m Model) handleShrink() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	m.statusMsg = fmt.Sprintf("Shrunk: %s", t.Description)
-	m.focusCursorOnTaskEnd(t)
+	m.statusMsg = fmt.Sprintf("Starts later: %s", t.Description)
+	m.focusCursorOnTaskStart(t)
 	m.markCacheDirty()
 	return m, nil
 }
@@ -886,7 +1182,7 @@ func (m Model) handleShrink() (tea.Model, tea.Cmd) {
 func (m Model) handleSpace() (tea.Model, tea.Cmd) {
 	t := m.taskAtCursor()
 	if t == nil {
-		dayIndex := WeekAndDayToDayIndex(1, m.cursor.Day)
+		dayIndex := WeekAndDayToDayIndex(m.slotState.Config().CurrentWeek(), m.cursor.Day)
 		slot := m.displaySlotToSlot(m.cursor.Slot)
 		if err := m.slotState.AddSpaceAt(dayIndex, slot); err != nil {
 			m.statusMsg = fmt.Sprintf("Error: %v", err)
@@ -916,7 +1212,7 @@ func (m Model) handleRemoveSpace() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	dayIndex := WeekAndDayToDayIndex(1, m.cursor.Day)
+	dayIndex := WeekAndDayToDayIndex(m.slotState.Config().CurrentWeek(), m.cursor.Day)
 	slot := m.displaySlotToSlot(m.cursor.Slot)
 	if err := m.slotState.RemoveSpaceAt(dayIndex, slot); err != nil {
 		if errors.Is(err, ErrNoGapToRemove) {
@@ -939,9 +1235,20 @@ func (m Model) handleRemoveSpace() (tea.Model, tea.Cmd) {
 // handlePromptSubmit processes the submitted prompt.
 func (m Model) handlePromptSubmit(value string) (tea.Model, tea.Cmd) {
 	if value == "" {
+		if m.planAmending {
+			m.planAmending = false
+			m.planPrevious = nil
+		}
 		return m, nil
 	}
 
+	if m.planAmending && !strings.HasPrefix(value, "/") {
+		m.planAmending = false
+		return m.startAmend(value)
+	}
+	m.planAmending = false
+	m.planPrevious = nil
+
 	if strings.HasPrefix(value, "/") {
 		fields := strings.Fields(value)
 		if len(fields) == 0 {
@@ -955,17 +1262,59 @@ func (m Model) handlePromptSubmit(value string) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.planInput = input
-			m.statusMsg = "Planning..."
-			return m, commands.Plan(input, m.config, m.repo)
+			m.planAuto = false
+			return m.startPlan(input)
+		case "/auto":
+			input := strings.TrimSpace(strings.TrimPrefix(value, "/auto"))
+			if input == "" {
+				m.statusMsg = "Auto requires input, e.g. /auto write report 2h; email 30m shallow"
+				return m, nil
+			}
+			m.planInput = input
+			m.planAuto = true
+			m.statusMsg = "Scheduling..."
+			return m, commands.AutoPlan(input, m.config, m.repo, m.clock)
+		case "/add":
+			return m.handleQuickAdd(strings.TrimPrefix(value, "/add"))
+		case "/move":
+			return m.handleMoveCommand(fields[1:])
+		case "/paste-meeting":
+			return m.handlePasteMeeting()
+		case "/postpone-rest":
+			return m.handlePostponeRest()
+		case "/export":
+			return m.handleExportCommand(fields[1:])
+		case "/snapshot":
+			return m.handleSnapshotCommand()
+		case "/import":
+			return m.handleImportCommand(fields[1:])
+		case "/backlog":
+			return m.openBacklog()
+		case "/park":
+			return m.handleParkCommand(fields[1:])
+		case "/allday":
+			return m.handleAllDayCommand(fields[1:])
+		case "/review":
+			return m.handleReviewCommand(fields[1:])
 		case "/help":
-			m.statusMsg = "Commands: /plan, /week, /help, /reflect"
+			m.statusMsg = "Commands: /plan, /auto, /add, /move, /paste-meeting, /postpone-rest, /import, /backlog, /park, /allday, /review, /export, /snapshot, /week, /year, /objectives, /reflect, /sandbox, /sync, /llm-log, /help"
 			return m, nil
 		case "/reflect":
-			m.statusMsg = "Reflect is not implemented yet"
-			return m, nil
+			m.statusMsg = "Reflecting..."
+			return m, commands.Reflect(m.config, m.repo, m.now())
+		case "/llm-log":
+			return m.openLLMLog()
+		case "/year":
+			return m.openYearOverview()
+		case "/objectives":
+			return m.openObjectives()
+		case "/sandbox":
+			return m.handleSandboxCommand(fields[1:])
+		case "/sync":
+			return m.handleSyncCommand(fields[1:])
 		case "/week":
 			m.statusMsg = "Summarizing..."
-			return m, commands.WeekSummary(m.config, m.repo, m.weekStart)
+			return m, commands.WeekSummary(m.config, m.repo, m.weekStart, m.now())
 		default:
 			m.statusMsg = fmt.Sprintf("Unknown command: %s", fields[0])
 			return m, nil
@@ -973,8 +1322,72 @@ func (m Model) handlePromptSubmit(value string) (tea.Model, tea.Cmd) {
 	}
 
 	m.planInput = value
-	m.statusMsg = "Planning..."
-	return m, commands.Plan(value, m.config, m.repo)
+	m.planAuto = false
+	return m.startPlan(value)
+}
+
+// startPlan opens the plan modal and starts streaming a plan for input.
+func (m Model) startPlan(input string) (tea.Model, tea.Cmd) {
+	stream, cmd := commands.Plan(input, m.config, m.repo, m.clock)
+	m.planStream = stream
+	m.planStreamText = ""
+	m.planAttempt = 0
+	m.planResult = nil
+	m.planner = nil
+	m.mode = ModeModal
+	m.modalType = ModalPlanResult
+	m.statusMsg = ""
+	return m, tea.Batch(cmd, m.planSpinner.Tick)
+}
+
+// startAmend revises the current draft with feedback and reopens the plan
+// modal, which shows a diff against the previous draft once the new one
+// arrives. Drafts without an LLM session are replanned from the original
+// input plus the feedback: /auto drafts by the rule-based scheduler, others
+// by the LLM.
+func (m Model) startAmend(feedback string) (tea.Model, tea.Cmd) {
+	previous := m.planPrevious
+	if m.planner == nil || !m.planner.HasSession() {
+		input := strings.TrimSpace(m.planInput + "\n" + feedback)
+		m.planInput = input
+		if m.planAuto {
+			m.statusMsg = "Scheduling..."
+			return m, commands.AutoPlan(input, m.config, m.repo, m.clock)
+		}
+		planner := m.planner
+		next, cmd := m.startPlan(input)
+		nm := next.(Model)
+		nm.planPrevious = previous
+		nm.planner = planner // Keeps the previous draft saveable if the amend is cancelled
+		return nm, cmd
+	}
+
+	stream, cmd := commands.AmendPlan(m.planner, feedback)
+	m.planStream = stream
+	m.planStreamText = ""
+	m.planAttempt = 0
+	m.planResult = nil
+	m.mode = ModeModal
+	m.modalType = ModalPlanResult
+	m.statusMsg = ""
+	return m, tea.Batch(cmd, m.planSpinner.Tick)
+}
+
+// stopPlanStream closes the streaming plan modal without a result.
+// The caller is responsible for cancelling the stream if it is still running.
+func (m *Model) stopPlanStream() {
+	m.planStream = nil
+	m.planStreamText = ""
+	if m.planResult == nil && m.planPrevious != nil {
+		// An amend was interrupted; fall back to the previous draft
+		m.planResult = m.planPrevious
+		m.planPrevious = nil
+		return
+	}
+	if m.modalType == ModalPlanResult && m.planResult == nil {
+		m.mode = ModeNormal
+		m.modalType = ModalNone
+	}
 }
 
 func (m Model) handleWeekSummaryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -996,6 +1409,17 @@ func (m Model) handleWeekSummaryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.statusMsg = "Copied week tasks"
 		return m, nil
+	case "o":
+		if m.weekSummary == nil || len(m.weekSummary.Tasks) == 0 {
+			m.statusMsg = "No tasks to export"
+			return m, nil
+		}
+		if err := clipboard.WriteAll(export.Org(m.weekSummary.Start, m.weekSummary.Tasks)); err != nil {
+			m.statusMsg = fmt.Sprintf("Copy failed: %v", err)
+			return m, nil
+		}
+		m.statusMsg = "Copied week as org-mode"
+		return m, nil
 	case "esc", "enter":
 		m.mode = ModeNormal
 		m.modalType = ModalNone
@@ -1007,3 +1431,23 @@ func (m Model) handleWeekSummaryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 	return m, nil
 }
+
+func (m Model) handleReflectionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y":
+		if err := clipboard.WriteAll(m.reflectionCopyText); err != nil {
+			m.statusMsg = fmt.Sprintf("Copy failed: %v", err)
+			return m, nil
+		}
+		m.statusMsg = "Copied reflection"
+		return m, nil
+	case "esc", "enter":
+		m.mode = ModeNormal
+		m.modalType = ModalNone
+		m.reflection = nil
+		m.reflectionText = nil
+		m.reflectionCopyText = ""
+		return m, nil
+	}
+	return m, nil
+}
@@ -27,3 +27,25 @@ func TestHandleTaskFormKeys_AllowsTypingH(t *testing.T) {
 		t.Fatalf("value = %q, want %q", got, "h")
 	}
 }
+
+func TestReadCount(t *testing.T) {
+	var m Model
+	press := func(key string) (int, bool) {
+		return m.readCount(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+
+	if _, consumed := press("0"); consumed {
+		t.Error("a leading 0 should not start a count")
+	}
+	for _, key := range []string{"1", "2"} {
+		if _, consumed := press(key); !consumed {
+			t.Fatalf("digit %q not consumed", key)
+		}
+	}
+	if count, consumed := press("g"); consumed || count != 12 {
+		t.Errorf("press g = (%d, %v), want (12, false)", count, consumed)
+	}
+	if count, _ := press("g"); count != 1 {
+		t.Errorf("count after use = %d, want 1", count)
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// kioskView is one of the screens kiosk mode cycles through.
+type kioskView int
+
+const (
+	kioskToday kioskView = iota // Today's column alone, as in focus mode
+	kioskWeek                   // The whole current week
+	kioskStats                  // The week summary
+	kioskViewCount
+)
+
+// handleKioskTick moves kiosk mode on to its next view and waits for the
+// next tick. Ticks left over after kiosk mode stopped do nothing.
+func (m Model) handleKioskTick() (tea.Model, tea.Cmd) {
+	if m.kioskInterval <= 0 {
+		return m, nil
+	}
+	m.kioskView = (m.kioskView + 1) % kioskViewCount
+	cmd := m.showKioskView()
+	return m, tea.Batch(cmd, commands.KioskTick(m.kioskInterval))
+}
+
+// showKioskView shows the current kiosk view on the week holding now, with
+// the cursor on the current task or time.
+func (m *Model) showKioskView() tea.Cmd {
+	if m.mode == ModeModal && m.modalType == ModalWeekSummary {
+		m.mode = ModeNormal
+		m.modalType = ModalNone
+	}
+
+	now := m.now()
+	var cmds []tea.Cmd
+	if weekStart := startOfWeek(now); !weekStart.Equal(m.weekStart) {
+		m.weekStart = weekStart
+		cmds = append(cmds, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius))
+	}
+	m.focusCursorOnCurrentTaskOrTime()
+	m.focusDate = time.Time{}
+	switch m.kioskView {
+	case kioskToday:
+		m.focusDate = m.cursorDate()
+		m.statusMsg = "Kiosk: today (any key to stop)"
+	case kioskWeek:
+		m.statusMsg = "Kiosk: this week (any key to stop)"
+	case kioskStats:
+		m.statusMsg = "Kiosk: week stats (any key to stop)"
+		cmds = append(cmds, commands.WeekSummary(m.config, m.repo, m.weekStart, now))
+	}
+	m.resizeColumns()
+	return tea.Batch(cmds...)
+}
+
+// stopKiosk leaves kiosk mode on the view it was showing.
+func (m *Model) stopKiosk() {
+	m.kioskInterval = 0
+	m.statusMsg = "Kiosk mode off"
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestKioskCycles(t *testing.T) {
+	repo := memrepo.New()
+	wednesday := time.Date(2025, 3, 12, 10, 40, 0, 0, time.Local)
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(wednesday)), WithKiosk(30*time.Second))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+	if m.focusDate.IsZero() || m.cursor.Day != 2 {
+		t.Fatalf("kiosk starts on focus %v, day %d, want today alone", m.focusDate, m.cursor.Day)
+	}
+
+	updated, _ = m.Update(commands.KioskTickMsg{})
+	m = updated.(Model)
+	if m.kioskView != kioskWeek || !m.focusDate.IsZero() {
+		t.Fatalf("after one tick: view %d, focus %v, want the whole week", m.kioskView, m.focusDate)
+	}
+
+	updated, _ = m.Update(commands.KioskTickMsg{})
+	m = updated.(Model)
+	if m.kioskView != kioskStats {
+		t.Fatalf("after two ticks: view %d, want the stats", m.kioskView)
+	}
+	updated, _ = m.Update(commands.WeekSummary(m.config, repo, m.weekStart, wednesday)())
+	m = updated.(Model)
+	if m.modalType != ModalWeekSummary {
+		t.Fatalf("modal = %v, want the week summary", m.modalType)
+	}
+
+	updated, _ = m.Update(commands.KioskTickMsg{})
+	m = updated.(Model)
+	if m.kioskView != kioskToday || m.mode != ModeNormal || m.focusDate.IsZero() {
+		t.Fatalf("after three ticks: view %d, mode %v, want today again", m.kioskView, m.mode)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = updated.(Model)
+	if m.kioskInterval != 0 || m.cursor.Day != 2 {
+		t.Fatalf("key press: interval %v, day %d, want kiosk stopped without moving", m.kioskInterval, m.cursor.Day)
+	}
+	if _, cmd := m.Update(commands.KioskTickMsg{}); cmd != nil {
+		t.Error("tick after stopping kiosk mode scheduled another")
+	}
+}
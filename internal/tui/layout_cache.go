@@ -35,6 +35,7 @@ func promptContentWidth(styles *Styles, innerW int) int {
 }
 
 func (m Model) buildLayoutCache(width, height int) LayoutCache {
+	m.profile.rebuild(profileLayout)
 	styles := m.styles
 	appH, appV := styles.AppStyle.GetFrameSize()
 	innerW := width - appH
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// llmLogEntries is how many recent calls /llm-log shows.
+const llmLogEntries = 10
+
+// openLLMLog loads the recent audit entries for the /llm-log modal.
+func (m Model) openLLMLog() (tea.Model, tea.Cmd) {
+	if m.config == nil || !m.config.LLM.Audit.Enabled {
+		m.statusMsg = "LLM audit log is off; set enabled = true under [llm.audit]"
+		return m, nil
+	}
+	m.statusMsg = "Loading LLM log..."
+	return m, commands.LoadLLMLog(m.config.AuditPath(), llmLogEntries)
+}
+
+func (m Model) handleLLMLogMsg(msg commands.LLMLogMsg) (tea.Model, tea.Cmd) {
+	m.llmLogText = view.BuildLLMLogLines(msg.Entries, msg.Path)
+	m.mode = ModeModal
+	m.modalType = ModalLLMLog
+	m.statusMsg = ""
+	return m, nil
+}
+
+func (m Model) handleLLMLogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "q":
+		m.mode = ModeNormal
+		m.modalType = ModalNone
+		m.llmLogText = nil
+	}
+	return m, nil
+}
+
+func (m Model) renderLLMLogModal() string {
+	styleSet := m.modalStyleSet()
+	width := view.ModalContentWidth(m.styles.ModalStyle, weekSummaryFallbackWidth)
+	body := view.RenderWeekSummaryBody(m.llmLogText, styleSet.WeekSummaryStyles(), width)
+	footer := view.LLMLogFooter(m.modalStyles())
+	return view.RenderModalFrame("LLM Log", body, footer, m.modalStyles())
+}
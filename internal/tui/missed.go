@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// missedLookbackDays is how far back the startup check looks for tasks that
+// ended without an outcome. Older tasks are left as they are.
+const missedLookbackDays = 7
+
+// handleMissedTasksMsg reloads the visible week, since its tasks may have
+// changed status. With reschedule_missed set it also offers to reschedule
+// the missed tasks into the next free slots starting today.
+func (m Model) handleMissedTasksMsg(msg commands.MissedTasksMsg) (tea.Model, tea.Cmd) {
+	if len(msg.Tasks) == 0 {
+		return m, nil
+	}
+	dates := make([]time.Time, 0, len(msg.Tasks))
+	for _, t := range msg.Tasks {
+		dates = append(dates, t.ScheduledDate)
+	}
+	reload := m.reloadDays(dates...)
+	if !m.config.Schedule.RescheduleMissed {
+		m.statusMsg = fmt.Sprintf("%d tasks marked missed", len(msg.Tasks))
+		return m, reload
+	}
+
+	now := m.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	updates, _, err := m.cascadeTasks(msg.Tasks, today, now)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, reload
+	}
+	if m.mode == ModeModal {
+		// Do not cover another modal; the tasks stay missed.
+		m.statusMsg = fmt.Sprintf("%d tasks marked missed", len(msg.Tasks))
+		return m, reload
+	}
+
+	m.missedTasks = msg.Tasks
+	m.missedUpdates = updates
+	m.mode = ModeModal
+	m.modalType = ModalMissed
+	return m, reload
+}
+
+func (m Model) handleMissedKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		updates := m.missedUpdates
+		total := len(m.missedTasks)
+		m.closeMissed()
+		if len(updates) == 0 {
+			m.statusMsg = "No free slots to reschedule into"
+			return m, nil
+		}
+		summary := fmt.Sprintf("Rescheduled %d missed tasks", len(updates))
+		if len(updates) < total {
+			summary += fmt.Sprintf("; %d had no free slot", total-len(updates))
+		}
+		return m, commands.BatchUpdate(m.repo, updates, summary)
+	case "esc", "q", "n":
+		m.statusMsg = fmt.Sprintf("%d tasks left missed", len(m.missedTasks))
+		m.closeMissed()
+	}
+	return m, nil
+}
+
+func (m *Model) closeMissed() {
+	m.missedTasks = nil
+	m.missedUpdates = nil
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+}
+
+func (m Model) renderMissedModal() string {
+	slots := make(map[int64]task.TaskUpdate, len(m.missedUpdates))
+	for _, u := range m.missedUpdates {
+		slots[u.ID] = u
+	}
+	rows := make([]view.MissedRow, 0, len(m.missedTasks))
+	for _, t := range m.missedTasks {
+		r := view.MissedRow{Description: t.Description, Date: t.ScheduledDate, Start: t.ScheduledStart}
+		if u, ok := slots[t.ID]; ok {
+			r.NewDate, r.NewStart, r.NewEnd = u.Date, u.Start, u.End
+		}
+		rows = append(rows, r)
+	}
+	styles := view.MissedStyles{
+		BodyStyle: m.styles.ModalBodyStyle,
+		MetaStyle: m.styles.ModalMetaStyle,
+	}
+	body := view.RenderMissedBody(rows, styles)
+	footer := view.MissedFooter(m.modalStyles())
+	return view.RenderModalFrame("Missed tasks", body, footer, m.modalStyles())
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestMissedTasksRescheduledOnConfirm(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	friday := time.Date(2025, 3, 14, 0, 0, 0, 0, time.Local)
+	thursday := friday.AddDate(0, 0, -1)
+	create := func(desc string, date time.Time, start, end string) *task.Task {
+		tk := &task.Task{Description: desc, Category: task.CategoryDeep, ScheduledDate: date,
+			ScheduledStart: start, ScheduledEnd: end, Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+		return tk
+	}
+	report := create("Report", thursday, "10:00", "11:00")
+	reviewed := create("Reviewed", thursday, "14:00", "15:00")
+	if err := repo.SetTaskOutcome(ctx, reviewed.ID, task.OutcomeOnTime, 0); err != nil {
+		t.Fatalf("SetTaskOutcome: %v", err)
+	}
+	create("Standup", friday, "10:00", "11:00")
+
+	cfg := config.Default()
+	cfg.Schedule.RescheduleMissed = true
+	now := friday.Add(9*time.Hour + 30*time.Minute)
+	m := *New(repo, cfg, WithClock(clock.Fixed(now)))
+
+	msg := commands.MarkMissed(repo, now.AddDate(0, 0, -missedLookbackDays), now)()
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	if m.modalType != ModalMissed || len(m.missedTasks) != 1 {
+		t.Fatalf("modal %v with %d tasks, want the missed modal with the report", m.modalType, len(m.missedTasks))
+	}
+	if got, _ := repo.GetTask(ctx, report.ID); !got.IsMissed() {
+		t.Fatalf("report status = %s, want missed", got.Status)
+	}
+	if u := m.missedUpdates[0]; !u.Date.Equal(friday) || u.Start != "11:00" {
+		t.Errorf("proposed %s %s, want today's first free slot after the standup", u.Date.Format("Mon"), u.Start)
+	}
+
+	updated, cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.mode != ModeNormal || cmd == nil {
+		t.Fatal("enter should close the modal and reschedule")
+	}
+	if res := cmd(); res == nil {
+		t.Fatal("reschedule command returned nothing")
+	} else if _, ok := res.(commands.BatchUpdatedMsg); !ok {
+		t.Fatalf("got %T, want a batch update", res)
+	}
+	today, err := repo.ListTasksByDateRange(ctx, friday, friday)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(today) != 2 || today[1].ScheduledStart != "11:00" || today[1].PostponedFrom == nil {
+		t.Errorf("today = %+v, want the report copied to 11:00", today)
+	}
+}
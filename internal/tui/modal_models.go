@@ -1,7 +1,11 @@
 // Package tui provides the terminal user interface for sancho.
 package tui
 
-import "github.com/javiermolinar/sancho/internal/tui/view"
+import (
+	"github.com/javiermolinar/sancho/internal/dwplanner"
+	"github.com/javiermolinar/sancho/internal/llm"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
 
 type taskFormModalViewModel struct {
 	Title  string
@@ -23,7 +27,7 @@ func (m Model) taskFormModalViewModel() taskFormModalViewModel {
 		startTime = m.modalTask.ScheduledStart
 		endTime = m.modalTask.ScheduledEnd
 		duration = m.modalTask.Duration()
-		if m.modalTask.IsPast() {
+		if m.isTaskPast(m.modalTask) {
 			nameLocked = true
 			nameValue = m.modalTask.Description
 		}
@@ -80,10 +84,23 @@ func (m Model) taskDetailModalViewModel() (taskDetailModalViewModel, bool) {
 		return taskDetailModalViewModel{}, false
 	}
 	styleSet := m.modalStyleSet()
+	model := view.NewTaskDetailModel(m.modalTask)
+	model.Warning = m.energyWarning(m.modalTask)
+	if links := m.modalLinks; links.TaskID == m.modalTask.ID {
+		t := m.modalTask
+		for _, b := range links.Links.BlockedBy {
+			outOfOrder := t.IsScheduled() && b.IsScheduled() && t.StartsBeforeEndOf(b)
+			model.BlockedBy = append(model.BlockedBy, view.NewTaskLink(b, outOfOrder))
+		}
+		for _, d := range links.Links.Blocks {
+			outOfOrder := t.IsScheduled() && d.IsScheduled() && d.StartsBeforeEndOf(t)
+			model.Blocks = append(model.Blocks, view.NewTaskLink(d, outOfOrder))
+		}
+	}
 	return taskDetailModalViewModel{
-		Model:  view.NewTaskDetailModel(m.modalTask),
+		Model:  model,
 		Styles: styleSet.TaskDetailStyles(),
-		IsPast: m.modalTask.IsPast(),
+		IsPast: m.isTaskPast(m.modalTask),
 	}, true
 }
 
@@ -100,22 +117,57 @@ func (m Model) confirmDeleteModalViewModel() confirmDeleteModalViewModel {
 	}
 }
 
+const planDiffFallbackWidth = 60
+
 type planResultModalViewModel struct {
 	Model               view.PlanResultModel
 	Styles              view.PlanResultStyles
 	HasValidationErrors bool
+	CanFix              bool // Some entries were dropped and the model can be asked to fix them
+	Streaming           bool
+}
+
+// streamedPlanTasks returns the tasks fully received so far in a streamed plan.
+func streamedPlanTasks(content string) []dwplanner.PlannedTask {
+	partial := llm.PartialPlanTasks(content)
+	tasks := make([]dwplanner.PlannedTask, 0, len(partial))
+	for _, pt := range partial {
+		tasks = append(tasks, dwplanner.PlannedTask{
+			Description:    pt.Description,
+			Category:       pt.Category,
+			ScheduledDate:  pt.ScheduledDate,
+			ScheduledStart: pt.ScheduledStart,
+			ScheduledEnd:   pt.ScheduledEnd,
+		})
+	}
+	return tasks
 }
 
 func (m Model) planResultModalViewModel() (planResultModalViewModel, bool) {
+	if m.planStream != nil {
+		styleSet := m.modalStyleSet()
+		return planResultModalViewModel{
+			Model:     view.NewStreamingPlanResultModel(streamedPlanTasks(m.planStreamText), m.planSpinner.View()),
+			Styles:    styleSet.PlanResultStyles(),
+			Streaming: true,
+		}, true
+	}
 	if m.planResult == nil {
 		return planResultModalViewModel{}, false
 	}
 
+	model := view.NewPlanResultModel(m.planResult)
+	if m.planPrevious != nil {
+		model.Changes, model.ChangeSummary = view.BuildPlanDiff(dwplanner.DiffPlans(m.planPrevious, m.planResult))
+		model.Width = view.ModalContentWidth(m.styles.ModalStyle, planDiffFallbackWidth)
+	}
+
 	styleSet := m.modalStyleSet()
 	return planResultModalViewModel{
-		Model:               view.NewPlanResultModel(m.planResult),
+		Model:               model,
 		Styles:              styleSet.PlanResultStyles(),
 		HasValidationErrors: m.planResult.HasValidationErrors(),
+		CanFix:              len(m.planResult.Dropped) > 0 && m.planner != nil && m.planner.HasSession(),
 	}, true
 }
 
@@ -190,3 +242,15 @@ func (m Model) weekSummaryBody() string {
 	vm := m.weekSummaryBodyViewModel()
 	return view.RenderWeekSummaryBody(vm.Lines, vm.Styles, vm.Width)
 }
+
+// renderReflectionModal renders the /reflect modal.
+func (m Model) renderReflectionModal() string {
+	if m.reflection == nil {
+		return ""
+	}
+	styleSet := m.modalStyleSet()
+	width := view.ModalContentWidth(m.styles.ModalStyle, weekSummaryFallbackWidth)
+	body := view.RenderWeekSummaryBody(m.reflectionText, styleSet.WeekSummaryStyles(), width)
+	footer := view.ReflectionFooter(m.modalStyles())
+	return view.RenderModalFrame("Reflection", body, footer, m.modalStyles())
+}
@@ -10,6 +10,8 @@ func (m Model) renderModal() string {
 		return m.renderTaskFormModal()
 	case ModalTaskDetail:
 		return m.renderTaskDetailModal()
+	case ModalTaskHistory:
+		return m.renderTaskHistoryModal()
 	case ModalConfirmDelete:
 		return m.renderConfirmDeleteModal()
 	case ModalPlanResult:
@@ -18,6 +20,30 @@ func (m Model) renderModal() string {
 		return m.renderWeekSummaryModal()
 	case ModalInit:
 		return m.renderInitModal()
+	case ModalReflection:
+		return m.renderReflectionModal()
+	case ModalSyncConflict:
+		return m.renderSyncConflictModal()
+	case ModalLLMLog:
+		return m.renderLLMLogModal()
+	case ModalBacklog:
+		return m.renderBacklogModal()
+	case ModalYear:
+		return m.renderYearModal()
+	case ModalPostpone:
+		return m.renderPostponeModal()
+	case ModalMissed:
+		return m.renderMissedModal()
+	case ModalReview:
+		return m.renderReviewModal()
+	case ModalSaveConflict:
+		return m.renderSaveConflictModal()
+	case ModalEditPreview:
+		return m.renderEditPreviewModal()
+	case ModalRestoreEdits:
+		return m.renderRestoreEditsModal()
+	case ModalObjectives:
+		return m.renderObjectivesModal()
 	default:
 		return ""
 	}
@@ -69,7 +95,10 @@ func (m Model) renderPlanResultModal() string {
 		return ""
 	}
 	body := view.RenderPlanResultBody(vm.Model, vm.Styles)
-	footer := view.PlanResultFooter(vm.HasValidationErrors, m.modalStyles())
+	footer := view.PlanResultFooter(vm.HasValidationErrors, vm.CanFix, m.modalStyles())
+	if vm.Streaming {
+		footer = view.PlanStreamingFooter(m.modalStyles())
+	}
 	return view.RenderModalFrame("LLM Draft", body, footer, m.modalStyles())
 }
 
@@ -2,15 +2,24 @@
 package tui
 
 import (
+	"context"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/javiermolinar/sancho/internal/autosave"
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/dateutil"
 	"github.com/javiermolinar/sancho/internal/dwplanner"
+	"github.com/javiermolinar/sancho/internal/replica"
+	"github.com/javiermolinar/sancho/internal/sandbox"
+	"github.com/javiermolinar/sancho/internal/startup"
 	"github.com/javiermolinar/sancho/internal/summary"
 	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
 	"github.com/javiermolinar/sancho/internal/tui/commands"
 	"github.com/javiermolinar/sancho/internal/tui/theme"
 	"github.com/javiermolinar/sancho/internal/tui/view"
@@ -25,6 +34,7 @@ const (
 	ModeMove        // Moving a task (can be within edit mode)
 	ModePrompt
 	ModeModal
+	ModeVisual // Selecting slots or tasks for a batch action
 )
 
 // ModalType identifies the type of modal.
@@ -38,6 +48,19 @@ const (
 	ModalPlanResult // Show LLM planning results
 	ModalWeekSummary
 	ModalInit
+	ModalReflection   // LLM review of the last week
+	ModalSyncConflict // Task changed locally and remotely since the last sync
+	ModalLLMLog       // Recent LLM prompts and responses from the audit log
+	ModalYear         // One row per week of the year
+	ModalPostpone     // Pick the day to postpone the cursor task to
+	ModalMissed       // Reschedule tasks marked missed on startup
+	ModalTaskHistory  // Earlier versions of the task shown in the detail modal
+	ModalBacklog      // Unscheduled work, such as imported issues
+	ModalReview       // Step through past tasks giving each an outcome
+	ModalSaveConflict // Edits that overlapped tasks changed by another process
+	ModalEditPreview  // What saving the edit session would change
+	ModalRestoreEdits // Offer to restore an autosaved edit session
+	ModalObjectives   // Quarterly objectives and the hours spent on them
 )
 
 type weekSummaryView int
@@ -52,7 +75,7 @@ var durationOptions = []int{12, 30, 60}
 
 // Position represents a cursor position in the grid.
 type Position struct {
-	Day  int // 0=Monday, 6=Sunday
+	Day  int // Column in the week, 0 = first weekday
 	Slot int // Row index in grid (based on rowHeight)
 }
 
@@ -65,35 +88,75 @@ type Model struct {
 	// Theme and styles
 	theme  *theme.Theme
 	styles *Styles
+	locale dateutil.Locale // Day and month names in the header
 
 	// State manager (slot-based)
 	slotState *SlotStateManager
 
+	// Weeks loaded just beyond the window, by start date (weekKey); nil
+	// while loading. Navigation shifts them in without waiting.
+	prefetched map[string]*task.Week
+
 	// State
-	weekStart time.Time // Monday of current week
-	cursor    Position  // Current cursor position
-	mode      Mode
-	loading   bool // True when loading week data
+	weekStart  time.Time // First day of current week
+	weekRadius int       // Weeks kept loaded on each side of weekStart
+	cursor     Position  // Current cursor position
+	mode       Mode
+	loading    bool // True when loading week data
+
+	visibleOnly bool // Only the current week is loaded; adjacent weeks are on their way
+
+	pendingKey   string     // First key of a two-key sequence (e.g. "y" of "yy")
+	pendingCount int        // Count typed before an edit or move key (e.g. 4 of "4g")
+	yanked       *task.Task // Task copied with yy, pasted with P
 
 	// Move mode (minimal state for UI - SlotStateManager owns the move session)
 	moveOriginalDay  int // Original day index of moving task (for view logic)
 	moveOriginalSlot int // Original slot of moving task (for view logic)
 
+	// Visual mode: the block between visualAnchor and the cursor is selected
+	// (while visualBlock is set), plus any tasks picked individually
+	visualAnchor Position
+	visualBlock  bool
+	visualPicked map[int64]bool
+
 	// Modal state
-	modalType      ModalType       // Current modal type
-	modalTask      *task.Task      // Task being viewed/edited (nil for new)
-	formDesc       textinput.Model // Description input
-	formCategory   int             // 0=deep, 1=shallow
-	formDuration   int             // Index into durationOptions
-	formFocus      int             // Which field is focused (0=desc, 1=duration)
-	confirmMessage string          // Message for confirm modal
-	initState      InitState       // Startup initialization state
-	initError      string          // Initialization error for modal display
+	modalType      ModalType             // Current modal type
+	modalTask      *task.Task            // Task being viewed/edited (nil for new)
+	modalLinks     commands.TaskLinksMsg // Dependencies of the task in the detail modal
+	formDesc       textinput.Model       // Description input
+	formCategory   int                   // Index into task.Categories(), 0=deep
+	formDuration   int                   // Index into durationOptions
+	formFocus      int                   // Which field is focused (0=desc, 1=duration)
+	confirmMessage string                // Message for confirm modal
+	initState      InitState             // Startup initialization state
+	initError      string                // Initialization error for modal display
 
 	// Planning state
 	planner    *dwplanner.Planner    // LLM planner (created on demand)
 	planResult *dwplanner.PlanResult // Current planning result
 	planInput  string                // Original plan input (for modify)
+	planAuto   bool                  // Draft came from /auto, not the LLM
+
+	clock clock.Clock // Source of "now" for the grid, planner, and storage
+
+	focusTask *task.Task // Task to open once the first weeks load (deep links)
+	focusDate time.Time  // Day shown alone in focus mode (f); zero when off
+
+	// Kiosk mode: the views cycled through every kioskInterval without
+	// input; zero when off
+	kioskInterval time.Duration
+	kioskView     kioskView
+
+	// Amend state
+	planAmending bool                  // Prompt is collecting amend feedback
+	planPrevious *dwplanner.PlanResult // Draft being amended (for the diff view)
+
+	// Streaming plan state
+	planStream     *commands.PlanStream // In-flight plan request (nil when idle)
+	planStreamText string               // LLM output received for the current attempt
+	planAttempt    int                  // Attempt the streamed text belongs to
+	planSpinner    spinner.Model
 
 	// Overlay state
 	overlay OverlayModel
@@ -105,25 +168,104 @@ type Model struct {
 	weekSummaryTasksText   []view.WeekSummaryLine
 	weekSummaryCopyText    string
 
+	// Sandbox state: when set, repo is the sandbox and writes stay in memory
+	sandbox *sandbox.Repo
+
+	// Reflection state
+	reflection         *summary.Reflection
+	reflectionText     []view.WeekSummaryLine
+	reflectionCopyText string
+	llmLogText         []view.WeekSummaryLine
+
+	// Year overview state
+	yearOverview int // ISO year shown
+	yearRows     []view.YearWeekRow
+	yearCursor   int
+
+	// Postpone picker state
+	postponeTask    *task.Task
+	postponeOptions []view.PostponeOption
+	postponeCursor  int
+
+	// Task history tab: earlier versions of modalTask, newest first
+	taskHistory   []task.Revision
+	historyCursor int
+
+	// Backlog modal state
+	backlogItems  []*task.BacklogItem
+	backlogCursor int
+
+	// Backlog items parked on a day without a time, by day as "2006-01-02"
+	parked map[string][]*task.BacklogItem
+
+	// Review state: the past tasks stepped through by /review and the
+	// outcome and note given to each; an empty outcome means skipped
+	reviewLabel       string
+	reviewTasks       []*task.Task
+	reviewOutcomes    []task.Outcome
+	reviewNotes       []string
+	reviewNotesBefore map[int64]string // Notes as loaded, to save only changes; nil when notes are not stored
+	reviewCursor      int
+	reviewNoting      bool // Typing the note of the task under the cursor
+	reviewNote        textinput.Model
+
+	// Missed tasks found on startup and the slots proposed for them
+	missedTasks   []*task.Task
+	missedUpdates []task.TaskUpdate
+
+	// Edits left unsaved because they conflicted with changes made elsewhere
+	saveConflict *SaveConflictError
+
+	// Objectives of the quarter shown in the objectives panel
+	objectivesQuarter string
+	objectives        []task.ObjectiveProgress
+
+	// Changes listed by the edit preview; edit mode resumes on close
+	previewChanges []PendingChange
+
+	// Autosave of edit sessions: the file (empty to disable), whether this
+	// session wrote it, a session found at startup and one being restored
+	autosavePath  string
+	autosaved     bool
+	autosaveFound *autosave.Session
+	restoreOnLoad *autosave.Session
+
+	// Sync state: conflicts waiting for resolution, first one shown
+	syncer            *tasksync.Scheduler // Background sync (nil when no source is configured)
+	syncConflicts     []*tasksync.Conflict
+	syncConflictField int                 // Selected field in the conflict modal
+	replica           *replica.Replicator // Database replication (nil when no replica is configured)
+	dataVersion       string              // Last seen repository data version, to notice other writers
+
 	// Components
 	prompt textinput.Model
 
 	// Terminal dimensions and layout
-	width        int
-	height       int
-	rowHeight    int // Minutes per slot (15, 30, or 60)
-	rowLines     int // Terminal lines per slot (1, 2, or 3)
-	colWidth     int // Dynamic column width based on terminal width
-	scrollOffset int // For scrolling the grid
+	width             int
+	height            int
+	rowHeight         int  // Minutes per slot (15, 30, or 60)
+	slotMinutes       int  // Zoom level chosen with +/- (rowHeight follows it)
+	offHoursCollapsed bool // Grid limited to working hours, with bands for tasks outside (z toggles)
+	rowLines          int  // Terminal lines per slot (1, 2, or 3)
+	colWidth          int  // Dynamic column width based on terminal width
+	scrollOffset      int  // For scrolling the grid
+
+	dayWidths     [7]int  // Width of each day column, sized by its tasks; zero falls back to colWidth
+	collapsedDays [7]bool // Empty days off drawn narrow or hidden by the weekends setting
 
 	// Cached render data
 	styleCache       StyleCache
 	layoutCache      LayoutCache
 	renderCache      RenderCache
+	cellCache        *CellCache // Styled day cells kept between frames
 	gridCache        [7][]*task.Task
+	overlapCache     [7][]*task.Task // Nil unless the grid holds overlapping tasks
 	cachedShadeMap   map[int]map[int64]bool
 	cachedTaskLines  map[int64][]string
+	cachedClock      clockState
+	cachedBanners    [7][]*task.Task // All-day tasks of each day of the visible week
 	cacheNeedsUpdate bool
+	profile          *renderProfile // Render timings shown in the footer (F12)
 
 	// Messages
 	statusMsg  string    // Temporary status/error message
@@ -147,6 +289,28 @@ func WithInitState(state InitState) ModelOption {
 	}
 }
 
+// WithClock sets the clock used for "now" throughout the TUI.
+func WithClock(c clock.Clock) ModelOption {
+	return func(m *Model) {
+		m.clock = c
+	}
+}
+
+// WithFocusTask opens the TUI on the week of t with its detail modal shown.
+func WithFocusTask(t *task.Task) ModelOption {
+	return func(m *Model) {
+		m.focusTask = t
+	}
+}
+
+// WithKiosk starts the TUI in kiosk mode, moving between today, the week and
+// its stats every interval, for a wall-mounted display. A key press stops it.
+func WithKiosk(interval time.Duration) ModelOption {
+	return func(m *Model) {
+		m.kioskInterval = interval
+	}
+}
+
 // New creates a new TUI model.
 func New(repo task.Repository, cfg *config.Config, opts ...ModelOption) *Model {
 	ti := textinput.New()
@@ -174,38 +338,75 @@ func New(repo task.Repository, cfg *config.Config, opts ...ModelOption) *Model {
 	formDesc.Cursor.Style = styles.ModalInputCursorStyle
 	formDesc.Cursor.TextStyle = styles.ModalInputTextStyle
 
-	// Create new slot-based state manager
-	// Use current week start as the middle of the 3-week window
-	weekStart := startOfWeek(time.Now())
-	defaultRowHeight := 60 // Default to 60-min blocks until layout calculated
-	slotConfig := SlotGridConfigFromWeekWindow(nil, cfg.Schedule.DayStart, cfg.Schedule.DayEnd, time.Now, defaultRowHeight)
-	slotState := NewSlotStateManager(slotConfig)
+	reviewNote := textinput.New()
+	reviewNote.Placeholder = "What happened?"
+	reviewNote.CharLimit = 256
+	reviewNote.Width = 50
+	reviewNote.Prompt = ""
+	reviewNote.PlaceholderStyle = styles.ModalPlaceholderStyle
+	reviewNote.TextStyle = styles.ModalInputTextStyle
+	reviewNote.Cursor.Style = styles.ModalInputCursorStyle
+	reviewNote.Cursor.TextStyle = styles.ModalInputTextStyle
 
 	m := &Model{
 		repo:             repo,
 		config:           cfg,
+		clock:            clock.System,
 		theme:            t,
 		styles:           styles,
-		slotState:        slotState,
-		weekStart:        weekStart,
-		cursor:           Position{Day: weekdayIndex(time.Now()), Slot: 0},
 		mode:             ModeNormal,
 		prompt:           ti,
 		formDesc:         formDesc,
+		reviewNote:       reviewNote,
 		formCategory:     0, // Default to deep
 		formDuration:     1, // Default to 30 min (index 1)
 		overlay:          NewOverlayModel(),
+		planSpinner:      spinner.New(spinner.WithSpinner(spinner.Dot)),
 		rowHeight:        15, // Default to 15min slots
 		rowLines:         1,  // Default to 1 line per slot
 		colWidth:         defaultColWidth,
 		styleCache:       NewStyleCache(styles, defaultColWidth),
+		cellCache:        NewCellCache(),
+		profile:          &renderProfile{},
 		cacheNeedsUpdate: true,
 	}
-	m.layoutCache = m.buildLayoutCache(0, 0)
-
 	for _, opt := range opts {
 		opt(m)
 	}
+	m.clock = clock.OrSystem(m.clock)
+	m.slotMinutes = zoomLevels[0]
+	if cfg != nil && cfg.UI.SlotMinutes > 0 {
+		m.slotMinutes = cfg.UI.SlotMinutes
+	}
+	m.rowHeight = m.slotMinutes
+	m.offHoursCollapsed = cfg != nil && cfg.UI.CollapseOffHours
+	if cfg != nil && cfg.Storage.DBPath != "" {
+		m.autosavePath = cfg.EditAutosavePath()
+	}
+	m.locale, _ = dateutil.LookupLocale(cfg.UI.Locale)
+	m.weekRadius = 1
+	if cfg != nil {
+		m.weekRadius = cfg.UI.WindowRadius()
+	}
+
+	// Create new slot-based state manager
+	// Use current week start as the middle of the week window
+	now := m.clock.Now()
+	defaultRowHeight := 60 // Default to 60-min blocks until layout calculated
+	slotConfig := SlotGridConfigFromWeekWindow(nil, cfg.Schedule.DayStart, cfg.Schedule.DayEnd, m.clock.Now, defaultRowHeight)
+	slotConfig.Location = cfg.LocationFor
+	slotConfig.SplitOwner = cfg.UI.SplitOwner
+	m.slotState = NewSlotStateManager(slotConfig)
+	m.weekStart = startOfWeek(now)
+	m.cursor = Position{Day: weekdayIndex(now), Slot: 0}
+	if m.focusTask != nil {
+		m.weekStart = startOfWeek(m.focusTask.ScheduledDate)
+		m.cursor.Day = weekdayIndex(m.focusTask.ScheduledDate)
+	}
+	if m.kioskInterval > 0 {
+		m.focusDate = m.cursorDate()
+	}
+	m.layoutCache = m.buildLayoutCache(0, 0)
 
 	return m
 }
@@ -215,17 +416,40 @@ func (m Model) Init() tea.Cmd {
 	if m.initState.NeedsInit {
 		return nil
 	}
-	return commands.LoadInitialWeeks(m.repo, m.weekStart)
+	return m.startupCmd()
+}
+
+// startupCmd loads the visible week first. Adjacent weeks and background sync
+// start once it has arrived; the planner and stats are built on first use.
+// Alongside, tasks from earlier days that ended without an outcome are
+// marked missed.
+func (m Model) startupCmd() tea.Cmd {
+	now := m.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	cmds := []tea.Cmd{
+		commands.LoadVisibleWeek(m.repo, m.weekStart, m.weekRadius),
+		commands.NowTick(now),
+		commands.MarkMissed(m.repo, today.AddDate(0, 0, -missedLookbackDays), today),
+		commands.LoadParked(m.repo),
+	}
+	if m.autosavePath != "" {
+		cmds = append(cmds, commands.LoadAutosave(m.autosavePath), commands.AutosaveTick(autosaveInterval))
+	}
+	if m.kioskInterval > 0 {
+		cmds = append(cmds, commands.KioskTick(m.kioskInterval))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Run starts the TUI.
 func Run(repo task.Repository, cfg *config.Config) error {
-	return RunWithDebug(repo, cfg, false)
+	return RunWithDebug(repo, cfg, "")
 }
 
-// RunWithDebug starts the TUI with optional debug logging.
-func RunWithDebug(repo task.Repository, cfg *config.Config, debug bool) error {
-	if err := InitDebugLogger(debug); err != nil {
+// RunWithDebug starts the TUI, logging at debugLevel when it is not empty.
+// Options such as WithClock are applied to the model.
+func RunWithDebug(repo task.Repository, cfg *config.Config, debugLevel string, opts ...ModelOption) error {
+	if err := InitDebugLogger(debugLevel); err != nil {
 		return err
 	}
 	defer CloseDebugLogger()
@@ -233,27 +457,56 @@ func RunWithDebug(repo task.Repository, cfg *config.Config, debug bool) error {
 	initialRepo := repo
 	var initState InitState
 
+	seedExample := false
+
 	if repo == nil {
 		state, err := DetectInitState(cfg)
 		if err != nil {
 			return err
 		}
 		initState = state
-		if !state.NeedsInit {
-			repo, err = openRepo(state.DBPath)
-			if err != nil {
+	}
+
+	// On first run, ask for the basics instead of starting from defaults.
+	// The wizard saves the config, so only a missing database is left to
+	// create and it gets an example day.
+	if initState.ConfigMissing {
+		chosen, ok, err := runOnboarding(cfg, initState.ConfigPath)
+		if err != nil || !ok {
+			return err
+		}
+		*cfg = *chosen
+		seedExample = initState.DBMissing
+		initState = InitState{ConfigPath: initState.ConfigPath, DBPath: cfg.Storage.DBPath}
+	}
+
+	model := New(repo, cfg, append([]ModelOption{WithInitState(initState)}, opts...)...)
+	startup.Mark("model")
+	if repo == nil && !initState.NeedsInit {
+		repo, err := openRepo(initState.DBPath, model.clock, cfg.Schedule.AllowOverlaps)
+		if err != nil {
+			return err
+		}
+		if seedExample {
+			if err := seedExampleDay(context.Background(), repo, cfg, model.now()); err != nil {
+				_ = repo.Close()
 				return err
 			}
 		}
+		model.repo = repo
+		startup.Mark("database")
 	}
-
-	model := New(repo, cfg, WithInitState(initState))
 	model.layoutCache = model.buildLayoutCache(0, 0)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	guard := newCrashGuard(*model)
+	p := tea.NewProgram(guard, tea.WithAltScreen())
+	guard.state.program = p
 	finalModel, err := p.Run()
-	if initialRepo == nil {
-		if m, ok := finalModel.(Model); ok && m.repo != nil {
-			_ = m.repo.Close()
+	if g, ok := finalModel.(crashGuard); ok {
+		if m, ok := g.model.(Model); ok && initialRepo == nil && m.persistentRepo() != nil {
+			_ = m.persistentRepo().Close()
+		}
+		if g.state.err != nil {
+			return g.state.err
 		}
 	}
 	return err
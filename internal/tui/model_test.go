@@ -3,7 +3,9 @@ package tui
 
 import (
 	"testing"
+	"time"
 
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/config"
 )
 
@@ -29,3 +31,27 @@ func TestNewModel_AppliesModalInputStyles(t *testing.T) {
 		t.Errorf("Cursor text style mismatch: got %q, want %q", got, want)
 	}
 }
+
+func TestNewModel_WithClock(t *testing.T) {
+	cfg := &config.Config{
+		Schedule: config.ScheduleConfig{
+			DayStart: "09:00",
+			DayEnd:   "17:00",
+		},
+	}
+
+	// Thursday 2025-01-09
+	now := time.Date(2025, 1, 9, 11, 0, 0, 0, time.Local)
+	m := New(nil, cfg, WithClock(clock.Fixed(now)))
+
+	wantWeek := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	if !m.weekStart.Equal(wantWeek) {
+		t.Errorf("weekStart = %v, want %v", m.weekStart, wantWeek)
+	}
+	if m.cursor.Day != 3 {
+		t.Errorf("cursor.Day = %d, want 3", m.cursor.Day)
+	}
+	if got := m.now(); !got.Equal(now) {
+		t.Errorf("now() = %v, want %v", got, now)
+	}
+}
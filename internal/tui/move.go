@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/nlp"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+const moveUsage = "Usage: /move <day> [time], e.g. /move 2025-02-10 14:00 or /move friday"
+
+// openMovePrompt opens the prompt prefilled with /move for the task under
+// the cursor.
+func (m Model) openMovePrompt() (tea.Model, tea.Cmd) {
+	if m.taskAtCursor() == nil {
+		m.statusMsg = "No task to move"
+		return m, nil
+	}
+	m.mode = ModePrompt
+	m.prompt.SetValue("/move ")
+	m.prompt.Focus()
+	m.calculateLayout()
+	m.layoutCache = m.buildLayoutCache(m.width, m.height)
+	return m, textinput.Blink
+}
+
+// handleMoveCommand handles "/move <day> [time]". It reschedules the task
+// under the cursor in place to any day, keeping its duration. Without a time
+// the task keeps its start time.
+func (m Model) handleMoveCommand(args []string) (tea.Model, tea.Cmd) {
+	t := m.taskAtCursor()
+	if t == nil {
+		m.statusMsg = "No task to move"
+		return m, nil
+	}
+	if m.isTaskPast(t) {
+		m.statusMsg = "Cannot move past tasks"
+		return m, nil
+	}
+	if len(args) == 0 || len(args) > 2 {
+		m.statusMsg = moveUsage
+		return m, nil
+	}
+
+	date, err := nlp.ParseDay(args[0], m.now())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	start := t.ScheduledStart
+	if len(args) == 2 {
+		var ok bool
+		if start, ok = nlp.ParseClock(args[1]); !ok {
+			m.statusMsg = fmt.Sprintf("Invalid time %q. %s", args[1], moveUsage)
+			return m, nil
+		}
+	}
+	end := task.MinutesToTime((task.TimeToMinutes(start) + t.Duration()) % (24 * 60))
+	if _, err := task.New(t.Description, string(t.Category), date.Format("2006-01-02"), start, end, m.now()); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+
+	ctx := context.Background()
+	update := task.TaskUpdate{ID: t.ID, Date: date, Start: start, End: end, Version: t.Version}
+	err = m.repo.BatchUpdate(ctx, []task.TaskUpdate{update})
+	if errors.Is(err, task.ErrTaskChanged) {
+		return m.handleTaskChanged()
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	moved := *t
+	moved.ScheduledDate = date
+	m.statusMsg = fmt.Sprintf("Moved %s to %s %s-%s%s", t.Description, date.Format("Mon Jan 2"), start, end, m.capacityWarning(ctx, &moved)+m.dependencyWarning(ctx, &moved))
+	return m, m.reloadDays(t.ScheduledDate, date)
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestHandleMoveCommand(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local) // Monday
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	review := &task.Task{
+		Description:    "Review",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  monday,
+		ScheduledStart: "10:00",
+		ScheduledEnd:   "11:30",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(ctx, review); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	cfg := config.Default()
+	m := *New(repo, cfg, WithClock(clock.Fixed(now)))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+	updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, task.NewWeekFromTasks(monday, []*task.Task{review}), nil)})
+	m = updated.(Model)
+	m.cursor.Day = 0
+	m.cursor.Slot = (10*60 - m.dayStartMinutes()) / m.rowHeight
+
+	for _, args := range [][]string{nil, {"someday"}, {"friday", "25:00"}} {
+		updated, cmd := m.handleMoveCommand(args)
+		if cmd != nil || updated.(Model).statusMsg == "" {
+			t.Errorf("handleMoveCommand(%q) should fail with a message", args)
+		}
+	}
+
+	updated, cmd := m.handleMoveCommand([]string{"2025-04-02", "2pm"})
+	if cmd == nil {
+		t.Fatalf("move failed: %s", updated.(Model).statusMsg)
+	}
+	got, err := repo.GetTask(ctx, review.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if d := got.ScheduledDate.Format("2006-01-02"); d != "2025-04-02" || got.ScheduledStart != "14:00" || got.ScheduledEnd != "15:30" {
+		t.Errorf("moved to %s %s-%s, want 2025-04-02 14:00-15:30", d, got.ScheduledStart, got.ScheduledEnd)
+	}
+	if got.Status != task.StatusScheduled {
+		t.Errorf("status = %s, want the task moved in place", got.Status)
+	}
+	// Moving a prerequisite past the task it blocks warns but still moves
+	publish := &task.Task{
+		Description:    "Publish",
+		Category:       task.CategoryShallow,
+		ScheduledDate:  time.Date(2025, 4, 3, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(ctx, publish); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if err := task.AddDependency(ctx, repo, publish.ID, review.ID); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	updated, cmd = m.handleMoveCommand([]string{"2025-04-03", "10:00"})
+	if cmd == nil {
+		t.Fatalf("move failed: %s", updated.(Model).statusMsg)
+	}
+	if msg, want := updated.(Model).statusMsg, "(out of order: Publish starts before Review ends)"; !strings.Contains(msg, want) {
+		t.Errorf("status = %q, want it to contain %q", msg, want)
+	}
+}
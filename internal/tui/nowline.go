@@ -0,0 +1,42 @@
+package tui
+
+import "strings"
+
+// nowMarker starts the current-time line in today's column.
+const nowMarker = "▶"
+
+// nowLine returns the grid cell and the line within it that hold the current
+// time. ok is false when today is not in the displayed week or the current
+// time is outside the visible day.
+func (m *Model) nowLine() (day, slot, line int, ok bool) {
+	if m.rowHeight <= 0 || m.rowLines <= 0 {
+		return 0, 0, 0, false
+	}
+	for d := 0; d < 7; d++ {
+		date := m.weekStart.AddDate(0, 0, d)
+		now := m.now().In(m.locationFor(date))
+		if !sameDay(date, now) {
+			continue
+		}
+		mins := now.Hour()*60 + now.Minute() - m.dayStartMinutes()
+		if mins < 0 || mins/m.rowHeight >= m.maxSlots() {
+			return 0, 0, 0, false
+		}
+		return d, mins / m.rowHeight, (mins % m.rowHeight) * m.rowLines / m.rowHeight, true
+	}
+	return 0, 0, 0, false
+}
+
+// markNowLine draws the current-time marker on line of a cell width
+// characters wide. Empty lines get a full-width rule; lines with content keep
+// it and swap their leading space for the marker.
+func markNowLine(lines []string, line, width int) {
+	if line < 0 || line >= len(lines) {
+		return
+	}
+	if lines[line] == "" {
+		lines[line] = nowMarker + strings.Repeat("─", max(width-2, 0))
+		return
+	}
+	lines[line] = nowMarker + strings.TrimPrefix(lines[line], " ")
+}
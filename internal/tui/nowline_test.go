@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestNowLine(t *testing.T) {
+	cfg := config.Default()
+	cfg.Schedule.DayStart = "09:00"
+	cfg.Schedule.DayEnd = "17:00"
+	wednesday := time.Date(2025, 3, 12, 10, 40, 0, 0, time.Local)
+	m := *New(nil, cfg, WithClock(clock.Fixed(wednesday)))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+
+	day, slot, line, ok := m.nowLine()
+	if !ok || day != 2 || slot != 6 { // 10:30 row at 15-minute rows
+		t.Fatalf("nowLine = %d, %d, %d, %v; want Wednesday slot 6", day, slot, line, ok)
+	}
+	if want := 10 * m.rowLines / 15; line != want {
+		t.Errorf("line = %d, want %d", line, want)
+	}
+	if !strings.Contains(m.View(), nowMarker) {
+		t.Error("view has no current-time marker")
+	}
+
+	m.weekStart = m.weekStart.AddDate(0, 0, 7)
+	if _, _, _, ok := m.nowLine(); ok {
+		t.Error("nowLine shown on a week without today")
+	}
+
+	evening := *New(nil, cfg, WithClock(clock.Fixed(wednesday.Add(8*time.Hour))))
+	updated, _ = evening.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	evening = updated.(Model)
+	if _, _, _, ok := evening.nowLine(); ok {
+		t.Error("nowLine shown after the day ends")
+	}
+}
+
+func TestMarkNowLine(t *testing.T) {
+	lines := []string{" D Review", ""}
+	markNowLine(lines, 0, 6)
+	markNowLine(lines, 1, 6)
+	if lines[0] != "▶D Review" {
+		t.Errorf("task line = %q", lines[0])
+	}
+	if lines[1] != "▶────" {
+		t.Errorf("empty line = %q", lines[1])
+	}
+}
+
+func TestNowTickRefreshesClockState(t *testing.T) {
+	cfg := config.Default()
+	cfg.Schedule.DayStart = "09:00"
+	cfg.Schedule.DayEnd = "17:00"
+	now := time.Date(2025, 3, 12, 9, 50, 0, 0, time.Local) // Wednesday
+	m := *New(nil, cfg, WithClock(clock.Func(func() time.Time { return now })))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+
+	week := task.NewWeek(m.weekStart)
+	_ = week.Day(2).AddTask(&task.Task{
+		ID:             5,
+		Description:    "Review",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  week.Day(2).Date,
+		ScheduledStart: "10:00",
+		ScheduledEnd:   "11:00",
+		Status:         task.StatusScheduled,
+	})
+	updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, week, nil)})
+	m = updated.(Model)
+
+	tick := func() bool {
+		t.Helper()
+		before := m.cachedClock
+		updated, cmd := m.Update(commands.NowTickMsg{})
+		if cmd == nil {
+			t.Fatal("tick did not schedule the next one")
+		}
+		m = updated.(Model)
+		return !before.equal(m.cachedClock)
+	}
+
+	now = now.Add(20 * time.Second)
+	if tick() {
+		t.Error("clock state changed within the same minute")
+	}
+	now = time.Date(2025, 3, 12, 10, 5, 0, 0, time.Local)
+	if !tick() || !m.cachedClock.current[5] {
+		t.Errorf("task should be current at 10:05: %+v", m.cachedClock)
+	}
+	now = time.Date(2025, 3, 12, 11, 5, 0, 0, time.Local)
+	if !tick() || !m.cachedClock.past[5] || m.cachedClock.current[5] {
+		t.Errorf("task should be past at 11:05: %+v", m.cachedClock)
+	}
+}
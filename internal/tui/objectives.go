@@ -0,0 +1,63 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// openObjectives loads the objectives of the quarter holding the shown week.
+func (m Model) openObjectives() (tea.Model, tea.Cmd) {
+	if _, err := task.ObjectivesOf(m.repo); err != nil {
+		m.statusMsg = "Objectives are not stored in this database"
+		return m, nil
+	}
+	m.statusMsg = "Loading objectives..."
+	return m, commands.LoadObjectives(m.repo, task.Quarter(m.weekStart), m.now())
+}
+
+func (m Model) handleObjectivesMsg(msg commands.ObjectivesMsg) (tea.Model, tea.Cmd) {
+	m.objectivesQuarter = msg.Quarter
+	m.objectives = msg.Progress
+	m.mode = ModeModal
+	m.modalType = ModalObjectives
+	m.statusMsg = ""
+	return m, nil
+}
+
+// handleObjectivesKeys steps through the quarters with h and l.
+func (m Model) handleObjectivesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "h", "left", "l", "right":
+		start, _, err := task.QuarterRange(m.objectivesQuarter, m.weekStart.Location())
+		if err != nil {
+			return m, nil
+		}
+		step := 3
+		if key := msg.String(); key == "h" || key == "left" {
+			step = -3
+		}
+		return m, commands.LoadObjectives(m.repo, task.Quarter(start.AddDate(0, step, 0)), m.now())
+	case "esc", "q":
+		m.closeObjectives()
+	}
+	return m, nil
+}
+
+func (m *Model) closeObjectives() {
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+	m.objectives = nil
+}
+
+func (m Model) renderObjectivesModal() string {
+	styles := view.MissedStyles{
+		BodyStyle: m.styles.ModalBodyStyle,
+		MetaStyle: m.styles.ModalMetaStyle,
+	}
+	body := view.RenderObjectivesBody(m.objectives, styles)
+	footer := view.ObjectivesFooter(m.modalStyles())
+	return view.RenderModalFrame("Objectives "+m.objectivesQuarter, body, footer, m.modalStyles())
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// offHoursBand is the marker of the collapsed hours before and after the
+// working day.
+const offHoursBand = "…"
+
+// toggleOffHours collapses the grid to working hours, with the tasks outside
+// them counted in a thin band above and below, or expands it to every hour
+// holding a task. The cursor stays on the same time of day when it can.
+func (m Model) toggleOffHours() (tea.Model, tea.Cmd) {
+	if m.slotState.IsEditing() {
+		m.statusMsg = "Finish moving before changing the hours shown"
+		return m, nil
+	}
+
+	cursorMins := m.dayStartMinutes() + m.cursor.Slot*m.rowHeight
+	m.offHoursCollapsed = !m.offHoursCollapsed
+	m.calculateLayout()
+	m.cursor.Slot = min(max((cursorMins-m.dayStartMinutes())/m.rowHeight, 0), max(m.maxSlots()-1, 0))
+	m.scrollOffset = 0
+	m.ensureCursorVisible()
+	m.layoutCache = m.buildLayoutCache(m.width, m.height)
+	m.refreshViewCaches()
+	if m.offHoursCollapsed {
+		m.statusMsg = fmt.Sprintf("Showing working hours %s-%s (z for all)", m.config.Schedule.DayStart, m.config.Schedule.DayEnd)
+	} else {
+		m.statusMsg = "Showing all hours"
+	}
+	return m, nil
+}
+
+// offHoursBandLines is how many grid lines the collapsed-hours bands take.
+func (m Model) offHoursBandLines() int {
+	if m.offHoursCollapsed {
+		return 2
+	}
+	return 0
+}
+
+// hiddenTaskCounts returns how many tasks on a day of the visible week start
+// before or end after the hours shown.
+func (m *Model) hiddenTaskCounts(day int) (before, after int) {
+	dayStart, dayEnd := m.dayStartMinutes(), m.dayEndMinutes()
+	for _, span := range m.daySpans(day) {
+		if span.start < dayStart {
+			before++
+		}
+		if span.end > dayEnd {
+			after++
+		}
+	}
+	return before, after
+}
+
+// offHoursBandRow renders the one-line band standing for the collapsed hours
+// before the working day (before) or after it, with the number of tasks
+// each day has there. The band is left blank while the edge of the working
+// day it borders is scrolled out of view (!atEdge).
+func (m Model) offHoursBandRow(before, atEdge bool) ([]string, []lipgloss.Style) {
+	days := m.visibleDays()
+	row := make([]string, 0, len(days)+1)
+	styles := make([]lipgloss.Style, 0, len(days)+1)
+	marker := offHoursBand
+	if !atEdge {
+		marker = ""
+	}
+	row = append(row, padRight(marker, 6))
+	styles = append(styles, m.styles.TimeColumnStyle.Width(6).Height(1))
+
+	cell := m.styles.EmptyCellStyle.Height(1)
+	for _, day := range days {
+		width := m.dayWidth(day)
+		if m.isNarrowDay(day) || !atEdge {
+			row = append(row, "")
+			styles = append(styles, cell.Width(width))
+			continue
+		}
+		earlier, later := m.hiddenTaskCounts(day)
+		n, word := later, "later"
+		if before {
+			n, word = earlier, "earlier"
+		}
+		label := ""
+		if n > 0 {
+			label = fmt.Sprintf("%s %d %s", offHoursBand, n, word)
+			if ansi.StringWidth(label) >= width {
+				label = fmt.Sprintf("%s %d", offHoursBand, n)
+			}
+		}
+		row = append(row, " "+label)
+		styles = append(styles, cell.Width(width))
+	}
+	return row, styles
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestCollapseOffHours(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{ID: 1, Description: "Gym", Category: task.CategoryShallow, ScheduledDate: monday, ScheduledStart: "06:00", ScheduledEnd: "07:00", Status: task.StatusScheduled},
+		{ID: 2, Description: "Write", Category: task.CategoryDeep, ScheduledDate: monday, ScheduledStart: "10:00", ScheduledEnd: "11:00", Status: task.StatusScheduled},
+		{ID: 3, Description: "Release", Category: task.CategoryDeep, ScheduledDate: monday, ScheduledStart: "20:00", ScheduledEnd: "21:00", Status: task.StatusScheduled},
+	}
+	cfg := config.Default()
+	cfg.UI.CollapseOffHours = true
+	m := *New(nil, cfg, WithClock(clock.Fixed(monday.Add(8*time.Hour))))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	m = updated.(Model)
+	updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, task.NewWeekFromTasks(monday, tasks), nil)})
+	m = updated.(Model)
+
+	if m.dayStartMinutes() != 9*60 || m.dayEndMinutes() != 17*60 {
+		t.Fatalf("collapsed range = %d-%d, want working hours", m.dayStartMinutes(), m.dayEndMinutes())
+	}
+	view := m.View()
+	for _, want := range []string{"… 1 earlier", "… 1 later"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("collapsed view has no %q band", want)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(Model)
+	if m.dayStartMinutes() != 6*60 || m.dayEndMinutes() != 21*60 {
+		t.Errorf("expanded range = %d-%d, want 06:00-21:00", m.dayStartMinutes(), m.dayEndMinutes())
+	}
+	if strings.Contains(m.View(), "earlier") {
+		t.Error("expanded view still shows the band")
+	}
+}
@@ -0,0 +1,374 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/llm"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/theme"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// onboardingStep is one page of the first-run wizard.
+type onboardingStep int
+
+const (
+	onboardingHours onboardingStep = iota
+	onboardingWorkdays
+	onboardingProvider
+	onboardingTheme
+	onboardingReview
+	onboardingStepCount
+)
+
+// onboardingWeekdays lists workday choices in calendar order.
+var onboardingWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// onboardingModel asks for the settings a new user most likely wants to change
+// before the week grid is shown. It edits a copy of the config; the caller
+// saves it once the wizard completes.
+type onboardingModel struct {
+	cfg        *config.Config
+	configPath string
+	step       onboardingStep
+	hours      textinput.Model
+	workdays   []bool // Parallel to onboardingWeekdays
+	providers  []string
+	themes     []string
+	cursor     int // Selected row on list steps
+	styles     *Styles
+	err        string
+	width      int
+	height     int
+	done       bool // Review confirmed
+}
+
+func newOnboardingModel(cfg *config.Config, configPath string) onboardingModel {
+	c := *cfg
+	c.Schedule.Workdays = append([]string(nil), cfg.Schedule.Workdays...)
+
+	hours := textinput.New()
+	hours.Placeholder = "09:00-17:00"
+	hours.CharLimit = 11
+	hours.Width = 12
+	hours.SetValue(c.Schedule.DayStart + "-" + c.Schedule.DayEnd)
+	hours.Focus()
+
+	workdays := make([]bool, len(onboardingWeekdays))
+	for i, day := range onboardingWeekdays {
+		workdays[i] = c.IsWorkday(day)
+	}
+
+	m := onboardingModel{
+		cfg:        &c,
+		configPath: configPath,
+		hours:      hours,
+		workdays:   workdays,
+		providers:  llm.ProviderNames(),
+		themes:     theme.Available(),
+	}
+	m.applyTheme(c.UI.Theme)
+	return m
+}
+
+// applyTheme restyles the wizard so the theme step previews each choice.
+func (m *onboardingModel) applyTheme(name string) {
+	t, err := theme.Load(name)
+	if err != nil {
+		t, _ = theme.Load("mocha")
+	}
+	m.styles = NewStyles(t)
+	m.hours.TextStyle = m.styles.ModalInputTextStyle
+	m.hours.PromptStyle = m.styles.ModalInputTextStyle
+	m.hours.PlaceholderStyle = m.styles.ModalPlaceholderStyle
+	m.hours.Cursor.Style = m.styles.ModalInputCursorStyle
+}
+
+func (m onboardingModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m onboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	if m.step == onboardingHours {
+		var cmd tea.Cmd
+		m.hours, cmd = m.hours.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m onboardingModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		if m.step == onboardingHours {
+			return m, tea.Quit
+		}
+		m.err = ""
+		m.enterStep(m.step - 1)
+		return m, nil
+	case "enter":
+		return m.next()
+	}
+
+	switch m.step {
+	case onboardingHours:
+		var cmd tea.Cmd
+		m.hours, cmd = m.hours.Update(msg)
+		return m, cmd
+	case onboardingWorkdays, onboardingProvider, onboardingTheme:
+		switch msg.String() {
+		case "j", "down":
+			m.cursor = min(m.cursor+1, m.optionCount()-1)
+		case "k", "up":
+			m.cursor = max(m.cursor-1, 0)
+		case " ", "x":
+			if m.step == onboardingWorkdays {
+				m.workdays[m.cursor] = !m.workdays[m.cursor]
+			}
+		}
+		if m.step == onboardingTheme {
+			m.applyTheme(m.themes[m.cursor])
+		}
+	}
+	return m, nil
+}
+
+// next stores the current step's answer and moves on, or quits once the
+// review step is confirmed.
+func (m onboardingModel) next() (tea.Model, tea.Cmd) {
+	c := *m.cfg
+	switch m.step {
+	case onboardingHours:
+		start, end, ok := strings.Cut(m.hours.Value(), "-")
+		if !ok {
+			m.err = "enter hours as HH:MM-HH:MM"
+			return m, nil
+		}
+		c.Schedule.DayStart, c.Schedule.DayEnd = strings.TrimSpace(start), strings.TrimSpace(end)
+	case onboardingWorkdays:
+		c.Schedule.Workdays = nil
+		for i, day := range onboardingWeekdays {
+			if m.workdays[i] {
+				c.Schedule.Workdays = append(c.Schedule.Workdays, day)
+			}
+		}
+	case onboardingProvider:
+		c.LLM.Provider = m.providers[m.cursor]
+	case onboardingTheme:
+		c.UI.Theme = m.themes[m.cursor]
+	case onboardingReview:
+		m.done = true
+		return m, tea.Quit
+	}
+	if err := c.Validate(); err != nil {
+		m.err = err.Error()
+		return m, nil
+	}
+	m.cfg = &c
+	m.err = ""
+	m.enterStep(m.step + 1)
+	return m, nil
+}
+
+// enterStep shows step with the cursor on the currently configured choice.
+func (m *onboardingModel) enterStep(step onboardingStep) {
+	m.step = step
+	m.cursor = 0
+	switch step {
+	case onboardingProvider:
+		m.cursor = indexOf(m.providers, m.cfg.LLM.Provider)
+	case onboardingTheme:
+		m.cursor = indexOf(m.themes, m.cfg.UI.Theme)
+	}
+	m.applyTheme(m.cfg.UI.Theme)
+}
+
+func (m onboardingModel) optionCount() int {
+	switch m.step {
+	case onboardingWorkdays:
+		return len(m.workdays)
+	case onboardingProvider:
+		return len(m.providers)
+	case onboardingTheme:
+		return len(m.themes)
+	}
+	return 0
+}
+
+func indexOf(values []string, v string) int {
+	for i, s := range values {
+		if strings.EqualFold(s, v) {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m onboardingModel) View() string {
+	model := view.OnboardingModel{
+		Step:         int(m.step) + 1,
+		Steps:        int(onboardingStepCount),
+		Cursor:       m.cursor,
+		ErrorMessage: m.err,
+	}
+	switch m.step {
+	case onboardingHours:
+		model.Title = "Working hours"
+		model.Prompt = "When does your working day start and end?"
+		model.Input = m.hours.View()
+	case onboardingWorkdays:
+		model.Title = "Workdays"
+		model.Prompt = "Which days do you work?"
+		model.Multi = true
+		for i, day := range onboardingWeekdays {
+			model.Options = append(model.Options, view.OnboardingOption{Label: capitalize(day), Checked: m.workdays[i]})
+		}
+	case onboardingProvider:
+		model.Title = "Planner"
+		model.Prompt = "Which LLM provider should plan your days?"
+		for _, p := range m.providers {
+			label := p
+			if llm.IsLocalProvider(p) {
+				label += " (runs locally)"
+			}
+			model.Options = append(model.Options, view.OnboardingOption{Label: label})
+		}
+	case onboardingTheme:
+		model.Title = "Theme"
+		model.Prompt = "Pick a color theme."
+		for _, t := range m.themes {
+			model.Options = append(model.Options, view.OnboardingOption{Label: t})
+		}
+	case onboardingReview:
+		model.Title = "Review"
+		model.Prompt = "Sancho will save these settings and add an example day to your calendar."
+		model.Summary = []string{
+			fmt.Sprintf("Hours:     %s-%s", m.cfg.Schedule.DayStart, m.cfg.Schedule.DayEnd),
+			fmt.Sprintf("Workdays:  %s", strings.Join(m.cfg.Schedule.Workdays, ", ")),
+			fmt.Sprintf("Planner:   %s", m.cfg.LLM.Provider),
+			fmt.Sprintf("Theme:     %s", m.cfg.UI.Theme),
+			"",
+			fmt.Sprintf("Config:    %s", m.configPath),
+			fmt.Sprintf("Database:  %s", m.cfg.Storage.DBPath),
+		}
+	}
+
+	modalStyles := view.ModalStyles{
+		ModalHeaderStyle:       m.styles.ModalHeaderStyle,
+		ModalTitleStyle:        m.styles.ModalTitleStyle,
+		ModalFooterStyle:       m.styles.ModalFooterStyle,
+		ModalStyle:             m.styles.ModalStyle,
+		ModalButtonStyle:       m.styles.ModalButtonStyle,
+		ModalButtonActiveStyle: m.styles.ModalButtonActiveStyle,
+		ModalBodyStyle:         m.styles.ModalBodyStyle,
+	}
+	body := view.RenderOnboardingBody(model, view.OnboardingStyles{
+		BodyStyle:   m.styles.ModalBodyStyle,
+		MetaStyle:   m.styles.ModalMetaStyle,
+		LabelStyle:  m.styles.ModalLabelStyle,
+		CursorStyle: m.styles.ModalInputCursorStyle,
+	})
+	footer := view.OnboardingFooter(m.step == onboardingHours, model.Multi, m.step == onboardingReview, modalStyles)
+	frame := view.RenderModalFrame("Welcome to Sancho", body, footer, modalStyles)
+	if m.width == 0 || m.height == 0 {
+		return frame
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, frame)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// runOnboarding shows the first-run wizard and saves the chosen settings to
+// configPath. It reports false when the user quits before the review step,
+// in which case nothing is written.
+func runOnboarding(cfg *config.Config, configPath string) (*config.Config, bool, error) {
+	p := tea.NewProgram(newOnboardingModel(cfg, configPath), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return nil, false, err
+	}
+	m, ok := final.(onboardingModel)
+	if !ok || !m.done {
+		return nil, false, nil
+	}
+	if err := m.cfg.SaveTo(configPath); err != nil {
+		return nil, false, fmt.Errorf("saving config: %w", err)
+	}
+	return m.cfg, true, nil
+}
+
+// exampleBlock is one task of the example day, placed relative to the start
+// of the working day, or to its end when fromEnd is set.
+type exampleBlock struct {
+	description string
+	category    task.Category
+	offset      int // Minutes from day start, or before day end when fromEnd
+	minutes     int
+	fromEnd     bool
+}
+
+var exampleDay = []exampleBlock{
+	{description: "Example: plan the day", category: task.CategoryShallow, offset: 0, minutes: 30},
+	{description: "Example: deep work on your main project", category: task.CategoryDeep, offset: 30, minutes: 120},
+	{description: "Example: email and messages", category: task.CategoryShallow, offset: 150, minutes: 30},
+	{description: "Example: review the day", category: task.CategoryShallow, offset: 30, minutes: 30, fromEnd: true},
+}
+
+// seedExampleDay fills the first workday from now with a few example tasks
+// so a new user sees how deep and shallow blocks look in the grid. Blocks
+// that do not fit inside the working hours are skipped.
+func seedExampleDay(ctx context.Context, repo task.Repository, cfg *config.Config, now time.Time) error {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := 0; i < 7 && !cfg.IsWorkday(day.Weekday().String()); i++ {
+		day = day.AddDate(0, 0, 1)
+	}
+
+	dayStart := task.TimeToMinutes(cfg.Schedule.DayStart)
+	dayEnd := task.TimeToMinutes(cfg.Schedule.DayEnd)
+	taken := dayStart
+	for _, b := range exampleDay {
+		start := dayStart + b.offset
+		if b.fromEnd {
+			start = dayEnd - b.offset
+		}
+		end := start + b.minutes
+		if start < taken || end > dayEnd {
+			continue
+		}
+		t := &task.Task{
+			Description:    b.description,
+			Category:       b.category,
+			ScheduledDate:  day,
+			ScheduledStart: minutesToTime(start),
+			ScheduledEnd:   minutesToTime(end),
+			Status:         task.StatusScheduled,
+		}
+		if err := repo.CreateTask(ctx, t); err != nil {
+			return fmt.Errorf("seeding example day: %w", err)
+		}
+		taken = end
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+)
+
+func onboardingKey(t *testing.T, m onboardingModel, key string) onboardingModel {
+	t.Helper()
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	updated, _ := m.Update(msg)
+	return updated.(onboardingModel)
+}
+
+func TestOnboardingCollectsSettings(t *testing.T) {
+	cfg := config.Default()
+	cfg.Storage.DBPath = filepath.Join(t.TempDir(), "sancho.db")
+	m := newOnboardingModel(cfg, filepath.Join(t.TempDir(), "config.toml"))
+
+	m.hours.SetValue("08:00-16:00")
+	m = onboardingKey(t, m, "enter")
+	if m.step != onboardingWorkdays {
+		t.Fatalf("step = %d, want workdays (err %q)", m.step, m.err)
+	}
+
+	// Drop Friday, add Saturday.
+	for range 4 {
+		m = onboardingKey(t, m, "j")
+	}
+	m = onboardingKey(t, m, " ")
+	m = onboardingKey(t, m, "j")
+	m = onboardingKey(t, m, " ")
+	m = onboardingKey(t, m, "enter")
+
+	for m.providers[m.cursor] != "ollama" {
+		m = onboardingKey(t, m, "j")
+	}
+	m = onboardingKey(t, m, "enter")
+
+	m = onboardingKey(t, m, "k")
+	m = onboardingKey(t, m, "enter")
+	if m.step != onboardingReview {
+		t.Fatalf("step = %d, want review", m.step)
+	}
+	m = onboardingKey(t, m, "enter")
+	if !m.done {
+		t.Fatal("expected wizard to finish on review")
+	}
+
+	got := m.cfg
+	if got.Schedule.DayStart != "08:00" || got.Schedule.DayEnd != "16:00" {
+		t.Errorf("hours = %s-%s, want 08:00-16:00", got.Schedule.DayStart, got.Schedule.DayEnd)
+	}
+	want := []string{"monday", "tuesday", "wednesday", "thursday", "saturday"}
+	if len(got.Schedule.Workdays) != len(want) {
+		t.Fatalf("workdays = %v, want %v", got.Schedule.Workdays, want)
+	}
+	for i := range want {
+		if got.Schedule.Workdays[i] != want[i] {
+			t.Fatalf("workdays = %v, want %v", got.Schedule.Workdays, want)
+		}
+	}
+	if got.LLM.Provider != "ollama" {
+		t.Errorf("provider = %q, want ollama", got.LLM.Provider)
+	}
+	if got.UI.Theme != "macchiato" {
+		t.Errorf("theme = %q, want macchiato (one above the default frappe)", got.UI.Theme)
+	}
+	if cfg.Schedule.DayStart != "09:00" || len(cfg.Schedule.Workdays) != 5 {
+		t.Error("wizard must not modify the config it was given")
+	}
+}
+
+func TestOnboardingRejectsInvalidHours(t *testing.T) {
+	m := newOnboardingModel(config.Default(), "config.toml")
+	m.hours.SetValue("17:00-09:00")
+	m = onboardingKey(t, m, "enter")
+	if m.step != onboardingHours {
+		t.Fatalf("step = %d, want to stay on hours", m.step)
+	}
+	if m.err == "" {
+		t.Fatal("expected an error for hours ending before they start")
+	}
+
+	m.hours.SetValue("09:00-17:00")
+	m = onboardingKey(t, m, "enter")
+	m = onboardingKey(t, m, "esc")
+	if m.step != onboardingHours || m.err != "" {
+		t.Fatalf("esc should go back to hours and clear the error, got step %d err %q", m.step, m.err)
+	}
+}
+
+func TestSeedExampleDay(t *testing.T) {
+	cfg := config.Default()
+	repo := memrepo.New()
+	saturday := time.Date(2025, 3, 15, 7, 0, 0, 0, time.Local)
+	ctx := context.Background()
+
+	if err := seedExampleDay(ctx, repo, cfg, saturday); err != nil {
+		t.Fatalf("seedExampleDay: %v", err)
+	}
+
+	monday := time.Date(2025, 3, 17, 0, 0, 0, 0, time.Local)
+	tasks, err := repo.ListTasksByDateRange(ctx, saturday, monday.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(tasks) != len(exampleDay) {
+		t.Fatalf("seeded %d tasks, want %d", len(tasks), len(exampleDay))
+	}
+	for _, tk := range tasks {
+		if !sameDay(tk.ScheduledDate, monday) {
+			t.Errorf("%s seeded on %s, want the next workday", tk.Description, tk.ScheduledDate.Format("Mon"))
+		}
+		if tk.ScheduledStart < "09:00" || tk.ScheduledEnd > "17:00" {
+			t.Errorf("%s at %s-%s is outside working hours", tk.Description, tk.ScheduledStart, tk.ScheduledEnd)
+		}
+	}
+}
+
+func TestSeedExampleDaySkipsBlocksThatDoNotFit(t *testing.T) {
+	cfg := config.Default()
+	cfg.Schedule.DayStart, cfg.Schedule.DayEnd = "09:00", "11:00"
+	repo := memrepo.New()
+	monday := time.Date(2025, 3, 17, 8, 0, 0, 0, time.Local)
+	ctx := context.Background()
+
+	if err := seedExampleDay(ctx, repo, cfg, monday); err != nil {
+		t.Fatalf("seedExampleDay: %v", err)
+	}
+	tasks, err := repo.ListTasksByDateRange(ctx, monday, monday)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	// Planning fits, the two-hour deep block and the email block after it do
+	// not, and the end-of-day review lands in the last half hour.
+	if len(tasks) != 2 {
+		t.Fatalf("seeded %d tasks, want 2: %v", len(tasks), tasks)
+	}
+}
@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// parkingLabel marks the parking strip in the time column.
+const parkingLabel = "park"
+
+// parkedMinutes is how long a parked item placed in the grid is blocked for.
+const parkedMinutes = 60
+
+// parkingLines is how many grid lines the parking strip takes. The strip is
+// shown while anything is parked, on any day.
+func (m Model) parkingLines() int {
+	if len(m.parked) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// parkedOn returns the items parked on day of the visible week.
+func (m Model) parkedOn(day int) []*task.BacklogItem {
+	return m.parked[m.weekStart.AddDate(0, 0, day).Format("2006-01-02")]
+}
+
+// handleParkedMsg keeps the parked items and resizes the grid when the
+// strip appears or goes.
+func (m Model) handleParkedMsg(msg commands.ParkedMsg) (tea.Model, tea.Cmd) {
+	before := m.parkingLines()
+	m.parked = msg.Items
+	if m.parkingLines() != before {
+		m.calculateLayout()
+		m.ensureCursorVisible()
+		m.layoutCache = m.buildLayoutCache(m.width, m.height)
+	}
+	return m, nil
+}
+
+// parkingRow renders the strip under the grid with the number of items
+// parked on each day and the first of their titles.
+func (m Model) parkingRow() ([]string, []lipgloss.Style) {
+	days := m.visibleDays()
+	row := make([]string, 0, len(days)+1)
+	styles := make([]lipgloss.Style, 0, len(days)+1)
+	row = append(row, padRight(parkingLabel, 6))
+	styles = append(styles, m.styles.TimeColumnStyle.Width(6).Height(1))
+
+	cell := m.styles.EmptyCellStyle.Height(1)
+	for _, day := range days {
+		width := m.dayWidth(day)
+		items := m.parkedOn(day)
+		label := ""
+		switch {
+		case len(items) == 0:
+		case m.isNarrowDay(day):
+			label = fmt.Sprintf("%d", len(items))
+		default:
+			label = fmt.Sprintf("%d %s", len(items), items[0].Title)
+		}
+		row = append(row, " "+truncateWithEllipsis(label, max(width-2, 0)))
+		styles = append(styles, cell.Width(width))
+	}
+	return row, styles
+}
+
+// handleParkCommand handles "/park <title>", which commits new work to the
+// cursor's day without giving it a time yet.
+func (m Model) handleParkCommand(args []string) (tea.Model, tea.Cmd) {
+	title := strings.TrimSpace(strings.Join(args, " "))
+	if title == "" {
+		m.statusMsg = "Usage: /park <title>, parks the task on the cursor's day"
+		return m, nil
+	}
+	backlog, err := task.BacklogOf(m.persistentRepo())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	day := m.weekStart.AddDate(0, 0, m.cursor.Day)
+	item := &task.BacklogItem{Title: title, Day: day}
+	if _, err := backlog.UpsertBacklogItem(context.Background(), item); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Parked on %s: %s (b places it at the cursor)", day.Format("Mon Jan 2"), title)
+	return m, commands.LoadParked(m.persistentRepo())
+}
+
+// toggleParkBacklogItem parks the selected backlog item on the cursor's day,
+// or returns it to the backlog when it is already parked.
+func (m Model) toggleParkBacklogItem() (tea.Model, tea.Cmd) {
+	if len(m.backlogItems) == 0 {
+		return m, nil
+	}
+	item := m.backlogItems[m.backlogCursor]
+	backlog, err := task.BacklogOf(m.persistentRepo())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	day := time.Time{}
+	if !item.IsParked() {
+		day = m.weekStart.AddDate(0, 0, m.cursor.Day)
+	}
+	if err := backlog.ParkBacklogItem(context.Background(), item.ID, day); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	item.Day = day
+	if item.IsParked() {
+		m.statusMsg = fmt.Sprintf("Parked on %s: %s", day.Format("Mon Jan 2"), item.Title)
+	} else {
+		m.statusMsg = "Back in the backlog: " + item.Title
+	}
+	return m, commands.LoadParked(m.persistentRepo())
+}
+
+// placeParked blocks an hour at the cursor slot for the oldest item parked
+// on the cursor's day and takes the item off the strip.
+func (m Model) placeParked() (tea.Model, tea.Cmd) {
+	items := m.parkedOn(m.cursor.Day)
+	if len(items) == 0 {
+		m.statusMsg = "Nothing parked on this day; /park <title> to add"
+		return m, nil
+	}
+	if m.sandbox != nil {
+		m.statusMsg = "Parked tasks are placed outside the sandbox"
+		return m, nil
+	}
+	if t := m.taskAtCursor(); t != nil {
+		m.statusMsg = fmt.Sprintf("Slot taken by %s", t.Description)
+		return m, nil
+	}
+	backlog, err := task.BacklogOf(m.repo)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+
+	item := items[0]
+	start := m.slotToTime(m.cursor.Slot)
+	newTask := &task.Task{
+		Description:    item.Title,
+		Category:       task.Categories()[0].Name,
+		ScheduledDate:  m.weekStart.AddDate(0, 0, m.cursor.Day),
+		ScheduledStart: start,
+		ScheduledEnd:   addMinutesToTime(start, parkedMinutes),
+		Status:         task.StatusScheduled,
+	}
+	ctx := context.Background()
+	if err := m.repo.CreateTask(ctx, newTask); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	if err := backlog.DeleteBacklogItem(ctx, item.ID); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, m.reloadDays(newTask.ScheduledDate)
+	}
+	m.statusMsg = fmt.Sprintf("Placed: %s %s-%s%s%s", newTask.Description, newTask.ScheduledStart, newTask.ScheduledEnd,
+		m.reserveBuffer(ctx, newTask), m.capacityWarning(ctx, newTask))
+	if left := len(items) - 1; left > 0 {
+		m.statusMsg += fmt.Sprintf(" (%d more parked)", left)
+	}
+	return m, tea.Batch(m.reloadDays(newTask.ScheduledDate), commands.LoadParked(m.repo))
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/nlp"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// handlePasteMeeting handles "/paste-meeting". It reads an invitation copied
+// from Outlook or Google Calendar off the clipboard and adds it as a shallow
+// task.
+func (m Model) handlePasteMeeting() (tea.Model, tea.Cmd) {
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Paste failed: %v", err)
+		return m, nil
+	}
+	return m.addMeeting(text)
+}
+
+// addMeeting creates a shallow task from the text of a meeting invitation.
+func (m Model) addMeeting(text string) (tea.Model, tea.Cmd) {
+	if strings.TrimSpace(text) == "" {
+		m.statusMsg = "Clipboard is empty; copy a meeting invitation first"
+		return m, nil
+	}
+	inv, err := nlp.ParseInvite(text, m.now())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+
+	newTask, err := task.New(inv.Title, string(task.CategoryShallow), inv.Date.Format("2006-01-02"), inv.Start, inv.End, m.now())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	ctx := context.Background()
+	if err := m.repo.CreateTask(ctx, newTask); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Added meeting: %s %s %s-%s%s%s", newTask.Description, inv.Date.Format("Mon Jan 2"),
+		newTask.ScheduledStart, newTask.ScheduledEnd, m.reserveBuffer(ctx, newTask), m.capacityWarning(ctx, newTask))
+	return m, m.reloadDays(newTask.ScheduledDate)
+}
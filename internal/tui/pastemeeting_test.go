@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestAddMeeting(t *testing.T) {
+	repo := memrepo.New()
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local) // Monday
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(now)))
+
+	updated, _ := m.addMeeting("Subject: Project review\nWhen: Tuesday, March 11, 2025 10:00 AM-11:00 AM. (UTC+01:00) Brussels\n")
+	m = updated.(Model)
+	if !strings.HasPrefix(m.statusMsg, "Added meeting: Project review Tue Mar 11 10:00-11:00") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	tuesday := time.Date(2025, 3, 11, 0, 0, 0, 0, time.Local)
+	tasks, err := repo.ListTasksByDateRange(context.Background(), tuesday, tuesday)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Category != task.CategoryShallow || tasks[0].ScheduledStart != "10:00" {
+		t.Fatalf("tasks = %+v, want one shallow task at 10:00", tasks)
+	}
+
+	updated, _ = m.addMeeting("Lunch with Ana")
+	if msg := updated.(Model).statusMsg; !strings.Contains(msg, "no meeting time range found") {
+		t.Errorf("status = %q, want a parse error", msg)
+	}
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// dataPollInterval is how often the TUI checks whether another process,
+// such as a CLI command, changed the database.
+const dataPollInterval = 2 * time.Second
+
+// handleDataVersion reloads the weeks when the database changed since the
+// last poll. While the user is editing, in a modal or in the sandbox the
+// reload waits for a later poll, so nothing shifts under them.
+func (m Model) handleDataVersion(msg commands.DataVersionMsg) (tea.Model, tea.Cmd) {
+	next := commands.PollDataVersion(m.persistentRepo(), dataPollInterval)
+	if msg.Err != nil || msg.Version == m.dataVersion {
+		return m, next
+	}
+	if m.dataVersion == "" {
+		m.dataVersion = msg.Version
+		return m, next
+	}
+	if m.sandbox != nil || m.mode != ModeNormal || m.slotState.IsEditing() {
+		return m, next
+	}
+	m.dataVersion = msg.Version
+	return m, tea.Batch(next, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius))
+}
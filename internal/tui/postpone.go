@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/scheduler"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+const (
+	// postponeCandidateDays is how many working days the postpone picker offers.
+	postponeCandidateDays = 7
+	// postponeRestHorizon is how many days ahead /postpone-rest looks for room.
+	postponeRestHorizon = 14
+)
+
+// openPostponePicker lists the next working days after the cursor task's day
+// with their free time and a suggested slot, keeping the task's own time
+// when it is free.
+func (m Model) openPostponePicker() (tea.Model, tea.Cmd) {
+	t := m.taskAtCursor()
+	if t == nil {
+		m.statusMsg = "No task to postpone"
+		return m, nil
+	}
+	if m.isTaskPast(t) {
+		m.statusMsg = "Cannot postpone past tasks"
+		return m, nil
+	}
+
+	var days []time.Time
+	for i := 1; len(days) < postponeCandidateDays && i <= 7*postponeCandidateDays; i++ {
+		if day := t.ScheduledDate.AddDate(0, 0, i); m.isWorkday(day) {
+			days = append(days, day)
+		}
+	}
+	if len(days) == 0 {
+		m.statusMsg = "No working days configured"
+		return m, nil
+	}
+
+	busy, err := m.busyBetween(days[0], days[len(days)-1])
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+
+	now := m.now()
+	sched := scheduler.New(m.config.Schedule.Workdays, m.config.Schedule.DayStart, m.config.Schedule.DayEnd)
+	options := make([]view.PostponeOption, 0, len(days))
+	for _, day := range days {
+		o := view.PostponeOption{Date: day, FreeMinutes: sched.FreeMinutes(day, busy, now)}
+		o.Start, o.End = m.postponeSlot(sched, day, busy, t, now)
+		options = append(options, o)
+	}
+
+	m.postponeTask = t
+	m.postponeOptions = options
+	m.postponeCursor = 0
+	m.mode = ModeModal
+	m.modalType = ModalPostpone
+	return m, nil
+}
+
+func (m Model) handlePostponeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(m.postponeOptions) {
+		m.postponeCursor = n - 1
+		return m.postponeToSelected()
+	}
+	switch key {
+	case "j", "down":
+		m.postponeCursor = min(m.postponeCursor+1, len(m.postponeOptions)-1)
+	case "k", "up":
+		m.postponeCursor = max(m.postponeCursor-1, 0)
+	case "enter":
+		return m.postponeToSelected()
+	case "esc", "q":
+		m.closePostponePicker()
+	}
+	return m, nil
+}
+
+// postponeToSelected postpones the task to the selected day's suggested slot.
+func (m Model) postponeToSelected() (tea.Model, tea.Cmd) {
+	o := m.postponeOptions[m.postponeCursor]
+	if o.Start == "" {
+		m.statusMsg = fmt.Sprintf("No free slot on %s", o.Date.Format("Mon Jan 2"))
+		return m, nil
+	}
+	t := m.postponeTask
+	m.closePostponePicker()
+
+	_, err := m.repo.PostponeTask(context.Background(), t.ID, o.Date, o.Start, o.End, t.Version)
+	if errors.Is(err, task.ErrTaskChanged) {
+		return m.handleTaskChanged()
+	}
+	if err != nil {
+		return m, func() tea.Msg { return commands.ErrMsg{Err: err} }
+	}
+	m.statusMsg = fmt.Sprintf("Postponed to %s %s-%s", o.Date.Format("Mon Jan 2"), o.Start, o.End)
+	return m, m.reloadDays(t.ScheduledDate, o.Date)
+}
+
+// handlePostponeRest moves today's tasks that have not started yet to the
+// next working days, in start order. Each keeps its time when that is free
+// and otherwise takes the first gap that fits, so tasks cascade to later
+// days as earlier ones fill up.
+func (m Model) handlePostponeRest() (tea.Model, tea.Cmd) {
+	now := m.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	todays, err := m.repo.ListTasksByDateRange(context.Background(), today, today)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	nowMinutes := now.Hour()*60 + now.Minute()
+	var rest []*task.Task
+	for _, t := range todays {
+		if t.IsScheduled() && task.TimeToMinutes(t.ScheduledStart) > nowMinutes {
+			rest = append(rest, t)
+		}
+	}
+	if len(rest) == 0 {
+		m.statusMsg = "No tasks left to postpone today"
+		return m, nil
+	}
+	sort.Slice(rest, func(i, j int) bool { return rest[i].ScheduledStart < rest[j].ScheduledStart })
+
+	updates, stuckTasks, err := m.cascadeTasks(rest, today.AddDate(0, 0, 1), now)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	moved := make([]string, len(updates))
+	for i, u := range updates {
+		moved[i] = fmt.Sprintf("%s → %s %s", descriptionOf(rest, u.ID), u.Date.Format("Mon"), u.Start)
+	}
+	stuck := make([]string, len(stuckTasks))
+	for i, t := range stuckTasks {
+		stuck[i] = t.Description
+	}
+	if len(updates) == 0 {
+		m.statusMsg = fmt.Sprintf("No room in the next %d days for: %s", postponeRestHorizon, strings.Join(stuck, ", "))
+		return m, nil
+	}
+
+	summary := fmt.Sprintf("Postponed %d: %s", len(updates), strings.Join(moved, ", "))
+	if len(stuck) > 0 {
+		summary += fmt.Sprintf("; no room for %s", strings.Join(stuck, ", "))
+	}
+	m.statusMsg = "Postponing..."
+	return m, commands.BatchUpdate(m.repo, updates, summary)
+}
+
+// cascadeTasks plans a postponed copy of each task, in order, on the first
+// working day from from onwards (within postponeRestHorizon days) that has
+// room. Each keeps its own time when that is free and otherwise takes the
+// first gap that fits. Tasks with no room anywhere are returned as stuck.
+func (m *Model) cascadeTasks(tasks []*task.Task, from, now time.Time) (updates []task.TaskUpdate, stuck []*task.Task, err error) {
+	var days []time.Time
+	for i := range postponeRestHorizon {
+		if day := from.AddDate(0, 0, i); m.isWorkday(day) {
+			days = append(days, day)
+		}
+	}
+	var busy []scheduler.Busy
+	if len(days) > 0 {
+		if busy, err = m.busyBetween(days[0], days[len(days)-1]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sched := scheduler.New(m.config.Schedule.Workdays, m.config.Schedule.DayStart, m.config.Schedule.DayEnd)
+	for _, t := range tasks {
+		placed := false
+		for _, day := range days {
+			start, end := m.postponeSlot(sched, day, busy, t, now)
+			if start == "" {
+				continue
+			}
+			busy = append(busy, scheduler.Busy{Date: day, Start: start, End: end})
+			updates = append(updates, task.TaskUpdate{ID: t.ID, Postpone: true, Date: day, Start: start, End: end, Version: t.Version})
+			placed = true
+			break
+		}
+		if !placed {
+			stuck = append(stuck, t)
+		}
+	}
+	return updates, stuck, nil
+}
+
+// postponeSlot returns the slot t would take on day: its own time when that
+// is free, otherwise the first gap that fits. Both are empty if nothing fits.
+func (m *Model) postponeSlot(sched *scheduler.Scheduler, day time.Time, busy []scheduler.Busy, t *task.Task, now time.Time) (start, end string) {
+	buffer := m.config.Schedule.BufferMinutes
+	start = t.ScheduledStart
+	if !sched.FitsAt(day, busy, start, t.Duration(), buffer, now) {
+		var ok bool
+		if start, ok = sched.FirstFit(day, busy, t.Duration(), buffer, now); !ok {
+			return "", ""
+		}
+	}
+	return start, task.MinutesToTime(task.TimeToMinutes(start) + t.Duration())
+}
+
+// descriptionOf returns the description of the task with id in tasks.
+func descriptionOf(tasks []*task.Task, id int64) string {
+	for _, t := range tasks {
+		if t.ID == id {
+			return t.Description
+		}
+	}
+	return ""
+}
+
+// busyBetween returns the scheduled blocks in the inclusive date range.
+func (m *Model) busyBetween(start, end time.Time) ([]scheduler.Busy, error) {
+	existing, err := m.repo.ListTasksByDateRange(context.Background(), start, end)
+	if err != nil {
+		return nil, err
+	}
+	var busy []scheduler.Busy
+	for _, e := range existing {
+		if e.IsScheduled() {
+			busy = append(busy, scheduler.Busy{Date: e.ScheduledDate, Start: e.ScheduledStart, End: e.ScheduledEnd})
+		}
+	}
+	return busy, nil
+}
+
+func (m *Model) closePostponePicker() {
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+	m.postponeTask = nil
+	m.postponeOptions = nil
+}
+
+func (m Model) renderPostponeModal() string {
+	if m.postponeTask == nil {
+		return ""
+	}
+	styles := view.PostponePickerStyles{
+		BodyStyle:   m.styles.ModalBodyStyle,
+		MetaStyle:   m.styles.ModalMetaStyle,
+		CursorStyle: m.styles.ModalInputCursorStyle,
+	}
+	body := view.RenderPostponePickerBody(m.postponeTask.Description, m.postponeOptions, m.postponeCursor, styles)
+	footer := view.PostponePickerFooter(m.modalStyles())
+	return view.RenderModalFrame("Postpone to", body, footer, m.modalStyles())
+}
@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestPostponePicker(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	friday := time.Date(2025, 3, 14, 0, 0, 0, 0, time.Local)
+	monday := friday.AddDate(0, 0, 3)
+	create := func(desc string, date time.Time, start, end string) *task.Task {
+		tk := &task.Task{Description: desc, Category: task.CategoryDeep, ScheduledDate: date,
+			ScheduledStart: start, ScheduledEnd: end, Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+		return tk
+	}
+	report := create("Report", friday, "10:00", "11:00")
+	create("Standup", monday, "10:00", "10:30")
+
+	cfg := config.Default()
+	m := *New(repo, cfg, WithClock(clock.Fixed(friday.Add(8*time.Hour))))
+	m.rowHeight = 15
+	updated, _ := m.Update(commands.LoadInitialWeeks(repo, m.weekStart, m.weekRadius)())
+	m = updated.(Model)
+	m.cursor = Position{Day: 4, Slot: m.timeToDisplaySlot(friday.Add(10 * time.Hour))}
+
+	press := func(key string) tea.Cmd {
+		t.Helper()
+		updated, cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+		return cmd
+	}
+
+	press("d")
+	if m.modalType != ModalPostpone || len(m.postponeOptions) != postponeCandidateDays {
+		t.Fatalf("modal %v with %d options, want the postpone picker with %d days", m.modalType, len(m.postponeOptions), postponeCandidateDays)
+	}
+	first := m.postponeOptions[0]
+	if !first.Date.Equal(monday) {
+		t.Fatalf("first option %v, want Monday (weekends skipped)", first.Date)
+	}
+	if first.Start != "09:00" || first.End != "10:00" {
+		t.Errorf("Monday slot = %s-%s, want the first free hour 09:00-10:00", first.Start, first.End)
+	}
+	if tuesday := m.postponeOptions[1]; tuesday.Start != "10:00" || tuesday.FreeMinutes != 8*60 {
+		t.Errorf("Tuesday = %+v, want the original 10:00 and a free day", tuesday)
+	}
+
+	reload := press("2")
+	if m.mode != ModeNormal {
+		t.Fatal("picking a day should close the picker")
+	}
+	// Only Friday and next Tuesday are reloaded, and next week's Tuesday
+	// is refreshed even though it is not the visible week
+	loaded, ok := reload().(commands.DaysLoadedMsg)
+	if !ok || len(loaded.Days) != 2 {
+		t.Fatalf("reload = %#v, want the two changed days", loaded)
+	}
+	updated, _ = m.Update(loaded)
+	m = updated.(Model)
+	if tuesday := m.slotState.WeekWindow().Next().Day(1); tuesday.Len() != 1 {
+		t.Errorf("next Tuesday has %d tasks in the window, want the postponed report", tuesday.Len())
+	}
+	moved, err := repo.ListTasksByDateRange(ctx, monday.AddDate(0, 0, 1), monday.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(moved) != 1 || moved[0].PostponedFrom == nil || *moved[0].PostponedFrom != report.ID || moved[0].ScheduledStart != "10:00" {
+		t.Fatalf("tuesday tasks = %+v, want the report postponed to 10:00", moved)
+	}
+}
+
+func TestPostponeRest(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	friday := time.Date(2025, 3, 14, 0, 0, 0, 0, time.Local)
+	monday := friday.AddDate(0, 0, 3)
+	create := func(desc string, date time.Time, start, end string) {
+		tk := &task.Task{Description: desc, Category: task.CategoryDeep, ScheduledDate: date,
+			ScheduledStart: start, ScheduledEnd: end, Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+	create("Started", friday, "11:00", "12:00")
+	create("Report", friday, "13:00", "14:00")
+	create("Email", friday, "14:00", "15:00")
+	create("Standup", monday, "13:00", "14:00")
+
+	cfg := config.Default()
+	m := *New(repo, cfg, WithClock(clock.Fixed(friday.Add(11*time.Hour+30*time.Minute))))
+	updated, cmd := m.handlePostponeRest()
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("no command, status %q", m.statusMsg)
+	}
+	res := cmd()
+	msg, ok := res.(commands.BatchUpdatedMsg)
+	if !ok {
+		t.Fatalf("got %T, want a successful batch update", res)
+	}
+	if want := "Postponed 2: Report → Mon 09:00, Email → Mon 14:00"; msg.Summary != want {
+		t.Errorf("summary = %q, want %q", msg.Summary, want)
+	}
+
+	moved, err := repo.ListTasksByDateRange(ctx, monday, monday)
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	slots := map[string]string{}
+	for _, tk := range moved {
+		slots[tk.Description] = tk.ScheduledStart
+	}
+	if slots["Report"] != "09:00" || slots["Email"] != "14:00" || len(slots) != 3 {
+		t.Errorf("monday = %v, want Report at 09:00 and Email keeping 14:00", slots)
+	}
+	if _, ok := slots["Started"]; ok {
+		t.Error("a task that already started was postponed")
+	}
+}
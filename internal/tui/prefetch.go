@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// weekKey keys a week in Model.prefetched by its start date.
+func weekKey(weekStart time.Time) string {
+	return weekStart.Format(time.DateOnly)
+}
+
+// shiftWeek moves the window one week forward or back. The week coming into
+// view is already loaded, so the grid changes at once; the week entering at
+// the far edge comes from the prefetched weeks when it is there and is
+// loaded in the background otherwise. The week leaving the window is kept
+// for going back.
+func (m *Model) shiftWeek(forward bool) tea.Cmd {
+	ww := m.slotState.WeekWindow()
+	step := 1
+	if !forward {
+		step = -1
+	}
+	leaving := ww.Week(-step * ww.Radius())
+	m.weekStart = m.weekStart.AddDate(0, 0, 7*step)
+	edge := m.prefetched[weekKey(m.weekStart.AddDate(0, 0, 7*step*ww.Radius()))]
+	if forward {
+		ww.ShiftForward(edge)
+	} else {
+		ww.ShiftBackward(edge)
+	}
+	if m.prefetched == nil {
+		m.prefetched = make(map[string]*task.Week)
+	}
+	if leaving != nil {
+		m.prefetched[weekKey(leaving.StartDate)] = leaving
+	}
+	m.evictPrefetched()
+
+	newConfig := SlotGridConfigFromWeekWindow(ww, m.config.Schedule.DayStart, m.config.Schedule.DayEnd, m.nowFunc(), m.rowHeight)
+	newConfig.Location = m.config.LocationFor
+	newConfig.SplitOwner = m.config.UI.SplitOwner
+	m.slotState.UpdateConfig(newConfig)
+	m.slotState.SetGrid(WeekWindowToSlotGrid(ww, newConfig))
+	m.focusCursorOnCurrentTaskOrTime()
+	m.refreshViewCaches()
+	return m.prefetchWeeks()
+}
+
+// evictPrefetched drops the prefetched weeks, loaded or on their way, that
+// are more than one week beyond the window.
+func (m *Model) evictPrefetched() {
+	limit := 7 * (m.weekRadius + 1)
+	for key := range m.prefetched {
+		start, err := time.ParseInLocation(time.DateOnly, key, m.weekStart.Location())
+		if err != nil {
+			delete(m.prefetched, key)
+			continue
+		}
+		if days := task.CalendarDaysBetween(m.weekStart, start); days < -limit || days > limit {
+			delete(m.prefetched, key)
+		}
+	}
+}
+
+// prefetchWeeks moves prefetched weeks into the gaps of the window and loads
+// the weeks still missing from it, plus the week just beyond each edge, so
+// the next H or L has its week ready. A week the window is waiting for while
+// an edit session is open stays prefetched, since rebuilding the grid would
+// drop the edits.
+func (m *Model) prefetchWeeks() tea.Cmd {
+	ww := m.navWeekWindow()
+	if ww == nil || m.repo == nil {
+		return nil
+	}
+	if m.prefetched == nil {
+		m.prefetched = make(map[string]*task.Week)
+	}
+
+	filled := false
+	var cmds []tea.Cmd
+	for offset := -ww.Radius() - 1; offset <= ww.Radius()+1; offset++ {
+		inWindow := offset >= -ww.Radius() && offset <= ww.Radius()
+		if inWindow && ww.Week(offset) != nil {
+			continue
+		}
+		start := m.weekStart.AddDate(0, 0, 7*offset)
+		week, ok := m.prefetched[weekKey(start)]
+		switch {
+		case !ok:
+			m.prefetched[weekKey(start)] = nil // Loading
+			cmds = append(cmds, commands.PrefetchWeek(m.repo, start))
+		case week != nil && inWindow && !m.slotState.IsEditing():
+			ww.SetWeek(offset, week)
+			delete(m.prefetched, weekKey(start))
+			filled = true
+		}
+	}
+	if filled {
+		m.slotState.SetGrid(WeekWindowToSlotGrid(ww, m.slotState.Config()))
+		m.refreshViewCaches()
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleWeekPrefetched keeps a week loaded in the background, unless it was
+// evicted or invalidated while loading, and brings it into the window when
+// the window is waiting for it.
+func (m Model) handleWeekPrefetched(msg commands.WeekPrefetchedMsg) (tea.Model, tea.Cmd) {
+	key := weekKey(msg.Week.StartDate)
+	if _, ok := m.prefetched[key]; ok {
+		m.prefetched[key] = msg.Week
+	}
+	return m, m.prefetchWeeks()
+}
+
+// forgetPrefetched drops the prefetched week holding date after a write to
+// it, so it is read again instead of shown stale.
+func (m *Model) forgetPrefetched(date time.Time) {
+	delete(m.prefetched, weekKey(startOfWeek(date)))
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// profileKey toggles the render profile in the footer. It is left out of
+// the help: the profile is for diagnosing slow terminals, not everyday use.
+const profileKey = "f12"
+
+// profiledCache names a render cache whose reuse the profile tracks.
+type profiledCache int
+
+const (
+	profileGrid profiledCache = iota
+	profileLayout
+	profileStyle
+	profiledCaches
+)
+
+// renderProfile measures frames while the profile is shown. It is shared by
+// the model's copies, so View can record into it.
+type renderProfile struct {
+	on      bool
+	frames  int
+	last    time.Duration // Render time of the last frame
+	total   time.Duration
+	rebuilt [profiledCaches]bool // Caches rebuilt since the last frame
+	hits    [profiledCaches]int  // Frames that reused each cache
+	cells   struct{ reused, styled int }
+	queries int64 // commands.Queries() when the profile was shown
+}
+
+// rebuild notes that cache was rebuilt for the next frame.
+func (p *renderProfile) rebuild(cache profiledCache) {
+	if p != nil {
+		p.rebuilt[cache] = true
+	}
+}
+
+// frame records a frame that took d to render, reusing cells and restyling
+// styled cells.
+func (p *renderProfile) frame(d time.Duration, reused, styled int) {
+	p.frames++
+	p.last = d
+	p.total += d
+	for i, rebuilt := range p.rebuilt {
+		if !rebuilt {
+			p.hits[i]++
+		}
+	}
+	p.rebuilt = [profiledCaches]bool{}
+	p.cells.reused += reused
+	p.cells.styled += styled
+}
+
+// String is the footer line, e.g.
+// "render 2.1ms (avg 1.8ms, 40 frames) · hits grid 90% layout 100% style 100% cells 97% · 6 queries".
+func (p *renderProfile) String() string {
+	if p.frames == 0 {
+		return fmt.Sprintf("render profile on (%s to hide)", profileKey)
+	}
+	pct := func(hits, total int) int {
+		if total == 0 {
+			return 100
+		}
+		return hits * 100 / total
+	}
+	return fmt.Sprintf("render %s (avg %s, %d frames) · hits grid %d%% layout %d%% style %d%% cells %d%% · %d queries",
+		p.last.Round(10*time.Microsecond), (p.total / time.Duration(p.frames)).Round(10*time.Microsecond), p.frames,
+		pct(p.hits[profileGrid], p.frames), pct(p.hits[profileLayout], p.frames), pct(p.hits[profileStyle], p.frames),
+		pct(p.cells.reused, p.cells.reused+p.cells.styled), commands.Queries()-p.queries)
+}
+
+// toggleProfile shows or hides the render profile, starting its counts over.
+func (m Model) toggleProfile() (tea.Model, tea.Cmd) {
+	on := m.profile == nil || !m.profile.on
+	if m.profile == nil {
+		m.profile = &renderProfile{}
+	}
+	*m.profile = renderProfile{on: on, queries: commands.Queries()}
+	return m, nil
+}
+
+// statsLine is the footer's stats bar, or the render profile while it is
+// shown.
+func (m Model) statsLine(width int) string {
+	if m.profile != nil && m.profile.on {
+		style := m.layoutCache.StatsBarStyle
+		frameW, _ := style.GetFrameSize()
+		contentWidth := max(0, width-frameW)
+		return style.Width(contentWidth).Render(ansi.Truncate(m.profile.String(), contentWidth, ""))
+	}
+	return m.renderStatsBar(width)
+}
+
+// rebuildStyleCache recomputes the width-dependent styles.
+func (m *Model) rebuildStyleCache() {
+	m.styleCache = NewStyleCache(m.styles, m.colWidth)
+	m.profile.rebuild(profileStyle)
+}
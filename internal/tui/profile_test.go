@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestRenderProfile(t *testing.T) {
+	cfg := config.Default()
+	m := *New(nil, cfg, WithClock(clock.Fixed(time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local))))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(Model)
+	updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, task.NewWeek(m.weekStart), nil)})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyF12})
+	m = updated.(Model)
+	if !m.profile.on {
+		t.Fatal("F12 did not show the render profile")
+	}
+	m.View()
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 180, Height: 50})
+	m = updated.(Model)
+	m.View()
+
+	p := m.profile
+	if p.frames != 2 {
+		t.Fatalf("frames = %d, want 2", p.frames)
+	}
+	for _, cache := range []profiledCache{profileGrid, profileLayout, profileStyle} {
+		if p.hits[cache] != 1 {
+			t.Errorf("cache %d hit on %d frames, want only the one before the resize", cache, p.hits[cache])
+		}
+	}
+	if line := m.statsLine(180); !strings.Contains(line, "hits grid 50% layout 50% style 50%") {
+		t.Errorf("footer = %q, want the profile's hit rates", line)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyF12})
+	m = updated.(Model)
+	if m.profile.on || strings.Contains(m.statsLine(180), "hits") {
+		t.Error("second F12 did not hide the render profile")
+	}
+}
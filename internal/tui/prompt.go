@@ -11,17 +11,85 @@ var promptCommands = []input.PromptCommand{
 		Name:        "/plan",
 		Description: "Plan tasks from natural language input",
 	},
+	{
+		Name:        "/auto",
+		Description: "Schedule tasks without AI (e.g. report 2h; email 30m shallow)",
+	},
+	{
+		Name:        "/add",
+		Description: "Add one task without AI (e.g. review PRs tomorrow 14:00 45m shallow)",
+	},
+	{
+		Name:        "/move",
+		Description: "Move the task under the cursor to any day (e.g. 2025-02-10 14:00, friday)",
+	},
+	{
+		Name:        "/paste-meeting",
+		Description: "Add a meeting from an Outlook or Google Calendar invitation on the clipboard",
+	},
+	{
+		Name:        "/postpone-rest",
+		Description: "Move today's tasks that have not started to the next working days",
+	},
+	{
+		Name:        "/import",
+		Description: "Add your open issues to the backlog (/import github owner/repo, /import jira)",
+	},
+	{
+		Name:        "/backlog",
+		Description: "Show the backlog; Enter schedules the selected item with /add",
+	},
+	{
+		Name:        "/park",
+		Description: "Park a task on the cursor's day without a time; b places it at the cursor",
+	},
+	{
+		Name:        "/allday",
+		Description: "Add an all-day task, like on call, shown over the cursor's day",
+	},
+	{
+		Name:        "/review",
+		Description: "Give yesterday's tasks an outcome one by one: t on time, o over, u under (/review week)",
+	},
+	{
+		Name:        "/export",
+		Description: "Write today's schedule to another tool (/export obsidian)",
+	},
+	{
+		Name:        "/snapshot",
+		Description: "Save the displayed week as a PNG image for sharing",
+	},
 	{
 		Name:        "/week",
 		Description: "Summarize the current week",
 	},
+	{
+		Name:        "/year",
+		Description: "Show hours and completion for every week of the year; Enter opens a week",
+	},
+	{
+		Name:        "/objectives",
+		Description: "Show the quarter's objectives with their scheduled and completed hours",
+	},
+	{
+		Name:        "/sandbox",
+		Description: "Try changes on a copy; /sandbox apply or /sandbox discard when done",
+	},
+	{
+		Name:        "/sync",
+		Description: "Show sync status, resolve conflicts, or settle replica conflicts (/sync status, /sync resolve, /sync push, /sync pull)",
+	},
+	{
+		Name:        "/llm-log",
+		Description: "Show recent LLM prompts and responses from the audit log",
+	},
 	{
 		Name:        "/help",
 		Description: "Show available commands",
 	},
 	{
 		Name:        "/reflect",
-		Description: "Reflect on recent work",
+		Description: "Review the last week and suggest adjustments",
 	},
 }
 
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/nlp"
+	"github.com/javiermolinar/sancho/internal/scheduler"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// handleQuickAdd creates a task from a /add line such as
+// "Review PRs tomorrow 14:00 45m shallow". Without a time the task goes in
+// the first free slot of the day within working hours.
+func (m Model) handleQuickAdd(input string) (tea.Model, tea.Cmd) {
+	if strings.TrimSpace(input) == "" {
+		m.statusMsg = "Add requires input, e.g. /add review PRs tomorrow 14:00 45m shallow"
+		return m, nil
+	}
+	now := m.now()
+	q, err := nlp.ParseQuickAdd(input, now)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+
+	ctx := context.Background()
+	start := q.Start
+	if start == "" {
+		existing, err := m.repo.ListTasksByDateRange(ctx, q.Date, q.Date)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		var busy []scheduler.Busy
+		for _, t := range existing {
+			if t.IsScheduled() {
+				busy = append(busy, scheduler.Busy{Date: t.ScheduledDate, Start: t.ScheduledStart, End: t.ScheduledEnd})
+			}
+		}
+		sched := scheduler.New(m.config.Schedule.Workdays, m.config.Schedule.DayStart, m.config.Schedule.DayEnd)
+		var ok bool
+		start, ok = sched.FirstFit(q.Date, busy, q.Minutes, m.config.Schedule.BufferMinutes, now)
+		if !ok {
+			m.statusMsg = fmt.Sprintf("No free %dm slot on %s", q.Minutes, q.Date.Format("Mon Jan 2"))
+			return m, nil
+		}
+		q.Start = start
+	}
+
+	newTask, err := task.New(q.Description, string(q.Category), q.Date.Format("2006-01-02"), q.Start, q.End(), now)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	if err := m.repo.CreateTask(ctx, newTask); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Added: %s %s %s-%s%s%s%s", newTask.Description, q.Date.Format("Mon Jan 2"),
+		newTask.ScheduledStart, newTask.ScheduledEnd, m.reserveBuffer(ctx, newTask), m.capacityWarning(ctx, newTask), m.estimateHint(ctx, newTask))
+	return m, m.reloadDays(newTask.ScheduledDate)
+}
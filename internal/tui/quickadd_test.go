@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestQuickAdd(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local) // Monday
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	standup := &task.Task{
+		Description:    "Standup",
+		Category:       task.CategoryShallow,
+		ScheduledDate:  monday,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "09:30",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(ctx, standup); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	cfg := config.Default()
+	m := *New(repo, cfg, WithClock(clock.Fixed(now)))
+
+	updated, _ := m.handleQuickAdd(" Review PRs tomorrow 14:00 45m shallow")
+	m = updated.(Model)
+	updated, _ = m.handleQuickAdd(" write report 1h")
+	m = updated.(Model)
+
+	tasks, err := repo.ListTasksByDateRange(ctx, monday, monday.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	got := make(map[string]string)
+	for _, tk := range tasks {
+		got[tk.Description] = tk.ScheduledDate.Format("Mon") + " " + tk.ScheduledStart + "-" + tk.ScheduledEnd + " " + string(tk.Category)
+	}
+	if want := "Tue 14:00-14:45 shallow"; got["Review PRs"] != want {
+		t.Errorf("Review PRs = %q, want %q", got["Review PRs"], want)
+	}
+	if want := "Mon 09:30-10:30 deep"; got["write report"] != want {
+		t.Errorf("write report = %q, want %q (first free slot after standup); status %q", got["write report"], want, m.statusMsg)
+	}
+
+	updated, _ = m.handleQuickAdd(" ")
+	if msg := updated.(Model).statusMsg; msg == "" {
+		t.Error("expected a usage message for empty input")
+	}
+}
+
+func TestQuickAdd_WarnsOverWeeklyLimit(t *testing.T) {
+	task.SetCategories([]task.CategoryInfo{{Name: task.CategoryShallow, WeeklyLimit: 60}})
+	defer task.SetCategories(nil)
+
+	repo := memrepo.New()
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local) // Monday
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(now)))
+
+	updated, _ := m.handleQuickAdd(" email 09:00 45m shallow")
+	m = updated.(Model)
+	if strings.Contains(m.statusMsg, "limit") {
+		t.Fatalf("status = %q, want no warning under the limit", m.statusMsg)
+	}
+	updated, _ = m.handleQuickAdd(" expenses friday 10:00 30m shallow")
+	m = updated.(Model)
+	if want := "(over shallow limit: 1h 15m/1h this week)"; !strings.Contains(m.statusMsg, want) {
+		t.Errorf("status = %q, want it to contain %q", m.statusMsg, want)
+	}
+	updated, _ = m.handleQuickAdd(" inbox next-week 10:00 30m shallow")
+	m = updated.(Model)
+	if strings.Contains(m.statusMsg, "limit") {
+		t.Errorf("status = %q, want next week counted on its own", m.statusMsg)
+	}
+}
+
+func TestQuickAdd_HintsHowLongSimilarTasksTake(t *testing.T) {
+	ctx := context.Background()
+	repo := memrepo.New()
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	for i, desc := range []string{"Weekly report", "weekly report #2", "Weekly report 3"} {
+		past := &task.Task{Description: desc, Category: task.CategoryDeep, ScheduledDate: day.AddDate(0, 0, i),
+			ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled}
+		if err := repo.CreateTask(ctx, past); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+		if err := task.RecordActual(ctx, repo, past, 90); err != nil {
+			t.Fatalf("RecordActual: %v", err)
+		}
+	}
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local)
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(now)))
+
+	updated, _ := m.handleQuickAdd(" weekly report 09:00 1h")
+	if want := "(similar tasks usually take 1.5× longer)"; !strings.Contains(updated.(Model).statusMsg, want) {
+		t.Errorf("status = %q, want it to contain %q", updated.(Model).statusMsg, want)
+	}
+	updated, _ = m.handleQuickAdd(" weekly review 11:00 1h")
+	if msg := updated.(Model).statusMsg; strings.Contains(msg, "similar") {
+		t.Errorf("status = %q, want no hint for an unrelated task", msg)
+	}
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/replica"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// startReplica starts database replication once, if a replica is configured.
+func (m Model) startReplica() tea.Cmd {
+	if m.replica != nil {
+		return nil
+	}
+	return commands.StartReplica(m.config, m.persistentRepo())
+}
+
+// handleReplicaMsg handles replication runs, background or user started.
+func (m Model) handleReplicaMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case commands.ReplicaStartedMsg:
+		m.replica = msg.Replicator
+		return m, commands.WaitReplicaEvent(msg.Events)
+
+	case commands.ReplicaEventMsg:
+		cmds := []tea.Cmd{commands.WaitReplicaEvent(msg.Events)}
+		if msg.Status.LastErr == nil {
+			if cmd := m.applyReplicaResult(msg.Status.Result); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		return m, tea.Batch(cmds...)
+
+	case commands.ReplicaSyncedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("Error: %v", msg.Err)
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Replica synced: %d pulled", msg.Result.Pulled)
+		if msg.Result.Pushed {
+			m.statusMsg += ", pushed"
+		}
+		return m, m.applyReplicaResult(msg.Result)
+	}
+	return m, nil
+}
+
+// applyReplicaResult reports conflicts and reloads the weeks when remote
+// rows were merged, unless the grid is being edited.
+func (m *Model) applyReplicaResult(res replica.Result) tea.Cmd {
+	if n := len(res.Conflicts); n > 0 {
+		m.statusMsg = fmt.Sprintf("Replica conflict: %d tasks changed on both machines; /sync push keeps yours, /sync pull takes theirs", n)
+		return nil
+	}
+	if res.Diverged {
+		m.statusMsg = "Replica conflict: notes, links, objectives or backlog changed on both machines; /sync push keeps yours, /sync pull takes theirs"
+		return nil
+	}
+	if res.Pulled == 0 || m.slotState.IsEditing() {
+		return nil
+	}
+	return commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+}
+
+// handleReplicaCommand handles "/sync push" and "/sync pull", which run
+// replication now and settle conflicts for the local or remote copy.
+func (m Model) handleReplicaCommand(resolve db.Resolution) (tea.Model, tea.Cmd) {
+	if m.replica == nil {
+		m.statusMsg = "Replication is not configured; set [sync.replica] in the config"
+		return m, nil
+	}
+	m.statusMsg = "Syncing replica..."
+	return m, commands.SyncReplica(m.replica, resolve)
+}
+
+// replicaIndicator returns the stats bar note for replication, e.g.
+// " [replica 3m ago]". It is empty when no replica is configured.
+func (m Model) replicaIndicator() string {
+	if m.replica == nil {
+		return ""
+	}
+	st := m.replica.Status()
+	switch {
+	case st.LastErr != nil:
+		return " [replica error]"
+	case st.Result.Conflicted():
+		return " [replica conflict]"
+	case st.LastSync.IsZero():
+		return " [replicating...]"
+	default:
+		return fmt.Sprintf(" [replica %s]", formatAgo(m.now().Sub(st.LastSync)))
+	}
+}
+
+// replicaStatus describes replication for "/sync status".
+func (m Model) replicaStatus() string {
+	st := m.replica.Status()
+	var line string
+	switch {
+	case st.LastErr != nil:
+		line = fmt.Sprintf("replica %s: error (%d failures): %v", st.Remote, st.Failures, st.LastErr)
+	case len(st.Result.Conflicts) > 0:
+		line = fmt.Sprintf("replica %s: %d conflicts, run /sync push or /sync pull", st.Remote, len(st.Result.Conflicts))
+	case st.Result.Diverged:
+		line = fmt.Sprintf("replica %s: notes or other tables conflict, run /sync push or /sync pull", st.Remote)
+	case st.LastSync.IsZero():
+		line = fmt.Sprintf("replica %s: not synced yet", st.Remote)
+	default:
+		line = fmt.Sprintf("replica %s: synced %s, %d pulled", st.Remote, formatAgo(m.now().Sub(st.LastSync)), st.Result.Pulled)
+		if st.Result.Pushed {
+			line += ", pushed"
+		}
+	}
+	if !st.NextRun.IsZero() {
+		line += fmt.Sprintf("; next at %s", st.NextRun.Format("15:04"))
+	}
+	return line
+}
@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// reviewUsage is shown for a /review line naming an unknown period.
+const reviewUsage = "Usage: /review [yesterday|today|week]"
+
+// handleReviewCommand handles "/review [yesterday|today|week]", which steps
+// through the past tasks of that period. Week is the displayed week.
+func (m Model) handleReviewCommand(args []string) (tea.Model, tea.Cmd) {
+	now := m.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	period := "yesterday"
+	if len(args) > 0 {
+		period = args[0]
+	}
+	var start, end time.Time
+	switch {
+	case len(args) > 1:
+		m.statusMsg = reviewUsage
+		return m, nil
+	case period == "yesterday":
+		start = today.AddDate(0, 0, -1)
+		end = start
+	case period == "today":
+		start, end = today, today
+	case period == "week":
+		start, end = m.weekStart, m.weekStart.AddDate(0, 0, 6)
+		period = "week of " + m.weekStart.Format("Jan 2")
+	default:
+		m.statusMsg = reviewUsage
+		return m, nil
+	}
+	return m, commands.LoadReview(m.repo, period, start, end)
+}
+
+// handleReviewMsg opens the review modal on the tasks that have ended and
+// were not cancelled or postponed. Outcomes and notes given earlier are
+// shown and can be changed.
+func (m Model) handleReviewMsg(msg commands.ReviewMsg) (tea.Model, tea.Cmd) {
+	var tasks []*task.Task
+	for _, t := range msg.Tasks {
+		if (t.IsScheduled() || t.IsMissed()) && m.isTaskPast(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	if len(tasks) == 0 {
+		m.statusMsg = fmt.Sprintf("No past tasks to review for %s", msg.Label)
+		return m, nil
+	}
+
+	m.reviewLabel = msg.Label
+	m.reviewTasks = tasks
+	m.reviewOutcomes = make([]task.Outcome, len(tasks))
+	m.reviewNotes = make([]string, len(tasks))
+	m.reviewNotesBefore = msg.Notes
+	m.reviewCursor = 0
+	for i, t := range tasks {
+		if t.Outcome != nil {
+			m.reviewOutcomes[i] = *t.Outcome
+		}
+		m.reviewNotes[i] = msg.Notes[t.ID]
+	}
+	m.mode = ModeModal
+	m.modalType = ModalReview
+	m.statusMsg = ""
+	return m, nil
+}
+
+// handleReviewKeys gives the task under the cursor an outcome with t, o or u
+// and moves on; rating the last task saves the review. Nothing is written
+// until then, or until Enter saves early.
+func (m Model) handleReviewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.reviewNoting {
+		return m.handleReviewNoteKeys(msg)
+	}
+	switch msg.String() {
+	case "t", "o", "u":
+		m.reviewOutcomes[m.reviewCursor] = map[string]task.Outcome{
+			"t": task.OutcomeOnTime,
+			"o": task.OutcomeOver,
+			"u": task.OutcomeUnder,
+		}[msg.String()]
+		if m.reviewCursor == len(m.reviewTasks)-1 {
+			return m.saveReview()
+		}
+		m.reviewCursor++
+	case " ", "j", "down":
+		m.reviewCursor = min(m.reviewCursor+1, len(m.reviewTasks)-1)
+	case "k", "up", "backspace":
+		m.reviewCursor = max(m.reviewCursor-1, 0)
+	case "n":
+		if m.reviewNotesBefore == nil {
+			m.statusMsg = "Notes are not stored in this database"
+			return m, nil
+		}
+		m.reviewNoting = true
+		m.reviewNote.SetValue(m.reviewNotes[m.reviewCursor])
+		m.reviewNote.CursorEnd()
+		m.reviewNote.Focus()
+		return m, textinput.Blink
+	case "enter":
+		return m.saveReview()
+	case "esc", "q":
+		m.statusMsg = "Review discarded"
+		m.closeReview()
+	}
+	return m, nil
+}
+
+// handleReviewNoteKeys edits the note of the task under the cursor.
+func (m Model) handleReviewNoteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.reviewNotes[m.reviewCursor] = strings.TrimSpace(m.reviewNote.Value())
+		fallthrough
+	case "esc":
+		m.reviewNoting = false
+		m.reviewNote.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.reviewNote, cmd = m.reviewNote.Update(msg)
+	return m, cmd
+}
+
+// saveReview closes the modal and writes the outcomes and notes that
+// changed in one batch. A note on a task left without an outcome is not
+// saved, since notes are kept with outcomes.
+func (m Model) saveReview() (tea.Model, tea.Cmd) {
+	tasks := m.reviewTasks
+	var (
+		entries []task.ReviewEntry
+		skipped int
+	)
+	for i, t := range tasks {
+		outcome, note := m.reviewOutcomes[i], m.reviewNotes[i]
+		if outcome == "" {
+			skipped++
+			continue
+		}
+		if t.Outcome != nil && *t.Outcome == outcome && note == m.reviewNotesBefore[t.ID] {
+			continue
+		}
+		entries = append(entries, task.ReviewEntry{ID: t.ID, Outcome: outcome, Note: note})
+	}
+	m.closeReview()
+	if len(entries) == 0 {
+		m.statusMsg = "Nothing to save"
+		return m, nil
+	}
+	return m, commands.SaveReview(m.repo, tasks, entries, skipped)
+}
+
+func (m Model) handleReviewSaved(msg commands.ReviewSavedMsg) (tea.Model, tea.Cmd) {
+	byID := make(map[int64]*task.Task, len(msg.Tasks))
+	for _, t := range msg.Tasks {
+		byID[t.ID] = t
+	}
+	cmds := make([]tea.Cmd, 0, len(msg.Entries)+1)
+	dates := make([]time.Time, 0, len(msg.Entries))
+	for _, e := range msg.Entries {
+		t := byID[e.ID]
+		dates = append(dates, t.ScheduledDate)
+		if t.Outcome == nil || *t.Outcome != e.Outcome {
+			cmds = append(cmds, m.pushAction(t, tasksync.ActionComplete, e.Outcome))
+		}
+	}
+	cmds = append(cmds, m.reloadDays(dates...))
+
+	m.statusMsg = fmt.Sprintf("Reviewed %d tasks", len(msg.Entries))
+	if len(msg.Entries) == 1 {
+		m.statusMsg = "Reviewed 1 task"
+	}
+	if msg.Skipped > 0 {
+		m.statusMsg += fmt.Sprintf("; %d skipped", msg.Skipped)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m *Model) closeReview() {
+	m.reviewLabel = ""
+	m.reviewTasks = nil
+	m.reviewOutcomes = nil
+	m.reviewNotes = nil
+	m.reviewNotesBefore = nil
+	m.reviewCursor = 0
+	m.reviewNoting = false
+	m.reviewNote.Blur()
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+}
+
+func (m Model) renderReviewModal() string {
+	rows := make([]view.ReviewRow, len(m.reviewTasks))
+	for i, t := range m.reviewTasks {
+		rows[i] = view.ReviewRow{
+			Date:        t.ScheduledDate,
+			Start:       t.ScheduledStart,
+			End:         t.ScheduledEnd,
+			Description: t.Description,
+			Outcome:     m.reviewOutcomes[i],
+			Note:        m.reviewNotes[i],
+		}
+	}
+	noteInput := ""
+	if m.reviewNoting {
+		noteInput = m.reviewNote.View()
+	}
+	styles := view.ReviewStyles{
+		BodyStyle:   m.styles.ModalBodyStyle,
+		MetaStyle:   m.styles.ModalMetaStyle,
+		CursorStyle: m.styles.ModalInputCursorStyle,
+	}
+	body := view.RenderReviewBody(rows, m.reviewCursor, noteInput, styles)
+	footer := view.ReviewFooter(m.reviewNoting, m.reviewNotesBefore != nil, m.modalStyles())
+	return view.RenderModalFrame("Review "+m.reviewLabel, body, footer, m.modalStyles())
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestReviewModal(t *testing.T) {
+	repo := memrepo.New()
+	ctx := context.Background()
+	yesterday := time.Date(2025, 3, 9, 0, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{Description: "Write report", Category: task.CategoryDeep, ScheduledDate: yesterday, ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled},
+		{Description: "Standup", Category: task.CategoryShallow, ScheduledDate: yesterday, ScheduledStart: "11:00", ScheduledEnd: "11:30", Status: task.StatusScheduled},
+		{Description: "Dropped", Category: task.CategoryDeep, ScheduledDate: yesterday, ScheduledStart: "13:00", ScheduledEnd: "14:00", Status: task.StatusCancelled},
+	}
+	for _, tk := range tasks {
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local)
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(now)))
+
+	_, cmd := m.handleReviewCommand(nil)
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if m.modalType != ModalReview || len(m.reviewTasks) != 2 {
+		t.Fatalf("modal = %v with %d tasks, want the review with 2", m.modalType, len(m.reviewTasks))
+	}
+
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("o")},
+		{Type: tea.KeyRunes, Runes: []rune("n")},
+		{Type: tea.KeyRunes, Runes: []rune("Ran long")},
+		{Type: tea.KeyEnter},
+	}
+	for _, k := range keys {
+		updated, _ = m.handleReviewKeys(k)
+		m = updated.(Model)
+	}
+	if got, _ := repo.GetTask(ctx, tasks[0].ID); got.Outcome != nil {
+		t.Fatalf("outcome written before the review finished: %v", *got.Outcome)
+	}
+
+	// Rating the last task saves everything at once
+	updated, cmd = m.handleReviewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = updated.(Model)
+	if m.modalType != ModalNone || cmd == nil {
+		t.Fatalf("modal = %v after the last task, want closed with a save", m.modalType)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.statusMsg != "Reviewed 2 tasks" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	for i, want := range []task.Outcome{task.OutcomeOver, task.OutcomeOnTime} {
+		got, _ := repo.GetTask(ctx, tasks[i].ID)
+		if got.Outcome == nil || *got.Outcome != want {
+			t.Errorf("%s outcome = %v, want %q", got.Description, got.Outcome, want)
+		}
+	}
+	notes, _ := repo.TaskNotes(ctx, []int64{tasks[0].ID, tasks[1].ID})
+	if len(notes) != 1 || notes[tasks[1].ID] != "Ran long" {
+		t.Errorf("notes = %v, want the standup note", notes)
+	}
+}
+
+func TestReviewCommand_Usage(t *testing.T) {
+	m := *New(memrepo.New(), config.Default())
+	updated, cmd := m.handleReviewCommand([]string{"month"})
+	if cmd != nil || updated.(Model).statusMsg != reviewUsage {
+		t.Errorf("status = %q, want usage", updated.(Model).statusMsg)
+	}
+}
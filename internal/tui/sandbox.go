@@ -0,0 +1,72 @@
+// Package tui provides the terminal user interface for sancho.
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// persistentRepo returns the real repository, bypassing the sandbox if active.
+func (m Model) persistentRepo() task.Repository {
+	if m.sandbox != nil {
+		return m.sandbox.Base()
+	}
+	return m.repo
+}
+
+// handleSandboxCommand handles "/sandbox [apply|discard]".
+func (m Model) handleSandboxCommand(args []string) (tea.Model, tea.Cmd) {
+	action := ""
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	if m.sandbox == nil {
+		if action != "" {
+			m.statusMsg = "Not in sandbox; use /sandbox to start one"
+			return m, nil
+		}
+		m.statusMsg = "Starting sandbox..."
+		return m, commands.EnterSandbox(m.repo, m.weekStart)
+	}
+
+	switch action {
+	case "":
+		m.statusMsg = fmt.Sprintf("Sandbox: %s (/sandbox apply or /sandbox discard)", m.sandbox.Changes().Summary())
+		return m, nil
+	case "apply":
+		m.statusMsg = "Applying sandbox..."
+		return m, commands.ApplySandbox(m.sandbox, m.sandbox.Base())
+	case "discard":
+		m.repo = m.sandbox.Base()
+		m.sandbox = nil
+		m.statusMsg = "Sandbox discarded"
+		return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+	default:
+		m.statusMsg = fmt.Sprintf("Unknown sandbox action: %s (use apply or discard)", action)
+		return m, nil
+	}
+}
+
+// handleSandboxMsg switches the model to or from the sandbox repository.
+func (m Model) handleSandboxMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case commands.SandboxStartedMsg:
+		m.sandbox = msg.Sandbox
+		m.repo = msg.Sandbox
+		m.statusMsg = "Sandbox on: changes are not saved until /sandbox apply"
+		return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+	case commands.SandboxAppliedMsg:
+		if m.sandbox != nil {
+			m.repo = m.sandbox.Base()
+			m.sandbox = nil
+		}
+		m.statusMsg = fmt.Sprintf("Sandbox applied: %s", msg.Summary)
+		return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+	}
+	return m, nil
+}
@@ -0,0 +1,51 @@
+// Package tui provides the terminal user interface for sancho.
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/sandbox"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestSandboxEnterAndDiscard(t *testing.T) {
+	base, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = base.Close() })
+
+	weekStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	sb, err := sandbox.New(context.Background(), base, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("sandbox.New: %v", err)
+	}
+
+	m := New(base, config.Default())
+	updated, cmd := m.Update(commands.SandboxStartedMsg{Sandbox: sb})
+	model := updated.(Model)
+	if model.repo != sb || model.sandbox != sb {
+		t.Fatal("expected model to use the sandbox repository")
+	}
+	if model.persistentRepo() != base {
+		t.Fatal("persistentRepo should return the base repository")
+	}
+	if cmd == nil {
+		t.Fatal("expected a reload command after entering the sandbox")
+	}
+	if !strings.Contains(model.renderLegend(), "SANDBOX") {
+		t.Error("expected sandbox indicator in legend")
+	}
+
+	updated, _ = model.handleSandboxCommand([]string{"discard"})
+	model = updated.(Model)
+	if model.sandbox != nil || model.repo != base {
+		t.Fatal("expected discard to restore the base repository")
+	}
+}
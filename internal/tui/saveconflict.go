@@ -0,0 +1,66 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// openSaveConflict lists the edits a save skipped because another process
+// changed their tasks or tasks they overlap, and reloads the week so the grid shows
+// what was stored. The other edits were saved.
+func (m Model) openSaveConflict(conflict *SaveConflictError) (tea.Model, tea.Cmd) {
+	m.saveConflict = conflict
+	m.mode = ModeModal
+	m.modalType = ModalSaveConflict
+	m.statusMsg = "Saved other changes; " + conflict.Error()
+	return m, commands.LoadWeek(m.repo, m.weekStart)
+}
+
+// handleSaveConflictKeys closes the conflict modal, going back to edit mode
+// on the reloaded week with i or Enter to place the tasks again.
+func (m Model) handleSaveConflictKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "i", "enter":
+		m.closeSaveConflict()
+		m.slotState.EnterEditMode()
+		m.mode = ModeEdit
+		m.statusMsg = "Edit mode: place the conflicting tasks again, Enter to save"
+	case "esc", "q":
+		m.closeSaveConflict()
+	}
+	return m, nil
+}
+
+func (m *Model) closeSaveConflict() {
+	m.saveConflict = nil
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+}
+
+func (m Model) renderSaveConflictModal() string {
+	var rows []view.SaveConflictRow
+	for _, group := range []struct {
+		tasks   []*task.Task
+		changed bool
+	}{{m.saveConflict.Tasks, false}, {m.saveConflict.Changed, true}} {
+		for _, t := range group.tasks {
+			rows = append(rows, view.SaveConflictRow{
+				Description: t.Description,
+				Date:        t.ScheduledDate,
+				Start:       t.ScheduledStart,
+				End:         t.ScheduledEnd,
+				Changed:     group.changed,
+			})
+		}
+	}
+	styles := view.MissedStyles{
+		BodyStyle: m.styles.ModalBodyStyle,
+		MetaStyle: m.styles.ModalMetaStyle,
+	}
+	body := view.RenderSaveConflictBody(rows, styles)
+	footer := view.SaveConflictFooter(m.modalStyles())
+	return view.RenderModalFrame("Save conflicts", body, footer, m.modalStyles())
+}
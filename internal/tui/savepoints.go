@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleSavepointKey completes "m{a-z}", which snapshots the working grid,
+// or "'{a-z}", which restores a snapshot; pending is the first key.
+func (m Model) handleSavepointKey(pending string, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "esc" {
+		m.statusMsg = ""
+		return m, nil
+	}
+	if len(key) != 1 || key[0] < 'a' || key[0] > 'z' {
+		m.statusMsg = "Savepoints are named a-z"
+		return m, nil
+	}
+	name := rune(key[0])
+
+	if pending == "m" {
+		if err := m.slotState.SetSavepoint(name); err != nil {
+			m.statusMsg = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Savepoint '%c set", name)
+		return m, nil
+	}
+
+	if err := m.slotState.RestoreSavepoint(name); err != nil {
+		if errors.Is(err, ErrSlotNoSavepoint) {
+			m.statusMsg = fmt.Sprintf("No savepoint '%c", name)
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.markCacheDirty()
+	m.statusMsg = fmt.Sprintf("Restored savepoint '%c (u to undo)", name)
+	return m, nil
+}
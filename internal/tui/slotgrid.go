@@ -2,6 +2,8 @@ package tui
 
 import (
 	"errors"
+	"maps"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +18,8 @@ var (
 	ErrSlotTaskNotFound     = errors.New("task not found in grid")
 	ErrMinimumSlotsDuration = errors.New("cannot shrink below 1 slot (15 minutes)")
 	ErrNoGapToRemove        = errors.New("no gap to remove")
+	ErrOvernightTask        = errors.New("overnight tasks cannot be moved in the grid")
+	ErrOverlappingTask      = errors.New("overlapping tasks cannot be edited in the grid")
 )
 
 const (
@@ -34,7 +38,7 @@ const (
 // SlotConfig holds grid configuration.
 type SlotConfig struct {
 	SlotDuration int              // Always 15 minutes internally
-	NumDays      int              // 21 (3 weeks * 7 days)
+	NumDays      int              // Window weeks * 7 days (21 by default)
 	FirstDate    time.Time        // Date of day index 0
 	Now          func() time.Time // Injectable for testing
 
@@ -47,6 +51,15 @@ type SlotConfig struct {
 	// Examples: 1 (15-min blocks), 2 (30-min blocks), 4 (60-min blocks).
 	// Defaults to 4 (60-min blocks) if not set.
 	DisplaySlotSize int
+
+	// Location returns the timezone pinned for a date. Slot times on that day
+	// are wall-clock times in the returned zone. Nil means the local zone.
+	Location func(date time.Time) *time.Location
+
+	// SplitOwner is the owner whose tasks go into the read-only overlap
+	// lane, to be shown beside everyone else's. Empty keeps every owner in
+	// one lane.
+	SplitOwner string
 }
 
 // SlotsPerDay returns the number of slots per day (always 96 for 24h grid).
@@ -115,14 +128,26 @@ func (c SlotConfig) DayIndexToDate(dayIndex int) time.Time {
 	return c.FirstDate.AddDate(0, 0, dayIndex)
 }
 
+// LocationForDay returns the timezone pinned for a day index, or nil if the
+// day uses the grid's own zone.
+func (c SlotConfig) LocationForDay(dayIndex int) *time.Location {
+	if c.Location == nil {
+		return nil
+	}
+	return c.Location(c.DayIndexToDate(dayIndex))
+}
+
+// CurrentWeek returns the week index of the focused week, the middle one of the grid.
+func (c SlotConfig) CurrentWeek() int {
+	return c.NumDays / DaysPerWeek / 2
+}
+
 // DateToDayIndex converts a date to a day index.
+// Only the calendar date is used, so dates from other timezones map to the
+// same column as their wall-clock day and DST transitions do not shift days.
 // Returns -1 if the date is before FirstDate or after the grid ends.
 func (c SlotConfig) DateToDayIndex(date time.Time) int {
-	// Truncate both to start of day for comparison
-	first := time.Date(c.FirstDate.Year(), c.FirstDate.Month(), c.FirstDate.Day(), 0, 0, 0, 0, c.FirstDate.Location())
-	d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-
-	days := int(d.Sub(first).Hours() / 24)
+	days := task.CalendarDaysBetween(c.FirstDate, date)
 	if days < 0 || days >= c.NumDays {
 		return -1
 	}
@@ -141,18 +166,46 @@ func NewSlotConfig(workStart, workEnd string, firstDate time.Time) SlotConfig {
 	}
 }
 
+// slotSpan is a task occupying slots [start, end) of one day.
+type slotSpan struct {
+	task       *task.Task
+	start, end int
+}
+
+// taskPos is where FindTaskByID finds a task: its first block of slots
+// [start, end) in the main lane, or its span in the overlap lane.
+type taskPos struct {
+	day, start, end int
+	overlap         bool
+}
+
 // SlotGrid is an immutable data structure representing task positions.
-// Each slot is 15 minutes. A task spanning multiple slots has its pointer
-// in each slot it occupies. The grid uses a 24-hour day (96 slots).
+// Each slot is 15 minutes and the grid uses a 24-hour day (96 slots). Every
+// day holds its tasks as spans sorted by start slot. A grid shares the span
+// lists of the days an operation did not change, so operations and undo
+// snapshots only copy the days they touch.
+//
+// Tasks of the split owner go into a separate overlap lane, and so do tasks
+// loaded over slots that are already taken when the repository allows
+// overlaps. The lane is read-only: grid operations leave its tasks where
+// they are, so the split owner's tasks cannot be moved or resized in the
+// TUI. All-day tasks take no slots and are kept apart the same way.
+//
+// Every grid also indexes where each task sits, updated with the days an
+// operation replaces, so looking a task up does not scan the grid.
 type SlotGrid struct {
-	slots  []*task.Task // Length = SlotsPerDay * NumDays
-	config SlotConfig
+	days     [][]slotSpan   // Length = NumDays; span lists are never modified in place
+	overlaps [][]slotSpan   // Nil unless some task overlaps another; spans may overlap
+	allDay   [][]*task.Task // Nil unless some day has an all-day task
+	index    map[int64]taskPos
+	config   SlotConfig
 }
 
 // NewSlotGrid creates an empty SlotGrid.
 func NewSlotGrid(config SlotConfig) *SlotGrid {
 	return &SlotGrid{
-		slots:  make([]*task.Task, config.TotalSlots()),
+		days:   make([][]slotSpan, max(config.NumDays, 0)),
+		index:  make(map[int64]taskPos),
 		config: config,
 	}
 }
@@ -162,11 +215,6 @@ func (g *SlotGrid) Config() SlotConfig {
 	return g.config
 }
 
-// slotIndex calculates the flat array index for a day and slot.
-func (g *SlotGrid) slotIndex(day, slot int) int {
-	return day*SlotsPerDay + slot
-}
-
 // isValidPosition checks if day and slot are within bounds.
 func (g *SlotGrid) isValidPosition(day, slot int) bool {
 	if day < 0 || day >= g.config.NumDays {
@@ -183,7 +231,12 @@ func (g *SlotGrid) TaskAt(day, slot int) *task.Task {
 	if !g.isValidPosition(day, slot) {
 		return nil
 	}
-	return g.slots[g.slotIndex(day, slot)]
+	spans := g.days[day]
+	i := sort.Search(len(spans), func(i int) bool { return spans[i].end > slot })
+	if i < len(spans) && spans[i].start <= slot {
+		return spans[i].task
+	}
+	return nil
 }
 
 // IsEmpty returns true if the given position is empty.
@@ -203,22 +256,34 @@ func (g *SlotGrid) FindTask(t *task.Task) (day, startSlot, endSlot int, found bo
 // FindTaskByID returns the position and size of a task by ID.
 // Returns day, startSlot, endSlot (exclusive), and found.
 func (g *SlotGrid) FindTaskByID(id int64) (day, startSlot, endSlot int, found bool) {
-	for d := 0; d < g.config.NumDays; d++ {
-		for s := 0; s < SlotsPerDay; s++ {
-			idx := g.slotIndex(d, s)
-			if g.slots[idx] != nil && g.slots[idx].ID == id {
-				// Found the start of the task, count consecutive slots
-				startSlot = s
-				day = d
-				endSlot = s + 1
-				for endSlot < SlotsPerDay && g.slots[g.slotIndex(d, endSlot)] != nil && g.slots[g.slotIndex(d, endSlot)].ID == id {
-					endSlot++
-				}
-				return day, startSlot, endSlot, true
-			}
+	pos, ok := g.index[id]
+	if !ok {
+		return 0, 0, 0, false
+	}
+	return pos.day, pos.start, pos.end, true
+}
+
+// HasOverlaps reports whether any task sits in the overlap lane.
+func (g *SlotGrid) HasOverlaps() bool {
+	for _, spans := range g.overlaps {
+		if len(spans) > 0 {
+			return true
 		}
 	}
-	return 0, 0, 0, false
+	return false
+}
+
+// AllDayTasks returns the all-day tasks of a day.
+func (g *SlotGrid) AllDayTasks(day int) []*task.Task {
+	if day < 0 || day >= len(g.allDay) {
+		return nil
+	}
+	return g.allDay[day]
+}
+
+// isOverlapping reports whether the task with id sits in the overlap lane.
+func (g *SlotGrid) isOverlapping(id int64) bool {
+	return g.index[id].overlap
 }
 
 // AllTasks returns all unique tasks in the grid.
@@ -226,10 +291,14 @@ func (g *SlotGrid) AllTasks() []*task.Task {
 	seen := make(map[int64]bool)
 	var result []*task.Task
 
-	for _, t := range g.slots {
-		if t != nil && !seen[t.ID] {
-			seen[t.ID] = true
-			result = append(result, t)
+	for _, lane := range [][][]slotSpan{g.days, g.overlaps} {
+		for _, spans := range lane {
+			for _, sp := range spans {
+				if !seen[sp.task.ID] {
+					seen[sp.task.ID] = true
+					result = append(result, sp.task)
+				}
+			}
 		}
 	}
 	return result
@@ -244,23 +313,126 @@ func (g *SlotGrid) TasksOnDay(day int) []*task.Task {
 	seen := make(map[int64]bool)
 	var result []*task.Task
 
-	for s := 0; s < SlotsPerDay; s++ {
-		t := g.slots[g.slotIndex(day, s)]
-		if t != nil && !seen[t.ID] {
-			seen[t.ID] = true
-			result = append(result, t)
+	spans := g.days[day]
+	if day < len(g.overlaps) {
+		spans = append(spans[:len(spans):len(spans)], g.overlaps[day]...)
+	}
+	for _, sp := range spans {
+		if !seen[sp.task.ID] {
+			seen[sp.task.ID] = true
+			result = append(result, sp.task)
 		}
 	}
 	return result
 }
 
-// clone creates a deep copy of the grid.
+// clone returns a copy of the grid that shares every day's spans, the
+// overlap lane and the all-day tasks. Operations then replace the days they change with setDaySlots.
 func (g *SlotGrid) clone() *SlotGrid {
-	newSlots := make([]*task.Task, len(g.slots))
-	copy(newSlots, g.slots)
+	days := make([][]slotSpan, len(g.days))
+	copy(days, g.days)
 	return &SlotGrid{
-		slots:  newSlots,
-		config: g.config,
+		days:     days,
+		overlaps: g.overlaps,
+		allDay:   g.allDay,
+		index:    maps.Clone(g.index),
+		config:   g.config,
+	}
+}
+
+// daySlots expands a day into one task pointer per slot for editing.
+func (g *SlotGrid) daySlots(day int) []*task.Task {
+	slots := make([]*task.Task, SlotsPerDay)
+	for _, sp := range g.days[day] {
+		for s := sp.start; s < sp.end; s++ {
+			slots[s] = sp.task
+		}
+	}
+	return slots
+}
+
+// setDaySlots replaces a day with the spans in slots. The old span list may
+// be shared with other grids, so only call it on a fresh clone.
+func (g *SlotGrid) setDaySlots(day int, slots []*task.Task) {
+	var spans []slotSpan
+	for s := 0; s < len(slots); {
+		t := slots[s]
+		if t == nil {
+			s++
+			continue
+		}
+		start := s
+		for s < len(slots) && slots[s] == t {
+			s++
+		}
+		spans = append(spans, slotSpan{task: t, start: start, end: s})
+	}
+	old := g.days[day]
+	g.days[day] = spans
+	g.reindexDay(day, old)
+}
+
+// reindexDay updates the task index after day's spans replaced old. A task
+// is indexed at its earliest block, so a task that left the day is looked
+// for on the other days, where an overnight task keeps its other part.
+func (g *SlotGrid) reindexDay(day int, old []slotSpan) {
+	if g.index == nil {
+		g.index = make(map[int64]taskPos)
+	}
+	left := make(map[int64]bool)
+	for _, sp := range old {
+		if pos, ok := g.index[sp.task.ID]; ok && pos.day == day && !pos.overlap {
+			delete(g.index, sp.task.ID)
+			left[sp.task.ID] = true
+		}
+	}
+	g.indexSpans(day, g.days[day], nil)
+	for id := range left {
+		if _, ok := g.index[id]; ok {
+			delete(left, id)
+		}
+	}
+	if len(left) == 0 {
+		return
+	}
+	for d, spans := range g.days {
+		if d != day {
+			g.indexSpans(d, spans, left)
+		}
+	}
+}
+
+// indexSpans indexes the first block of each task in spans, unless the task
+// is already indexed on the main lane of an earlier day. A non-nil only
+// limits it to those tasks.
+func (g *SlotGrid) indexSpans(day int, spans []slotSpan, only map[int64]bool) {
+	for i := 0; i < len(spans); i++ {
+		id := spans[i].task.ID
+		if only != nil && !only[id] {
+			continue
+		}
+		if pos, ok := g.index[id]; ok && !pos.overlap && pos.day <= day {
+			continue
+		}
+		// Adjacent spans of the same task count as one block
+		pos := taskPos{day: day, start: spans[i].start, end: spans[i].end}
+		for i+1 < len(spans) && spans[i+1].start == pos.end && spans[i+1].task.ID == id {
+			i++
+			pos.end = spans[i].end
+		}
+		g.index[id] = pos
+	}
+}
+
+// indexOverlaps indexes the tasks of the overlap lane. Call it once the lane
+// is sorted.
+func (g *SlotGrid) indexOverlaps() {
+	for d, spans := range g.overlaps {
+		for _, sp := range spans {
+			if _, ok := g.index[sp.task.ID]; !ok {
+				g.index[sp.task.ID] = taskPos{day: d, start: sp.start, end: sp.end, overlap: true}
+			}
+		}
 	}
 }
 
@@ -268,8 +440,11 @@ func (g *SlotGrid) clone() *SlotGrid {
 // Returns (-1, -1) if Now is before the grid starts (nothing is past).
 // Returns (NumDays, 0) if Now is after the grid ends (everything is past).
 func (g *SlotGrid) currentTimePosition() (day, slot int) {
-	now := g.config.Now()
+	return g.timePosition(g.config.Now())
+}
 
+// timePosition returns the day index and slot for a wall-clock time.
+func (g *SlotGrid) timePosition(now time.Time) (day, slot int) {
 	// Find day index
 	day = g.config.DateToDayIndex(now)
 	if day < 0 {
@@ -282,15 +457,20 @@ func (g *SlotGrid) currentTimePosition() (day, slot int) {
 	}
 
 	// Find slot from current time (24-hour grid, so always valid)
-	mins := now.Hour()*60 + now.Minute()
+	mins := task.WallClockMinutes(now)
 	slot = mins / g.config.SlotDuration
 
 	return day, slot
 }
 
 // isPastPosition returns true if the given position is in the past.
+// Days pinned to another timezone compare against the wall clock in that zone.
 func (g *SlotGrid) isPastPosition(day, slot int) bool {
-	nowDay, nowSlot := g.currentTimePosition()
+	now := g.config.Now()
+	if loc := g.config.LocationForDay(day); loc != nil {
+		now = now.In(loc)
+	}
+	nowDay, nowSlot := g.timePosition(now)
 
 	// If now is before the grid, nothing is past
 	if nowDay < 0 {
@@ -304,12 +484,22 @@ func (g *SlotGrid) isPastPosition(day, slot int) bool {
 }
 
 // canModifyTask checks if a task can be modified (not started yet).
+// Overnight tasks span two days and are pinned where they are, and so are
+// tasks in the overlap lane.
 func (g *SlotGrid) canModifyTask(t *task.Task) error {
 	day, startSlot, _, found := g.FindTask(t)
 	if !found {
 		return ErrSlotTaskNotFound
 	}
 
+	if g.isOverlapping(t.ID) {
+		return ErrOverlappingTask
+	}
+
+	if t.IsOvernight() {
+		return ErrOvernightTask
+	}
+
 	if g.isPastPosition(day, startSlot) {
 		return ErrTaskAlreadyStarted
 	}
@@ -317,6 +507,18 @@ func (g *SlotGrid) canModifyTask(t *task.Task) error {
 	return nil
 }
 
+// hasOvernightFrom returns true if an overnight task occupies any slot of
+// day from fromSlot on. Shifting those slots would split the task from the
+// part on the neighbouring day.
+func (g *SlotGrid) hasOvernightFrom(day, fromSlot int) bool {
+	for s := max(fromSlot, 0); s < SlotsPerDay; s++ {
+		if t := g.TaskAt(day, s); t != nil && t.IsOvernight() {
+			return true
+		}
+	}
+	return false
+}
+
 // Place adds a task to the grid at the specified position.
 // This is used during initial load and does not check for past positions.
 // Returns a new grid with the task placed.
@@ -345,10 +547,71 @@ func (g *SlotGrid) Place(t *task.Task, day, startSlot, numSlots int) (*SlotGrid,
 
 	// Clone and place
 	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
 	for s := startSlot; s < endSlot; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s)] = t
+		slots[s] = t
+	}
+
+	newGrid.setDaySlots(day, slots)
+	return newGrid, nil
+}
+
+// slotMove is a task's new position for reposition.
+type slotMove struct {
+	task       *task.Task
+	day        int
+	start, end int
+}
+
+// reposition takes every task in moves out of the grid and puts it at its
+// new position. If a position holds a task that is not moving, nothing is
+// placed and the moves that could not be made are returned.
+func (g *SlotGrid) reposition(moves []slotMove) (*SlotGrid, []slotMove) {
+	newGrid := g.clone()
+	days := make(map[int][]*task.Task)
+	slotsOf := func(day int) []*task.Task {
+		if days[day] == nil {
+			days[day] = newGrid.daySlots(day)
+		}
+		return days[day]
+	}
+
+	for _, mv := range moves {
+		day, start, end, found := g.FindTask(mv.task)
+		if !found {
+			continue
+		}
+		slots := slotsOf(day)
+		for s := start; s < end; s++ {
+			slots[s] = nil
+		}
 	}
 
+	var blocked []slotMove
+	for _, mv := range moves {
+		slots := slotsOf(mv.day)
+		free := true
+		for s := mv.start; s < mv.end; s++ {
+			if slots[s] != nil {
+				free = false
+				break
+			}
+		}
+		if !free {
+			blocked = append(blocked, mv)
+			continue
+		}
+		for s := mv.start; s < mv.end; s++ {
+			slots[s] = mv.task
+		}
+	}
+	if len(blocked) > 0 {
+		return nil, blocked
+	}
+
+	for day, slots := range days {
+		newGrid.setDaySlots(day, slots)
+	}
 	return newGrid, nil
 }
 
@@ -356,14 +619,23 @@ func (g *SlotGrid) Place(t *task.Task, day, startSlot, numSlots int) (*SlotGrid,
 // Direction-based Move Operations
 // ============================================================================
 
-// MoveUp moves a task to earlier time on the same day.
+// MoveUp moves a task to earlier time on the same day, stepping into a gap
+// by the display slot size. See MoveUpBy.
+func (g *SlotGrid) MoveUp(t *task.Task) (*SlotGrid, error) {
+	return g.MoveUpBy(t, g.config.GetDisplaySlotSize())
+}
+
+// MoveUpBy moves a task to earlier time on the same day.
 // Returns the same grid if already at slot 0 or no-op.
 // The task swaps with the previous task or moves one step into a gap.
 //
 // Behavior:
 // - If adjacent to another task: swap positions (other task takes our old position)
-// - If adjacent to a gap: move by task's size into the gap (one "step")
-func (g *SlotGrid) MoveUp(t *task.Task) (*SlotGrid, error) {
+// - If adjacent to a gap: move step slots into the gap, stopping at its start
+func (g *SlotGrid) MoveUpBy(t *task.Task, step int) (*SlotGrid, error) {
+	if step <= 0 {
+		step = g.config.GetDisplaySlotSize()
+	}
 	if t == nil {
 		return nil, ErrSlotTaskNotFound
 	}
@@ -388,16 +660,14 @@ func (g *SlotGrid) MoveUp(t *task.Task) (*SlotGrid, error) {
 	prevTask := g.TaskAt(day, startSlot-1)
 
 	if prevTask == nil {
-		// Moving into a gap - move by displaySlotSize (one visual "step")
+		// Moving into a gap - move by one step
 		// But don't go past the previous task or start of day
 		gapStart := startSlot - 1
 		for gapStart > 0 && g.TaskAt(day, gapStart-1) == nil {
 			gapStart--
 		}
 
-		// Move by display slot size (visual block), not task size
-		stepSize := g.config.GetDisplaySlotSize()
-		landing := startSlot - stepSize
+		landing := startSlot - step
 		if landing < gapStart {
 			landing = gapStart
 		}
@@ -408,21 +678,27 @@ func (g *SlotGrid) MoveUp(t *task.Task) (*SlotGrid, error) {
 
 		// Clone and perform move
 		newGrid := g.clone()
+		slots := newGrid.daySlots(day)
 
 		// Clear current position
 		for s := startSlot; s < endSlot; s++ {
-			newGrid.slots[newGrid.slotIndex(day, s)] = nil
+			slots[s] = nil
 		}
 
 		// Place at new position
 		for s := landing; s < landing+numSlots; s++ {
-			newGrid.slots[newGrid.slotIndex(day, s)] = t
+			slots[s] = t
 		}
 
+		newGrid.setDaySlots(day, slots)
 		return newGrid, nil
 	}
 
 	// Adjacent to another task - swap positions
+	if prevTask.IsOvernight() {
+		return nil, ErrOvernightTask
+	}
+
 	// Find the start of the previous task
 	prevStart := startSlot - 1
 	for prevStart > 0 && g.TaskAt(day, prevStart-1) != nil && g.TaskAt(day, prevStart-1).ID == prevTask.ID {
@@ -434,34 +710,45 @@ func (g *SlotGrid) MoveUp(t *task.Task) (*SlotGrid, error) {
 
 	// Clone and perform swap
 	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
 
 	// Clear both tasks
 	for s := prevStart; s < endSlot; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s)] = nil
+		slots[s] = nil
 	}
 
 	// Place our task first (at previous task's old position)
 	for s := prevStart; s < prevStart+numSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s)] = t
+		slots[s] = t
 	}
 
 	// Place prevTask after
 	newStart := prevStart + numSlots
 	for s := newStart; s < newStart+prevSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s)] = prevTask
+		slots[s] = prevTask
 	}
 
+	newGrid.setDaySlots(day, slots)
 	return newGrid, nil
 }
 
-// MoveDown moves a task to later time on the same day.
+// MoveDown moves a task to later time on the same day, stepping into a gap
+// by the display slot size. See MoveDownBy.
+func (g *SlotGrid) MoveDown(t *task.Task) (*SlotGrid, error) {
+	return g.MoveDownBy(t, g.config.GetDisplaySlotSize())
+}
+
+// MoveDownBy moves a task to later time on the same day.
 // Returns the same grid if at day end or no-op.
 // The task swaps with the next task or moves one step into a gap.
 //
 // Behavior:
 // - If adjacent to another task: swap positions (other task takes our old position)
-// - If adjacent to a gap: move by task's size into the gap (one "step")
-func (g *SlotGrid) MoveDown(t *task.Task) (*SlotGrid, error) {
+// - If adjacent to a gap: move step slots into the gap, stopping at its end
+func (g *SlotGrid) MoveDownBy(t *task.Task, step int) (*SlotGrid, error) {
+	if step <= 0 {
+		step = g.config.GetDisplaySlotSize()
+	}
 	if t == nil {
 		return nil, ErrSlotTaskNotFound
 	}
@@ -486,16 +773,14 @@ func (g *SlotGrid) MoveDown(t *task.Task) (*SlotGrid, error) {
 	nextTask := g.TaskAt(day, endSlot)
 
 	if nextTask == nil {
-		// Moving into a gap - move by displaySlotSize (one visual "step")
+		// Moving into a gap - move by one step
 		// But don't go past the next task or end of day
 		gapEnd := endSlot
 		for gapEnd < SlotsPerDay && g.TaskAt(day, gapEnd) == nil {
 			gapEnd++
 		}
 
-		// Move by display slot size (visual block), not task size
-		stepSize := g.config.GetDisplaySlotSize()
-		landing := startSlot + stepSize
+		landing := startSlot + step
 		maxLanding := gapEnd - numSlots
 		if landing > maxLanding {
 			landing = maxLanding
@@ -507,21 +792,27 @@ func (g *SlotGrid) MoveDown(t *task.Task) (*SlotGrid, error) {
 
 		// Clone and perform move
 		newGrid := g.clone()
+		slots := newGrid.daySlots(day)
 
 		// Clear current position
 		for s := startSlot; s < endSlot; s++ {
-			newGrid.slots[newGrid.slotIndex(day, s)] = nil
+			slots[s] = nil
 		}
 
 		// Place at new position
 		for s := landing; s < landing+numSlots; s++ {
-			newGrid.slots[newGrid.slotIndex(day, s)] = t
+			slots[s] = t
 		}
 
+		newGrid.setDaySlots(day, slots)
 		return newGrid, nil
 	}
 
 	// Adjacent to another task - swap positions
+	if nextTask.IsOvernight() {
+		return nil, ErrOvernightTask
+	}
+
 	// Find the end of the next task
 	nextEnd := endSlot + 1
 	for nextEnd < SlotsPerDay && g.TaskAt(day, nextEnd) != nil && g.TaskAt(day, nextEnd).ID == nextTask.ID {
@@ -534,22 +825,24 @@ func (g *SlotGrid) MoveDown(t *task.Task) (*SlotGrid, error) {
 
 	// Clone and perform swap
 	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
 
 	// Clear both tasks
 	for s := startSlot; s < nextEnd; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s)] = nil
+		slots[s] = nil
 	}
 
 	// Place nextTask first (at our old position)
 	for s := startSlot; s < startSlot+nextSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s)] = nextTask
+		slots[s] = nextTask
 	}
 
 	// Place our task after
 	for s := newStart; s < newStart+numSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s)] = t
+		slots[s] = t
 	}
 
+	newGrid.setDaySlots(day, slots)
 	return newGrid, nil
 }
 
@@ -596,34 +889,42 @@ func (g *SlotGrid) MoveRight(t *task.Task) (*SlotGrid, error) {
 		return g, nil
 	}
 
+	if g.hasOvernightFrom(sourceDay, endSlot) || g.hasOvernightFrom(targetDay, insertSlot) {
+		return nil, ErrOvernightTask
+	}
+
 	// Clone and perform move
 	newGrid := g.clone()
+	src := newGrid.daySlots(sourceDay)
+	dst := newGrid.daySlots(targetDay)
 
 	// === SOURCE DAY: Remove task and shift left (preserving gaps) ===
 	// Shift all slots after the removed task left by numSlots positions
 	for s := startSlot; s < SlotsPerDay-numSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(sourceDay, s)] = newGrid.slots[newGrid.slotIndex(sourceDay, s+numSlots)]
+		src[s] = src[s+numSlots]
 	}
 	// Clear the slots at the end that were vacated
 	for s := SlotsPerDay - numSlots; s < SlotsPerDay; s++ {
-		newGrid.slots[newGrid.slotIndex(sourceDay, s)] = nil
+		src[s] = nil
 	}
 
 	// === TARGET DAY: Shift right and place task ===
 	// Shift slots from insertSlot onwards by numSlots to make room
 	for s := SlotsPerDay - 1; s >= insertSlot+numSlots; s-- {
-		newGrid.slots[newGrid.slotIndex(targetDay, s)] = newGrid.slots[newGrid.slotIndex(targetDay, s-numSlots)]
+		dst[s] = dst[s-numSlots]
 	}
 	// Clear the slots where we'll place the task
 	for s := insertSlot; s < insertSlot+numSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(targetDay, s)] = nil
+		dst[s] = nil
 	}
 
 	// Place task at insert position
 	for s := insertSlot; s < insertSlot+numSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(targetDay, s)] = t
+		dst[s] = t
 	}
 
+	newGrid.setDaySlots(sourceDay, src)
+	newGrid.setDaySlots(targetDay, dst)
 	return newGrid, nil
 }
 
@@ -670,34 +971,42 @@ func (g *SlotGrid) MoveLeft(t *task.Task) (*SlotGrid, error) {
 		return g, nil
 	}
 
+	if g.hasOvernightFrom(sourceDay, endSlot) || g.hasOvernightFrom(targetDay, insertSlot) {
+		return nil, ErrOvernightTask
+	}
+
 	// Clone and perform move
 	newGrid := g.clone()
+	src := newGrid.daySlots(sourceDay)
+	dst := newGrid.daySlots(targetDay)
 
 	// === SOURCE DAY: Remove task and shift left (preserving gaps) ===
 	// Shift all slots after the removed task left by numSlots positions
 	for s := startSlot; s < SlotsPerDay-numSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(sourceDay, s)] = newGrid.slots[newGrid.slotIndex(sourceDay, s+numSlots)]
+		src[s] = src[s+numSlots]
 	}
 	// Clear the slots at the end that were vacated
 	for s := SlotsPerDay - numSlots; s < SlotsPerDay; s++ {
-		newGrid.slots[newGrid.slotIndex(sourceDay, s)] = nil
+		src[s] = nil
 	}
 
 	// === TARGET DAY: Shift right and place task ===
 	// Shift slots from insertSlot onwards by numSlots to make room
 	for s := SlotsPerDay - 1; s >= insertSlot+numSlots; s-- {
-		newGrid.slots[newGrid.slotIndex(targetDay, s)] = newGrid.slots[newGrid.slotIndex(targetDay, s-numSlots)]
+		dst[s] = dst[s-numSlots]
 	}
 	// Clear the slots where we'll place the task
 	for s := insertSlot; s < insertSlot+numSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(targetDay, s)] = nil
+		dst[s] = nil
 	}
 
 	// Place task at insert position
 	for s := insertSlot; s < insertSlot+numSlots; s++ {
-		newGrid.slots[newGrid.slotIndex(targetDay, s)] = t
+		dst[s] = t
 	}
 
+	newGrid.setDaySlots(sourceDay, src)
+	newGrid.setDaySlots(targetDay, dst)
 	return newGrid, nil
 }
 
@@ -775,15 +1084,20 @@ func (g *SlotGrid) Grow(t *task.Task) (*SlotGrid, error) {
 	growSlot := endSlot // The slot we're growing into
 
 	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
 
 	// Check if there's a task in the grow slot
-	existingTask := newGrid.TaskAt(day, growSlot)
+	existingTask := slots[growSlot]
 	if existingTask != nil && existingTask.ID != t.ID {
+		if g.hasOvernightFrom(day, growSlot) {
+			return nil, ErrOvernightTask
+		}
+
 		// Need to shift following tasks right
 		// Check if there's room (would overflow?)
 		lastOccupied := -1
 		for s := SlotsPerDay - 1; s >= growSlot; s-- {
-			if newGrid.TaskAt(day, s) != nil {
+			if slots[s] != nil {
 				lastOccupied = s
 				break
 			}
@@ -795,13 +1109,14 @@ func (g *SlotGrid) Grow(t *task.Task) (*SlotGrid, error) {
 
 		// Shift from right to left
 		for s := SlotsPerDay - 1; s > growSlot; s-- {
-			newGrid.slots[newGrid.slotIndex(day, s)] = newGrid.slots[newGrid.slotIndex(day, s-1)]
+			slots[s] = slots[s-1]
 		}
 	}
 
 	// Add the new slot to the task
-	newGrid.slots[newGrid.slotIndex(day, growSlot)] = t
+	slots[growSlot] = t
 
+	newGrid.setDaySlots(day, slots)
 	return newGrid, nil
 }
 
@@ -827,10 +1142,99 @@ func (g *SlotGrid) Shrink(t *task.Task) (*SlotGrid, error) {
 	}
 
 	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
 	// Remove the last slot of the task
 	lastSlot := endSlot - 1
-	newGrid.slots[newGrid.slotIndex(day, lastSlot)] = nil
+	slots[lastSlot] = nil
+
+	newGrid.setDaySlots(day, slots)
+	return newGrid, nil
+}
+
+// GrowStart moves a task's start one slot (15 minutes) earlier.
+// Tasks right before it are shifted earlier into the nearest gap if
+// necessary. Returns same grid if there is no room before the task (no-op).
+func (g *SlotGrid) GrowStart(t *task.Task) (*SlotGrid, error) {
+	if t == nil {
+		return nil, ErrSlotTaskNotFound
+	}
+
+	if err := g.canModifyTask(t); err != nil {
+		return nil, err
+	}
+
+	day, startSlot, _, found := g.FindTask(t)
+	if !found {
+		return nil, ErrSlotTaskNotFound
+	}
+
+	// Already at day start - no-op
+	if startSlot == 0 {
+		return g, nil
+	}
+
+	growSlot := startSlot - 1 // The slot we're growing into
+
+	// Find the tasks packed right before the task; they shift into the gap
+	// before them
+	runStart := startSlot
+	for runStart > 0 && g.TaskAt(day, runStart-1) != nil {
+		runStart--
+	}
+	if runStart == 0 {
+		// No gap to shift into - no-op
+		return g, nil
+	}
+	if g.isPastPosition(day, runStart-1) {
+		return nil, ErrTaskAlreadyStarted
+	}
+	for s := runStart; s < startSlot; s++ {
+		if g.TaskAt(day, s).IsOvernight() {
+			return nil, ErrOvernightTask
+		}
+	}
+
+	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
+
+	// Shift from left to right
+	for s := runStart - 1; s < growSlot; s++ {
+		slots[s] = slots[s+1]
+	}
+
+	// Add the new slot to the task
+	slots[growSlot] = t
+
+	newGrid.setDaySlots(day, slots)
+	return newGrid, nil
+}
+
+// ShrinkStart moves a task's start one slot (15 minutes) later.
+// Returns error if task is already at minimum (1 slot).
+func (g *SlotGrid) ShrinkStart(t *task.Task) (*SlotGrid, error) {
+	if t == nil {
+		return nil, ErrSlotTaskNotFound
+	}
+
+	if err := g.canModifyTask(t); err != nil {
+		return nil, err
+	}
+
+	day, startSlot, endSlot, found := g.FindTask(t)
+	if !found {
+		return nil, ErrSlotTaskNotFound
+	}
 
+	if endSlot-startSlot <= 1 {
+		return nil, ErrMinimumSlotsDuration
+	}
+
+	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
+	// Remove the first slot of the task
+	slots[startSlot] = nil
+
+	newGrid.setDaySlots(day, slots)
 	return newGrid, nil
 }
 
@@ -883,6 +1287,10 @@ func (g *SlotGrid) AddSpaceAt(day, insertSlot int) (*SlotGrid, error) {
 		return g, nil
 	}
 
+	if g.hasOvernightFrom(day, insertSlot) {
+		return nil, ErrOvernightTask
+	}
+
 	// Check if there's room to shift (would overflow?)
 	lastOccupied := -1
 	for s := SlotsPerDay - 1; s >= insertSlot; s-- {
@@ -897,14 +1305,16 @@ func (g *SlotGrid) AddSpaceAt(day, insertSlot int) (*SlotGrid, error) {
 	}
 
 	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
 
 	// Shift everything from insertSlot onward right by 1
 	for s := SlotsPerDay - 1; s > insertSlot; s-- {
-		newGrid.slots[newGrid.slotIndex(day, s)] = newGrid.slots[newGrid.slotIndex(day, s-1)]
+		slots[s] = slots[s-1]
 	}
 	// Clear the insert slot (now empty space)
-	newGrid.slots[newGrid.slotIndex(day, insertSlot)] = nil
+	slots[insertSlot] = nil
 
+	newGrid.setDaySlots(day, slots)
 	return newGrid, nil
 }
 
@@ -935,13 +1345,19 @@ func (g *SlotGrid) RemoveSpaceAt(day, removeSlot int) (*SlotGrid, error) {
 		return nil, ErrNoGapToRemove
 	}
 
+	if g.hasOvernightFrom(day, removeSlot+1) {
+		return nil, ErrOvernightTask
+	}
+
 	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
 
 	for s := removeSlot + 1; s < SlotsPerDay; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s-1)] = newGrid.slots[newGrid.slotIndex(day, s)]
+		slots[s-1] = slots[s]
 	}
-	newGrid.slots[newGrid.slotIndex(day, SlotsPerDay-1)] = nil
+	slots[SlotsPerDay-1] = nil
 
+	newGrid.setDaySlots(day, slots)
 	return newGrid, nil
 }
 
@@ -961,24 +1377,30 @@ func (g *SlotGrid) Delete(t *task.Task) (*SlotGrid, error) {
 		return nil, ErrSlotTaskNotFound
 	}
 
+	if g.hasOvernightFrom(day, endSlot) {
+		return nil, ErrOvernightTask
+	}
+
 	newGrid := g.clone()
+	slots := newGrid.daySlots(day)
 
 	// Clear the task slots
 	for s := startSlot; s < endSlot; s++ {
-		newGrid.slots[newGrid.slotIndex(day, s)] = nil
+		slots[s] = nil
 	}
 
 	// Shift following tasks left
 	gapStart := startSlot
 	for s := endSlot; s < SlotsPerDay; s++ {
-		slotTask := newGrid.TaskAt(day, s)
+		slotTask := slots[s]
 		if slotTask != nil {
-			newGrid.slots[newGrid.slotIndex(day, gapStart)] = slotTask
-			newGrid.slots[newGrid.slotIndex(day, s)] = nil
+			slots[gapStart] = slotTask
+			slots[s] = nil
 			gapStart++
 		}
 	}
 
+	newGrid.setDaySlots(day, slots)
 	return newGrid, nil
 }
 
@@ -62,6 +62,7 @@ func gridFromString(s string, cfg SlotConfig) *SlotGrid {
 		if dayIdx >= cfg.NumDays {
 			break
 		}
+		slots := make([]*task.Task, SlotsPerDay)
 		for slot, ch := range dayStr {
 			if slot >= SlotsPerDay {
 				break
@@ -76,10 +77,10 @@ func gridFromString(s string, cfg SlotConfig) *SlotGrid {
 					t = makeTask(id)
 					tasks[ch] = t
 				}
-				idx := grid.slotIndex(dayIdx, slot)
-				grid.slots[idx] = t
+				slots[slot] = t
 			}
 		}
+		grid.setDaySlots(dayIdx, slots)
 	}
 
 	return grid
@@ -196,14 +197,14 @@ func TestNewSlotGrid(t *testing.T) {
 	cfg := testConfig()
 	grid := NewSlotGrid(cfg)
 
-	if len(grid.slots) != cfg.TotalSlots() {
-		t.Errorf("len(slots) = %d, want %d", len(grid.slots), cfg.TotalSlots())
+	if len(grid.days) != cfg.NumDays {
+		t.Errorf("len(days) = %d, want %d", len(grid.days), cfg.NumDays)
 	}
 
-	// All slots should be nil (empty)
-	for i, slot := range grid.slots {
-		if slot != nil {
-			t.Errorf("slot[%d] should be nil, got %v", i, slot)
+	// All days should be empty
+	for i, spans := range grid.days {
+		if len(spans) != 0 {
+			t.Errorf("day %d should be empty, got %v", i, spans)
 		}
 	}
 }
@@ -537,12 +538,111 @@ func TestSlotGrid_Clone(t *testing.T) {
 	}
 
 	// Modifying cloned should not affect original
-	cloned.slots[0] = nil
+	cloned.setDaySlots(0, make([]*task.Task, SlotsPerDay))
 	if grid.TaskAt(0, 0) == nil {
 		t.Error("modifying clone affected original")
 	}
 }
 
+func TestSlotGrid_OperationsShareUntouchedDays(t *testing.T) {
+	cfg := testConfig()
+	grid := gridFromString("AA--BB--|CC------|DD------", cfg)
+	a := grid.TaskAt(0, 0)
+
+	moved, err := grid.MoveDown(a)
+	if err != nil {
+		t.Fatalf("MoveDown: %v", err)
+	}
+	if got := printDayPrefix(moved, 0, 8); got != "--AABB--" {
+		t.Errorf("day 0 = %q, want --AABB--", got)
+	}
+	if got := printDayPrefix(grid, 0, 8); got != "AA--BB--" {
+		t.Errorf("original day 0 changed to %q", got)
+	}
+	for day := 1; day <= 2; day++ {
+		if &moved.days[day][0] != &grid.days[day][0] {
+			t.Errorf("day %d was copied, want it shared with the original grid", day)
+		}
+	}
+
+	right, err := moved.MoveRight(a)
+	if err != nil {
+		t.Fatalf("MoveRight: %v", err)
+	}
+	if &right.days[2][0] != &grid.days[2][0] {
+		t.Error("day 2 was copied by a move between days 0 and 1")
+	}
+}
+
+func TestSlotGrid_IndexFollowsOperations(t *testing.T) {
+	cfg := testConfig()
+	grid := gridFromString("AA--BB--|CC------|DD------", cfg)
+	// Overnight task from 23:00 on day 1 into day 2
+	night := &task.Task{ID: 9, Status: task.StatusScheduled, ScheduledDate: cfg.DayIndexToDate(1), ScheduledStart: "23:00", ScheduledEnd: "01:00"}
+	slots := grid.daySlots(2)
+	slots[0], slots[1], slots[2], slots[3] = night, night, night, night
+	grid = grid.clone()
+	grid.setDaySlots(2, slots)
+	slots = grid.daySlots(1)
+	for s := 92; s < SlotsPerDay; s++ {
+		slots[s] = night
+	}
+	grid.setDaySlots(1, slots)
+
+	// scan is the linear lookup the index replaces.
+	scan := func(g *SlotGrid, id int64) (int, int, int, bool) {
+		for d := range g.days {
+			for s := 0; s < SlotsPerDay; s++ {
+				if tsk := g.TaskAt(d, s); tsk != nil && tsk.ID == id {
+					end := s
+					for end < SlotsPerDay && g.TaskAt(d, end) != nil && g.TaskAt(d, end).ID == id {
+						end++
+					}
+					return d, s, end, true
+				}
+			}
+		}
+		return 0, 0, 0, false
+	}
+	check := func(step string, g *SlotGrid) {
+		t.Helper()
+		for id := int64(0); id <= 9; id++ {
+			wd, ws, we, wf := scan(g, id)
+			d, s, e, f := g.FindTaskByID(id)
+			if d != wd || s != ws || e != we || f != wf {
+				t.Errorf("%s: FindTaskByID(%d) = %d,%d,%d,%v, want %d,%d,%d,%v", step, id, d, s, e, f, wd, ws, we, wf)
+			}
+		}
+	}
+
+	check("load", grid)
+	a, b, c := grid.TaskAt(0, 0), grid.TaskAt(0, 4), grid.TaskAt(1, 0)
+	steps := []struct {
+		name string
+		op   func(*SlotGrid) (*SlotGrid, error)
+	}{
+		{"move right", func(g *SlotGrid) (*SlotGrid, error) { return g.MoveRight(a) }},
+		{"move left", func(g *SlotGrid) (*SlotGrid, error) { return g.MoveLeft(a) }},
+		{"move down", func(g *SlotGrid) (*SlotGrid, error) { return g.MoveDown(b) }},
+		{"grow", func(g *SlotGrid) (*SlotGrid, error) { return g.Grow(c) }},
+		{"delete", func(g *SlotGrid) (*SlotGrid, error) { return g.Delete(b) }},
+		{"clear night's first day", func(g *SlotGrid) (*SlotGrid, error) {
+			next := g.clone()
+			next.setDaySlots(1, make([]*task.Task, SlotsPerDay))
+			return next, nil
+		}},
+	}
+	for _, step := range steps {
+		next, err := step.op(grid)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		check(step.name, next)
+		check(step.name+" (original)", grid)
+		grid = next
+	}
+}
+
 func TestSlotGrid_CurrentTimePosition(t *testing.T) {
 	baseDate := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
 
@@ -661,6 +761,37 @@ func TestSlotGrid_CanModifyTask(t *testing.T) {
 	}
 }
 
+func TestSlotGrid_OvernightTaskIsPinned(t *testing.T) {
+	firstDate := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	cfg := translateTestConfig(firstDate)
+
+	overnight := makeScheduledTask(1, firstDate, "23:00", "01:00")
+	evening := makeScheduledTask(2, firstDate, "20:00", "21:00")
+	morning := makeScheduledTask(3, firstDate.AddDate(0, 0, 1), "08:00", "09:00")
+	grid := TasksToSlotGrid([]*task.Task{overnight, evening, morning}, cfg)
+
+	if _, err := grid.MoveDown(overnight); err != ErrOvernightTask {
+		t.Errorf("MoveDown(overnight) = %v, want ErrOvernightTask", err)
+	}
+	// Removing the gap before the overnight task would pull it off midnight
+	if _, err := grid.RemoveSpaceAt(0, 21*4); err != ErrOvernightTask {
+		t.Errorf("RemoveSpaceAt before overnight = %v, want ErrOvernightTask", err)
+	}
+	// Inserting space at the start of the next day would push the spilled part
+	if _, err := grid.AddSpaceAt(1, 0); err != ErrOvernightTask {
+		t.Errorf("AddSpaceAt in spilled part = %v, want ErrOvernightTask", err)
+	}
+
+	// Tasks clear of the overnight block still move freely
+	moved, err := grid.MoveUp(morning)
+	if err != nil {
+		t.Fatalf("MoveUp(morning) error = %v", err)
+	}
+	if _, start, _, _ := moved.FindTask(morning); start >= 8*4 {
+		t.Errorf("morning start slot = %d, want earlier than %d", start, 8*4)
+	}
+}
+
 func TestSlotGrid_IsPastPosition(t *testing.T) {
 	// Now is 2030-01-01 09:30 (day 0, slot 38)
 	now := time.Date(2030, 1, 1, 9, 30, 0, 0, time.UTC)
@@ -696,6 +827,159 @@ func TestSlotGrid_IsPastPosition(t *testing.T) {
 	}
 }
 
+func TestSlotGrid_IsPastPosition_PinnedTimezone(t *testing.T) {
+	// Now is 2030-01-01 09:30 UTC, which is 19:30 on day 0 in UTC+10
+	now := time.Date(2030, 1, 1, 9, 30, 0, 0, time.UTC)
+	pinned := time.FixedZone("AEST", 10*3600)
+	cfg := SlotConfig{
+		SlotDuration: 15,
+		NumDays:      7,
+		FirstDate:    time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		Now:          func() time.Time { return now },
+		Location: func(date time.Time) *time.Location {
+			if date.Day() == 1 {
+				return pinned
+			}
+			return nil
+		},
+	}
+
+	grid := NewSlotGrid(cfg)
+
+	tests := []struct {
+		day      int
+		slot     int
+		wantPast bool
+	}{
+		{day: 0, slot: 60, wantPast: true},  // 15:00 pinned day, already 19:30 there
+		{day: 0, slot: 78, wantPast: true},  // 19:30 pinned day (current slot)
+		{day: 0, slot: 79, wantPast: false}, // 19:45 pinned day
+		{day: 1, slot: 0, wantPast: false},  // unpinned next day
+	}
+
+	for _, tt := range tests {
+		got := grid.isPastPosition(tt.day, tt.slot)
+		if got != tt.wantPast {
+			t.Errorf("isPastPosition(%d, %d) = %v, want %v", tt.day, tt.slot, got, tt.wantPast)
+		}
+	}
+}
+
+func TestSlotConfig_DateToDayIndex_DST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	cfg := SlotConfig{
+		SlotDuration: 15,
+		NumDays:      21,
+		FirstDate:    time.Date(2026, 3, 2, 0, 0, 0, 0, loc), // spring forward on 2026-03-08
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want int
+	}{
+		{"day before transition", time.Date(2026, 3, 7, 0, 0, 0, 0, loc), 5},
+		{"transition day", time.Date(2026, 3, 8, 0, 0, 0, 0, loc), 6},
+		{"monday after transition", time.Date(2026, 3, 9, 0, 0, 0, 0, loc), 7},
+		{"late on last day", time.Date(2026, 3, 22, 23, 45, 0, 0, loc), 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cfg.DateToDayIndex(tt.date)
+			if got != tt.want {
+				t.Errorf("DateToDayIndex(%s) = %d, want %d", tt.date.Format("2006-01-02"), got, tt.want)
+			}
+			if back := cfg.DayIndexToDate(got); !sameDay(back, tt.date) {
+				t.Errorf("DayIndexToDate(%d) = %s, want %s", got, back, tt.date)
+			}
+		})
+	}
+}
+
+func TestSlotConfig_DayIndexRoundTrip_YearBoundaryAndLeapDay(t *testing.T) {
+	firstDates := []time.Time{
+		time.Date(2024, 12, 23, 0, 0, 0, 0, time.Local), // Window spans Dec 31, 2024 -> Jan 1, 2025
+		time.Date(2025, 12, 22, 0, 0, 0, 0, time.Local), // Dec 31 is a Wednesday
+		time.Date(2024, 2, 19, 0, 0, 0, 0, time.Local),  // Window contains Feb 29, 2024
+		time.Date(2028, 2, 21, 0, 0, 0, 0, time.Local),  // Window contains Feb 29, 2028
+		time.Date(2025, 2, 17, 0, 0, 0, 0, time.Local),  // Non-leap year Feb 28 -> Mar 1
+	}
+
+	for _, first := range firstDates {
+		cfg := SlotConfig{FirstDate: first, NumDays: 21}
+
+		prev := cfg.DayIndexToDate(0)
+		for dayIndex := 0; dayIndex < cfg.NumDays; dayIndex++ {
+			date := cfg.DayIndexToDate(dayIndex)
+			if got := cfg.DateToDayIndex(date); got != dayIndex {
+				t.Errorf("%s: DateToDayIndex(DayIndexToDate(%d)) = %d", first.Format("2006-01-02"), dayIndex, got)
+			}
+			if got := cfg.DateToDayIndex(time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 0, 0, date.Location())); got != dayIndex {
+				t.Errorf("%s: late on day %d mapped to %d", first.Format("2006-01-02"), dayIndex, got)
+			}
+			if dayIndex > 0 && task.CalendarDaysBetween(prev, date) != 1 {
+				t.Errorf("%s: day %d (%s) does not follow %s", first.Format("2006-01-02"), dayIndex, date.Format("2006-01-02"), prev.Format("2006-01-02"))
+			}
+			prev = date
+		}
+
+		if got := cfg.DateToDayIndex(first.AddDate(0, 0, -1)); got != -1 {
+			t.Errorf("%s: day before grid = %d, want -1", first.Format("2006-01-02"), got)
+		}
+		if got := cfg.DateToDayIndex(first.AddDate(0, 0, cfg.NumDays)); got != -1 {
+			t.Errorf("%s: day after grid = %d, want -1", first.Format("2006-01-02"), got)
+		}
+	}
+
+	// Feb 29 lands on the expected column.
+	cfg := SlotConfig{FirstDate: time.Date(2024, 2, 19, 0, 0, 0, 0, time.Local), NumDays: 21}
+	if got := cfg.DateToDayIndex(time.Date(2024, 2, 29, 10, 0, 0, 0, time.Local)); got != 10 {
+		t.Errorf("Feb 29 day index = %d, want 10", got)
+	}
+	// A UTC-midnight Jan 1 maps to the Jan 1 column regardless of local zone.
+	cfg = SlotConfig{FirstDate: time.Date(2024, 12, 23, 0, 0, 0, 0, time.Local), NumDays: 21}
+	if got := cfg.DateToDayIndex(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); got != 9 {
+		t.Errorf("Jan 1 day index = %d, want 9", got)
+	}
+}
+
+func TestSlotGrid_IsPastPosition_DSTFallBack(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	// Second pass through 01:15 on 2026-11-01 (EST, after clocks went back)
+	now := time.Date(2026, 11, 1, 1, 15, 0, 0, loc).Add(time.Hour)
+	cfg := SlotConfig{
+		SlotDuration: 15,
+		NumDays:      7,
+		FirstDate:    time.Date(2026, 10, 26, 0, 0, 0, 0, loc),
+		Now:          func() time.Time { return now },
+	}
+	grid := NewSlotGrid(cfg)
+
+	tests := []struct {
+		slot     int
+		wantPast bool
+	}{
+		{slot: 5, wantPast: true},  // 01:15 passed in the first pass
+		{slot: 7, wantPast: true},  // 01:45 passed in the first pass
+		{slot: 8, wantPast: false}, // 02:00 is still ahead
+	}
+
+	for _, tt := range tests {
+		if got := grid.isPastPosition(6, tt.slot); got != tt.wantPast {
+			t.Errorf("isPastPosition(6, %d) = %v, want %v", tt.slot, got, tt.wantPast)
+		}
+	}
+}
+
 // =============================================================================
 // MoveDown Tests
 // =============================================================================
@@ -796,6 +1080,35 @@ func TestSlotGrid_MoveDown_PreservesLength(t *testing.T) {
 // MoveUp Tests
 // =============================================================================
 
+func TestSlotGrid_MoveByStep(t *testing.T) {
+	cfg := testConfig()
+	grid := gridFromString("--------AA------", cfg)
+	tsk := grid.TaskAt(0, 8)
+
+	tests := []struct {
+		name string
+		move func(*task.Task, int) (*SlotGrid, error)
+		step int
+		want string
+	}{
+		{name: "down one slot", move: grid.MoveDownBy, step: 1, want: "---------AA-----"},
+		{name: "down an hour", move: grid.MoveDownBy, step: 4, want: "------------AA--"},
+		{name: "up an hour", move: grid.MoveUpBy, step: 4, want: "----AA----------"},
+		{name: "zero uses display size", move: grid.MoveUpBy, step: 0, want: "------AA--------"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newGrid, err := tt.move(tsk, tt.step)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := printDayPrefix(newGrid, 0, len(tt.want)); got != tt.want {
+				t.Errorf("result = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSlotGrid_MoveUp(t *testing.T) {
 	cfg := testConfig()
 
@@ -1260,6 +1573,77 @@ func TestSlotGrid_Grow_NoOp(t *testing.T) {
 // Shrink Tests
 // =============================================================================
 
+func TestSlotGrid_GrowStartAndShrinkStart(t *testing.T) {
+	cfg := testConfig()
+
+	tests := []struct {
+		name       string
+		initial    string
+		taskLetter rune
+		shrink     bool
+		wantDay0   string
+		wantErr    error
+	}{
+		{
+			name:       "grow start into empty space",
+			initial:    "--AA----",
+			taskLetter: 'A',
+			wantDay0:   "-AAA----",
+		},
+		{
+			name:       "grow start shifts preceding tasks",
+			initial:    "--AABB--",
+			taskLetter: 'B',
+			wantDay0:   "-AABBB--",
+		},
+		{
+			name:       "grow start at day start - no-op",
+			initial:    "AABB----",
+			taskLetter: 'B',
+			wantDay0:   "AABB----",
+		},
+		{
+			name:       "shrink start",
+			initial:    "AAA-----",
+			taskLetter: 'A',
+			shrink:     true,
+			wantDay0:   "-AA-----",
+		},
+		{
+			name:       "shrink start minimum - error",
+			initial:    "A-------",
+			taskLetter: 'A',
+			shrink:     true,
+			wantErr:    ErrMinimumSlotsDuration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := gridFromString(tt.initial, cfg)
+			taskID := int64(tt.taskLetter - 'A')
+			tsk := grid.TaskAt(0, findTaskStart(grid, taskID))
+
+			op := grid.GrowStart
+			if tt.shrink {
+				op = grid.ShrinkStart
+			}
+			newGrid, err := op(tsk)
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := printDayPrefix(newGrid, 0, len(tt.wantDay0))
+			if got != tt.wantDay0 {
+				t.Errorf("result = %q, want %q", got, tt.wantDay0)
+			}
+		})
+	}
+}
+
 func TestSlotGrid_Shrink(t *testing.T) {
 	cfg := testConfig()
 
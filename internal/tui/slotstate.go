@@ -3,6 +3,9 @@ package tui
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/javiermolinar/sancho/internal/task"
@@ -12,6 +15,8 @@ import (
 var (
 	ErrSlotNotInEditMode = errors.New("not in edit mode")
 	ErrSlotNothingToUndo = errors.New("nothing to undo")
+	ErrSlotNothingToRedo = errors.New("nothing to redo")
+	ErrSlotNoSavepoint   = errors.New("no such savepoint")
 	ErrSlotNotMoving     = errors.New("not in move mode")
 	ErrSlotAlreadyMoving = errors.New("already moving a task")
 )
@@ -26,6 +31,39 @@ type SlotHistoryEntry struct {
 	Grid        *SlotGrid // The grid state before the operation
 }
 
+// SaveConflictError is returned by SaveChanges when some changes could not
+// be written because another process changed the tasks or wrote tasks they
+// overlap. The other changes were saved.
+type SaveConflictError struct {
+	Tasks   []*task.Task // Left unchanged because they overlap, at the times they were given
+	Changed []*task.Task // Left unchanged because another process wrote them since they were read
+}
+
+func (e *SaveConflictError) Error() string {
+	n := len(e.Tasks) + len(e.Changed)
+	if n == 1 {
+		return "1 change conflicts with the saved schedule"
+	}
+	return fmt.Sprintf("%d changes conflict with the saved schedule", n)
+}
+
+func (e *SaveConflictError) Unwrap() []error {
+	var errs []error
+	if len(e.Tasks) > 0 {
+		errs = append(errs, task.ErrTimeBlockOverlap)
+	}
+	if len(e.Changed) > 0 {
+		errs = append(errs, task.ErrTaskChanged)
+	}
+	return errs
+}
+
+// slotSavepoint is a named snapshot of the working grid.
+type slotSavepoint struct {
+	grid      *SlotGrid
+	dirtyDays map[int]bool // Days modified when the snapshot was taken
+}
+
 // SlotMoveState contains information needed to render move mode.
 type SlotMoveState struct {
 	MovingTask   *task.Task   // The task being moved
@@ -55,6 +93,14 @@ type SlotStateManager struct {
 	history    []SlotHistoryEntry
 	maxHistory int
 
+	// Redo stack: the grids undone since the last edit, most recent last.
+	// redoDirty keeps the dirty days that undoing everything cleared.
+	redo      []SlotHistoryEntry
+	redoDirty map[int]bool
+
+	// Named snapshots of the working grid, kept until the session ends
+	savepoints map[rune]slotSavepoint
+
 	// Track which days have been modified (for efficient persistence)
 	dirtyDays map[int]bool
 
@@ -107,6 +153,7 @@ func (sm *SlotStateManager) SetGrid(grid *SlotGrid) {
 	sm.savedGrid = grid
 	if sm.editing {
 		sm.workingGrid = grid.clone()
+		sm.savepoints = nil
 	}
 }
 
@@ -123,6 +170,8 @@ func (sm *SlotStateManager) EnterEditMode() {
 	sm.editing = true
 	sm.workingGrid = sm.savedGrid.clone()
 	sm.history = nil
+	sm.clearRedo()
+	sm.savepoints = nil
 	sm.dirtyDays = make(map[int]bool)
 }
 
@@ -131,6 +180,8 @@ func (sm *SlotStateManager) DiscardChanges() {
 	sm.editing = false
 	sm.workingGrid = nil
 	sm.history = nil
+	sm.clearRedo()
+	sm.savepoints = nil
 	sm.dirtyDays = make(map[int]bool)
 	sm.clearMoveState()
 }
@@ -172,17 +223,108 @@ func (sm *SlotStateManager) Undo() error {
 	entry := sm.history[len(sm.history)-1]
 	sm.history = sm.history[:len(sm.history)-1]
 
+	// Keep the current grid so the operation can be redone
+	sm.redo = append(sm.redo, SlotHistoryEntry{
+		Description: entry.Description,
+		Grid:        sm.workingGrid,
+	})
+
 	// Restore the grid from the snapshot (just use the reference!)
 	sm.workingGrid = entry.Grid
 
 	// Recalculate dirty days
 	if len(sm.history) == 0 {
+		sm.redoDirty = sm.dirtyDays
 		sm.dirtyDays = make(map[int]bool)
 	}
 
 	return nil
 }
 
+// CanRedo returns true if there are undone operations to redo.
+func (sm *SlotStateManager) CanRedo() bool {
+	return sm.editing && len(sm.redo) > 0
+}
+
+// RedoCount returns the number of operations that can be redone.
+func (sm *SlotStateManager) RedoCount() int {
+	return len(sm.redo)
+}
+
+// Redo restores the last undone operation. Any new edit clears what is
+// left to redo.
+func (sm *SlotStateManager) Redo() error {
+	if !sm.editing {
+		return ErrSlotNotInEditMode
+	}
+	if len(sm.redo) == 0 {
+		return ErrSlotNothingToRedo
+	}
+
+	entry := sm.redo[len(sm.redo)-1]
+	sm.redo = sm.redo[:len(sm.redo)-1]
+
+	sm.history = append(sm.history, SlotHistoryEntry{
+		Description: entry.Description,
+		Grid:        sm.workingGrid,
+	})
+	sm.workingGrid = entry.Grid
+
+	if len(sm.dirtyDays) == 0 {
+		for day := range sm.redoDirty {
+			sm.dirtyDays[day] = true
+		}
+	}
+
+	return nil
+}
+
+// SetSavepoint snapshots the working grid under name, replacing any
+// savepoint of that name.
+func (sm *SlotStateManager) SetSavepoint(name rune) error {
+	if !sm.editing {
+		return ErrSlotNotInEditMode
+	}
+	if sm.savepoints == nil {
+		sm.savepoints = make(map[rune]slotSavepoint)
+	}
+	sm.savepoints[name] = slotSavepoint{
+		grid:      sm.workingGrid.clone(),
+		dirtyDays: sm.DirtyDays(),
+	}
+	return nil
+}
+
+// RestoreSavepoint replaces the working grid with the savepoint name as
+// one undo step. The savepoint is kept so it can be restored again.
+func (sm *SlotStateManager) RestoreSavepoint(name rune) error {
+	if !sm.editing {
+		return ErrSlotNotInEditMode
+	}
+	sp, ok := sm.savepoints[name]
+	if !ok {
+		return ErrSlotNoSavepoint
+	}
+
+	sm.pushHistory("Restore: '" + string(name))
+
+	// Days changed before the savepoint differ from the saved grid again;
+	// those changed since stay dirty, as they differ from the savepoint.
+	for day := range sp.dirtyDays {
+		sm.markDayDirty(day)
+	}
+	sm.clearRedo()
+
+	sm.workingGrid = sp.grid.clone()
+	return nil
+}
+
+// clearRedo drops the undone operations.
+func (sm *SlotStateManager) clearRedo() {
+	sm.redo = nil
+	sm.redoDirty = nil
+}
+
 // pushHistory saves the current state before a modification.
 func (sm *SlotStateManager) pushHistory(description string) {
 	if len(sm.history) >= sm.maxHistory {
@@ -197,9 +339,11 @@ func (sm *SlotStateManager) pushHistory(description string) {
 	})
 }
 
-// markDayDirty marks a day as modified.
+// markDayDirty marks a day as modified. Every edit that succeeds marks
+// its days, so this is also where a new edit drops the redo stack.
 func (sm *SlotStateManager) markDayDirty(dayIndex int) {
 	sm.dirtyDays[dayIndex] = true
+	sm.clearRedo()
 }
 
 // clearMoveState clears all move-related state.
@@ -267,33 +411,53 @@ func (sm *SlotStateManager) StartMove(t *task.Task) error {
 	return nil
 }
 
-// MoveUp moves the task to earlier time on the same day.
+// MoveUp moves the task to earlier time on the same day, by the display
+// slot size into a gap.
 // Each call accumulates on workingGrid. Use CancelMove to revert all moves.
 func (sm *SlotStateManager) MoveUp() error {
-	if !sm.isMoving {
-		return ErrSlotNotMoving
-	}
-
-	newGrid, err := sm.workingGrid.MoveUp(sm.movingTask)
-	if err != nil {
-		return err
-	}
+	return sm.MoveUpBy(0, 1)
+}
 
-	sm.workingGrid = newGrid
-	sm.updateMoveState()
-	return nil
+// MoveUpBy moves the task to earlier time on the same day count times, by
+// step slots into a gap each time (0 = the display slot size).
+func (sm *SlotStateManager) MoveUpBy(step, count int) error {
+	return sm.moveRepeat(count, func(g *SlotGrid) (*SlotGrid, error) {
+		return g.MoveUpBy(sm.movingTask, step)
+	})
 }
 
-// MoveDown moves the task to later time on the same day.
+// MoveDown moves the task to later time on the same day, by the display
+// slot size into a gap.
 // Each call accumulates on workingGrid. Use CancelMove to revert all moves.
 func (sm *SlotStateManager) MoveDown() error {
+	return sm.MoveDownBy(0, 1)
+}
+
+// MoveDownBy moves the task to later time on the same day count times, by
+// step slots into a gap each time (0 = the display slot size).
+func (sm *SlotStateManager) MoveDownBy(step, count int) error {
+	return sm.moveRepeat(count, func(g *SlotGrid) (*SlotGrid, error) {
+		return g.MoveDownBy(sm.movingTask, step)
+	})
+}
+
+// moveRepeat applies a move to the working grid count times. Repeats stop
+// at the first that fails; only a failure of the first is returned.
+func (sm *SlotStateManager) moveRepeat(count int, move func(*SlotGrid) (*SlotGrid, error)) error {
 	if !sm.isMoving {
 		return ErrSlotNotMoving
 	}
 
-	newGrid, err := sm.workingGrid.MoveDown(sm.movingTask)
-	if err != nil {
-		return err
+	newGrid := sm.workingGrid
+	for i := 0; i < max(count, 1); i++ {
+		next, err := move(newGrid)
+		if err != nil {
+			if i > 0 {
+				break
+			}
+			return err
+		}
+		newGrid = next
 	}
 
 	sm.workingGrid = newGrid
@@ -426,41 +590,51 @@ func (sm *SlotStateManager) CancelMove() {
 
 // Grow extends a task by one slot, shifting subsequent tasks if needed.
 func (sm *SlotStateManager) Grow(t *task.Task) error {
-	if !sm.editing {
-		return ErrSlotNotInEditMode
-	}
-	if t == nil {
-		return ErrSlotTaskNotFound
-	}
+	return sm.GrowBy(t, 1)
+}
 
-	// Check if task can be modified
-	if err := sm.workingGrid.canModifyTask(t); err != nil {
-		return err
-	}
+// GrowBy extends a task by n slots as one undo step.
+func (sm *SlotStateManager) GrowBy(t *task.Task, n int) error {
+	return sm.resize("Grow: ", t, n, (*SlotGrid).Grow)
+}
 
-	// Push history before modification
-	sm.pushHistory("Grow: " + t.Description)
+// Shrink reduces a task by one slot.
+func (sm *SlotStateManager) Shrink(t *task.Task) error {
+	return sm.ShrinkBy(t, 1)
+}
 
-	// Perform grow
-	newGrid, err := sm.workingGrid.Grow(t)
-	if err != nil {
-		// Pop history since operation failed
-		sm.history = sm.history[:len(sm.history)-1]
-		return err
-	}
+// ShrinkBy reduces a task by n slots as one undo step, stopping at one
+// slot.
+func (sm *SlotStateManager) ShrinkBy(t *task.Task, n int) error {
+	return sm.resize("Shrink: ", t, n, (*SlotGrid).Shrink)
+}
 
-	// Find which day was affected
-	day, _, _, found := sm.workingGrid.FindTask(t)
-	if found {
-		sm.markDayDirty(day)
-	}
+// GrowStart starts a task one slot earlier, shifting preceding tasks if
+// needed.
+func (sm *SlotStateManager) GrowStart(t *task.Task) error {
+	return sm.GrowStartBy(t, 1)
+}
 
-	sm.workingGrid = newGrid
-	return nil
+// GrowStartBy starts a task n slots earlier as one undo step.
+func (sm *SlotStateManager) GrowStartBy(t *task.Task, n int) error {
+	return sm.resize("Grow start: ", t, n, (*SlotGrid).GrowStart)
 }
 
-// Shrink reduces a task by one slot.
-func (sm *SlotStateManager) Shrink(t *task.Task) error {
+// ShrinkStart starts a task one slot later.
+func (sm *SlotStateManager) ShrinkStart(t *task.Task) error {
+	return sm.ShrinkStartBy(t, 1)
+}
+
+// ShrinkStartBy starts a task n slots later as one undo step, stopping at
+// one slot.
+func (sm *SlotStateManager) ShrinkStartBy(t *task.Task, n int) error {
+	return sm.resize("Shrink start: ", t, n, (*SlotGrid).ShrinkStart)
+}
+
+// resize applies a grid operation changing t's length n times, with one
+// undo entry named by label and t's description. Repeats stop at the
+// first that fails; only a failure of the first is returned.
+func (sm *SlotStateManager) resize(label string, t *task.Task, n int, op func(*SlotGrid, *task.Task) (*SlotGrid, error)) error {
 	if !sm.editing {
 		return ErrSlotNotInEditMode
 	}
@@ -474,14 +648,20 @@ func (sm *SlotStateManager) Shrink(t *task.Task) error {
 	}
 
 	// Push history before modification
-	sm.pushHistory("Shrink: " + t.Description)
-
-	// Perform shrink
-	newGrid, err := sm.workingGrid.Shrink(t)
-	if err != nil {
-		// Pop history since operation failed
-		sm.history = sm.history[:len(sm.history)-1]
-		return err
+	sm.pushHistory(label + t.Description)
+
+	newGrid := sm.workingGrid
+	for i := 0; i < max(n, 1); i++ {
+		next, err := op(newGrid, t)
+		if err != nil {
+			if i > 0 {
+				break
+			}
+			// Pop history since operation failed
+			sm.history = sm.history[:len(sm.history)-1]
+			return err
+		}
+		newGrid = next
 	}
 
 	// Find which day was affected
@@ -622,17 +802,128 @@ func (sm *SlotStateManager) CommitChanges() {
 	sm.editing = false
 	sm.workingGrid = nil
 	sm.history = nil
+	sm.clearRedo()
+	sm.savepoints = nil
 	sm.dirtyDays = make(map[int]bool)
 	sm.clearMoveState()
 }
 
+// PendingChange is a task whose day or times the edit session changes.
+type PendingChange struct {
+	Before *task.Task // As saved
+	After  *task.Task // As SaveChanges would write it
+}
+
+// PendingChanges lists what SaveChanges would write, ordered by the new
+// day and start.
+func (sm *SlotStateManager) PendingChanges() []PendingChange {
+	if !sm.editing {
+		return nil
+	}
+	changes := GetChangedTasks(sm.savedGrid, sm.workingGrid).UpdatedTasks
+	sortByDateAndStart(changes)
+
+	pending := make([]PendingChange, 0, len(changes))
+	for _, after := range changes {
+		before := *after
+		if day, start, end, found := sm.savedGrid.FindTaskByID(after.ID); found {
+			before.ScheduledDate = sm.savedGrid.config.DayIndexToDate(day)
+			before.ScheduledStart = sm.savedGrid.config.SlotToTime(start)
+			before.ScheduledEnd = sm.savedGrid.config.SlotToTime(end)
+		}
+		pending = append(pending, PendingChange{Before: &before, After: after})
+	}
+	return pending
+}
+
+// ApplyChanges makes the changes of an earlier edit session, such as an
+// autosaved one, as one undo step. A change is skipped when its task is no
+// longer where the change found it, cannot be edited, or would land on a
+// task that stays. It returns how many changes were skipped.
+func (sm *SlotStateManager) ApplyChanges(changes []PendingChange) (int, error) {
+	if !sm.editing {
+		return 0, ErrSlotNotInEditMode
+	}
+
+	g := sm.workingGrid
+	cfg := g.config
+	var moves []slotMove
+	for _, c := range changes {
+		day, start, end, found := g.FindTaskByID(c.Before.ID)
+		if !found {
+			continue
+		}
+		t := g.TaskAt(day, start)
+		if t == nil || g.canModifyTask(t) != nil {
+			continue
+		}
+		if cfg.DateToDayIndex(c.Before.ScheduledDate) != day ||
+			cfg.SlotToTime(start) != c.Before.ScheduledStart ||
+			cfg.SlotToTime(end) != c.Before.ScheduledEnd {
+			continue
+		}
+
+		mv := slotMove{
+			task:  t,
+			day:   cfg.DateToDayIndex(c.After.ScheduledDate),
+			start: cfg.TimeToSlot(c.After.ScheduledStart),
+			end:   (task.TimeToMinutes(c.After.ScheduledEnd) + cfg.SlotDuration - 1) / cfg.SlotDuration,
+		}
+		if mv.day < 0 || mv.end <= mv.start || mv.end > SlotsPerDay || g.isPastPosition(mv.day, mv.start) {
+			continue
+		}
+		moves = append(moves, mv)
+	}
+	skipped := len(changes) - len(moves)
+
+	for len(moves) > 0 {
+		newGrid, blocked := g.reposition(moves)
+		if len(blocked) > 0 {
+			moves = slices.DeleteFunc(moves, func(mv slotMove) bool {
+				return slices.ContainsFunc(blocked, func(b slotMove) bool { return b.task == mv.task })
+			})
+			skipped += len(blocked)
+			continue
+		}
+
+		sm.pushHistory("Restore: autosaved edits")
+		for _, mv := range moves {
+			day, _, _, _ := g.FindTask(mv.task)
+			sm.markDayDirty(day)
+			sm.markDayDirty(mv.day)
+		}
+		sm.workingGrid = newGrid
+		break
+	}
+	return skipped, nil
+}
+
+// sortByDateAndStart orders tasks by scheduled day, then start time.
+func sortByDateAndStart(tasks []*task.Task) {
+	sort.Slice(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if !a.ScheduledDate.Equal(b.ScheduledDate) {
+			return a.ScheduledDate.Before(b.ScheduledDate)
+		}
+		return a.ScheduledStart < b.ScheduledStart
+	})
+}
+
 // ============================================================================
 // Repository Integration
 // ============================================================================
 
 // SaveChanges persists all modifications to the database using the provided repository.
-// It extracts changed tasks from the grid and updates them in the database.
+// It extracts changed tasks from the grid and writes them in one batch, so
+// tasks moved across days commit together or not at all.
 // After saving, it exits edit mode and updates the saved grid.
+//
+// When another process wrote the changed tasks, or tasks they overlap,
+// since the week was loaded, the changes are written one at a time and
+// those that still conflict are skipped. A *SaveConflictError lists them;
+// the saved grid no longer matches the database then and the week should be
+// reloaded. Saved tasks take the version their write gave them, so they can
+// be edited again before the reload.
 func (sm *SlotStateManager) SaveChanges(ctx context.Context, repo task.Repository) error {
 	if !sm.editing {
 		return nil
@@ -641,39 +932,99 @@ func (sm *SlotStateManager) SaveChanges(ctx context.Context, repo task.Repositor
 	// Get the changes between saved and working grids
 	changes := GetChangedTasks(sm.savedGrid, sm.workingGrid)
 
-	// Group updates by the NEW date (after move)
-	updatesByDate := make(map[string][]task.TaskTimeUpdate)
-	dateMap := make(map[string]time.Time)
-
+	updates := make([]task.TaskTimeUpdate, 0, len(changes.UpdatedTasks))
+	byID := make(map[int64]*task.Task, len(changes.UpdatedTasks))
 	for _, t := range changes.UpdatedTasks {
-		dateKey := t.ScheduledDate.Format("2006-01-02")
-		updatesByDate[dateKey] = append(updatesByDate[dateKey], task.TaskTimeUpdate{
+		updates = append(updates, task.TaskTimeUpdate{
 			ID:       t.ID,
+			Date:     t.ScheduledDate,
 			NewStart: t.ScheduledStart,
 			NewEnd:   t.ScheduledEnd,
+			Version:  t.Version,
 		})
-		dateMap[dateKey] = t.ScheduledDate
+		byID[t.ID] = t
 	}
 
-	// Persist each day's updates
-	for dateKey, updates := range updatesByDate {
-		if len(updates) == 0 {
-			continue
+	var conflict SaveConflictError
+	err := repo.BatchUpdateTaskTimes(ctx, time.Time{}, updates)
+	if errors.Is(err, task.ErrTimeBlockOverlap) || errors.Is(err, task.ErrTaskChanged) {
+		var overlapping, changed []task.TaskTimeUpdate
+		overlapping, changed, err = saveEach(ctx, repo, updates)
+		for _, u := range overlapping {
+			conflict.Tasks = append(conflict.Tasks, byID[u.ID])
+			delete(byID, u.ID)
 		}
-		date := dateMap[dateKey]
-		if err := repo.BatchUpdateTaskTimes(ctx, date, updates); err != nil {
-			return err
+		for _, u := range changed {
+			conflict.Changed = append(conflict.Changed, byID[u.ID])
+			delete(byID, u.ID)
 		}
 	}
+	if err != nil {
+		return err
+	}
+	if err := adoptVersions(ctx, repo, sm.workingGrid, byID); err != nil {
+		return err
+	}
 
 	// Update saved state and exit edit mode
 	sm.savedGrid = sm.workingGrid
 	sm.editing = false
 	sm.workingGrid = nil
 	sm.history = nil
+	sm.clearRedo()
+	sm.savepoints = nil
 	sm.dirtyDays = make(map[int]bool)
 	sm.clearMoveState()
 
+	if len(conflict.Tasks) > 0 || len(conflict.Changed) > 0 {
+		sortByDateAndStart(conflict.Tasks)
+		sortByDateAndStart(conflict.Changed)
+		return &conflict
+	}
+	return nil
+}
+
+// saveEach writes updates one at a time, repeating until no more can be
+// written, since one change may make room for another. It returns the
+// updates that overlap the stored schedule, and apart from them those whose
+// task another process wrote since it was read, which no retry can save.
+func saveEach(ctx context.Context, repo task.Repository, updates []task.TaskTimeUpdate) (overlapping, changed []task.TaskTimeUpdate, err error) {
+	for {
+		var skipped []task.TaskTimeUpdate
+		for _, u := range updates {
+			err := repo.BatchUpdateTaskTimes(ctx, u.Date, []task.TaskTimeUpdate{u})
+			switch {
+			case errors.Is(err, task.ErrTaskChanged):
+				changed = append(changed, u)
+			case errors.Is(err, task.ErrTimeBlockOverlap):
+				skipped = append(skipped, u)
+			case err != nil:
+				return nil, nil, err
+			}
+		}
+		if len(skipped) == 0 || len(skipped) == len(updates) {
+			return skipped, changed, nil
+		}
+		updates = skipped
+	}
+}
+
+// adoptVersions sets the grid's copies of the saved tasks to their stored
+// version, so the next save does not take the write it just made for a
+// change by another process.
+func adoptVersions(ctx context.Context, repo task.Repository, grid *SlotGrid, saved map[int64]*task.Task) error {
+	for _, t := range grid.AllTasks() {
+		if t == nil || saved[t.ID] == nil {
+			continue
+		}
+		stored, err := repo.GetTask(ctx, t.ID)
+		if err != nil {
+			return fmt.Errorf("reading saved task: %w", err)
+		}
+		if stored != nil {
+			t.Version = stored.Version
+		}
+	}
 	return nil
 }
 
@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/javiermolinar/sancho/internal/memrepo"
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
@@ -190,6 +193,131 @@ func TestSlotStateManager_UndoErrors(t *testing.T) {
 	}
 }
 
+func TestSlotStateManager_Redo(t *testing.T) {
+	cfg := stateTestConfig()
+	sm := NewSlotStateManager(cfg)
+
+	grid := gridFromString("AAAA----", cfg)
+	sm.SetGrid(grid)
+	sm.EnterEditMode()
+
+	taskA := getTaskByID(sm.Grid(), 0)
+
+	if err := sm.Redo(); err != ErrSlotNothingToRedo {
+		t.Errorf("expected ErrSlotNothingToRedo, got %v", err)
+	}
+
+	if err := sm.Grow(taskA); err != nil {
+		t.Fatalf("Grow failed: %v", err)
+	}
+	if err := sm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if sm.HasChanges() {
+		t.Error("should have no changes after undoing everything")
+	}
+	if !sm.CanRedo() {
+		t.Fatal("should be able to redo")
+	}
+
+	if err := sm.Redo(); err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+	if _, _, endSlot, _ := sm.Grid().FindTask(taskA); endSlot != 5 {
+		t.Errorf("task should have grown again: got end %d, want 5", endSlot)
+	}
+	if !sm.HasChanges() || !sm.DirtyDays()[0] {
+		t.Error("day 0 should be dirty after redo")
+	}
+	if sm.UndoCount() != 1 || sm.CanRedo() {
+		t.Errorf("after redo: undo count %d, can redo %v", sm.UndoCount(), sm.CanRedo())
+	}
+
+	// A new edit drops what is left to redo.
+	if err := sm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := sm.Shrink(taskA); err != nil {
+		t.Fatalf("Shrink failed: %v", err)
+	}
+	if sm.CanRedo() {
+		t.Error("should not be able to redo after a new edit")
+	}
+
+	// So does leaving edit mode.
+	if err := sm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	sm.DiscardChanges()
+	sm.EnterEditMode()
+	if sm.CanRedo() {
+		t.Error("should not be able to redo after discarding")
+	}
+}
+
+func TestSlotStateManager_Savepoints(t *testing.T) {
+	cfg := stateTestConfig()
+	sm := NewSlotStateManager(cfg)
+
+	grid := gridFromString("AAAA----", cfg)
+	sm.SetGrid(grid)
+
+	if err := sm.SetSavepoint('a'); err != ErrSlotNotInEditMode {
+		t.Errorf("expected ErrSlotNotInEditMode, got %v", err)
+	}
+
+	sm.EnterEditMode()
+	taskA := getTaskByID(sm.Grid(), 0)
+	end := func() int {
+		_, _, endSlot, _ := sm.Grid().FindTask(taskA)
+		return endSlot
+	}
+
+	if err := sm.Grow(taskA); err != nil {
+		t.Fatalf("Grow failed: %v", err)
+	}
+	if err := sm.SetSavepoint('a'); err != nil {
+		t.Fatalf("SetSavepoint failed: %v", err)
+	}
+	if err := sm.ShrinkBy(taskA, 3); err != nil {
+		t.Fatalf("ShrinkBy failed: %v", err)
+	}
+
+	if err := sm.RestoreSavepoint('b'); err != ErrSlotNoSavepoint {
+		t.Errorf("expected ErrSlotNoSavepoint, got %v", err)
+	}
+	if err := sm.RestoreSavepoint('a'); err != nil {
+		t.Fatalf("RestoreSavepoint failed: %v", err)
+	}
+	if got := end(); got != 5 {
+		t.Errorf("end after restore = %d, want 5", got)
+	}
+	if !sm.DirtyDays()[0] {
+		t.Error("day 0 should be dirty after restore")
+	}
+
+	// Restoring is one undo step, and the savepoint can be restored again.
+	if err := sm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got := end(); got != 2 {
+		t.Errorf("end after undo = %d, want 2", got)
+	}
+	if err := sm.RestoreSavepoint('a'); err != nil {
+		t.Fatalf("RestoreSavepoint failed: %v", err)
+	}
+	if got := end(); got != 5 {
+		t.Errorf("end after second restore = %d, want 5", got)
+	}
+
+	// Savepoints end with the edit session.
+	sm.DiscardChanges()
+	sm.EnterEditMode()
+	if err := sm.RestoreSavepoint('a'); err != ErrSlotNoSavepoint {
+		t.Errorf("expected ErrSlotNoSavepoint after discard, got %v", err)
+	}
+}
+
 func TestSlotStateManager_Grow(t *testing.T) {
 	cfg := stateTestConfig()
 	sm := NewSlotStateManager(cfg)
@@ -265,6 +393,45 @@ func TestSlotStateManager_ShrinkMinimum(t *testing.T) {
 	}
 }
 
+func TestSlotStateManager_GrowByAndShrinkBy(t *testing.T) {
+	cfg := stateTestConfig()
+	sm := NewSlotStateManager(cfg)
+
+	grid := gridFromString("AA------", cfg)
+	sm.SetGrid(grid)
+	sm.EnterEditMode()
+
+	taskA := getTaskByID(sm.Grid(), 0)
+
+	if err := sm.GrowBy(taskA, 4); err != nil {
+		t.Fatalf("GrowBy failed: %v", err)
+	}
+	if _, start, end, _ := sm.Grid().FindTask(taskA); start != 0 || end != 6 {
+		t.Errorf("after GrowBy(4): start=%d, end=%d, want 0, 6", start, end)
+	}
+	if got := sm.UndoCount(); got != 1 {
+		t.Errorf("UndoCount() = %d, want 1", got)
+	}
+
+	// Shrinking past one slot stops there.
+	if err := sm.ShrinkBy(taskA, 10); err != nil {
+		t.Fatalf("ShrinkBy failed: %v", err)
+	}
+	if _, start, end, _ := sm.Grid().FindTask(taskA); start != 0 || end != 1 {
+		t.Errorf("after ShrinkBy(10): start=%d, end=%d, want 0, 1", start, end)
+	}
+	if err := sm.ShrinkBy(taskA, 2); err != ErrMinimumSlotsDuration {
+		t.Errorf("expected ErrMinimumSlotsDuration, got %v", err)
+	}
+
+	if err := sm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if _, start, end, _ := sm.Grid().FindTask(taskA); start != 0 || end != 6 {
+		t.Errorf("after undo: start=%d, end=%d, want 0, 6", start, end)
+	}
+}
+
 func TestSlotStateManager_AddSpace(t *testing.T) {
 	cfg := stateTestConfig()
 	sm := NewSlotStateManager(cfg)
@@ -920,3 +1087,238 @@ func TestSlotStateManager_UpdateConfig(t *testing.T) {
 		t.Errorf("config NumDays mismatch: got %d, want %d", sm.Config().NumDays, newCfg.NumDays)
 	}
 }
+
+func TestSlotStateManager_SaveChangesConflict(t *testing.T) {
+	ctx := context.Background()
+	cfg := stateTestConfig()
+	repo := memrepo.New()
+
+	newTask := func(desc, start, end string) *task.Task {
+		tk := &task.Task{
+			Description:    desc,
+			Category:       task.CategoryDeep,
+			ScheduledDate:  cfg.FirstDate,
+			ScheduledStart: start,
+			ScheduledEnd:   end,
+			Status:         task.StatusScheduled,
+		}
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask(%s): %v", desc, err)
+		}
+		return tk
+	}
+	a := newTask("A", "09:00", "10:00")
+	b := newTask("B", "11:00", "12:00")
+
+	sm := NewSlotStateManager(cfg)
+	sm.SetGrid(TasksToSlotGrid([]*task.Task{a, b}, cfg))
+	sm.EnterEditMode()
+	if err := sm.GrowBy(getTaskByID(sm.Grid(), a.ID), 2); err != nil {
+		t.Fatalf("GrowBy(A): %v", err)
+	}
+	if err := sm.GrowBy(getTaskByID(sm.Grid(), b.ID), 2); err != nil {
+		t.Fatalf("GrowBy(B): %v", err)
+	}
+
+	// Another process books the time A grew into.
+	newTask("C", "10:00", "10:30")
+
+	err := sm.SaveChanges(ctx, repo)
+	var conflict *SaveConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("SaveChanges error = %v, want *SaveConflictError", err)
+	}
+	if !errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Error("conflict should wrap ErrTimeBlockOverlap")
+	}
+	if len(conflict.Tasks) != 1 || conflict.Tasks[0].ID != a.ID || conflict.Tasks[0].ScheduledEnd != "10:30" {
+		t.Errorf("conflicts = %+v, want A ending 10:30", conflict.Tasks)
+	}
+	if sm.IsEditing() {
+		t.Error("should leave edit mode after a partial save")
+	}
+
+	storedA, _ := repo.GetTask(ctx, a.ID)
+	storedB, _ := repo.GetTask(ctx, b.ID)
+	if storedA.ScheduledEnd != "10:00" {
+		t.Errorf("A end = %s, want 10:00 (not saved)", storedA.ScheduledEnd)
+	}
+	if storedB.ScheduledEnd != "12:30" {
+		t.Errorf("B end = %s, want 12:30 (saved)", storedB.ScheduledEnd)
+	}
+}
+
+func TestSlotStateManager_SaveChangesTwice(t *testing.T) {
+	ctx := context.Background()
+	cfg := stateTestConfig()
+	repo := memrepo.New()
+	if err := repo.CreateTask(ctx, &task.Task{
+		Description:    "A",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  cfg.FirstDate,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+	}); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	tasks, _ := repo.ListTasksByDateRange(ctx, cfg.FirstDate, cfg.FirstDate)
+
+	sm := NewSlotStateManager(cfg)
+	sm.SetGrid(TasksToSlotGrid(tasks, cfg))
+	for i := range 2 {
+		sm.EnterEditMode()
+		if err := sm.GrowBy(getTaskByID(sm.Grid(), tasks[0].ID), 2); err != nil {
+			t.Fatalf("GrowBy: %v", err)
+		}
+		// The second save writes over the first one, not over a change by
+		// another process
+		if err := sm.SaveChanges(ctx, repo); err != nil {
+			t.Fatalf("save %d: %v", i+1, err)
+		}
+	}
+	if stored, _ := repo.GetTask(ctx, tasks[0].ID); stored.ScheduledEnd != "11:00" {
+		t.Errorf("A end = %s, want 11:00", stored.ScheduledEnd)
+	}
+}
+
+func TestSlotStateManager_SaveChangesChangedElsewhere(t *testing.T) {
+	ctx := context.Background()
+	cfg := stateTestConfig()
+	repo := memrepo.New()
+	for _, slot := range []struct{ desc, start, end string }{{"A", "09:00", "10:00"}, {"B", "11:00", "12:00"}} {
+		if err := repo.CreateTask(ctx, &task.Task{
+			Description:    slot.desc,
+			Category:       task.CategoryDeep,
+			ScheduledDate:  cfg.FirstDate,
+			ScheduledStart: slot.start,
+			ScheduledEnd:   slot.end,
+			Status:         task.StatusScheduled,
+		}); err != nil {
+			t.Fatalf("CreateTask(%s): %v", slot.desc, err)
+		}
+	}
+	tasks, _ := repo.ListTasksByDateRange(ctx, cfg.FirstDate, cfg.FirstDate)
+	a, b := tasks[0], tasks[1]
+
+	sm := NewSlotStateManager(cfg)
+	sm.SetGrid(TasksToSlotGrid(tasks, cfg))
+	sm.EnterEditMode()
+	for _, tk := range tasks {
+		if err := sm.GrowBy(getTaskByID(sm.Grid(), tk.ID), 2); err != nil {
+			t.Fatalf("GrowBy(%s): %v", tk.Description, err)
+		}
+	}
+
+	// Another process renames A after the week was loaded.
+	if err := repo.UpdateTaskDescription(ctx, a.ID, "A renamed", 0); err != nil {
+		t.Fatalf("UpdateTaskDescription: %v", err)
+	}
+
+	err := sm.SaveChanges(ctx, repo)
+	var conflict *SaveConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("SaveChanges error = %v, want *SaveConflictError", err)
+	}
+	if !errors.Is(err, task.ErrTaskChanged) || errors.Is(err, task.ErrTimeBlockOverlap) {
+		t.Errorf("conflict should wrap only ErrTaskChanged: %v", err)
+	}
+	if len(conflict.Tasks) != 0 || len(conflict.Changed) != 1 || conflict.Changed[0].ID != a.ID {
+		t.Errorf("conflicts = %+v, changed = %+v, want only A changed", conflict.Tasks, conflict.Changed)
+	}
+
+	storedA, _ := repo.GetTask(ctx, a.ID)
+	storedB, _ := repo.GetTask(ctx, b.ID)
+	if storedA.ScheduledEnd != "10:00" || storedA.Description != "A renamed" {
+		t.Errorf("A = %s %s, want the other process's write kept", storedA.Description, storedA.ScheduledEnd)
+	}
+	if storedB.ScheduledEnd != "12:30" {
+		t.Errorf("B end = %s, want 12:30 (saved)", storedB.ScheduledEnd)
+	}
+}
+
+func TestSlotStateManager_PendingChanges(t *testing.T) {
+	cfg := stateTestConfig()
+	sm := NewSlotStateManager(cfg)
+
+	grid := gridFromString("AAAABBBB", cfg)
+	sm.SetGrid(grid)
+	if got := sm.PendingChanges(); got != nil {
+		t.Errorf("PendingChanges outside edit mode = %v, want nil", got)
+	}
+
+	sm.EnterEditMode()
+	taskA := getTaskByID(sm.Grid(), 0)
+	if err := sm.Grow(taskA); err != nil {
+		t.Fatalf("Grow failed: %v", err)
+	}
+
+	// Growing A pushes B one slot later.
+	changes := sm.PendingChanges()
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2", len(changes))
+	}
+	a, b := changes[0], changes[1]
+	if a.After.ID != taskA.ID {
+		t.Errorf("first change is task %d, want A", a.After.ID)
+	}
+	if a.Before.ScheduledStart != a.After.ScheduledStart || a.Before.ScheduledEnd == a.After.ScheduledEnd {
+		t.Errorf("A: %s-%s → %s-%s, want only the end to change",
+			a.Before.ScheduledStart, a.Before.ScheduledEnd, a.After.ScheduledStart, a.After.ScheduledEnd)
+	}
+	if b.Before.ScheduledStart != a.Before.ScheduledEnd || b.After.ScheduledStart != a.After.ScheduledEnd {
+		t.Errorf("B: start %s → %s, want it to follow A", b.Before.ScheduledStart, b.After.ScheduledStart)
+	}
+}
+
+func TestSlotStateManager_ApplyChanges(t *testing.T) {
+	cfg := stateTestConfig()
+
+	// An earlier session grew A, which pushed B, and moved C to day 2.
+	before := gridFromString("AAAABBBB|CC", cfg)
+	earlier := NewSlotStateManager(cfg)
+	earlier.SetGrid(before)
+	earlier.EnterEditMode()
+	if err := earlier.GrowBy(getTaskByID(earlier.Grid(), 0), 2); err != nil {
+		t.Fatalf("GrowBy failed: %v", err)
+	}
+	changes := earlier.PendingChanges()
+	taskC := getTaskByID(before, 2)
+	changes = append(changes, PendingChange{
+		Before: &task.Task{ID: taskC.ID, ScheduledDate: cfg.FirstDate.AddDate(0, 0, 1), ScheduledStart: "00:00", ScheduledEnd: "00:30"},
+		After:  &task.Task{ID: taskC.ID, ScheduledDate: cfg.FirstDate.AddDate(0, 0, 2), ScheduledStart: "09:00", ScheduledEnd: "09:30"},
+	})
+	// A change whose task has moved since is skipped.
+	changes = append(changes, PendingChange{
+		Before: &task.Task{ID: taskC.ID + 1, ScheduledDate: cfg.FirstDate, ScheduledStart: "08:00", ScheduledEnd: "09:00"},
+		After:  &task.Task{ID: taskC.ID + 1, ScheduledDate: cfg.FirstDate, ScheduledStart: "10:00", ScheduledEnd: "11:00"},
+	})
+
+	sm := NewSlotStateManager(cfg)
+	sm.SetGrid(before)
+	if _, err := sm.ApplyChanges(changes); err != ErrSlotNotInEditMode {
+		t.Errorf("expected ErrSlotNotInEditMode, got %v", err)
+	}
+	sm.EnterEditMode()
+	skipped, err := sm.ApplyChanges(changes)
+	if err != nil {
+		t.Fatalf("ApplyChanges failed: %v", err)
+	}
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want 1", skipped)
+	}
+
+	for _, id := range []int64{0, 1} {
+		_, wantStart, wantEnd, _ := earlier.Grid().FindTaskByID(id)
+		_, start, end, _ := sm.Grid().FindTaskByID(id)
+		if start != wantStart || end != wantEnd {
+			t.Errorf("task %d at %d-%d, want %d-%d", id, start, end, wantStart, wantEnd)
+		}
+	}
+	if day, start, _, _ := sm.Grid().FindTask(taskC); day != 2 || cfg.SlotToTime(start) != "09:00" {
+		t.Errorf("C on day %d at %s, want day 2 at 09:00", day, cfg.SlotToTime(start))
+	}
+	if dirty := sm.DirtyDays(); sm.UndoCount() != 1 || !dirty[0] || !dirty[1] || !dirty[2] {
+		t.Errorf("undo count %d, dirty %v; want one step dirtying days 0 to 2", sm.UndoCount(), sm.DirtyDays())
+	}
+}
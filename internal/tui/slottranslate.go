@@ -1,23 +1,28 @@
 package tui
 
 import (
+	"sort"
 	"time"
 
+	"github.com/javiermolinar/sancho/internal/dateutil"
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
 // TasksToSlotGrid converts a slice of tasks to a SlotGrid.
-// Tasks are placed at their scheduled time positions.
+// Tasks are placed at their scheduled time positions. A task whose slots are
+// already taken, or that belongs to the split owner, goes into the overlap
+// lane of the day it starts on.
 func TasksToSlotGrid(tasks []*task.Task, cfg SlotConfig) *SlotGrid {
 	grid := NewSlotGrid(cfg)
+	days := make(map[int][]*task.Task)
 
 	for _, t := range tasks {
 		if t == nil {
 			continue
 		}
 
-		// Only include scheduled tasks (not cancelled/postponed)
-		if t.Status != task.StatusScheduled {
+		// Only include scheduled and missed tasks (not cancelled/postponed)
+		if !t.IsScheduled() && !t.IsMissed() {
 			continue
 		}
 
@@ -27,29 +32,80 @@ func TasksToSlotGrid(tasks []*task.Task, cfg SlotConfig) *SlotGrid {
 			continue // Task is outside the grid's date range
 		}
 
-		// Convert time to slot
-		startSlot := cfg.MinutesToSlot(task.TimeToMinutes(t.ScheduledStart))
-		endSlot := cfg.MinutesToSlot(task.TimeToMinutes(t.ScheduledEnd))
+		// All-day tasks take no slots; they show in the banner row
+		if t.IsAllDay() {
+			if grid.allDay == nil {
+				grid.allDay = make([][]*task.Task, cfg.NumDays)
+			}
+			grid.allDay[dayIndex] = append(grid.allDay[dayIndex], t)
+			continue
+		}
+
+		// Convert time to slot. Overnight tasks end past the last slot of the
+		// day and spill into the first slots of the next one.
+		startMins, endMins := task.Span(t.ScheduledStart, t.ScheduledEnd)
+		startSlot := cfg.MinutesToSlot(startMins)
+		endSlot := cfg.MinutesToSlot(endMins)
 
-		if endSlot <= startSlot {
+		if endSlot <= startSlot || (t.IsOvernight() && endMins-startMins > task.MaxOvernightMinutes) {
+			continue
+		}
+
+		if cfg.isSplitOwner(t) || occupied(days, dayIndex, startSlot, endSlot, cfg.NumDays) {
+			if grid.overlaps == nil {
+				grid.overlaps = make([][]slotSpan, cfg.NumDays)
+			}
+			grid.overlaps[dayIndex] = append(grid.overlaps[dayIndex], slotSpan{task: t, start: startSlot, end: min(endSlot, SlotsPerDay)})
 			continue
 		}
 
-		// Place task in grid (directly modify slots, bypassing Place() validation)
+		// Place task in grid (bypassing Place() validation)
 		// This is safe during initial load
-		for s := startSlot; s < endSlot && s < SlotsPerDay; s++ {
-			idx := grid.slotIndex(dayIndex, s)
-			if idx >= 0 && idx < len(grid.slots) {
-				grid.slots[idx] = t
+		for s := startSlot; s < endSlot; s++ {
+			day := dayIndex + s/SlotsPerDay
+			if day >= cfg.NumDays {
+				break
 			}
+			if days[day] == nil {
+				days[day] = make([]*task.Task, SlotsPerDay)
+			}
+			days[day][s%SlotsPerDay] = t
 		}
 	}
 
+	for day, slots := range days {
+		grid.setDaySlots(day, slots)
+	}
+	for _, spans := range grid.overlaps {
+		sort.SliceStable(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	}
+	grid.indexOverlaps()
 	return grid
 }
 
+// isSplitOwner reports whether t belongs to the owner shown on the right
+// half of each day.
+func (c SlotConfig) isSplitOwner(t *task.Task) bool {
+	return c.SplitOwner != "" && t.Owner == c.SplitOwner
+}
+
+// occupied reports whether any of the slots [startSlot, endSlot) counted from
+// dayIndex already holds a task.
+func occupied(days map[int][]*task.Task, dayIndex, startSlot, endSlot, numDays int) bool {
+	for s := startSlot; s < endSlot; s++ {
+		day := dayIndex + s/SlotsPerDay
+		if day >= numDays {
+			break
+		}
+		if slots := days[day]; slots != nil && slots[s%SlotsPerDay] != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // WeekWindowToSlotGrid converts a WeekWindow to a SlotGrid.
-// The SlotGrid will contain 21 days (3 weeks) starting from prev week's Monday.
+// The SlotGrid will contain every week of the window starting from the first week's first day.
 func WeekWindowToSlotGrid(ww *task.WeekWindow, cfg SlotConfig) *SlotGrid {
 	if ww == nil {
 		return NewSlotGrid(cfg)
@@ -58,14 +114,10 @@ func WeekWindowToSlotGrid(ww *task.WeekWindow, cfg SlotConfig) *SlotGrid {
 	var allTasks []*task.Task
 
 	// Collect tasks from all weeks
-	if ww.Previous() != nil {
-		allTasks = append(allTasks, ww.Previous().AllTasks()...)
-	}
-	if ww.Current() != nil {
-		allTasks = append(allTasks, ww.Current().AllTasks()...)
-	}
-	if ww.Next() != nil {
-		allTasks = append(allTasks, ww.Next().AllTasks()...)
+	for _, week := range ww.Weeks() {
+		if week != nil {
+			allTasks = append(allTasks, week.AllTasks()...)
+		}
 	}
 
 	return TasksToSlotGrid(allTasks, cfg)
@@ -124,26 +176,22 @@ func GetChangedTasks(before, after *SlotGrid) SlotGridChanges {
 }
 
 // SlotGridConfigFromWeekWindow creates a SlotConfig based on a WeekWindow.
-// The grid will start from the previous week's Monday and span 3 weeks.
+// The grid will start from the first day of the first week and span every week of the
+// window (3 weeks when ww is nil).
+// now is the grid's clock (required); without a window it also picks the weeks shown.
 // rowHeight is the display row size in minutes (currently fixed at 15) - used for visual block movement.
 func SlotGridConfigFromWeekWindow(ww *task.WeekWindow, workStart, workEnd string, now func() time.Time, rowHeight int) SlotConfig {
 	var firstDate time.Time
+	numWeeks := DefaultNumWeeks
 
 	switch {
-	case ww != nil && ww.Previous() != nil:
-		firstDate = ww.Previous().StartDate
 	case ww != nil && ww.Current() != nil:
-		// Calculate previous Monday
-		firstDate = ww.Current().StartDate.AddDate(0, 0, -7)
+		// Start radius weeks before the current one
+		numWeeks = ww.Len()
+		firstDate = ww.Current().StartDate.AddDate(0, 0, -7*ww.Radius())
 	default:
-		// Default to 1 week before today's Monday
-		today := time.Now()
-		weekday := int(today.Weekday())
-		if weekday == 0 {
-			weekday = 7 // Sunday
-		}
-		monday := today.AddDate(0, 0, -(weekday - 1))
-		firstDate = monday.AddDate(0, 0, -7)
+		// Default to 1 week before the start of today's week
+		firstDate = dateutil.StartOfWeek(now()).AddDate(0, 0, -7)
 	}
 
 	// Truncate to start of day
@@ -159,7 +207,7 @@ func SlotGridConfigFromWeekWindow(ww *task.WeekWindow, workStart, workEnd string
 
 	cfg := SlotConfig{
 		SlotDuration:      DefaultSlotDuration,
-		NumDays:           DefaultNumWeeks * DaysPerWeek, // 21 days
+		NumDays:           numWeeks * DaysPerWeek,
 		FirstDate:         firstDate,
 		Now:               now,
 		WorkingHoursStart: task.TimeToMinutes(workStart),
@@ -174,10 +222,17 @@ func SlotGridConfigFromWeekWindow(ww *task.WeekWindow, workStart, workEnd string
 	return cfg
 }
 
-// DayIndexToWeekAndDay converts a grid day index to week index (0-2) and day within week (0-6).
+// DayIndexToWeekAndDay converts a grid day index to week index and day within week (0-6).
+// The grid starts on the configured first weekday, so day 0 of a week is
+// Monday or Sunday depending on dateutil.FirstWeekday.
+// Negative indexes floor towards the previous week, so -1 is the last day of week -1.
 func DayIndexToWeekAndDay(dayIndex int) (weekIndex, dayOfWeek int) {
 	weekIndex = dayIndex / DaysPerWeek
 	dayOfWeek = dayIndex % DaysPerWeek
+	if dayOfWeek < 0 {
+		weekIndex--
+		dayOfWeek += DaysPerWeek
+	}
 	return weekIndex, dayOfWeek
 }
 
@@ -187,25 +242,24 @@ func WeekAndDayToDayIndex(weekIndex, dayOfWeek int) int {
 }
 
 // SlotGridToWeekWindow converts a SlotGrid to a WeekWindow.
-// The SlotGrid must contain 21 days (3 weeks):
-//   - Days 0-6:   Previous week
-//   - Days 7-13:  Current week
-//   - Days 14-20: Next week
+// Every 7 days of the grid become one week and the middle week is current,
+// so a 21-day grid holds the previous (days 0-6), current (7-13) and next
+// (14-20) weeks.
 func SlotGridToWeekWindow(grid *SlotGrid) *task.WeekWindow {
 	if grid == nil {
 		return nil
 	}
 
-	// Create three weeks from the grid
-	prevWeek := slotGridToWeek(grid, 0)  // Days 0-6
-	currWeek := slotGridToWeek(grid, 7)  // Days 7-13
-	nextWeek := slotGridToWeek(grid, 14) // Days 14-20
+	weeks := make([]*task.Week, grid.config.NumDays/DaysPerWeek)
+	for i := range weeks {
+		weeks[i] = slotGridToWeek(grid, WeekAndDayToDayIndex(i, 0))
+	}
 
-	return task.NewWeekWindow(prevWeek, currWeek, nextWeek)
+	return task.NewWeekWindowOf(weeks)
 }
 
 // slotGridToWeek extracts a single Week from the SlotGrid starting at the given day index.
-// startDay should be 0, 7, or 14 for prev/current/next week respectively.
+// startDay should be a multiple of 7 (7 is the current week of a 21-day grid).
 func slotGridToWeek(grid *SlotGrid, startDay int) *task.Week {
 	if grid == nil {
 		return nil
@@ -230,6 +284,16 @@ func slotGridToWeek(grid *SlotGrid, startDay int) *task.Week {
 				continue
 			}
 
+			// Overnight tasks are pinned in the grid: keep their own times and
+			// add them only to the day they start on, not the day they spill into
+			if t.IsOvernight() {
+				if grid.config.DateToDayIndex(t.ScheduledDate) == dayIndex {
+					taskCopy := *t
+					week.Day(dayOffset).AddOverlapping(&taskCopy)
+				}
+				continue
+			}
+
 			// Find the task's position in the grid to get accurate times
 			foundDay, startSlot, endSlot, found := grid.FindTask(t)
 			if !found || foundDay != dayIndex {
@@ -242,8 +306,12 @@ func slotGridToWeek(grid *SlotGrid, startDay int) *task.Week {
 			taskCopy.ScheduledStart = grid.config.SlotToTime(startSlot)
 			taskCopy.ScheduledEnd = grid.config.SlotToTime(endSlot)
 
-			// Add to the day (ignore overlap errors since SlotGrid doesn't allow overlaps)
-			_ = week.Day(dayOffset).AddTask(&taskCopy)
+			// The overlap lane may hold tasks that overlap others on purpose
+			week.Day(dayOffset).AddOverlapping(&taskCopy)
+		}
+		for _, t := range grid.AllDayTasks(dayIndex) {
+			taskCopy := *t
+			week.Day(dayOffset).AddOverlapping(&taskCopy)
 		}
 	}
 
@@ -99,6 +99,22 @@ func TestTasksToSlotGrid(t *testing.T) {
 			},
 			wantCount: 1,
 		},
+		{
+			name: "missed task is included",
+			tasks: []*task.Task{
+				makeScheduledTask(1, firstDate, "09:00", "10:00"),
+				{
+					ID:             2,
+					Description:    "Missed",
+					Category:       task.CategoryDeep,
+					ScheduledDate:  firstDate,
+					ScheduledStart: "11:00",
+					ScheduledEnd:   "12:00",
+					Status:         task.StatusMissed,
+				},
+			},
+			wantCount: 2,
+		},
 		{
 			name: "task outside grid date range is excluded",
 			tasks: []*task.Task{
@@ -462,6 +478,9 @@ func TestDayIndexToWeekAndDay(t *testing.T) {
 		{13, 1, 6},
 		{14, 2, 0},
 		{20, 2, 6},
+		{-1, -1, 6},
+		{-7, -1, 0},
+		{-8, -2, 6},
 	}
 
 	for _, tt := range tests {
@@ -499,7 +518,7 @@ func TestWeekAndDayToDayIndex(t *testing.T) {
 }
 
 func TestDayIndexConversionsRoundTrip(t *testing.T) {
-	for dayIndex := 0; dayIndex < 21; dayIndex++ {
+	for dayIndex := -21; dayIndex < 42; dayIndex++ {
 		week, day := DayIndexToWeekAndDay(dayIndex)
 		got := WeekAndDayToDayIndex(week, day)
 		if got != dayIndex {
@@ -508,6 +527,33 @@ func TestDayIndexConversionsRoundTrip(t *testing.T) {
 	}
 }
 
+func TestTasksToSlotGrid_OvernightSpillsIntoNextDay(t *testing.T) {
+	firstDate := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC) // Monday
+	cfg := translateTestConfig(firstDate)
+
+	// 23:00-01:00 occupies slots 92-95 on Monday and 0-3 on Tuesday
+	overnight := makeScheduledTask(1, firstDate, "23:00", "01:00")
+	grid := TasksToSlotGrid([]*task.Task{overnight}, cfg)
+
+	for _, pos := range [][2]int{{0, 92}, {0, 95}, {1, 0}, {1, 3}} {
+		if got := grid.TaskAt(pos[0], pos[1]); got == nil || got.ID != 1 {
+			t.Errorf("day %d slot %d = %v, want task 1", pos[0], pos[1], got)
+		}
+	}
+	if grid.TaskAt(0, 91) != nil || grid.TaskAt(1, 4) != nil {
+		t.Error("slots around the overnight task should be empty")
+	}
+
+	// Converting back keeps the stored times and only the starting day
+	week := slotGridToWeek(grid, 0)
+	if tasks := week.Day(0).ScheduledTasks(); len(tasks) != 1 || tasks[0].ScheduledEnd != "01:00" {
+		t.Fatalf("Monday tasks = %v, want the overnight task ending 01:00", tasks)
+	}
+	if tasks := week.Day(1).ScheduledTasks(); len(tasks) != 0 {
+		t.Errorf("Tuesday tasks = %d, want 0", len(tasks))
+	}
+}
+
 func TestSlotGridToWeekWindow(t *testing.T) {
 	// Monday, Dec 30, 2024 (prev week start)
 	prevMonday := time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)
@@ -861,3 +907,80 @@ func TestSlotGridToWeekWindow_MultipleAdjacentTasks(t *testing.T) {
 		t.Error("Task 4 not found")
 	}
 }
+
+func TestSlotGridConfigFromWeekWindow_YearBoundaryAndLeapDay(t *testing.T) {
+	// Walk every week from 2023 through 2029 (covers the 2024 and 2028 leap
+	// days and every Dec 31/Jan 1 crossing) and check the window invariants.
+	monday := time.Date(2023, 1, 2, 0, 0, 0, 0, time.Local)
+	end := time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local)
+
+	for ; monday.Before(end); monday = monday.AddDate(0, 0, 7) {
+		ww := task.NewWeekWindow(
+			task.NewWeek(monday.AddDate(0, 0, -7)),
+			task.NewWeek(monday),
+			task.NewWeek(monday.AddDate(0, 0, 7)),
+		)
+		cfg := SlotGridConfigFromWeekWindow(ww, "09:00", "17:00", nil, 15)
+
+		wantFirst := monday.AddDate(0, 0, -7)
+		if !cfg.FirstDate.Equal(wantFirst) {
+			t.Fatalf("window at %s: FirstDate = %s, want %s", monday.Format("2006-01-02"), cfg.FirstDate.Format("2006-01-02"), wantFirst.Format("2006-01-02"))
+		}
+
+		for dayIndex := 0; dayIndex < cfg.NumDays; dayIndex++ {
+			date := cfg.DayIndexToDate(dayIndex)
+			if date.Weekday() != time.Weekday((dayIndex+1)%7) {
+				t.Fatalf("window at %s: day %d is %s", monday.Format("2006-01-02"), dayIndex, date.Weekday())
+			}
+
+			weekIndex, dayOfWeek := DayIndexToWeekAndDay(dayIndex)
+			week := []*task.Week{ww.Previous(), ww.Current(), ww.Next()}[weekIndex]
+			if day := week.Day(dayOfWeek); !day.Date.Equal(date) {
+				t.Fatalf("window at %s: day %d = %s, week day = %s", monday.Format("2006-01-02"), dayIndex, date.Format("2006-01-02"), day.Date.Format("2006-01-02"))
+			}
+		}
+	}
+}
+
+func TestTasksToSlotGrid_OverlapLane(t *testing.T) {
+	firstDate := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	cfg := translateTestConfig(firstDate)
+	focus := makeScheduledTask(1, firstDate, "09:00", "11:00")
+	meeting := makeScheduledTask(2, firstDate, "10:00", "10:30")
+	later := makeScheduledTask(3, firstDate, "11:00", "12:00")
+
+	grid := TasksToSlotGrid([]*task.Task{focus, meeting, later}, cfg)
+
+	if !grid.HasOverlaps() {
+		t.Fatal("expected the meeting in the overlap lane")
+	}
+	if got := grid.TaskAt(0, 40); got != focus {
+		t.Errorf("TaskAt(0, 40) = %v, want the focus block", got)
+	}
+	if got := len(grid.TasksOnDay(0)); got != 3 {
+		t.Errorf("TasksOnDay(0) = %d tasks, want 3", got)
+	}
+	day, start, end, found := grid.FindTaskByID(2)
+	if !found || day != 0 || start != 40 || end != 42 {
+		t.Errorf("FindTaskByID(2) = %d, %d, %d, %v; want 0, 40, 42, true", day, start, end, found)
+	}
+	if _, err := grid.Delete(meeting); err != ErrOverlappingTask {
+		t.Errorf("Delete(meeting) = %v, want ErrOverlappingTask", err)
+	}
+
+	moved, err := grid.MoveDown(later)
+	if err != nil {
+		t.Fatalf("MoveDown: %v", err)
+	}
+	if !moved.HasOverlaps() {
+		t.Error("MoveDown dropped the overlap lane")
+	}
+
+	week := SlotGridToWeekWindow(moved).Previous()
+	if got := len(week.Day(0).ScheduledTasks()); got != 3 {
+		t.Errorf("week day 0 has %d tasks, want 3", got)
+	}
+	if changes := GetChangedTasks(grid, moved); len(changes.UpdatedTasks) != 1 || changes.UpdatedTasks[0].ID != 3 {
+		t.Errorf("changed tasks = %+v, want only task 3", changes.UpdatedTasks)
+	}
+}
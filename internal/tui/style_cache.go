@@ -1,7 +1,11 @@
 // Package tui provides the terminal user interface for sancho.
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
 
 // StyleCache stores width-specific styles to avoid per-cell mutations.
 type StyleCache struct {
@@ -18,6 +22,7 @@ type StyleCache struct {
 	TaskPastShallow        lipgloss.Style
 	TaskPastDeepAlt        lipgloss.Style
 	TaskPastShallowAlt     lipgloss.Style
+	TaskMissed             lipgloss.Style
 	TaskSelected           lipgloss.Style
 	TaskMovePreview        lipgloss.Style
 	TaskShifted            lipgloss.Style
@@ -25,12 +30,14 @@ type StyleCache struct {
 	TaskCurrentShallow     lipgloss.Style
 	TaskCurrentDeepBody    lipgloss.Style
 	TaskCurrentShallowBody lipgloss.Style
+	TaskCategory           []lipgloss.Style // Categories with their own color
+	categoryKeys           map[task.Category]cellStyleKey
 }
 
 // NewStyleCache precomputes all width-dependent styles for the grid.
 func NewStyleCache(styles *Styles, width int) StyleCache {
 	contentWidth := max(1, width-1)
-	return StyleCache{
+	c := StyleCache{
 		TitleBoxStyle:          styles.TitleStyle.Border(lipgloss.RoundedBorder()).Padding(0, 2),
 		DayHeader:              styles.DayHeaderStyleWidth(width),
 		DayHeaderToday:         styles.DayHeaderTodayStyleWidth(width),
@@ -44,6 +51,7 @@ func NewStyleCache(styles *Styles, width int) StyleCache {
 		TaskPastShallow:        styles.TaskPastShallowStyleWidth(width),
 		TaskPastDeepAlt:        styles.TaskPastDeepAltStyleWidth(width),
 		TaskPastShallowAlt:     styles.TaskPastShallowAltStyleWidth(width),
+		TaskMissed:             styles.TaskMissedStyleWidth(width),
 		TaskSelected:           styles.TaskSelectedStyleWidth(width),
 		TaskMovePreview:        styles.TaskMovePreviewStyleWidth(width),
 		TaskShifted:            styles.TaskShiftedStyleWidth(width),
@@ -51,5 +59,89 @@ func NewStyleCache(styles *Styles, width int) StyleCache {
 		TaskCurrentShallow:     styles.TaskCurrentStyleWidth(width, false),
 		TaskCurrentDeepBody:    styles.TaskCurrentStyleWidth(contentWidth, true),
 		TaskCurrentShallowBody: styles.TaskCurrentStyleWidth(contentWidth, false),
+		categoryKeys:           map[task.Category]cellStyleKey{},
+	}
+	for _, info := range task.Categories() {
+		if info.Color == "" || len(c.TaskCategory) >= maxCategoryStyles {
+			continue
+		}
+		c.categoryKeys[info.Name] = cellCategory + cellStyleKey(len(c.TaskCategory))
+		c.TaskCategory = append(c.TaskCategory, styles.TaskCategoryStyleWidth(info.Color, width))
+	}
+	return c
+}
+
+// categoryKey returns the key of the style for category, if it has its own
+// color.
+func (c *StyleCache) categoryKey(category task.Category) (cellStyleKey, bool) {
+	key, ok := c.categoryKeys[category]
+	return key, ok
+}
+
+// cellStyleKey names one of the grid cell styles, so a rendered cell can be
+// matched against the next frame's without comparing styles.
+type cellStyleKey uint8
+
+const (
+	cellEmpty cellStyleKey = iota
+	cellCursor
+	cellDeep
+	cellShallow
+	cellDeepAlt
+	cellShallowAlt
+	cellPastDeep
+	cellPastShallow
+	cellPastDeepAlt
+	cellPastShallowAlt
+	cellMissed
+	cellSelected
+	cellMovePreview
+	cellShifted
+	cellCurrentDeep
+	cellCurrentShallow
+	cellCategory // First of the keys of categories with their own color
+)
+
+// maxCategoryStyles bounds the colored categories so their keys fit a cellStyleKey.
+const maxCategoryStyles = 256 - int(cellCategory)
+
+// cell returns the grid cell style named by key.
+func (c *StyleCache) cell(key cellStyleKey) lipgloss.Style {
+	switch key {
+	case cellCursor:
+		return c.Cursor
+	case cellDeep:
+		return c.TaskDeep
+	case cellShallow:
+		return c.TaskShallow
+	case cellDeepAlt:
+		return c.TaskDeepAlt
+	case cellShallowAlt:
+		return c.TaskShallowAlt
+	case cellPastDeep:
+		return c.TaskPastDeep
+	case cellPastShallow:
+		return c.TaskPastShallow
+	case cellPastDeepAlt:
+		return c.TaskPastDeepAlt
+	case cellPastShallowAlt:
+		return c.TaskPastShallowAlt
+	case cellMissed:
+		return c.TaskMissed
+	case cellSelected:
+		return c.TaskSelected
+	case cellMovePreview:
+		return c.TaskMovePreview
+	case cellShifted:
+		return c.TaskShifted
+	case cellCurrentDeep:
+		return c.TaskCurrentDeep
+	case cellCurrentShallow:
+		return c.TaskCurrentShallow
+	default:
+		if i := int(key) - int(cellCategory); i >= 0 && i < len(c.TaskCategory) {
+			return c.TaskCategory[i]
+		}
+		return c.EmptyCell
 	}
 }
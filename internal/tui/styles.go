@@ -63,6 +63,7 @@ type Styles struct {
 	TaskPastShallowStyle    lipgloss.Style // Past shallow work (muted but visible)
 	TaskPastDeepAltStyle    lipgloss.Style // Past deep work alternate shade
 	TaskPastShallowAltStyle lipgloss.Style // Past shallow work alternate shade
+	TaskMissedStyle         lipgloss.Style // Ended without an outcome
 	TaskSelectedStyle       lipgloss.Style
 	TaskMovePreviewStyle    lipgloss.Style
 	TaskShiftedStyle        lipgloss.Style // Tasks shifted to make room during move
@@ -114,6 +115,11 @@ type Styles struct {
 	ModalButtonActiveStyle lipgloss.Style
 	ModalHintStyle         lipgloss.Style
 
+	// Year overview rows colored by completion
+	YearHighStyle lipgloss.Style
+	YearMidStyle  lipgloss.Style
+	YearLowStyle  lipgloss.Style
+
 	// Category toggle styles
 	CategoryActiveStyle   lipgloss.Style
 	CategoryInactiveStyle lipgloss.Style
@@ -254,6 +260,12 @@ func NewStyles(t *theme.Theme) *Styles {
 		Background(palette.ShallowPastBgAlt).
 		Foreground(s.colorFg)
 
+	// Missed: no category colour, struck through in the warning colour
+	s.TaskMissedStyle = s.TaskCellStyle.
+		Background(s.colorBgHighlight).
+		Foreground(s.colorWarning).
+		Strikethrough(true)
+
 	s.TaskSelectedStyle = s.TaskCellStyle.
 		Background(s.colorWarning).
 		Foreground(s.colorTextOnWarning).
@@ -456,6 +468,10 @@ func NewStyles(t *theme.Theme) *Styles {
 		Foreground(modalMuted).
 		Background(modalBg)
 
+	s.YearHighStyle = s.ModalBodyStyle.Foreground(s.colorShallow)
+	s.YearMidStyle = s.ModalBodyStyle.Foreground(s.colorCurrent)
+	s.YearLowStyle = s.ModalBodyStyle.Foreground(s.colorWarning)
+
 	// Category toggle styles
 	s.CategoryActiveStyle = lipgloss.NewStyle().
 		Background(s.colorDeep).
@@ -517,6 +533,16 @@ func (s *Styles) TaskShallowStyleWidth(width int) lipgloss.Style {
 	return s.TaskShallowStyle.Width(width)
 }
 
+// TaskCategoryStyleWidth returns the style of a category with its own
+// color, with specified width.
+func (s *Styles) TaskCategoryStyleWidth(color string, width int) lipgloss.Style {
+	return s.TaskCellStyle.
+		Background(lipgloss.Color(color)).
+		Foreground(s.colorFg).
+		Bold(true).
+		Width(width)
+}
+
 // TaskDeepAltStyleWidth returns the alternate deep task style with specified width.
 func (s *Styles) TaskDeepAltStyleWidth(width int) lipgloss.Style {
 	return s.TaskDeepAltStyle.Width(width)
@@ -547,6 +573,11 @@ func (s *Styles) TaskPastShallowAltStyleWidth(width int) lipgloss.Style {
 	return s.TaskPastShallowAltStyle.Width(width)
 }
 
+// TaskMissedStyleWidth returns the missed task style with specified width.
+func (s *Styles) TaskMissedStyleWidth(width int) lipgloss.Style {
+	return s.TaskMissedStyle.Width(width)
+}
+
 // TaskSelectedStyleWidth returns the selected task style with specified width.
 func (s *Styles) TaskSelectedStyleWidth(width int) lipgloss.Style {
 	return s.TaskSelectedStyle.Width(width)
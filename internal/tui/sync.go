@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// startSync starts the background sync scheduler once, if any source is
+// configured.
+func (m Model) startSync() tea.Cmd {
+	if m.syncer != nil {
+		return nil
+	}
+	return commands.StartSync(m.config, m.persistentRepo())
+}
+
+// handleSyncMsg handles messages from the background sync scheduler.
+func (m Model) handleSyncMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case commands.SyncStartedMsg:
+		m.syncer = msg.Scheduler
+		return m, commands.WaitSyncEvent(msg.Events)
+
+	case commands.ActionsPushedMsg:
+		if msg.Err != nil && m.syncer != nil {
+			m.statusMsg = fmt.Sprintf("Offline? %d sync actions queued", m.syncer.Queued())
+		}
+		return m, nil
+
+	case commands.SyncEventMsg:
+		cmds := []tea.Cmd{commands.WaitSyncEvent(msg.Events)}
+		res := msg.Event.Status.Result
+		if msg.Event.Status.LastErr == nil && res.Created+res.Updated > 0 && !m.slotState.IsEditing() {
+			cmds = append(cmds, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius))
+		}
+		if len(res.Conflicts) > 0 {
+			updated, cmd := m.handleSyncConflictsMsg(commands.SyncConflictsMsg{Conflicts: res.Conflicts})
+			return updated, tea.Batch(append(cmds, cmd)...)
+		}
+		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+// pushAction queues a change to a synced task for its source and tries to
+// send it right away. It returns nil when the task is not synced to a source
+// that accepts updates, or while in the sandbox.
+func (m *Model) pushAction(t *task.Task, kind tasksync.ActionKind, outcome task.Outcome) tea.Cmd {
+	if m.syncer == nil || m.sandbox != nil || t == nil || t.ExternalRef.IsZero() {
+		return nil
+	}
+	ok, err := m.syncer.Enqueue(tasksync.Action{Kind: kind, Ref: t.ExternalRef, Outcome: outcome})
+	if err != nil {
+		return func() tea.Msg { return commands.ErrMsg{Err: err} }
+	}
+	if !ok {
+		return nil
+	}
+	return commands.PushActions(m.syncer)
+}
+
+// syncIndicator returns the stats bar note for background sync, e.g.
+// " [sync 3m ago]". It is empty when sync is not configured.
+func (m Model) syncIndicator() string {
+	if m.syncer == nil {
+		return ""
+	}
+	queued := ""
+	if n := m.syncer.Queued(); n > 0 {
+		queued = fmt.Sprintf(" [%d queued]", n)
+	}
+	var last time.Time
+	for _, s := range m.syncer.Statuses() {
+		if s.LastErr != nil {
+			return " [sync error]" + queued
+		}
+		if s.LastSync.IsZero() {
+			return " [syncing...]" + queued
+		}
+		if last.IsZero() || s.LastSync.Before(last) {
+			last = s.LastSync
+		}
+	}
+	if last.IsZero() {
+		return queued
+	}
+	return fmt.Sprintf(" [sync %s]", formatAgo(m.now().Sub(last))) + queued
+}
+
+// syncStatus describes every sync source for "/sync status".
+func (m Model) syncStatus() string {
+	if m.syncer == nil && m.replica == nil {
+		return "Sync is not configured; add [[sync.ics]] feeds or [sync.replica] to the config"
+	}
+	now := m.now()
+	var parts []string
+	if m.replica != nil {
+		parts = append(parts, m.replicaStatus())
+	}
+	if m.syncer == nil {
+		return strings.Join(parts, " | ")
+	}
+	for _, s := range m.syncer.Statuses() {
+		var line string
+		switch {
+		case s.LastErr != nil:
+			line = fmt.Sprintf("%s: error (%d failures): %v", s.Source, s.Failures, s.LastErr)
+		case s.LastSync.IsZero():
+			line = fmt.Sprintf("%s: not synced yet", s.Source)
+		default:
+			line = fmt.Sprintf("%s: synced %s, %d new, %d updated", s.Source, formatAgo(now.Sub(s.LastSync)), s.Result.Created, s.Result.Updated)
+			if s.Result.Skipped > 0 {
+				line += fmt.Sprintf(", %d skipped", s.Result.Skipped)
+			}
+		}
+		if s.PushErr != nil {
+			line += fmt.Sprintf("; push failed: %v", s.PushErr)
+		}
+		if !s.NextRun.IsZero() {
+			line += fmt.Sprintf("; next at %s", s.NextRun.Format("15:04"))
+		}
+		parts = append(parts, line)
+	}
+	if n := m.syncer.Queued(); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d actions queued", n))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// formatAgo formats an elapsed duration as "just now", "5m ago" or "2h ago".
+func formatAgo(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+}
@@ -0,0 +1,134 @@
+// Package tui provides the terminal user interface for sancho.
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/tasksync"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+const syncConflictFallbackWidth = 72
+
+// handleSyncConflictsMsg queues conflicts reported by a sync integration and
+// opens the resolution modal unless the user is busy with something else.
+func (m Model) handleSyncConflictsMsg(msg commands.SyncConflictsMsg) (tea.Model, tea.Cmd) {
+	m.syncConflicts = append(m.syncConflicts, msg.Conflicts...)
+	if len(m.syncConflicts) == 0 {
+		return m, nil
+	}
+	if m.mode != ModeNormal {
+		m.statusMsg = fmt.Sprintf("%d sync conflicts waiting; run /sync resolve", len(m.syncConflicts))
+		return m, nil
+	}
+	m.openSyncConflict()
+	return m, nil
+}
+
+// openSyncConflict shows the first queued conflict.
+func (m *Model) openSyncConflict() {
+	m.syncConflictField = 0
+	m.mode = ModeModal
+	m.modalType = ModalSyncConflict
+}
+
+// nextSyncConflict drops the current conflict and shows the next one, or
+// closes the modal when the queue is empty.
+func (m *Model) nextSyncConflict() {
+	if len(m.syncConflicts) > 0 {
+		m.syncConflicts = m.syncConflicts[1:]
+	}
+	if len(m.syncConflicts) > 0 {
+		m.openSyncConflict()
+		return
+	}
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+}
+
+// handleSyncConflictKeys handles keys in the sync conflict modal.
+func (m Model) handleSyncConflictKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if len(m.syncConflicts) == 0 {
+		m.mode = ModeNormal
+		m.modalType = ModalNone
+		return m, nil
+	}
+	c := m.syncConflicts[0]
+
+	switch msg.String() {
+	case "j", "down", "tab":
+		m.syncConflictField = (m.syncConflictField + 1) % len(tasksync.Fields)
+	case "k", "up", "shift+tab":
+		m.syncConflictField = (m.syncConflictField + len(tasksync.Fields) - 1) % len(tasksync.Fields)
+	case "h", "left":
+		c.Choice[tasksync.Fields[m.syncConflictField]] = tasksync.SideLocal
+	case "l", "right":
+		c.Choice[tasksync.Fields[m.syncConflictField]] = tasksync.SideRemote
+	case " ":
+		c.Toggle(tasksync.Fields[m.syncConflictField])
+	case "L":
+		c.ChooseAll(tasksync.SideLocal)
+	case "R":
+		c.ChooseAll(tasksync.SideRemote)
+	case "enter":
+		merged := c.Merged()
+		if m.syncer != nil {
+			m.syncer.Resolve(c)
+		}
+		m.nextSyncConflict()
+		m.statusMsg = "Saving merged task..."
+		return m, commands.ResolveConflict(m.persistentRepo(), merged)
+	case "esc":
+		if m.syncer != nil {
+			m.syncer.Resolve(c)
+		}
+		m.nextSyncConflict()
+		m.statusMsg = "Conflict skipped; local copy kept"
+	}
+	return m, nil
+}
+
+// handleSyncCommand handles "/sync status", "/sync resolve", "/sync push"
+// and "/sync pull".
+func (m Model) handleSyncCommand(args []string) (tea.Model, tea.Cmd) {
+	action := ""
+	if len(args) > 0 {
+		action = args[0]
+	}
+	switch action {
+	case "status":
+		m.statusMsg = m.syncStatus()
+		return m, nil
+	case "resolve":
+		if len(m.syncConflicts) == 0 {
+			m.statusMsg = "No sync conflicts"
+			return m, nil
+		}
+		m.openSyncConflict()
+		return m, nil
+	case "push":
+		return m.handleReplicaCommand(db.ResolveLocal)
+	case "pull":
+		return m.handleReplicaCommand(db.ResolveRemote)
+	default:
+		m.statusMsg = "Usage: /sync status|resolve|push|pull"
+		return m, nil
+	}
+}
+
+// renderSyncConflictModal renders the three-pane conflict resolution modal.
+func (m Model) renderSyncConflictModal() string {
+	if len(m.syncConflicts) == 0 {
+		return ""
+	}
+	styleSet := m.modalStyleSet()
+	width := view.ModalContentWidth(m.styles.ModalStyle, syncConflictFallbackWidth)
+	model := view.NewSyncConflictModel(m.syncConflicts[0], m.syncConflictField, len(m.syncConflicts)-1, width)
+	body := view.RenderSyncConflictBody(model, styleSet.SyncConflictStyles())
+	footer := view.SyncConflictFooter(m.modalStyles())
+	return view.RenderModalFrame("Sync Conflict", body, footer, m.modalStyles())
+}
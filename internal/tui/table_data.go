@@ -6,6 +6,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/view"
 )
 
 func (m Model) visibleSlotsForTable(height int) int {
@@ -13,7 +14,7 @@ func (m Model) visibleSlotsForTable(height int) int {
 		return 0
 	}
 
-	tableChrome := 4 // top border + header + header separator + bottom border
+	tableChrome := 3 + view.HeaderLines + m.offHoursBandLines() + m.parkingLines() + m.bannerLines() // borders, header lines and header separator
 	if height <= tableChrome {
 		return 0
 	}
@@ -35,6 +36,20 @@ func (m Model) buildGridTableRows(visibleSlots int) ([][]string, [][]lipgloss.St
 
 	shadeByDay := m.cachedShadeMap
 	cursorTask := m.cachedCursorTask()
+	clk := m.cachedClock
+	maxSlots := m.maxSlots()
+	dayStart := m.dayStartMinutes()
+	timeStyle := m.timeColumnStyle()
+	// Day cells arrive styled from the cell cache; the table only sizes them.
+	days := m.visibleDays()
+	var framed [7]lipgloss.Style
+	for _, day := range days {
+		framed[day] = lipgloss.NewStyle().Width(m.dayWidth(day)).Height(m.rowLines)
+	}
+	m.cellCache.beginFrame(m.dayWidths, m.rowLines)
+	narrow := m.styles.EmptyCellStyleWidth(narrowColWidth).Height(m.rowLines)
+	// Columns split by owner keep both halves on every row, so they line up
+	split := m.slotState.Config().SplitOwner != ""
 
 	for i := 0; i < visibleSlots; i++ {
 		slot := m.scrollOffset + i
@@ -42,23 +57,42 @@ func (m Model) buildGridTableRows(visibleSlots int) ([][]string, [][]lipgloss.St
 		rowStyles := make([]lipgloss.Style, 0, 8)
 
 		timeLabel := ""
-		if slot >= 0 && slot < m.maxSlots() {
-			timeLabel = minutesToTime(m.dayStartMinutes() + (slot * m.rowHeight))
+		if slot >= 0 && slot < maxSlots {
+			timeLabel = minutesToTime(dayStart + (slot * m.rowHeight))
 		}
 
 		row = append(row, m.timeColumnContent(timeLabel))
-		rowStyles = append(rowStyles, m.timeColumnStyle())
+		rowStyles = append(rowStyles, timeStyle)
 
-		for day := 0; day < 7; day++ {
+		for _, day := range days {
+			if m.isNarrowDay(day) {
+				row = append(row, "")
+				rowStyles = append(rowStyles, narrow)
+				continue
+			}
 			dayTasks := m.gridCache[day]
 			var t *task.Task
 			if slot >= 0 && slot < len(dayTasks) {
 				t = dayTasks[slot]
 			}
 
-			style, lines := m.cellStyleAndLines(day, slot, t, dayTasks, cursorTask, shadeByDay)
-			row = append(row, strings.Join(lines, "\n"))
-			rowStyles = append(rowStyles, style)
+			key, lines := m.cellStyleAndLines(day, slot, t, dayTasks, cursorTask, shadeByDay)
+			if clk.showNow && day == clk.nowDay && slot == clk.nowSlot {
+				markNowLine(lines, clk.nowLine, m.dayWidth(day))
+			}
+			overlaps := m.overlapCache[day]
+			var o *task.Task
+			if slot >= 0 && slot < len(overlaps) {
+				o = overlaps[slot]
+			}
+			if o != nil || split {
+				content, style := m.splitCell(day, slot, key, lines, o, overlaps, cursorTask, shadeByDay)
+				row = append(row, content)
+				rowStyles = append(rowStyles, style)
+				continue
+			}
+			row = append(row, m.styledCell(day, slot, key, strings.Join(lines, "\n")))
+			rowStyles = append(rowStyles, framed[day])
 		}
 
 		rows = append(rows, row)
@@ -68,6 +102,55 @@ func (m Model) buildGridTableRows(visibleSlots int) ([][]string, [][]lipgloss.St
 	return rows, cellStyles
 }
 
+// splitCell renders a cell shared by two overlapping tasks: the grid's own
+// task on the left half and the overlapping task o, or the split owner's,
+// on the right. o may be nil in a column split by owner. It returns the
+// rendered halves and a plain style sized to the whole cell.
+func (m Model) splitCell(
+	day, slot int,
+	key cellStyleKey,
+	lines []string,
+	o *task.Task,
+	overlaps []*task.Task,
+	cursorTask *task.Task,
+	shadeByDay map[int]map[int64]bool,
+) (string, lipgloss.Style) {
+	width := m.dayWidth(day)
+	leftWidth := width / 2
+	rightWidth := width - leftWidth
+
+	oKey := cellEmpty
+	if o != nil {
+		oKey, _, _ = m.cellStyleKeyForSlot(day, slot, o, cursorTask, shadeByDay)
+	}
+	oLines := m.cellContentLines(slot, o, overlaps)
+	for i := range oLines {
+		if oLines[i] != "" {
+			oLines[i] = " " + oLines[i]
+		}
+	}
+
+	half := func(key cellStyleKey, lines []string, width int) string {
+		fitted := make([]string, len(lines))
+		for i, line := range lines {
+			fitted[i] = truncateWithEllipsis(line, width)
+		}
+		return m.styleCache.cell(key).Width(width).Height(m.rowLines).Render(strings.Join(fitted, "\n"))
+	}
+	content := lipgloss.JoinHorizontal(lipgloss.Top,
+		half(key, lines, leftWidth),
+		half(oKey, oLines, rightWidth),
+	)
+	return content, lipgloss.NewStyle().Width(width).Height(m.rowLines)
+}
+
+// cellStyle returns the style named by key sized to a grid cell width
+// characters wide. Lines wrapped past the cell's height are cut, as the
+// table would.
+func (m Model) cellStyle(key cellStyleKey, width int) lipgloss.Style {
+	return m.styleCache.cell(key).Width(width).Height(m.rowLines).MaxHeight(m.rowLines)
+}
+
 func (m Model) timeColumnStyle() lipgloss.Style {
 	return m.styles.TimeColumnStyle.Width(6).Height(m.rowLines)
 }
@@ -86,8 +169,8 @@ func (m Model) cellStyleAndLines(
 	dayTasks []*task.Task,
 	cursorTask *task.Task,
 	shadeByDay map[int]map[int64]bool,
-) (lipgloss.Style, []string) {
-	style, isCursor, isPartOfCursorTask := m.cellStyleForSlot(day, slot, t, cursorTask, shadeByDay)
+) (cellStyleKey, []string) {
+	key, isCursor, isPartOfCursorTask := m.cellStyleKeyForSlot(day, slot, t, cursorTask, shadeByDay)
 
 	lines := m.cellContentLines(slot, t, dayTasks)
 	if t == nil && isCursor && !isPartOfCursorTask && len(lines) > 0 {
@@ -100,8 +183,7 @@ func (m Model) cellStyleAndLines(
 		}
 	}
 
-	style = style.Width(m.colWidth).Height(m.rowLines)
-	return style, lines
+	return key, lines
 }
 
 func (m Model) cellContentLines(slot int, t *task.Task, dayTasks []*task.Task) []string {
@@ -110,9 +192,9 @@ func (m Model) cellContentLines(slot int, t *task.Task, dayTasks []*task.Task) [
 		return lines
 	}
 
-	indicator := "S"
-	if t.IsDeep() {
-		indicator = "D"
+	indicator := t.Category.Code()
+	if t.IsMissed() {
+		indicator = "!"
 	}
 	descLines := m.cachedTaskLines[t.ID]
 
@@ -124,6 +206,11 @@ func (m Model) cellContentLines(slot int, t *task.Task, dayTasks []*task.Task) [
 		}
 		startSlot--
 	}
+	// A block whose top is scrolled off is labelled from the topmost
+	// visible slot, so tall tasks stay identifiable.
+	if startSlot < m.scrollOffset && slot >= m.scrollOffset {
+		startSlot = m.scrollOffset
+	}
 	endSlot := slot
 	for endSlot+1 < len(dayTasks) {
 		nextTask := dayTasks[endSlot+1]
@@ -185,77 +272,86 @@ func (m Model) cellContentLines(slot int, t *task.Task, dayTasks []*task.Task) [
 	return lines
 }
 
-func (m Model) cellStyleForSlot(
+func (m Model) cellStyleKeyForSlot(
 	day, slot int,
 	t *task.Task,
 	cursorTask *task.Task,
 	shadeByDay map[int]map[int64]bool,
-) (lipgloss.Style, bool, bool) {
+) (cellStyleKey, bool, bool) {
 	isCursor := m.cursor.Day == day && m.cursor.Slot == slot
 	isPartOfCursorTask := cursorTask != nil && t != nil && t.ID == cursorTask.ID
 
-	style := m.styleCache.EmptyCell
+	key := cellEmpty
 	if t != nil {
-		isCurrent := m.isCurrentTask(t)
+		isCurrent := m.cachedClock.current[t.ID]
 		useAltShade := false
 		if dayShade := shadeByDay[day]; dayShade != nil {
 			useAltShade = dayShade[t.ID]
 		}
+		categoryKey, colored := m.styleCache.categoryKey(t.Category)
 
 		switch {
-		case t.IsPast():
+		case t.IsMissed():
+			key = cellMissed
+		case m.cachedClock.past[t.ID]:
 			if t.IsDeep() {
 				if useAltShade {
-					style = m.styleCache.TaskPastDeepAlt
+					key = cellPastDeepAlt
 				} else {
-					style = m.styleCache.TaskPastDeep
+					key = cellPastDeep
 				}
 			} else {
 				if useAltShade {
-					style = m.styleCache.TaskPastShallowAlt
+					key = cellPastShallowAlt
 				} else {
-					style = m.styleCache.TaskPastShallow
+					key = cellPastShallow
 				}
 			}
 		case isCurrent:
 			if t.IsDeep() {
-				style = m.styleCache.TaskCurrentDeep
+				key = cellCurrentDeep
 			} else {
-				style = m.styleCache.TaskCurrentShallow
+				key = cellCurrentShallow
 			}
+		case colored:
+			key = categoryKey
 		case t.IsDeep():
 			if useAltShade {
-				style = m.styleCache.TaskDeepAlt
+				key = cellDeepAlt
 			} else {
-				style = m.styleCache.TaskDeep
+				key = cellDeep
 			}
 		default:
 			if useAltShade {
-				style = m.styleCache.TaskShallowAlt
+				key = cellShallowAlt
 			} else {
-				style = m.styleCache.TaskShallow
+				key = cellShallow
 			}
 		}
 	}
 
 	if isCursor || isPartOfCursorTask {
 		if m.mode == ModeMove {
-			style = m.styleCache.TaskMovePreview
+			key = cellMovePreview
 		} else {
-			style = m.styleCache.Cursor
+			key = cellCursor
 		}
 	}
 
+	if m.isVisualSelected(day, slot, t) {
+		key = cellSelected
+	}
+
 	movingTask := m.slotState.MovingTask()
 	if m.mode == ModeMove && t != nil && movingTask != nil {
 		if t.ID == movingTask.ID {
-			style = m.styleCache.TaskSelected
+			key = cellSelected
 		} else if m.isTaskShifted(t) {
-			style = m.styleCache.TaskShifted
+			key = cellShifted
 		}
 	}
 
-	return style, isCursor, isPartOfCursorTask
+	return key, isCursor, isPartOfCursorTask
 }
 
 func (m Model) taskShadeMap() map[int]map[int64]bool {
@@ -315,7 +411,8 @@ func (m Model) renderCell(
 	cursorTask *task.Task,
 	shadeByDay map[int]map[int64]bool,
 ) string {
-	style, lines := m.cellStyleAndLines(0, slot, t, dayTasks, cursorTask, shadeByDay)
+	key, lines := m.cellStyleAndLines(0, slot, t, dayTasks, cursorTask, shadeByDay)
+	style := m.cellStyle(key, m.dayWidth(0))
 	if line < 0 || line >= len(lines) {
 		return style.Render(" ")
 	}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/javiermolinar/sancho/internal/startup"
 	"github.com/javiermolinar/sancho/internal/tui/commands"
 	"github.com/javiermolinar/sancho/internal/tui/view"
 )
@@ -18,6 +20,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		updated, cmd := m.handleKeyMsg(msg)
 		if model, ok := updated.(Model); ok {
+			model.leaveFocusIfMoved()
+			model.syncColumns()
 			model.refreshCachesIfNeeded()
 			return model, cmd
 		}
@@ -28,7 +32,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		m.colWidth = m.calculateColWidth()
 		m.calculateLayout() // Calculate rowHeight and rowLines together
-		m.styleCache = NewStyleCache(m.styles, m.colWidth)
+		m.rebuildStyleCache()
 		m.layoutCache = m.buildLayoutCache(m.width, m.height)
 		m.refreshViewCaches()
 		return m, nil
@@ -47,64 +51,137 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.refreshViewCaches()
 		return m, nil
 
+	case commands.DaysLoadedMsg:
+		// Days changed by a write
+		return m.handleDaysLoaded(msg)
+
 	case commands.InitialLoadMsg:
-		// Initial load of 3 weeks - update config and convert to slot grid
+		// Initial load of the week window - update config and convert to slot grid
 		newConfig := SlotGridConfigFromWeekWindow(msg.Window, m.config.Schedule.DayStart, m.config.Schedule.DayEnd, m.nowFunc(), m.rowHeight)
+		newConfig.Location = m.config.LocationFor
+		newConfig.SplitOwner = m.config.UI.SplitOwner
 		m.slotState.UpdateConfig(newConfig)
 		slotGrid := WeekWindowToSlotGrid(msg.Window, newConfig)
 		m.slotState.SetGrid(slotGrid)
 		m.loading = false
-		m.focusCursorOnCurrentTaskOrTime()
+		m.visibleOnly = msg.VisibleOnly
+		m.prefetched = nil // A full load may follow writes made elsewhere
+		var openLinks tea.Cmd
+		if m.focusTask != nil {
+			openLinks = m.openFocusTask()
+		} else {
+			m.focusCursorOnCurrentTaskOrTime()
+		}
+		if m.restoreOnLoad != nil && !msg.VisibleOnly {
+			m.restoreAutosave()
+		}
 		m.refreshViewCaches()
-		return m, nil
+		if msg.VisibleOnly {
+			startup.Mark("visible week")
+			return m, tea.Batch(commands.LoadAdjacentWeeks(m.repo, m.weekStart, m.weekRadius), m.startSync(), m.startReplica(),
+				commands.PollDataVersion(m.persistentRepo(), 0), openLinks)
+		}
+		return m, tea.Batch(m.prefetchWeeks(), openLinks)
 
-	case commands.WeekShiftedMsg:
-		// Shift prev/next week - shift the window and set the newly loaded edge week
+	case commands.AdjacentWeeksLoadedMsg:
+		// Skip stale loads and keep an edit session's grid; week navigation
+		// falls back to a full reload while visibleOnly is set.
+		if !m.visibleOnly || !msg.WeekStart.Equal(m.weekStart) || m.slotState.IsEditing() {
+			return m, nil
+		}
 		ww := m.slotState.WeekWindow()
-		if ww != nil {
-			if msg.Forward {
-				// Shift forward: prev←current, current←next, then set new next
-				ww.ShiftForward(msg.Week)
-			} else {
-				// Shift backward: next←current, current←prev, then set new prev
-				ww.ShiftBackward(msg.Week)
+		if ww == nil {
+			return m, nil
+		}
+		for offset := -ww.Radius(); offset <= ww.Radius(); offset++ {
+			if offset != 0 {
+				ww.SetWeek(offset, msg.Window.Week(offset))
 			}
 		}
-		// Update config with new FirstDate from the shifted week window
-		newConfig := SlotGridConfigFromWeekWindow(ww, m.config.Schedule.DayStart, m.config.Schedule.DayEnd, m.nowFunc(), m.rowHeight)
-		m.slotState.UpdateConfig(newConfig)
-		// Rebuild slot grid from updated week window with new config
-		slotGrid := WeekWindowToSlotGrid(ww, newConfig)
-		m.slotState.SetGrid(slotGrid)
-		m.loading = false
-		m.focusCursorOnCurrentTaskOrTime()
+		m.slotState.SetGrid(WeekWindowToSlotGrid(ww, m.slotState.Config()))
+		m.visibleOnly = false
 		m.refreshViewCaches()
-		return m, nil
+		startup.Mark("adjacent weeks")
+		return m, m.prefetchWeeks()
+
+	case commands.WeekPrefetchedMsg:
+		return m.handleWeekPrefetched(msg)
 
 	case commands.ErrMsg:
+		if msg.Stream != nil && msg.Stream != m.planStream {
+			// Late failure from a cancelled or replaced stream
+			return m, nil
+		}
+		if m.planStream != nil {
+			m.stopPlanStream()
+		}
 		m.err = msg.Err
 		m.statusMsg = fmt.Sprintf("Error: %v", msg.Err)
-		m.statusTime = time.Now().Add(5 * time.Second)
+		m.statusTime = m.now().Add(5 * time.Second)
 		return m, nil
 
 	case commands.StatusMsgCmd:
 		m.statusMsg = msg.Msg
-		m.statusTime = time.Now().Add(3 * time.Second)
+		m.statusTime = m.now().Add(3 * time.Second)
 		return m, tea.Tick(3*time.Second, func(time.Time) tea.Msg {
 			return commands.ClearStatusMsg{}
 		})
 
 	case commands.ClearStatusMsg:
-		if time.Now().After(m.statusTime) {
+		if m.now().After(m.statusTime) {
 			m.statusMsg = ""
 		}
 		return m, nil
 
+	case commands.AutosaveTickMsg:
+		return m.handleAutosaveTick()
+
+	case commands.AutosaveFoundMsg:
+		return m.handleAutosaveFound(msg)
+
+	case commands.KioskTickMsg:
+		return m.handleKioskTick()
+
+	case commands.NowTickMsg:
+		// The model only changes when the now-line moves or a task becomes
+		// current or past, so quiet minutes render an identical frame that
+		// the renderer skips.
+		m.refreshClock()
+		return m, commands.NowTick(m.now())
+
 	case commands.PlanStartedMsg:
 		m.statusMsg = "Planning..."
 		return m, nil
 
+	case commands.PlanChunkMsg:
+		if msg.Stream != m.planStream {
+			// Drain output from a cancelled stream
+			return m, msg.Stream.Next()
+		}
+		if msg.Attempt != m.planAttempt {
+			m.planAttempt = msg.Attempt
+			m.planStreamText = ""
+		}
+		m.planStreamText += msg.Chunk
+		return m, msg.Stream.Next()
+
+	case commands.PlanCancelledMsg:
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.planStream == nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.planSpinner, cmd = m.planSpinner.Update(msg)
+		return m, cmd
+
 	case commands.PlanResultMsg:
+		if msg.Stream != nil && msg.Stream != m.planStream {
+			return m, nil
+		}
+		m.planStream = nil
+		m.planStreamText = ""
 		m.planner = msg.Planner
 		m.planResult = msg.Result
 		m.mode = ModeModal
@@ -115,6 +192,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case commands.PlanSavedMsg:
 		m.statusMsg = fmt.Sprintf("Saved %d tasks", msg.Count)
 		m.planResult = nil
+		m.planPrevious = nil
 		m.planner = nil
 		m.mode = ModeNormal
 		m.modalType = ModalNone
@@ -130,6 +208,69 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.modalType = ModalWeekSummary
 		m.statusMsg = ""
 		return m, nil
+
+	case commands.SandboxStartedMsg, commands.SandboxAppliedMsg:
+		return m.handleSandboxMsg(msg)
+
+	case commands.SyncConflictsMsg:
+		return m.handleSyncConflictsMsg(msg)
+
+	case commands.SyncStartedMsg, commands.SyncEventMsg, commands.ActionsPushedMsg:
+		return m.handleSyncMsg(msg)
+
+	case commands.ReplicaStartedMsg, commands.ReplicaEventMsg, commands.ReplicaSyncedMsg:
+		return m.handleReplicaMsg(msg)
+
+	case commands.DataVersionMsg:
+		return m.handleDataVersion(msg)
+
+	case commands.BatchUpdatedMsg:
+		return m.handleBatchUpdated(msg)
+
+	case commands.TaskChangedMsg:
+		return m.handleTaskChanged()
+
+	case commands.TaskLinksMsg:
+		return m.handleTaskLinks(msg)
+
+	case commands.ObjectivesMsg:
+		return m.handleObjectivesMsg(msg)
+
+	case commands.ConflictResolvedMsg:
+		m.statusMsg = fmt.Sprintf("Merged %q", msg.Task.Description)
+		return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+
+	case commands.LLMLogMsg:
+		return m.handleLLMLogMsg(msg)
+
+	case commands.BacklogMsg:
+		return m.handleBacklogMsg(msg)
+	case commands.ParkedMsg:
+		return m.handleParkedMsg(msg)
+
+	case commands.ReviewMsg:
+		return m.handleReviewMsg(msg)
+
+	case commands.ReviewSavedMsg:
+		return m.handleReviewSaved(msg)
+
+	case commands.BacklogImportedMsg:
+		return m.handleBacklogImportedMsg(msg)
+
+	case commands.YearOverviewMsg:
+		return m.handleYearOverviewMsg(msg)
+
+	case commands.MissedTasksMsg:
+		return m.handleMissedTasksMsg(msg)
+
+	case commands.ReflectionMsg:
+		m.reflection = msg.Reflection
+		m.reflectionText = view.BuildReflectionLines(msg.Reflection)
+		m.reflectionCopyText = view.BuildReflectionCopyText(m.reflectionText)
+		m.mode = ModeModal
+		m.modalType = ModalReflection
+		m.statusMsg = ""
+		return m, nil
 	}
 
 	// Handle prompt input when in prompt mode
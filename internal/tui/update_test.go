@@ -2,11 +2,23 @@
 package tui
 
 import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/dwplanner"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/replica"
 	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
 	"github.com/javiermolinar/sancho/internal/tui/commands"
 )
 
@@ -66,3 +78,516 @@ func TestInitialLoadFocusesCursorOnCurrentTaskOrTime(t *testing.T) {
 		})
 	}
 }
+
+func TestInitialLoadOpensFocusTask(t *testing.T) {
+	cfg := &config.Config{
+		Schedule: config.ScheduleConfig{
+			DayStart: "09:00",
+			DayEnd:   "17:00",
+		},
+	}
+	linked := &task.Task{
+		ID:             42,
+		Description:    "Linked task",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 3, 12, 0, 0, 0, 0, time.Local), // Wednesday
+		ScheduledStart: "14:00",
+		ScheduledEnd:   "15:00",
+		Status:         task.StatusScheduled,
+	}
+
+	m := New(nil, cfg, WithFocusTask(linked))
+	m.rowHeight = 15
+	if !m.weekStart.Equal(startOfWeek(linked.ScheduledDate)) {
+		t.Fatalf("weekStart = %v, want week of the linked task", m.weekStart)
+	}
+
+	week := task.NewWeek(m.weekStart)
+	_ = week.Day(2).AddTask(linked)
+	updated, _ := m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, week, nil)})
+	model := updated.(Model)
+
+	if model.mode != ModeModal || model.modalType != ModalTaskDetail {
+		t.Fatalf("mode = %v modal = %v, want task detail modal", model.mode, model.modalType)
+	}
+	if model.modalTask == nil || model.modalTask.ID != 42 {
+		t.Fatalf("modalTask = %+v, want task 42", model.modalTask)
+	}
+	if model.cursor.Day != 2 {
+		t.Fatalf("cursor day = %d, want 2", model.cursor.Day)
+	}
+	if want := (14*60 - 9*60) / 15; model.cursor.Slot != want {
+		t.Fatalf("cursor slot = %d, want %d", model.cursor.Slot, want)
+	}
+	if model.focusTask != nil {
+		t.Fatal("focusTask should be cleared after opening")
+	}
+}
+
+func TestVisibleWeekLoadsAdjacentWeeksLater(t *testing.T) {
+	cfg := &config.Config{
+		Schedule: config.ScheduleConfig{
+			DayStart: "09:00",
+			DayEnd:   "17:00",
+		},
+	}
+	m := New(nil, cfg)
+	current := task.NewWeek(m.weekStart)
+	updated, cmd := m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, current, nil), VisibleOnly: true})
+	model := updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected a command loading the adjacent weeks")
+	}
+	if model.navWeekWindow() != nil {
+		t.Fatal("week navigation should fall back to a full reload before adjacent weeks load")
+	}
+
+	next := task.NewWeek(m.weekStart.AddDate(0, 0, 7))
+	_ = next.Day(0).AddTask(&task.Task{
+		ID:             7,
+		Description:    "Next Monday",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  next.StartDate,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+	})
+	stale := commands.AdjacentWeeksLoadedMsg{WeekStart: m.weekStart.AddDate(0, 0, 7)}
+	updated, _ = model.Update(stale)
+	model = updated.(Model)
+	if !model.visibleOnly {
+		t.Fatal("adjacent weeks for another week should be ignored")
+	}
+
+	updated, _ = model.Update(commands.AdjacentWeeksLoadedMsg{
+		WeekStart: m.weekStart,
+		Window:    task.NewWeekWindow(task.NewWeek(m.weekStart.AddDate(0, 0, -7)), nil, next),
+	})
+	model = updated.(Model)
+	ww := model.navWeekWindow()
+	if ww == nil {
+		t.Fatal("week window should be available once adjacent weeks load")
+	}
+	if tasks := ww.Next().AllTasks(); len(tasks) != 1 || tasks[0].ID != 7 {
+		t.Fatalf("next week tasks = %+v, want task 7", tasks)
+	}
+}
+
+func TestWeekNavigationLoadsEdgeOfWiderWindow(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	later := &task.Task{
+		Description:    "Quarterly review",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  monday.AddDate(0, 0, 21),
+		ScheduledStart: "10:00",
+		ScheduledEnd:   "11:00",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(context.Background(), later); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.UI.WindowWeeks = 5
+	m := *New(repo, cfg, WithClock(clock.Fixed(monday)))
+	updated, cmd := m.Update(commands.LoadInitialWeeks(repo, m.weekStart, m.weekRadius)())
+	m = runCmds(updated.(Model), cmd)
+	if got := m.slotState.Config().NumDays; got != 35 {
+		t.Fatalf("NumDays = %d, want 35 for a 5-week window", got)
+	}
+
+	updated, cmd = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	m = updated.(Model)
+	if m.loading || !m.weekStart.Equal(monday.AddDate(0, 0, 7)) {
+		t.Fatalf("L should show the next week at once, weekStart = %v loading = %v", m.weekStart, m.loading)
+	}
+	if !m.navWeekWindow().HasNext() {
+		t.Fatal("L should bring in the prefetched edge week")
+	}
+	msg, ok := cmd().(commands.WeekPrefetchedMsg)
+	if !ok {
+		t.Fatalf("L should prefetch the week beyond the new edge, got %T", msg)
+	}
+	if want := monday.AddDate(0, 0, 28); !msg.Week.StartDate.Equal(want) {
+		t.Fatalf("prefetched week starts %v, want %v", msg.Week.StartDate, want)
+	}
+	m = runCmds(m, func() tea.Msg { return msg })
+	if _, ok := m.prefetched[weekKey(monday.AddDate(0, 0, -14))]; !ok {
+		t.Error("the week leaving the window should be kept for going back")
+	}
+
+	cfgAfter := m.slotState.Config()
+	if want := monday.AddDate(0, 0, -7); !cfgAfter.FirstDate.Equal(want) {
+		t.Errorf("FirstDate = %v, want %v", cfgAfter.FirstDate, want)
+	}
+	ww := m.navWeekWindow()
+	if tasks := ww.Week(2).AllTasks(); len(tasks) != 1 || tasks[0].ID != later.ID {
+		t.Fatalf("last week tasks = %+v, want the quarterly review", tasks)
+	}
+	if !ww.Week(-2).StartDate.Equal(monday.AddDate(0, 0, -7)) {
+		t.Errorf("first week starts %v, want the week before the original", ww.Week(-2).StartDate)
+	}
+}
+
+// runCmds feeds the messages of cmd, and of the commands they lead to, to m.
+func runCmds(m Model, cmd tea.Cmd) Model {
+	if cmd == nil {
+		return m
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			m = runCmds(m, c)
+		}
+		return m
+	}
+	updated, next := m.Update(msg)
+	return runCmds(updated.(Model), next)
+}
+
+func TestPlanStreamChunksAndCancel(t *testing.T) {
+	cfg := config.Default()
+	cfg.LLM.Provider = "unsupported"
+
+	m := New(nil, cfg)
+	updated, _ := m.startPlan("write report")
+	model := updated.(Model)
+	if model.planStream == nil {
+		t.Fatal("expected plan stream to be started")
+	}
+	if model.mode != ModeModal || model.modalType != ModalPlanResult {
+		t.Fatalf("mode = %v modal = %v, want plan result modal", model.mode, model.modalType)
+	}
+
+	stream := model.planStream
+	updated, _ = model.Update(commands.PlanChunkMsg{Stream: stream, Attempt: 0, Chunk: `{"tasks": [`})
+	model = updated.(Model)
+	updated, _ = model.Update(commands.PlanChunkMsg{Stream: stream, Attempt: 0, Chunk: `{"description": "Report"}`})
+	model = updated.(Model)
+	if model.planStreamText != `{"tasks": [{"description": "Report"}` {
+		t.Fatalf("planStreamText = %q", model.planStreamText)
+	}
+
+	vm, ok := model.planResultModalViewModel()
+	if !ok || !vm.Streaming {
+		t.Fatal("expected streaming view model")
+	}
+	if len(vm.Model.Days) != 1 {
+		t.Fatalf("days = %d, want 1 partial day", len(vm.Model.Days))
+	}
+
+	// A new attempt discards output from the previous one
+	updated, _ = model.Update(commands.PlanChunkMsg{Stream: stream, Attempt: 1, Chunk: "{"})
+	model = updated.(Model)
+	if model.planStreamText != "{" {
+		t.Fatalf("planStreamText = %q, want reset on new attempt", model.planStreamText)
+	}
+
+	updated, _ = model.handlePlanResultKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(Model)
+	if model.planStream != nil {
+		t.Fatal("expected plan stream to be cleared after cancel")
+	}
+	if model.mode != ModeNormal || model.modalType != ModalNone {
+		t.Fatalf("mode = %v modal = %v, want normal", model.mode, model.modalType)
+	}
+
+	// Late results from the cancelled stream are ignored
+	updated, _ = model.Update(commands.PlanResultMsg{Stream: stream})
+	model = updated.(Model)
+	if model.modalType != ModalNone {
+		t.Fatal("expected stale plan result to be ignored")
+	}
+
+	// So are late errors from it
+	status := model.statusMsg
+	updated, _ = model.Update(commands.ErrMsg{Stream: stream, Err: errors.New("boom")})
+	model = updated.(Model)
+	if model.err != nil || model.statusMsg != status {
+		t.Fatalf("expected stale plan error to be ignored, got err=%v status=%q", model.err, model.statusMsg)
+	}
+}
+
+func TestPlanAmendKeepsPreviousDraftForDiff(t *testing.T) {
+	cfg := config.Default()
+	cfg.LLM.Provider = "unsupported"
+
+	draft := &dwplanner.PlanResult{
+		TasksByDate: map[string][]dwplanner.PlannedTask{
+			"2026-01-12": {{Description: "Report", Category: "deep", ScheduledDate: "2026-01-12", ScheduledStart: "09:00", ScheduledEnd: "10:00"}},
+		},
+		SortedDates: []string{"2026-01-12"},
+	}
+
+	m := New(nil, cfg)
+	m.planResult = draft
+	m.mode = ModeModal
+	m.modalType = ModalPlanResult
+
+	updated, _ := m.handlePlanResultKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	model := updated.(Model)
+	if !model.planAmending || model.planPrevious != draft || model.mode != ModePrompt {
+		t.Fatalf("expected amend prompt with previous draft, got amending=%v mode=%v", model.planAmending, model.mode)
+	}
+
+	// Esc returns to the unchanged draft
+	updated, _ = model.handlePromptKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(Model)
+	if model.planAmending || model.planPrevious != nil || model.planResult != draft || model.modalType != ModalPlanResult {
+		t.Fatal("expected esc to restore the draft being amended")
+	}
+
+	// Submitting feedback starts a new draft and keeps the old one for the diff
+	updated, _ = model.handlePlanResultKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	model = updated.(Model)
+	updated, _ = model.handlePromptSubmit("move it to the afternoon")
+	model = updated.(Model)
+	if model.planStream == nil || model.planPrevious != draft {
+		t.Fatal("expected amend stream with previous draft kept")
+	}
+
+	revised := &dwplanner.PlanResult{
+		TasksByDate: map[string][]dwplanner.PlannedTask{
+			"2026-01-12": {{Description: "Report", Category: "deep", ScheduledDate: "2026-01-12", ScheduledStart: "14:00", ScheduledEnd: "15:00"}},
+		},
+		SortedDates: []string{"2026-01-12"},
+	}
+	stream := model.planStream
+	defer stream.Cancel()
+	updated, _ = model.Update(commands.PlanResultMsg{Stream: stream, Result: revised})
+	model = updated.(Model)
+
+	vm, ok := model.planResultModalViewModel()
+	if !ok || len(vm.Model.Changes) != 1 || vm.Model.Changes[0].Marker != "~" {
+		t.Fatalf("expected one moved change in the diff, got %+v", vm.Model.Changes)
+	}
+}
+
+func TestPlanAmendAutoDraftReschedules(t *testing.T) {
+	repo := memrepo.New()
+	monday := time.Date(2025, 1, 13, 8, 0, 0, 0, time.Local)
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(monday)))
+
+	updated, cmd := m.handlePromptSubmit("/auto write report 2h")
+	m = updated.(Model)
+	if m.planInput != "write report 2h" || cmd == nil {
+		t.Fatalf("planInput = %q, want the /auto input", m.planInput)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.planResult == nil || m.planResult.TotalTasks() != 1 {
+		t.Fatal("expected an auto draft with one task")
+	}
+
+	updated, _ = m.handlePlanResultKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m = updated.(Model)
+	updated, cmd = m.handlePromptSubmit("email triage 30m shallow")
+	m = updated.(Model)
+	if m.planStream != nil || cmd == nil {
+		t.Fatal("expected the amend to go through the auto scheduler, not the LLM")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.planResult == nil || m.planResult.TotalTasks() != 2 {
+		t.Fatal("expected the amended auto draft to keep the original task and add the new one")
+	}
+}
+
+func TestSyncConflictModalSavesMergedTask(t *testing.T) {
+	cfg := &config.Config{
+		Schedule: config.ScheduleConfig{
+			DayStart: "09:00",
+			DayEnd:   "17:00",
+		},
+	}
+	base := task.Task{
+		ID:             7,
+		Description:    "Write report",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 3, 12, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+		ExternalRef:    task.ExternalRef{Source: "todoist", ID: "42"},
+	}
+	local, remote := base, base
+	local.Description = "Draft report"
+	remote.Description = "Outline report"
+	conflict, ok := tasksync.Detect("todoist", base, local, remote)
+	if !ok {
+		t.Fatal("expected a conflict")
+	}
+
+	m := New(nil, cfg)
+	updated, _ := m.Update(commands.SyncConflictsMsg{Conflicts: []*tasksync.Conflict{conflict}})
+	model := updated.(Model)
+	if model.mode != ModeModal || model.modalType != ModalSyncConflict {
+		t.Fatalf("mode = %v modal = %v, want sync conflict modal", model.mode, model.modalType)
+	}
+	if view := model.renderSyncConflictModal(); !strings.Contains(view, "Outline report") || !strings.Contains(view, "TODOIST") {
+		t.Fatalf("modal missing remote pane:\n%s", view)
+	}
+
+	// Take the remote description, then save the merge
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	model = updated.(Model)
+	if got := model.syncConflicts[0].Merged().Description; got != "Outline report" {
+		t.Fatalf("merged description = %q, want Outline report", got)
+	}
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected a save command")
+	}
+	if model.mode != ModeNormal || len(model.syncConflicts) != 0 {
+		t.Fatalf("mode = %v conflicts = %d, want modal closed and queue empty", model.mode, len(model.syncConflicts))
+	}
+}
+
+func TestSyncEventQueuesConflictsAndKeepsWaiting(t *testing.T) {
+	cfg := &config.Config{
+		Schedule: config.ScheduleConfig{
+			DayStart: "09:00",
+			DayEnd:   "17:00",
+		},
+	}
+	base := task.Task{
+		Description:    "Standup",
+		Category:       task.CategoryShallow,
+		ScheduledDate:  time.Date(2025, 3, 12, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "09:30",
+		Status:         task.StatusScheduled,
+		ExternalRef:    task.ExternalRef{Source: "ics", ID: "work/1"},
+	}
+	local, remote := base, base
+	local.Description = "Standup (moved)"
+	remote.ScheduledStart, remote.ScheduledEnd = "10:00", "10:30"
+	conflict, ok := tasksync.Detect("work", base, local, remote)
+	if !ok {
+		t.Fatal("expected a conflict")
+	}
+
+	events := make(chan tasksync.Event)
+	m := New(nil, cfg)
+	updated, cmd := m.Update(commands.SyncEventMsg{
+		Event: tasksync.Event{Status: tasksync.Status{
+			Source: "work",
+			Result: tasksync.Result{Conflicts: []*tasksync.Conflict{conflict}},
+		}},
+		Events: events,
+	})
+	model := updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected to keep waiting for sync events")
+	}
+	if model.modalType != ModalSyncConflict || len(model.syncConflicts) != 1 {
+		t.Fatalf("modal = %v conflicts = %d, want sync conflict modal", model.modalType, len(model.syncConflicts))
+	}
+}
+
+func TestReplicaEventReportsConflicts(t *testing.T) {
+	cfg := &config.Config{
+		Schedule: config.ScheduleConfig{
+			DayStart: "09:00",
+			DayEnd:   "17:00",
+		},
+	}
+	events := make(chan replica.Status)
+	m := New(nil, cfg)
+	updated, cmd := m.Update(commands.ReplicaEventMsg{
+		Status: replica.Status{Remote: "dav.example.com", Result: replica.Result{
+			Conflicts: []*task.Task{{ID: 1, Description: "Report"}},
+		}},
+		Events: events,
+	})
+	model := updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected to keep waiting for replica events")
+	}
+	if !strings.Contains(model.statusMsg, "1 tasks changed on both machines") {
+		t.Errorf("status = %q, want the conflict reported", model.statusMsg)
+	}
+
+	updated, _ = model.handleSyncCommand([]string{"push"})
+	if got := updated.(Model).statusMsg; !strings.Contains(got, "not configured") {
+		t.Errorf("/sync push without a replica = %q, want not configured", got)
+	}
+}
+
+func TestDataVersionReloadsAfterAnotherWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	repo, err := db.New(path)
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	defer func() { _ = repo.Close() }()
+	other, err := db.New(path)
+	if err != nil {
+		t.Fatalf("db.New second instance: %v", err)
+	}
+	defer func() { _ = other.Close() }()
+
+	monday := time.Date(2025, 1, 6, 9, 0, 0, 0, time.Local)
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(monday)))
+	poll := func() tea.Cmd {
+		t.Helper()
+		version, err := repo.DataVersion(context.Background())
+		if err != nil {
+			t.Fatalf("DataVersion: %v", err)
+		}
+		updated, cmd := m.Update(commands.DataVersionMsg{Version: version})
+		m = updated.(Model)
+		return cmd
+	}
+
+	if cmd := poll(); cmd == nil || m.dataVersion == "" {
+		t.Fatal("first poll should record the version and keep polling")
+	}
+	first := m.dataVersion
+
+	err = other.CreateTask(context.Background(), &task.Task{
+		Description:    "Added from the CLI",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "10:00",
+		ScheduledEnd:   "11:00",
+		Status:         task.StatusScheduled,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	m.mode = ModeModal
+	poll()
+	if m.dataVersion != first {
+		t.Fatal("reload should wait while a modal is open")
+	}
+
+	m.mode = ModeNormal
+	if cmd := poll(); cmd == nil || m.dataVersion == first {
+		t.Fatal("expected a reload after another process wrote")
+	}
+}
+
+func TestFormatAgo(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{2*time.Hour + 10*time.Minute, "2h ago"},
+	}
+	for _, tt := range tests {
+		if got := formatAgo(tt.d); got != tt.want {
+			t.Errorf("formatAgo(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
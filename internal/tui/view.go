@@ -1,18 +1,31 @@
 package tui
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
+	"github.com/javiermolinar/sancho/internal/debuglog"
+	"github.com/javiermolinar/sancho/internal/startup"
 	"github.com/javiermolinar/sancho/internal/tui/view"
 )
 
 // View renders the TUI using a boxed, parent-controlled layout.
 func (m Model) View() string {
+	defer debuglog.Span("render", "mode", modeString(m.mode))()
+	start := time.Now()
 	state := m.viewState()
-	return view.Render(state)
+	out := view.Render(state)
+	if p := m.profile; p != nil && p.on {
+		p.frame(time.Since(start), m.cellCache.reused, m.cellCache.restyled)
+	}
+	if m.slotState.Grid() != nil {
+		startup.Mark(startup.FirstFrame)
+	}
+	return out
 }
 
 func (m Model) viewState() view.ViewState {
@@ -77,20 +90,60 @@ func (m Model) tableViewState(layout LayoutCache) view.TableViewState {
 		visibleSlots = 0
 	}
 
-	headers, todayCols := view.HeaderLabels(m.weekStart, time.Now())
-	rows, cellStyles := m.buildGridTableRows(visibleSlots)
+	weekNames, weekDates, weekToday := view.HeaderLabels(m.weekStart, m.now(), m.locale)
+	headers := []string{weekNames[0]}
+	dates := []string{weekDates[0]}
+	todayCols := make(map[int]bool)
+	widths := []int{6}
+	for _, day := range m.visibleDays() {
+		name, date := weekNames[day+1], weekDates[day+1]
+		if m.isNarrowDay(day) {
+			d := m.weekStart.AddDate(0, 0, day)
+			name = string([]rune(m.locale.ShortWeekday(d.Weekday()))[:2])
+			if weekToday[day+1] {
+				name = "*" + name + "*"
+			}
+			date = strconv.Itoa(d.Day())
+		} else if abbr := m.pinnedZoneAbbr(m.weekStart.AddDate(0, 0, day)); abbr != "" {
+			date += " " + abbr
+		}
+		headers = append(headers, name)
+		dates = append(dates, date)
+		todayCols[len(headers)-1] = weekToday[day+1]
+		widths = append(widths, m.dayWidth(day))
+	}
 
 	headerStyles := make([]lipgloss.Style, len(headers))
-	if len(headers) > 0 {
-		headerStyles[0] = m.styles.TimeColumnStyle.Width(6)
-	}
+	headerStyles[0] = m.styles.TimeColumnStyle.Width(6)
 	for i := 1; i < len(headers); i++ {
 		style := m.styleCache.DayHeader
 		if todayCols[i] {
 			style = m.styleCache.DayHeaderToday
 		}
-		headerStyles[i] = style
+		headerStyles[i] = style.Width(widths[i])
+	}
+
+	// The table header holds one line, so the dates are the first row,
+	// styled as header.
+	rows, cellStyles := m.buildGridTableRows(visibleSlots)
+	if m.offHoursCollapsed {
+		top, topStyles := m.offHoursBandRow(true, m.scrollOffset == 0)
+		bottom, bottomStyles := m.offHoursBandRow(false, m.scrollOffset+visibleSlots >= totalSlots)
+		rows = append(append([][]string{top}, rows...), bottom)
+		cellStyles = append(append([][]lipgloss.Style{topStyles}, cellStyles...), bottomStyles)
+	}
+	if m.bannerLines() > 0 {
+		banner, bannerStyles := m.bannerRow()
+		rows = append([][]string{banner}, rows...)
+		cellStyles = append([][]lipgloss.Style{bannerStyles}, cellStyles...)
+	}
+	if m.parkingLines() > 0 {
+		parking, parkingStyles := m.parkingRow()
+		rows = append(rows, parking)
+		cellStyles = append(cellStyles, parkingStyles)
 	}
+	rows = append([][]string{dates}, rows...)
+	cellStyles = append([][]lipgloss.Style{headerStyles}, cellStyles...)
 
 	borderStyle := lipgloss.NewStyle().
 		Foreground(m.styles.colorAccent).
@@ -127,7 +180,7 @@ func (m Model) footerViewState(layout LayoutCache) view.FooterModel {
 		InnerW:           layout.InnerW,
 		FooterH:          layout.FooterH,
 		FullFooter:       layout.FooterH >= footerMinHeight,
-		StatsLine:        m.renderStatsBar(layout.InnerW),
+		StatsLine:        m.statsLine(layout.InnerW),
 		LegendText:       legendText,
 		StatusText:       statusText,
 		HelpText:         helpText,
@@ -145,9 +198,11 @@ func (m Model) footerViewState(layout LayoutCache) view.FooterModel {
 	}
 }
 
+// padRight pads s with spaces to width terminal columns.
 func padRight(s string, width int) string {
-	if len(s) >= width {
+	w := ansi.StringWidth(s)
+	if w >= width {
 		return s
 	}
-	return s + strings.Repeat(" ", width-len(s))
+	return s + strings.Repeat(" ", width-w)
 }
@@ -0,0 +1,43 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BacklogRow is one item in the backlog modal.
+type BacklogRow struct {
+	Title  string
+	Notes  string // e.g. the link to the imported issue
+	Parked string // Day the item is parked on, e.g. "Mon Jan 6"; empty in the backlog
+}
+
+// BacklogStyles groups styles for the backlog modal.
+type BacklogStyles struct {
+	BodyStyle   stringRenderer
+	MetaStyle   stringRenderer
+	CursorStyle stringRenderer
+}
+
+// RenderBacklogBody renders one line per backlog item with its notes below.
+func RenderBacklogBody(rows []BacklogRow, cursor int, styles BacklogStyles) string {
+	if len(rows) == 0 {
+		return styles.MetaStyle.Render("The backlog is empty. Try /import github owner/repo.")
+	}
+	lines := make([]string, 0, len(rows)*2)
+	for i, r := range rows {
+		style := styles.BodyStyle
+		if i == cursor {
+			style = styles.CursorStyle
+		}
+		line := fmt.Sprintf("%2d  %s", i+1, r.Title)
+		if r.Parked != "" {
+			line += "  (parked " + r.Parked + ")"
+		}
+		lines = append(lines, style.Render(line))
+		if r.Notes != "" {
+			lines = append(lines, styles.MetaStyle.Render("    "+snippet(r.Notes)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,39 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EditPreviewRow is one task whose day or times an edit session changes.
+type EditPreviewRow struct {
+	Description string
+	OldDate     time.Time
+	OldStart    string
+	OldEnd      string
+	NewDate     time.Time
+	NewStart    string
+	NewEnd      string
+}
+
+// RenderEditPreviewBody renders one line per changed task, old → new. The
+// new day is shown only when it differs. Styles are those of the missed
+// tasks modal.
+func RenderEditPreviewBody(rows []EditPreviewRow, styles MissedStyles) string {
+	lines := make([]string, 0, len(rows)+2)
+	header := fmt.Sprintf("Saving will change %d tasks:", len(rows))
+	if len(rows) == 1 {
+		header = "Saving will change 1 task:"
+	}
+	lines = append(lines, styles.MetaStyle.Render(header), "")
+	for _, r := range rows {
+		was := fmt.Sprintf("%s %s-%s", r.OldDate.Format("Mon Jan 02"), r.OldStart, r.OldEnd)
+		to := fmt.Sprintf("%s-%s", r.NewStart, r.NewEnd)
+		if !r.NewDate.Equal(r.OldDate) {
+			to = r.NewDate.Format("Mon Jan 02") + " " + to
+		}
+		lines = append(lines, styles.BodyStyle.Render(fmt.Sprintf("%s → %s  %s", was, to, r.Description)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,7 +1,12 @@
 // Package view provides rendering helpers for the TUI.
 package view
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
 
 // FormatDuration formats minutes as "Xh Ym".
 func FormatDuration(minutes int) string {
@@ -15,3 +20,40 @@ func FormatDuration(minutes int) string {
 	}
 	return fmt.Sprintf("%dh %dm", h, m)
 }
+
+// FormatCategory returns the category name capitalized, e.g. "Meeting".
+func FormatCategory(c task.Category) string {
+	name := string(c)
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// GoalBar renders goal progress as a fixed-width bar: done time as "█",
+// planned time as "▓" and the remainder as "░".
+func GoalBar(g task.GoalProgress, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	done, planned := 0, 0
+	if g.Target > 0 {
+		done = min(width, g.Done*width/g.Target)
+		planned = min(width-done, g.Total()*width/g.Target-done)
+	}
+	return strings.Repeat("█", done) + strings.Repeat("▓", planned) + strings.Repeat("░", width-done-planned)
+}
+
+// FormatBudget formats a category's use of its weekly limit as e.g.
+// "meeting 12h/10h over" or "meeting 6h/10h 60%".
+func FormatBudget(g task.GoalProgress) string {
+	if g.Over() {
+		return fmt.Sprintf("%s %s/%s over", g.Label, FormatDuration(g.Total()), FormatDuration(g.Target))
+	}
+	return FormatGoal(g)
+}
+
+// FormatGoal formats goal progress as e.g. "Deep 9h/15h 60%".
+func FormatGoal(g task.GoalProgress) string {
+	return fmt.Sprintf("%s %s/%s %d%%", g.Label, FormatDuration(g.Total()), FormatDuration(g.Target), g.Percent())
+}
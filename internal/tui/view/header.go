@@ -1,34 +1,48 @@
 package view
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
-	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/dateutil"
 )
 
-// HeaderLabels builds column labels and marks today's column.
-func HeaderLabels(weekStart time.Time, today time.Time) ([]string, map[int]bool) {
-	labels := make([]string, 0, 8)
-	todayCols := make(map[int]bool)
+// HeaderLines is how many lines the grid header takes: the table header
+// with the day names and the row of dates below it.
+const HeaderLines = 2
 
+// HeaderLabels builds the labels of the grid header, with day and month
+// names from locale: names for the table header and dates for the row below
+// it. The corner shows the ISO week number over the month and year. A day's
+// date names the month on the first of a month other than the corner's.
+// Today's name is starred and its column marked.
+func HeaderLabels(weekStart time.Time, today time.Time, locale dateutil.Locale) (names, dates []string, todayCols map[int]bool) {
+	names = make([]string, 0, 8)
+	dates = make([]string, 0, 8)
+	todayCols = make(map[int]bool)
+
+	_, week := dateutil.ISOWeek(weekStart)
 	yearSuffix := weekStart.Year() % 100
-	monthLabel := weekStart.Format("Jan") + " " + strconv.Itoa(yearSuffix/10) + strconv.Itoa(yearSuffix%10)
-	labels = append(labels, monthLabel)
+	names = append(names, fmt.Sprintf("W%02d", week))
+	dates = append(dates, locale.ShortMonth(weekStart.Month())+" "+strconv.Itoa(yearSuffix/10)+strconv.Itoa(yearSuffix%10))
 
 	for i := 0; i < 7; i++ {
 		dayDate := weekStart.AddDate(0, 0, i)
-		dayName := task.WeekdayShortName(i)
-		dayNum := dayDate.Day()
-		label := dayName + " " + strconv.Itoa(dayNum)
+		name := locale.ShortWeekday(dayDate.Weekday())
+		date := strconv.Itoa(dayDate.Day())
+		if dayDate.Month() != weekStart.Month() && dayDate.Day() == 1 {
+			date = locale.ShortMonth(dayDate.Month()) + " " + date
+		}
 		if sameDay(dayDate, today) {
-			label = "*" + label + "*"
+			name = "*" + name + "*"
 			todayCols[i+1] = true
 		}
-		labels = append(labels, label)
+		names = append(names, name)
+		dates = append(dates, date)
 	}
 
-	return labels, todayCols
+	return names, dates, todayCols
 }
 
 func sameDay(a, b time.Time) bool {
@@ -0,0 +1,40 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+)
+
+func TestHeaderLabels_UsesLocaleAndWeekStart(t *testing.T) {
+	es, _ := dateutil.LookupLocale("es")
+	sunday := time.Date(2025, 8, 3, 0, 0, 0, 0, time.Local)
+	today := sunday.AddDate(0, 0, 2)
+
+	names, dates, todayCols := HeaderLabels(sunday, today, es)
+	wantNames := []string{"W32", "Dom", "Lun", "*Mar*", "Mié", "Jue", "Vie", "Sáb"}
+	wantDates := []string{"Ago 25", "3", "4", "5", "6", "7", "8", "9"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("names = %q, want %q", names, wantNames)
+	}
+	if !reflect.DeepEqual(dates, wantDates) {
+		t.Errorf("dates = %q, want %q", dates, wantDates)
+	}
+	if !todayCols[3] {
+		t.Errorf("todayCols = %v, want column 3", todayCols)
+	}
+}
+
+func TestHeaderLabels_MonthChange(t *testing.T) {
+	en, _ := dateutil.LookupLocale("en")
+	monday := time.Date(2025, 3, 31, 0, 0, 0, 0, time.Local)
+	names, dates, _ := HeaderLabels(monday, monday.AddDate(0, 0, -7), en)
+	if names[0] != "W14" || dates[0] != "Mar 25" {
+		t.Errorf("corner = %q over %q, want the week over the month it starts in", names[0], dates[0])
+	}
+	if dates[1] != "31" || dates[2] != "Apr 1" || dates[3] != "2" {
+		t.Errorf("dates = %q, want the month named on the 1st", dates[1:4])
+	}
+}
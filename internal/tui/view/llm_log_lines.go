@@ -0,0 +1,57 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/llm"
+)
+
+// llmLogSnippetLen caps how much of each prompt and response is shown.
+const llmLogSnippetLen = 160
+
+// BuildLLMLogLines builds lines for the LLM audit log modal, newest call first.
+func BuildLLMLogLines(entries []llm.AuditEntry, path string) []WeekSummaryLine {
+	lines := make([]WeekSummaryLine, 0, len(entries)*4+2)
+	if len(entries) == 0 {
+		lines = append(lines, WeekSummaryLine{Text: "No LLM calls recorded yet.", Style: WeekSummaryLineMeta})
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		header := fmt.Sprintf("%s  %s/%s  %s  %s",
+			e.Time.Local().Format("Mon Jan 2 15:04"), e.Provider, e.Model, e.Kind,
+			(time.Duration(e.DurationMS) * time.Millisecond).Round(100*time.Millisecond))
+		lines = append(lines, WeekSummaryLine{Text: header, Style: WeekSummaryLineSection})
+		if prompt := lastUserMessage(e.Messages); prompt != "" {
+			lines = append(lines, WeekSummaryLine{Text: "> " + snippet(prompt)})
+		}
+		if e.Response != "" {
+			lines = append(lines, WeekSummaryLine{Text: "< " + snippet(e.Response)})
+		}
+		if e.Error != "" {
+			lines = append(lines, WeekSummaryLine{Text: "! " + snippet(e.Error)})
+		}
+		lines = append(lines, WeekSummaryLine{Text: ""})
+	}
+	lines = append(lines, WeekSummaryLine{Text: "Full log: " + path, Style: WeekSummaryLineMeta})
+	return lines
+}
+
+func lastUserMessage(messages []llm.Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// snippet flattens s onto one line and truncates it.
+func snippet(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > llmLogSnippetLen {
+		return string(r[:llmLogSnippetLen-1]) + "…"
+	}
+	return s
+}
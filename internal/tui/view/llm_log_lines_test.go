@@ -0,0 +1,41 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/llm"
+)
+
+func TestBuildLLMLogLines(t *testing.T) {
+	entries := []llm.AuditEntry{
+		{
+			Time: time.Date(2025, 1, 6, 9, 30, 0, 0, time.Local), Provider: "ollama", Model: "llama3", Kind: "stream",
+			Messages:   []llm.Message{{Role: "system", Content: "You plan"}, {Role: "user", Content: "write\nreport 2h"}},
+			Response:   `{"tasks": []}`,
+			DurationMS: 1250,
+		},
+		{
+			Time: time.Date(2025, 1, 6, 10, 0, 0, 0, time.Local), Provider: "copilot", Model: "gpt-4o", Kind: "chat",
+			Messages: []llm.Message{{Role: "user", Content: "reflect"}},
+			Error:    "timeout",
+		},
+	}
+
+	text := linesToText(BuildLLMLogLines(entries, "/tmp/llm-audit.jsonl"))
+	for _, want := range []string{
+		"Mon Jan 6 09:30  ollama/llama3  stream  1.3s",
+		"> write report 2h",
+		`< {"tasks": []}`,
+		"! timeout",
+		"Full log: /tmp/llm-audit.jsonl",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "copilot") > strings.Index(text, "ollama") {
+		t.Errorf("expected newest call first:\n%s", text)
+	}
+}
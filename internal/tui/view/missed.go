@@ -0,0 +1,44 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MissedRow is one missed task and the slot proposed for it.
+type MissedRow struct {
+	Description string
+	Date        time.Time
+	Start       string
+	NewDate     time.Time
+	NewStart    string // Empty when no upcoming slot fits
+	NewEnd      string
+}
+
+// MissedStyles groups styles for the missed tasks modal.
+type MissedStyles struct {
+	BodyStyle stringRenderer
+	MetaStyle stringRenderer
+}
+
+// RenderMissedBody renders one line per missed task with where it would be
+// rescheduled to.
+func RenderMissedBody(rows []MissedRow, styles MissedStyles) string {
+	lines := make([]string, 0, len(rows)+2)
+	header := fmt.Sprintf("%d tasks ended without an outcome.", len(rows))
+	if len(rows) == 1 {
+		header = "1 task ended without an outcome."
+	}
+	lines = append(lines, styles.MetaStyle.Render(header), "")
+	for _, r := range rows {
+		was := r.Date.Format("Mon Jan 02") + " " + r.Start
+		if r.NewStart == "" {
+			lines = append(lines, styles.MetaStyle.Render(fmt.Sprintf("%s  %s  → no free slot", was, r.Description)))
+			continue
+		}
+		to := fmt.Sprintf("%s %s-%s", r.NewDate.Format("Mon Jan 02"), r.NewStart, r.NewEnd)
+		lines = append(lines, styles.BodyStyle.Render(fmt.Sprintf("%s  %s  → %s", was, r.Description, to)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -3,9 +3,12 @@ package view
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/javiermolinar/sancho/internal/dwplanner"
 	"github.com/javiermolinar/sancho/internal/task"
@@ -45,33 +48,50 @@ func NewTaskFormModel(input TaskFormInput) TaskFormModel {
 	}
 }
 
+// BuildTaskCopyText renders a task as a single plain-text line for copying,
+// e.g. "2025-02-03 09:00-11:00 [D] Write design doc".
+func BuildTaskCopyText(t *task.Task) string {
+	return fmt.Sprintf("%s %s-%s [%s] %s",
+		t.ScheduledDate.Format("2006-01-02"), t.ScheduledStart, t.ScheduledEnd, t.Category.Code(), t.Description)
+}
+
 // NewTaskDetailModel builds a task detail model from a task.
 func NewTaskDetailModel(t *task.Task) TaskDetailModel {
-	categoryIcon := "D"
-	categoryLabel := "Deep work"
-	if t.IsShallow() {
-		categoryIcon = "S"
-		categoryLabel = "Shallow work"
+	categoryLabel := FormatCategory(t.Category)
+	if t.IsDeep() || t.IsShallow() {
+		categoryLabel += " work"
 	}
 	outcomeStr := "Not set"
 	if t.Outcome != nil {
-		switch *t.Outcome {
-		case task.OutcomeOnTime:
-			outcomeStr = "On time"
-		case task.OutcomeOver:
-			outcomeStr = "Over time"
-		case task.OutcomeUnder:
-			outcomeStr = "Under time"
-		}
+		outcomeStr = outcomeLabel(*t.Outcome)
+	}
+
+	energyStr := "Not set"
+	switch t.Energy {
+	case task.EnergyHigh:
+		energyStr = "High"
+	case task.EnergyMedium:
+		energyStr = "Medium"
+	case task.EnergyLow:
+		energyStr = "Low"
 	}
 
 	return TaskDetailModel{
 		Description:   t.Description,
-		CategoryIcon:  categoryIcon,
+		CategoryIcon:  t.Category.Code(),
 		CategoryLabel: categoryLabel,
 		TimeRange:     fmt.Sprintf("%s - %s (%s)", t.ScheduledStart, t.ScheduledEnd, FormatDuration(t.Duration())),
 		DateLabel:     t.ScheduledDate.Format("Monday, Jan 2, 2006"),
 		OutcomeLabel:  outcomeStr,
+		EnergyLabel:   energyStr,
+	}
+}
+
+// NewTaskLink builds the line for a task linked to the one shown.
+func NewTaskLink(t *task.Task, outOfOrder bool) TaskLink {
+	return TaskLink{
+		Label:      fmt.Sprintf("%s %s-%s %s", t.ScheduledDate.Format("Mon Jan 2"), t.ScheduledStart, t.ScheduledEnd, t.Description),
+		OutOfOrder: outOfOrder,
 	}
 }
 
@@ -98,29 +118,19 @@ func NewPlanResultModel(result *dwplanner.PlanResult) PlanResultModel {
 	for _, ve := range result.ValidationErrors {
 		issues = append(issues, ve.Message)
 	}
-	days := make([]PlanResultDay, 0, len(result.SortedDates))
-	for _, dateStr := range result.SortedDates {
-		tasks := result.TasksByDate[dateStr]
-		if len(tasks) == 0 {
-			continue
-		}
-		label := dateStr + ":"
-		if date, err := time.Parse("2006-01-02", dateStr); err == nil {
-			label = date.Format("Mon Jan 2") + ":"
-		}
-		lines := make([]string, 0, len(tasks))
-		for _, t := range tasks {
-			icon := "D"
-			if t.Category == "shallow" {
-				icon = "S"
-			}
-			lines = append(lines, fmt.Sprintf("  [%s] %s-%s %s", icon, t.ScheduledStart, t.ScheduledEnd, t.Description))
+	dropped := make([]string, 0, len(result.Dropped))
+	for _, d := range result.Dropped {
+		line := d.String()
+		if d.Entry != "" {
+			line += ": " + ansi.Truncate(strings.Join(strings.Fields(d.Entry), " "), 60, "…")
 		}
-		days = append(days, PlanResultDay{
-			DateLabel: label,
-			Lines:     lines,
-		})
+		dropped = append(dropped, line)
+	}
+	constraints := make([]string, 0, len(result.Constraints))
+	for _, c := range result.Constraints {
+		constraints = append(constraints, c.String())
 	}
+	days := planResultDays(result.SortedDates, result.TasksByDate)
 
 	summary := fmt.Sprintf("Total: %d tasks", result.TotalTasks())
 	if len(result.SortedDates) > 1 {
@@ -130,6 +140,8 @@ func NewPlanResultModel(result *dwplanner.PlanResult) PlanResultModel {
 	return PlanResultModel{
 		IntroMessage:   "Review the draft and amend it before applying.",
 		Issues:         issues,
+		Dropped:        dropped,
+		Constraints:    constraints,
 		Warnings:       result.Warnings,
 		Days:           days,
 		NoTasks:        result.TotalTasks() == 0,
@@ -138,3 +150,99 @@ func NewPlanResultModel(result *dwplanner.PlanResult) PlanResultModel {
 		AmendHint:      "Press m to amend the plan text before applying.",
 	}
 }
+
+// NewStreamingPlanResultModel builds a plan result model for a plan that is
+// still being generated. tasks holds the tasks parsed so far.
+func NewStreamingPlanResultModel(tasks []dwplanner.PlannedTask, spinnerFrame string) PlanResultModel {
+	var dates []string
+	byDate := make(map[string][]dwplanner.PlannedTask)
+	for _, t := range tasks {
+		if _, ok := byDate[t.ScheduledDate]; !ok {
+			dates = append(dates, t.ScheduledDate)
+		}
+		byDate[t.ScheduledDate] = append(byDate[t.ScheduledDate], t)
+	}
+	sort.Strings(dates)
+
+	return PlanResultModel{
+		IntroMessage:   strings.TrimSpace(spinnerFrame + " Generating plan..."),
+		Days:           planResultDays(dates, byDate),
+		NoTasks:        len(tasks) == 0,
+		NoTasksMessage: "Waiting for tasks...",
+		Summary:        fmt.Sprintf("Received: %d tasks", len(tasks)),
+		Streaming:      true,
+	}
+}
+
+// BuildPlanDiff converts plan changes into diff rows and a short summary
+// such as "1 added, 2 moved".
+func BuildPlanDiff(changes []dwplanner.PlanChange) ([]PlanDiffRow, string) {
+	if !dwplanner.HasPlanChanges(changes) {
+		return nil, "No changes from the previous draft."
+	}
+
+	counts := make(map[dwplanner.ChangeKind]int)
+	rows := make([]PlanDiffRow, 0, len(changes))
+	for _, c := range changes {
+		counts[c.Kind]++
+		row := PlanDiffRow{Before: planDiffCell(c.Before), After: planDiffCell(c.After)}
+		switch c.Kind {
+		case dwplanner.ChangeAdded:
+			row.Marker = "+"
+		case dwplanner.ChangeRemoved:
+			row.Marker = "-"
+		case dwplanner.ChangeMoved, dwplanner.ChangeUpdated:
+			row.Marker = "~"
+		default:
+			row.Marker = " "
+		}
+		rows = append(rows, row)
+	}
+
+	var parts []string
+	for _, kind := range []dwplanner.ChangeKind{dwplanner.ChangeAdded, dwplanner.ChangeRemoved, dwplanner.ChangeMoved, dwplanner.ChangeUpdated} {
+		if n := counts[kind]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, kind))
+		}
+	}
+	return rows, strings.Join(parts, ", ")
+}
+
+func planDiffCell(t *dwplanner.PlannedTask) string {
+	if t == nil {
+		return ""
+	}
+	day := t.ScheduledDate
+	if date, err := time.Parse("2006-01-02", t.ScheduledDate); err == nil {
+		day = date.Format("Mon")
+	}
+	icon := task.CategoryOrDeep(t.Category).Code()
+	return fmt.Sprintf("%s %s-%s [%s] %s", day, t.ScheduledStart, t.ScheduledEnd, icon, t.Description)
+}
+
+func planResultDays(sortedDates []string, tasksByDate map[string][]dwplanner.PlannedTask) []PlanResultDay {
+	days := make([]PlanResultDay, 0, len(sortedDates))
+	for _, dateStr := range sortedDates {
+		tasks := tasksByDate[dateStr]
+		if len(tasks) == 0 {
+			continue
+		}
+		label := dateStr + ":"
+		if date, err := time.Parse("2006-01-02", dateStr); err == nil {
+			label = date.Format("Mon Jan 2") + ":"
+		}
+		lines := make([]string, 0, len(tasks))
+		for _, t := range tasks {
+			icon := task.CategoryOrDeep(t.Category).Code()
+			lines = append(lines, fmt.Sprintf("  [%s] %s-%s %s", icon, t.ScheduledStart, t.ScheduledEnd, t.Description))
+			for _, issue := range t.Issues {
+				lines = append(lines, "      ! "+issue)
+			}
+		}
+		days = append(days, PlanResultDay{
+			DateLabel: label,
+			Lines:     lines,
+		})
+	}
+	return days
+}
@@ -16,12 +16,23 @@ type TaskDetailModel struct {
 	TimeRange     string
 	DateLabel     string
 	OutcomeLabel  string
+	EnergyLabel   string
+	Warning       string // e.g. energy fit warning; empty hides the line
+	BlockedBy     []TaskLink
+	Blocks        []TaskLink
+}
+
+// TaskLink is a task linked to the one shown, e.g. "Thu 10:00-11:00 Draft".
+type TaskLink struct {
+	Label      string
+	OutOfOrder bool // The blocked task starts before its prerequisite ends
 }
 
 // TaskDetailStyles groups styles for the task detail body.
 type TaskDetailStyles struct {
 	BodyStyle  lipgloss.Style
 	LabelStyle lipgloss.Style
+	HintStyle  lipgloss.Style
 }
 
 // RenderTaskDetailBody renders the modal body for task details.
@@ -32,11 +43,33 @@ func RenderTaskDetailBody(model TaskDetailModel, styles TaskDetailStyles) string
 	body.WriteString(styles.BodyStyle.Render(fmt.Sprintf(" [%s] %s", model.CategoryIcon, model.CategoryLabel)) + "\n")
 	body.WriteString(styles.BodyStyle.Render(" "+model.TimeRange) + "\n")
 	body.WriteString(styles.BodyStyle.Render(" "+model.DateLabel) + "\n\n")
-	body.WriteString(styles.LabelStyle.Render(" Outcome:") + styles.BodyStyle.Render(model.OutcomeLabel))
+	body.WriteString(styles.LabelStyle.Render(" Outcome:") + styles.BodyStyle.Render(model.OutcomeLabel) + "\n")
+	body.WriteString(styles.LabelStyle.Render(" Energy:") + styles.BodyStyle.Render(model.EnergyLabel))
+	writeTaskLinks(&body, " Blocked by:", model.BlockedBy, styles)
+	writeTaskLinks(&body, " Blocks:", model.Blocks, styles)
+	if model.Warning != "" {
+		body.WriteString("\n\n" + styles.HintStyle.Render(" ! "+model.Warning))
+	}
 
 	return body.String()
 }
 
+// writeTaskLinks writes a labelled list of linked tasks, flagging those out
+// of order. An empty list writes nothing.
+func writeTaskLinks(body *strings.Builder, label string, links []TaskLink, styles TaskDetailStyles) {
+	if len(links) == 0 {
+		return
+	}
+	body.WriteString("\n\n" + styles.LabelStyle.Render(label))
+	for _, l := range links {
+		if l.OutOfOrder {
+			body.WriteString("\n" + styles.HintStyle.Render(" ! "+l.Label+" (out of order)"))
+		} else {
+			body.WriteString("\n" + styles.BodyStyle.Render("   "+l.Label))
+		}
+	}
+}
+
 // ConfirmDeleteModel contains the fields needed to render the confirm delete body.
 type ConfirmDeleteModel struct {
 	Description string
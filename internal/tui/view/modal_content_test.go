@@ -4,8 +4,11 @@ package view
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/javiermolinar/sancho/internal/task"
 )
 
 func TestRenderTaskDetailBody_UsesBodyStyleForDescription(t *testing.T) {
@@ -61,3 +64,67 @@ func TestRenderInitBody_UsesBodyStyleForConfigPath(t *testing.T) {
 		t.Fatalf("expected config path to use body style")
 	}
 }
+
+func TestRenderTaskDetailBody_ShowsEnergyAndWarning(t *testing.T) {
+	styles := TaskDetailStyles{}
+	model := TaskDetailModel{
+		Description:  "Write report",
+		OutcomeLabel: "Not set",
+		EnergyLabel:  "High",
+	}
+
+	body := RenderTaskDetailBody(model, styles)
+	if !strings.Contains(body, "Energy:High") {
+		t.Fatalf("expected energy line, got %q", body)
+	}
+	if strings.Contains(body, "!") {
+		t.Fatalf("expected no warning line, got %q", body)
+	}
+
+	model.Warning = "High-energy task in a low-energy window"
+	body = RenderTaskDetailBody(model, styles)
+	if !strings.Contains(body, "! High-energy task in a low-energy window") {
+		t.Fatalf("expected warning line, got %q", body)
+	}
+}
+
+func TestRenderTaskDetailBody_ShowsDependencies(t *testing.T) {
+	model := TaskDetailModel{
+		Description: "Review draft",
+		BlockedBy:   []TaskLink{{Label: "Mon Jan 13 09:00-10:00 Write draft"}},
+		Blocks:      []TaskLink{{Label: "Mon Jan 13 09:30-10:00 Send draft", OutOfOrder: true}},
+	}
+
+	body := RenderTaskDetailBody(model, TaskDetailStyles{})
+	for _, want := range []string{
+		"Blocked by:\n   Mon Jan 13 09:00-10:00 Write draft",
+		"Blocks:\n ! Mon Jan 13 09:30-10:00 Send draft (out of order)",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+
+	body = RenderTaskDetailBody(TaskDetailModel{Description: "Alone"}, TaskDetailStyles{})
+	if strings.Contains(body, "Blocked by") || strings.Contains(body, "Blocks") {
+		t.Errorf("expected no dependency sections, got %q", body)
+	}
+}
+
+func TestBuildTaskCopyText(t *testing.T) {
+	tk := &task.Task{
+		Description:    "Write design doc",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 2, 3, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "11:00",
+	}
+	if got, want := BuildTaskCopyText(tk), "2025-02-03 09:00-11:00 [D] Write design doc"; got != want {
+		t.Fatalf("BuildTaskCopyText() = %q, want %q", got, want)
+	}
+
+	tk.Category = task.CategoryShallow
+	if got, want := BuildTaskCopyText(tk), "2025-02-03 09:00-11:00 [S] Write design doc"; got != want {
+		t.Fatalf("BuildTaskCopyText() = %q, want %q", got, want)
+	}
+}
@@ -9,9 +9,17 @@ func TaskFormFooter(styles ModalStyles) string {
 // TaskDetailFooter renders the footer for the task detail modal.
 func TaskDetailFooter(isPast bool, styles ModalStyles) string {
 	if isPast {
-		return RenderModalButtons(styles, "[o] Outcome", "[Esc] Close")
+		return RenderModalButtons(styles, "[o] Outcome", "[n] Energy", "[Tab] History", "[Esc] Close")
 	}
-	return RenderModalButtonsCompact(styles, "[o] Outcome", "[e] Edit", "[x] Cancel", "[Esc] Close")
+	return RenderModalButtonsCompact(styles, "[o] Outcome", "[n] Energy", "[e] Edit", "[x] Cancel", "[Tab] History", "[Esc] Close")
+}
+
+// TaskHistoryFooter renders the footer for the task detail history tab.
+func TaskHistoryFooter(canRestore bool, styles ModalStyles) string {
+	if !canRestore {
+		return RenderModalButtons(styles, "[Tab] Details", "[Esc] Close")
+	}
+	return RenderModalButtonsCompact(styles, "[Enter] Restore", "[j/k] Pick", "[Tab] Details", "[Esc] Close")
 }
 
 // ConfirmDeleteFooter renders the footer for the confirm delete modal.
@@ -20,22 +28,120 @@ func ConfirmDeleteFooter(styles ModalStyles) string {
 }
 
 // PlanResultFooter renders the footer for the plan result modal.
-func PlanResultFooter(hasValidationErrors bool, styles ModalStyles) string {
+// canFix adds the button that asks the model to resend dropped entries.
+func PlanResultFooter(hasValidationErrors, canFix bool, styles ModalStyles) string {
+	buttons := []string{"[Enter/a] Apply", "[m] Amend", "[Esc/c] Cancel"}
 	if hasValidationErrors {
-		return RenderModalButtons(styles, "[m] Amend", "[Esc/c] Cancel")
+		buttons = buttons[1:]
 	}
-	return RenderModalButtons(styles, "[Enter/a] Apply", "[m] Amend", "[Esc/c] Cancel")
+	if canFix {
+		buttons = append([]string{"[f] Fix output"}, buttons...)
+		return RenderModalButtonsCompact(styles, buttons...)
+	}
+	return RenderModalButtons(styles, buttons...)
+}
+
+// PlanStreamingFooter renders the footer while a plan is being generated.
+func PlanStreamingFooter(styles ModalStyles) string {
+	return RenderModalButtons(styles, "[Esc] Cancel")
 }
 
 // WeekSummaryFooter renders the footer for the week summary modal.
 func WeekSummaryFooter(showTasks bool, styles ModalStyles) string {
 	if showTasks {
-		return RenderModalButtonsCompact(styles, "[s] Summary", "[y] Copy", "[Esc] Close")
+		return RenderModalButtonsCompact(styles, "[s] Summary", "[y] Copy", "[o] Org", "[Esc] Close")
 	}
-	return RenderModalButtonsCompact(styles, "[w] Tasks", "[y] Copy", "[Esc] Close")
+	return RenderModalButtonsCompact(styles, "[w] Tasks", "[y] Copy", "[o] Org", "[Esc] Close")
+}
+
+// ReflectionFooter renders the footer for the reflection modal.
+func ReflectionFooter(styles ModalStyles) string {
+	return RenderModalButtons(styles, "[y] Copy", "[Esc] Close")
+}
+
+// LLMLogFooter renders the footer for the LLM audit log modal.
+func LLMLogFooter(styles ModalStyles) string {
+	return RenderModalButtons(styles, "[Esc] Close")
+}
+
+// BacklogFooter renders the footer for the backlog modal.
+func BacklogFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[Enter] Schedule", "[p] Park", "[x] Remove", "[Esc] Close")
+}
+
+// PostponePickerFooter renders the footer for the postpone picker modal.
+func PostponePickerFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[Enter] Postpone", "[j/k/1-7] Pick", "[Esc] Cancel")
+}
+
+// MissedFooter renders the footer for the missed tasks modal.
+func MissedFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[Enter] Reschedule all", "[Esc] Leave missed")
+}
+
+// SaveConflictFooter renders the footer for the save conflicts modal.
+func SaveConflictFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[Enter] Edit again", "[Esc] Close")
+}
+
+// EditPreviewFooter renders the footer for the edit preview modal.
+func EditPreviewFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[Enter] Save", "[Esc] Keep editing")
+}
+
+// RestoreEditsFooter renders the footer for the autosaved edits modal.
+func RestoreEditsFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[Enter] Restore", "[Esc] Discard")
+}
+
+// ReviewFooter renders the footer for the review modal. Notes are offered
+// only when the repository can store them.
+func ReviewFooter(noting, canNote bool, styles ModalStyles) string {
+	if noting {
+		return RenderModalButtonsCompact(styles, "[Enter] Keep note", "[Esc] Cancel")
+	}
+	labels := []string{"[t] On time", "[o] Over", "[u] Under"}
+	if canNote {
+		labels = append(labels, "[n] Note")
+	}
+	labels = append(labels, "[Space] Skip", "[Enter] Save", "[Esc] Discard")
+	return RenderModalButtonsCompact(styles, labels...)
+}
+
+// YearOverviewFooter renders the footer for the year overview modal.
+func YearOverviewFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[Enter] Open week", "[h/l] Year", "[Esc] Close")
+}
+
+// ObjectivesFooter renders the footer for the objectives modal.
+func ObjectivesFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[h/l] Quarter", "[Esc] Close")
+}
+
+// SyncConflictFooter renders the footer for the sync conflict modal.
+func SyncConflictFooter(styles ModalStyles) string {
+	return RenderModalButtonsCompact(styles, "[Enter] Save merged", "[h/l] Pick side", "[L/R] All local/remote", "[Esc] Skip")
 }
 
 // InitFooter renders the footer for the init modal.
 func InitFooter(styles ModalStyles) string {
 	return RenderModalButtons(styles, "[Enter] Allow", "[Esc] Quit")
 }
+
+// OnboardingFooter renders the footer for a first-run wizard step. first
+// turns Back into Quit, multi adds the toggle hint and last saves.
+func OnboardingFooter(first, multi, last bool, styles ModalStyles) string {
+	buttons := []string{"[Enter] Next"}
+	if last {
+		buttons[0] = "[Enter] Save"
+	}
+	if multi {
+		buttons = append(buttons, "[Space] Toggle")
+	}
+	if first {
+		buttons = append(buttons, "[Esc] Quit")
+	} else {
+		buttons = append(buttons, "[Esc] Back")
+	}
+	return RenderModalButtons(styles, buttons...)
+}
@@ -8,15 +8,20 @@ import (
 func TestPlanResultFooterUsesValidationBranch(t *testing.T) {
 	styles := ModalStyles{}
 
-	withErrors := PlanResultFooter(true, styles)
+	withErrors := PlanResultFooter(true, false, styles)
 	if !strings.Contains(withErrors, "[m] Amend") || strings.Contains(withErrors, "[Enter/a] Apply") {
 		t.Fatalf("expected amend-only footer for validation errors, got %q", withErrors)
 	}
 
-	withoutErrors := PlanResultFooter(false, styles)
-	if !strings.Contains(withoutErrors, "[Enter/a] Apply") {
+	withoutErrors := PlanResultFooter(false, false, styles)
+	if !strings.Contains(withoutErrors, "[Enter/a] Apply") || strings.Contains(withoutErrors, "[f] Fix output") {
 		t.Fatalf("expected apply footer when no errors, got %q", withoutErrors)
 	}
+
+	withDropped := PlanResultFooter(false, true, styles)
+	if !strings.Contains(withDropped, "[f] Fix output") || !strings.Contains(withDropped, "[Enter/a] Apply") {
+		t.Fatalf("expected fix button next to apply, got %q", withDropped)
+	}
 }
 
 func TestWeekSummaryFooterTogglesLabels(t *testing.T) {
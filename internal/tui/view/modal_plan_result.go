@@ -1,7 +1,13 @@
 // Package view provides rendering helpers for the TUI.
 package view
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
 
 // PlanResultDay represents a day's task lines in the plan result modal.
 type PlanResultDay struct {
@@ -13,12 +19,28 @@ type PlanResultDay struct {
 type PlanResultModel struct {
 	IntroMessage   string
 	Issues         []string
+	Dropped        []string // Unusable entries of a malformed response, with the reason
+	Constraints    []string // Fixed appointments and blocked windows
 	Warnings       []string
 	Days           []PlanResultDay
 	NoTasks        bool
 	NoTasksMessage string
 	Summary        string
 	AmendHint      string
+	Streaming      bool // Plan is still being generated
+
+	// Changes is the side-by-side diff against the plan being amended.
+	Changes       []PlanDiffRow
+	ChangeSummary string
+	Width         int // Content width used to lay out the diff columns
+}
+
+// PlanDiffRow is one row of the amend diff. Marker is "+" (added),
+// "-" (removed), "~" (moved or updated) or " " (unchanged).
+type PlanDiffRow struct {
+	Marker string
+	Before string
+	After  string
 }
 
 // PlanResultStyles groups styles for the plan result body.
@@ -46,6 +68,22 @@ func RenderPlanResultBody(model PlanResultModel, styles PlanResultStyles) string
 		body.WriteString("\n")
 	}
 
+	if len(model.Dropped) > 0 {
+		body.WriteString(styles.SectionTitleStyle.Render("DROPPED") + "\n")
+		for _, d := range model.Dropped {
+			body.WriteString(styles.BodyStyle.Render("- "+d) + "\n")
+		}
+		body.WriteString("\n")
+	}
+
+	if len(model.Constraints) > 0 {
+		body.WriteString(styles.SectionTitleStyle.Render("CONSTRAINTS") + "\n")
+		for _, c := range model.Constraints {
+			body.WriteString(styles.BodyStyle.Render("- "+c) + "\n")
+		}
+		body.WriteString("\n")
+	}
+
 	if len(model.Warnings) > 0 {
 		body.WriteString(styles.SectionTitleStyle.Render("WARNINGS") + "\n")
 		for _, warning := range model.Warnings {
@@ -54,6 +92,17 @@ func RenderPlanResultBody(model PlanResultModel, styles PlanResultStyles) string
 		body.WriteString("\n")
 	}
 
+	if len(model.Changes) > 0 {
+		body.WriteString(styles.SectionTitleStyle.Render("CHANGES") + "\n")
+		if model.ChangeSummary != "" {
+			body.WriteString(styles.MetaStyle.Render(model.ChangeSummary) + "\n")
+		}
+		for _, line := range planDiffLines(model.Changes, model.Width) {
+			body.WriteString(styles.BodyStyle.Render(line) + "\n")
+		}
+		body.WriteString("\n")
+	}
+
 	body.WriteString(styles.SectionTitleStyle.Render("DRAFT SCHEDULE") + "\n")
 	if model.NoTasks {
 		body.WriteString(styles.MetaStyle.Render(model.NoTasksMessage) + "\n")
@@ -70,9 +119,41 @@ func RenderPlanResultBody(model PlanResultModel, styles PlanResultStyles) string
 		}
 	}
 
+	if model.Streaming {
+		body.WriteString(styles.MetaStyle.Render(model.Summary) + "\n")
+		return body.String()
+	}
+
 	body.WriteString(styles.MetaStyle.Render(model.Summary) + "\n\n")
 	body.WriteString(styles.SectionTitleStyle.Render("AMEND") + "\n")
 	body.WriteString(styles.MetaStyle.Render(model.AmendHint) + "\n")
 
 	return body.String()
 }
+
+const planDiffFallbackWidth = 60
+
+// planDiffLines lays out diff rows in two columns: before | after.
+func planDiffLines(rows []PlanDiffRow, width int) []string {
+	if width <= 0 {
+		width = planDiffFallbackWidth
+	}
+	// "M " marker prefix plus " | " separator.
+	colW := max((width-5)/2, 10)
+
+	lines := make([]string, 0, len(rows)+1)
+	lines = append(lines, fmt.Sprintf("  %s | %s", padColumn("BEFORE", colW), "AFTER"))
+	for _, row := range rows {
+		lines = append(lines, fmt.Sprintf("%s %s | %s",
+			row.Marker, padColumn(row.Before, colW), ansi.Truncate(row.After, colW, "…")))
+	}
+	return lines
+}
+
+func padColumn(s string, width int) string {
+	s = ansi.Truncate(s, width, "…")
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
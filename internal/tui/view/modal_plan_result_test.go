@@ -88,3 +88,120 @@ func TestNewPlanResultModelBuildsIssuesAndWarnings(t *testing.T) {
 		t.Fatalf("expected planned task lines to be formatted")
 	}
 }
+
+func TestNewPlanResultModelShowsConstraintViolationsInline(t *testing.T) {
+	result := &dwplanner.PlanResult{
+		ValidationErrors: []dwplanner.ValidationError{
+			{TaskIndex: 0, Field: "constraint", Message: "overlaps blocked window 'lunch'"},
+		},
+		Constraints: []dwplanner.Constraint{
+			{Kind: dwplanner.ConstraintBlocked, Label: "lunch", Start: "12:00", End: "13:00"},
+		},
+		TasksByDate: map[string][]dwplanner.PlannedTask{
+			"2026-01-12": {
+				{
+					Description:    "Email",
+					Category:       "shallow",
+					ScheduledStart: "12:00",
+					ScheduledEnd:   "12:30",
+					Issues:         []string{"overlaps blocked window 'lunch'"},
+				},
+			},
+		},
+		SortedDates: []string{"2026-01-12"},
+	}
+
+	model := NewPlanResultModel(result)
+	if len(model.Constraints) != 1 || model.Constraints[0] != "lunch 12:00-13:00 (blocked, daily)" {
+		t.Fatalf("expected constraints to be mapped, got %v", model.Constraints)
+	}
+	lines := model.Days[0].Lines
+	if len(lines) != 2 || !strings.Contains(lines[1], "! overlaps blocked window 'lunch'") {
+		t.Fatalf("expected inline violation after task line, got %v", lines)
+	}
+
+	body := RenderPlanResultBody(model, PlanResultStyles{
+		MetaStyle:         lipgloss.NewStyle(),
+		SectionTitleStyle: lipgloss.NewStyle(),
+		BodyStyle:         lipgloss.NewStyle(),
+	})
+	if !strings.Contains(body, "CONSTRAINTS") {
+		t.Errorf("expected constraints section, got %q", body)
+	}
+}
+
+func TestBuildPlanDiffRendersSideBySide(t *testing.T) {
+	before := &dwplanner.PlannedTask{Description: "Review", Category: "deep", ScheduledDate: "2026-01-12", ScheduledStart: "09:00", ScheduledEnd: "10:00"}
+	after := &dwplanner.PlannedTask{Description: "Review", Category: "deep", ScheduledDate: "2026-01-13", ScheduledStart: "09:00", ScheduledEnd: "10:00"}
+	added := &dwplanner.PlannedTask{Description: "Email", Category: "shallow", ScheduledDate: "2026-01-13", ScheduledStart: "14:00", ScheduledEnd: "14:30"}
+
+	rows, summary := BuildPlanDiff([]dwplanner.PlanChange{
+		{Kind: dwplanner.ChangeMoved, Before: before, After: after},
+		{Kind: dwplanner.ChangeAdded, After: added},
+	})
+	if summary != "1 added, 1 moved" {
+		t.Fatalf("summary = %q", summary)
+	}
+	if len(rows) != 2 || rows[0].Marker != "~" || rows[1].Marker != "+" {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+	if rows[0].Before != "Mon 09:00-10:00 [D] Review" || rows[0].After != "Tue 09:00-10:00 [D] Review" {
+		t.Fatalf("unexpected moved row %+v", rows[0])
+	}
+	if rows[1].Before != "" {
+		t.Fatalf("added row should have an empty before column, got %q", rows[1].Before)
+	}
+
+	body := RenderPlanResultBody(PlanResultModel{Changes: rows, ChangeSummary: summary, Width: 60}, PlanResultStyles{
+		MetaStyle:         lipgloss.NewStyle(),
+		SectionTitleStyle: lipgloss.NewStyle(),
+		BodyStyle:         lipgloss.NewStyle(),
+	})
+	if !strings.Contains(body, "CHANGES") || !strings.Contains(body, "1 added, 1 moved") {
+		t.Fatalf("expected changes section, got %q", body)
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "~ ") && !strings.Contains(line, "Mon 09:00-10:00 [D] Review") {
+			t.Fatalf("moved row missing before column: %q", line)
+		}
+		if strings.HasPrefix(line, "~ ") && lipgloss.Width(line) > 60 {
+			t.Fatalf("diff row wider than content width: %q", line)
+		}
+	}
+}
+
+func TestBuildPlanDiffNoChanges(t *testing.T) {
+	task := &dwplanner.PlannedTask{Description: "Review", ScheduledDate: "2026-01-12", ScheduledStart: "09:00", ScheduledEnd: "10:00"}
+	rows, summary := BuildPlanDiff([]dwplanner.PlanChange{{Kind: dwplanner.ChangeUnchanged, Before: task, After: task}})
+	if rows != nil || summary != "No changes from the previous draft." {
+		t.Fatalf("rows = %v summary = %q", rows, summary)
+	}
+}
+
+func TestNewStreamingPlanResultModelGroupsPartialTasks(t *testing.T) {
+	tasks := []dwplanner.PlannedTask{
+		{Description: "Later", Category: "shallow", ScheduledDate: "2026-01-13", ScheduledStart: "14:00", ScheduledEnd: "14:30"},
+		{Description: "Focus", Category: "deep", ScheduledDate: "2026-01-12", ScheduledStart: "09:00", ScheduledEnd: "10:00"},
+	}
+
+	model := NewStreamingPlanResultModel(tasks, "*")
+	if !model.Streaming {
+		t.Fatal("expected streaming model")
+	}
+	if len(model.Days) != 2 || !strings.Contains(model.Days[0].Lines[0], "Focus") {
+		t.Fatalf("expected days sorted by date, got %+v", model.Days)
+	}
+
+	styles := PlanResultStyles{
+		MetaStyle:         lipgloss.NewStyle(),
+		SectionTitleStyle: lipgloss.NewStyle(),
+		BodyStyle:         lipgloss.NewStyle(),
+	}
+	body := RenderPlanResultBody(model, styles)
+	if strings.Contains(body, "AMEND") {
+		t.Fatal("expected amend section to be hidden while streaming")
+	}
+	if !strings.Contains(body, "Generating plan") {
+		t.Fatal("expected generating message")
+	}
+}
@@ -32,6 +32,7 @@ func (s ModalStyleSet) TaskDetailStyles() TaskDetailStyles {
 	return TaskDetailStyles{
 		BodyStyle:  s.BodyStyle,
 		LabelStyle: s.LabelStyle,
+		HintStyle:  s.HintStyle,
 	}
 }
 
@@ -68,3 +69,14 @@ func (s ModalStyleSet) WeekSummaryStyles() WeekSummaryStyles {
 		SectionTitleStyle: s.SectionTitleStyle,
 	}
 }
+
+// SyncConflictStyles returns the modal styles needed for sync conflicts.
+func (s ModalStyleSet) SyncConflictStyles() SyncConflictStyles {
+	return SyncConflictStyles{
+		BodyStyle:         s.BodyStyle,
+		MetaStyle:         s.MetaStyle,
+		SectionTitleStyle: s.SectionTitleStyle,
+		LabelStyle:        s.LabelStyle,
+		HintStyle:         s.HintStyle,
+	}
+}
@@ -0,0 +1,122 @@
+// Package view provides rendering helpers for the TUI.
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
+)
+
+// SyncConflictRow is one field of a conflicting task across the three panes.
+type SyncConflictRow struct {
+	Label       string
+	Local       string
+	Remote      string
+	Merged      string
+	FromRemote  bool // Merged value is taken from the remote copy
+	Conflicting bool // Changed on both sides to different values
+	Selected    bool
+}
+
+// SyncConflictModel contains the fields needed to render the conflict body.
+type SyncConflictModel struct {
+	Source    string
+	Rows      []SyncConflictRow
+	Remaining int // Conflicts queued after this one
+	Width     int
+}
+
+// SyncConflictStyles groups styles for the conflict body.
+type SyncConflictStyles struct {
+	BodyStyle         lipgloss.Style
+	MetaStyle         lipgloss.Style
+	SectionTitleStyle lipgloss.Style
+	LabelStyle        lipgloss.Style
+	HintStyle         lipgloss.Style
+}
+
+// NewSyncConflictModel builds the three-pane rows for a conflict.
+func NewSyncConflictModel(c *tasksync.Conflict, selected, remaining, width int) SyncConflictModel {
+	merged := c.Merged()
+	rows := make([]SyncConflictRow, 0, len(tasksync.Fields))
+	for i, f := range tasksync.Fields {
+		rows = append(rows, SyncConflictRow{
+			Label:       f.String(),
+			Local:       syncFieldValue(c.Local, f),
+			Remote:      syncFieldValue(c.Remote, f),
+			Merged:      syncFieldValue(merged, f),
+			FromRemote:  c.Choice[f] == tasksync.SideRemote,
+			Conflicting: c.Conflicting(f),
+			Selected:    i == selected,
+		})
+	}
+	return SyncConflictModel{Source: c.Source, Rows: rows, Remaining: remaining, Width: width}
+}
+
+func syncFieldValue(t task.Task, f tasksync.Field) string {
+	switch f {
+	case tasksync.FieldDescription:
+		return t.Description
+	case tasksync.FieldCategory:
+		return string(t.Category)
+	case tasksync.FieldSchedule:
+		return fmt.Sprintf("%s %s-%s", t.ScheduledDate.Format("Mon Jan 2"), t.ScheduledStart, t.ScheduledEnd)
+	case tasksync.FieldEnergy:
+		if t.Energy == "" {
+			return "-"
+		}
+		return string(t.Energy)
+	default:
+		return ""
+	}
+}
+
+// RenderSyncConflictBody renders the local | remote | merged panes.
+func RenderSyncConflictBody(model SyncConflictModel, styles SyncConflictStyles) string {
+	width := model.Width
+	if width <= 0 {
+		width = planDiffFallbackWidth
+	}
+	// Marker column plus two " | " separators.
+	colW := max((width-8)/3, 8)
+
+	var body strings.Builder
+	source := model.Source
+	if source == "" {
+		source = "remote"
+	}
+	body.WriteString(styles.MetaStyle.Render(fmt.Sprintf(" Changed here and in %s since the last sync", source)) + "\n\n")
+	body.WriteString(styles.SectionTitleStyle.Render(fmt.Sprintf("  %s | %s | %s",
+		padColumn("LOCAL", colW), padColumn(strings.ToUpper(source), colW), "MERGED")) + "\n")
+
+	for _, row := range model.Rows {
+		marker := " "
+		if row.Selected {
+			marker = ">"
+		}
+		flag := " "
+		if row.Conflicting {
+			flag = "!"
+		}
+		local, remote := padColumn(row.Local, colW), padColumn(row.Remote, colW)
+		if row.FromRemote {
+			remote = styles.LabelStyle.Render(remote)
+		} else {
+			local = styles.LabelStyle.Render(local)
+		}
+		line := fmt.Sprintf("%s%s", marker, flag) + local + " | " + remote + " | " +
+			styles.BodyStyle.Render(padColumn(row.Merged, colW))
+		body.WriteString(line + "\n")
+	}
+
+	hint := " ! changed on both sides"
+	if model.Remaining > 0 {
+		hint += fmt.Sprintf(" · %d more after this", model.Remaining)
+	}
+	body.WriteString("\n" + styles.HintStyle.Render(hint))
+	return body.String()
+}
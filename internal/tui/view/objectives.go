@@ -0,0 +1,37 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// objectiveBarWidth is the width of objective progress bars.
+const objectiveBarWidth = 20
+
+// RenderObjectivesBody renders one line per objective of a quarter: a bar of
+// its completed and still planned time against the target, then the hours.
+// Objectives without a target show the hours only. Styles are those of the
+// missed tasks modal.
+func RenderObjectivesBody(progress []task.ObjectiveProgress, styles MissedStyles) string {
+	if len(progress) == 0 {
+		return styles.MetaStyle.Render(`No objectives this quarter. Add one with: sancho objective add "Title" --target 40h`)
+	}
+	lines := make([]string, 0, len(progress)+2)
+	for _, p := range progress {
+		hours := fmt.Sprintf("%s done, %s scheduled", FormatDuration(p.CompletedMinutes), FormatDuration(p.ScheduledMinutes))
+		bar := strings.Repeat(" ", objectiveBarWidth)
+		if target := p.Objective.TargetMinutes; target > 0 {
+			bar = GoalBar(task.GoalProgress{
+				Target:  target,
+				Done:    p.CompletedMinutes,
+				Planned: p.ScheduledMinutes - p.CompletedMinutes,
+			}, objectiveBarWidth)
+			hours += fmt.Sprintf(" of %s", FormatDuration(target))
+		}
+		lines = append(lines, styles.BodyStyle.Render(fmt.Sprintf("%s %s %s", bar, padColumn(p.Objective.Title, 24), hours)))
+	}
+	lines = append(lines, "", styles.MetaStyle.Render("█ done  ▓ planned"))
+	return strings.Join(lines, "\n")
+}
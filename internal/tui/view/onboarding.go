@@ -0,0 +1,70 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OnboardingOption is one choice in a list step of the first-run wizard.
+type OnboardingOption struct {
+	Label   string
+	Checked bool // Shown as a checkbox when the step allows several choices
+}
+
+// OnboardingModel contains fields for one step of the first-run wizard.
+type OnboardingModel struct {
+	Step         int // 1-based
+	Steps        int
+	Title        string
+	Prompt       string
+	Input        string // Rendered text input; empty for list steps
+	Options      []OnboardingOption
+	Multi        bool // Options are checkboxes rather than a single choice
+	Cursor       int
+	Summary      []string // Lines shown on the review step
+	ErrorMessage string
+}
+
+// OnboardingStyles groups styles for the first-run wizard body.
+type OnboardingStyles struct {
+	BodyStyle   stringRenderer
+	MetaStyle   stringRenderer
+	LabelStyle  stringRenderer
+	CursorStyle stringRenderer
+}
+
+// RenderOnboardingBody renders the current wizard step: a progress line, the
+// question, then either the text input, the list of options or the summary.
+func RenderOnboardingBody(model OnboardingModel, styles OnboardingStyles) string {
+	lines := []string{
+		styles.MetaStyle.Render(fmt.Sprintf("Step %d of %d: %s", model.Step, model.Steps, model.Title)),
+		"",
+		styles.BodyStyle.Render(model.Prompt),
+		"",
+	}
+	if model.Input != "" {
+		lines = append(lines, model.Input)
+	}
+	for i, o := range model.Options {
+		label := o.Label
+		if model.Multi {
+			box := "[ ]"
+			if o.Checked {
+				box = "[x]"
+			}
+			label = box + " " + label
+		}
+		if i == model.Cursor {
+			lines = append(lines, styles.CursorStyle.Render("> "+label))
+		} else {
+			lines = append(lines, styles.BodyStyle.Render("  "+label))
+		}
+	}
+	for _, s := range model.Summary {
+		lines = append(lines, styles.BodyStyle.Render(s))
+	}
+	if model.ErrorMessage != "" {
+		lines = append(lines, "", styles.LabelStyle.Render("Error:")+" "+styles.BodyStyle.Render(model.ErrorMessage))
+	}
+	return strings.Join(lines, "\n")
+}
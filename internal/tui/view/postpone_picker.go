@@ -0,0 +1,45 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PostponeOption is one candidate day in the postpone picker.
+type PostponeOption struct {
+	Date        time.Time
+	FreeMinutes int    // Working minutes not taken by other tasks
+	Start       string // Suggested slot; empty when the task does not fit
+	End         string
+}
+
+// PostponePickerStyles groups styles for the postpone picker modal.
+type PostponePickerStyles struct {
+	BodyStyle   stringRenderer
+	MetaStyle   stringRenderer
+	CursorStyle stringRenderer
+}
+
+// RenderPostponePickerBody renders the task being postponed followed by one
+// line per candidate day with its free time and suggested slot.
+func RenderPostponePickerBody(description string, options []PostponeOption, cursor int, styles PostponePickerStyles) string {
+	lines := make([]string, 0, len(options)+2)
+	lines = append(lines, styles.BodyStyle.Render(description), "")
+	for i, o := range options {
+		slot := "no free slot"
+		if o.Start != "" {
+			slot = o.Start + "-" + o.End
+		}
+		line := fmt.Sprintf("%d  %s  %7s free  %s", i+1, o.Date.Format("Mon Jan 02"), FormatDuration(o.FreeMinutes), slot)
+		style := styles.BodyStyle
+		switch {
+		case i == cursor:
+			style = styles.CursorStyle
+		case o.Start == "":
+			style = styles.MetaStyle
+		}
+		lines = append(lines, style.Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
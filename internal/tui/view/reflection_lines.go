@@ -0,0 +1,44 @@
+// Package view provides rendering helpers for the TUI.
+package view
+
+import (
+	"fmt"
+
+	"github.com/javiermolinar/sancho/internal/summary"
+)
+
+// BuildReflectionLines builds lines for the reflection modal.
+func BuildReflectionLines(r *summary.Reflection) []WeekSummaryLine {
+	lines := make([]WeekSummaryLine, 0, 16)
+	dateLine := fmt.Sprintf("%s - %s", r.Start.Format("Mon Jan 2"), r.End.Format("Mon Jan 2, 2006"))
+	lines = append(lines, WeekSummaryLine{Text: dateLine, Style: WeekSummaryLineMeta})
+
+	c := r.Counts
+	countLine := fmt.Sprintf("Blocks: %d | Over: %d | Under: %d | Postponed: %d | Cancelled: %d | Missed: %d",
+		c.Scheduled, c.Over, c.Under, c.Postponed, c.Cancelled, c.Missed)
+	lines = append(lines, WeekSummaryLine{Text: countLine, Style: WeekSummaryLineMeta})
+
+	lines = append(lines, WeekSummaryLine{Text: ""})
+	lines = append(lines, WeekSummaryLine{Text: "OBSERVATIONS", Style: WeekSummaryLineSection})
+	if len(r.Observations) == 0 {
+		lines = append(lines, WeekSummaryLine{Text: "No patterns found.", Style: WeekSummaryLineMeta})
+	}
+	for _, o := range r.Observations {
+		lines = append(lines, WeekSummaryLine{Text: "• " + o})
+	}
+
+	if len(r.Adjustments) > 0 {
+		lines = append(lines, WeekSummaryLine{Text: ""})
+		lines = append(lines, WeekSummaryLine{Text: "SUGGESTED ADJUSTMENTS", Style: WeekSummaryLineSection})
+		for _, a := range r.Adjustments {
+			lines = append(lines, WeekSummaryLine{Text: "➜ " + a})
+		}
+	}
+
+	return lines
+}
+
+// BuildReflectionCopyText renders reflection lines to plain text for copying.
+func BuildReflectionCopyText(lines []WeekSummaryLine) string {
+	return linesToText(lines)
+}
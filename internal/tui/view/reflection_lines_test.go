@@ -0,0 +1,48 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/summary"
+)
+
+func TestBuildReflectionLines(t *testing.T) {
+	r := &summary.Reflection{
+		Start:        time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local),
+		End:          time.Date(2025, 1, 12, 0, 0, 0, 0, time.Local),
+		Counts:       summary.ReflectionCounts{Scheduled: 8, Over: 3, Postponed: 2},
+		Observations: []string{"Deep blocks after 15:00 usually run over"},
+		Adjustments:  []string{"End deep work by 15:00"},
+	}
+
+	text := linesToText(BuildReflectionLines(r))
+	for _, want := range []string{
+		"Mon Jan 6 - Sun Jan 12, 2025",
+		"Blocks: 8 | Over: 3 | Under: 0 | Postponed: 2 | Cancelled: 0",
+		"OBSERVATIONS",
+		"• Deep blocks after 15:00 usually run over",
+		"SUGGESTED ADJUSTMENTS",
+		"➜ End deep work by 15:00",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestBuildReflectionLinesWithoutAdjustments(t *testing.T) {
+	r := &summary.Reflection{
+		Start: time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local),
+		End:   time.Date(2025, 1, 12, 0, 0, 0, 0, time.Local),
+	}
+
+	text := linesToText(BuildReflectionLines(r))
+	if !strings.Contains(text, "No patterns found.") {
+		t.Errorf("expected empty observations note, got:\n%s", text)
+	}
+	if strings.Contains(text, "SUGGESTED ADJUSTMENTS") {
+		t.Errorf("unexpected adjustments section:\n%s", text)
+	}
+}
@@ -0,0 +1,81 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// reviewVisibleRows is how many tasks the review modal lists at once.
+const reviewVisibleRows = 12
+
+// ReviewRow is one past task in the review modal.
+type ReviewRow struct {
+	Date        time.Time
+	Start       string
+	End         string
+	Description string
+	Outcome     task.Outcome // Empty until picked
+	Note        string
+}
+
+// ReviewStyles groups styles for the review modal.
+type ReviewStyles struct {
+	BodyStyle   stringRenderer
+	MetaStyle   stringRenderer
+	CursorStyle stringRenderer
+}
+
+// RenderReviewBody lists the tasks under review with the outcome picked for
+// each. The note of the task under the cursor is shown below it, or
+// noteInput while the note is being typed.
+func RenderReviewBody(rows []ReviewRow, cursor int, noteInput string, styles ReviewStyles) string {
+	if len(rows) == 0 {
+		return styles.MetaStyle.Render("No past tasks to review.")
+	}
+	rated := 0
+	for _, r := range rows {
+		if r.Outcome != "" {
+			rated++
+		}
+	}
+	lines := []string{styles.MetaStyle.Render(fmt.Sprintf("Task %d of %d · %d rated", cursor+1, len(rows), rated)), ""}
+
+	first := max(0, min(cursor-reviewVisibleRows/2, len(rows)-reviewVisibleRows))
+	last := min(len(rows), first+reviewVisibleRows)
+	for i := first; i < last; i++ {
+		r := rows[i]
+		outcome := "·"
+		if r.Outcome != "" {
+			outcome = outcomeLabel(r.Outcome)
+		}
+		line := fmt.Sprintf("%s %s-%s  %-10s  %s", r.Date.Format("Mon Jan 02"), r.Start, r.End, outcome, r.Description)
+		if i != cursor {
+			lines = append(lines, styles.BodyStyle.Render("  "+line))
+			continue
+		}
+		lines = append(lines, styles.CursorStyle.Render("> "+line))
+		switch {
+		case noteInput != "":
+			lines = append(lines, "    Note: "+noteInput)
+		case r.Note != "":
+			lines = append(lines, styles.MetaStyle.Render("    Note: "+snippet(r.Note)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// outcomeLabel describes an outcome for display.
+func outcomeLabel(o task.Outcome) string {
+	switch o {
+	case task.OutcomeOnTime:
+		return "On time"
+	case task.OutcomeOver:
+		return "Over time"
+	case task.OutcomeUnder:
+		return "Under time"
+	}
+	return string(o)
+}
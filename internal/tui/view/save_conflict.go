@@ -0,0 +1,38 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SaveConflictRow is one edit that could not be saved, at the times it was
+// given.
+type SaveConflictRow struct {
+	Description string
+	Date        time.Time
+	Start       string
+	End         string
+	Changed     bool // Another process wrote the task; otherwise the edit overlaps
+}
+
+// RenderSaveConflictBody renders one line per edit a save skipped because
+// it conflicts with changes made elsewhere. Styles are those of the missed tasks
+// modal.
+func RenderSaveConflictBody(rows []SaveConflictRow, styles MissedStyles) string {
+	lines := make([]string, 0, len(rows)+4)
+	header := fmt.Sprintf("%d changes conflict with changes made elsewhere and were not saved.", len(rows))
+	if len(rows) == 1 {
+		header = "1 change conflicts with changes made elsewhere and was not saved."
+	}
+	lines = append(lines, styles.MetaStyle.Render(header), "")
+	for _, r := range rows {
+		line := fmt.Sprintf("%s %s-%s  %s", r.Date.Format("Mon Jan 02"), r.Start, r.End, r.Description)
+		if r.Changed {
+			line += "  (changed elsewhere)"
+		}
+		lines = append(lines, styles.BodyStyle.Render(line))
+	}
+	lines = append(lines, "", styles.MetaStyle.Render("Your other changes were saved and the week reloaded."))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,42 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// TaskHistoryStyles groups styles for the task history tab.
+type TaskHistoryStyles struct {
+	BodyStyle   stringRenderer
+	MetaStyle   stringRenderer
+	CursorStyle stringRenderer
+}
+
+// RenderTaskHistoryBody renders the task's current description followed by
+// one line per earlier version, newest first, with the one under the cursor
+// highlighted.
+func RenderTaskHistoryBody(t *task.Task, revisions []task.Revision, cursor int, styles TaskHistoryStyles) string {
+	lines := make([]string, 0, len(revisions)+3)
+	lines = append(lines,
+		styles.BodyStyle.Render(t.Description),
+		styles.MetaStyle.Render(fmt.Sprintf("Now: %s %s-%s", t.ScheduledDate.Format("Mon Jan 02"), t.ScheduledStart, t.ScheduledEnd)),
+		"")
+	if len(revisions) == 0 {
+		lines = append(lines, styles.MetaStyle.Render("No earlier versions"))
+		return strings.Join(lines, "\n")
+	}
+	for i, rev := range revisions {
+		line := fmt.Sprintf("%s  %s %s-%s  %s",
+			rev.ChangedAt.Local().Format("Jan 02 15:04"),
+			rev.ScheduledDate.Format("Mon Jan 02"), rev.ScheduledStart, rev.ScheduledEnd,
+			rev.Description)
+		style := styles.BodyStyle
+		if i == cursor {
+			style = styles.CursorStyle
+		}
+		lines = append(lines, style.Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
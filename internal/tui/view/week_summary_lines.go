@@ -9,6 +9,9 @@ import (
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
+// goalBarWidth is the width of goal progress bars in the week summary.
+const goalBarWidth = 20
+
 // BuildWeekSummaryLines builds summary lines for the week summary modal.
 func BuildWeekSummaryLines(summary *summary.WeekSummary, showPeak bool) []WeekSummaryLine {
 	lines := make([]WeekSummaryLine, 0, 16)
@@ -48,11 +51,42 @@ func BuildWeekSummaryLines(summary *summary.WeekSummary, showPeak bool) []WeekSu
 		lines = append(lines, WeekSummaryLine{Text: peakLine})
 	}
 
-	if stats.CancelledBlocks > 0 || stats.PostponedBlocks > 0 {
-		line := fmt.Sprintf("Cancelled: %d | Postponed: %d", stats.CancelledBlocks, stats.PostponedBlocks)
+	if delta, ok := summary.Delta(); ok {
+		lines = append(lines, WeekSummaryLine{Text: "vs last week: " + delta.String(), Style: WeekSummaryLineMeta})
+	}
+
+	if stats.CancelledBlocks > 0 || stats.PostponedBlocks > 0 || stats.MissedBlocks > 0 {
+		line := fmt.Sprintf("Cancelled: %d | Postponed: %d | Missed: %d", stats.CancelledBlocks, stats.PostponedBlocks, stats.MissedBlocks)
 		lines = append(lines, WeekSummaryLine{Text: line, Style: WeekSummaryLineMeta})
 	}
 
+	if len(summary.Goals) > 0 {
+		lines = append(lines, WeekSummaryLine{Text: ""})
+		lines = append(lines, WeekSummaryLine{Text: "GOALS", Style: WeekSummaryLineSection})
+		for _, g := range summary.Goals {
+			line := fmt.Sprintf("%s %s", GoalBar(g, goalBarWidth), FormatGoal(g))
+			lines = append(lines, WeekSummaryLine{Text: line, Style: WeekSummaryLineBody})
+		}
+		lines = append(lines, WeekSummaryLine{Text: "█ done  ▓ planned", Style: WeekSummaryLineMeta})
+	}
+
+	if len(summary.Budgets) > 0 {
+		lines = append(lines, WeekSummaryLine{Text: ""})
+		lines = append(lines, WeekSummaryLine{Text: "BUDGETS", Style: WeekSummaryLineSection})
+		for _, g := range summary.Budgets {
+			line := fmt.Sprintf("%s %s", GoalBar(g, goalBarWidth), FormatBudget(g))
+			lines = append(lines, WeekSummaryLine{Text: line, Style: WeekSummaryLineBody})
+		}
+	}
+
+	if len(summary.Tickets) > 0 {
+		lines = append(lines, WeekSummaryLine{Text: ""})
+		lines = append(lines, WeekSummaryLine{Text: "TICKETS", Style: WeekSummaryLineSection})
+		for _, tt := range summary.Tickets {
+			lines = append(lines, WeekSummaryLine{Text: fmt.Sprintf("%-12s %s", tt.Key, FormatDuration(tt.Minutes)), Style: WeekSummaryLineBody})
+		}
+	}
+
 	if summary.Insight != "" {
 		lines = append(lines, WeekSummaryLine{Text: ""})
 		lines = append(lines, WeekSummaryLine{Text: "INSIGHT", Style: WeekSummaryLineSection})
@@ -89,10 +123,7 @@ func BuildWeekTasksLines(summary *summary.WeekSummary) []WeekSummaryLine {
 		}
 
 		status := weekSummaryStatusSymbol(t.Status)
-		category := "[S]"
-		if t.Category == task.CategoryDeep {
-			category = "[D]"
-		}
+		category := "[" + t.Category.Code() + "]"
 		line := fmt.Sprintf("  %s %s %s-%s %s", status, category, t.ScheduledStart, t.ScheduledEnd, t.Description)
 		lines = append(lines, WeekSummaryLine{Text: line})
 	}
@@ -121,6 +152,8 @@ func weekSummaryStatusSymbol(s task.Status) string {
 		return "✗"
 	case task.StatusPostponed:
 		return "→"
+	case task.StatusMissed:
+		return "!"
 	default:
 		return "?"
 	}
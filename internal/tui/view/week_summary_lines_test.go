@@ -59,3 +59,101 @@ func TestBuildWeekSummaryLinesIncludesInsight(t *testing.T) {
 		t.Fatalf("expected insight content in summary text, got %q", text)
 	}
 }
+
+func TestBuildWeekSummaryLinesIncludesGoals(t *testing.T) {
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{
+			Description:    "Done",
+			Category:       task.CategoryDeep,
+			ScheduledDate:  monday,
+			ScheduledStart: "09:00",
+			ScheduledEnd:   "12:00",
+			Status:         task.StatusScheduled,
+		},
+		{
+			Description:    "Planned",
+			Category:       task.CategoryDeep,
+			ScheduledDate:  monday.AddDate(0, 0, 2),
+			ScheduledStart: "09:00",
+			ScheduledEnd:   "12:00",
+			Status:         task.StatusScheduled,
+		},
+	}
+	summaryData := summary.SummarizeWeek(monday, tasks, summary.WeekSummaryOptions{
+		Goals: summary.GoalTargets{DeepMinutes: 600},
+		Now:   monday.Add(13 * time.Hour),
+	})
+
+	lines := BuildWeekSummaryLines(summaryData, false)
+	text := linesToText(lines)
+
+	if !strings.Contains(text, "GOALS") {
+		t.Fatalf("expected goals header in summary text, got %q", text)
+	}
+	want := "██████▓▓▓▓▓▓░░░░░░░░ Deep 6h/10h 60%"
+	if !strings.Contains(text, want) {
+		t.Fatalf("expected goal line %q in summary text, got %q", want, text)
+	}
+}
+
+func TestBuildWeekSummaryLinesIncludesBudgets(t *testing.T) {
+	task.SetCategories([]task.CategoryInfo{{Name: "meeting", Code: "M", WeeklyLimit: 120}})
+	defer task.SetCategories(nil)
+
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{Description: "Standup", Category: "meeting", ScheduledDate: monday, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled},
+		{Description: "Review", Category: "meeting", ScheduledDate: monday.AddDate(0, 0, 2), ScheduledStart: "14:00", ScheduledEnd: "15:00", Status: task.StatusScheduled},
+	}
+	summaryData := summary.SummarizeWeek(monday, tasks, summary.WeekSummaryOptions{Now: monday.Add(13 * time.Hour)})
+
+	text := linesToText(BuildWeekSummaryLines(summaryData, false))
+	if !strings.Contains(text, "BUDGETS") {
+		t.Fatalf("expected budgets header in summary text, got %q", text)
+	}
+	want := "████████████████████ meeting 3h/2h over"
+	if !strings.Contains(text, want) {
+		t.Fatalf("expected budget line %q in summary text, got %q", want, text)
+	}
+}
+
+func TestGoalBar(t *testing.T) {
+	tests := []struct {
+		name string
+		goal task.GoalProgress
+		want string
+	}{
+		{"empty", task.GoalProgress{Target: 600}, "░░░░░░░░░░"},
+		{"done and planned", task.GoalProgress{Target: 600, Done: 180, Planned: 120}, "███▓▓░░░░░"},
+		{"over target", task.GoalProgress{Target: 600, Done: 480, Planned: 480}, "████████▓▓"},
+		{"no target", task.GoalProgress{Done: 60}, "░░░░░░░░░░"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GoalBar(tt.goal, 10); got != tt.want {
+				t.Errorf("GoalBar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildWeekSummaryLinesIncludesTickets(t *testing.T) {
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	tasks := []*task.Task{
+		{
+			Description:    "API-42 Fix login",
+			Category:       task.CategoryDeep,
+			ScheduledDate:  monday,
+			ScheduledStart: "09:00",
+			ScheduledEnd:   "11:30",
+			Status:         task.StatusScheduled,
+		},
+	}
+	summaryData := summary.SummarizeWeek(monday, tasks, summary.WeekSummaryOptions{})
+
+	text := linesToText(BuildWeekSummaryLines(summaryData, false))
+	if !strings.Contains(text, "TICKETS") || !strings.Contains(text, "API-42       2h 30m") {
+		t.Fatalf("expected ticket time in summary text, got %q", text)
+	}
+}
@@ -0,0 +1,120 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// yearBarWidth is the width of the hours bar in the year overview.
+const yearBarWidth = 20
+
+// YearWeekRow is one week of the year overview.
+type YearWeekRow struct {
+	WeekStart  time.Time
+	Minutes    int  // Scheduled minutes
+	Blocks     int  // Blocks that were neither cancelled nor postponed
+	Completion int  // Percent of those blocks with an outcome
+	Past       bool // The week ended before today
+}
+
+// YearOverviewStyles groups styles for the year overview modal.
+type YearOverviewStyles struct {
+	BodyStyle   stringRenderer
+	MetaStyle   stringRenderer
+	CursorStyle stringRenderer
+	HighStyle   stringRenderer // Past weeks with most blocks reviewed
+	MidStyle    stringRenderer
+	LowStyle    stringRenderer
+}
+
+// BuildYearOverviewRows returns one row per week starting at firstWeek,
+// filling in weeks without tasks.
+func BuildYearOverviewRows(firstWeek time.Time, numWeeks int, weeks []task.WeeklyAggregate, today time.Time) []YearWeekRow {
+	byDate := make(map[string]task.WeeklyAggregate, len(weeks))
+	for _, w := range weeks {
+		byDate[w.WeekStart.Format("2006-01-02")] = w
+	}
+
+	rows := make([]YearWeekRow, 0, numWeeks)
+	for i := range numWeeks {
+		start := firstWeek.AddDate(0, 0, 7*i)
+		row := YearWeekRow{
+			WeekStart: start,
+			Past:      task.CalendarDaysBetween(start, today) >= 7,
+		}
+		if w, ok := byDate[start.Format("2006-01-02")]; ok {
+			row.Minutes = w.TotalMinutes()
+			row.Blocks = w.Scheduled()
+			row.Completion = w.CompletionPercent()
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// RenderYearOverviewBody renders up to height rows around the cursor, one
+// line per week: number, first day, hours with a bar, and completion.
+func RenderYearOverviewBody(rows []YearWeekRow, cursor, height int, styles YearOverviewStyles) string {
+	if len(rows) == 0 {
+		return styles.MetaStyle.Render("No weeks to show.")
+	}
+
+	maxMinutes := 0
+	for _, r := range rows {
+		maxMinutes = max(maxMinutes, r.Minutes)
+	}
+
+	height = max(1, min(height, len(rows)))
+	first := min(max(0, cursor-height/2), len(rows)-height)
+
+	lines := make([]string, 0, height)
+	for i := first; i < first+height; i++ {
+		r := rows[i]
+		style := yearRowStyle(r, styles)
+		if i == cursor {
+			style = styles.CursorStyle
+		}
+		lines = append(lines, style.Render(formatYearWeekRow(r, maxMinutes)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatYearWeekRow(r YearWeekRow, maxMinutes int) string {
+	_, week := dateutil.ISOWeek(r.WeekStart)
+	filled := 0
+	if maxMinutes > 0 {
+		filled = (r.Minutes*yearBarWidth + maxMinutes - 1) / maxMinutes
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", yearBarWidth-filled)
+
+	completion := ""
+	switch {
+	case r.Blocks == 0:
+	case r.Past:
+		completion = fmt.Sprintf("%3d%% done", r.Completion)
+	default:
+		completion = fmt.Sprintf("%d blocks", r.Blocks)
+	}
+	return fmt.Sprintf("W%02d %s %5.1fh %s %s", week, r.WeekStart.Format("Jan 02"),
+		float64(r.Minutes)/60, bar, completion)
+}
+
+// yearRowStyle colors past weeks by how many of their blocks got an outcome.
+func yearRowStyle(r YearWeekRow, styles YearOverviewStyles) stringRenderer {
+	switch {
+	case r.Blocks == 0:
+		return styles.MetaStyle
+	case !r.Past:
+		return styles.BodyStyle
+	case r.Completion >= 80:
+		return styles.HighStyle
+	case r.Completion >= 50:
+		return styles.MidStyle
+	default:
+		return styles.LowStyle
+	}
+}
@@ -0,0 +1,53 @@
+package view
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestYearOverviewRowsAndStyles(t *testing.T) {
+	first := time.Date(2024, 12, 30, 0, 0, 0, 0, time.Local)
+	weeks := []task.WeeklyAggregate{
+		{WeekStart: first, DayStats: task.DayStats{DeepMinutes: 600, TotalBlocks: 5}, OnTime: 4},
+		{WeekStart: first.AddDate(0, 0, 7), DayStats: task.DayStats{ShallowMinutes: 120, TotalBlocks: 4}, Over: 1},
+		{WeekStart: first.AddDate(0, 0, 21), DayStats: task.DayStats{DeepMinutes: 300, TotalBlocks: 2}},
+	}
+	today := first.AddDate(0, 0, 16)
+
+	rows := BuildYearOverviewRows(first, 4, weeks, today)
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want 4", len(rows))
+	}
+	if rows[0].Minutes != 600 || rows[0].Completion != 80 || !rows[0].Past {
+		t.Errorf("first row = %+v, want 10h, 80%% and past", rows[0])
+	}
+	if rows[2].Blocks != 0 || rows[2].Past {
+		t.Errorf("current week row = %+v, want empty and not past", rows[2])
+	}
+
+	styles := YearOverviewStyles{
+		BodyStyle:   testRenderer{prefix: "B:"},
+		MetaStyle:   testRenderer{prefix: "M:"},
+		CursorStyle: testRenderer{prefix: "C:"},
+		HighStyle:   testRenderer{prefix: "H:"},
+		MidStyle:    testRenderer{prefix: "I:"},
+		LowStyle:    testRenderer{prefix: "L:"},
+	}
+	lines := strings.Split(RenderYearOverviewBody(rows, 3, 10, styles), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4", len(lines))
+	}
+	want := []string{"H:W01 Dec 30  10.0h ████████████████████  80% done", "L:W02 Jan 06   2.0h ████░░░░░░░░░░░░░░░░  25% done", "M:W03", "C:W04"}
+	for i, w := range want {
+		if !strings.HasPrefix(lines[i], w) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], w)
+		}
+	}
+
+	if got := RenderYearOverviewBody(rows, 3, 2, styles); strings.Count(got, "\n") != 1 || !strings.Contains(got, "C:W04") {
+		t.Errorf("limited height should keep the cursor row visible, got %q", got)
+	}
+}
@@ -1,7 +1,17 @@
 // Package tui provides the terminal user interface for sancho.
 package tui
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
 
 func TestVisibleSlotsForTable(t *testing.T) {
 	tests := []struct {
@@ -10,8 +20,8 @@ func TestVisibleSlotsForTable(t *testing.T) {
 		rowLines int
 		want     int
 	}{
-		{name: "height_uses_table_chrome", height: 19, rowLines: 1, want: 15},
-		{name: "multi_line_rows", height: 20, rowLines: 2, want: 8},
+		{name: "height_uses_table_chrome", height: 19, rowLines: 1, want: 14},
+		{name: "multi_line_rows", height: 20, rowLines: 2, want: 7},
 		{name: "too_small", height: 4, rowLines: 1, want: 0},
 	}
 
@@ -24,3 +34,41 @@ func TestVisibleSlotsForTable(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildGridTableRows_RestylesOnlyDamagedCells(t *testing.T) {
+	cfg := config.Default()
+	cfg.Schedule.DayStart = "09:00"
+	cfg.Schedule.DayEnd = "17:00"
+	m := *New(nil, cfg, WithClock(clock.Fixed(time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local))))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 250, Height: 50})
+	m = updated.(Model)
+	week := task.NewWeek(m.weekStart)
+	monday := week.Day(0)
+	monday.AddOverlapping(&task.Task{ID: 1, Description: "Focus", Category: task.CategoryDeep, ScheduledDate: monday.Date, ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled})
+	updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, week, nil)})
+	m = updated.(Model)
+	m.cursor.Day, m.cursor.Slot = 3, 8
+
+	visible := m.visibleSlotsForTable(m.layoutCache.GridH)
+	first, _ := m.buildGridTableRows(visible)
+	if got, want := m.cellCache.restyled, 7*visible; got != want {
+		t.Fatalf("first frame restyled %d cells, want %d", got, want)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	rows, _ := m.buildGridTableRows(visible)
+	if got := m.cellCache.restyled; got != 2 {
+		t.Errorf("cursor move restyled %d cells, want the 2 it left and entered", got)
+	}
+	if rows[8][4] == first[8][4] || rows[9][4] == first[9][4] || rows[8][1] != first[8][1] {
+		t.Error("cursor move did not redraw the cells it left and entered")
+	}
+
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(Model)
+	m.buildGridTableRows(visible)
+	if got, want := m.cellCache.restyled, 7*visible; got != want {
+		t.Errorf("resized frame restyled %d cells, want %d", got, want)
+	}
+}
@@ -6,13 +6,16 @@ import (
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/muesli/termenv"
 
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/config"
 	"github.com/javiermolinar/sancho/internal/dwplanner"
 	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
 	"github.com/javiermolinar/sancho/internal/tui/view"
 )
 
@@ -800,3 +803,127 @@ func TestRenderCell_TimeRangeAfterTitle(t *testing.T) {
 		t.Errorf("expected time range after title, got %q", cell)
 	}
 }
+
+func TestBuildGridTableRows_SplitsOverlappingTasks(t *testing.T) {
+	cfg := config.Default()
+	cfg.Schedule.DayStart = "09:00"
+	cfg.Schedule.DayEnd = "17:00"
+	m := *New(nil, cfg, WithClock(clock.Fixed(time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local))))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+
+	week := task.NewWeek(m.weekStart)
+	wednesday := week.Day(2)
+	wednesday.AddOverlapping(&task.Task{ID: 1, Description: "Focus", Category: task.CategoryDeep, ScheduledDate: wednesday.Date, ScheduledStart: "10:00", ScheduledEnd: "12:00", Status: task.StatusScheduled})
+	wednesday.AddOverlapping(&task.Task{ID: 2, Description: "Sync", Category: task.CategoryShallow, ScheduledDate: wednesday.Date, ScheduledStart: "10:30", ScheduledEnd: "11:00", Status: task.StatusScheduled})
+	updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, week, nil)})
+	m = updated.(Model)
+
+	slot := (10*60 + 30 - 9*60) / m.rowHeight
+	if got := m.overlapCache[2][slot]; got == nil || got.ID != 2 {
+		t.Fatalf("overlapCache at 10:30 = %v, want the sync", got)
+	}
+	if m.overlapCache[2][0] != nil {
+		t.Error("09:00 has no task but an overlap was cached")
+	}
+
+	rows, _ := m.buildGridTableRows(slot + 1)
+	focusRow := (10*60 - 9*60) / m.rowHeight
+	cell := ansi.Strip(rows[focusRow][3])
+	if !strings.Contains(cell, "[D]") || strings.Contains(cell, "[S]") {
+		t.Errorf("10:00 cell = %q, want only the focus block", cell)
+	}
+	cell = ansi.Strip(rows[slot][3])
+	if got := lipgloss.Width(cell); got != m.dayWidth(2) {
+		t.Errorf("split cell width = %d, want %d", got, m.dayWidth(2))
+	}
+	if !strings.Contains(cell, "[S]") {
+		t.Errorf("10:30 cell = %q, want the sync on the right", cell)
+	}
+}
+
+func TestBuildGridTableRows_SplitsOwners(t *testing.T) {
+	cfg := config.Default()
+	cfg.Schedule.DayStart = "09:00"
+	cfg.Schedule.DayEnd = "17:00"
+	cfg.UI.SplitOwner = "ben"
+	m := *New(nil, cfg, WithClock(clock.Fixed(time.Date(2025, 3, 10, 8, 0, 0, 0, time.Local))))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+
+	week := task.NewWeek(m.weekStart)
+	wednesday := week.Day(2)
+	wednesday.AddOverlapping(&task.Task{ID: 1, Description: "Writing", Category: task.CategoryDeep, ScheduledDate: wednesday.Date, ScheduledStart: "10:00", ScheduledEnd: "12:00", Status: task.StatusScheduled})
+	wednesday.AddOverlapping(&task.Task{ID: 2, Description: "Calls", Category: task.CategoryShallow, ScheduledDate: wednesday.Date, ScheduledStart: "13:00", ScheduledEnd: "14:00", Status: task.StatusScheduled, Owner: "ben"})
+	updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, week, nil)})
+	m = updated.(Model)
+
+	slot := (13*60 - 9*60) / m.rowHeight
+	if got := m.taskAt(2, "13:00"); got != nil {
+		t.Errorf("taskAt(13:00) = %q, want the other owner's task left out", got.Description)
+	}
+	if got := m.overlapCache[2][slot]; got == nil || got.ID != 2 {
+		t.Fatalf("overlapCache at 13:00 = %v, want the calls", got)
+	}
+
+	rows, _ := m.buildGridTableRows(slot + 1)
+	cell := ansi.Strip(rows[slot][3])
+	if got := lipgloss.Width(cell); got != m.dayWidth(2) {
+		t.Errorf("split cell width = %d, want %d", got, m.dayWidth(2))
+	}
+	line, _, _ := strings.Cut(cell, "\n")
+	if half := m.dayWidth(2) / 2; strings.TrimSpace(line[:half]) != "" || !strings.Contains(line[half:], "[S]") {
+		t.Errorf("13:00 cell = %q, want the calls on the right half", cell)
+	}
+	focusRow := (10*60 - 9*60) / m.rowHeight
+	if cell := ansi.Strip(rows[focusRow][3]); !strings.Contains(cell, "[D]") {
+		t.Errorf("10:00 cell = %q, want the writing on the left", cell)
+	}
+}
+
+func TestCellContentLines_StickyLabelWhenScrolled(t *testing.T) {
+	cfg := &config.Config{
+		Schedule: config.ScheduleConfig{
+			DayStart: "09:00",
+			DayEnd:   "17:00",
+		},
+	}
+
+	m := New(nil, cfg)
+	m.rowHeight = 30
+	m.rowLines = 1
+	m.colWidth = 24
+
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	long := &task.Task{
+		ID:             7,
+		Description:    "Offsite",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  monday,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "13:00",
+		Status:         task.StatusScheduled,
+	}
+	week := task.NewWeek(monday)
+	if err := week.Day(0).AddTask(long); err != nil {
+		t.Fatalf("add task: %v", err)
+	}
+	ww := task.NewWeekWindow(nil, week, nil)
+	slotConfig := SlotGridConfigFromWeekWindow(ww, cfg.Schedule.DayStart, cfg.Schedule.DayEnd, time.Now, m.rowHeight)
+	m.slotState = NewSlotStateManager(slotConfig)
+	m.slotState.SetGrid(WeekWindowToSlotGrid(ww, m.slotState.Config()))
+	refreshCachesForTest(m)
+	dayTasks := m.gridCache[0]
+
+	if lines := m.cellContentLines(3, long, dayTasks); strings.Contains(lines[0], "Offsite") {
+		t.Fatalf("unscrolled slot 3 = %q, want the label only at the top", lines[0])
+	}
+
+	m.scrollOffset = 3
+	if lines := m.cellContentLines(3, long, dayTasks); lines[0] != "[D] Offsite" {
+		t.Errorf("topmost visible slot = %q, want the label repeated", lines[0])
+	}
+	if lines := m.cellContentLines(4, long, dayTasks); lines[0] != "09:00-13:00" {
+		t.Errorf("next slot = %q, want the time range below the label", lines[0])
+	}
+}
@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tasksync"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+// visualShiftMinutes is how far > and < move the selected tasks.
+const visualShiftMinutes = 60
+
+// enterVisualMode starts a selection anchored at the cursor.
+func (m Model) enterVisualMode() (tea.Model, tea.Cmd) {
+	m.mode = ModeVisual
+	m.visualAnchor = m.cursor
+	m.visualBlock = true
+	m.visualPicked = make(map[int64]bool)
+	m.statusMsg = ""
+	return m, nil
+}
+
+// exitVisualMode drops the selection and returns to normal mode.
+func (m *Model) exitVisualMode() {
+	m.mode = ModeNormal
+	m.visualPicked = nil
+}
+
+// inVisualRange reports whether a cell lies in the block between the
+// anchor and the cursor.
+func (m *Model) inVisualRange(day, slot int) bool {
+	if !m.visualBlock {
+		return false
+	}
+	a, c := m.visualAnchor, m.cursor
+	return day >= min(a.Day, c.Day) && day <= max(a.Day, c.Day) &&
+		slot >= min(a.Slot, c.Slot) && slot <= max(a.Slot, c.Slot)
+}
+
+// isVisualSelected reports whether a cell is highlighted in visual mode.
+func (m *Model) isVisualSelected(day, slot int, t *task.Task) bool {
+	if m.mode != ModeVisual {
+		return false
+	}
+	return m.inVisualRange(day, slot) || (t != nil && m.visualPicked[t.ID])
+}
+
+// visualSelection returns the scheduled tasks touched by the selected block
+// plus the ones picked with Space, ordered by date and start.
+func (m *Model) visualSelection() []*task.Task {
+	seen := make(map[int64]bool)
+	var selected []*task.Task
+	for day := range 7 {
+		for slot, t := range m.gridCache[day] {
+			if t == nil || seen[t.ID] || !t.IsScheduled() {
+				continue
+			}
+			if m.inVisualRange(day, slot) || m.visualPicked[t.ID] {
+				seen[t.ID] = true
+				selected = append(selected, t)
+			}
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		a, b := selected[i], selected[j]
+		if days := task.CalendarDaysBetween(a.ScheduledDate, b.ScheduledDate); days != 0 {
+			return days > 0
+		}
+		return a.ScheduledStart < b.ScheduledStart
+	})
+	return selected
+}
+
+// handleVisualKeys handles keys in visual mode. Movement grows the block
+// one slot at a time and stays within the visible week; Space toggles the
+// task under the cursor and ends the block.
+func (m Model) handleVisualKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "v", "q":
+		m.exitVisualMode()
+		return m, nil
+
+	case "h", "left":
+		m.cursor.Day = max(0, m.cursor.Day-1)
+	case "l", "right":
+		m.cursor.Day = min(6, m.cursor.Day+1)
+	case "j", "down":
+		m.cursor.Slot = min(m.maxSlots()-1, m.cursor.Slot+1)
+		m.ensureCursorVisible()
+	case "k", "up":
+		m.cursor.Slot = max(0, m.cursor.Slot-1)
+		m.ensureCursorVisible()
+
+	case " ":
+		t := m.cachedCursorTask()
+		if t == nil {
+			m.statusMsg = "No task here"
+			return m, nil
+		}
+		// Keep the block's tasks and stop growing it, so the cursor can
+		// move on to pick tasks elsewhere
+		wasPicked := m.visualPicked[t.ID]
+		for _, s := range m.visualSelection() {
+			m.visualPicked[s.ID] = true
+		}
+		m.visualBlock = false
+		if wasPicked {
+			delete(m.visualPicked, t.ID)
+		}
+
+	case "x", "d", ">", "<", "c":
+		return m.applyVisualAction(msg.String())
+	}
+	return m, nil
+}
+
+// applyVisualAction runs a batch action on the selected upcoming tasks.
+func (m Model) applyVisualAction(key string) (tea.Model, tea.Cmd) {
+	var tasks []*task.Task
+	for _, t := range m.visualSelection() {
+		if !m.isTaskPast(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	if len(tasks) == 0 {
+		m.statusMsg = "No upcoming tasks selected"
+		return m, nil
+	}
+
+	updates, summary, err := m.visualUpdates(key, tasks)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.exitVisualMode()
+	m.statusMsg = "Updating..."
+	return m, commands.BatchUpdate(m.repo, updates, summary)
+}
+
+// handleBatchUpdated reloads the weeks after a batch action and tells synced
+// sources about cancelled tasks.
+func (m Model) handleBatchUpdated(msg commands.BatchUpdatedMsg) (tea.Model, tea.Cmd) {
+	ctx := context.Background()
+	cmds := []tea.Cmd{commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)}
+	var changed []*task.Task
+	for _, u := range msg.Updates {
+		t, err := m.repo.GetTask(ctx, u.ID)
+		if err != nil {
+			continue
+		}
+		if !u.Cancel {
+			changed = append(changed, t)
+		} else if m.syncer != nil {
+			cmds = append(cmds, m.pushAction(t, tasksync.ActionCancel, ""))
+		}
+	}
+	m.statusMsg = msg.Summary + m.capacityWarning(ctx, changed...) + m.dependencyWarning(ctx, changed...)
+	return m, tea.Batch(cmds...)
+}
+
+// visualUpdates builds the batch update for a visual mode action along
+// with the status message shown once it is saved.
+func (m *Model) visualUpdates(key string, tasks []*task.Task) ([]task.TaskUpdate, string, error) {
+	updates := make([]task.TaskUpdate, 0, len(tasks))
+	switch key {
+	case "x":
+		for _, t := range tasks {
+			updates = append(updates, task.TaskUpdate{ID: t.ID, Cancel: true, Version: t.Version})
+		}
+		return updates, fmt.Sprintf("Cancelled %d tasks", len(tasks)), nil
+
+	case "d":
+		for _, t := range tasks {
+			nextDay := t.ScheduledDate.AddDate(0, 0, 1)
+			for !m.isWorkday(nextDay) {
+				nextDay = nextDay.AddDate(0, 0, 1)
+			}
+			updates = append(updates, task.TaskUpdate{ID: t.ID, Postpone: true, Date: nextDay, Version: t.Version})
+		}
+		return updates, fmt.Sprintf("Postponed %d tasks to the next workday", len(tasks)), nil
+
+	case ">", "<":
+		delta := visualShiftMinutes
+		if key == "<" {
+			delta = -delta
+		}
+		for _, t := range tasks {
+			start := task.TimeToMinutes(t.ScheduledStart) + delta
+			if start < 0 || start >= MinutesPerDay {
+				return nil, "", fmt.Errorf("%q would move to another day", t.Description)
+			}
+			end := (start + t.Duration()) % MinutesPerDay
+			updates = append(updates, task.TaskUpdate{
+				ID:      t.ID,
+				Start:   task.MinutesToTime(start),
+				End:     task.MinutesToTime(end),
+				Version: t.Version,
+			})
+		}
+		direction := "later"
+		if delta < 0 {
+			direction = "earlier"
+		}
+		return updates, fmt.Sprintf("Moved %d tasks 1h %s", len(tasks), direction), nil
+
+	case "c":
+		// Move them all to the next category when they share one, else
+		// make them all shallow
+		category := task.CategoryShallow
+		same := true
+		for _, t := range tasks {
+			same = same && t.Category == tasks[0].Category
+		}
+		if same {
+			category = tasks[0].Category.Next()
+		}
+		for _, t := range tasks {
+			updates = append(updates, task.TaskUpdate{ID: t.ID, Category: category, Version: t.Version})
+		}
+		return updates, fmt.Sprintf("Marked %d tasks %s", len(tasks), category), nil
+	}
+	return nil, "", fmt.Errorf("unknown action %q", key)
+}
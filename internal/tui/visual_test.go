@@ -0,0 +1,98 @@
+// Package tui provides the terminal user interface for sancho.
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestVisualModeSelectsBlockAndBuildsUpdates(t *testing.T) {
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	mk := func(id int64, category task.Category, start, end string) *task.Task {
+		return &task.Task{
+			ID:             id,
+			Description:    "Task",
+			Category:       category,
+			ScheduledDate:  date,
+			ScheduledStart: start,
+			ScheduledEnd:   end,
+			Status:         task.StatusScheduled,
+		}
+	}
+	a := mk(1, task.CategoryDeep, "09:00", "10:00")
+	b := mk(2, task.CategoryShallow, "10:30", "11:00")
+	c := mk(3, task.CategoryDeep, "13:00", "14:00")
+
+	m := *New(nil, config.Default(), WithClock(clock.Fixed(date.AddDate(0, 0, -1))))
+	m.gridCache[0] = []*task.Task{a, a, b, nil, nil, nil, nil, nil, c}
+	m.cursor = Position{}
+
+	press := func(key string) {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if key == " " {
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)}
+		}
+		updated, _ := m.handleKeyMsg(msg)
+		m = updated.(Model)
+	}
+
+	press("v")
+	if m.mode != ModeVisual {
+		t.Fatalf("mode = %v, want visual", m.mode)
+	}
+	press("j")
+	press("j")
+	if got := len(m.visualSelection()); got != 2 {
+		t.Fatalf("block selection = %d tasks, want 2", got)
+	}
+
+	// Pick a task outside the block
+	for range 6 {
+		press("j")
+	}
+	press(" ")
+	selected := m.visualSelection()
+	if len(selected) != 3 || selected[2].ID != c.ID {
+		t.Fatalf("selection = %v, want a, b and c", selected)
+	}
+
+	updates, _, err := m.visualUpdates(">", selected)
+	if err != nil {
+		t.Fatalf("shift: %v", err)
+	}
+	if u := updates[0]; u.Start != "10:00" || u.End != "11:00" {
+		t.Errorf("shifted a = %s-%s, want 10:00-11:00", u.Start, u.End)
+	}
+
+	updates, _, err = m.visualUpdates("c", selected)
+	if err != nil {
+		t.Fatalf("category: %v", err)
+	}
+	for _, u := range updates {
+		if u.Category != task.CategoryShallow {
+			t.Errorf("task %d category = %s, want shallow", u.ID, u.Category)
+		}
+	}
+
+	press(" ")
+	if got := len(m.visualSelection()); got != 2 {
+		t.Fatalf("selection after unpicking = %d tasks, want 2", got)
+	}
+
+	early := mk(4, task.CategoryDeep, "00:30", "01:00")
+	if _, _, err := m.visualUpdates("<", []*task.Task{early}); err == nil {
+		t.Error("expected shifting past midnight to fail")
+	}
+
+	press("v")
+	if m.mode != ModeNormal || m.visualPicked != nil {
+		t.Fatalf("mode = %v, want normal with selection cleared", m.mode)
+	}
+}
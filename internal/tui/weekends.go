@@ -0,0 +1,39 @@
+package tui
+
+// narrowColWidth is the width of a collapsed day-off column: enough for the
+// day of the month, marked when it is today.
+const narrowColWidth = 4
+
+// collapsibleDays returns the days of the visible week that the weekends
+// setting narrows or hides: days off without tasks. The day under the
+// cursor always keeps its full width, so moving onto it expands it.
+func (m *Model) collapsibleDays() [7]bool {
+	var days [7]bool
+	if m.config == nil || m.config.UI.WeekendsMode() == "show" {
+		return days
+	}
+	for day := range days {
+		date := m.weekStart.AddDate(0, 0, day)
+		if day == m.cursor.Day || m.config.IsWorkday(date.Weekday().String()) {
+			continue
+		}
+		days[day] = len(m.daySpans(day)) == 0
+	}
+	return days
+}
+
+// isNarrowDay reports whether a visible day is drawn as a narrow column.
+func (m Model) isNarrowDay(day int) bool {
+	return m.collapsedDays[day] && m.config.UI.WeekendsMode() == "narrow"
+}
+
+// narrowDays counts the visible days drawn as narrow columns.
+func (m Model) narrowDays() int {
+	n := 0
+	for _, day := range m.visibleDays() {
+		if m.isNarrowDay(day) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestWeekendColumns(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	sunday := monday.AddDate(0, 0, 6)
+	tasks := []*task.Task{
+		{ID: 1, Description: "Hike", Category: task.CategoryShallow, ScheduledDate: sunday, ScheduledStart: "10:00", ScheduledEnd: "12:00", Status: task.StatusScheduled},
+	}
+	load := func(weekends string) Model {
+		cfg := config.Default()
+		cfg.UI.Weekends = weekends
+		m := *New(nil, cfg, WithClock(clock.Fixed(monday.Add(8*time.Hour))))
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+		m = updated.(Model)
+		updated, _ = m.Update(commands.InitialLoadMsg{Window: task.NewWeekWindow(nil, task.NewWeekFromTasks(monday, tasks), nil)})
+		return updated.(Model)
+	}
+	weekWidth := load("show").colWidth
+
+	m := load("narrow")
+	if !m.isNarrowDay(5) || m.isNarrowDay(6) {
+		t.Fatalf("narrow days: saturday %v, sunday %v; want only the empty saturday", m.isNarrowDay(5), m.isNarrowDay(6))
+	}
+	if m.colWidth <= weekWidth {
+		t.Errorf("column width = %d, want wider than %d", m.colWidth, weekWidth)
+	}
+	view := m.View()
+	if strings.Contains(view, "Sat") || !strings.Contains(view, "Sa ") || !strings.Contains(view, "Sun") {
+		t.Error("narrow saturday should show a shortened name")
+	}
+
+	// Moving onto the empty day expands it
+	m.cursor.Day = 4
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = updated.(Model)
+	if m.cursor.Day != 5 || m.isNarrowDay(5) || m.colWidth != weekWidth {
+		t.Errorf("cursor on saturday: narrow %v, column width %d; want the full week", m.isNarrowDay(5), m.colWidth)
+	}
+
+	m = load("hide")
+	if days := m.visibleDays(); len(days) != 6 || days[5] != 6 {
+		t.Fatalf("visible days = %v, want saturday hidden", days)
+	}
+	if view := m.View(); strings.Contains(view, "Sa") || !strings.Contains(view, "Fri") {
+		t.Error("hidden saturday still in the grid")
+	}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// handleYankTask copies the task at the cursor as a template for pasting.
+func (m Model) handleYankTask() (tea.Model, tea.Cmd) {
+	t := m.taskAtCursor()
+	if t == nil {
+		m.statusMsg = "No task to copy"
+		return m, nil
+	}
+	cp := *t
+	m.yanked = &cp
+	m.statusMsg = fmt.Sprintf("Copied: %s (P to paste)", t.Description)
+	return m, nil
+}
+
+// handlePasteTask creates a copy of the yanked task at the cursor slot with
+// the same description, category and duration.
+func (m Model) handlePasteTask() (tea.Model, tea.Cmd) {
+	if m.yanked == nil {
+		m.statusMsg = "Nothing to paste; copy a task with yy first"
+		return m, nil
+	}
+	if t := m.taskAtCursor(); t != nil {
+		m.statusMsg = fmt.Sprintf("Slot taken by %s", t.Description)
+		return m, nil
+	}
+
+	start := m.slotToTime(m.cursor.Slot)
+	newTask := &task.Task{
+		Description:    m.yanked.Description,
+		Category:       m.yanked.Category,
+		ScheduledDate:  m.weekStart.AddDate(0, 0, m.cursor.Day),
+		ScheduledStart: start,
+		ScheduledEnd:   addMinutesToTime(start, m.yanked.Duration()),
+		Status:         task.StatusScheduled,
+	}
+
+	ctx := context.Background()
+	if err := m.repo.CreateTask(ctx, newTask); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Pasted: %s%s%s", newTask.Description, m.reserveBuffer(ctx, newTask), m.capacityWarning(ctx, newTask))
+	return m, m.reloadDays(newTask.ScheduledDate)
+}
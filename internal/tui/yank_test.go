@@ -0,0 +1,80 @@
+// Package tui provides the terminal user interface for sancho.
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestYankAndPasteTask(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	ctx := context.Background()
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	standup := &task.Task{
+		Description:    "Standup",
+		Category:       task.CategoryShallow,
+		ScheduledDate:  monday,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "09:30",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(ctx, standup); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Schedule.DayStart = "09:00"
+	m := *New(repo, cfg, WithClock(clock.Fixed(monday)))
+	m.rowHeight = 15
+	updated, _ := m.Update(commands.LoadInitialWeeks(repo, m.weekStart, m.weekRadius)())
+	m = updated.(Model)
+
+	press := func(key string) tea.Cmd {
+		t.Helper()
+		updated, cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+		return cmd
+	}
+
+	m.cursor = Position{Day: 0, Slot: 0}
+	press("y")
+	press("y")
+	if m.yanked == nil || m.yanked.ID != standup.ID {
+		t.Fatalf("yanked = %+v, want the standup", m.yanked)
+	}
+
+	// Paste on Tuesday at 10:00
+	m.cursor = Position{Day: 1, Slot: 4}
+	if cmd := press("P"); cmd == nil {
+		t.Fatalf("expected a reload after pasting, status %q", m.statusMsg)
+	}
+
+	tasks, err := repo.ListTasksByDateRange(ctx, monday.AddDate(0, 0, 1), monday.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("ListTasksByDateRange: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("got %d tasks on Tuesday, want 1", len(tasks))
+	}
+	got := tasks[0]
+	if got.Description != "Standup" || got.Category != task.CategoryShallow ||
+		got.ScheduledStart != "10:00" || got.ScheduledEnd != "10:30" {
+		t.Errorf("pasted task = %q %s %s-%s, want Standup shallow 10:00-10:30",
+			got.Description, got.Category, got.ScheduledStart, got.ScheduledEnd)
+	}
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+	"github.com/javiermolinar/sancho/internal/tui/view"
+)
+
+// yearModalChrome is the number of screen lines the year modal uses around
+// its rows (border, padding, title and footer).
+const yearModalChrome = 10
+
+// openYearOverview loads the weekly totals of the ISO year of the shown week.
+func (m Model) openYearOverview() (tea.Model, tea.Cmd) {
+	year, _ := dateutil.ISOWeek(m.weekStart)
+	m.statusMsg = "Loading year..."
+	return m, commands.LoadYearOverview(m.repo, year)
+}
+
+func (m Model) handleYearOverviewMsg(msg commands.YearOverviewMsg) (tea.Model, tea.Cmd) {
+	m.yearOverview = msg.Year
+	m.yearRows = view.BuildYearOverviewRows(msg.FirstWeek, msg.NumWeeks, msg.Weeks, m.now())
+	m.yearCursor = 0
+	for i, r := range m.yearRows {
+		if r.WeekStart.Equal(m.weekStart) {
+			m.yearCursor = i
+		}
+	}
+	m.mode = ModeModal
+	m.modalType = ModalYear
+	m.statusMsg = ""
+	return m, nil
+}
+
+func (m Model) handleYearKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.yearCursor = min(m.yearCursor+1, len(m.yearRows)-1)
+	case "k", "up":
+		m.yearCursor = max(m.yearCursor-1, 0)
+	case "h", "left":
+		return m, commands.LoadYearOverview(m.repo, m.yearOverview-1)
+	case "l", "right":
+		return m, commands.LoadYearOverview(m.repo, m.yearOverview+1)
+	case "enter":
+		if m.yearCursor >= len(m.yearRows) {
+			return m, nil
+		}
+		m.weekStart = m.yearRows[m.yearCursor].WeekStart
+		m.closeYearOverview()
+		m.loading = true
+		return m, commands.LoadInitialWeeks(m.repo, m.weekStart, m.weekRadius)
+	case "esc", "q":
+		m.closeYearOverview()
+	}
+	return m, nil
+}
+
+func (m *Model) closeYearOverview() {
+	m.mode = ModeNormal
+	m.modalType = ModalNone
+	m.yearRows = nil
+}
+
+func (m Model) renderYearModal() string {
+	styles := view.YearOverviewStyles{
+		BodyStyle:   m.styles.ModalBodyStyle,
+		MetaStyle:   m.styles.ModalMetaStyle,
+		CursorStyle: m.styles.ModalInputCursorStyle,
+		HighStyle:   m.styles.YearHighStyle,
+		MidStyle:    m.styles.YearMidStyle,
+		LowStyle:    m.styles.YearLowStyle,
+	}
+	body := view.RenderYearOverviewBody(m.yearRows, m.yearCursor, m.height-yearModalChrome, styles)
+	footer := view.YearOverviewFooter(m.modalStyles())
+	return view.RenderModalFrame(fmt.Sprintf("Year %d", m.yearOverview), body, footer, m.modalStyles())
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui/commands"
+)
+
+func TestYearOverviewJumpsToWeek(t *testing.T) {
+	repo, err := db.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	review := &task.Task{
+		Description:    "Review",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  monday.AddDate(0, 0, 8),
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "11:00",
+		Status:         task.StatusScheduled,
+	}
+	if err := repo.CreateTask(context.Background(), review); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	m := *New(repo, config.Default(), WithClock(clock.Fixed(monday)))
+	updated, cmd := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	m = updated.(Model)
+	msg, ok := cmd().(commands.YearOverviewMsg)
+	if !ok {
+		t.Fatalf("O should load the year overview, got %T", msg)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+
+	if m.modalType != ModalYear || m.yearOverview != 2025 || len(m.yearRows) != 52 {
+		t.Fatalf("modal %v, year %d, %d rows; want the 52 weeks of 2025", m.modalType, m.yearOverview, len(m.yearRows))
+	}
+	if !m.yearRows[m.yearCursor].WeekStart.Equal(monday) {
+		t.Fatalf("cursor on %v, want the shown week", m.yearRows[m.yearCursor].WeekStart)
+	}
+	if next := m.yearRows[m.yearCursor+1]; next.Minutes != 120 || next.Blocks != 1 {
+		t.Errorf("next week row = %+v, want the 2h review", next)
+	}
+
+	updated, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	updated, cmd = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.mode != ModeNormal || !m.weekStart.Equal(monday.AddDate(0, 0, 7)) {
+		t.Fatalf("mode %v, week %v; want the grid on the next week", m.mode, m.weekStart)
+	}
+	if _, ok := cmd().(commands.InitialLoadMsg); !ok {
+		t.Error("Enter should reload the week window around the chosen week")
+	}
+}
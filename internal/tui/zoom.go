@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// zoomLevels are the minutes per grid row, from finest to coarsest.
+var zoomLevels = []int{15, 30, 60}
+
+// handleZoom moves one zoom level finer (step < 0) or coarser (step > 0),
+// keeping the cursor on the same time of day.
+func (m Model) handleZoom(step int) (tea.Model, tea.Cmd) {
+	if m.slotState.IsEditing() {
+		m.statusMsg = "Finish moving before zooming"
+		return m, nil
+	}
+
+	level := 0
+	for i, mins := range zoomLevels {
+		if mins == m.rowHeight {
+			level = i
+		}
+	}
+	next := min(max(level+step, 0), len(zoomLevels)-1)
+	if next == level {
+		m.statusMsg = fmt.Sprintf("Zoom: %d-minute rows", m.rowHeight)
+		return m, nil
+	}
+
+	cursorMins := m.dayStartMinutes() + m.cursor.Slot*m.rowHeight
+	m.slotMinutes = zoomLevels[next]
+	m.calculateLayout()
+
+	cfg := m.slotState.Config()
+	cfg.DisplaySlotSize = m.rowHeight / DefaultSlotDuration
+	m.slotState.UpdateConfig(cfg)
+
+	m.cursor.Slot = min(max((cursorMins-m.dayStartMinutes())/m.rowHeight, 0), max(m.maxSlots()-1, 0))
+	m.ensureCursorVisible()
+	m.layoutCache = m.buildLayoutCache(m.width, m.height)
+	m.refreshViewCaches()
+	m.statusMsg = fmt.Sprintf("Zoom: %d-minute rows", m.rowHeight)
+	return m, nil
+}
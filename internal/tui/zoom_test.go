@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+)
+
+func TestZoomKeepsCursorTime(t *testing.T) {
+	cfg := config.Default()
+	cfg.Schedule.DayStart = "09:00"
+	cfg.Schedule.DayEnd = "17:00"
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	m := *New(nil, cfg, WithClock(clock.Fixed(monday)))
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(Model)
+	m.cursor.Slot = 9 // 11:15 at 15-minute rows
+
+	press := func(key string) {
+		t.Helper()
+		updated, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+
+	press("-")
+	if m.rowHeight != 30 || m.cursor.Slot != 4 { // 11:00
+		t.Fatalf("after -: rowHeight %d slot %d, want 30 and 4", m.rowHeight, m.cursor.Slot)
+	}
+	press("-")
+	press("-")
+	if m.rowHeight != 60 || m.cursor.Slot != 2 || m.maxSlots() != 8 {
+		t.Fatalf("after zooming out: rowHeight %d slot %d slots %d, want 60, 2 and 8", m.rowHeight, m.cursor.Slot, m.maxSlots())
+	}
+	if got := m.slotState.Config().DisplaySlotSize; got != 4 {
+		t.Errorf("DisplaySlotSize = %d, want 4", got)
+	}
+	if m.View() == "" {
+		t.Error("empty view at 60-minute rows")
+	}
+	press("+")
+	if m.rowHeight != 30 || m.cursor.Slot != 4 {
+		t.Errorf("after +: rowHeight %d slot %d, want 30 and 4", m.rowHeight, m.cursor.Slot)
+	}
+}
+
+func TestSlotMinutesFromConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.SlotMinutes = 30
+	m := *New(nil, cfg)
+	m.calculateLayout()
+	if m.rowHeight != 30 {
+		t.Errorf("rowHeight = %d, want 30", m.rowHeight)
+	}
+}
@@ -2,7 +2,10 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -15,6 +18,9 @@ func (a *App) addCmd() *cobra.Command {
 		start    string
 		end      string
 		category string
+		energy   string
+		owner    string
+		allDay   bool
 	)
 
 	cmd := &cobra.Command{
@@ -22,44 +28,125 @@ func (a *App) addCmd() *cobra.Command {
 		Short: "Add a new task",
 		Long: `Add a new task to your schedule.
 
+All-day tasks, like being on call or at a conference, take no times: they
+show as a banner over their day and never overlap other tasks.
+
+In a schedule shared with someone else, --owner puts the task in their
+column. Tasks only overlap tasks of the same owner.
+
 Example:
-  sancho add "Write documentation" --date=2025-01-10 --start=09:00 --end=11:00 --category=deep`,
+  sancho add "Write documentation" --date=2025-01-10 --start=09:00 --end=11:00 --category=deep --energy=high
+  sancho add "Conference" --date=2025-01-10 --all-day --category=shallow
+  sancho add "Thesis chapter" --start=09:00 --end=11:00 --owner=ben`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			if err := a.ensureRepo(); err != nil {
 				return err
 			}
 
-			t, err := task.New(args[0], category, date, start, end)
+			var (
+				t   *task.Task
+				err error
+			)
+			switch {
+			case allDay && (start != "" || end != ""):
+				return errors.New("--all-day takes no --start or --end")
+			case allDay:
+				t, err = task.NewAllDay(args[0], category, date, a.clock.Now())
+			case start == "" || end == "":
+				return errors.New("--start and --end are required, or --all-day")
+			default:
+				t, err = task.New(args[0], category, date, start, end, a.clock.Now())
+			}
 			if err != nil {
 				return err
 			}
+			if t.Energy, err = task.ParseEnergy(energy); err != nil {
+				return err
+			}
+			t.Owner = strings.TrimSpace(owner)
 
 			ctx := context.Background()
 			if err := a.repo.CreateTask(ctx, t); err != nil {
 				return fmt.Errorf("creating task: %w", err)
 			}
 
-			fmt.Printf("Created task #%d: %s [%s] %s %s-%s\n",
+			var warnings []string
+			shifted := 0 // Later tasks moved to keep the buffer
+			if buffer := a.config.Schedule.BufferMinutes; buffer > 0 {
+				moved, ok, err := task.ReserveBuffer(ctx, a.repo, t.ID, t.ScheduledDate, buffer)
+				switch {
+				case err != nil:
+					return err
+				case !ok:
+					warnings = append(warnings, fmt.Sprintf("No room for a %dm buffer after this task", buffer))
+				default:
+					shifted = moved
+				}
+			}
+
+			if a.config.Energy.HasProfile() {
+				warning := task.EnergyWarning(t.Description, t.Energy, t.ScheduledStart, t.ScheduledEnd, func(minute int) task.Energy {
+					return task.Energy(a.config.Energy.LevelAt(minute))
+				})
+				if warning != "" {
+					warnings = append(warnings, warning)
+				}
+			}
+
+			used, limit, err := task.WeeklyUsage(ctx, a.repo, t.Category, t.ScheduledDate)
+			if err != nil {
+				return err
+			}
+			if limit > 0 && used > limit {
+				warnings = append(warnings, fmt.Sprintf("%s work this week is over its limit: %s of %s", t.Category, FormatDuration(used), FormatDuration(limit)))
+			}
+
+			hint, err := task.EstimateHint(ctx, a.repo, t)
+			if err != nil {
+				return err
+			}
+			if hint != "" {
+				warnings = append(warnings, "Estimate: "+hint)
+			}
+
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, struct {
+					Task             jsonTask `json:"task"`
+					ShiftedForBuffer int      `json:"shifted_for_buffer"`
+					Warnings         []string `json:"warnings"`
+				}{newJSONTask(t), shifted, append([]string{}, warnings...)})
+			}
+
+			fmt.Printf("Created task #%d: %s [%s] %s %s\n",
 				t.ID,
 				t.Description,
 				t.Category,
 				t.ScheduledDate.Format("2006-01-02"),
-				t.ScheduledStart,
-				t.ScheduledEnd,
+				t.TimeRange(),
 			)
+			if shifted > 0 {
+				fmt.Printf("  Shifted %d later task(s) to keep a %dm buffer\n", shifted, a.config.Schedule.BufferMinutes)
+			}
+			for _, w := range warnings {
+				fmt.Printf("  ! %s\n", w)
+			}
 
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&date, "date", "", "Scheduled date (YYYY-MM-DD, default: today)")
-	cmd.Flags().StringVar(&start, "start", "", "Start time (HH:MM, required)")
-	cmd.Flags().StringVar(&end, "end", "", "End time (HH:MM, required)")
-	cmd.Flags().StringVar(&category, "category", "deep", "Category: deep or shallow")
+	cmd.Flags().StringVar(&start, "start", "", "Start time (HH:MM, required unless --all-day)")
+	cmd.Flags().StringVar(&end, "end", "", "End time (HH:MM, required unless --all-day)")
+	cmd.Flags().StringVar(&category, "category", "deep", "Category: deep, shallow or a configured category")
+	cmd.Flags().StringVar(&energy, "energy", "", "Energy: high, medium or low (optional)")
+	cmd.Flags().BoolVar(&allDay, "all-day", false, "Take the whole day without times, e.g. on call")
+	cmd.Flags().StringVar(&owner, "owner", "", "Who the task belongs to in a shared schedule (default: you)")
 
-	_ = cmd.MarkFlagRequired("start")
-	_ = cmd.MarkFlagRequired("end")
+	_ = cmd.RegisterFlagCompletionFunc("date", a.completeDates)
+	_ = cmd.RegisterFlagCompletionFunc("category", a.completeCategories)
+	_ = cmd.RegisterFlagCompletionFunc("energy", completeValues(string(task.EnergyHigh), string(task.EnergyMedium), string(task.EnergyLow)))
 
 	return cmd
 }
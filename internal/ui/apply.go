@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/patch"
+)
+
+func (a *App) applyCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "apply <patch-file>",
+		Short: "Apply batch edits from a JSON or YAML patch file",
+		Long: `Apply a patch file describing creates, moves, postpones and cancellations.
+
+Every operation is validated with the same overlap rules as interactive edits
+before anything is written; if one fails, nothing is applied. A git-style
+diff of every affected day is printed afterwards.
+
+Patch format (YAML; JSON uses the same fields):
+
+  operations:
+    - op: create
+      description: Write report
+      category: deep        # default deep
+      date: 2025-01-07
+      start: "09:00"
+      end: "11:00"
+    - op: move              # same day only
+      id: 12
+      start: "14:00"        # end defaults to the same duration
+    - op: postpone
+      id: 13
+      date: 2025-01-08      # start/end default to the current times
+    - op: cancel
+      id: 14`,
+		Example: `  sancho apply patch.yaml --dry-run
+  sancho apply patch.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+
+			p, err := patch.Load(args[0])
+			if err != nil {
+				return err
+			}
+
+			result, err := patch.Run(context.Background(), a.repo, p, a.clock.Now(), dryRun)
+			if a.jsonOutput {
+				if err != nil {
+					return fmt.Errorf("applying patch: %w", err)
+				}
+				return writeJSON(os.Stdout, newJSONPatchResult(result))
+			}
+			if result != nil {
+				for _, step := range result.Steps {
+					fmt.Printf("  ✓ %-24s %s\n", step.Op, step.Result)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("applying patch: %w", err)
+			}
+
+			fmt.Println()
+			printDayDiff(os.Stdout, result.Before, result.After)
+
+			if dryRun {
+				fmt.Printf("\nDry run: %s (nothing written)\n", result.Changes.Summary())
+				return nil
+			}
+			fmt.Printf("\nApplied: %s\n", result.Changes.Summary())
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate and show the changes without writing them")
+
+	return cmd
+}
+
+// jsonPatchResult is the output of 'sancho apply --json'.
+type jsonPatchResult struct {
+	Steps   []jsonPatchStep `json:"steps"`
+	Summary string          `json:"summary"`
+	DryRun  bool            `json:"dry_run"`
+	jsonDiff
+}
+
+type jsonPatchStep struct {
+	patch.Op
+	Result string `json:"result"`
+}
+
+func newJSONPatchResult(r *patch.Result) jsonPatchResult {
+	out := jsonPatchResult{
+		Steps:    make([]jsonPatchStep, 0, len(r.Steps)),
+		Summary:  r.Changes.Summary(),
+		DryRun:   r.DryRun,
+		jsonDiff: jsonDiff{Before: newJSONTasks(r.Before), After: newJSONTasks(r.After)},
+	}
+	for _, step := range r.Steps {
+		out.Steps = append(out.Steps, jsonPatchStep{Op: step.Op, Result: step.Result})
+	}
+	return out
+}
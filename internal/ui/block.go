@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func (a *App) blockCmd() *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "block [task-id] [blocked-by-id]",
+		Short: "Mark a task as blocked by another",
+		Long: `Record that a task cannot start before another one ends.
+
+The link is refused when the task is already scheduled to start before
+its prerequisite ends, or when it would make two tasks wait on each
+other. Moving a task out of order later is allowed, with a warning in
+the TUI.
+
+Example:
+  sancho block 43 42
+  sancho block 43 42 --remove`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid task ID: %w", err)
+			}
+			blockedBy, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid task ID: %w", err)
+			}
+
+			ctx := context.Background()
+			if remove {
+				if err := task.RemoveDependency(ctx, a.repo, id, blockedBy); err != nil {
+					return fmt.Errorf("removing dependency: %w", err)
+				}
+			} else if err := task.AddDependency(ctx, a.repo, id, blockedBy); err != nil {
+				return fmt.Errorf("adding dependency: %w", err)
+			}
+
+			if a.jsonOutput {
+				return a.writeTaskJSON(ctx, id)
+			}
+			if remove {
+				fmt.Printf("Task #%d is no longer blocked by #%d\n", id, blockedBy)
+			} else {
+				fmt.Printf("Task #%d is blocked by #%d\n", id, blockedBy)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the link instead of adding it")
+	return cmd
+}
@@ -16,7 +16,8 @@ func (a *App) cancelCmd() *cobra.Command {
 
 Example:
   sancho cancel 42`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
 		RunE: func(_ *cobra.Command, args []string) error {
 			if err := a.ensureRepo(); err != nil {
 				return err
@@ -28,10 +29,13 @@ Example:
 			}
 
 			ctx := context.Background()
-			if err := a.repo.CancelTask(ctx, id); err != nil {
+			if err := a.repo.CancelTask(ctx, id, 0); err != nil {
 				return fmt.Errorf("cancelling task: %w", err)
 			}
 
+			if a.jsonOutput {
+				return a.writeTaskJSON(ctx, id)
+			}
 			fmt.Printf("Cancelled task #%d\n", id)
 			return nil
 		},
@@ -4,11 +4,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/javiermolinar/sancho/internal/clock"
 	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/dateutil"
 	"github.com/javiermolinar/sancho/internal/db"
+	"github.com/javiermolinar/sancho/internal/httpclient"
+	"github.com/javiermolinar/sancho/internal/llm"
+	"github.com/javiermolinar/sancho/internal/startup"
 	"github.com/javiermolinar/sancho/internal/task"
 	"github.com/javiermolinar/sancho/internal/tui"
 )
@@ -25,12 +31,19 @@ type App struct {
 	repo   task.Repository
 	config *config.Config
 	root   *cobra.Command
-	debug  bool // Enable debug logging
+	debug  string // Debug log level; empty when off
+
+	fakeNow string      // --fake-now value, empty for the real clock
+	clock   clock.Clock // Source of "now" for commands and the TUI
+
+	traceStartup bool          // Print per-phase startup timings when the TUI exits
+	kiosk        time.Duration // --kiosk: cycle the TUI views every interval; zero when off
+	jsonOutput   bool          // --json: print machine-readable output
 }
 
 // NewApp creates a new CLI application with the given repository and config.
 func NewApp(repo task.Repository, cfg *config.Config) *App {
-	a := &App{repo: repo, config: cfg}
+	a := &App{repo: repo, config: cfg, clock: clock.System}
 
 	a.root = &cobra.Command{
 		Use:   "sancho",
@@ -39,25 +52,66 @@ func NewApp(repo task.Repository, cfg *config.Config) *App {
 
 It helps you plan your day with focused work blocks, manage tasks,
 and track your productivity over time.`,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := a.setupClock(); err != nil {
+				return err
+			}
+			if a.config != nil {
+				httpclient.SetLocalOnly(a.config.Privacy.LocalOnly)
+				dateutil.SetFirstWeekday(a.config.UI.FirstWeekday())
+				task.SetCategories(categoryInfos(a.config.Categories))
+			}
+			if err := a.setupAudit(); err != nil {
+				return err
+			}
+			startup.Mark("setup")
+			return nil
+		},
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return tui.RunWithDebug(a.repo, a.config, a.debug)
+			if a.kiosk < 0 {
+				return fmt.Errorf("--kiosk must be positive, got %s", a.kiosk)
+			}
+			opts := []tui.ModelOption{tui.WithClock(a.clock)}
+			if a.kiosk > 0 {
+				opts = append(opts, tui.WithKiosk(a.kiosk))
+			}
+			err := tui.RunWithDebug(a.repo, a.config, a.debug, opts...)
+			if a.traceStartup {
+				startup.Report(os.Stderr)
+			}
+			return err
 		},
 	}
 
 	// Add global flags
-	a.root.PersistentFlags().BoolVar(&a.debug, "debug", false, "Enable debug logging (logs to temp file)")
+	a.root.PersistentFlags().StringVar(&a.debug, "debug", "", "Log at this level (debug, info, warn, error) to a rotated file in the temp dir")
+	a.root.PersistentFlags().Lookup("debug").NoOptDefVal = "debug"
+	a.root.PersistentFlags().StringVar(&a.fakeNow, "fake-now", "", "Pretend the current time is this (YYYY-MM-DD[ HH:MM] or RFC 3339), for debugging")
+	a.root.PersistentFlags().BoolVar(&a.jsonOutput, "json", false, "Print machine-readable JSON instead of text")
+	a.root.Flags().BoolVar(&a.traceStartup, "trace-startup", false, "Print how long each startup phase took when the TUI exits")
+	a.root.Flags().DurationVar(&a.kiosk, "kiosk", 0, "Cycle between today, the week and its stats every interval, e.g. 30s, for a wall display")
 
 	a.root.AddCommand(a.versionCmd())
 	a.root.AddCommand(a.configCmd())
 	a.root.AddCommand(a.addCmd())
 	a.root.AddCommand(a.cancelCmd())
+	a.root.AddCommand(a.blockCmd())
+	a.root.AddCommand(a.objectiveCmd())
+	a.root.AddCommand(a.parkCmd())
 	a.root.AddCommand(a.outcomeCmd())
 	a.root.AddCommand(a.listCmd())
 	a.root.AddCommand(a.postponeCmd())
 	a.root.AddCommand(a.planCmd())
 	a.root.AddCommand(a.weekCmd())
 	a.root.AddCommand(a.showCmd())
+	a.root.AddCommand(a.statusCmd())
+	a.root.AddCommand(a.watchCmd())
 	a.root.AddCommand(a.importCmd())
+	a.root.AddCommand(a.applyCmd())
+	a.root.AddCommand(a.timezoneCmd())
+	a.root.AddCommand(a.openCmd())
+	a.root.AddCommand(a.linkCmd())
+	a.root.AddCommand(a.exportCmd())
 
 	return a
 }
@@ -66,8 +120,12 @@ func (a *App) versionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Print the version number",
-		Run: func(_ *cobra.Command, _ []string) {
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, map[string]string{"version": Version, "commit": Commit})
+			}
 			fmt.Printf("sancho %s (commit: %s)\n", Version, Commit)
+			return nil
 		},
 	}
 }
@@ -87,6 +145,42 @@ func (a *App) Close() error {
 	return err
 }
 
+// setupClock applies --fake-now. The fake clock keeps ticking from the given
+// time so the TUI still advances.
+func (a *App) setupClock() error {
+	if a.fakeNow == "" {
+		return nil
+	}
+	start, err := clock.Parse(a.fakeNow)
+	if err != nil {
+		return fmt.Errorf("--fake-now: %w", err)
+	}
+	a.clock = clock.Offset(start)
+	return nil
+}
+
+// setupAudit turns on recording of LLM prompts and responses when configured.
+func (a *App) setupAudit() error {
+	if a.config == nil || !a.config.LLM.Audit.Enabled {
+		return nil
+	}
+	log, err := llm.NewAuditLog(a.config.AuditPath(), a.config.LLM.Audit.Redact)
+	if err != nil {
+		return fmt.Errorf("llm audit: %w", err)
+	}
+	llm.SetAuditLog(log)
+	return nil
+}
+
+// categoryInfos converts the configured categories for the task registry.
+func categoryInfos(categories []config.CategoryConfig) []task.CategoryInfo {
+	infos := make([]task.CategoryInfo, len(categories))
+	for i, c := range categories {
+		infos[i] = task.CategoryInfo{Name: task.Category(c.Name), Code: c.Code, Color: c.Color, Icon: c.Icon, WeeklyLimit: c.WeeklyLimitMinutes()}
+	}
+	return infos
+}
+
 func (a *App) ensureRepo() error {
 	if a.repo != nil {
 		return nil
@@ -95,7 +189,7 @@ func (a *App) ensureRepo() error {
 	if err := os.MkdirAll(dbDir, 0o755); err != nil {
 		return fmt.Errorf("creating data directory: %w", err)
 	}
-	repo, err := db.New(a.config.Storage.DBPath)
+	repo, err := db.New(a.config.Storage.DBPath, db.WithClock(a.clock), db.WithAllowOverlaps(a.config.Schedule.AllowOverlaps))
 	if err != nil {
 		return fmt.Errorf("initializing database: %w", err)
 	}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Dates offered by shell completion, relative to today.
+const (
+	completeDaysBack  = 7
+	completeDaysAhead = 14
+)
+
+// Shell completion runs hidden commands that skip the root's setup, so these
+// functions read the config and clock directly instead of relying on it.
+
+// completeDates suggests dates from a week back to two weeks ahead, each
+// described by its weekday.
+func (a *App) completeDates(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	today := dateutil.TruncateToDay(a.clock.Now())
+	var dates []string
+	for i := -completeDaysBack; i <= completeDaysAhead; i++ {
+		day := today.AddDate(0, 0, i)
+		desc := day.Format("Mon Jan 2")
+		switch i {
+		case 0:
+			desc += ", today"
+		case 1:
+			desc += ", tomorrow"
+		}
+		dates = append(dates, day.Format("2006-01-02")+"\t"+desc)
+	}
+	return dates, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeCategories suggests deep, shallow and the configured categories.
+func (a *App) completeCategories(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	names := []string{string(task.CategoryDeep), string(task.CategoryShallow)}
+	if a.config != nil {
+		for _, c := range a.config.Categories {
+			if c.Name != string(task.CategoryDeep) && c.Name != string(task.CategoryShallow) {
+				names = append(names, c.Name)
+			}
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTaskIDs suggests the IDs of scheduled tasks from a week back to two
+// weeks ahead, described by their date, time and description. It only
+// completes the first argument.
+func (a *App) completeTaskIDs(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if err := a.ensureRepo(); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	today := dateutil.TruncateToDay(a.clock.Now())
+	tasks, err := a.repo.ListTasksByDateRange(context.Background(), today.AddDate(0, 0, -completeDaysBack), today.AddDate(0, 0, completeDaysAhead))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	task.SortByTime(tasks)
+	var ids []string
+	for _, t := range tasks {
+		if !t.IsScheduled() {
+			continue
+		}
+		ids = append(ids, fmt.Sprintf("%s\t%s %s %s", strconv.FormatInt(t.ID, 10),
+			t.ScheduledDate.Format("Mon Jan 2"), t.ScheduledStart, t.Description))
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeValues returns a completion function offering a fixed list.
+func completeValues(values ...string) cobra.CompletionFunc {
+	return cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)
+}
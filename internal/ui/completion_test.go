@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/clock"
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestCompleteTaskIDs(t *testing.T) {
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	repo := memrepo.New()
+	ctx := context.Background()
+	for _, tk := range []*task.Task{
+		{Description: "Review", Category: task.CategoryShallow, ScheduledDate: today.AddDate(0, 0, 1), ScheduledStart: "14:00", ScheduledEnd: "15:00", Status: task.StatusScheduled},
+		{Description: "Write report", Category: task.CategoryDeep, ScheduledDate: today, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled},
+		{Description: "Dropped", Category: task.CategoryDeep, ScheduledDate: today, ScheduledStart: "12:00", ScheduledEnd: "13:00", Status: task.StatusCancelled},
+		{Description: "Long ago", Category: task.CategoryDeep, ScheduledDate: today.AddDate(0, -2, 0), ScheduledStart: "09:00", ScheduledEnd: "10:00", Status: task.StatusScheduled},
+	} {
+		if err := repo.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+	}
+	a := NewApp(repo, config.Default())
+	a.clock = clock.Fixed(today.Add(8 * time.Hour))
+
+	got, _ := a.completeTaskIDs(nil, nil, "")
+	want := []string{"2\tMon Mar 10 09:00 Write report", "1\tTue Mar 11 14:00 Review"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("completeTaskIDs = %q, want %q", got, want)
+	}
+	if got, _ := a.completeTaskIDs(nil, []string{"2"}, ""); got != nil {
+		t.Errorf("second argument completions = %q, want none", got)
+	}
+}
+
+func TestCompleteCategories(t *testing.T) {
+	cfg := config.Default()
+	cfg.Categories = []config.CategoryConfig{{Name: "admin"}, {Name: "deep", Color: "#ffffff"}}
+	a := NewApp(nil, cfg)
+
+	got, _ := a.completeCategories(nil, nil, "")
+	if want := []string{"deep", "shallow", "admin"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("completeCategories = %q, want %q", got, want)
+	}
+}
@@ -3,9 +3,11 @@ package ui
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/javiermolinar/sancho/internal/config"
@@ -19,16 +21,38 @@ func (a *App) configCmd() *cobra.Command {
 		Long: `Interactive configuration management.
 
 If no config file exists, creates one with default values.
-Otherwise, displays current config and allows editing.
+Otherwise, displays current config and allows editing. With --json, the
+config is printed without prompting.
 
 Example:
   sancho config`,
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if a.jsonOutput {
+				return writeConfigJSON(os.Stdout, config.DefaultConfigPath())
+			}
 			return runConfigInteractive()
 		},
 	}
 }
 
+// writeConfigJSON writes the config at path as JSON without prompting. Keys
+// are the same as in the config file.
+func writeConfigJSON(w io.Writer, path string) error {
+	cfg, err := config.LoadFrom(path)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	data, err := toml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("marshaling config: %w", err)
+	}
+	var tree map[string]any
+	if err := toml.Unmarshal(data, &tree); err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	return writeJSON(w, tree)
+}
+
 func runConfigInteractive() error {
 	configPath := config.DefaultConfigPath()
 	fmt.Printf("Config file: %s\n\n", configPath)
@@ -98,14 +122,74 @@ func printConfig(cfg *config.Config) {
 		fmt.Printf("  peak_hours_start = %s\n", cfg.Schedule.PeakHoursStart)
 		fmt.Printf("  peak_hours_end   = %s\n", cfg.Schedule.PeakHoursEnd)
 	}
+	if cfg.Schedule.BufferMinutes > 0 {
+		fmt.Printf("  buffer_minutes   = %d\n", cfg.Schedule.BufferMinutes)
+	}
+	if cfg.Schedule.AllowOverlaps {
+		fmt.Println("  allow_overlaps   = true")
+	}
+	if cfg.Schedule.RescheduleMissed {
+		fmt.Println("  reschedule_missed = true")
+	}
+	for _, pin := range cfg.Schedule.TimezonePins {
+		fmt.Printf("  timezone_pin     = %s..%s %s\n", pin.Start, pin.End, pin.Zone)
+	}
 	fmt.Println("\n[llm]")
 	fmt.Printf("  provider         = %s\n", cfg.LLM.Provider)
 	fmt.Printf("  model            = %s\n", cfg.LLM.Model)
 	fmt.Printf("  base_url         = %s\n", cfg.LLM.BaseURL)
+	if cfg.LLM.Ollama.Host != "" || cfg.LLM.Ollama.Model != "" {
+		fmt.Println("\n[llm.ollama]")
+		fmt.Printf("  host             = %s\n", cfg.LLM.Ollama.Host)
+		fmt.Printf("  model            = %s\n", cfg.LLM.Ollama.Model)
+	}
+	if cfg.LLM.Audit.Enabled {
+		fmt.Println("\n[llm.audit]")
+		fmt.Printf("  path             = %s\n", cfg.AuditPath())
+		fmt.Printf("  redact           = %d patterns\n", len(cfg.LLM.Audit.Redact))
+	}
+	if cfg.Privacy.LocalOnly {
+		fmt.Println("\n[privacy]")
+		fmt.Println("  local_only       = true")
+	}
+	if cfg.Import.GitHub.User != "" || cfg.Import.GitHub.TokenEnv != "" {
+		fmt.Println("\n[import.github]")
+		fmt.Printf("  user             = %s\n", cfg.Import.GitHub.User)
+		fmt.Printf("  token_env        = %s\n", cfg.Import.GitHub.TokenEnv)
+	}
+	if cfg.Import.Jira.JQL != "" {
+		fmt.Println("\n[import.jira]")
+		fmt.Printf("  url              = %s\n", cfg.Import.Jira.URL)
+		fmt.Printf("  jql              = %s\n", cfg.Import.Jira.JQL)
+		if cfg.Import.Jira.Email != "" {
+			fmt.Printf("  email            = %s\n", cfg.Import.Jira.Email)
+		}
+	}
+	if cfg.Export.ObsidianDailyNote != "" {
+		fmt.Println("\n[export]")
+		fmt.Printf("  obsidian_daily_note = %s\n", cfg.Export.ObsidianDailyNote)
+	}
 	fmt.Println("\n[storage]")
 	fmt.Printf("  db_path          = %s\n", cfg.Storage.DBPath)
 	fmt.Println("\n[ui]")
 	fmt.Printf("  theme            = %s\n", cfg.UI.Theme)
+	if cfg.UI.WeekStart != "" {
+		fmt.Printf("  week_start       = %s\n", cfg.UI.WeekStart)
+	}
+	if cfg.UI.Locale != "" {
+		fmt.Printf("  locale           = %s\n", cfg.UI.Locale)
+	}
+	if cfg.Energy.HasProfile() {
+		fmt.Println("\n[energy]")
+		fmt.Printf("  high             = %v\n", cfg.Energy.High)
+		fmt.Printf("  medium           = %v\n", cfg.Energy.Medium)
+		fmt.Printf("  low              = %v\n", cfg.Energy.Low)
+	}
+	if cfg.HasGoals() {
+		fmt.Println("\n[goals]")
+		fmt.Printf("  deep_hours       = %v\n", cfg.Goals.DeepHours)
+		fmt.Printf("  total_hours      = %v\n", cfg.Goals.TotalHours)
+	}
 }
 
 func promptYesNo(question string) bool {
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// diffKind marks a line in a day diff.
+type diffKind int
+
+const (
+	diffContext diffKind = iota
+	diffRemoved
+	diffAdded
+)
+
+// diffLine is one task line in a day diff.
+type diffLine struct {
+	Kind diffKind
+	Task *task.Task
+}
+
+// dayDiff holds the diff lines for one day.
+type dayDiff struct {
+	Date  string // YYYY-MM-DD
+	Lines []diffLine
+}
+
+// buildDayDiff compares the scheduled tasks before and after a change, day by
+// day. Tasks are matched by ID; a stored task whose slot, description or
+// category changed shows up as a removal on its old day and an addition on
+// its new one. Tasks without a stored ID are always additions. Only days with
+// at least one change are returned.
+func buildDayDiff(before, after []*task.Task) []dayDiff {
+	afterByID := make(map[int64]*task.Task)
+	for _, t := range after {
+		if t.ID > 0 && t.IsScheduled() {
+			afterByID[t.ID] = t
+		}
+	}
+	beforeByID := make(map[int64]*task.Task)
+	for _, t := range before {
+		if t.ID > 0 && t.IsScheduled() {
+			beforeByID[t.ID] = t
+		}
+	}
+
+	days := make(map[string]*dayDiff)
+	changed := make(map[string]bool)
+	add := func(kind diffKind, t *task.Task) {
+		key := t.ScheduledDate.Format("2006-01-02")
+		d, ok := days[key]
+		if !ok {
+			d = &dayDiff{Date: key}
+			days[key] = d
+		}
+		d.Lines = append(d.Lines, diffLine{Kind: kind, Task: t})
+		if kind != diffContext {
+			changed[key] = true
+		}
+	}
+
+	for _, t := range before {
+		if !t.IsScheduled() {
+			continue
+		}
+		if a, ok := afterByID[t.ID]; ok && sameSlot(t, a) {
+			add(diffContext, t)
+		} else {
+			add(diffRemoved, t)
+		}
+	}
+	for _, t := range after {
+		if !t.IsScheduled() {
+			continue
+		}
+		if b, ok := beforeByID[t.ID]; ok && t.ID > 0 && sameSlot(b, t) {
+			continue
+		}
+		add(diffAdded, t)
+	}
+
+	result := make([]dayDiff, 0, len(changed))
+	for key := range changed {
+		d := days[key]
+		sort.SliceStable(d.Lines, func(i, j int) bool {
+			a, b := d.Lines[i], d.Lines[j]
+			if a.Task.ScheduledStart != b.Task.ScheduledStart {
+				return a.Task.ScheduledStart < b.Task.ScheduledStart
+			}
+			return a.Kind < b.Kind
+		})
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date < result[j].Date })
+	return result
+}
+
+// sameSlot reports whether two versions of a task look the same in a diff.
+func sameSlot(a, b *task.Task) bool {
+	return task.CalendarDaysBetween(a.ScheduledDate, b.ScheduledDate) == 0 &&
+		a.ScheduledStart == b.ScheduledStart &&
+		a.ScheduledEnd == b.ScheduledEnd &&
+		a.Description == b.Description &&
+		a.Category == b.Category
+}
+
+// printDayDiff writes a git-style colored diff of the affected days.
+func printDayDiff(w io.Writer, before, after []*task.Task) {
+	diffs := buildDayDiff(before, after)
+	if len(diffs) == 0 {
+		_, _ = fmt.Fprintln(w, formatMuted("  No schedule changes."))
+		return
+	}
+	for i, d := range diffs {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintf(w, "  %s\n", formatHeader(d.Lines[0].Task.ScheduledDate.Format("Mon Jan 2")))
+		for _, line := range d.Lines {
+			_, _ = fmt.Fprintln(w, formatDiffLine(line))
+		}
+	}
+}
+
+func formatDiffLine(line diffLine) string {
+	t := line.Task
+	category := "[" + t.Category.Code() + "]"
+	text := fmt.Sprintf("%s-%s  %s %s", t.ScheduledStart, t.ScheduledEnd, category, t.Description)
+	switch line.Kind {
+	case diffRemoved:
+		return formatRemoved("  - " + text)
+	case diffAdded:
+		return formatAdded("  + " + text)
+	default:
+		return formatMuted("    " + text)
+	}
+}
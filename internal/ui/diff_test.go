@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestBuildDayDiff(t *testing.T) {
+	mon := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	tue := mon.AddDate(0, 0, 1)
+	wed := mon.AddDate(0, 0, 2)
+
+	mk := func(id int64, desc string, date time.Time, start, end string) *task.Task {
+		return &task.Task{
+			ID:             id,
+			Description:    desc,
+			Category:       task.CategoryDeep,
+			ScheduledDate:  date,
+			ScheduledStart: start,
+			ScheduledEnd:   end,
+			Status:         task.StatusScheduled,
+		}
+	}
+
+	kept := mk(1, "Kept", mon, "09:00", "10:00")
+	moved := mk(2, "Moved", mon, "10:00", "11:00")
+	cancelled := mk(3, "Cancelled", tue, "09:00", "10:00")
+	untouched := mk(4, "Untouched", wed, "09:00", "10:00")
+
+	movedAfter := *moved
+	movedAfter.ScheduledStart, movedAfter.ScheduledEnd = "14:00", "15:00"
+	cancelledAfter := *cancelled
+	cancelledAfter.Status = task.StatusCancelled
+
+	before := []*task.Task{kept, moved, cancelled, untouched}
+	after := []*task.Task{kept, &movedAfter, &cancelledAfter, untouched, mk(0, "New", tue, "11:00", "12:00")}
+
+	diffs := buildDayDiff(before, after)
+	if len(diffs) != 2 {
+		t.Fatalf("got %d days, want 2 (untouched day skipped): %+v", len(diffs), diffs)
+	}
+
+	type line struct {
+		kind diffKind
+		desc string
+	}
+	want := map[string][]line{
+		"2025-01-06": {{diffContext, "Kept"}, {diffRemoved, "Moved"}, {diffAdded, "Moved"}},
+		"2025-01-07": {{diffRemoved, "Cancelled"}, {diffAdded, "New"}},
+	}
+	for _, d := range diffs {
+		wantLines, ok := want[d.Date]
+		if !ok {
+			t.Fatalf("unexpected day %s", d.Date)
+		}
+		if len(d.Lines) != len(wantLines) {
+			t.Fatalf("%s: got %d lines, want %d", d.Date, len(d.Lines), len(wantLines))
+		}
+		for i, l := range d.Lines {
+			if l.Kind != wantLines[i].kind || l.Task.Description != wantLines[i].desc {
+				t.Errorf("%s line %d = (%d, %q), want (%d, %q)",
+					d.Date, i, l.Kind, l.Task.Description, wantLines[i].kind, wantLines[i].desc)
+			}
+		}
+	}
+}
+
+func TestPrintDayDiff(t *testing.T) {
+	DisableColor()
+	defer EnableColor()
+
+	day := time.Date(2025, 1, 6, 0, 0, 0, 0, time.Local)
+	added := &task.Task{
+		Description:    "Write report",
+		Category:       task.CategoryShallow,
+		ScheduledDate:  day,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+	}
+
+	var buf bytes.Buffer
+	printDayDiff(&buf, nil, []*task.Task{added})
+	out := buf.String()
+	if !strings.Contains(out, "Mon Jan 6") {
+		t.Errorf("expected day header, got %q", out)
+	}
+	if !strings.Contains(out, "  + 09:00-10:00  [S] Write report") {
+		t.Errorf("expected added line, got %q", out)
+	}
+
+	buf.Reset()
+	added.ID = 1
+	printDayDiff(&buf, []*task.Task{added}, []*task.Task{added})
+	if !strings.Contains(buf.String(), "No schedule changes") {
+		t.Errorf("expected no-changes message, got %q", buf.String())
+	}
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/export"
+	"github.com/javiermolinar/sancho/internal/summary"
+)
+
+func (a *App) exportCmd() *cobra.Command {
+	var obsidian bool
+	var org bool
+	var html bool
+	var date string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export your schedule to other tools",
+		Long: `Export a day or week of your schedule to another tool.
+
+With --obsidian, the day's blocks and their outcomes are written as a
+markdown section to the Obsidian daily note set by
+export.obsidian_daily_note. Exporting again replaces the section.
+
+With --org, the week containing the date is printed as an org-mode file
+with scheduled timestamps and TODO/DONE states, or written to --output.
+
+With --html, the week's grid and summary are rendered as a static HTML
+page with no scripts, for emailing or archiving.
+
+With --json, the path written to is printed as JSON; documents sent to
+standard output are printed as they are.`,
+		Example: `  sancho export --obsidian
+  sancho export --obsidian --date=2025-01-15
+  sancho export --org --output=week.org
+  sancho export --html --date=2025-01-15 -o week.html`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if countTrue(obsidian, org, html) != 1 {
+				return errors.New("choose one format: --obsidian, --org or --html")
+			}
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+
+			day := dateutil.TruncateToDay(a.clock.Now())
+			if date != "" {
+				var err error
+				if day, err = dateutil.ParseDate(date); err != nil {
+					return fmt.Errorf("invalid date: %w", err)
+				}
+			}
+			ctx := context.Background()
+
+			if obsidian {
+				path, err := export.Obsidian(ctx, a.repo, a.config.Export.ObsidianDailyNote, day)
+				if err != nil {
+					return err
+				}
+				if a.jsonOutput {
+					return writeJSON(os.Stdout, map[string]string{"path": path})
+				}
+				fmt.Printf("Exported %s to %s\n", day.Format("Mon Jan 2"), path)
+				return nil
+			}
+
+			var text string
+			if org {
+				var err error
+				if text, err = export.OrgWeek(ctx, a.repo, day); err != nil {
+					return err
+				}
+			} else {
+				weekSummary, err := summary.BuildWeekSummary(ctx, a.repo, summary.BuildWeekSummaryOptions{
+					WeekStart: day,
+					PeakStart: a.config.Schedule.PeakHoursStart,
+					PeakEnd:   a.config.Schedule.PeakHoursEnd,
+					Goals:     summary.GoalTargets{DeepMinutes: a.config.Goals.DeepMinutes(), TotalMinutes: a.config.Goals.TotalMinutes()},
+					Now:       a.clock.Now(),
+				})
+				if err != nil {
+					return fmt.Errorf("building week summary: %w", err)
+				}
+				var sb strings.Builder
+				if err := export.HTML(&sb, weekSummary); err != nil {
+					return fmt.Errorf("rendering report: %w", err)
+				}
+				text = sb.String()
+			}
+			if output == "" {
+				fmt.Print(text)
+				return nil
+			}
+			if err := os.WriteFile(output, []byte(text), 0o644); err != nil {
+				return fmt.Errorf("writing %s: %w", output, err)
+			}
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, map[string]string{"path": output})
+			}
+			fmt.Printf("Exported the week of %s to %s\n", dateutil.StartOfWeek(day).Format("Mon Jan 2"), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&obsidian, "obsidian", false, "Write the day to its Obsidian daily note")
+	cmd.Flags().BoolVar(&org, "org", false, "Export the week as an org-mode file")
+	cmd.Flags().BoolVar(&html, "html", false, "Export the week as a static HTML report")
+	cmd.Flags().StringVar(&date, "date", "", "Day to export, or a day in the week to export (YYYY-MM-DD, default today)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write instead of standard output (--org, --html)")
+	_ = cmd.RegisterFlagCompletionFunc("date", a.completeDates)
+
+	return cmd
+}
+
+// countTrue returns how many of flags are set.
+func countTrue(flags ...bool) int {
+	n := 0
+	for _, f := range flags {
+		if f {
+			n++
+		}
+	}
+	return n
+}
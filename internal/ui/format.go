@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mattn/go-runewidth"
+
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
@@ -15,6 +17,7 @@ type Stats struct {
 	TotalBlocks     int
 	CancelledBlocks int
 	PostponedBlocks int
+	MissedBlocks    int
 	DayStats        map[string]DayStats
 }
 
@@ -114,9 +117,9 @@ func PrintTaskRow(t *task.Task, opts PrintOpts, maxDescWidth int) {
 	// Format category
 	var catFormatted string
 	if t.Category == task.CategoryDeep {
-		catFormatted = formatDeep("[D]")
+		catFormatted = formatDeep("[" + t.Category.Code() + "]")
 	} else {
-		catFormatted = formatShallow("[S]")
+		catFormatted = formatShallow("[" + t.Category.Code() + "]")
 	}
 
 	// Peak indicator (⚡ is 2 columns wide)
@@ -129,32 +132,34 @@ func PrintTaskRow(t *task.Task, opts PrintOpts, maxDescWidth int) {
 		}
 	}
 
-	// Truncate description
+	// Truncate and pad the description by terminal columns, so icons and
+	// wide characters keep the duration column aligned
 	desc := t.Description
-	if len(desc) > maxDescWidth {
-		desc = desc[:maxDescWidth-3] + "..."
+	if icon := t.Category.Icon(); icon != "" {
+		desc = icon + " " + desc
 	}
+	desc = runewidth.Truncate(desc, maxDescWidth, "...")
 
 	// Build format string based on options
 	if opts.ShowDuration {
 		duration := formatMuted(FormatDuration(TaskDurationMinutes(t)))
 		if opts.ShowPeak {
-			fmt.Printf("  %s%s  %s-%s  %s  %-*s  %s\n",
-				peakIndicator, symbol, t.ScheduledStart, t.ScheduledEnd,
-				catFormatted, maxDescWidth, desc, duration)
+			fmt.Printf("  %s%s  %-11s  %s  %s  %s\n",
+				peakIndicator, symbol, t.TimeRange(),
+				catFormatted, runewidth.FillRight(desc, maxDescWidth), duration)
 		} else {
-			fmt.Printf("    %s  %s-%s  %s  %-*s  %s\n",
-				symbol, t.ScheduledStart, t.ScheduledEnd,
-				catFormatted, maxDescWidth, desc, duration)
+			fmt.Printf("    %s  %-11s  %s  %s  %s\n",
+				symbol, t.TimeRange(),
+				catFormatted, runewidth.FillRight(desc, maxDescWidth), duration)
 		}
 	} else {
 		if opts.ShowPeak {
-			fmt.Printf("  %s%s  %s-%s  %s  %s\n",
-				peakIndicator, symbol, t.ScheduledStart, t.ScheduledEnd,
+			fmt.Printf("  %s%s  %-11s  %s  %s\n",
+				peakIndicator, symbol, t.TimeRange(),
 				catFormatted, desc)
 		} else {
-			fmt.Printf("    %s  %s-%s  %s  %s\n",
-				symbol, t.ScheduledStart, t.ScheduledEnd,
+			fmt.Printf("    %s  %-11s  %s  %s\n",
+				symbol, t.TimeRange(),
 				catFormatted, desc)
 		}
 	}
@@ -176,6 +181,8 @@ func AccumulateStats(stats *Stats, t *task.Task, dayKey string, opts PrintOpts)
 		stats.CancelledBlocks++
 	case task.StatusPostponed:
 		stats.PostponedBlocks++
+	case task.StatusMissed:
+		stats.MissedBlocks++
 	default:
 		if t.Category == task.CategoryDeep {
 			stats.DeepMinutes += minutes
@@ -227,9 +234,9 @@ func PrintStatsExtended(stats task.WeekStats, showPeakAlignment bool) {
 			FormatDuration(stats.DeepMinutes))
 	}
 
-	if stats.CancelledBlocks > 0 || stats.PostponedBlocks > 0 {
-		fmt.Printf("  %s\n", formatMuted(fmt.Sprintf("Cancelled: %d  |  Postponed: %d",
-			stats.CancelledBlocks, stats.PostponedBlocks)))
+	if stats.CancelledBlocks > 0 || stats.PostponedBlocks > 0 || stats.MissedBlocks > 0 {
+		fmt.Printf("  %s\n", formatMuted(fmt.Sprintf("Cancelled: %d  |  Postponed: %d  |  Missed: %d",
+			stats.CancelledBlocks, stats.PostponedBlocks, stats.MissedBlocks)))
 	}
 }
 
@@ -246,6 +253,19 @@ func FlowBar(deepMinutes, totalMinutes, width int) string {
 	return fmt.Sprintf("[%s] %s", formatDeep(bar), formatStats(fmt.Sprintf("(%d%% Focused)", pct)))
 }
 
+// GoalBar creates an ASCII progress bar for a weekly goal: done time as "█",
+// planned time as "▓" and the remainder as "░".
+func GoalBar(g task.GoalProgress, width int) string {
+	done, planned := 0, 0
+	if g.Target > 0 {
+		done = min(width, g.Done*width/g.Target)
+		planned = min(width-done, g.Total()*width/g.Target-done)
+	}
+	bar := formatDeep(strings.Repeat("█", done)) + formatShallow(strings.Repeat("▓", planned)) + strings.Repeat("░", width-done-planned)
+	return fmt.Sprintf("[%s] %s/%s %s", bar, FormatDuration(g.Total()), FormatDuration(g.Target),
+		formatStats(fmt.Sprintf("(%d%%)", g.Percent())))
+}
+
 // FormatDuration formats minutes as a human-readable duration.
 func FormatDuration(minutes int) string {
 	if minutes == 0 {
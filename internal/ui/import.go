@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,12 +15,19 @@ import (
 )
 
 func (a *App) importCmd() *cobra.Command {
+	var dryRun bool
+
 	cmd := &cobra.Command{
 		Use:   "import [database_path]",
 		Short: "Import tasks from another database",
 		Long: `Import all tasks from another Sancho database into the current one.
 
+Use --dry-run to print a diff of the affected days without importing.
+Importing the same database again updates the tasks it imported before
+instead of duplicating them.
+
 Example:
+  sancho import /path/to/other.db --dry-run
   sancho import /path/to/other.db`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
@@ -51,32 +59,124 @@ Example:
 				return fmt.Errorf("source database path is a directory: %s", sourcePath)
 			}
 
-			count, err := importTasks(context.Background(), a.repo, sourcePath)
+			if dryRun {
+				before, after, err := previewImport(context.Background(), a.repo, sourcePath)
+				if err != nil {
+					return err
+				}
+				if a.jsonOutput {
+					return writeJSON(os.Stdout, jsonDiff{Before: newJSONTasks(before), After: newJSONTasks(after)})
+				}
+				printDayDiff(os.Stdout, before, after)
+				fmt.Println("\n(Dry run - tasks not imported)")
+				return nil
+			}
+
+			created, updated, err := importTasks(context.Background(), a.repo, sourcePath)
 			if err != nil {
 				return err
 			}
 
-			fmt.Printf("Imported %d tasks from %s\n", count, sourcePath)
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, struct {
+					Source  string `json:"source"`
+					Created int    `json:"created"`
+					Updated int    `json:"updated"`
+				}{sourcePath, created, updated})
+			}
+			fmt.Printf("Imported %d tasks from %s (%d updated)\n", created, sourcePath, updated)
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show a diff of the affected days without importing")
+
 	return cmd
 }
 
-func importTasks(ctx context.Context, dest task.Repository, sourcePath string) (int, error) {
+// previewImport returns the destination tasks on every day the source
+// database schedules something, before and after the import. Tasks imported
+// before are shown as updated rather than added again.
+func previewImport(ctx context.Context, dest task.Repository, sourcePath string) (before, after []*task.Task, err error) {
+	sourceRepo, err := db.New(sourcePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening source database: %w", err)
+	}
+	defer func() { _ = sourceRepo.Close() }()
+
+	tasks, err := sourceRepo.ListAllTasks(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing source tasks: %w", err)
+	}
+
+	var days []time.Time
+	seen := make(map[string]bool)
+	addDay := func(date time.Time) {
+		key := date.Format("2006-01-02")
+		if !seen[key] {
+			seen[key] = true
+			days = append(days, date)
+		}
+	}
+
+	replaced := make(map[int64]bool)
+	incoming := make([]*task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		cp := *t
+		cp.ID = 0
+		cp.ExternalRef = importRef(sourcePath, t)
+		existing, err := dest.GetTaskByExternalRef(ctx, cp.ExternalRef)
+		if err != nil {
+			return nil, nil, fmt.Errorf("looking up task %q: %w", t.Description, err)
+		}
+		if existing != nil {
+			cp.ID = existing.ID
+			cp.Status = existing.Status
+			cp.Outcome = existing.Outcome
+			replaced[existing.ID] = true
+			if existing.IsScheduled() {
+				addDay(existing.ScheduledDate)
+			}
+		}
+		if t.IsScheduled() {
+			addDay(t.ScheduledDate)
+		}
+		incoming = append(incoming, &cp)
+	}
+
+	for _, day := range days {
+		existing, err := dest.ListTasksByDateRange(ctx, day, day)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing tasks: %w", err)
+		}
+		before = append(before, existing...)
+	}
+
+	for _, t := range before {
+		if !replaced[t.ID] {
+			after = append(after, t)
+		}
+	}
+	after = append(after, incoming...)
+	return before, after, nil
+}
+
+// importTasks copies every task from the source database into dest. Each
+// imported task is linked to its source row through an external reference,
+// so importing the same database again updates the tasks instead of adding
+// duplicates. It returns how many tasks were created and updated.
+func importTasks(ctx context.Context, dest task.Repository, sourcePath string) (created, updated int, err error) {
 	sourceRepo, err := db.New(sourcePath)
 	if err != nil {
-		return 0, fmt.Errorf("opening source database: %w", err)
+		return 0, 0, fmt.Errorf("opening source database: %w", err)
 	}
 	defer func() { _ = sourceRepo.Close() }()
 
 	tasks, err := sourceRepo.ListAllTasks(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("listing source tasks: %w", err)
+		return 0, 0, fmt.Errorf("listing source tasks: %w", err)
 	}
 
-	imported := 0
 	idMap := make(map[int64]int64, len(tasks))
 	for _, sourceTask := range tasks {
 		newTask := &task.Task{
@@ -87,26 +187,42 @@ func importTasks(ctx context.Context, dest task.Repository, sourcePath string) (
 			ScheduledEnd:   sourceTask.ScheduledEnd,
 			Status:         sourceTask.Status,
 			Outcome:        sourceTask.Outcome,
+			Energy:         sourceTask.Energy,
+			ExternalRef:    importRef(sourcePath, sourceTask),
 			CreatedAt:      sourceTask.CreatedAt,
 		}
 
 		if sourceTask.PostponedFrom != nil {
 			newID, ok := idMap[*sourceTask.PostponedFrom]
 			if !ok {
-				return imported, fmt.Errorf("postponed from task %d not imported yet", *sourceTask.PostponedFrom)
+				return created, updated, fmt.Errorf("postponed from task %d not imported yet", *sourceTask.PostponedFrom)
 			}
 			newTask.PostponedFrom = &newID
 		}
 
-		if err := dest.CreateTask(ctx, newTask); err != nil {
-			return imported, fmt.Errorf("importing task %q: %w", sourceTask.Description, err)
+		isNew, err := dest.UpsertTask(ctx, newTask)
+		if err != nil {
+			return created, updated, fmt.Errorf("importing task %q: %w", sourceTask.Description, err)
 		}
 
 		idMap[sourceTask.ID] = newTask.ID
-		imported++
+		if isNew {
+			created++
+		} else {
+			updated++
+		}
 	}
 
-	return imported, nil
+	return created, updated, nil
+}
+
+// importRef returns the external reference an imported task is stored
+// under: the reference it already carries, or its row in the source database.
+func importRef(sourcePath string, t *task.Task) task.ExternalRef {
+	if !t.ExternalRef.IsZero() {
+		return t.ExternalRef
+	}
+	return task.ExternalRef{Source: "sancho", ID: fmt.Sprintf("%s#%d", sourcePath, t.ID)}
 }
 
 func resolvePath(path string) (string, error) {
@@ -58,12 +58,12 @@ func TestImportTasks(t *testing.T) {
 	}
 	defer func() { _ = destRepo.Close() }()
 
-	count, err := importTasks(ctx, destRepo, sourcePath)
+	created, updated, err := importTasks(ctx, destRepo, sourcePath)
 	if err != nil {
 		t.Fatalf("importTasks failed: %v", err)
 	}
-	if count != 2 {
-		t.Fatalf("expected 2 imported tasks, got %d", count)
+	if created != 2 || updated != 0 {
+		t.Fatalf("expected 2 created and 0 updated tasks, got %d and %d", created, updated)
 	}
 
 	imported, err := destRepo.ListAllTasks(ctx)
@@ -107,3 +107,69 @@ func TestImportTasks(t *testing.T) {
 		t.Fatalf("expected PostponedFrom %d, got %d", importedOriginal.ID, *importedPostponed.PostponedFrom)
 	}
 }
+
+func TestImportTasks_UpdatesOnReimport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	sourcePath := filepath.Join(dir, "source.db")
+
+	sourceRepo, err := db.New(sourcePath)
+	if err != nil {
+		t.Fatalf("creating source repo: %v", err)
+	}
+	defer func() { _ = sourceRepo.Close() }()
+
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	src := &task.Task{
+		Description:    "Write report",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  date,
+		ScheduledStart: "09:00",
+		ScheduledEnd:   "10:00",
+		Status:         task.StatusScheduled,
+		CreatedAt:      time.Now(),
+	}
+	if err := sourceRepo.CreateTask(ctx, src); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	destRepo, err := db.New(filepath.Join(dir, "dest.db"))
+	if err != nil {
+		t.Fatalf("creating destination repo: %v", err)
+	}
+	defer func() { _ = destRepo.Close() }()
+
+	if _, _, err := importTasks(ctx, destRepo, sourcePath); err != nil {
+		t.Fatalf("first import failed: %v", err)
+	}
+
+	if err := sourceRepo.UpdateTaskDescription(ctx, src.ID, "Write final report", 0); err != nil {
+		t.Fatalf("UpdateTaskDescription failed: %v", err)
+	}
+	if err := sourceRepo.UpdateTask(ctx, src.ID, "10:00", "11:30", 0); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+
+	created, updated, err := importTasks(ctx, destRepo, sourcePath)
+	if err != nil {
+		t.Fatalf("second import failed: %v", err)
+	}
+	if created != 0 || updated != 1 {
+		t.Fatalf("expected 0 created and 1 updated tasks, got %d and %d", created, updated)
+	}
+
+	imported, err := destRepo.ListAllTasks(ctx)
+	if err != nil {
+		t.Fatalf("ListAllTasks failed: %v", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 task in destination, got %d", len(imported))
+	}
+	got := imported[0]
+	if got.Description != "Write final report" || got.ScheduledStart != "10:00" || got.ScheduledEnd != "11:30" {
+		t.Errorf("task not updated: %q %s-%s", got.Description, got.ScheduledStart, got.ScheduledEnd)
+	}
+	if got.ExternalRef.Source != "sancho" {
+		t.Errorf("ExternalRef = %q, want a sancho reference", got.ExternalRef)
+	}
+}
@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/javiermolinar/sancho/internal/summary"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// The types below are the --json output of the CLI. Scripts depend on their
+// field names, so add fields rather than renaming or removing them.
+
+// jsonTask is a task in --json output.
+type jsonTask struct {
+	ID              int64  `json:"id"`
+	Description     string `json:"description"`
+	Category        string `json:"category"`
+	Date            string `json:"date"`  // YYYY-MM-DD
+	Start           string `json:"start"` // HH:MM
+	End             string `json:"end"`   // HH:MM, before start for overnight blocks
+	DurationMinutes int    `json:"duration_minutes"`
+	Status          string `json:"status"`
+	Outcome         string `json:"outcome,omitempty"`
+	Energy          string `json:"energy,omitempty"`
+	PostponedFrom   *int64 `json:"postponed_from,omitempty"`
+	Source          string `json:"source,omitempty"` // External reference, e.g. "github:owner/repo#12"
+	Owner           string `json:"owner,omitempty"`
+	URL             string `json:"url,omitempty"`
+}
+
+// jsonStats is the deep/shallow breakdown of a day or week.
+type jsonStats struct {
+	DeepMinutes     int `json:"deep_minutes"`
+	ShallowMinutes  int `json:"shallow_minutes"`
+	PeakDeepMinutes int `json:"peak_deep_minutes"`
+	DeepPercent     int `json:"deep_percent"`
+	TotalBlocks     int `json:"total_blocks"`
+	CancelledBlocks int `json:"cancelled_blocks"`
+	PostponedBlocks int `json:"postponed_blocks"`
+	MissedBlocks    int `json:"missed_blocks"`
+}
+
+type jsonGoal struct {
+	Label          string `json:"label"`
+	TargetMinutes  int    `json:"target_minutes"`
+	DoneMinutes    int    `json:"done_minutes"`
+	PlannedMinutes int    `json:"planned_minutes"`
+	Percent        int    `json:"percent"`
+}
+
+type jsonTicket struct {
+	Key     string `json:"key"`
+	Minutes int    `json:"minutes"`
+}
+
+// jsonWeek is the output of 'sancho week --json'.
+type jsonWeek struct {
+	Start    string       `json:"start"`
+	End      string       `json:"end"`
+	Tasks    []jsonTask   `json:"tasks"`
+	Stats    jsonStats    `json:"stats"`
+	Previous *jsonStats   `json:"previous,omitempty"` // The week before, when it had tasks
+	Goals    []jsonGoal   `json:"goals"`
+	Budgets  []jsonGoal   `json:"budgets"` // Weekly category limits
+	Tickets  []jsonTicket `json:"tickets"`
+	Insight  string       `json:"insight,omitempty"`
+}
+
+// jsonDiff is the tasks on every affected day before and after a change.
+type jsonDiff struct {
+	Before []jsonTask `json:"before"`
+	After  []jsonTask `json:"after"`
+}
+
+// writeJSON writes v as indented JSON followed by a newline.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	return nil
+}
+
+// writeJSONLine writes v as compact JSON on a single line, for streams.
+func writeJSONLine(w io.Writer, v any) error {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	return nil
+}
+
+func newJSONTask(t *task.Task) jsonTask {
+	jt := jsonTask{
+		ID:              t.ID,
+		Description:     t.Description,
+		Category:        string(t.Category),
+		Date:            t.ScheduledDate.Format("2006-01-02"),
+		Start:           t.ScheduledStart,
+		End:             t.ScheduledEnd,
+		DurationMinutes: t.Duration(),
+		Status:          string(t.Status),
+		Energy:          string(t.Energy),
+		PostponedFrom:   t.PostponedFrom,
+		Owner:           t.Owner,
+	}
+	if t.Outcome != nil {
+		jt.Outcome = string(*t.Outcome)
+	}
+	if !t.ExternalRef.IsZero() {
+		jt.Source = t.ExternalRef.String()
+	}
+	if t.ID != 0 {
+		jt.URL = task.TaskURL(t.ID)
+	}
+	return jt
+}
+
+// newJSONTasks converts tasks, returning an empty list rather than null.
+func newJSONTasks(tasks []*task.Task) []jsonTask {
+	out := make([]jsonTask, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, newJSONTask(t))
+	}
+	return out
+}
+
+func newJSONStats(s task.WeekStats) jsonStats {
+	return jsonStats{
+		DeepMinutes:     s.DeepMinutes,
+		ShallowMinutes:  s.ShallowMinutes,
+		PeakDeepMinutes: s.PeakDeepMinutes,
+		DeepPercent:     s.DeepPercent(),
+		TotalBlocks:     s.TotalBlocks,
+		CancelledBlocks: s.CancelledBlocks,
+		PostponedBlocks: s.PostponedBlocks,
+		MissedBlocks:    s.MissedBlocks,
+	}
+}
+
+func newJSONDayStats(s Stats) jsonStats {
+	return jsonStats{
+		DeepMinutes:     s.DeepMinutes,
+		ShallowMinutes:  s.ShallowMinutes,
+		PeakDeepMinutes: s.PeakDeepMinutes,
+		DeepPercent:     s.DeepPercent(),
+		TotalBlocks:     s.TotalBlocks,
+		CancelledBlocks: s.CancelledBlocks,
+		PostponedBlocks: s.PostponedBlocks,
+		MissedBlocks:    s.MissedBlocks,
+	}
+}
+
+func newJSONGoal(g task.GoalProgress) jsonGoal {
+	return jsonGoal{
+		Label:          g.Label,
+		TargetMinutes:  g.Target,
+		DoneMinutes:    g.Done,
+		PlannedMinutes: g.Planned,
+		Percent:        g.Percent(),
+	}
+}
+
+func newJSONWeek(s *summary.WeekSummary) jsonWeek {
+	w := jsonWeek{
+		Start:   s.Start.Format("2006-01-02"),
+		End:     s.End.Format("2006-01-02"),
+		Tasks:   newJSONTasks(s.Tasks),
+		Stats:   newJSONStats(s.Stats),
+		Goals:   make([]jsonGoal, 0, len(s.Goals)),
+		Budgets: make([]jsonGoal, 0, len(s.Budgets)),
+		Tickets: make([]jsonTicket, 0, len(s.Tickets)),
+		Insight: s.Insight,
+	}
+	if s.Previous != nil {
+		prev := newJSONStats(*s.Previous)
+		w.Previous = &prev
+	}
+	for _, g := range s.Goals {
+		w.Goals = append(w.Goals, newJSONGoal(g))
+	}
+	for _, g := range s.Budgets {
+		w.Budgets = append(w.Budgets, newJSONGoal(g))
+	}
+	for _, tt := range s.Tickets {
+		w.Tickets = append(w.Tickets, jsonTicket{Key: tt.Key, Minutes: tt.Minutes})
+	}
+	return w
+}
+
+// writeTaskJSON reads the task with the given ID back from the repository
+// and writes it as {"task": ...}, so changes show the stored result.
+func (a *App) writeTaskJSON(ctx context.Context, id int64) error {
+	t, err := a.repo.GetTask(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting task: %w", err)
+	}
+	if t == nil {
+		return fmt.Errorf("task #%d: %w", id, task.ErrTaskNotFound)
+	}
+	return writeJSON(os.Stdout, struct {
+		Task jsonTask `json:"task"`
+	}{newJSONTask(t)})
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/summary"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestNewJSONTask_FieldNames(t *testing.T) {
+	outcome := task.OutcomeOver
+	from := int64(7)
+	tk := &task.Task{
+		ID:             42,
+		Description:    "Write report",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 1, 7, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "10:00",
+		ScheduledEnd:   "11:30",
+		Status:         task.StatusScheduled,
+		Outcome:        &outcome,
+		Energy:         task.EnergyHigh,
+		PostponedFrom:  &from,
+		ExternalRef:    task.ExternalRef{Source: "github", ID: "acme/app#3"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONTask(tk)); err != nil {
+		t.Fatalf("writeJSON: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	want := map[string]any{
+		"id":               float64(42),
+		"description":      "Write report",
+		"category":         "deep",
+		"date":             "2025-01-07",
+		"start":            "10:00",
+		"end":              "11:30",
+		"duration_minutes": float64(90),
+		"status":           "scheduled",
+		"outcome":          "over",
+		"energy":           "high",
+		"postponed_from":   float64(7),
+		"source":           "github:acme/app#3",
+		"url":              "sancho://task/42",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("task JSON = %v\nwant %v", got, want)
+	}
+}
+
+func TestNewJSONWeek_EmptyListsNotNull(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	s := summary.SummarizeWeek(monday, nil, summary.WeekSummaryOptions{Now: monday})
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONWeek(s)); err != nil {
+		t.Fatalf("writeJSON: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	for _, key := range []string{"tasks", "goals", "tickets"} {
+		if list, ok := got[key].([]any); !ok || len(list) != 0 {
+			t.Errorf("%s = %v, want []", key, got[key])
+		}
+	}
+	if got["start"] != "2025-03-10" || got["end"] != "2025-03-16" {
+		t.Errorf("range = %v..%v", got["start"], got["end"])
+	}
+	if _, ok := got["previous"]; ok {
+		t.Error("previous is set without a previous week")
+	}
+}
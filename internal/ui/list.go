@@ -3,6 +3,8 @@ package ui
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -14,6 +16,10 @@ func (a *App) listCmd() *cobra.Command {
 	var (
 		startDate string
 		endDate   string
+		search    string
+		statuses  []string
+		limit     int
+		offset    int
 	)
 
 	cmd := &cobra.Command{
@@ -23,25 +29,56 @@ func (a *App) listCmd() *cobra.Command {
 
 If no dates are specified, lists today's tasks.
 If only --start is specified, lists tasks for that single day.
-If both --start and --end are specified, lists tasks in that range (inclusive).`,
+If both --start and --end are specified, lists tasks in that range (inclusive).
+
+--search looks for text in the descriptions of every task, or only in the
+date range if one is given. Use --limit and --offset to page through long
+results.`,
 		Example: `  sancho list
   sancho list --start=2025-01-15
-  sancho list --start=2025-01-15 --end=2025-01-20`,
+  sancho list --start=2025-01-15 --end=2025-01-20
+  sancho list --search=review --status=scheduled --limit=20
+  sancho list --search=review --limit=20 --offset=20`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			if err := a.ensureRepo(); err != nil {
 				return err
 			}
 
-			dateRange, err := dateutil.NewDateRange(startDate, endDate)
-			if err != nil {
-				return err
+			filter := task.TaskFilter{Query: search}
+			for _, st := range statuses {
+				switch status := task.Status(st); status {
+				case task.StatusScheduled, task.StatusPostponed, task.StatusCancelled, task.StatusMissed:
+					filter.Statuses = append(filter.Statuses, status)
+				default:
+					return fmt.Errorf("unknown status %q", st)
+				}
+			}
+			// A search without dates looks through every task
+			if search == "" || startDate != "" || endDate != "" {
+				dateRange, err := dateutil.NewDateRange(startDate, endDate)
+				if err != nil {
+					return err
+				}
+				filter.From, filter.To = dateRange.Start, dateRange.End
 			}
 
-			tasks, err := a.repo.ListTasksByDateRange(context.Background(), dateRange.Start, dateRange.End)
+			tasks, err := task.ListTasks(context.Background(), a.repo, filter, limit, offset)
 			if err != nil {
 				return fmt.Errorf("listing tasks: %w", err)
 			}
 
+			if a.jsonOutput {
+				out := struct {
+					Tasks      []jsonTask `json:"tasks"`
+					NextOffset *int       `json:"next_offset,omitempty"` // Set when more tasks may follow
+				}{Tasks: newJSONTasks(tasks)}
+				if limit > 0 && len(tasks) == limit {
+					next := offset + limit
+					out.NextOffset = &next
+				}
+				return writeJSON(os.Stdout, out)
+			}
+
 			if len(tasks) == 0 {
 				fmt.Println("No tasks found in the specified date range.")
 				return nil
@@ -60,23 +97,38 @@ If both --start and --end are specified, lists tasks in that range (inclusive).`
 				}
 
 				status := statusSymbol(t.Status)
-				category := string(t.Category)[0:1] // "d" or "s"
-				fmt.Printf("  %s #%d [%s] %s-%s %s\n",
+				category := strings.ToLower(t.Category.Code()) // "d" or "s"
+				owner := ""
+				if t.Owner != "" {
+					owner = " @" + t.Owner
+				}
+				fmt.Printf("  %s #%d [%s] %s %s%s\n",
 					status,
 					t.ID,
 					category,
-					t.ScheduledStart,
-					t.ScheduledEnd,
+					t.TimeRange(),
 					t.Description,
+					owner,
 				)
 			}
 
+			if limit > 0 && len(tasks) == limit {
+				fmt.Printf("\nMore tasks may follow: use --offset=%d\n", offset+limit)
+			}
+
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&startDate, "start", "", "Start date (YYYY-MM-DD, defaults to today)")
 	cmd.Flags().StringVar(&endDate, "end", "", "End date (YYYY-MM-DD, defaults to start date)")
+	cmd.Flags().StringVar(&search, "search", "", "Only tasks whose description contains this text (all dates unless --start is set)")
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "Only tasks with these statuses (scheduled, postponed, cancelled, missed)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Show at most this many tasks (0 for all)")
+	cmd.Flags().IntVar(&offset, "offset", 0, "Skip this many tasks, to page through results")
+	_ = cmd.RegisterFlagCompletionFunc("start", a.completeDates)
+	_ = cmd.RegisterFlagCompletionFunc("end", a.completeDates)
+	_ = cmd.RegisterFlagCompletionFunc("status", completeValues(string(task.StatusScheduled), string(task.StatusPostponed), string(task.StatusCancelled), string(task.StatusMissed)))
 
 	return cmd
 }
@@ -89,6 +141,8 @@ func statusSymbol(s task.Status) string {
 		return "✗"
 	case task.StatusPostponed:
 		return "→"
+	case task.StatusMissed:
+		return "!"
 	default:
 		return "?"
 	}
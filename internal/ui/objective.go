@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/nlp"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func (a *App) objectiveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "objective",
+		Short: "Manage quarterly objectives above tasks",
+		Long: `Keep quarterly objectives and attach tasks to them, so the time spent
+on each adds up across weeks. The planner is told how far along each
+objective of the current quarter is and balances new work across them.
+
+Examples:
+  sancho objective add "Ship v2" --target 40h
+  sancho objective attach 42 1
+  sancho objective list`,
+	}
+
+	cmd.AddCommand(a.objectiveAddCmd())
+	cmd.AddCommand(a.objectiveAttachCmd())
+	cmd.AddCommand(a.objectiveListCmd())
+	return cmd
+}
+
+func (a *App) objectiveAddCmd() *cobra.Command {
+	var quarter, target string
+
+	cmd := &cobra.Command{
+		Use:   "add [title]",
+		Short: "Add an objective for a quarter",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+			store, err := task.ObjectivesOf(a.repo)
+			if err != nil {
+				return err
+			}
+
+			o := &task.Objective{Title: args[0], Quarter: quarter}
+			if o.Quarter == "" {
+				o.Quarter = task.Quarter(a.clock.Now())
+			}
+			if target != "" {
+				minutes, ok := nlp.ParseDuration(target)
+				if !ok {
+					return fmt.Errorf("invalid --target %q: use a duration such as 40h", target)
+				}
+				o.TargetMinutes = minutes
+			}
+			if err := store.CreateObjective(context.Background(), o); err != nil {
+				return fmt.Errorf("adding objective: %w", err)
+			}
+
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, newJSONObjective(task.ObjectiveProgress{Objective: o}))
+			}
+			fmt.Printf("Added objective #%d for %s: %s\n", o.ID, o.Quarter, o.Title)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&quarter, "quarter", "", "Quarter of the objective, e.g. 2025-Q1 (default: the current one)")
+	cmd.Flags().StringVar(&target, "target", "", "Hours to spend on it over the quarter, e.g. 40h")
+	return cmd
+}
+
+func (a *App) objectiveAttachCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "attach [task-id] [objective-id]",
+		Short: "Attach a task to an objective (0 detaches it)",
+		Args:  cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return a.completeTaskIDs(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+			store, err := task.ObjectivesOf(a.repo)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid task ID: %w", err)
+			}
+			objectiveID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid objective ID: %w", err)
+			}
+
+			ctx := context.Background()
+			if err := store.AttachTask(ctx, id, objectiveID); err != nil {
+				return fmt.Errorf("attaching task: %w", err)
+			}
+
+			if a.jsonOutput {
+				return a.writeTaskJSON(ctx, id)
+			}
+			if objectiveID == 0 {
+				fmt.Printf("Detached task #%d\n", id)
+			} else {
+				fmt.Printf("Attached task #%d to objective #%d\n", id, objectiveID)
+			}
+			return nil
+		},
+	}
+}
+
+func (a *App) objectiveListCmd() *cobra.Command {
+	var quarter string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List a quarter's objectives with their scheduled and completed hours",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+
+			now := a.clock.Now()
+			if quarter == "" {
+				quarter = task.Quarter(now)
+			}
+			progress, err := task.QuarterProgress(context.Background(), a.repo, quarter, now)
+			if err != nil {
+				return fmt.Errorf("listing objectives: %w", err)
+			}
+
+			if a.jsonOutput {
+				out := make([]jsonObjective, len(progress))
+				for i, p := range progress {
+					out[i] = newJSONObjective(p)
+				}
+				return writeJSON(os.Stdout, map[string]any{"quarter": quarter, "objectives": out})
+			}
+			if len(progress) == 0 {
+				fmt.Printf("No objectives for %s.\n", quarter)
+				return nil
+			}
+			fmt.Printf("=== Objectives %s ===\n\n", quarter)
+			for _, p := range progress {
+				line := fmt.Sprintf("#%-4d %-30s %s done, %s scheduled", p.Objective.ID, truncateDescription(p.Objective.Title, 30),
+					FormatDuration(p.CompletedMinutes), FormatDuration(p.ScheduledMinutes))
+				if p.Objective.TargetMinutes > 0 {
+					line += fmt.Sprintf(" of %s (%d%%)", FormatDuration(p.Objective.TargetMinutes), p.Percent())
+				}
+				fmt.Println(line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&quarter, "quarter", "", "Quarter to list, e.g. 2025-Q1 (default: the current one)")
+	return cmd
+}
+
+// jsonObjective is an objective and its progress in --json output.
+type jsonObjective struct {
+	ID               int64  `json:"id"`
+	Title            string `json:"title"`
+	Quarter          string `json:"quarter"`
+	TargetMinutes    int    `json:"target_minutes"`
+	ScheduledMinutes int    `json:"scheduled_minutes"`
+	CompletedMinutes int    `json:"completed_minutes"`
+}
+
+func newJSONObjective(p task.ObjectiveProgress) jsonObjective {
+	return jsonObjective{
+		ID:               p.Objective.ID,
+		Title:            p.Objective.Title,
+		Quarter:          p.Objective.Quarter,
+		TargetMinutes:    p.Objective.TargetMinutes,
+		ScheduledMinutes: p.ScheduledMinutes,
+		CompletedMinutes: p.CompletedMinutes,
+	}
+}
@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/javiermolinar/sancho/internal/task"
+	"github.com/javiermolinar/sancho/internal/tui"
+)
+
+// urlHandlerFile is the desktop entry installed by 'sancho link --register'.
+const urlHandlerFile = "sancho-url-handler.desktop"
+
+func (a *App) openCmd() *cobra.Command {
+	var printOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "open [link|task-id]",
+		Short: "Open a task from a sancho:// link",
+		Long: `Open the TUI on the week of a task with its details shown.
+
+Accepts a deep link such as sancho://task/42 or a bare task ID. With
+--print, or when output is not a terminal, the task details are printed
+instead.
+
+Example:
+  sancho open sancho://task/42
+  sancho open 42 --print`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			id, err := task.ParseTaskURL(args[0])
+			if err != nil {
+				return err
+			}
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+
+			t, err := a.repo.GetTask(context.Background(), id)
+			if err != nil {
+				return fmt.Errorf("getting task: %w", err)
+			}
+			if t == nil {
+				return fmt.Errorf("task #%d: %w", id, task.ErrTaskNotFound)
+			}
+
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, struct {
+					Task jsonTask `json:"task"`
+				}{newJSONTask(t)})
+			}
+			if printOnly || !term.IsTerminal(int(os.Stdout.Fd())) {
+				printTaskDetail(os.Stdout, t)
+				return nil
+			}
+			return tui.RunWithDebug(a.repo, a.config, a.debug, tui.WithClock(a.clock), tui.WithFocusTask(t))
+		},
+	}
+
+	cmd.Flags().BoolVar(&printOnly, "print", false, "Print the task details instead of opening the TUI")
+	return cmd
+}
+
+func (a *App) linkCmd() *cobra.Command {
+	var register bool
+
+	cmd := &cobra.Command{
+		Use:   "link [task-id]",
+		Short: "Print the sancho:// link of a task",
+		Long: `Print a deep link to a task, for pasting into notes apps.
+
+With --register, install sancho as the handler for sancho:// links so
+clicking one runs 'sancho open'. Registration is supported on Linux
+desktops that follow the XDG conventions.
+
+Example:
+  sancho link 42
+  sancho link --register`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if register {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if register {
+				return registerURLHandler(os.Stdout)
+			}
+
+			id, err := task.ParseTaskURL(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid task ID: %w", err)
+			}
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+			t, err := a.repo.GetTask(context.Background(), id)
+			if err != nil {
+				return fmt.Errorf("getting task: %w", err)
+			}
+			if t == nil {
+				return fmt.Errorf("task #%d: %w", id, task.ErrTaskNotFound)
+			}
+
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, map[string]any{"id": t.ID, "url": task.TaskURL(t.ID)})
+			}
+			fmt.Println(task.TaskURL(t.ID))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&register, "register", false, "Register sancho as the sancho:// link handler")
+	return cmd
+}
+
+// printTaskDetail writes the details of a single task.
+func printTaskDetail(w io.Writer, t *task.Task) {
+	desc := t.Description
+	if t.Category == task.CategoryDeep {
+		desc = formatDeep(desc)
+	} else {
+		desc = formatShallow(desc)
+	}
+	_, _ = fmt.Fprintf(w, "#%d %s [%s]\n", t.ID, desc, t.Category)
+	_, _ = fmt.Fprintf(w, "  %s %s-%s (%s)\n", t.ScheduledDate.Format("Mon Jan 2 2006"),
+		t.ScheduledStart, t.ScheduledEnd, FormatDuration(t.Duration()))
+	_, _ = fmt.Fprintf(w, "  Status:  %s\n", t.Status)
+	if t.Energy != "" {
+		_, _ = fmt.Fprintf(w, "  Energy:  %s\n", t.Energy)
+	}
+	if t.Outcome != nil {
+		_, _ = fmt.Fprintf(w, "  Outcome: %s\n", *t.Outcome)
+	}
+	if !t.ExternalRef.IsZero() {
+		_, _ = fmt.Fprintf(w, "  Source:  %s\n", t.ExternalRef)
+	}
+	_, _ = fmt.Fprintf(w, "  Link:    %s\n", formatMuted(task.TaskURL(t.ID)))
+}
+
+// registerURLHandler installs a desktop entry that hands sancho:// links to
+// 'sancho open'.
+func registerURLHandler(w io.Writer) error {
+	if runtime.GOOS != "linux" {
+		return errors.New("link registration is only supported on Linux; configure your OS to run 'sancho open %u' for sancho:// links")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating sancho executable: %w", err)
+	}
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("locating home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	dir := filepath.Join(dataHome, "applications")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating applications directory: %w", err)
+	}
+
+	path := filepath.Join(dir, urlHandlerFile)
+	if err := os.WriteFile(path, []byte(urlHandlerEntry(exe)), 0o644); err != nil {
+		return fmt.Errorf("writing desktop entry: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(w, "Wrote %s\n", path)
+	_, _ = fmt.Fprintf(w, "To make it the default handler, run:\n  xdg-mime default %s x-scheme-handler/%s\n", urlHandlerFile, task.URLScheme)
+	return nil
+}
+
+// urlHandlerEntry returns the XDG desktop entry for the sancho:// handler.
+// The command runs in a terminal so 'sancho open' can start the TUI.
+func urlHandlerEntry(exe string) string {
+	var b strings.Builder
+	b.WriteString("[Desktop Entry]\n")
+	b.WriteString("Type=Application\n")
+	b.WriteString("Name=Sancho\n")
+	b.WriteString("Comment=Open sancho task links\n")
+	fmt.Fprintf(&b, "Exec=\"%s\" open %%u\n", exe)
+	b.WriteString("Terminal=true\n")
+	b.WriteString("NoDisplay=true\n")
+	fmt.Fprintf(&b, "MimeType=x-scheme-handler/%s;\n", task.URLScheme)
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestPrintTaskDetail(t *testing.T) {
+	DisableColor()
+	defer EnableColor()
+
+	outcome := task.OutcomeOver
+	tk := &task.Task{
+		ID:             42,
+		Description:    "Write report",
+		Category:       task.CategoryDeep,
+		ScheduledDate:  time.Date(2025, 1, 7, 0, 0, 0, 0, time.Local),
+		ScheduledStart: "10:00",
+		ScheduledEnd:   "11:30",
+		Status:         task.StatusScheduled,
+		Energy:         task.EnergyHigh,
+		Outcome:        &outcome,
+	}
+
+	var buf bytes.Buffer
+	printTaskDetail(&buf, tk)
+	out := buf.String()
+
+	for _, want := range []string{
+		"#42 Write report [deep]",
+		"Tue Jan 7 2025 10:00-11:30 (1h30m)",
+		"Energy:  high",
+		"Outcome: over",
+		"Link:    sancho://task/42",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestURLHandlerEntry(t *testing.T) {
+	entry := urlHandlerEntry("/usr/local/bin/sancho")
+	for _, want := range []string{
+		"[Desktop Entry]\n",
+		"Exec=\"/usr/local/bin/sancho\" open %u\n",
+		"MimeType=x-scheme-handler/sancho;\n",
+	} {
+		if !strings.Contains(entry, want) {
+			t.Errorf("entry missing %q:\n%s", want, entry)
+		}
+	}
+}
@@ -7,11 +7,14 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/javiermolinar/sancho/internal/nlp"
 	"github.com/javiermolinar/sancho/internal/task"
 )
 
 func (a *App) outcomeCmd() *cobra.Command {
-	return &cobra.Command{
+	var took string
+
+	cmd := &cobra.Command{
 		Use:   "outcome [task-id] [on_time|over|under]",
 		Short: "Set the outcome of a completed task",
 		Long: `Set how the task went during review.
@@ -21,9 +24,20 @@ Outcomes:
   over    - Task took longer than scheduled
   under   - Task was completed faster than scheduled
 
-Example:
-  sancho outcome 42 on_time`,
+With --took, the time the task really took is recorded too; on_time
+records the scheduled time. Tasks with a recorded time teach 'sancho add'
+how long similar tasks usually take.
+
+Examples:
+  sancho outcome 42 on_time
+  sancho outcome 42 over --took 1h30m`,
 		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return []string{string(task.OutcomeOnTime), string(task.OutcomeOver), string(task.OutcomeUnder)}, cobra.ShellCompDirectiveNoFileComp
+			}
+			return a.completeTaskIDs(cmd, args, toComplete)
+		},
 		RunE: func(_ *cobra.Command, args []string) error {
 			if err := a.ensureRepo(); err != nil {
 				return err
@@ -38,14 +52,40 @@ Example:
 			if !outcome.Valid() {
 				return fmt.Errorf("invalid outcome %q: must be on_time, over, or under", args[1])
 			}
+			actual := 0
+			if took != "" {
+				minutes, ok := nlp.ParseDuration(took)
+				if !ok {
+					return fmt.Errorf("invalid duration %q: use e.g. 45m or 1h30m", took)
+				}
+				actual = minutes
+			}
 
 			ctx := context.Background()
-			if err := a.repo.SetTaskOutcome(ctx, id, outcome); err != nil {
+			if err := a.repo.SetTaskOutcome(ctx, id, outcome, 0); err != nil {
 				return fmt.Errorf("setting outcome: %w", err)
 			}
+			t, err := a.repo.GetTask(ctx, id)
+			if err != nil {
+				return fmt.Errorf("getting task: %w", err)
+			}
+			if t != nil {
+				if actual == 0 && outcome == task.OutcomeOnTime {
+					actual = t.Duration()
+				}
+				if err := task.RecordActual(ctx, a.repo, t, actual); err != nil {
+					return err
+				}
+			}
 
+			if a.jsonOutput {
+				return a.writeTaskJSON(ctx, id)
+			}
 			fmt.Printf("Set outcome for task #%d: %s\n", id, outcome)
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&took, "took", "", "How long the task really took, e.g. 1h30m")
+	return cmd
 }
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func (a *App) parkCmd() *cobra.Command {
+	var date string
+
+	cmd := &cobra.Command{
+		Use:   "park [title]",
+		Short: "Commit a task to a day without giving it a time",
+		Long: `Park a task on a day. Parked tasks show in a strip under the day's
+column in the TUI, where b places one at the cursor, and the AI planner
+schedules them on their day.
+
+Example:
+  sancho park "Call the bank" --date 2025-01-10`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+			day := dateutil.TruncateToDay(a.clock.Now())
+			if date != "" {
+				var err error
+				if day, err = dateutil.ParseDate(date); err != nil {
+					return err
+				}
+			}
+			backlog, err := task.BacklogOf(a.repo)
+			if err != nil {
+				return err
+			}
+
+			item := &task.BacklogItem{Title: strings.Join(args, " "), Day: day}
+			if _, err := backlog.UpsertBacklogItem(context.Background(), item); err != nil {
+				return fmt.Errorf("parking task: %w", err)
+			}
+
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, struct {
+					ID    int64  `json:"id"`
+					Title string `json:"title"`
+					Day   string `json:"day"`
+				}{item.ID, item.Title, day.Format("2006-01-02")})
+			}
+			fmt.Printf("Parked #%d on %s: %s\n", item.ID, day.Format("Mon Jan 2"), item.Title)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&date, "date", "", "Day to park the task on (YYYY-MM-DD, default: today)")
+	_ = cmd.RegisterFlagCompletionFunc("date", a.completeDates)
+	return cmd
+}
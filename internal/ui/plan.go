@@ -12,6 +12,7 @@ import (
 
 	"github.com/javiermolinar/sancho/internal/dwplanner"
 	"github.com/javiermolinar/sancho/internal/llm"
+	"github.com/javiermolinar/sancho/internal/task"
 )
 
 const maxRetries = 3
@@ -41,7 +42,10 @@ Interactive mode:
   After the AI proposes a schedule, you can:
   - [a]ccept: Save the tasks to your schedule
   - [m]odify: Provide feedback to adjust the proposal
-  - [c]ancel: Exit without saving`,
+  - [c]ancel: Exit without saving
+
+With --json, the proposal is printed as JSON without prompting and is not
+saved.`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := a.ensureRepo(); err != nil {
@@ -51,12 +55,12 @@ Interactive mode:
 			input := strings.Join(args, " ")
 
 			// Use config default for model if not overridden
+			defaultModel, baseURL := a.config.LLM.Endpoint()
 			model := modelFlag
 			if model == "" {
-				model = a.config.LLM.Model
+				model = defaultModel
 			}
 			provider := a.config.LLM.Provider
-			baseURL := a.config.LLM.BaseURL
 
 			// Create LLM client
 			client, err := llm.NewClient(provider, model, baseURL)
@@ -66,9 +70,12 @@ Interactive mode:
 
 			// Create planner
 			p := dwplanner.New(client, a.config, a.repo)
+			p.SetClock(a.clock)
 
 			// Initial planning
-			fmt.Println("Planning tasks...")
+			if !a.jsonOutput {
+				fmt.Println("Planning tasks...")
+			}
 			result, err := p.PlanWithRetry(context.Background(), dwplanner.PlanRequest{
 				Input: input,
 			}, maxRetries)
@@ -76,6 +83,12 @@ Interactive mode:
 				return fmt.Errorf("planning: %w", err)
 			}
 
+			// JSON output is for scripts, so the proposal is printed
+			// without prompting and nothing is saved.
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, newJSONPlan(result))
+			}
+
 			// Interactive loop
 			reader := bufio.NewReader(os.Stdin)
 			for {
@@ -90,6 +103,16 @@ Interactive mode:
 					}
 				}
 
+				if len(result.Dropped) > 0 {
+					fmt.Println("\nDropped from the LLM response:")
+					for _, d := range result.Dropped {
+						fmt.Printf("  - %s\n", d)
+						if d.Entry != "" {
+							fmt.Printf("    %s\n", d.Entry)
+						}
+					}
+				}
+
 				// If dry run, show and exit
 				if dryRun {
 					fmt.Println("\n(Dry run - tasks not saved)")
@@ -97,7 +120,11 @@ Interactive mode:
 				}
 
 				// Prompt for action
-				fmt.Print("\n[a]ccept / [m]odify / [c]ancel: ")
+				if len(result.Dropped) > 0 {
+					fmt.Print("\n[a]ccept / [f]ix output / [m]odify / [c]ancel: ")
+				} else {
+					fmt.Print("\n[a]ccept / [m]odify / [c]ancel: ")
+				}
 				choice, err := reader.ReadString('\n')
 				if err != nil {
 					return fmt.Errorf("reading input: %w", err)
@@ -138,6 +165,17 @@ Interactive mode:
 					}
 					// Loop back to display new result
 
+				case "f", "fix":
+					if len(result.Dropped) == 0 {
+						fmt.Println("Nothing was dropped from the plan.")
+						continue
+					}
+					fmt.Println("\nAsking the model to fix its output...")
+					result, err = p.FixDropped(context.Background(), maxRetries)
+					if err != nil {
+						return fmt.Errorf("fixing plan: %w", err)
+					}
+
 				case "c", "cancel":
 					fmt.Println("Planning cancelled.")
 					return nil
@@ -190,21 +228,29 @@ func (a *App) displayPlanResult(result *dwplanner.PlanResult) {
 		return
 	}
 
-	for _, dateStr := range result.SortedDates {
-		tasks := result.TasksByDate[dateStr]
-		if len(tasks) == 0 {
-			continue
-		}
+	// Show the plan as a diff against what is already on each day
+	before, after, err := a.planDiffTasks(context.Background(), result)
+	if err == nil {
+		fmt.Println()
+		fmt.Println(strings.Repeat("-", 60))
+		printDayDiff(os.Stdout, before, after)
+	} else {
+		for _, dateStr := range result.SortedDates {
+			tasks := result.TasksByDate[dateStr]
+			if len(tasks) == 0 {
+				continue
+			}
 
-		// Parse date for display
-		date, err := time.Parse("2006-01-02", dateStr)
-		if err != nil {
-			fmt.Printf("\n%s:\n", dateStr)
-		} else {
-			fmt.Printf("\n%s:\n", date.Format("Monday, January 2"))
+			// Parse date for display
+			date, err := time.Parse("2006-01-02", dateStr)
+			if err != nil {
+				fmt.Printf("\n%s:\n", dateStr)
+			} else {
+				fmt.Printf("\n%s:\n", date.Format("Monday, January 2"))
+			}
+			fmt.Println(strings.Repeat("-", 60))
+			displayTasks(tasks)
 		}
-		fmt.Println(strings.Repeat("-", 60))
-		displayTasks(tasks)
 	}
 
 	fmt.Println(strings.Repeat("-", 60))
@@ -215,12 +261,37 @@ func (a *App) displayPlanResult(result *dwplanner.PlanResult) {
 	fmt.Println()
 }
 
+// planDiffTasks returns the existing tasks on each planned day and the same
+// tasks with the planned ones added.
+func (a *App) planDiffTasks(ctx context.Context, result *dwplanner.PlanResult) (before, after []*task.Task, err error) {
+	for _, dateStr := range result.SortedDates {
+		date, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing date %q: %w", dateStr, err)
+		}
+		existing, err := a.repo.ListTasksByDateRange(ctx, date, date)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing tasks: %w", err)
+		}
+		before = append(before, existing...)
+		after = append(after, existing...)
+		for _, pt := range result.TasksByDate[dateStr] {
+			after = append(after, &task.Task{
+				Description:    pt.Description,
+				Category:       task.Category(pt.Category),
+				ScheduledDate:  date,
+				ScheduledStart: pt.ScheduledStart,
+				ScheduledEnd:   pt.ScheduledEnd,
+				Status:         task.StatusScheduled,
+			})
+		}
+	}
+	return before, after, nil
+}
+
 func displayTasks(tasks []dwplanner.PlannedTask) {
 	for _, t := range tasks {
-		categoryIcon := "[D]" // deep
-		if t.Category == "shallow" {
-			categoryIcon = "[S]"
-		}
+		categoryIcon := "[" + task.CategoryOrDeep(t.Category).Code() + "]"
 		fmt.Printf("  %s %s-%s  %s\n",
 			categoryIcon,
 			t.ScheduledStart,
@@ -229,3 +300,62 @@ func displayTasks(tasks []dwplanner.PlannedTask) {
 		)
 	}
 }
+
+// jsonPlan is the output of 'sancho plan --json'.
+type jsonPlan struct {
+	Date             string            `json:"date"` // The day the plan was made for
+	AvailableStart   string            `json:"available_start"`
+	AvailableEnd     string            `json:"available_end"`
+	AvailableMinutes int               `json:"available_minutes"`
+	NonWorkday       bool              `json:"non_workday"`
+	Tasks            []jsonPlannedTask `json:"tasks"`
+	Warnings         []string          `json:"warnings"`
+	Suggestions      []string          `json:"suggestions"`
+	Errors           []string          `json:"errors"`  // Validation errors left after the retries
+	Dropped          []string          `json:"dropped"` // Entries of the LLM response that were left out
+}
+
+type jsonPlannedTask struct {
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	Date        string   `json:"date"`
+	Start       string   `json:"start"`
+	End         string   `json:"end"`
+	Energy      string   `json:"energy,omitempty"`
+	Issues      []string `json:"issues,omitempty"`
+}
+
+func newJSONPlan(r *dwplanner.PlanResult) jsonPlan {
+	out := jsonPlan{
+		Date:             r.TodayDate.Format("2006-01-02"),
+		AvailableStart:   r.EffectiveStart,
+		AvailableEnd:     r.EffectiveEnd,
+		AvailableMinutes: r.AvailableMinutes,
+		NonWorkday:       r.IsNonWorkday,
+		Tasks:            []jsonPlannedTask{},
+		Warnings:         append([]string{}, r.Warnings...),
+		Suggestions:      append([]string{}, r.Suggestions...),
+		Errors:           []string{},
+		Dropped:          []string{},
+	}
+	for _, date := range r.SortedDates {
+		for _, t := range r.TasksByDate[date] {
+			out.Tasks = append(out.Tasks, jsonPlannedTask{
+				Description: t.Description,
+				Category:    string(task.CategoryOrDeep(t.Category)),
+				Date:        date,
+				Start:       t.ScheduledStart,
+				End:         t.ScheduledEnd,
+				Energy:      t.Energy,
+				Issues:      t.Issues,
+			})
+		}
+	}
+	for _, ve := range r.ValidationErrors {
+		out.Errors = append(out.Errors, ve.Message)
+	}
+	for _, d := range r.Dropped {
+		out.Dropped = append(out.Dropped, d.String())
+	}
+	return out
+}
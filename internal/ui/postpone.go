@@ -3,6 +3,7 @@ package ui
 import (
 	"context"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -27,7 +28,8 @@ with the same description and category at the specified date/time.
 The new task will have a reference to the original task.`,
 		Example: `  sancho postpone 123 --date=2025-01-16 --start=14:00 --end=16:00
   sancho postpone 123 --start=09:00 --end=11:00  # defaults to today`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
 		RunE: func(_ *cobra.Command, args []string) error {
 			if err := a.ensureRepo(); err != nil {
 				return err
@@ -38,9 +40,11 @@ The new task will have a reference to the original task.`,
 				return fmt.Errorf("invalid task ID: %w", err)
 			}
 
-			newDate, err := dateutil.ParseDate(date)
-			if err != nil {
-				return fmt.Errorf("invalid date: %w", err)
+			newDate := dateutil.TruncateToDay(a.clock.Now())
+			if date != "" {
+				if newDate, err = dateutil.ParseDate(date); err != nil {
+					return fmt.Errorf("invalid date: %w", err)
+				}
 			}
 
 			// Validate time format
@@ -48,11 +52,17 @@ The new task will have a reference to the original task.`,
 				return fmt.Errorf("--start and --end are required")
 			}
 
-			newTask, err := a.repo.PostponeTask(context.Background(), taskID, newDate, start, end)
+			newTask, err := a.repo.PostponeTask(context.Background(), taskID, newDate, start, end, 0)
 			if err != nil {
 				return err
 			}
 
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, struct {
+					PostponedFrom int64    `json:"postponed_from"`
+					Task          jsonTask `json:"task"`
+				}{taskID, newJSONTask(newTask)})
+			}
 			fmt.Printf("Postponed task #%d → #%d: %s [%s] %s %s-%s\n",
 				taskID,
 				newTask.ID,
@@ -72,6 +82,7 @@ The new task will have a reference to the original task.`,
 	cmd.Flags().StringVar(&end, "end", "", "New end time (HH:MM, required)")
 
 	_ = cmd.MarkFlagRequired("start")
+	_ = cmd.RegisterFlagCompletionFunc("date", a.completeDates)
 	_ = cmd.MarkFlagRequired("end")
 
 	return cmd
@@ -3,7 +3,7 @@ package ui
 import (
 	"context"
 	"fmt"
-	"time"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -31,20 +31,13 @@ weekly stats and insights.`,
 			}
 
 			ctx := context.Background()
-			today := dateutil.TruncateToDay(time.Now())
+			today := dateutil.TruncateToDay(a.clock.Now())
 
 			tasks, err := a.repo.ListTasksByDateRange(ctx, today, today)
 			if err != nil {
 				return fmt.Errorf("fetching tasks: %w", err)
 			}
 
-			if len(tasks) == 0 {
-				fmt.Println("No time blocks scheduled for today.")
-				return nil
-			}
-
-			fmt.Printf("=== %s ===\n\n", formatHeader(today.Format("Monday, January 2, 2006")))
-
 			// Configure print options
 			opts := PrintOpts{
 				PeakStart: a.config.Schedule.PeakHoursStart,
@@ -52,11 +45,30 @@ weekly stats and insights.`,
 				Verbose:   verbose,
 				ShowPeak:  a.config.HasPeakHours(),
 			}
+			dayKey := today.Format("Mon Jan 2")
+
+			if a.jsonOutput {
+				var stats Stats
+				for _, t := range tasks {
+					AccumulateStats(&stats, t, dayKey, opts)
+				}
+				return writeJSON(os.Stdout, struct {
+					Date  string     `json:"date"`
+					Tasks []jsonTask `json:"tasks"`
+					Stats jsonStats  `json:"stats"`
+				}{today.Format("2006-01-02"), newJSONTasks(tasks), newJSONDayStats(stats)})
+			}
+
+			if len(tasks) == 0 {
+				fmt.Println("No time blocks scheduled for today.")
+				return nil
+			}
+
+			fmt.Printf("=== %s ===\n\n", formatHeader(today.Format("Monday, January 2, 2006")))
 			maxDescWidth := opts.CalcMaxDescWidth(50)
 
 			// Print tasks and accumulate stats
 			var stats Stats
-			dayKey := today.Format("Mon Jan 2")
 			for _, t := range tasks {
 				PrintTaskRow(t, opts, maxDescWidth)
 				AccumulateStats(&stats, t, dayKey, opts)
@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/dateutil"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// statusShortWidth is the longest task description printed by --short.
+const statusShortWidth = 30
+
+// scheduleStatus is the block running now and the next one today.
+type scheduleStatus struct {
+	Current *task.Task
+	Left    int // Minutes until Current ends
+	Next    *task.Task
+	Until   int // Minutes until Next starts
+}
+
+func (a *App) statusCmd() *cobra.Command {
+	var short bool
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the current and next task",
+		Long: `Show the block running now and the next one today.
+
+With --short, print one line such as "Write report 35m left · next
+Standup in 1h10m", or nothing when no block is left today. It is cheap
+enough to run from a tmux status line or a shell prompt.
+
+Example:
+  sancho status
+  sancho status --short
+
+  # ~/.tmux.conf
+  set -g status-right '#(sancho status --short)'
+  set -g status-interval 60`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+			now := a.clock.Now()
+			today := dateutil.TruncateToDay(now)
+			// Yesterday is read too for an overnight block still running.
+			tasks, err := a.repo.ListTasksByDateRange(context.Background(), today.AddDate(0, 0, -1), today)
+			if err != nil {
+				return fmt.Errorf("fetching tasks: %w", err)
+			}
+			s := statusAt(tasks, now, a.config.LocationFor)
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, newJSONStatus(s))
+			}
+			if short {
+				if line := shortStatus(s); line != "" {
+					fmt.Println(line)
+				}
+				return nil
+			}
+			printStatus(os.Stdout, s)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&short, "short", false, "Print a single line for status bars and prompts")
+	return cmd
+}
+
+// statusAt finds the block running at now and the next block starting
+// later that day. Only scheduled blocks count. Each block's times are read
+// in the timezone locationFor returns for its day.
+func statusAt(tasks []*task.Task, now time.Time, locationFor func(time.Time) *time.Location) scheduleStatus {
+	var s scheduleStatus
+	var currentStart time.Time
+	for _, t := range tasks {
+		if !t.IsScheduled() {
+			continue
+		}
+		loc := locationFor(t.ScheduledDate)
+		startMins, endMins := task.Span(t.ScheduledStart, t.ScheduledEnd)
+		day := time.Date(t.ScheduledDate.Year(), t.ScheduledDate.Month(), t.ScheduledDate.Day(), 0, 0, 0, 0, loc)
+		start := day.Add(time.Duration(startMins) * time.Minute)
+		end := day.Add(time.Duration(endMins) * time.Minute)
+
+		switch {
+		case !now.Before(start) && now.Before(end):
+			// With overlapping blocks, the one that started last wins.
+			if s.Current == nil || start.After(currentStart) {
+				s.Current, s.Left, currentStart = t, minutesUntil(now, end), start
+			}
+		case start.After(now) && dateutil.TruncateToDay(start.In(now.Location())).Equal(dateutil.TruncateToDay(now)):
+			if until := minutesUntil(now, start); s.Next == nil || until < s.Until {
+				s.Next, s.Until = t, until
+			}
+		}
+	}
+	return s
+}
+
+// minutesUntil returns the whole minutes from now to then, rounding up so a
+// block ending in 30 seconds still shows 1m.
+func minutesUntil(now, then time.Time) int {
+	return int((then.Sub(now) + time.Minute - 1) / time.Minute)
+}
+
+// jsonStatus is the output of 'sancho status --json'.
+type jsonStatus struct {
+	Current      *jsonTask `json:"current"`
+	MinutesLeft  int       `json:"minutes_left,omitempty"`
+	Next         *jsonTask `json:"next"`
+	MinutesUntil int       `json:"minutes_until,omitempty"`
+}
+
+func newJSONStatus(s scheduleStatus) jsonStatus {
+	var out jsonStatus
+	if s.Current != nil {
+		current := newJSONTask(s.Current)
+		out.Current, out.MinutesLeft = &current, s.Left
+	}
+	if s.Next != nil {
+		next := newJSONTask(s.Next)
+		out.Next, out.MinutesUntil = &next, s.Until
+	}
+	return out
+}
+
+// truncateDescription cuts s to n characters, ending in "…" when cut.
+func truncateDescription(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// shortStatus formats s as one line, or "" when nothing is left today.
+func shortStatus(s scheduleStatus) string {
+	var line string
+	if s.Current != nil {
+		line = fmt.Sprintf("%s %s left", truncateDescription(s.Current.Description, statusShortWidth), FormatDuration(s.Left))
+	}
+	if s.Next != nil {
+		next := fmt.Sprintf("next %s in %s", truncateDescription(s.Next.Description, statusShortWidth), FormatDuration(s.Until))
+		if line == "" {
+			return next
+		}
+		line += " · " + next
+	}
+	return line
+}
+
+func printStatus(w io.Writer, s scheduleStatus) {
+	if s.Current == nil && s.Next == nil {
+		_, _ = fmt.Fprintln(w, "Nothing left on today's schedule")
+		return
+	}
+	if s.Current != nil {
+		t := s.Current
+		_, _ = fmt.Fprintf(w, "Now:  #%d %s [%s] %s-%s, %s left\n",
+			t.ID, t.Description, t.Category, t.ScheduledStart, t.ScheduledEnd, FormatDuration(s.Left))
+	}
+	if s.Next != nil {
+		t := s.Next
+		_, _ = fmt.Fprintf(w, "Next: #%d %s [%s] %s-%s, in %s\n",
+			t.ID, t.Description, t.Category, t.ScheduledStart, t.ScheduledEnd, FormatDuration(s.Until))
+	}
+}
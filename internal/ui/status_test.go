@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestStatusAt(t *testing.T) {
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	local := func(time.Time) *time.Location { return time.Local }
+	block := func(id int64, desc string, day time.Time, start, end string, status task.Status) *task.Task {
+		return &task.Task{ID: id, Description: desc, ScheduledDate: day, ScheduledStart: start, ScheduledEnd: end, Status: status}
+	}
+	tasks := []*task.Task{
+		block(1, "Late deploy", today.AddDate(0, 0, -1), "23:00", "01:00", task.StatusScheduled),
+		block(2, "Write report", today, "09:00", "11:00", task.StatusScheduled),
+		block(3, "Cancelled sync", today, "11:00", "11:30", task.StatusCancelled),
+		block(4, "Standup", today, "12:10", "12:25", task.StatusScheduled),
+		block(5, "Review", today, "15:00", "16:00", task.StatusScheduled),
+	}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		wantCurrent int64
+		wantLeft    int
+		wantNext    int64
+		wantUntil   int
+		wantShort   string
+	}{
+		{
+			name:        "during a block",
+			now:         today.Add(10*time.Hour + 25*time.Minute),
+			wantCurrent: 2, wantLeft: 35,
+			wantNext: 4, wantUntil: 105,
+			wantShort: "Write report 35m left · next Standup in 1h45m",
+		},
+		{
+			name:     "between blocks",
+			now:      today.Add(11*time.Hour + 15*time.Minute),
+			wantNext: 4, wantUntil: 55,
+			wantShort: "next Standup in 55m",
+		},
+		{
+			name:        "overnight block from yesterday",
+			now:         today.Add(30 * time.Minute),
+			wantCurrent: 1, wantLeft: 30,
+			wantNext: 2, wantUntil: 510,
+			wantShort: "Late deploy 30m left · next Write report in 8h30m",
+		},
+		{
+			name:      "nothing left",
+			now:       today.Add(17 * time.Hour),
+			wantShort: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := statusAt(tasks, tt.now, local)
+			if id := taskID(s.Current); id != tt.wantCurrent || (s.Current != nil && s.Left != tt.wantLeft) {
+				t.Errorf("current = #%d with %dm left, want #%d with %dm", id, s.Left, tt.wantCurrent, tt.wantLeft)
+			}
+			if id := taskID(s.Next); id != tt.wantNext || (s.Next != nil && s.Until != tt.wantUntil) {
+				t.Errorf("next = #%d in %dm, want #%d in %dm", id, s.Until, tt.wantNext, tt.wantUntil)
+			}
+			if got := shortStatus(s); got != tt.wantShort {
+				t.Errorf("shortStatus = %q, want %q", got, tt.wantShort)
+			}
+		})
+	}
+}
+
+func taskID(t *task.Task) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.ID
+}
@@ -26,6 +26,10 @@ var (
 
 	// Muted: for secondary information
 	colorMuted = color.New(color.FgWhite, color.Faint)
+
+	// Diff lines: green for additions, red for removals
+	colorAdded   = color.New(color.FgGreen)
+	colorRemoved = color.New(color.FgRed)
 )
 
 // termWidth returns the terminal width, or a default if detection fails.
@@ -76,3 +80,13 @@ func formatStats(s string) string {
 func formatMuted(s string) string {
 	return colorMuted.Sprint(s)
 }
+
+// formatAdded formats an added diff line.
+func formatAdded(s string) string {
+	return colorAdded.Sprint(s)
+}
+
+// formatRemoved formats a removed diff line.
+func formatRemoved(s string) string {
+	return colorRemoved.Sprint(s)
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/config"
+	"github.com/javiermolinar/sancho/internal/dateutil"
+)
+
+func (a *App) timezoneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tz",
+		Short: "Pin days or weeks to another timezone",
+		Long: `Pin a day or week to a different timezone, e.g. for a conference abroad.
+
+Tasks on pinned days are displayed and validated against that zone while
+the rest of the schedule stays local.
+
+Examples:
+  sancho tz pin 2026-03-02 Europe/Berlin --week
+  sancho tz pin 2026-03-02 America/New_York --until 2026-03-04
+  sancho tz unpin 2026-03-02
+  sancho tz list`,
+	}
+
+	cmd.AddCommand(a.timezonePinCmd())
+	cmd.AddCommand(a.timezoneUnpinCmd())
+	cmd.AddCommand(a.timezoneListCmd())
+	return cmd
+}
+
+func (a *App) timezonePinCmd() *cobra.Command {
+	var (
+		week  bool
+		until string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pin [date] [zone]",
+		Short: "Pin a date range to a timezone",
+		Args:  cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return a.completeDates(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			start, err := dateutil.ParseDate(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid date: %w", err)
+			}
+			end := start
+			switch {
+			case week:
+				start, end = dateutil.WeekRange(start)
+			case until != "":
+				end, err = dateutil.ParseDate(until)
+				if err != nil {
+					return fmt.Errorf("invalid --until date: %w", err)
+				}
+			}
+
+			return updateConfigFile(func(cfg *config.Config) error {
+				if err := cfg.PinTimezone(start, end, args[1]); err != nil {
+					return err
+				}
+				if a.jsonOutput {
+					return writeJSON(os.Stdout, jsonPin{start.Format("2006-01-02"), end.Format("2006-01-02"), args[1]})
+				}
+				fmt.Printf("Pinned %s..%s to %s\n", start.Format("2006-01-02"), end.Format("2006-01-02"), args[1])
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&week, "week", false, "Pin the whole week containing the date")
+	cmd.Flags().StringVar(&until, "until", "", "Last pinned date (YYYY-MM-DD, inclusive)")
+	_ = cmd.RegisterFlagCompletionFunc("until", a.completeDates)
+	return cmd
+}
+
+func (a *App) timezoneUnpinCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unpin [date]",
+		Short: "Remove timezone pins covering a date",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return a.completeDates(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			date, err := dateutil.ParseDate(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid date: %w", err)
+			}
+
+			return updateConfigFile(func(cfg *config.Config) error {
+				removed := cfg.UnpinTimezone(date)
+				if removed == 0 {
+					return fmt.Errorf("no timezone pin covers %s", args[0])
+				}
+				if a.jsonOutput {
+					return writeJSON(os.Stdout, map[string]int{"removed": removed})
+				}
+				fmt.Printf("Removed %d timezone pin(s)\n", removed)
+				return nil
+			})
+		},
+	}
+}
+
+func (a *App) timezoneListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List timezone pins",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			pins := a.config.Schedule.TimezonePins
+			if a.jsonOutput {
+				out := make([]jsonPin, 0, len(pins))
+				for _, p := range pins {
+					out = append(out, jsonPin{p.Start, p.End, p.Zone})
+				}
+				return writeJSON(os.Stdout, map[string][]jsonPin{"pins": out})
+			}
+			if len(pins) == 0 {
+				fmt.Println("No timezone pins.")
+				return nil
+			}
+			for _, p := range pins {
+				fmt.Printf("%s..%s  %s\n", p.Start, p.End, p.Zone)
+			}
+			return nil
+		},
+	}
+}
+
+// jsonPin is a timezone pin in --json output.
+type jsonPin struct {
+	Start string `json:"start"` // YYYY-MM-DD
+	End   string `json:"end"`   // YYYY-MM-DD, inclusive
+	Zone  string `json:"zone"`
+}
+
+// updateConfigFile loads the config file, applies fn, and saves it back.
+func updateConfigFile(fn func(cfg *config.Config) error) error {
+	configPath := config.DefaultConfigPath()
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := fn(cfg); err != nil {
+		return err
+	}
+	if err := cfg.SaveTo(configPath); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	return nil
+}
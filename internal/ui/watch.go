@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/javiermolinar/sancho/internal/watch"
+)
+
+// watchDefaultInterval matches how often the TUI checks for outside changes.
+const watchDefaultInterval = 2 * time.Second
+
+func (a *App) watchCmd() *cobra.Command {
+	var (
+		format   string
+		interval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Stream task changes as they happen",
+		Long: `Print an event whenever a task is created, updated or deleted, including
+edits made in the TUI or by another sancho command, until interrupted.
+
+With --format json (or the global --json), each event is one JSON object
+per line, for piping into jq or a script:
+
+  {"event":"updated","at":"2026-03-02T10:15:02+01:00","fields":["status"],
+   "task":{...},"previous":{...}}
+
+The database is polled every --interval; changes made between two polls
+are reported together.
+
+Example:
+  sancho watch
+  sancho watch --format json | jq -r 'select(.event == "created") | .task.description'`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if a.jsonOutput {
+				format = "json"
+			}
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q: use text or json", format)
+			}
+			if interval <= 0 {
+				return fmt.Errorf("invalid --interval %s: must be positive", interval)
+			}
+			if err := a.ensureRepo(); err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			poller, err := watch.NewPoller(ctx, a.repo)
+			if err != nil {
+				return fmt.Errorf("reading tasks: %w", err)
+			}
+			return poller.Run(ctx, interval, func(changes []watch.Change) error {
+				return writeChanges(os.Stdout, format, a.clock.Now(), changes)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.Flags().DurationVar(&interval, "interval", watchDefaultInterval, "How often to check for changes")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("text", "json"))
+	return cmd
+}
+
+// jsonChange is one event of 'sancho watch --format json'.
+type jsonChange struct {
+	Event    string    `json:"event"` // created, updated or deleted
+	At       string    `json:"at"`    // RFC 3339 time the change was seen
+	Fields   []string  `json:"fields,omitempty"`
+	Task     jsonTask  `json:"task"`
+	Previous *jsonTask `json:"previous,omitempty"`
+}
+
+func newJSONChange(c watch.Change, at time.Time) jsonChange {
+	jc := jsonChange{
+		Event:  string(c.Kind),
+		At:     at.Format(time.RFC3339),
+		Fields: c.Fields,
+		Task:   newJSONTask(c.Task),
+	}
+	if c.Previous != nil {
+		prev := newJSONTask(c.Previous)
+		jc.Previous = &prev
+	}
+	return jc
+}
+
+// writeChanges writes changes as JSON lines or as one line of text each.
+func writeChanges(w io.Writer, format string, at time.Time, changes []watch.Change) error {
+	for _, c := range changes {
+		if format == "json" {
+			if err := writeJSONLine(w, newJSONChange(c, at)); err != nil {
+				return err
+			}
+			continue
+		}
+		t := c.Task
+		line := fmt.Sprintf("%s %-7s #%d %s (%s %s-%s)", at.Format("15:04:05"), c.Kind, t.ID,
+			t.Description, t.ScheduledDate.Format("Mon Jan 2"), t.ScheduledStart, t.ScheduledEnd)
+		if len(c.Fields) > 0 {
+			line += " " + strings.Join(c.Fields, ", ")
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("writing event: %w", err)
+		}
+	}
+	return nil
+}
@@ -3,8 +3,8 @@ package ui
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
@@ -35,23 +35,30 @@ calculates deep/shallow work stats, and optionally provides LLM coaching.`,
 			}
 
 			ctx := context.Background()
+			defaultModel, baseURL := a.config.LLM.Endpoint()
 			if model == "" {
-				model = a.config.LLM.Model
+				model = defaultModel
 			}
 
 			weekSummary, err := summary.BuildWeekSummary(ctx, a.repo, summary.BuildWeekSummaryOptions{
-				WeekStart:      time.Now(),
+				WeekStart:      a.clock.Now(),
 				PeakStart:      a.config.Schedule.PeakHoursStart,
 				PeakEnd:        a.config.Schedule.PeakHoursEnd,
+				Goals:          summary.GoalTargets{DeepMinutes: a.config.Goals.DeepMinutes(), TotalMinutes: a.config.Goals.TotalMinutes()},
+				Now:            a.clock.Now(),
 				IncludeInsight: !noInsight,
 				Provider:       a.config.LLM.Provider,
 				Model:          model,
-				BaseURL:        a.config.LLM.BaseURL,
+				BaseURL:        baseURL,
 			})
 			if err != nil {
 				return fmt.Errorf("building week summary: %w", err)
 			}
 
+			if a.jsonOutput {
+				return writeJSON(os.Stdout, newJSONWeek(weekSummary))
+			}
+
 			if len(weekSummary.Tasks) == 0 {
 				fmt.Println("No time blocks scheduled for this week.")
 				return nil
@@ -78,11 +85,31 @@ calculates deep/shallow work stats, and optionally provides LLM coaching.`,
 			// Print stats
 			fmt.Println(strings.Repeat("─", 74))
 			PrintStatsExtended(weekSummary.Stats, a.config.HasPeakHours())
+			if delta, ok := weekSummary.Delta(); ok {
+				fmt.Printf("  %s\n", formatMuted("vs last week: "+delta.String()))
+			}
 
 			// Show flow bar
 			if weekSummary.Stats.TotalMinutes() > 0 {
 				fmt.Printf("  Flow: %s\n", FlowBar(weekSummary.Stats.DeepMinutes, weekSummary.Stats.TotalMinutes(), 20))
 			}
+			for _, g := range weekSummary.Goals {
+				fmt.Printf("  %-6s %s\n", g.Label+":", GoalBar(g, 20))
+			}
+			for _, g := range weekSummary.Budgets {
+				line := GoalBar(g, 20)
+				if g.Over() {
+					line += " " + formatRemoved("over budget")
+				}
+				fmt.Printf("  %s %s\n", g.Label+":", line)
+			}
+			if len(weekSummary.Tickets) > 0 {
+				parts := make([]string, len(weekSummary.Tickets))
+				for i, tt := range weekSummary.Tickets {
+					parts[i] = tt.Key + " " + FormatDuration(tt.Minutes)
+				}
+				fmt.Printf("  Tickets: %s\n", strings.Join(parts, ", "))
+			}
 
 			// Get LLM insight if not disabled
 			if !noInsight && weekSummary.Insight != "" {
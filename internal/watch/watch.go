@@ -0,0 +1,153 @@
+// Package watch reports changes to the tasks in a repository, including
+// changes made by another process such as the TUI, by polling it.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+// Kind is what happened to a task.
+type Kind string
+
+// Change kinds.
+const (
+	Created Kind = "created"
+	Updated Kind = "updated"
+	Deleted Kind = "deleted"
+)
+
+// Change is one task that was created, updated or deleted between two polls.
+type Change struct {
+	Kind     Kind
+	Task     *task.Task // The task as it is now; the last known version when deleted
+	Previous *task.Task // The task before an update
+	Fields   []string   // Fields an update changed, e.g. "status", "start"
+}
+
+// Diff compares two snapshots of the tasks and returns the changes ordered
+// by task ID.
+func Diff(before, after []*task.Task) []Change {
+	old := make(map[int64]*task.Task, len(before))
+	for _, t := range before {
+		old[t.ID] = t
+	}
+
+	var changes []Change
+	for _, t := range after {
+		prev, ok := old[t.ID]
+		delete(old, t.ID)
+		if !ok {
+			changes = append(changes, Change{Kind: Created, Task: t})
+			continue
+		}
+		if fields := changedFields(prev, t); len(fields) > 0 {
+			changes = append(changes, Change{Kind: Updated, Task: t, Previous: prev, Fields: fields})
+		}
+	}
+	for _, t := range old {
+		changes = append(changes, Change{Kind: Deleted, Task: t})
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Task.ID < changes[j].Task.ID })
+	return changes
+}
+
+// changedFields names the fields that differ between two versions of a task.
+func changedFields(a, b *task.Task) []string {
+	var fields []string
+	add := func(name string, changed bool) {
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	add("description", a.Description != b.Description)
+	add("category", a.Category != b.Category)
+	add("date", !a.ScheduledDate.Equal(b.ScheduledDate))
+	add("start", a.ScheduledStart != b.ScheduledStart)
+	add("end", a.ScheduledEnd != b.ScheduledEnd)
+	add("status", a.Status != b.Status)
+	add("outcome", outcomeOf(a) != outcomeOf(b))
+	add("energy", a.Energy != b.Energy)
+	add("source", a.ExternalRef != b.ExternalRef)
+	return fields
+}
+
+func outcomeOf(t *task.Task) task.Outcome {
+	if t.Outcome == nil {
+		return ""
+	}
+	return *t.Outcome
+}
+
+// Poller remembers the tasks seen at the last poll.
+type Poller struct {
+	repo    task.Repository
+	version string
+	tasks   []*task.Task
+}
+
+// NewPoller takes a first snapshot of repo's tasks. Changes are reported
+// from then on.
+func NewPoller(ctx context.Context, repo task.Repository) (*Poller, error) {
+	p := &Poller{repo: repo}
+	if _, err := p.Poll(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Poll returns the changes since the last poll. When the repository can
+// report a data version, the tasks are only read again once it changes.
+func (p *Poller) Poll(ctx context.Context) ([]Change, error) {
+	version := ""
+	if v, ok := p.repo.(task.DataVersioner); ok {
+		var err error
+		if version, err = v.DataVersion(ctx); err != nil {
+			return nil, err
+		}
+		if p.tasks != nil && version == p.version {
+			return nil, nil
+		}
+	}
+
+	tasks, err := task.ListTasks(ctx, p.repo, task.TaskFilter{}, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing tasks: %w", err)
+	}
+	if tasks == nil {
+		tasks = []*task.Task{}
+	}
+	changes := Diff(p.tasks, tasks)
+	p.version, p.tasks = version, tasks
+	return changes, nil
+}
+
+// Run polls every interval and passes each batch of changes to emit until
+// ctx is done or emit fails. It returns nil when ctx is done.
+func (p *Poller) Run(ctx context.Context, interval time.Duration, emit func([]Change) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		changes, err := p.Poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if len(changes) > 0 {
+			if err := emit(changes); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package watch
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/javiermolinar/sancho/internal/memrepo"
+	"github.com/javiermolinar/sancho/internal/task"
+)
+
+func TestDiff(t *testing.T) {
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	report := &task.Task{ID: 1, Description: "Write report", Category: task.CategoryDeep, ScheduledDate: day, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled}
+	standup := &task.Task{ID: 2, Description: "Standup", Category: task.CategoryShallow, ScheduledDate: day, ScheduledStart: "11:00", ScheduledEnd: "11:30", Status: task.StatusScheduled}
+	review := &task.Task{ID: 3, Description: "Review", Category: task.CategoryShallow, ScheduledDate: day, ScheduledStart: "14:00", ScheduledEnd: "15:00", Status: task.StatusScheduled}
+
+	moved := *report
+	moved.ScheduledStart, moved.ScheduledEnd = "10:00", "12:00"
+	onTime := task.OutcomeOnTime
+	finished := *standup
+	finished.Outcome = &onTime
+
+	changes := Diff([]*task.Task{report, standup, review}, []*task.Task{&moved, &finished, review, {ID: 4, Description: "New"}})
+	var got []string
+	for _, c := range changes {
+		got = append(got, string(c.Kind)+" "+c.Task.Description)
+	}
+	want := []string{"updated Write report", "updated Standup", "created New"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff = %q, want %q", got, want)
+	}
+	if want := []string{"start", "end"}; !reflect.DeepEqual(changes[0].Fields, want) {
+		t.Errorf("moved fields = %q, want %q", changes[0].Fields, want)
+	}
+	if changes[0].Previous != report {
+		t.Errorf("moved previous = %v, want the old task", changes[0].Previous)
+	}
+	if want := []string{"outcome"}; !reflect.DeepEqual(changes[1].Fields, want) {
+		t.Errorf("outcome fields = %q, want %q", changes[1].Fields, want)
+	}
+
+	changes = Diff([]*task.Task{report, standup}, []*task.Task{standup})
+	if len(changes) != 1 || changes[0].Kind != Deleted || changes[0].Task != report {
+		t.Errorf("Diff after removal = %+v, want report deleted", changes)
+	}
+}
+
+func TestPoller(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	repo := memrepo.New()
+	existing := &task.Task{Description: "Write report", Category: task.CategoryDeep, ScheduledDate: day, ScheduledStart: "09:00", ScheduledEnd: "11:00", Status: task.StatusScheduled}
+	if err := repo.CreateTask(ctx, existing); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	p, err := NewPoller(ctx, repo)
+	if err != nil {
+		t.Fatalf("NewPoller: %v", err)
+	}
+	if changes, err := p.Poll(ctx); err != nil || len(changes) != 0 {
+		t.Fatalf("Poll without edits = %v, %v; want no changes", changes, err)
+	}
+
+	if err := repo.CreateTask(ctx, &task.Task{Description: "Standup", Category: task.CategoryShallow, ScheduledDate: day, ScheduledStart: "11:00", ScheduledEnd: "11:30", Status: task.StatusScheduled}); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if err := repo.CancelTask(ctx, existing.ID, 0); err != nil {
+		t.Fatalf("CancelTask: %v", err)
+	}
+	changes, err := p.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Kind != Updated || changes[1].Kind != Created {
+		t.Fatalf("Poll = %+v, want the cancel then the new task", changes)
+	}
+	if want := []string{"status"}; !reflect.DeepEqual(changes[0].Fields, want) {
+		t.Errorf("cancel fields = %q, want %q", changes[0].Fields, want)
+	}
+}